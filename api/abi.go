@@ -0,0 +1,150 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"github.com/insolar/rpc/v2"
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/api/requester"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// ABIService is a service that provides ABI of contracts generated by insgocc.
+type ABIService struct {
+	runner *Runner
+}
+
+// NewABIService creates new ABI service instance.
+func NewABIService(runner *Runner) *ABIService {
+	return &ABIService{runner: runner}
+}
+
+// Get returns ABI of contract by its prototype reference.
+//
+//   Request structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "method": "abi.get",
+//     "id": str|int|null
+//     "params": {
+//       "prototype": str // reference to prototype
+//     }
+//   }
+//
+//   Response structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "result": {
+//       "prototype": str,
+//       "abi": {
+//         "contract": str, // name of contract type
+//         "version": int, // version of contract set by ins:version
+//         "constructors": [{
+//           "name": str,
+//           "arguments": [{"name": str, "type": str}],
+//           "results": [str]
+//         }],
+//         "methods": [{
+//           "name": str,
+//           "arguments": [{"name": str, "type": str}],
+//           "results": [str],
+//           "immutable": bool,
+//           "api": bool, // method can be called through API
+//           "saga": {"rollback": str} // absent if method is not a saga
+//         }],
+//         "types": [{"name": str, "type": str, "fields": [{"name": str, "type": str}]}],
+//         "migrations": [{"version": int, "state": str}]
+//       }
+//     },
+//     "id": str|int|null
+//   }
+func (s *ABIService) Get(r *http.Request, args *requester.ContractABIParams, requestBody *rpc.RequestBody, reply *requester.ContractABIResponse) error {
+	_, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ ABIService.Get ] Incoming request: %s", r.RequestURI)
+
+	if len(args.Prototype) == 0 {
+		return errors.New("params.prototype is missing")
+	}
+
+	prototype, err := insolar.NewReferenceFromBase58(args.Prototype)
+	if err != nil {
+		return errors.Wrap(err, "can't parse prototype reference")
+	}
+
+	abi, ok := s.runner.ABIs[*prototype]
+	if !ok {
+		return errors.Errorf("there is no ABI for prototype %s", prototype)
+	}
+
+	reply.Prototype = prototype.String()
+	reply.ABI = json.RawMessage(abi)
+	return nil
+}
+
+// List returns prototypes of contracts with known ABI.
+//
+//   Request structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "method": "abi.list",
+//     "id": str|int|null
+//     "params": { }
+//   }
+//
+//   Response structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "result": {
+//       "contracts": [{
+//         "prototype": str,
+//         "contract": str
+//       }]
+//     },
+//     "id": str|int|null
+//   }
+func (s *ABIService) List(r *http.Request, args *struct{}, requestBody *rpc.RequestBody, reply *requester.ContractABIListResponse) error {
+	_, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ ABIService.List ] Incoming request: %s", r.RequestURI)
+
+	reply.Contracts = make([]requester.ContractABIListEntry, 0, len(s.runner.ABIs))
+	for prototype, abi := range s.runner.ABIs {
+		var header struct {
+			Contract string `json:"contract"`
+		}
+		if err := json.Unmarshal([]byte(abi), &header); err != nil {
+			return errors.Wrapf(err, "broken ABI of prototype %s", prototype.String())
+		}
+		reply.Contracts = append(reply.Contracts, requester.ContractABIListEntry{
+			Prototype: prototype.String(),
+			Contract:  header.Contract,
+		})
+	}
+	sort.Slice(reply.Contracts, func(i, j int) bool {
+		return reply.Contracts[i].Contract < reply.Contracts[j].Contract
+	})
+	return nil
+}
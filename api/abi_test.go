@@ -0,0 +1,83 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package api
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/api/requester"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/gen"
+	"github.com/insolar/insolar/insolar/genesisrefs"
+	"github.com/insolar/insolar/logicrunner/builtin"
+)
+
+func TestABIService(t *testing.T) {
+	service := NewABIService(&Runner{ABIs: builtin.InitializePrototypeABIs()})
+	r := httptest.NewRequest("POST", "/api/rpc", nil)
+	memberPrototype := genesisrefs.GenerateProtoReferenceFromContractID(genesisrefs.PrototypeType, insolar.GenesisNameMember, 0)
+
+	t.Run("get", func(t *testing.T) {
+		reply := requester.ContractABIResponse{}
+		err := service.Get(r, &requester.ContractABIParams{Prototype: memberPrototype.String()}, nil, &reply)
+		require.NoError(t, err)
+		require.Equal(t, memberPrototype.String(), reply.Prototype)
+
+		var abi struct {
+			Contract string `json:"contract"`
+			Methods  []struct {
+				Name string `json:"name"`
+				API  bool   `json:"api"`
+			} `json:"methods"`
+		}
+		require.NoError(t, json.Unmarshal(reply.ABI, &abi))
+		require.Equal(t, "Member", abi.Contract)
+		api := map[string]bool{}
+		for _, m := range abi.Methods {
+			api[m.Name] = m.API
+		}
+		require.Contains(t, api, "Call")
+		require.True(t, api["Call"])
+	})
+
+	t.Run("unknown prototype", func(t *testing.T) {
+		reply := requester.ContractABIResponse{}
+		err := service.Get(r, &requester.ContractABIParams{Prototype: gen.Reference().String()}, nil, &reply)
+		require.Error(t, err)
+	})
+
+	t.Run("bad reference", func(t *testing.T) {
+		reply := requester.ContractABIResponse{}
+		require.Error(t, service.Get(r, &requester.ContractABIParams{}, nil, &reply))
+		require.Error(t, service.Get(r, &requester.ContractABIParams{Prototype: "bad"}, nil, &reply))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		reply := requester.ContractABIListResponse{}
+		err := service.List(r, &struct{}{}, nil, &reply)
+		require.NoError(t, err)
+		require.Len(t, reply.Contracts, len(service.runner.ABIs))
+		require.Contains(t, reply.Contracts, requester.ContractABIListEntry{
+			Prototype: memberPrototype.String(),
+			Contract:  "Member",
+		})
+	})
+}
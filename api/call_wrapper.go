@@ -45,6 +45,10 @@ func wrapCall(runner *Runner, allowedMethods map[string]bool, req *http.Request,
 		return errors.New("method not allowed")
 	}
 
+	if runner.decommissioning() {
+		return errors.New("node is decommissioning, send request to another node")
+	}
+
 	if args.Test != "" {
 		logger.Infof("ContractRequest related to %s", args.Test)
 	}
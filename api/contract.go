@@ -71,7 +71,7 @@ func (ar *Runner) checkSeed(paramsSeed string) (insolar.PulseNumber, error) {
 		return pulse, nil
 	}
 
-	return 0, errors.New("[ checkSeed ] Incorrect seed")
+	return 0, errors.New(requester.IncorrectSeedMessage)
 }
 
 func (ar *Runner) makeCall(ctx context.Context, method string, params requester.Params, rawBody []byte, signature string, pulseTimeStamp int64, seedPulse insolar.PulseNumber) (interface{}, *insolar.Reference, error) {
@@ -0,0 +1,196 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/insolar/rpc/v2"
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/api/requester"
+	"github.com/insolar/insolar/insolar"
+	insolarApi "github.com/insolar/insolar/insolar/api"
+	"github.com/insolar/insolar/insolar/payload"
+	"github.com/insolar/insolar/insolar/record"
+	"github.com/insolar/insolar/insolar/reply"
+	"github.com/insolar/insolar/insolar/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/instrumentation/instracer"
+	"github.com/insolar/insolar/logicrunner/builtin/foundation"
+)
+
+// SetCode deploys compiled code of a new version of contract and registers prototype with this code.
+// Objects are moved to the prototype by contract.migrate. Method is served by admin API only.
+//
+//   Request structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "method": "contract.setCode",
+//     "id": str|int|null
+//     "params": {
+//       "code": str // base64 encoded go plugin built by insgocc
+//     }
+//   }
+//
+//   Response structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "result": {
+//       "prototypeReference": str,
+//       "codeReference": str,
+//       "traceID": str
+//     },
+//     "id": str|int|null
+//   }
+func (cs *AdminContractService) SetCode(r *http.Request, args *requester.SetCodeParams, requestBody *rpc.RequestBody, reply *requester.SetCodeResponse) error {
+	traceID := utils.RandTraceID()
+	ctx, logger := inslogger.WithTraceField(context.Background(), traceID)
+	reply.TraceID = traceID
+
+	ctx, span := instracer.StartSpan(ctx, "SetCode")
+	defer span.End()
+
+	logger.Infof("[ AdminContractService.SetCode ] Incoming request: %s", r.RequestURI)
+
+	if len(args.Code) == 0 {
+		return errors.New("code is missing")
+	}
+
+	pulse, err := cs.runner.PulseAccessor.Latest(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get current pulse")
+	}
+
+	info, err := cs.runner.ArtifactManager.RegisterIncomingRequest(ctx, &record.IncomingRequest{
+		CallType:     record.CTDeployPrototype,
+		APIRequestID: traceID,
+		Reason:       insolarApi.MakeReason(pulse.PulseNumber, args.Code),
+		APINode:      cs.runner.JetCoordinator.Me(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to register request")
+	}
+	protoRef := insolar.NewReference(info.RequestID)
+
+	codeID, err := cs.runner.ArtifactManager.DeployCode(
+		ctx, *insolar.NewEmptyReference(), *protoRef, args.Code, insolar.MachineTypeGoPlugin,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to deploy code")
+	}
+	codeRef := insolar.NewReference(*codeID)
+
+	err = cs.runner.ArtifactManager.ActivatePrototype(ctx, *protoRef, insolar.GenesisRecord.Ref(), *codeRef, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to activate prototype")
+	}
+
+	logger.Infof("[ AdminContractService.SetCode ] Code %s is deployed with prototype %s", codeRef, protoRef)
+
+	reply.PrototypeReference = protoRef.String()
+	reply.CodeReference = codeRef.String()
+	return nil
+}
+
+// Migrate moves object to prototype registered by contract.setCode. State of the object is converted by
+// migration of new prototype from version of current prototype of the object. Method is served by admin API only.
+//
+//   Request structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "method": "contract.migrate",
+//     "id": str|int|null
+//     "params": {
+//       "objectReference": str,
+//       "prototypeReference": str // prototype returned by contract.setCode
+//     }
+//   }
+//
+//   Response structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "result": {
+//       "objectReference": str,
+//       "traceID": str
+//     },
+//     "id": str|int|null
+//   }
+func (cs *AdminContractService) Migrate(r *http.Request, args *requester.MigrateParams, requestBody *rpc.RequestBody, re *requester.MigrateResponse) error {
+	traceID := utils.RandTraceID()
+	ctx, logger := inslogger.WithTraceField(context.Background(), traceID)
+	re.TraceID = traceID
+
+	ctx, span := instracer.StartSpan(ctx, "Migrate")
+	defer span.End()
+
+	logger.Infof("[ AdminContractService.Migrate ] Incoming request: %s", r.RequestURI)
+
+	objectRef, err := insolar.NewReferenceFromBase58(args.ObjectReference)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse objectReference")
+	}
+	protoRef, err := insolar.NewReferenceFromBase58(args.PrototypeReference)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse prototypeReference")
+	}
+
+	pulse, err := cs.runner.PulseAccessor.Latest(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get current pulse")
+	}
+
+	// arguments of migration are set by logic executor from current prototype of the object
+	methodArgs, err := insolar.Serialize([]interface{}{})
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize arguments")
+	}
+
+	callReply, _, err := cs.runner.ContractRequester.Call(ctx, &payload.CallMethod{
+		Request: &record.IncomingRequest{
+			Object:       objectRef,
+			Prototype:    protoRef,
+			Method:       insolar.MigrateMethod,
+			Arguments:    methodArgs,
+			CallType:     record.CTMethod,
+			APIRequestID: traceID,
+			Reason:       insolarApi.MakeReason(pulse.PulseNumber, methodArgs),
+			APINode:      cs.runner.JetCoordinator.Me(),
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to call migration")
+	}
+
+	typedReply, ok := callReply.(*reply.CallMethod)
+	if !ok {
+		return errors.Errorf("unexpected reply %T", callReply)
+	}
+
+	var contractErr *foundation.Error
+	err = foundation.UnmarshalMethodResultSimplified(typedReply.Result, &contractErr)
+	if err != nil {
+		return errors.Wrap(err, "failed to unmarshal migration result")
+	}
+	if contractErr != nil {
+		return errors.Wrap(contractErr, "migration failed")
+	}
+
+	re.ObjectReference = objectRef.String()
+	return nil
+}
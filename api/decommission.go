@@ -0,0 +1,128 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/insolar/rpc/v2"
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/api/requester"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// DecommissionArgs is arguments that decommission methods accept.
+type DecommissionArgs struct{}
+
+// Decommission starts to take node out of network. Node stops to receive new tasks, hands over its work
+// and only then leaves network. Method returns immediately, progress is reported by node.getDecommissionStatus.
+//
+//   Request structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "method": "node.decommission",
+//     "id": str|int|null
+//   }
+//
+//   Response structure is the same as of node.getDecommissionStatus.
+func (s *NodeService) Decommission(r *http.Request, args *DecommissionArgs, requestBody *rpc.RequestBody, reply *requester.DecommissionStatusResponse) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ NodeService.Decommission ] Incoming request: %s", r.RequestURI)
+	if err := s.checkDecommissionAllowed(); err != nil {
+		return err
+	}
+
+	if err := s.runner.TerminationHandler.Decommission(ctx); err != nil {
+		return errors.Wrap(err, "failed to start decommission")
+	}
+
+	fillDecommissionStatus(s.runner.TerminationHandler.DecommissionStatus(), reply)
+	return nil
+}
+
+// GetDecommissionStatus returns progress of node decommission.
+//
+//   Request structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "method": "node.getDecommissionStatus",
+//     "id": str|int|null
+//   }
+//
+//   Response structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "result": {
+//       "state": str, // "none", "draining", "handoff", "leaving" or "left"
+//       "startedAt": str, // absent if decommission is not started
+//       "components": [{ // reports of components that hand over their work
+//         "component": str,
+//         "done": bool,
+//         "pending": int, // optional, number of work items left
+//         "details": str // optional, what component is waiting for
+//       }]
+//     },
+//     "id": str|int|null
+//   }
+func (s *NodeService) GetDecommissionStatus(r *http.Request, args *DecommissionArgs, requestBody *rpc.RequestBody, reply *requester.DecommissionStatusResponse) error {
+	_, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ NodeService.GetDecommissionStatus ] Incoming request: %s", r.RequestURI)
+	if err := s.checkDecommissionAllowed(); err != nil {
+		return err
+	}
+
+	fillDecommissionStatus(s.runner.TerminationHandler.DecommissionStatus(), reply)
+	return nil
+}
+
+func (s *NodeService) checkDecommissionAllowed() error {
+	if !s.runner.cfg.IsAdmin {
+		return errors.New("method not allowed")
+	}
+	if s.runner.TerminationHandler == nil {
+		return errors.New("termination handler is not available")
+	}
+	return nil
+}
+
+func fillDecommissionStatus(status insolar.DecommissionStatus, reply *requester.DecommissionStatusResponse) {
+	reply.State = string(status.State)
+	if !status.StartedAt.IsZero() {
+		startedAt := status.StartedAt
+		reply.StartedAt = &startedAt
+	}
+	reply.Components = make([]requester.DecommissionComponent, 0, len(status.Components))
+	for _, c := range status.Components {
+		reply.Components = append(reply.Components, requester.DecommissionComponent{
+			Component: c.Component,
+			Done:      c.Done,
+			Pending:   c.Pending,
+			Details:   c.Details,
+		})
+	}
+}
+
+// decommissioning checks if node is being taken out of network, such node doesn't accept new calls.
+func (ar *Runner) decommissioning() bool {
+	return ar.TerminationHandler != nil && ar.TerminationHandler.DecommissionStatus().State != insolar.DecommissionNone
+}
@@ -0,0 +1,128 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gojuno/minimock"
+	"github.com/insolar/rpc/v2"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/api/requester"
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestNodeService_Decommission(t *testing.T) {
+	startedAt := time.Now()
+	status := insolar.DecommissionStatus{
+		State:     insolar.DecommissionHandoff,
+		StartedAt: startedAt,
+		Components: []insolar.DrainProgress{
+			{Component: "LogicRunner", Pending: 2, Details: "2 messages are being sent to next executors"},
+		},
+	}
+	r := httptest.NewRequest("POST", "/admin-api/rpc", nil)
+
+	t.Run("decommission", func(t *testing.T) {
+		mc := minimock.NewController(t)
+		defer mc.Finish()
+
+		th := testutils.NewTerminationHandlerMock(mc).
+			DecommissionMock.Return(nil).
+			DecommissionStatusMock.Return(insolar.DecommissionStatus{State: insolar.DecommissionDraining, StartedAt: startedAt})
+		cfg := configuration.NewAPIRunner(true)
+		service := NewNodeService(&Runner{cfg: &cfg, TerminationHandler: th})
+
+		reply := requester.DecommissionStatusResponse{}
+		err := service.Decommission(r, &DecommissionArgs{}, nil, &reply)
+		require.NoError(t, err)
+		require.Equal(t, "draining", reply.State)
+		require.NotNil(t, reply.StartedAt)
+		require.Empty(t, reply.Components)
+	})
+
+	t.Run("already leaving", func(t *testing.T) {
+		mc := minimock.NewController(t)
+		defer mc.Finish()
+
+		th := testutils.NewTerminationHandlerMock(mc).
+			DecommissionMock.Return(errors.New("node is already leaving network"))
+		cfg := configuration.NewAPIRunner(true)
+		service := NewNodeService(&Runner{cfg: &cfg, TerminationHandler: th})
+
+		err := service.Decommission(r, &DecommissionArgs{}, nil, &requester.DecommissionStatusResponse{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "already leaving")
+	})
+
+	t.Run("status", func(t *testing.T) {
+		mc := minimock.NewController(t)
+		defer mc.Finish()
+
+		th := testutils.NewTerminationHandlerMock(mc).DecommissionStatusMock.Return(status)
+		cfg := configuration.NewAPIRunner(true)
+		service := NewNodeService(&Runner{cfg: &cfg, TerminationHandler: th})
+
+		reply := requester.DecommissionStatusResponse{}
+		err := service.GetDecommissionStatus(r, &DecommissionArgs{}, nil, &reply)
+		require.NoError(t, err)
+		require.Equal(t, "handoff", reply.State)
+		require.Equal(t, []requester.DecommissionComponent{
+			{Component: "LogicRunner", Pending: 2, Details: "2 messages are being sent to next executors"},
+		}, reply.Components)
+	})
+
+	t.Run("public api", func(t *testing.T) {
+		mc := minimock.NewController(t)
+		defer mc.Finish()
+
+		cfg := configuration.NewAPIRunner(false)
+		service := NewNodeService(&Runner{cfg: &cfg, TerminationHandler: testutils.NewTerminationHandlerMock(mc)})
+
+		err := service.Decommission(r, &DecommissionArgs{}, nil, &requester.DecommissionStatusResponse{})
+		require.Error(t, err)
+		err = service.GetDecommissionStatus(r, &DecommissionArgs{}, nil, &requester.DecommissionStatusResponse{})
+		require.Error(t, err)
+	})
+}
+
+func TestWrapCall_Decommissioning(t *testing.T) {
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	th := testutils.NewTerminationHandlerMock(mc).
+		DecommissionStatusMock.Return(insolar.DecommissionStatus{State: insolar.DecommissionDraining})
+	runner := &Runner{TerminationHandler: th}
+	r := httptest.NewRequest("POST", "/api/rpc", nil)
+
+	err := wrapCall(
+		runner,
+		map[string]bool{"member.create": true},
+		r,
+		&requester.Params{CallSite: "member.create"},
+		&rpc.RequestBody{},
+		&requester.ContractResult{},
+	)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "node is decommissioning")
+}
@@ -0,0 +1,129 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/application/extractor"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/payload"
+	"github.com/insolar/insolar/insolar/reply"
+)
+
+// Event types. They are sent as event field of server-sent event.
+const (
+	TypePulse  = "pulse"
+	TypeResult = "result"
+	TypeObject = "object"
+)
+
+// Event is a server-sent event. Data is sent as JSON.
+type Event struct {
+	Type string
+	Data interface{}
+}
+
+// PulseEvent is sent when node gets new pulse.
+type PulseEvent struct {
+	PulseNumber uint32 `json:"pulseNumber"`
+	Timestamp   int64  `json:"timestamp"`
+}
+
+// ResultEvent is sent when request made through this node gets its result.
+type ResultEvent struct {
+	RequestReference string      `json:"requestReference"`
+	Result           interface{} `json:"result,omitempty"`
+	Error            string      `json:"error,omitempty"`
+}
+
+// ObjectEvent is sent when object gets new state.
+type ObjectEvent struct {
+	Reference string `json:"reference"`
+	State     string `json:"state"`
+	Pulse     uint32 `json:"pulse"`
+}
+
+func newPulseEvent(pulse insolar.Pulse) Event {
+	return Event{
+		Type: TypePulse,
+		Data: PulseEvent{PulseNumber: uint32(pulse.PulseNumber), Timestamp: pulse.PulseTimestamp},
+	}
+}
+
+func newResultEvent(res *payload.ReturnResults) Event {
+	data := ResultEvent{RequestReference: res.RequestRef.String()}
+	if res.Error != "" {
+		data.Error = res.Error
+		return Event{Type: TypeResult, Data: data}
+	}
+
+	result, err := decodeResult(res.Reply)
+	if err != nil {
+		data.Error = err.Error()
+	} else {
+		data.Result = result
+	}
+	return Event{Type: TypeResult, Data: data}
+}
+
+// decodeResult extracts result of contract method from reply. Error returned by the method is returned as error.
+func decodeResult(buf []byte) (interface{}, error) {
+	rep, err := reply.Deserialize(bytes.NewReader(buf))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize reply")
+	}
+	callMethod, ok := rep.(*reply.CallMethod)
+	if !ok {
+		return nil, errors.Errorf("unexpected reply %T", rep)
+	}
+
+	result, contractErr, err := extractor.CallResponse(callMethod.Result)
+	if err != nil {
+		return nil, err
+	}
+	if contractErr != nil {
+		return nil, errors.New(contractErr.S)
+	}
+	return result, nil
+}
+
+func newObjectEvent(object insolar.Reference, state insolar.ID, pulse insolar.PulseNumber) Event {
+	return Event{
+		Type: TypeObject,
+		Data: ObjectEvent{
+			Reference: object.String(),
+			State:     insolar.NewRecordReference(state).String(),
+			Pulse:     uint32(pulse),
+		},
+	}
+}
+
+// write writes event in server-sent events format.
+func (e Event) write(w io.Writer) error {
+	data, err := json.Marshal(e.Data)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal event")
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data)
+	return err
+}
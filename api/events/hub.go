@@ -0,0 +1,299 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package events
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/payload"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/logicrunner/artifacts"
+)
+
+const keepAliveInterval = 30 * time.Second
+
+// subscriber is a connected client.
+type subscriber struct {
+	events   chan Event
+	pulses   bool
+	requests map[insolar.Reference]struct{}
+	objects  map[insolar.Reference]struct{}
+	// done is closed when subscriber is dropped by hub.
+	done chan struct{}
+}
+
+// Hub delivers events to clients connected to server-sent events endpoint of api.
+//
+// Client subscribes with query params of GET request: "pulse=true" for pulse events, "request=<reference>" for
+// result of request and "object=<reference>" for new states of object. Request and object params can be repeated.
+// Results are known only for requests made through this node, subscription to request ends with its result.
+// Object states are checked on every pulse, so changes are reported starting from the second pulse
+// after subscription.
+//
+// Hub is fed by pulse manager as a dispatcher and by contract requester result hook.
+type Hub struct {
+	cfg     configuration.APIEvents
+	objects artifacts.Client
+
+	lock        sync.Mutex
+	subscribers map[*subscriber]struct{}
+	stopped     bool
+
+	// states are known states of subscribed objects. It's used only by checkObjects.
+	states   map[insolar.Reference]insolar.ID
+	checking int32
+}
+
+// NewHub creates new events hub. Objects are used to get states of subscribed objects.
+func NewHub(cfg configuration.APIEvents, objects artifacts.Client) *Hub {
+	return &Hub{
+		cfg:         cfg,
+		objects:     objects,
+		subscribers: map[*subscriber]struct{}{},
+		states:      map[insolar.Reference]insolar.ID{},
+	}
+}
+
+// BeginPulse sends pulse event and starts check of subscribed objects.
+func (h *Hub) BeginPulse(ctx context.Context, pulse insolar.Pulse) {
+	h.publish(newPulseEvent(pulse), func(s *subscriber) bool {
+		return s.pulses
+	})
+
+	if atomic.CompareAndSwapInt32(&h.checking, 0, 1) {
+		go func() {
+			defer atomic.StoreInt32(&h.checking, 0)
+			h.checkObjects(ctx, pulse.PulseNumber)
+		}()
+	}
+}
+
+// ClosePulse does nothing, it's required by dispatcher interface.
+func (h *Hub) ClosePulse(ctx context.Context, pulse insolar.Pulse) {}
+
+// Process does nothing, hub doesn't handle messages. It's required by dispatcher interface.
+func (h *Hub) Process(msg *message.Message) error {
+	return nil
+}
+
+// OnResult sends result event to subscribers of the request and ends their subscriptions.
+func (h *Hub) OnResult(ctx context.Context, res *payload.ReturnResults) {
+	var event *Event
+	h.publishLocked(func(s *subscriber) *Event {
+		if _, ok := s.requests[res.RequestRef]; !ok {
+			return nil
+		}
+		delete(s.requests, res.RequestRef)
+		if event == nil {
+			e := newResultEvent(res)
+			event = &e
+		}
+		return event
+	})
+}
+
+func (h *Hub) checkObjects(ctx context.Context, pulse insolar.PulseNumber) {
+	logger := inslogger.FromContext(ctx)
+
+	h.lock.Lock()
+	subscribed := map[insolar.Reference]struct{}{}
+	for s := range h.subscribers {
+		for object := range s.objects {
+			subscribed[object] = struct{}{}
+		}
+	}
+	h.lock.Unlock()
+
+	for object := range h.states {
+		if _, ok := subscribed[object]; !ok {
+			delete(h.states, object)
+		}
+	}
+
+	for object := range subscribed {
+		desc, err := h.objects.GetObject(ctx, object)
+		if err != nil {
+			logger.Debugf("failed to get state of subscribed object %s: %s", object.String(), err)
+			continue
+		}
+		state := *desc.StateID()
+		known, ok := h.states[object]
+		h.states[object] = state
+		if !ok || known == state {
+			continue
+		}
+
+		object := object
+		h.publish(newObjectEvent(object, state, pulse), func(s *subscriber) bool {
+			_, ok := s.objects[object]
+			return ok
+		})
+	}
+}
+
+func (h *Hub) publish(event Event, match func(s *subscriber) bool) {
+	h.publishLocked(func(s *subscriber) *Event {
+		if !match(s) {
+			return nil
+		}
+		return &event
+	})
+}
+
+// publishLocked sends event returned by eventFor to every subscriber under hub lock.
+// Subscriber which buffer is full is dropped.
+func (h *Hub) publishLocked(eventFor func(s *subscriber) *Event) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for s := range h.subscribers {
+		event := eventFor(s)
+		if event == nil {
+			continue
+		}
+		select {
+		case s.events <- *event:
+		default:
+			h.drop(s)
+		}
+	}
+}
+
+func (h *Hub) drop(s *subscriber) {
+	delete(h.subscribers, s)
+	close(s.done)
+}
+
+// subscribe registers subscriber with params of request.
+func (h *Hub) subscribe(r *http.Request) (*subscriber, int, error) {
+	s := &subscriber{
+		events:   make(chan Event, h.cfg.BufferSize),
+		requests: map[insolar.Reference]struct{}{},
+		objects:  map[insolar.Reference]struct{}{},
+		done:     make(chan struct{}),
+	}
+
+	query := r.URL.Query()
+	s.pulses = query.Get("pulse") == "true"
+	if len(query["request"])+len(query["object"]) > h.cfg.MaxSubscriptions {
+		return nil, http.StatusBadRequest, errors.Errorf("too many subscriptions, max %d", h.cfg.MaxSubscriptions)
+	}
+	for _, param := range query["request"] {
+		ref, err := insolar.NewReferenceFromBase58(param)
+		if err != nil {
+			return nil, http.StatusBadRequest, errors.Wrap(err, "failed to parse request reference")
+		}
+		s.requests[*ref] = struct{}{}
+	}
+	for _, param := range query["object"] {
+		ref, err := insolar.NewReferenceFromBase58(param)
+		if err != nil {
+			return nil, http.StatusBadRequest, errors.Wrap(err, "failed to parse object reference")
+		}
+		s.objects[*ref] = struct{}{}
+	}
+	if !s.pulses && len(s.requests) == 0 && len(s.objects) == 0 {
+		return nil, http.StatusBadRequest, errors.New("no subscriptions")
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.stopped {
+		return nil, http.StatusServiceUnavailable, errors.New("api is stopping")
+	}
+	if len(h.subscribers) >= h.cfg.MaxConnections {
+		return nil, http.StatusServiceUnavailable, errors.Errorf("too many connections, max %d", h.cfg.MaxConnections)
+	}
+	h.subscribers[s] = struct{}{}
+	return s, http.StatusOK, nil
+}
+
+func (h *Hub) unsubscribe(s *subscriber) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if _, ok := h.subscribers[s]; ok {
+		h.drop(s)
+	}
+}
+
+// ServeHTTP streams events to subscribed client until it disconnects, falls behind or hub is stopped.
+func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := inslogger.FromContext(r.Context())
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	s, code, err := h.subscribe(r)
+	if err != nil {
+		http.Error(w, err.Error(), code)
+		return
+	}
+	defer h.unsubscribe(s)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case event := <-s.events:
+			err := event.write(w)
+			if err != nil {
+				logger.Debug("failed to write event: ", err)
+				return
+			}
+			flusher.Flush()
+		case <-keepAlive.C:
+			_, err := w.Write([]byte(":\n\n"))
+			if err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-s.done:
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// Stop disconnects all clients. Connections have to be closed before http server shutdown, it waits for them.
+func (h *Hub) Stop() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.stopped = true
+	for s := range h.subscribers {
+		h.drop(s)
+	}
+}
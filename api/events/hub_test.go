@@ -0,0 +1,220 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/gen"
+	"github.com/insolar/insolar/insolar/payload"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/logicrunner/artifacts"
+	"github.com/insolar/insolar/pulse"
+)
+
+// readEvent reads one server-sent event from stream skipping keep-alive comments.
+func readEvent(t *testing.T, r *bufio.Reader) (string, map[string]interface{}) {
+	var typ string
+	data := map[string]interface{}{}
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			typ = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &data))
+		case line == "" && typ != "":
+			return typ, data
+		}
+	}
+}
+
+func subscribe(t *testing.T, server *httptest.Server, query string) *bufio.Reader {
+	resp, err := http.Get(server.URL + "?" + query)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	t.Cleanup(func() { resp.Body.Close() })
+	return bufio.NewReader(resp.Body)
+}
+
+func TestHub_Endpoint(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+
+	hub := NewHub(configuration.NewAPIEvents("/"), artifacts.NewClientMock(t))
+	server := httptest.NewServer(hub)
+	defer server.Close()
+	defer hub.Stop()
+
+	t.Run("pulse", func(t *testing.T) {
+		stream := subscribe(t, server, "pulse=true")
+
+		hub.BeginPulse(ctx, insolar.Pulse{PulseNumber: pulse.MinTimePulse + 10, PulseTimestamp: 42})
+
+		typ, data := readEvent(t, stream)
+		require.Equal(t, TypePulse, typ)
+		require.Equal(t, float64(pulse.MinTimePulse+10), data["pulseNumber"])
+		require.Equal(t, float64(42), data["timestamp"])
+	})
+
+	t.Run("result", func(t *testing.T) {
+		reqRef := gen.Reference()
+		stream := subscribe(t, server, "request="+reqRef.String())
+
+		hub.OnResult(ctx, &payload.ReturnResults{RequestRef: gen.Reference(), Error: "other"})
+		hub.OnResult(ctx, &payload.ReturnResults{RequestRef: reqRef, Error: "failed"})
+
+		typ, data := readEvent(t, stream)
+		require.Equal(t, TypeResult, typ)
+		require.Equal(t, reqRef.String(), data["requestReference"])
+		require.Equal(t, "failed", data["error"])
+	})
+
+	t.Run("bad requests", func(t *testing.T) {
+		tooMany := make([]string, 0, hub.cfg.MaxSubscriptions+1)
+		for i := 0; i <= hub.cfg.MaxSubscriptions; i++ {
+			tooMany = append(tooMany, "object="+gen.Reference().String())
+		}
+
+		for _, query := range []string{"", "pulse=false", "request=bad", strings.Join(tooMany, "&")} {
+			resp, err := http.Get(server.URL + "?" + query)
+			require.NoError(t, err)
+			resp.Body.Close()
+			require.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+		}
+	})
+}
+
+func TestHub_MaxConnections(t *testing.T) {
+	cfg := configuration.NewAPIEvents("/")
+	cfg.MaxConnections = 1
+	hub := NewHub(cfg, artifacts.NewClientMock(t))
+	server := httptest.NewServer(hub)
+	defer server.Close()
+	defer hub.Stop()
+
+	subscribe(t, server, "pulse=true")
+
+	resp, err := http.Get(server.URL + "?pulse=true")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
+
+func TestHub_OnResult(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	reqRef := gen.Reference()
+
+	hub := NewHub(configuration.NewAPIEvents("/"), artifacts.NewClientMock(t))
+	s := &subscriber{
+		events:   make(chan Event, 10),
+		requests: map[insolar.Reference]struct{}{reqRef: {}},
+		done:     make(chan struct{}),
+	}
+	hub.subscribers[s] = struct{}{}
+
+	hub.OnResult(ctx, &payload.ReturnResults{RequestRef: reqRef})
+	hub.OnResult(ctx, &payload.ReturnResults{RequestRef: reqRef})
+
+	require.Len(t, s.events, 1)
+	require.Empty(t, s.requests)
+}
+
+func TestHub_Overflow(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+
+	cfg := configuration.NewAPIEvents("/")
+	cfg.BufferSize = 2
+	hub := NewHub(cfg, artifacts.NewClientMock(t))
+	s := &subscriber{
+		events: make(chan Event, cfg.BufferSize),
+		pulses: true,
+		done:   make(chan struct{}),
+	}
+	hub.subscribers[s] = struct{}{}
+
+	hub.publish(newPulseEvent(insolar.Pulse{}), func(s *subscriber) bool { return s.pulses })
+	hub.publish(newPulseEvent(insolar.Pulse{}), func(s *subscriber) bool { return s.pulses })
+	require.Len(t, hub.subscribers, 1)
+
+	hub.publish(newPulseEvent(insolar.Pulse{}), func(s *subscriber) bool { return s.pulses })
+	require.Empty(t, hub.subscribers)
+	select {
+	case <-s.done:
+	default:
+		t.Fatal("subscriber is not dropped")
+	}
+
+	// Dropped subscriber doesn't get events.
+	hub.BeginPulse(ctx, insolar.Pulse{})
+	require.Len(t, s.events, cfg.BufferSize)
+}
+
+func TestHub_CheckObjects(t *testing.T) {
+	ctx := context.Background()
+	object := gen.Reference()
+	states := []insolar.ID{gen.ID(), gen.ID(), gen.ID()}
+
+	current := states[0]
+	desc := artifacts.NewObjectDescriptorMock(t).StateIDMock.Set(func() *insolar.ID {
+		id := current
+		return &id
+	})
+	objects := artifacts.NewClientMock(t).GetObjectMock.Set(
+		func(_ context.Context, head insolar.Reference) (artifacts.ObjectDescriptor, error) {
+			require.Equal(t, object, head)
+			return desc, nil
+		})
+
+	hub := NewHub(configuration.NewAPIEvents("/"), objects)
+	s := &subscriber{
+		events:  make(chan Event, 10),
+		objects: map[insolar.Reference]struct{}{object: {}},
+		done:    make(chan struct{}),
+	}
+	hub.subscribers[s] = struct{}{}
+
+	// First check remembers state.
+	hub.checkObjects(ctx, pulse.MinTimePulse)
+	require.Empty(t, s.events)
+
+	hub.checkObjects(ctx, pulse.MinTimePulse+1)
+	require.Empty(t, s.events)
+
+	current = states[1]
+	hub.checkObjects(ctx, pulse.MinTimePulse+2)
+	require.Len(t, s.events, 1)
+	event := <-s.events
+	require.Equal(t, newObjectEvent(object, states[1], pulse.MinTimePulse+2), event)
+
+	// State of object is forgotten without subscribers.
+	delete(hub.subscribers, s)
+	hub.checkObjects(ctx, pulse.MinTimePulse+3)
+	require.Empty(t, hub.states)
+}
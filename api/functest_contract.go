@@ -209,7 +209,6 @@ func (s *FuncTestContractService) CallMethod(r *http.Request, args *CallMethodAr
 	return nil
 }
 
-// CallConstructor make an object from its prototype
 func (s *FuncTestContractService) call(ctx context.Context, msg insolar.Payload, re *CallMethodReply) error {
 	inslog := inslogger.FromContext(ctx)
 
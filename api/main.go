@@ -31,15 +31,19 @@ import (
 
 	"github.com/insolar/insolar/insolar/pulse"
 	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/blame"
 
 	"github.com/insolar/insolar/insolar/jet"
 
+	"github.com/insolar/insolar/api/events"
 	"github.com/insolar/insolar/api/seedmanager"
 
+	"github.com/insolar/insolar/certificate"
 	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/insolar"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/logicrunner/artifacts"
+	"github.com/insolar/insolar/logicrunner/builtin"
 )
 
 // Runner implements Component for API
@@ -53,6 +57,13 @@ type Runner struct {
 	ArtifactManager   artifacts.Client
 	JetCoordinator    jet.Coordinator
 	NetworkStatus     insolar.NetworkStatus
+	Misbehavior       blame.Accessor
+	// TerminationHandler takes node out of network on decommission
+	TerminationHandler insolar.TerminationHandler
+	// CertificateUpdater announces rotations and revocations of node keys
+	CertificateUpdater certificate.Updater
+	// ABIs are JSON descriptions of builtin contracts by prototype reference
+	ABIs map[insolar.Reference]string
 
 	server        *http.Server
 	rpcServer     *rpc.Server
@@ -62,6 +73,7 @@ type Runner struct {
 	timeout       time.Duration
 	SeedManager   *seedmanager.SeedManager
 	SeedGenerator seedmanager.SeedGenerator
+	Events        *events.Hub
 }
 
 func checkConfig(cfg *configuration.APIRunner) error {
@@ -89,6 +101,11 @@ func (ar *Runner) registerPublicServices(rpcServer *rpc.Server) error {
 		return errors.Wrap(err, "[ registerServices ] Can't RegisterService: contract")
 	}
 
+	err = rpcServer.RegisterService(NewABIService(ar), "abi")
+	if err != nil {
+		return errors.Wrap(err, "[ registerServices ] Can't RegisterService: abi")
+	}
+
 	return nil
 }
 
@@ -117,6 +134,11 @@ func (ar *Runner) registerAdminServices(rpcServer *rpc.Server) error {
 	if err != nil {
 		return errors.Wrap(err, "[ registerServices ] Can't RegisterService: funcTestContract")
 	}
+
+	err = rpcServer.RegisterService(NewABIService(ar), "abi")
+	if err != nil {
+		return errors.Wrap(err, "[ registerServices ] Can't RegisterService: abi")
+	}
 	return nil
 }
 
@@ -131,6 +153,9 @@ func NewRunner(cfg *configuration.APIRunner,
 	artifactManager artifacts.Client,
 	jetCoordinator jet.Coordinator,
 	networkStatus insolar.NetworkStatus,
+	misbehavior blame.Accessor,
+	terminationHandler insolar.TerminationHandler,
+	certificateUpdater certificate.Updater,
 ) (*Runner, error) {
 
 	if err := checkConfig(cfg); err != nil {
@@ -148,12 +173,17 @@ func NewRunner(cfg *configuration.APIRunner,
 		ArtifactManager:    artifactManager,
 		JetCoordinator:     jetCoordinator,
 		NetworkStatus:      networkStatus,
+		Misbehavior:        misbehavior,
+		TerminationHandler: terminationHandler,
+		CertificateUpdater: certificateUpdater,
+		ABIs:               builtin.InitializePrototypeABIs(),
 		server:             &http.Server{Addr: addrStr},
 		rpcServer:          rpcServer,
 		cfg:                cfg,
 		timeout:            30 * time.Second,
 		keyCache:           make(map[string]crypto.PublicKey),
 		cacheLock:          &sync.RWMutex{},
+		Events:             events.NewHub(cfg.Events, artifactManager),
 	}
 
 	rpcServer.RegisterCodec(jsonrpc.NewCodec(), "application/json")
@@ -187,6 +217,9 @@ func (ar *Runner) Start(ctx context.Context) error {
 
 	router.HandleFunc("/healthcheck", hc.CheckHandler)
 	router.Handle(ar.cfg.RPC, ar.rpcServer)
+	if ar.cfg.Events.Path != "" {
+		router.Handle(ar.cfg.Events.Path, ar.Events)
+	}
 
 	logger.Info("Starting ApiRunner ...")
 	logger.Info("Config: ", ar.cfg)
@@ -209,6 +242,8 @@ func (ar *Runner) Stop(ctx context.Context) error {
 	inslogger.FromContext(ctx).Infof("Shutting down server gracefully ...(waiting for %d seconds)", timeOut)
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, time.Duration(timeOut)*time.Second)
 	defer cancel()
+	// Event streams are never finished by clients, so they have to be closed before shutdown.
+	ar.Events.Stop()
 	err := ar.server.Shutdown(ctxWithTimeout)
 	if err != nil {
 		return errors.Wrap(err, "Can't gracefully stop API server")
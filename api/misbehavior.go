@@ -0,0 +1,109 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/insolar/rpc/v2"
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/api/requester"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/network/blame"
+)
+
+// GetMisbehaviorReports returns blame and fraud reports consensus of this node made about other nodes.
+//
+//   Request structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "method": "node.getMisbehaviorReports",
+//     "id": str|int|null
+//     "params": {
+//       "node": str, // optional, reference, short id or host of violator
+//       "category": str, // optional, "blame" or "fraud"
+//       "type": str, // optional, misbehavior type, e.g. "protocol_violation"
+//       "fromPulse": int, // optional, the earliest pulse of reports
+//       "limit": int // optional, max number of reports
+//     }
+//   }
+//
+//   Response structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "result": {
+//       "reports": [{ // the latest first
+//         "id": int,
+//         "pulse": int, // pulse of consensus round report was made in
+//         "time": str,
+//         "category": str,
+//         "type": str,
+//         "nodeId": int, // short id of violator, absent if only host is known
+//         "node": str, // reference of violator, absent if node has not introduced itself
+//         "host": str, // address of violator
+//         "description": str,
+//         "evidence": [str]
+//       }]
+//     },
+//     "id": str|int|null
+//   }
+func (s *NodeService) GetMisbehaviorReports(r *http.Request, args *requester.MisbehaviorReportsParams, requestBody *rpc.RequestBody, reply *requester.MisbehaviorReportsResponse) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ NodeService.GetMisbehaviorReports ] Incoming request: %s", r.RequestURI)
+	if !s.runner.cfg.IsAdmin {
+		return errors.New("method not allowed")
+	}
+	if s.runner.Misbehavior == nil {
+		return errors.New("misbehavior journal is not available")
+	}
+	if args.Limit < 0 {
+		return errors.New("limit must not be negative")
+	}
+
+	entries, err := s.runner.Misbehavior.Find(ctx, blame.Filter{
+		Node:      args.Node,
+		Category:  args.Category,
+		Type:      args.Type,
+		FromPulse: insolar.PulseNumber(args.FromPulse),
+		Limit:     args.Limit,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to find misbehavior reports")
+	}
+
+	reply.Reports = make([]requester.MisbehaviorReport, 0, len(entries))
+	for _, e := range entries {
+		reply.Reports = append(reply.Reports, requester.MisbehaviorReport{
+			ID:          e.ID,
+			Pulse:       uint32(e.Pulse),
+			Time:        e.Time,
+			Category:    e.Category,
+			Type:        e.Type,
+			NodeID:      uint32(e.NodeID),
+			Node:        e.Node,
+			Host:        e.Host,
+			Description: e.Description,
+			Evidence:    e.Evidence,
+		})
+	}
+	return nil
+}
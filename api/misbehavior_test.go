@@ -0,0 +1,78 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package api
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/api/requester"
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/network/blame"
+	"github.com/insolar/insolar/network/consensus/common/endpoints"
+	"github.com/insolar/insolar/network/consensus/gcpv2/api/misbehavior"
+)
+
+func TestNodeService_GetMisbehaviorReports(t *testing.T) {
+	ctx := context.Background()
+	journal := blame.NewJournal(configuration.Blame{})
+	for _, addr := range []string{"127.0.0.1:1", "127.0.0.1:2"} {
+		host := &endpoints.InboundConnection{Addr: endpoints.Name(addr)}
+		report := misbehavior.NewBlameFactory(nil).NewHostBlame(misbehavior.ProtocolViolation, "bad packet", host)
+		require.NoError(t, journal.Add(ctx, 65537, &report))
+	}
+
+	cfg := configuration.NewAPIRunner(true)
+	service := NewNodeService(&Runner{cfg: &cfg, Misbehavior: journal})
+	r := httptest.NewRequest("POST", "/admin-api/rpc", nil)
+
+	t.Run("all", func(t *testing.T) {
+		reply := requester.MisbehaviorReportsResponse{}
+		err := service.GetMisbehaviorReports(r, &requester.MisbehaviorReportsParams{}, nil, &reply)
+		require.NoError(t, err)
+		require.Len(t, reply.Reports, 2)
+		require.Equal(t, "127.0.0.1:2", reply.Reports[0].Host)
+		require.Equal(t, uint32(65537), reply.Reports[0].Pulse)
+		require.Equal(t, "blame", reply.Reports[0].Category)
+		require.Equal(t, "protocol_violation", reply.Reports[0].Type)
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+		reply := requester.MisbehaviorReportsResponse{}
+		err := service.GetMisbehaviorReports(r, &requester.MisbehaviorReportsParams{Node: "127.0.0.1:1"}, nil, &reply)
+		require.NoError(t, err)
+		require.Len(t, reply.Reports, 1)
+		require.Equal(t, "127.0.0.1:1", reply.Reports[0].Host)
+	})
+
+	t.Run("bad limit", func(t *testing.T) {
+		reply := requester.MisbehaviorReportsResponse{}
+		err := service.GetMisbehaviorReports(r, &requester.MisbehaviorReportsParams{Limit: -1}, nil, &reply)
+		require.Error(t, err)
+	})
+
+	t.Run("public api", func(t *testing.T) {
+		cfg := configuration.NewAPIRunner(false)
+		service := NewNodeService(&Runner{cfg: &cfg, Misbehavior: journal})
+		reply := requester.MisbehaviorReportsResponse{}
+		err := service.GetMisbehaviorReports(r, &requester.MisbehaviorReportsParams{}, nil, &reply)
+		require.Error(t, err)
+	})
+}
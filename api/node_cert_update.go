@@ -0,0 +1,145 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/insolar/rpc/v2"
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/api/requester"
+	"github.com/insolar/insolar/certificate"
+	"github.com/insolar/insolar/insolar/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// RevocationListArgs is arguments that cert.getRevocationList accepts.
+type RevocationListArgs struct{}
+
+// Rotate announces new key of node to network. Rotation has to be signed by all discovery nodes,
+// old key of node is revoked when rotation is applied.
+//
+//   Request structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "method": "cert.rotate",
+//     "params": {
+//       "reference": str, // reference of node
+//       "role": str,
+//       "old_public_key": str,
+//       "public_key": str,
+//       "discovery_signs": {str: str}, // signs of rotation by references of discovery nodes
+//       "certificate_signs": {str: str} // signs of new certificate by references of discovery nodes
+//     },
+//     "id": str|int|null
+//   }
+//
+//   Response structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "result": {
+//       "status": "announced"
+//     },
+//     "id": str|int|null
+//   }
+func (s *NodeCertService) Rotate(r *http.Request, args *certificate.Rotation, requestBody *rpc.RequestBody, reply *requester.CertificateUpdateResponse) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ NodeCertService.Rotate ] Incoming request: %s", r.RequestURI)
+	if s.runner.CertificateUpdater == nil {
+		return errors.New("certificate updates are not available")
+	}
+
+	err := s.runner.CertificateUpdater.Rotate(ctx, *args)
+	if err != nil {
+		return errors.Wrap(err, "[ NodeCertService.Rotate ]")
+	}
+	reply.Status = "announced"
+	return nil
+}
+
+// Revoke announces revocation of node key to network. Revocation has to be signed by majority of discovery nodes.
+//
+//   Request structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "method": "cert.revoke",
+//     "params": {
+//       "reference": str, // reference of node
+//       "public_key": str, // optional, all keys of node are revoked if empty
+//       "reason": str, // optional
+//       "discovery_signs": {str: str} // signs of revocation by references of discovery nodes
+//     },
+//     "id": str|int|null
+//   }
+//
+//   Response structure is the same as of cert.rotate.
+func (s *NodeCertService) Revoke(r *http.Request, args *certificate.Revocation, requestBody *rpc.RequestBody, reply *requester.CertificateUpdateResponse) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ NodeCertService.Revoke ] Incoming request: %s", r.RequestURI)
+	if s.runner.CertificateUpdater == nil {
+		return errors.New("certificate updates are not available")
+	}
+
+	err := s.runner.CertificateUpdater.Revoke(ctx, *args)
+	if err != nil {
+		return errors.Wrap(err, "[ NodeCertService.Revoke ]")
+	}
+	reply.Status = "announced"
+	return nil
+}
+
+// GetRevocationList returns rotations and revocations applied by node.
+//
+//   Request structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "method": "cert.getRevocationList",
+//     "id": str|int|null
+//   }
+//
+//   Response structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "result": {
+//       "rotations": [...], // in format of cert.rotate params
+//       "revocations": [...] // in format of cert.revoke params
+//     },
+//     "id": str|int|null
+//   }
+func (s *NodeCertService) GetRevocationList(r *http.Request, args *RevocationListArgs, requestBody *rpc.RequestBody, reply *requester.RevocationListResponse) error {
+	_, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ NodeCertService.GetRevocationList ] Incoming request: %s", r.RequestURI)
+	if s.runner.CertificateUpdater == nil {
+		return errors.New("certificate updates are not available")
+	}
+
+	list := s.runner.CertificateUpdater.RevocationList()
+	reply.Rotations = list.Rotations()
+	reply.Revocations = list.Revocations()
+	if reply.Rotations == nil {
+		reply.Rotations = []certificate.Rotation{}
+	}
+	if reply.Revocations == nil {
+		reply.Revocations = []certificate.Revocation{}
+	}
+	return nil
+}
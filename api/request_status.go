@@ -0,0 +1,160 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/insolar/rpc/v2"
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/api/requester"
+	"github.com/insolar/insolar/application/extractor"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/payload"
+	"github.com/insolar/insolar/insolar/record"
+	"github.com/insolar/insolar/insolar/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/instrumentation/instracer"
+)
+
+// GetRequestStatus returns status of request registered by contract.call.
+//
+//   Request structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "method": "contract.getRequestStatus",
+//     "id": str|int|null
+//     "params": {
+//       "requestReference": str, // requestReference returned by contract.call
+//       "objectReference": str // reference of called object: "reference" param of contract.call or root member
+//     }
+//   }
+//
+//   Response structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "result": {
+//       "status": str, // "pending", "executed" or "failed"
+//       "pulse": int, // pulse of request
+//       "resultPulse": int, // pulse of result, absent for pending request
+//       "result": any, // result of executed request
+//       "error": str, // error of failed request
+//       "outgoingRequests": [str], // references of outgoing requests made by request
+//       "traceID": str
+//     },
+//     "id": str|int|null
+//   }
+func (cs *ContractService) GetRequestStatus(r *http.Request, args *requester.RequestStatusParams, requestBody *rpc.RequestBody, reply *requester.RequestStatusResponse) error {
+	return getRequestStatus(cs.runner, r, args, reply)
+}
+
+// GetRequestStatus returns status of request registered by contract.call. See ContractService.GetRequestStatus.
+func (cs *AdminContractService) GetRequestStatus(r *http.Request, args *requester.RequestStatusParams, requestBody *rpc.RequestBody, reply *requester.RequestStatusResponse) error {
+	return getRequestStatus(cs.runner, r, args, reply)
+}
+
+// GetReceipt is contract.getReceipt alias of contract.getRequestStatus. See ContractService.GetRequestStatus.
+func (cs *ContractService) GetReceipt(r *http.Request, args *requester.RequestStatusParams, requestBody *rpc.RequestBody, reply *requester.RequestStatusResponse) error {
+	return getRequestStatus(cs.runner, r, args, reply)
+}
+
+// GetReceipt is contract.getReceipt alias of contract.getRequestStatus. See ContractService.GetRequestStatus.
+func (cs *AdminContractService) GetReceipt(r *http.Request, args *requester.RequestStatusParams, requestBody *rpc.RequestBody, reply *requester.RequestStatusResponse) error {
+	return getRequestStatus(cs.runner, r, args, reply)
+}
+
+func getRequestStatus(runner *Runner, r *http.Request, args *requester.RequestStatusParams, reply *requester.RequestStatusResponse) error {
+	traceID := utils.RandTraceID()
+	ctx, logger := inslogger.WithTraceField(context.Background(), traceID)
+
+	ctx, span := instracer.StartSpan(ctx, "GetRequestStatus")
+	defer span.End()
+
+	logger.Infof("[ ContractService.GetRequestStatus ] Incoming request: %s", r.RequestURI)
+
+	requestRef, err := insolar.NewReferenceFromBase58(args.RequestReference)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse requestReference")
+	}
+	objectRef, err := insolar.NewReferenceFromBase58(args.ObjectReference)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse objectReference")
+	}
+
+	info, err := runner.ArtifactManager.GetRequestInfo(ctx, *objectRef, *requestRef)
+	if err != nil {
+		return errors.Wrap(err, "failed to get request info")
+	}
+
+	err = fillRequestStatus(info, reply)
+	if err != nil {
+		return err
+	}
+	reply.Pulse = uint32(requestRef.GetLocal().Pulse())
+	reply.TraceID = traceID
+	return nil
+}
+
+// fillRequestStatus fills reply with request status from filament of the called object.
+func fillRequestStatus(info *payload.RequestInfo, reply *requester.RequestStatusResponse) error {
+	if len(info.Request) == 0 {
+		return errors.New("request is not found")
+	}
+
+	reply.OutgoingRequests = make([]string, 0, len(info.OutgoingRequests))
+	for _, buf := range info.OutgoingRequests {
+		outgoing := record.Material{}
+		err := outgoing.Unmarshal(buf)
+		if err != nil {
+			return errors.Wrap(err, "failed to unmarshal outgoing request record")
+		}
+		reply.OutgoingRequests = append(reply.OutgoingRequests, insolar.NewRecordReference(outgoing.ID).String())
+	}
+
+	if len(info.Result) == 0 {
+		reply.Status = requester.RequestStatusPending
+		return nil
+	}
+
+	rec := record.Material{}
+	err := rec.Unmarshal(info.Result)
+	if err != nil {
+		return errors.Wrap(err, "failed to unmarshal result record")
+	}
+	result, ok := record.Unwrap(&rec.Virtual).(*record.Result)
+	if !ok {
+		return errors.Errorf("unexpected result record %T", record.Unwrap(&rec.Virtual))
+	}
+	reply.ResultPulse = uint32(rec.ID.Pulse())
+
+	callResult, contractErr, err := extractor.CallResponse(result.Payload)
+	switch {
+	case err != nil:
+		// Result isn't made by a contract method, so it's returned as is.
+		reply.Status = requester.RequestStatusExecuted
+		reply.Result = result.Payload
+	case contractErr != nil:
+		reply.Status = requester.RequestStatusFailed
+		reply.Error = contractErr.S
+	default:
+		reply.Status = requester.RequestStatusExecuted
+		reply.Result = callResult
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/api/requester"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/gen"
+	"github.com/insolar/insolar/insolar/payload"
+	"github.com/insolar/insolar/insolar/record"
+	"github.com/insolar/insolar/logicrunner/artifacts"
+	"github.com/insolar/insolar/logicrunner/builtin/foundation"
+)
+
+func TestFillRequestStatus(t *testing.T) {
+	requestID := gen.ID()
+	marshal := func(id insolar.ID, virtual record.Record) []byte {
+		rec := record.Material{ID: id, Virtual: record.Wrap(virtual)}
+		buf, err := rec.Marshal()
+		require.NoError(t, err)
+		return buf
+	}
+	request := marshal(requestID, &record.IncomingRequest{Method: "Call"})
+	outgoingID := gen.ID()
+	outgoing := marshal(outgoingID, &record.OutgoingRequest{Reason: *insolar.NewRecordReference(requestID)})
+	resultID := gen.ID()
+	result := func(payload []byte) []byte {
+		return marshal(resultID, &record.Result{Request: *insolar.NewRecordReference(requestID), Payload: payload})
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		reply := requester.RequestStatusResponse{}
+		err := fillRequestStatus(&payload.RequestInfo{}, &reply)
+		require.Error(t, err)
+	})
+
+	t.Run("pending", func(t *testing.T) {
+		reply := requester.RequestStatusResponse{}
+		err := fillRequestStatus(&payload.RequestInfo{Request: request, OutgoingRequests: [][]byte{outgoing}}, &reply)
+		require.NoError(t, err)
+		require.Equal(t, requester.RequestStatusPending, reply.Status)
+		require.Equal(t, []string{insolar.NewRecordReference(outgoingID).String()}, reply.OutgoingRequests)
+		require.Zero(t, reply.ResultPulse)
+	})
+
+	t.Run("executed", func(t *testing.T) {
+		data, err := foundation.MarshalMethodResult(map[string]interface{}{"fee": "1"}, nil)
+		require.NoError(t, err)
+
+		reply := requester.RequestStatusResponse{}
+		err = fillRequestStatus(&payload.RequestInfo{Request: request, Result: result(data)}, &reply)
+		require.NoError(t, err)
+		require.Equal(t, requester.RequestStatusExecuted, reply.Status)
+		require.Equal(t, map[string]interface{}{"fee": "1"}, reply.Result)
+		require.Equal(t, uint32(resultID.Pulse()), reply.ResultPulse)
+		require.Empty(t, reply.OutgoingRequests)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		data, err := foundation.MarshalMethodErrorResult(errors.New("not enough balance"))
+		require.NoError(t, err)
+
+		reply := requester.RequestStatusResponse{}
+		err = fillRequestStatus(&payload.RequestInfo{Request: request, Result: result(data)}, &reply)
+		require.NoError(t, err)
+		require.Equal(t, requester.RequestStatusFailed, reply.Status)
+		require.Equal(t, "not enough balance", reply.Error)
+	})
+}
+
+func TestContractService_GetReceipt(t *testing.T) {
+	requestRef := gen.Reference()
+	objectRef := gen.Reference()
+	request, err := (&record.Material{
+		ID:      *requestRef.GetLocal(),
+		Virtual: record.Wrap(&record.IncomingRequest{Method: "Call"}),
+	}).Marshal()
+	require.NoError(t, err)
+
+	am := artifacts.NewClientMock(t)
+	am.GetRequestInfoMock.Inspect(func(_ context.Context, object insolar.Reference, req insolar.Reference) {
+		require.Equal(t, objectRef, object)
+		require.Equal(t, requestRef, req)
+	}).Return(&payload.RequestInfo{Request: request}, nil)
+	runner := &Runner{ArtifactManager: am}
+	args := &requester.RequestStatusParams{
+		RequestReference: requestRef.String(),
+		ObjectReference:  objectRef.String(),
+	}
+	r := &http.Request{}
+
+	t.Run("public", func(t *testing.T) {
+		cs := NewContractService(runner)
+
+		status := requester.RequestStatusResponse{}
+		err := cs.GetRequestStatus(r, args, nil, &status)
+		require.NoError(t, err)
+
+		receipt := requester.RequestStatusResponse{}
+		err = cs.GetReceipt(r, args, nil, &receipt)
+		require.NoError(t, err)
+
+		require.Equal(t, requester.RequestStatusPending, receipt.Status)
+		require.Equal(t, status.Status, receipt.Status)
+		require.Equal(t, status.Pulse, receipt.Pulse)
+	})
+
+	t.Run("admin", func(t *testing.T) {
+		cs := NewAdminContractService(runner)
+
+		receipt := requester.RequestStatusResponse{}
+		err := cs.GetReceipt(r, args, nil, &receipt)
+		require.NoError(t, err)
+		require.Equal(t, requester.RequestStatusPending, receipt.Status)
+	})
+}
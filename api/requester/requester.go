@@ -36,6 +36,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/insolar/insolar/certificate"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 )
 
@@ -47,6 +48,9 @@ const (
 	Signature      = "Signature"
 	ContentType    = "Content-Type"
 	JSONRPCVersion = "2.0"
+
+	// IncorrectSeedMessage is an error message of api when seed is unknown to node, expired or already used.
+	IncorrectSeedMessage = "[ checkSeed ] Incorrect seed"
 )
 
 func init() {
@@ -116,7 +120,11 @@ type Params struct {
 
 // GetResponseBodyContract makes request to contract and extracts body
 func GetResponseBodyContract(url string, postP ContractRequest, signature string) ([]byte, error) {
-	req, jsonValue, err := prepareReq(url, postP)
+	return getResponseBodyContract(context.Background(), url, postP, signature)
+}
+
+func getResponseBodyContract(ctx context.Context, url string, postP ContractRequest, signature string) ([]byte, error) {
+	req, jsonValue, err := prepareReq(ctx, url, postP)
 	if err != nil {
 		return nil, errors.Wrap(err, "problem with preparing contract request")
 	}
@@ -135,6 +143,10 @@ func GetResponseBodyContract(url string, postP ContractRequest, signature string
 
 // GetResponseBodyContract makes request to platform and extracts body
 func GetResponseBodyPlatform(url string, method string, params interface{}) ([]byte, error) {
+	return getResponseBodyPlatform(context.Background(), url, method, params)
+}
+
+func getResponseBodyPlatform(ctx context.Context, url string, method string, params interface{}) ([]byte, error) {
 	request := PlatformRequest{
 		Request: Request{
 			Version: JSONRPCVersion,
@@ -144,7 +156,7 @@ func GetResponseBodyPlatform(url string, method string, params interface{}) ([]b
 		PlatformParams: params,
 	}
 
-	req, _, err := prepareReq(url, request)
+	req, _, err := prepareReq(ctx, url, request)
 	if err != nil {
 		return nil, errors.Wrap(err, "problem with preparing platform request")
 	}
@@ -152,7 +164,7 @@ func GetResponseBodyPlatform(url string, method string, params interface{}) ([]b
 	return doReq(req)
 }
 
-func prepareReq(url string, postP interface{}) (*http.Request, []byte, error) {
+func prepareReq(ctx context.Context, url string, postP interface{}) (*http.Request, []byte, error) {
 	jsonValue, err := json.Marshal(postP)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "problem with marshaling params")
@@ -164,7 +176,7 @@ func prepareReq(url string, postP interface{}) (*http.Request, []byte, error) {
 	}
 	req.Header.Set(ContentType, "application/json")
 
-	return req, jsonValue, nil
+	return req.WithContext(ctx), jsonValue, nil
 }
 
 func doReq(req *http.Request) ([]byte, error) {
@@ -192,7 +204,12 @@ func doReq(req *http.Request) ([]byte, error) {
 
 // GetSeed makes rpc request to node.getSeed method and extracts it
 func GetSeed(url string) (string, error) {
-	body, err := GetResponseBodyPlatform(url, "node.getSeed", nil)
+	return GetSeedContext(context.Background(), url)
+}
+
+// GetSeedContext is GetSeed which request is canceled with ctx
+func GetSeedContext(ctx context.Context, url string) (string, error) {
+	body, err := getResponseBodyPlatform(ctx, url, "node.getSeed", nil)
 	if err != nil {
 		return "", errors.Wrap(err, "[ GetSeed ] seed request")
 	}
@@ -242,7 +259,7 @@ func SendWithSeed(ctx context.Context, url string, userCfg *UserConfigJSON, para
 	}
 	verboseInfo(ctx, "Signing request completed")
 
-	body, err := GetResponseBodyContract(url, *request, signature)
+	body, err := getResponseBodyContract(ctx, url, *request, signature)
 	if err != nil {
 		return nil, errors.Wrap(err, "[ SendWithSeed ] Problem with sending target request")
 	}
@@ -279,7 +296,7 @@ func marshalSig(r, s *big.Int) (string, error) {
 // Send first gets seed and after that makes target request
 func Send(ctx context.Context, url string, userCfg *UserConfigJSON, params *Params) ([]byte, error) {
 	verboseInfo(ctx, "Sending GETSEED request ...")
-	seed, err := GetSeed(url)
+	seed, err := GetSeedContext(ctx, url)
 	if err != nil {
 		return nil, errors.Wrap(err, "[ Send ] Problem with getting seed")
 	}
@@ -332,3 +349,141 @@ func Status(url string) (*StatusResponse, error) {
 
 	return &statusResp.Result, nil
 }
+
+// GetRequestStatus makes rpc request to contract.getRequestStatus method and extracts it
+func GetRequestStatus(ctx context.Context, url string, params RequestStatusParams) (*RequestStatusResponse, error) {
+	body, err := getResponseBodyPlatform(ctx, url, "contract.getRequestStatus", params)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ GetRequestStatus ]")
+	}
+
+	statusResp := rpcRequestStatusResponse{}
+
+	err = json.Unmarshal(body, &statusResp)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ GetRequestStatus ] Can't unmarshal")
+	}
+	if statusResp.Error != nil {
+		return nil, errors.New("[ GetRequestStatus ] Field 'error' is not nil: " + fmt.Sprint(statusResp.Error))
+	}
+
+	return &statusResp.Result, nil
+}
+
+// GetMisbehaviorReports makes rpc request to node.getMisbehaviorReports method and extracts it
+func GetMisbehaviorReports(ctx context.Context, url string, params MisbehaviorReportsParams) (*MisbehaviorReportsResponse, error) {
+	body, err := getResponseBodyPlatform(ctx, url, "node.getMisbehaviorReports", params)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ GetMisbehaviorReports ]")
+	}
+
+	reportsResp := rpcMisbehaviorReportsResponse{}
+
+	err = json.Unmarshal(body, &reportsResp)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ GetMisbehaviorReports ] Can't unmarshal")
+	}
+	if reportsResp.Error != nil {
+		return nil, errors.New("[ GetMisbehaviorReports ] Field 'error' is not nil: " + fmt.Sprint(reportsResp.Error))
+	}
+
+	return &reportsResp.Result, nil
+}
+
+// GetContractABI makes rpc request to abi.get method and extracts it
+func GetContractABI(ctx context.Context, url string, prototype string) (*ContractABIResponse, error) {
+	body, err := getResponseBodyPlatform(ctx, url, "abi.get", ContractABIParams{Prototype: prototype})
+	if err != nil {
+		return nil, errors.Wrap(err, "[ GetContractABI ]")
+	}
+
+	abiResp := rpcContractABIResponse{}
+
+	err = json.Unmarshal(body, &abiResp)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ GetContractABI ] Can't unmarshal")
+	}
+	if abiResp.Error != nil {
+		return nil, errors.New("[ GetContractABI ] Field 'error' is not nil: " + fmt.Sprint(abiResp.Error))
+	}
+
+	return &abiResp.Result, nil
+}
+
+// Decommission makes rpc request to node.decommission method and extracts it
+func Decommission(ctx context.Context, url string) (*DecommissionStatusResponse, error) {
+	return decommissionStatus(ctx, url, "node.decommission")
+}
+
+// GetDecommissionStatus makes rpc request to node.getDecommissionStatus method and extracts it
+func GetDecommissionStatus(ctx context.Context, url string) (*DecommissionStatusResponse, error) {
+	return decommissionStatus(ctx, url, "node.getDecommissionStatus")
+}
+
+func decommissionStatus(ctx context.Context, url string, method string) (*DecommissionStatusResponse, error) {
+	body, err := getResponseBodyPlatform(ctx, url, method, struct{}{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "[ %s ]", method)
+	}
+
+	statusResp := rpcDecommissionStatusResponse{}
+
+	err = json.Unmarshal(body, &statusResp)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[ %s ] Can't unmarshal", method)
+	}
+	if statusResp.Error != nil {
+		return nil, errors.New("[ " + method + " ] Field 'error' is not nil: " + fmt.Sprint(statusResp.Error))
+	}
+
+	return &statusResp.Result, nil
+}
+
+// RotateKey makes rpc request to cert.rotate method and extracts it
+func RotateKey(ctx context.Context, url string, rotation certificate.Rotation) (*CertificateUpdateResponse, error) {
+	return certificateUpdate(ctx, url, "cert.rotate", rotation)
+}
+
+// RevokeKey makes rpc request to cert.revoke method and extracts it
+func RevokeKey(ctx context.Context, url string, revocation certificate.Revocation) (*CertificateUpdateResponse, error) {
+	return certificateUpdate(ctx, url, "cert.revoke", revocation)
+}
+
+func certificateUpdate(ctx context.Context, url string, method string, params interface{}) (*CertificateUpdateResponse, error) {
+	body, err := getResponseBodyPlatform(ctx, url, method, params)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[ %s ]", method)
+	}
+
+	updateResp := rpcCertificateUpdateResponse{}
+
+	err = json.Unmarshal(body, &updateResp)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[ %s ] Can't unmarshal", method)
+	}
+	if updateResp.Error != nil {
+		return nil, errors.New("[ " + method + " ] Field 'error' is not nil: " + fmt.Sprint(updateResp.Error))
+	}
+
+	return &updateResp.Result, nil
+}
+
+// GetRevocationList makes rpc request to cert.getRevocationList method and extracts it
+func GetRevocationList(ctx context.Context, url string) (*RevocationListResponse, error) {
+	body, err := getResponseBodyPlatform(ctx, url, "cert.getRevocationList", struct{}{})
+	if err != nil {
+		return nil, errors.Wrap(err, "[ GetRevocationList ]")
+	}
+
+	listResp := rpcRevocationListResponse{}
+
+	err = json.Unmarshal(body, &listResp)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ GetRevocationList ] Can't unmarshal")
+	}
+	if listResp.Error != nil {
+		return nil, errors.New("[ GetRevocationList ] Field 'error' is not nil: " + fmt.Sprint(listResp.Error))
+	}
+
+	return &listResp.Result, nil
+}
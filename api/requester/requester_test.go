@@ -46,6 +46,7 @@ const TESTSEED = "VGVzdA=="
 var testSeedResponse = seedResponse{Seed: "Test", TraceID: "testTraceID"}
 var testInfoResponse = InfoResponse{RootMember: "root_member_ref", RootDomain: "root_domain_ref", NodeDomain: "node_domain_ref"}
 var testStatusResponse = StatusResponse{NetworkState: "OK"}
+var testRequestStatusResponse = RequestStatusResponse{Status: RequestStatusExecuted, Pulse: 65537, OutgoingRequests: []string{}}
 
 func writeReponse(response http.ResponseWriter, answer interface{}) {
 	serJSON, err := json.MarshalIndent(answer, "", "    ")
@@ -83,6 +84,8 @@ func FakeRPCHandler(response http.ResponseWriter, req *http.Request) {
 		rpcResponse.Result = testSeedResponse
 	case "contract.call":
 		rpcResponse.Result = TESTREFERENCE
+	case "contract.getRequestStatus":
+		rpcResponse.Result = testRequestStatusResponse
 	default:
 		rpcResponse.Result = TESTSEED
 
@@ -255,6 +258,12 @@ func TestStatus(t *testing.T) {
 	require.Equal(t, resp, &testStatusResponse)
 }
 
+func TestGetRequestStatus(t *testing.T) {
+	resp, err := GetRequestStatus(context.Background(), URL, RequestStatusParams{RequestReference: TESTREFERENCE})
+	require.NoError(t, err)
+	require.Equal(t, resp, &testRequestStatusResponse)
+}
+
 func TestMarshalSig(t *testing.T) {
 	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	require.NoError(t, err)
@@ -17,7 +17,10 @@
 package requester
 
 import (
+	"encoding/json"
 	"time"
+
+	"github.com/insolar/insolar/certificate"
 )
 
 type Response struct {
@@ -104,3 +107,157 @@ type rpcInfoResponse struct {
 	Response
 	Result InfoResponse `json:"result"`
 }
+
+// Request statuses of contract.getRequestStatus method.
+const (
+	RequestStatusPending  = "pending"
+	RequestStatusExecuted = "executed"
+	RequestStatusFailed   = "failed"
+)
+
+// RequestStatusParams represents params of contract.getRequestStatus method
+type RequestStatusParams struct {
+	RequestReference string `json:"requestReference"`
+	ObjectReference  string `json:"objectReference"`
+}
+
+// RequestStatusResponse represents response from rpc on contract.getRequestStatus method
+type RequestStatusResponse struct {
+	Status           string      `json:"status"`
+	Pulse            uint32      `json:"pulse"`
+	ResultPulse      uint32      `json:"resultPulse,omitempty"`
+	Result           interface{} `json:"result,omitempty"`
+	Error            string      `json:"error,omitempty"`
+	OutgoingRequests []string    `json:"outgoingRequests"`
+	TraceID          string      `json:"traceID"`
+}
+
+type rpcRequestStatusResponse struct {
+	Response
+	Result RequestStatusResponse `json:"result"`
+}
+
+// SetCodeParams represents params of contract.setCode method
+type SetCodeParams struct {
+	Code []byte `json:"code"`
+}
+
+// SetCodeResponse represents response from rpc on contract.setCode method
+type SetCodeResponse struct {
+	PrototypeReference string `json:"prototypeReference"`
+	CodeReference      string `json:"codeReference"`
+	TraceID            string `json:"traceID"`
+}
+
+// MigrateParams represents params of contract.migrate method
+type MigrateParams struct {
+	ObjectReference    string `json:"objectReference"`
+	PrototypeReference string `json:"prototypeReference"`
+}
+
+// MigrateResponse represents response from rpc on contract.migrate method
+type MigrateResponse struct {
+	ObjectReference string `json:"objectReference"`
+	TraceID         string `json:"traceID"`
+}
+
+// MisbehaviorReportsParams represents params of node.getMisbehaviorReports method
+type MisbehaviorReportsParams struct {
+	Node      string `json:"node,omitempty"`
+	Category  string `json:"category,omitempty"`
+	Type      string `json:"type,omitempty"`
+	FromPulse uint32 `json:"fromPulse,omitempty"`
+	Limit     int    `json:"limit,omitempty"`
+}
+
+// MisbehaviorReport represents blame or fraud report about a node
+type MisbehaviorReport struct {
+	ID          uint64    `json:"id"`
+	Pulse       uint32    `json:"pulse"`
+	Time        time.Time `json:"time"`
+	Category    string    `json:"category"`
+	Type        string    `json:"type"`
+	NodeID      uint32    `json:"nodeId,omitempty"`
+	Node        string    `json:"node,omitempty"`
+	Host        string    `json:"host,omitempty"`
+	Description string    `json:"description"`
+	Evidence    []string  `json:"evidence,omitempty"`
+}
+
+// MisbehaviorReportsResponse represents response from rpc on node.getMisbehaviorReports method
+type MisbehaviorReportsResponse struct {
+	Reports []MisbehaviorReport `json:"reports"`
+}
+
+type rpcMisbehaviorReportsResponse struct {
+	Response
+	Result MisbehaviorReportsResponse `json:"result"`
+}
+
+// ContractABIParams represents params of abi.get method
+type ContractABIParams struct {
+	Prototype string `json:"prototype"`
+}
+
+// ContractABIResponse represents response from rpc on abi.get method
+type ContractABIResponse struct {
+	Prototype string          `json:"prototype"`
+	ABI       json.RawMessage `json:"abi"`
+}
+
+type rpcContractABIResponse struct {
+	Response
+	Result ContractABIResponse `json:"result"`
+}
+
+// ContractABIListEntry is a contract with known ABI
+type ContractABIListEntry struct {
+	Prototype string `json:"prototype"`
+	Contract  string `json:"contract"`
+}
+
+// ContractABIListResponse represents response from rpc on abi.list method
+type ContractABIListResponse struct {
+	Contracts []ContractABIListEntry `json:"contracts"`
+}
+
+// DecommissionComponent represents progress of node component that hands over its work
+type DecommissionComponent struct {
+	Component string `json:"component"`
+	Done      bool   `json:"done"`
+	Pending   int    `json:"pending,omitempty"`
+	Details   string `json:"details,omitempty"`
+}
+
+// DecommissionStatusResponse represents response from rpc on node.decommission and node.getDecommissionStatus methods
+type DecommissionStatusResponse struct {
+	State      string                  `json:"state"`
+	StartedAt  *time.Time              `json:"startedAt,omitempty"`
+	Components []DecommissionComponent `json:"components"`
+}
+
+type rpcDecommissionStatusResponse struct {
+	Response
+	Result DecommissionStatusResponse `json:"result"`
+}
+
+// CertificateUpdateResponse represents response from rpc on cert.rotate and cert.revoke methods
+type CertificateUpdateResponse struct {
+	Status string `json:"status"`
+}
+
+type rpcCertificateUpdateResponse struct {
+	Response
+	Result CertificateUpdateResponse `json:"result"`
+}
+
+// RevocationListResponse represents response from rpc on cert.getRevocationList method
+type RevocationListResponse struct {
+	Rotations   []certificate.Rotation   `json:"rotations"`
+	Revocations []certificate.Revocation `json:"revocations"`
+}
+
+type rpcRevocationListResponse struct {
+	Response
+	Result RevocationListResponse `json:"result"`
+}
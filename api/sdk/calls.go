@@ -0,0 +1,162 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package sdk
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/api/requester"
+)
+
+// MemberCreate creates member with keys of m. Reference of m is ignored.
+func (sdk *SDK) MemberCreate(ctx context.Context, m *Member) (*CreateResponse, error) {
+	response := &CreateResponse{}
+	info, err := sdk.callAs(ctx, sdk.publicAPIURLs, m, "member.create", map[string]interface{}{}, response)
+	if err != nil {
+		return nil, err
+	}
+	response.CallInfo = *info
+	return response, nil
+}
+
+// MemberMigrationCreate creates member with keys of m and a free migration address.
+func (sdk *SDK) MemberMigrationCreate(ctx context.Context, m *Member) (*MigrationCreateResponse, error) {
+	response := &MigrationCreateResponse{}
+	info, err := sdk.callAs(ctx, sdk.publicAPIURLs, m, "member.migrationCreate", map[string]interface{}{}, response)
+	if err != nil {
+		return nil, err
+	}
+	response.CallInfo = *info
+	return response, nil
+}
+
+// MemberGet returns reference and migration address of member with public key of m.
+func (sdk *SDK) MemberGet(ctx context.Context, m *Member) (*GetResponse, error) {
+	response := &GetResponse{}
+	info, err := sdk.callAs(ctx, sdk.publicAPIURLs, m, "member.get", map[string]interface{}{}, response)
+	if err != nil {
+		return nil, err
+	}
+	response.CallInfo = *info
+	return response, nil
+}
+
+// MemberGetBalance returns balance and deposits of member from request on behalf of m.
+func (sdk *SDK) MemberGetBalance(ctx context.Context, m *Member, request GetBalanceRequest) (*GetBalanceResponse, error) {
+	response := &GetBalanceResponse{}
+	info, err := sdk.callAs(ctx, sdk.adminAPIURLs, m, "member.getBalance", request, response)
+	if err != nil {
+		return nil, err
+	}
+	response.CallInfo = *info
+	return response, nil
+}
+
+// MemberTransfer transfers amount from m to another member.
+func (sdk *SDK) MemberTransfer(ctx context.Context, m *Member, request TransferRequest) (*TransferResponse, error) {
+	response := &TransferResponse{}
+	info, err := sdk.callAs(ctx, sdk.publicAPIURLs, m, "member.transfer", request, response)
+	if err != nil {
+		return nil, err
+	}
+	response.CallInfo = *info
+	return response, nil
+}
+
+// RegisterNode registers node on behalf of root member.
+func (sdk *SDK) RegisterNode(ctx context.Context, request RegisterNodeRequest) (*RegisterNodeResponse, error) {
+	response := &RegisterNodeResponse{}
+	info, err := sdk.call(ctx, sdk.adminAPIURLs, sdk.rootMember, "contract.registerNode", request, &response.Reference)
+	if err != nil {
+		return nil, err
+	}
+	response.CallInfo = *info
+	return response, nil
+}
+
+// GetNodeRef returns reference of node with public key on behalf of root member.
+func (sdk *SDK) GetNodeRef(ctx context.Context, request GetNodeRefRequest) (*GetNodeRefResponse, error) {
+	response := &GetNodeRefResponse{}
+	info, err := sdk.call(ctx, sdk.adminAPIURLs, sdk.rootMember, "contract.getNodeRef", request, &response.Reference)
+	if err != nil {
+		return nil, err
+	}
+	response.CallInfo = *info
+	return response, nil
+}
+
+// DepositMigration confirms migration of deposit on behalf of migration daemon.
+func (sdk *SDK) DepositMigration(ctx context.Context, daemon *Member, request DepositMigrationRequest) (*DepositMigrationResponse, error) {
+	response := &DepositMigrationResponse{}
+	info, err := sdk.callAs(ctx, sdk.adminAPIURLs, daemon, "deposit.migration", request, response)
+	if err != nil {
+		return nil, err
+	}
+	response.CallInfo = *info
+	return response, nil
+}
+
+// DepositTransfer transfers released amount of deposit to wallet of m.
+func (sdk *SDK) DepositTransfer(ctx context.Context, m *Member, request DepositTransferRequest) (*CallInfo, error) {
+	return sdk.callAs(ctx, sdk.publicAPIURLs, m, "deposit.transfer", request, nil)
+}
+
+// MigrationAddAddresses adds migration addresses on behalf of migration admin.
+func (sdk *SDK) MigrationAddAddresses(ctx context.Context, request AddMigrationAddressesRequest) (*CallInfo, error) {
+	return sdk.call(ctx, sdk.adminAPIURLs, sdk.migrationAdminMember, "migration.addAddresses", request, nil)
+}
+
+// MigrationActivateDaemon activates migration daemon on behalf of migration admin.
+func (sdk *SDK) MigrationActivateDaemon(ctx context.Context, request DaemonRequest) (*CallInfo, error) {
+	return sdk.call(ctx, sdk.adminAPIURLs, sdk.migrationAdminMember, "migration.activateDaemon", request, nil)
+}
+
+// MigrationDeactivateDaemon deactivates migration daemon on behalf of migration admin.
+func (sdk *SDK) MigrationDeactivateDaemon(ctx context.Context, request DaemonRequest) (*CallInfo, error) {
+	return sdk.call(ctx, sdk.adminAPIURLs, sdk.migrationAdminMember, "migration.deactivateDaemon", request, nil)
+}
+
+// MigrationCheckDaemon returns status of migration daemon on behalf of migration admin.
+func (sdk *SDK) MigrationCheckDaemon(ctx context.Context, request DaemonRequest) (*CheckDaemonResponse, error) {
+	response := &CheckDaemonResponse{}
+	info, err := sdk.call(ctx, sdk.adminAPIURLs, sdk.migrationAdminMember, "migration.checkDaemon", request, response)
+	if err != nil {
+		return nil, err
+	}
+	response.CallInfo = *info
+	return response, nil
+}
+
+// RequestStatus returns status of request registered by a call to object. It's safe to retry, so the next url
+// is tried on any error.
+func (sdk *SDK) RequestStatus(ctx context.Context, request requester.RequestStatusParams) (*requester.RequestStatusResponse, error) {
+	var lastErr error
+	for attempt := 0; attempt <= sdk.maxRetries; attempt++ {
+		url := sdk.publicAPIURLs.next()
+		response, err := requester.GetRequestStatus(ctx, url, request)
+		if err == nil {
+			return response, nil
+		}
+		if ctx.Err() != nil {
+			return nil, errors.Wrap(ctx.Err(), "request was canceled")
+		}
+		lastErr = errors.Wrapf(err, "failed to get request status from %s", url)
+	}
+	return nil, errors.Wrapf(lastErr, "request failed after %d attempts", sdk.maxRetries+1)
+}
@@ -31,3 +31,112 @@ func NewMember(ref string, privateKey string, publicKey string) *Member {
 		PublicKey:  publicKey,
 	}
 }
+
+// CallInfo identifies a contract call. It's a part of every typed response.
+type CallInfo struct {
+	TraceID          string `json:"-"`
+	RequestReference string `json:"-"`
+}
+
+// CreateResponse is a result of member.create call.
+type CreateResponse struct {
+	CallInfo
+	Reference string `json:"reference"`
+}
+
+// MigrationCreateResponse is a result of member.migrationCreate call.
+type MigrationCreateResponse struct {
+	CallInfo
+	Reference        string `json:"reference"`
+	MigrationAddress string `json:"migrationAddress"`
+}
+
+// GetResponse is a result of member.get call.
+type GetResponse struct {
+	CallInfo
+	Reference        string `json:"reference"`
+	MigrationAddress string `json:"migrationAddress,omitempty"`
+}
+
+// GetBalanceRequest is params of member.getBalance call.
+type GetBalanceRequest struct {
+	Reference string `json:"reference"`
+}
+
+// GetBalanceResponse is a result of member.getBalance call.
+type GetBalanceResponse struct {
+	CallInfo
+	Balance  string                 `json:"balance"`
+	Deposits map[string]interface{} `json:"deposits"`
+}
+
+// TransferRequest is params of member.transfer call. Empty asset means default one.
+type TransferRequest struct {
+	Amount            string `json:"amount"`
+	ToMemberReference string `json:"toMemberReference"`
+	Asset             string `json:"asset,omitempty"`
+}
+
+// TransferResponse is a result of member.transfer call.
+type TransferResponse struct {
+	CallInfo
+	Fee string `json:"fee"`
+}
+
+// RegisterNodeRequest is params of contract.registerNode call.
+type RegisterNodeRequest struct {
+	PublicKey string `json:"publicKey"`
+	Role      string `json:"role"`
+}
+
+// RegisterNodeResponse is a result of contract.registerNode call.
+type RegisterNodeResponse struct {
+	CallInfo
+	Reference string
+}
+
+// GetNodeRefRequest is params of contract.getNodeRef call.
+type GetNodeRefRequest struct {
+	PublicKey string `json:"publicKey"`
+}
+
+// GetNodeRefResponse is a result of contract.getNodeRef call.
+type GetNodeRefResponse struct {
+	CallInfo
+	Reference string
+}
+
+// DepositMigrationRequest is params of deposit.migration call.
+type DepositMigrationRequest struct {
+	Amount           string `json:"amount"`
+	EthTxHash        string `json:"ethTxHash"`
+	MigrationAddress string `json:"migrationAddress"`
+}
+
+// DepositMigrationResponse is a result of deposit.migration call.
+type DepositMigrationResponse struct {
+	CallInfo
+	MemberReference string `json:"memberReference"`
+}
+
+// DepositTransferRequest is params of deposit.transfer call.
+type DepositTransferRequest struct {
+	Amount    string `json:"amount"`
+	EthTxHash string `json:"ethTxHash"`
+}
+
+// AddMigrationAddressesRequest is params of migration.addAddresses call.
+type AddMigrationAddressesRequest struct {
+	MigrationAddresses []string `json:"migrationAddresses"`
+}
+
+// DaemonRequest is params of migration.activateDaemon, migration.deactivateDaemon and migration.checkDaemon calls.
+type DaemonRequest struct {
+	Reference string `json:"reference"`
+}
+
+// CheckDaemonResponse is a result of migration.checkDaemon call.
+type CheckDaemonResponse struct {
+	CallInfo
+	Status string `json:"status"`
+}
@@ -33,6 +33,9 @@ import (
 	"github.com/insolar/insolar/platformpolicy"
 )
 
+// DefaultMaxRetries is a number of times a call is retried with a new seed from the next url.
+const DefaultMaxRetries = 3
+
 type ringBuffer struct {
 	sync.Mutex
 	urls   []string
@@ -62,10 +65,14 @@ type SDK struct {
 	migrationAdminMember   *requester.UserConfigJSON
 	migrationDaemonMembers []*requester.UserConfigJSON
 	logLevel               string
+	maxRetries             int
 }
 
 // NewSDK creates insSDK object
 func NewSDK(adminUrls []string, publicUrls []string, memberKeysDirPath string) (*SDK, error) {
+	if len(adminUrls) == 0 || len(publicUrls) == 0 {
+		return nil, errors.New("admin and public api urls are required")
+	}
 	adminBuffer := &ringBuffer{urls: adminUrls}
 	publicBuffer := &ringBuffer{urls: publicUrls}
 
@@ -107,6 +114,7 @@ func NewSDK(adminUrls []string, publicUrls []string, memberKeysDirPath string) (
 		migrationAdminMember:   migrationAdminMember,
 		migrationDaemonMembers: []*requester.UserConfigJSON{},
 		logLevel:               "",
+		maxRetries:             DefaultMaxRetries,
 	}
 
 	if len(response.MigrationDaemonMembers) < insolar.GenesisAmountMigrationDaemonMembers {
@@ -133,25 +141,124 @@ func (sdk *SDK) SetLogLevel(logLevel string) error {
 	return nil
 }
 
-func (sdk *SDK) sendRequest(ctx context.Context, urls *ringBuffer, method string, params map[string]interface{}, userCfg *requester.UserConfigJSON) ([]byte, error) {
-	reqParams := requester.Params{CallParams: params, CallSite: method, PublicKey: userCfg.PublicKey, LogLevel: sdk.logLevel}
+// SetMaxRetries sets how many times a call is retried when seed can't be got or is expired.
+func (sdk *SDK) SetMaxRetries(maxRetries int) error {
+	if maxRetries < 0 {
+		return errors.New("max retries can't be negative")
+	}
+	sdk.maxRetries = maxRetries
+	return nil
+}
+
+// RootMember returns root member loaded from keys dir.
+func (sdk *SDK) RootMember() *Member {
+	return newMemberFromConfig(sdk.rootMember)
+}
 
-	body, err := requester.Send(ctx, urls.next(), userCfg, &reqParams)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to send request")
+// MigrationAdminMember returns migration admin member loaded from keys dir.
+func (sdk *SDK) MigrationAdminMember() *Member {
+	return newMemberFromConfig(sdk.migrationAdminMember)
+}
+
+// MigrationDaemonMembers returns migration daemon members loaded from keys dir.
+func (sdk *SDK) MigrationDaemonMembers() []*Member {
+	members := make([]*Member, 0, len(sdk.migrationDaemonMembers))
+	for _, m := range sdk.migrationDaemonMembers {
+		members = append(members, newMemberFromConfig(m))
 	}
+	return members
+}
 
-	return body, nil
+func newMemberFromConfig(cfg *requester.UserConfigJSON) *Member {
+	return NewMember(cfg.Caller, cfg.PrivateKey, cfg.PublicKey)
 }
 
-func (sdk *SDK) getResponse(body []byte) (*requester.ContractResponse, error) {
-	res := &requester.ContractResponse{}
-	err := json.Unmarshal(body, &res)
-	if err != nil {
-		return nil, errors.Wrap(err, "problems with unmarshal response")
+// rawContractResponse is requester.ContractResponse with call result left undecoded.
+type rawContractResponse struct {
+	requester.Response
+	Result *struct {
+		CallResult       json.RawMessage `json:"callResult,omitempty"`
+		RequestReference string          `json:"requestReference,omitempty"`
+		TraceID          string          `json:"traceID,omitempty"`
+	} `json:"result,omitempty"`
+}
+
+// call is the only way requests are sent to API. It gets seed from the next url, signs the request with
+// user keys and sends it to the same url. If seed can't be got or node rejects it as expired, the call is
+// retried with a new seed from the next url. Calls that failed after sending are never retried, because
+// they could have been executed. Call result is decoded into result if it's not nil.
+func (sdk *SDK) call(
+	ctx context.Context,
+	urls *ringBuffer,
+	user *requester.UserConfigJSON,
+	method string,
+	params interface{},
+	result interface{},
+) (*CallInfo, error) {
+	ctx = inslogger.ContextWithTrace(ctx, method)
+	reqParams := requester.Params{CallSite: method, CallParams: params, PublicKey: user.PublicKey, LogLevel: sdk.logLevel}
+
+	var lastErr error
+	for attempt := 0; attempt <= sdk.maxRetries; attempt++ {
+		url := urls.next()
+
+		seed, err := requester.GetSeedContext(ctx, url)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, errors.Wrap(ctx.Err(), "request was canceled")
+			}
+			lastErr = errors.Wrapf(err, "failed to get seed from %s", url)
+			continue
+		}
+
+		body, err := requester.SendWithSeed(ctx, url, user, &reqParams, seed)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to send request to %s", url)
+		}
+
+		response := rawContractResponse{}
+		err = json.Unmarshal(body, &response)
+		if err != nil {
+			return nil, errors.Wrap(err, "problems with unmarshal response")
+		}
+
+		if response.Error != nil {
+			if response.Error.Message == requester.IncorrectSeedMessage {
+				lastErr = errors.Errorf("seed is rejected by %s", url)
+				continue
+			}
+			return nil, errors.New(response.Error.Message + ". TraceId: " + response.Error.Data.TraceID + ". RequestRef: " + response.Error.Data.RequestReference)
+		}
+		if response.Result == nil {
+			return nil, errors.New("response has neither result nor error")
+		}
+
+		if result != nil && len(response.Result.CallResult) > 0 {
+			err = json.Unmarshal(response.Result.CallResult, result)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to unmarshal result of %s", method)
+			}
+		}
+		return &CallInfo{TraceID: response.Result.TraceID, RequestReference: response.Result.RequestReference}, nil
 	}
 
-	return res, nil
+	return nil, errors.Wrapf(lastErr, "request failed after %d attempts", sdk.maxRetries+1)
+}
+
+// callAs is call on behalf of member m.
+func (sdk *SDK) callAs(
+	ctx context.Context,
+	urls *ringBuffer,
+	m *Member,
+	method string,
+	params interface{},
+	result interface{},
+) (*CallInfo, error) {
+	userConfig, err := requester.CreateUserConfig(m.Reference, m.PrivateKey, m.PublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create user config for request")
+	}
+	return sdk.call(ctx, urls, userConfig, method, params, result)
 }
 
 // CreateMember api request creates member with new random keys
@@ -175,46 +282,19 @@ func (sdk *SDK) CreateMember() (*Member, string, error) {
 	}
 	publicKeyStr := string(publicKey)
 
-	userConfig, err := requester.CreateUserConfig("", privateKeyStr, publicKeyStr)
-	if err != nil {
-		return nil, "", errors.Wrap(err, "failed to create user config for request")
-	}
-
-	response, err := sdk.DoRequest(
-		sdk.publicAPIURLs,
-		userConfig,
-		"member.create",
-		map[string]interface{}{},
-	)
+	response, err := sdk.MemberCreate(context.Background(), NewMember("", privateKeyStr, publicKeyStr))
 	if err != nil {
 		return nil, "", errors.Wrap(err, "request was failed ")
 	}
 
-	var memberRef string
-	var contractResultCasted map[string]interface{}
-	var ok bool
-	if contractResultCasted, ok = response.CallResult.(map[string]interface{}); !ok {
-		return nil, "", errors.Errorf("failed to cast result: expected map[string]interface{}, got %T", response.CallResult)
-	}
-	if memberRef, ok = contractResultCasted["reference"].(string); !ok {
-		return nil, "", errors.Errorf("failed to cast reference: expected string, got %T", contractResultCasted["reference"])
-	}
-
-	return NewMember(memberRef, privateKeyStr, publicKeyStr), response.TraceID, nil
+	return NewMember(response.Reference, privateKeyStr, publicKeyStr), response.TraceID, nil
 }
 
 // addMigrationAddresses method add burn addresses
 func (sdk *SDK) AddMigrationAddresses(migrationAddresses []string) (string, error) {
-	userConfig, err := requester.CreateUserConfig(sdk.migrationAdminMember.Caller, sdk.migrationAdminMember.PrivateKey, sdk.migrationAdminMember.PublicKey)
-	if err != nil {
-		return "", errors.Wrap(err, "failed to create user config for request")
-	}
-
-	response, err := sdk.DoRequest(
-		sdk.adminAPIURLs,
-		userConfig,
-		"migration.addAddresses",
-		map[string]interface{}{"migrationAddresses": migrationAddresses},
+	response, err := sdk.MigrationAddAddresses(
+		context.Background(),
+		AddMigrationAddressesRequest{MigrationAddresses: migrationAddresses},
 	)
 	if err != nil {
 		return "", errors.Wrap(err, "request was failed ")
@@ -225,15 +305,10 @@ func (sdk *SDK) AddMigrationAddresses(migrationAddresses []string) (string, erro
 
 // Transfer method send money from one member to another
 func (sdk *SDK) Transfer(amount string, from *Member, to *Member) (string, error) {
-	userConfig, err := requester.CreateUserConfig(from.Reference, from.PrivateKey, from.PublicKey)
-	if err != nil {
-		return "", errors.Wrap(err, "failed to create user config for request")
-	}
-	response, err := sdk.DoRequest(
-		sdk.publicAPIURLs,
-		userConfig,
-		"member.transfer",
-		map[string]interface{}{"amount": amount, "toMemberReference": to.Reference},
+	response, err := sdk.MemberTransfer(
+		context.Background(),
+		from,
+		TransferRequest{Amount: amount, ToMemberReference: to.Reference},
 	)
 	if err != nil {
 		return "", errors.Wrap(err, "request was failed ")
@@ -244,21 +319,12 @@ func (sdk *SDK) Transfer(amount string, from *Member, to *Member) (string, error
 
 // GetBalance returns current balance of the given member.
 func (sdk *SDK) GetBalance(m *Member) (*big.Int, error) {
-	userConfig, err := requester.CreateUserConfig(m.Reference, m.PrivateKey, m.PublicKey)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create user config for request")
-	}
-	response, err := sdk.DoRequest(
-		sdk.adminAPIURLs,
-		userConfig,
-		"member.getBalance",
-		map[string]interface{}{"reference": m.Reference},
-	)
+	response, err := sdk.MemberGetBalance(context.Background(), m, GetBalanceRequest{Reference: m.Reference})
 	if err != nil {
 		return nil, errors.Wrap(err, "request was failed ")
 	}
 
-	result, ok := new(big.Int).SetString(response.CallResult.(map[string]interface{})["balance"].(string), 10)
+	result, ok := new(big.Int).SetString(response.Balance, 10)
 	if !ok {
 		return nil, errors.Errorf("can't parse returned balance")
 	}
@@ -266,23 +332,17 @@ func (sdk *SDK) GetBalance(m *Member) (*big.Int, error) {
 	return result, nil
 }
 
+// DoRequest sends untyped request on behalf of user.
 func (sdk *SDK) DoRequest(urls *ringBuffer, user *requester.UserConfigJSON, method string, params map[string]interface{}) (*requester.ContractResult, error) {
-	ctx := inslogger.ContextWithTrace(context.Background(), method)
-
-	body, err := sdk.sendRequest(ctx, urls, method, params, user)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to send request")
-	}
-
-	response, err := sdk.getResponse(body)
+	var callResult interface{}
+	info, err := sdk.call(context.Background(), urls, user, method, params, &callResult)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get response from body")
+		return nil, err
 	}
 
-	if response.Error != nil {
-		return nil, errors.New(response.Error.Message + ". TraceId: " + response.Error.Data.TraceID + ". RequestRef: " + response.Error.Data.RequestReference)
-	}
-
-	return response.Result, nil
-
+	return &requester.ContractResult{
+		CallResult:       callResult,
+		RequestReference: info.RequestReference,
+		TraceID:          info.TraceID,
+	}, nil
 }
@@ -0,0 +1,201 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/api/requester"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/platformpolicy"
+)
+
+type testAPI struct {
+	server *httptest.Server
+	seeds  int32
+	calls  int32
+	// call returns result or error of contract.call request
+	call func(params requester.Params) (interface{}, *requester.Error)
+}
+
+func newTestAPI(t *testing.T, call func(params requester.Params) (interface{}, *requester.Error)) *testAPI {
+	api := &testAPI{call: call}
+	api.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := struct {
+			Method string           `json:"method"`
+			Params requester.Params `json:"params"`
+		}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		response := map[string]interface{}{"jsonrpc": requester.JSONRPCVersion}
+		switch request.Method {
+		case "node.getSeed":
+			atomic.AddInt32(&api.seeds, 1)
+			response["result"] = map[string]interface{}{"seed": "c2VlZA=="}
+		case "contract.call":
+			atomic.AddInt32(&api.calls, 1)
+			result, rpcErr := api.call(request.Params)
+			if rpcErr != nil {
+				response["error"] = rpcErr
+			} else {
+				response["result"] = requester.ContractResult{CallResult: result, TraceID: "trace"}
+			}
+		case "contract.getRequestStatus":
+			response["result"] = requester.RequestStatusResponse{Status: requester.RequestStatusPending}
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	return api
+}
+
+func newTestMember(t *testing.T) *Member {
+	ks := platformpolicy.NewKeyProcessor()
+	privateKey, err := ks.GeneratePrivateKey()
+	require.NoError(t, err)
+	privatePEM, err := ks.ExportPrivateKeyPEM(privateKey)
+	require.NoError(t, err)
+	publicPEM, err := ks.ExportPublicKeyPEM(ks.ExtractPublicKey(privateKey))
+	require.NoError(t, err)
+	return NewMember("member", string(privatePEM), string(publicPEM))
+}
+
+func newTestSDK(urls ...string) *SDK {
+	return &SDK{
+		adminAPIURLs:  &ringBuffer{urls: urls},
+		publicAPIURLs: &ringBuffer{urls: urls},
+		maxRetries:    DefaultMaxRetries,
+	}
+}
+
+func TestSDK_TypedCall(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	api := newTestAPI(t, func(params requester.Params) (interface{}, *requester.Error) {
+		require.Equal(t, "member.transfer", params.CallSite)
+		require.Equal(t, "member", params.Reference)
+		require.Equal(t, "c2VlZA==", params.Seed)
+		require.Equal(t, map[string]interface{}{"amount": "10", "toMemberReference": "recipient"}, params.CallParams)
+		return map[string]interface{}{"fee": "1"}, nil
+	})
+	defer api.server.Close()
+
+	response, err := newTestSDK(api.server.URL).MemberTransfer(
+		ctx, newTestMember(t), TransferRequest{Amount: "10", ToMemberReference: "recipient"},
+	)
+	require.NoError(t, err)
+	require.Equal(t, "1", response.Fee)
+	require.Equal(t, "trace", response.TraceID)
+}
+
+func TestSDK_Retries(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	m := newTestMember(t)
+
+	t.Run("fails over to next url", func(t *testing.T) {
+		api := newTestAPI(t, func(params requester.Params) (interface{}, *requester.Error) {
+			return "node_ref", nil
+		})
+		defer api.server.Close()
+		down := httptest.NewServer(http.NotFoundHandler())
+		down.Close()
+
+		sdk := newTestSDK(api.server.URL, down.URL)
+		sdk.rootMember, _ = requester.CreateUserConfig(m.Reference, m.PrivateKey, m.PublicKey)
+
+		response, err := sdk.GetNodeRef(ctx, GetNodeRefRequest{PublicKey: "key"})
+		require.NoError(t, err)
+		require.Equal(t, "node_ref", response.Reference)
+		require.Equal(t, int32(1), api.calls)
+	})
+
+	t.Run("retries expired seed", func(t *testing.T) {
+		api := newTestAPI(t, nil)
+		api.call = func(params requester.Params) (interface{}, *requester.Error) {
+			if api.calls == 1 {
+				return nil, &requester.Error{Message: requester.IncorrectSeedMessage}
+			}
+			return map[string]interface{}{"reference": "ref"}, nil
+		}
+		defer api.server.Close()
+
+		response, err := newTestSDK(api.server.URL).MemberCreate(ctx, m)
+		require.NoError(t, err)
+		require.Equal(t, "ref", response.Reference)
+		require.Equal(t, int32(2), api.seeds)
+		require.Equal(t, int32(2), api.calls)
+	})
+
+	t.Run("doesn't retry contract error", func(t *testing.T) {
+		api := newTestAPI(t, func(params requester.Params) (interface{}, *requester.Error) {
+			return nil, &requester.Error{Message: "not enough balance"}
+		})
+		defer api.server.Close()
+
+		_, err := newTestSDK(api.server.URL).DepositTransfer(ctx, m, DepositTransferRequest{Amount: "1", EthTxHash: "hash"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not enough balance")
+		require.Equal(t, int32(1), api.calls)
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		api := newTestAPI(t, func(params requester.Params) (interface{}, *requester.Error) {
+			return nil, &requester.Error{Message: requester.IncorrectSeedMessage}
+		})
+		defer api.server.Close()
+
+		sdk := newTestSDK(api.server.URL)
+		require.NoError(t, sdk.SetMaxRetries(1))
+		_, err := sdk.MemberGet(ctx, m)
+		require.Error(t, err)
+		require.Equal(t, int32(2), api.calls)
+	})
+
+	t.Run("stops on canceled context", func(t *testing.T) {
+		api := newTestAPI(t, func(params requester.Params) (interface{}, *requester.Error) {
+			return nil, nil
+		})
+		defer api.server.Close()
+
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := newTestSDK(api.server.URL).MemberMigrationCreate(canceled, m)
+		require.Error(t, err)
+		require.Equal(t, context.Canceled, errors.Cause(err))
+		require.Equal(t, int32(0), api.seeds)
+	})
+}
+
+func TestSDK_RequestStatus(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	api := newTestAPI(t, nil)
+	defer api.server.Close()
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	response, err := newTestSDK(api.server.URL, down.URL).RequestStatus(
+		ctx, requester.RequestStatusParams{RequestReference: "request", ObjectReference: "object"},
+	)
+	require.NoError(t, err)
+	require.Equal(t, requester.RequestStatusPending, response.Status)
+}
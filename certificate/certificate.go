@@ -215,6 +215,11 @@ func (cert *Certificate) GetMajorityRule() int {
 	return cert.MajorityRule
 }
 
+// GetPulsarPublicKeys returns keys of pulsars
+func (cert *Certificate) GetPulsarPublicKeys() []crypto.PublicKey {
+	return cert.pulsarPublicKey
+}
+
 // Dump returns all info about certificate in json format
 func (cert *Certificate) Dump() (string, error) {
 	result, err := json.MarshalIndent(cert, "", "    ")
@@ -38,11 +38,21 @@ func (m *CertificateManager) GetCertificate() insolar.Certificate {
 	return m.certificate
 }
 
-// VerifyAuthorizationCertificate verifies certificate from some node
-func VerifyAuthorizationCertificate(cs insolar.CryptographyService, discoveryNodes []insolar.DiscoveryNode, authCert insolar.AuthorizationCertificate) (bool, error) {
+// VerifyAuthorizationCertificate verifies certificate from some node. Certificates with revoked or rotated keys are rejected.
+func VerifyAuthorizationCertificate(
+	cs insolar.CryptographyService,
+	discoveryNodes []insolar.DiscoveryNode,
+	revocations *RevocationList,
+	authCert insolar.AuthorizationCertificate,
+) (bool, error) {
 	if len(discoveryNodes) != len(authCert.GetDiscoverySigns()) {
 		return false, nil
 	}
+	if ref := authCert.GetNodeRef(); ref != nil {
+		if err := revocations.Check(*ref, authCert.GetPublicKey()); err != nil {
+			return false, err
+		}
+	}
 	data := authCert.SerializeNodePart()
 	for _, node := range discoveryNodes {
 		sign := authCert.GetDiscoverySigns()[*node.GetNodeRef()]
@@ -82,16 +82,16 @@ func TestSignAndVerifyCertificate(t *testing.T) {
 
 	otherDiscovery, otherDiscoveryCS := newDiscovery()
 
-	valid, err := VerifyAuthorizationCertificate(otherDiscoveryCS, []insolar.DiscoveryNode{discovery}, cert2)
+	valid, err := VerifyAuthorizationCertificate(otherDiscoveryCS, []insolar.DiscoveryNode{discovery}, nil, cert2)
 	require.NoError(t, err)
 	require.True(t, valid)
 
 	// bad cases
-	valid, err = VerifyAuthorizationCertificate(otherDiscoveryCS, []insolar.DiscoveryNode{discovery, otherDiscovery}, cert2)
+	valid, err = VerifyAuthorizationCertificate(otherDiscoveryCS, []insolar.DiscoveryNode{discovery, otherDiscovery}, nil, cert2)
 	require.NoError(t, err)
 	require.False(t, valid)
 
-	valid, err = VerifyAuthorizationCertificate(otherDiscoveryCS, []insolar.DiscoveryNode{otherDiscovery}, cert2)
+	valid, err = VerifyAuthorizationCertificate(otherDiscoveryCS, []insolar.DiscoveryNode{otherDiscovery}, nil, cert2)
 	require.NoError(t, err)
 	require.False(t, valid)
 }
@@ -0,0 +1,287 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package certificate
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/platformpolicy"
+)
+
+// Revocation revokes key of compromised node, empty PublicKey revokes all keys of node.
+// Revocation has to be signed by majority of discovery nodes.
+type Revocation struct {
+	Reference string `json:"reference"`
+	PublicKey string `json:"public_key,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	// DiscoverySigns are signs of revocation by discovery nodes, by base58 reference of discovery node.
+	DiscoverySigns map[string][]byte `json:"discovery_signs"`
+}
+
+// SerializeRevocationPart returns data of revocation that is signed by discovery nodes.
+func (r *Revocation) SerializeRevocationPart() []byte {
+	return []byte("revoke" + r.Reference + r.PublicKey + r.Reason)
+}
+
+// Sign adds sign of revocation made by discovery node.
+func (r *Revocation) Sign(signer insolar.Signer, discoveryRef insolar.Reference) error {
+	sign, err := signer.Sign(r.SerializeRevocationPart())
+	if err != nil {
+		return errors.Wrap(err, "[ Revocation::Sign ] failed to sign revocation")
+	}
+	if r.DiscoverySigns == nil {
+		r.DiscoverySigns = make(map[string][]byte)
+	}
+	r.DiscoverySigns[discoveryRef.String()] = sign.Bytes()
+	return nil
+}
+
+// VerifyRevocation checks that revocation is signed by majority of discovery nodes.
+func VerifyRevocation(cs insolar.CryptographyService, cert insolar.Certificate, r *Revocation) error {
+	if _, err := insolar.NewReferenceFromBase58(r.Reference); err != nil {
+		return errors.Wrap(err, "invalid node reference")
+	}
+	err := verifyDiscoverySigns(cs, cert.GetDiscoveryNodes(), cert.GetMajorityRule(), r.DiscoverySigns, r.SerializeRevocationPart())
+	if err != nil {
+		return errors.Wrap(err, "invalid signs of revocation")
+	}
+	return nil
+}
+
+// Updater announces rotations and revocations to network.
+type Updater interface {
+	Rotate(ctx context.Context, rotation Rotation) error
+	Revoke(ctx context.Context, revocation Revocation) error
+	RevocationList() *RevocationList
+}
+
+type revocationListData struct {
+	Rotations   []Rotation   `json:"rotations"`
+	Revocations []Revocation `json:"revocations"`
+}
+
+// RevocationList keeps applied rotations and revocations. It's consulted when certificates of other nodes are verified.
+// Nil RevocationList has no revoked keys.
+type RevocationList struct {
+	lock sync.RWMutex
+	path string
+	data revocationListData
+
+	// revoked keys by node reference, empty key means that all keys of node are revoked
+	revoked map[string]map[string]struct{}
+	// current keys of rotated nodes
+	current map[string]string
+}
+
+// NewRevocationList creates revocation list. It's persisted in file by path, if path is not empty.
+func NewRevocationList(path string) (*RevocationList, error) {
+	rl := &RevocationList{
+		path:    path,
+		revoked: make(map[string]map[string]struct{}),
+		current: make(map[string]string),
+	}
+	if path == "" {
+		return rl, nil
+	}
+
+	raw, err := ioutil.ReadFile(filepath.Clean(path))
+	if os.IsNotExist(err) {
+		return rl, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "[ NewRevocationList ] failed to read revocation list from: %s", path)
+	}
+	data := revocationListData{}
+	err = json.Unmarshal(raw, &data)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NewRevocationList ] failed to parse revocation list json")
+	}
+	for _, r := range data.Rotations {
+		if _, err := rl.addRotation(r); err != nil {
+			return nil, errors.Wrap(err, "[ NewRevocationList ] bad rotation")
+		}
+	}
+	for _, r := range data.Revocations {
+		if _, err := rl.addRevocation(r); err != nil {
+			return nil, errors.Wrap(err, "[ NewRevocationList ] bad revocation")
+		}
+	}
+	return rl, nil
+}
+
+// Check returns error if key of node is revoked or replaced by rotation.
+func (rl *RevocationList) Check(ref insolar.Reference, publicKey crypto.PublicKey) error {
+	if rl == nil {
+		return nil
+	}
+	key, err := platformpolicy.NewKeyProcessor().ExportPublicKeyPEM(publicKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to export public key")
+	}
+
+	rl.lock.RLock()
+	defer rl.lock.RUnlock()
+	return rl.check(ref.String(), string(key))
+}
+
+func (rl *RevocationList) check(ref string, key string) error {
+	keys := rl.revoked[ref]
+	if _, ok := keys[""]; ok {
+		return errors.Errorf("node %s is revoked", ref)
+	}
+	if _, ok := keys[key]; ok {
+		return errors.Errorf("key of node %s is revoked", ref)
+	}
+	if current, ok := rl.current[ref]; ok && current != key {
+		return errors.Errorf("key of node %s is rotated", ref)
+	}
+	return nil
+}
+
+// AddRotation applies rotation. It returns false if rotation is already applied.
+func (rl *RevocationList) AddRotation(r Rotation) (bool, error) {
+	rl.lock.Lock()
+	defer rl.lock.Unlock()
+
+	added, err := rl.addRotation(r)
+	if err != nil || !added {
+		return false, err
+	}
+	return true, rl.save()
+}
+
+func (rl *RevocationList) addRotation(r Rotation) (bool, error) {
+	oldKey, err := normalizeKey(r.OldPublicKey)
+	if err != nil {
+		return false, errors.Wrap(err, "bad old key")
+	}
+	newKey, err := normalizeKey(r.PublicKey)
+	if err != nil {
+		return false, errors.Wrap(err, "bad new key")
+	}
+
+	if rl.current[r.Reference] == newKey {
+		return false, nil
+	}
+	if err := rl.check(r.Reference, oldKey); err != nil {
+		return false, errors.Wrap(err, "old key can't be rotated")
+	}
+	if _, revoked := rl.revoked[r.Reference][newKey]; revoked {
+		return false, errors.Errorf("new key of node %s is revoked", r.Reference)
+	}
+
+	rl.revoke(r.Reference, oldKey)
+	rl.current[r.Reference] = newKey
+	rl.data.Rotations = append(rl.data.Rotations, r)
+	return true, nil
+}
+
+// AddRevocation applies revocation. It returns false if key is already revoked.
+func (rl *RevocationList) AddRevocation(r Revocation) (bool, error) {
+	rl.lock.Lock()
+	defer rl.lock.Unlock()
+
+	added, err := rl.addRevocation(r)
+	if err != nil || !added {
+		return false, err
+	}
+	return true, rl.save()
+}
+
+func (rl *RevocationList) addRevocation(r Revocation) (bool, error) {
+	key := ""
+	if r.PublicKey != "" {
+		var err error
+		key, err = normalizeKey(r.PublicKey)
+		if err != nil {
+			return false, errors.Wrap(err, "bad key")
+		}
+	}
+
+	if _, ok := rl.revoked[r.Reference][key]; ok {
+		return false, nil
+	}
+	rl.revoke(r.Reference, key)
+	rl.data.Revocations = append(rl.data.Revocations, r)
+	return true, nil
+}
+
+func (rl *RevocationList) revoke(ref string, key string) {
+	if _, ok := rl.revoked[ref]; !ok {
+		rl.revoked[ref] = make(map[string]struct{})
+	}
+	rl.revoked[ref][key] = struct{}{}
+}
+
+// Rotations returns applied rotations in order they were applied.
+func (rl *RevocationList) Rotations() []Rotation {
+	if rl == nil {
+		return nil
+	}
+	rl.lock.RLock()
+	defer rl.lock.RUnlock()
+	return append([]Rotation(nil), rl.data.Rotations...)
+}
+
+// Revocations returns applied revocations in order they were applied.
+func (rl *RevocationList) Revocations() []Revocation {
+	if rl == nil {
+		return nil
+	}
+	rl.lock.RLock()
+	defer rl.lock.RUnlock()
+	return append([]Revocation(nil), rl.data.Revocations...)
+}
+
+func (rl *RevocationList) save() error {
+	if rl.path == "" {
+		return nil
+	}
+	raw, err := json.MarshalIndent(rl.data, "", "    ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal revocation list")
+	}
+	tmp := rl.path + ".tmp"
+	err = ioutil.WriteFile(tmp, raw, 0600)
+	if err != nil {
+		return errors.Wrap(err, "failed to write revocation list")
+	}
+	return errors.Wrap(os.Rename(tmp, rl.path), "failed to write revocation list")
+}
+
+// normalizeKey brings PEM of public key to the form it's exported in.
+func normalizeKey(key string) (string, error) {
+	kp := platformpolicy.NewKeyProcessor()
+	publicKey, err := kp.ImportPublicKeyPEM([]byte(key))
+	if err != nil {
+		return "", err
+	}
+	pem, err := kp.ExportPublicKeyPEM(publicKey)
+	if err != nil {
+		return "", err
+	}
+	return string(pem), nil
+}
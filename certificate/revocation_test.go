@@ -0,0 +1,223 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package certificate
+
+import (
+	"crypto"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/gen"
+	"github.com/insolar/insolar/platformpolicy"
+)
+
+type testNode struct {
+	cs  insolar.CryptographyService
+	key string
+}
+
+func newTestNode(t *testing.T) testNode {
+	kp := platformpolicy.NewKeyProcessor()
+	privKey, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+	pubKey, err := kp.ExportPublicKeyPEM(kp.ExtractPublicKey(privKey))
+	require.NoError(t, err)
+	return testNode{cs: cryptography.NewKeyBoundCryptographyService(privKey), key: string(pubKey)}
+}
+
+func newTestNetwork(discoveryCount int) (*Certificate, []insolar.CryptographyService) {
+	cert := &Certificate{MajorityRule: discoveryCount/2 + 1}
+	var signers []insolar.CryptographyService
+	for i := 0; i < discoveryCount; i++ {
+		discovery, cs := newDiscovery()
+		cert.BootstrapNodes = append(cert.BootstrapNodes, *discovery)
+		signers = append(signers, cs)
+	}
+	return cert, signers
+}
+
+func TestRotation(t *testing.T) {
+	cert, signers := newTestNetwork(3)
+	oldKey, newKey := newTestNode(t), newTestNode(t)
+
+	nodeCert := &Certificate{
+		AuthorizationCertificate: AuthorizationCertificate{
+			PublicKey: oldKey.key,
+			Reference: gen.Reference().String(),
+			Role:      insolar.StaticRoleVirtual.String(),
+		},
+		BootstrapNodes: cert.BootstrapNodes,
+	}
+	rotation := &Rotation{
+		Reference:    nodeCert.Reference,
+		Role:         nodeCert.Role,
+		OldPublicKey: oldKey.key,
+		PublicKey:    newKey.key,
+	}
+
+	for i, cs := range signers[:2] {
+		require.NoError(t, rotation.Sign(cs, *cert.BootstrapNodes[i].GetNodeRef()))
+	}
+	err := VerifyRotation(newKey.cs, cert, rotation)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "signed by 2 discovery nodes, 3 required")
+
+	require.NoError(t, rotation.Sign(signers[2], *cert.BootstrapNodes[2].GetNodeRef()))
+	require.NoError(t, VerifyRotation(newKey.cs, cert, rotation))
+
+	t.Run("new certificate", func(t *testing.T) {
+		rotated, err := RotateCertificate(nodeCert, rotation)
+		require.NoError(t, err)
+		require.Equal(t, newKey.key, rotated.PublicKey)
+
+		data, err := rotated.Dump()
+		require.NoError(t, err)
+		pubKey, err := newKey.cs.GetPublicKey()
+		require.NoError(t, err)
+		authCert, err := ReadCertificateFromReader(pubKey, platformpolicy.NewKeyProcessor(), strings.NewReader(data))
+		require.NoError(t, err)
+
+		valid, err := VerifyAuthorizationCertificate(newKey.cs, cert.GetDiscoveryNodes(), nil, authCert)
+		require.NoError(t, err)
+		require.True(t, valid)
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		tampered := *rotation
+		tampered.OldPublicKey = newTestNode(t).key
+		require.Error(t, VerifyRotation(newKey.cs, cert, &tampered))
+	})
+
+	t.Run("discovery node", func(t *testing.T) {
+		discovery := *rotation
+		discovery.Reference = cert.BootstrapNodes[0].NodeRef
+		err := VerifyRotation(newKey.cs, cert, &discovery)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "key of discovery node can't be rotated")
+	})
+}
+
+func TestRevocation(t *testing.T) {
+	cert, signers := newTestNetwork(3)
+	node := newTestNode(t)
+
+	revocation := &Revocation{Reference: gen.Reference().String(), PublicKey: node.key, Reason: "compromised"}
+	require.NoError(t, revocation.Sign(signers[0], *cert.BootstrapNodes[0].GetNodeRef()))
+	require.Error(t, VerifyRevocation(node.cs, cert, revocation))
+
+	require.NoError(t, revocation.Sign(signers[1], *cert.BootstrapNodes[1].GetNodeRef()))
+	require.NoError(t, VerifyRevocation(node.cs, cert, revocation))
+
+	revocation.Reason = "other reason"
+	require.Error(t, VerifyRevocation(node.cs, cert, revocation))
+}
+
+func TestRevocationList(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "revocations-")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+	path := filepath.Join(tmpDir, "revocations.json")
+
+	rl, err := NewRevocationList(path)
+	require.NoError(t, err)
+
+	ref := gen.Reference()
+	keyA, keyB, keyC := newTestNode(t), newTestNode(t), newTestNode(t)
+	publicKey := func(n testNode) crypto.PublicKey {
+		pk, err := n.cs.GetPublicKey()
+		require.NoError(t, err)
+		return pk
+	}
+
+	require.NoError(t, rl.Check(ref, publicKey(keyA)))
+
+	rotation := Rotation{Reference: ref.String(), OldPublicKey: keyA.key, PublicKey: keyB.key}
+	added, err := rl.AddRotation(rotation)
+	require.NoError(t, err)
+	require.True(t, added)
+
+	added, err = rl.AddRotation(rotation)
+	require.NoError(t, err)
+	require.False(t, added)
+
+	require.Error(t, rl.Check(ref, publicKey(keyA)))
+	require.NoError(t, rl.Check(ref, publicKey(keyB)))
+	require.Error(t, rl.Check(ref, publicKey(keyC)))
+
+	// old key can't come back
+	_, err = rl.AddRotation(Rotation{Reference: ref.String(), OldPublicKey: keyB.key, PublicKey: keyA.key})
+	require.Error(t, err)
+
+	added, err = rl.AddRevocation(Revocation{Reference: ref.String()})
+	require.NoError(t, err)
+	require.True(t, added)
+	require.Error(t, rl.Check(ref, publicKey(keyB)))
+
+	_, err = rl.AddRotation(Rotation{Reference: ref.String(), OldPublicKey: keyB.key, PublicKey: keyC.key})
+	require.Error(t, err)
+
+	t.Run("persisted", func(t *testing.T) {
+		loaded, err := NewRevocationList(path)
+		require.NoError(t, err)
+		require.Equal(t, rl.Rotations(), loaded.Rotations())
+		require.Equal(t, rl.Revocations(), loaded.Revocations())
+		require.Error(t, loaded.Check(ref, publicKey(keyB)))
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var empty *RevocationList
+		require.NoError(t, empty.Check(ref, publicKey(keyB)))
+		require.Empty(t, empty.Rotations())
+	})
+}
+
+func TestVerifyAuthorizationCertificate_Revoked(t *testing.T) {
+	cert, signers := newTestNetwork(1)
+	node := newTestNode(t)
+
+	authCert := &AuthorizationCertificate{
+		PublicKey: node.key,
+		Reference: gen.Reference().String(),
+		Role:      insolar.StaticRoleLightMaterial.String(),
+	}
+	sign, err := SignCert(signers[0], authCert.PublicKey, authCert.Role, authCert.Reference)
+	require.NoError(t, err)
+	authCert.DiscoverySigns = map[insolar.Reference][]byte{*cert.BootstrapNodes[0].GetNodeRef(): sign.Bytes()}
+	authCert.nodePublicKey, err = node.cs.GetPublicKey()
+	require.NoError(t, err)
+
+	rl, err := NewRevocationList("")
+	require.NoError(t, err)
+
+	valid, err := VerifyAuthorizationCertificate(node.cs, cert.GetDiscoveryNodes(), rl, authCert)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	_, err = rl.AddRevocation(Revocation{Reference: authCert.Reference, PublicKey: node.key})
+	require.NoError(t, err)
+
+	valid, err = VerifyAuthorizationCertificate(node.cs, cert.GetDiscoveryNodes(), rl, authCert)
+	require.Error(t, err)
+	require.False(t, valid)
+}
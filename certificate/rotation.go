@@ -0,0 +1,150 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package certificate
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/insolar"
+)
+
+// Rotation replaces key of node without new genesis. Old key of node is revoked when rotation is applied.
+// Rotation has to be signed by all discovery nodes, as certificate of node is.
+type Rotation struct {
+	Reference    string `json:"reference"`
+	Role         string `json:"role"`
+	OldPublicKey string `json:"old_public_key"`
+	PublicKey    string `json:"public_key"`
+	// DiscoverySigns are signs of rotation by discovery nodes, by base58 reference of discovery node.
+	DiscoverySigns map[string][]byte `json:"discovery_signs"`
+	// CertificateSigns are signs of new certificate of node by discovery nodes.
+	CertificateSigns map[string][]byte `json:"certificate_signs"`
+}
+
+// SerializeRotationPart returns data of rotation that is signed by discovery nodes.
+func (r *Rotation) SerializeRotationPart() []byte {
+	return []byte("rotate" + r.Reference + r.Role + r.OldPublicKey + r.PublicKey)
+}
+
+// Sign adds signs of rotation and of new certificate made by discovery node.
+func (r *Rotation) Sign(signer insolar.Signer, discoveryRef insolar.Reference) error {
+	sign, err := signer.Sign(r.SerializeRotationPart())
+	if err != nil {
+		return errors.Wrap(err, "[ Rotation::Sign ] failed to sign rotation")
+	}
+	certSign, err := SignCert(signer, r.PublicKey, r.Role, r.Reference)
+	if err != nil {
+		return errors.Wrap(err, "[ Rotation::Sign ] failed to sign certificate")
+	}
+
+	if r.DiscoverySigns == nil {
+		r.DiscoverySigns = make(map[string][]byte)
+	}
+	if r.CertificateSigns == nil {
+		r.CertificateSigns = make(map[string][]byte)
+	}
+	r.DiscoverySigns[discoveryRef.String()] = sign.Bytes()
+	r.CertificateSigns[discoveryRef.String()] = certSign.Bytes()
+	return nil
+}
+
+// VerifyRotation checks that rotation and new certificate of node are signed by all discovery nodes.
+// Keys of discovery nodes are part of certificates of all nodes, so they can't be rotated.
+func VerifyRotation(cs insolar.CryptographyService, cert insolar.Certificate, r *Rotation) error {
+	if r.PublicKey == r.OldPublicKey {
+		return errors.New("new key of node is the same as old one")
+	}
+	if _, err := insolar.NewReferenceFromBase58(r.Reference); err != nil {
+		return errors.Wrap(err, "invalid node reference")
+	}
+	if insolar.GetStaticRoleFromString(r.Role) == insolar.StaticRoleUnknown {
+		return errors.Errorf("invalid node role %s", r.Role)
+	}
+
+	discoveryNodes := cert.GetDiscoveryNodes()
+	for _, node := range discoveryNodes {
+		if node.GetNodeRef().String() == r.Reference {
+			return errors.New("key of discovery node can't be rotated")
+		}
+	}
+
+	err := verifyDiscoverySigns(cs, discoveryNodes, len(discoveryNodes), r.DiscoverySigns, r.SerializeRotationPart())
+	if err != nil {
+		return errors.Wrap(err, "invalid signs of rotation")
+	}
+	authCert := AuthorizationCertificate{PublicKey: r.PublicKey, Reference: r.Reference, Role: r.Role}
+	err = verifyDiscoverySigns(cs, discoveryNodes, len(discoveryNodes), r.CertificateSigns, authCert.SerializeNodePart())
+	if err != nil {
+		return errors.Wrap(err, "invalid signs of certificate")
+	}
+	return nil
+}
+
+// RotateCertificate returns certificate of node with new key and discovery signs from rotation.
+func RotateCertificate(cert *Certificate, r *Rotation) (*Certificate, error) {
+	if cert.Reference != r.Reference || cert.Role != r.Role {
+		return nil, errors.New("[ RotateCertificate ] rotation is made for another node")
+	}
+	if cert.PublicKey != r.OldPublicKey {
+		return nil, errors.New("[ RotateCertificate ] rotation is made for another key of node")
+	}
+
+	newCert, err := NewUnsignedCertificate(cert, r.PublicKey, r.Role, r.Reference)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ RotateCertificate ]")
+	}
+	rotated := newCert.(*Certificate)
+	for i := range rotated.BootstrapNodes {
+		node := &rotated.BootstrapNodes[i]
+		sign, ok := r.CertificateSigns[node.NodeRef]
+		if !ok {
+			return nil, errors.Errorf("[ RotateCertificate ] no sign of discovery node %s", node.NodeRef)
+		}
+		node.NodeSign = sign
+	}
+	return rotated, nil
+}
+
+// verifyDiscoverySigns checks that data is signed by at least required number of discovery nodes.
+func verifyDiscoverySigns(
+	cs insolar.CryptographyService,
+	discoveryNodes []insolar.DiscoveryNode,
+	required int,
+	signs map[string][]byte,
+	data []byte,
+) error {
+	if required < 1 {
+		required = 1
+	}
+
+	valid := 0
+	for _, node := range discoveryNodes {
+		ref := node.GetNodeRef().String()
+		sign, ok := signs[ref]
+		if !ok {
+			continue
+		}
+		if !cs.Verify(node.GetPublicKey(), insolar.SignatureFromBytes(sign), data) {
+			return errors.Errorf("wrong sign of discovery node %s", ref)
+		}
+		valid++
+	}
+	if valid < required {
+		return errors.Errorf("signed by %d discovery nodes, %d required", valid, required)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/store"
+	"github.com/insolar/insolar/ledger/heavy/executor"
+)
+
+var (
+	backupDir       string
+	targetDBPath    string
+	backend         string
+	pulse           uint32
+	numberOfWorkers int
+	metaInfoFile    string
+	backupFile      string
+	dirNameTemplate string
+	verifyOnly      bool
+	help            bool
+)
+
+func usage() {
+	pflag.Usage()
+	os.Exit(0)
+}
+
+func parseInputParams() {
+	defaults := configuration.NewLedger().Backup
+
+	pflag.StringVarP(
+		&backupDir, "bkp-dir", "b", "", "directory with incremental backups (required)")
+	pflag.StringVarP(
+		&targetDBPath, "target-db", "t", "", "directory where db will be restored to, must be empty (required)")
+	pflag.StringVar(
+		&backend, "backend", configuration.NewLedger().Storage.Backend,
+		"storage backend of the backups and restored db: "+configuration.StorageBackendBadger+" or "+configuration.StorageBackendSkipList)
+	pflag.Uint32VarP(
+		&pulse, "pulse", "p", 0, "pulse to restore db to (default last backuped pulse)")
+	pflag.IntVarP(
+		&numberOfWorkers, "workers-num", "w", 1, "number of workers to read backup file")
+	pflag.StringVar(
+		&metaInfoFile, "meta-file", defaults.MetaInfoFile, "name of backup meta info file")
+	pflag.StringVar(
+		&backupFile, "bkp-file", defaults.BackupFile, "name of incremental backup file")
+	pflag.StringVar(
+		&dirNameTemplate, "dir-template", defaults.DirNameTemplate, "template of backup directory names")
+	pflag.BoolVar(
+		&verifyOnly, "verify-only", false, "only verify backups, don't restore")
+	pflag.BoolVarP(
+		&help, "help", "h", false, "show this help")
+
+	pflag.Parse()
+
+	if help {
+		usage()
+	}
+
+	if len(backupDir) == 0 || (len(targetDBPath) == 0 && !verifyOnly) {
+		println("bkp-dir and target-db are required\n")
+		usage()
+	}
+}
+
+func printError(err error, message string) {
+	println(errors.Wrap(err, "ERROR "+message).Error())
+}
+
+func checkTargetIsEmpty(dir string) error {
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "can't read %s", dir)
+	}
+	if len(files) != 0 {
+		return errors.Errorf("%s is not empty", dir)
+	}
+	return nil
+}
+
+func restore(ctx context.Context, chain []executor.BackupIncrement, cfg configuration.Backup) error {
+	db, err := store.NewEngine(configuration.Storage{DataDirectory: targetDBPath, Backend: backend})
+	if err != nil {
+		return errors.Wrap(err, "failed to open db")
+	}
+
+	err = executor.RestoreBackup(ctx, db, chain, cfg, numberOfWorkers)
+	stopErr := db.Stop(ctx)
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(stopErr, "failed to close db")
+}
+
+func main() {
+	parseInputParams()
+
+	ctx := context.Background()
+	cfg := configuration.Backup{
+		TargetDirectory: backupDir,
+		MetaInfoFile:    metaInfoFile,
+		BackupFile:      backupFile,
+		DirNameTemplate: dirNameTemplate,
+	}
+
+	chain, err := executor.FindBackupChain(cfg, insolar.PulseNumber(pulse))
+	if err != nil {
+		printError(err, "failed to find backups")
+		os.Exit(1)
+	}
+	for _, incr := range chain {
+		println("found backup of pulse", uint32(incr.Info.Pulse), "in", incr.Dir)
+	}
+
+	err = executor.VerifyBackupChain(chain, cfg)
+	if err != nil {
+		printError(err, "failed to verify backups")
+		os.Exit(1)
+	}
+	println("backups are verified")
+	if verifyOnly {
+		return
+	}
+
+	err = checkTargetIsEmpty(targetDBPath)
+	if err != nil {
+		printError(err, "wrong target-db")
+		os.Exit(1)
+	}
+
+	err = restore(ctx, chain, cfg)
+	if err != nil {
+		printError(err, "failed to restore db")
+		os.Exit(1)
+	}
+
+	println()
+	println("successfully restored", targetDBPath, "to pulse", uint32(chain[len(chain)-1].Info.Pulse))
+}
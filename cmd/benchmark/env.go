@@ -0,0 +1,194 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/api/sdk"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/backoff"
+)
+
+const migrationAddressesBatch = 100
+
+// Env is an environment shared by scenarios: SDK, output and a pool of members.
+type Env struct {
+	SDK *sdk.SDK
+	Out io.Writer
+
+	// prefix makes migration addresses and transaction hashes of the run unique.
+	prefix    string
+	addresses int64
+	retries   int32
+
+	lock    sync.Mutex
+	members []*sdk.Member
+	loaded  bool
+}
+
+func NewEnv(insSDK *sdk.SDK, out io.Writer) *Env {
+	return &Env{
+		SDK:    insSDK,
+		Out:    out,
+		prefix: fmt.Sprintf("bench_%d", time.Now().UnixNano()),
+	}
+}
+
+// PendingRetries returns a number of retries made while preparing scenarios.
+func (e *Env) PendingRetries() int {
+	return int(atomic.LoadInt32(&e.retries))
+}
+
+// UniqueID returns identifier unique within all benchmark runs, e.g. for a transaction hash.
+func (e *Env) UniqueID(kind string, n int) string {
+	return fmt.Sprintf("%s_%s_%d", e.prefix, kind, n)
+}
+
+// Members returns the first count members of the pool, the pool is loaded from file or extended with new members
+// if needed. Scenarios share members.
+func (e *Env) Members(count int) ([]*sdk.Member, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if useMembersFromFile && !e.loaded {
+		members, err := loadMembers(count)
+		if err != nil {
+			return nil, errors.Wrap(err, "error while loading members")
+		}
+		e.members = members
+		e.loaded = true
+	}
+
+	if missing := count - len(e.members); missing > 0 {
+		if err := e.AddMigrationAddresses(missing); err != nil {
+			return nil, err
+		}
+		start := time.Now()
+		members, err := e.createMembers(missing)
+		if err != nil {
+			return nil, err
+		}
+		creationTime := time.Since(start)
+		fmt.Printf("Members were created in %s\n", creationTime)
+		fmt.Printf("Average creation of member time - %s\n", time.Duration(int64(creationTime)/int64(missing)))
+		e.members = append(e.members, members...)
+
+		if saveMembersToFile {
+			if err := saveMembers(e.members); err != nil {
+				return nil, errors.Wrap(err, "save member done with error")
+			}
+		}
+	}
+	return e.members[:count], nil
+}
+
+func (e *Env) createMembers(count int) ([]*sdk.Member, error) {
+	var members []*sdk.Member
+	for i := 0; i < count; i++ {
+		var member *sdk.Member
+		_, err := e.retryPending(func() (string, error) {
+			var traceID string
+			var err error
+			member, traceID, err = e.SDK.CreateMember()
+			if err != nil {
+				fmt.Printf("Retry to create member. TraceID: %s Error is: %s\n", traceID, err.Error())
+			}
+			return traceID, err
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "couldn't create member after retries: %d", backoffAttemptsCount)
+		}
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+// AddMigrationAddresses adds count unique migration addresses, each created member takes one.
+func (e *Env) AddMigrationAddresses(count int) error {
+	for count > 0 {
+		batch := count
+		if batch > migrationAddressesBatch {
+			batch = migrationAddressesBatch
+		}
+		addresses := make([]string, 0, batch)
+		for i := 0; i < batch; i++ {
+			n := atomic.AddInt64(&e.addresses, 1)
+			addresses = append(addresses, e.UniqueID("burn_address", int(n)))
+		}
+		_, err := e.retryPending(func() (string, error) {
+			traceID, err := e.SDK.AddMigrationAddresses(addresses)
+			if err != nil {
+				fmt.Printf("Retry to add burn address. TraceID: %s Error is: %s\n", traceID, err.Error())
+			}
+			return traceID, err
+		})
+		if err != nil {
+			return errors.Wrapf(err, "couldn't add burn address after retries: %d", backoffAttemptsCount)
+		}
+		count -= batch
+	}
+	return nil
+}
+
+// retryPending retries preparation call on any error with backoff.
+func (e *Env) retryPending(call func() (string, error)) (string, error) {
+	var traceID string
+	var err error
+	bof := backoff.Backoff{Min: 1 * time.Second, Max: 10 * time.Second}
+	for bof.Attempt() < backoffAttemptsCount {
+		traceID, err = call()
+		if err == nil {
+			return traceID, nil
+		}
+		if strings.Contains(err.Error(), insolar.ErrTooManyPendingRequests.Error()) {
+			atomic.AddInt32(&e.retries, 1)
+		}
+		time.Sleep(bof.Duration())
+	}
+	return traceID, err
+}
+
+// retryOperation retries scenario operation while network replies with too many pending requests.
+// It returns a number of retries made.
+func retryOperation(ctx context.Context, call func() (string, error)) (string, int, error) {
+	var traceID string
+	var err error
+	retries := 0
+	bof := backoff.Backoff{Min: 500 * time.Millisecond, Max: 20 * time.Second}
+	for bof.Attempt() < backoffAttemptsCount {
+		traceID, err = call()
+		if err == nil || !strings.Contains(err.Error(), insolar.ErrTooManyPendingRequests.Error()) {
+			break
+		}
+		retries++
+		select {
+		case <-ctx.Done():
+			return traceID, retries, ctx.Err()
+		case <-time.After(bof.Duration()):
+		}
+	}
+	return traceID, retries, err
+}
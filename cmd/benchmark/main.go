@@ -27,7 +27,6 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
-	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -65,6 +64,10 @@ var (
 	useMembersFromFile bool
 	noCheckBalance     bool
 	discoveryNodesLogs string
+	scenarioName       string
+	loadConfig         string
+	reportFormat       string
+	reportPath         string
 )
 
 func parseInputParams() {
@@ -81,6 +84,10 @@ func parseInputParams() {
 	pflag.StringVarP(&memberFilesDir, "members-dir", "", defaultMemberFileDir, "dir for saving memebers data")
 	pflag.BoolVarP(&noCheckBalance, "nocheckbalance", "b", false, "don't check balance at the end")
 	pflag.StringVarP(&discoveryNodesLogs, "discovery-nodes-logs-dir", "", defaultDiscoveryNodesLogs, "launchnet logs dir for checking errors")
+	pflag.StringVarP(&scenarioName, "scenario", "", defaultScenario, fmt.Sprintf("scenario to run by concurrent users, one of %v", scenarioNames()))
+	pflag.StringVarP(&loadConfig, "load", "", "", "path to open loop load config with mix of scenarios, overrides scenario, concurrent and repetitions")
+	pflag.StringVarP(&reportFormat, "format", "", formatText, "report format: text, json or csv")
+	pflag.StringVarP(&reportPath, "report", "", defaultStdoutPath, "report file (use - for output)")
 	pflag.Parse()
 }
 
@@ -110,61 +117,38 @@ func check(msg string, err error) {
 	}
 }
 
-func newScenarios(out io.Writer, insSDK *sdk.SDK, members []*sdk.Member, concurrent int, repetitions int, penRetries int32) scenario {
-	return &transferDifferentMembersScenario{
-		concurrent:  concurrent,
-		repetitions: repetitions,
-		name:        "TransferDifferentMembers",
-		out:         out,
-		members:     members,
-		insSDK:      insSDK,
-		penRetries:  penRetries,
-	}
-}
+func startLoad(ctx context.Context, out io.Writer, rec *Recorder, run func(ctx context.Context)) {
+	writeToOutput(out, "Start to load\n")
 
-func startScenario(ctx context.Context, s scenario) {
-	err := s.canBeStarted()
-	check(fmt.Sprintf("Scenario %s can not be started:", s.getName()), err)
-
-	writeToOutput(s.getOut(), fmt.Sprintf("Scenario %s: Start to transfer\n", s.getName()))
+	logReaderCloseChan := nodesErrorLogReader(out)
 
+	rec.Reset()
 	start := time.Now()
-	logReaderCloseChan := nodesErrorLogReader(s)
-
-	s.start(ctx)
+	run(ctx)
 	elapsed := time.Since(start)
-	writeToOutput(s.getOut(), fmt.Sprintf("Scenario %s: Transferring took %s \n", s.getName(), elapsed))
+	writeToOutput(out, fmt.Sprintf("Load took %s \n", elapsed))
 
 	close(logReaderCloseChan)
-	printResults(s)
 }
 
-func printResults(s scenario) {
-	speed := s.getOperationPerSecond()
-	writeToOutput(s.getOut(), fmt.Sprintf("Scenario %s: Speed - %f resp/s \n", s.getName(), speed))
-	writeToOutput(
-		s.getOut(),
-		fmt.Sprintf(
-			"Scenario %s: Average Request Duration - %s\n",
-			s.getName(), s.getAverageOperationDuration(),
-		),
-	)
-	s.printResult()
+func printResults(out io.Writer, rec *Recorder) {
+	err := writeReports(out, formatText, rec.Reports())
+	check("Can't write results:", err)
 }
 
-func nodesErrorLogReader(s scenario) chan struct{} {
+func nodesErrorLogReader(out io.Writer) chan struct{} {
 	closeChan := make(chan struct{})
 	wg := sync.WaitGroup{}
 
 	logs, err := getLogs(discoveryNodesLogs)
 	if err != nil {
-		writeToOutput(s.getOut(), fmt.Sprintf("Can't find node logs: %s \n", err))
+		writeToOutput(out, fmt.Sprintf("Can't find node logs: %s \n", err))
 	}
 
 	wg.Add(len(logs))
 	for _, fileName := range logs {
 		fName := fileName // be careful using loops and values in parallel code
-		go readLogs(s, &wg, fName, closeChan)
+		go readLogs(out, &wg, fName, closeChan)
 	}
 
 	wg.Wait()
@@ -186,23 +170,23 @@ func getLogs(root string) ([]string, error) {
 	return files, err
 }
 
-func readLogs(s scenario, wg *sync.WaitGroup, fileName string, closeChan chan struct{}) {
+func readLogs(out io.Writer, wg *sync.WaitGroup, fileName string, closeChan chan struct{}) {
 	defer wg.Done()
 
 	file, err := os.Open(fileName)
 	if err != nil {
-		writeToOutput(s.getOut(), fmt.Sprintln("Can't open log file ", fileName, ", error : ", err))
+		writeToOutput(out, fmt.Sprintln("Can't open log file ", fileName, ", error : ", err))
 	}
 	_, err = file.Seek(-1, io.SeekEnd)
 	if err != nil {
-		writeToOutput(s.getOut(), fmt.Sprintln("Can't seek through log file ", fileName, ", error : ", err))
+		writeToOutput(out, fmt.Sprintln("Can't seek through log file ", fileName, ", error : ", err))
 	}
 
 	// for making wg.Done()
-	go findErrorsInLog(s, fileName, file, closeChan)
+	go findErrorsInLog(out, fileName, file, closeChan)
 }
 
-func findErrorsInLog(s scenario, fName string, file io.ReadCloser, closeChan chan struct{}) {
+func findErrorsInLog(out io.Writer, fName string, file io.ReadCloser, closeChan chan struct{}) {
 	defer file.Close()
 	reader := bufio.NewReader(file)
 
@@ -212,12 +196,12 @@ func findErrorsInLog(s scenario, fName string, file io.ReadCloser, closeChan cha
 		case <-time.After(time.Millisecond):
 			line, err := reader.ReadString('\n')
 			if err != nil && err != io.EOF {
-				writeToOutput(s.getOut(), fmt.Sprintln("Can't read string from ", fName, ", error: ", err))
+				writeToOutput(out, fmt.Sprintln("Can't read string from ", fName, ", error: ", err))
 				ok = false
 			}
 
 			if strings.Contains(line, " ERR ") {
-				writeToOutput(s.getOut(), fmt.Sprintln("!!! THERE ARE ERRORS IN ERROR LOG !!! ", fName))
+				writeToOutput(out, fmt.Sprintln("!!! THERE ARE ERRORS IN ERROR LOG !!! ", fName))
 				ok = false
 			}
 		case <-closeChan:
@@ -227,63 +211,6 @@ func findErrorsInLog(s scenario, fName string, file io.ReadCloser, closeChan cha
 	}
 }
 
-func addMigrationAddresses(insSDK *sdk.SDK) int32 {
-	var err error
-	var retriesCount int32
-
-	bof := backoff.Backoff{Min: 1 * time.Second, Max: 10 * time.Second}
-	for bof.Attempt() < backoffAttemptsCount {
-		migrationAddresses := []string{}
-		for j := 0; j < concurrent*2; j++ {
-			migrationAddresses = append(migrationAddresses, "fake_burn_address_"+strconv.Itoa(j))
-		}
-		traceID, err := insSDK.AddMigrationAddresses(migrationAddresses)
-		if err == nil {
-			break
-		}
-
-		if strings.Contains(err.Error(), insolar.ErrTooManyPendingRequests.Error()) {
-			retriesCount++
-		} else {
-			fmt.Printf("Retry to add burn address. TraceID: %s Error is: %s\n", traceID, err.Error())
-		}
-		time.Sleep(bof.Duration())
-	}
-	check(fmt.Sprintf("Couldn't add burn address after retries: %d", backoffAttemptsCount), err)
-	bof.Reset()
-
-	return retriesCount
-}
-
-func createMembers(insSDK *sdk.SDK, count int) ([]*sdk.Member, int32) {
-	var members []*sdk.Member
-	var member *sdk.Member
-	var traceID string
-	var err error
-	var retriesCount int32
-
-	for i := 0; i < count; i++ {
-		bof := backoff.Backoff{Min: 1 * time.Second, Max: 10 * time.Second}
-		for bof.Attempt() < backoffAttemptsCount {
-			member, traceID, err = insSDK.CreateMember()
-			if err == nil {
-				members = append(members, member)
-				break
-			}
-
-			if strings.Contains(err.Error(), insolar.ErrTooManyPendingRequests.Error()) {
-				retriesCount++
-			} else {
-				fmt.Printf("Retry to create member. TraceID: %s Error is: %s\n", traceID, err.Error())
-			}
-			time.Sleep(bof.Duration())
-		}
-		check(fmt.Sprintf("Couldn't create member after retries: %d", backoffAttemptsCount), err)
-		bof.Reset()
-	}
-	return members, retriesCount
-}
-
 func getTotalBalance(insSDK *sdk.SDK, members []*sdk.Member) (totalBalance *big.Int, penRetires int32) {
 	type Result struct {
 		num     int
@@ -337,33 +264,6 @@ func getTotalBalance(insSDK *sdk.SDK, members []*sdk.Member) (totalBalance *big.
 	return totalBalance, penRetires
 }
 
-func getMembers(insSDK *sdk.SDK) ([]*sdk.Member, int32, error) {
-	var members []*sdk.Member
-	var err error
-	var retriesCount int32
-
-	if useMembersFromFile {
-		members, err = loadMembers(concurrent * 2)
-		if err != nil {
-			return nil, 0, errors.Wrap(err, "error while loading members: ")
-		}
-	} else {
-		start := time.Now()
-		members, retriesCount = createMembers(insSDK, concurrent*2)
-		creationTime := time.Since(start)
-		fmt.Printf("Members were created in %s\n", creationTime)
-		fmt.Printf("Average creation of member time - %s\n", time.Duration(int64(creationTime)/int64(concurrent*2)))
-	}
-
-	if saveMembersToFile {
-		err = saveMembers(members)
-		if err != nil {
-			return nil, 0, errors.Wrap(err, "save member done with error: ")
-		}
-	}
-	return members, retriesCount, nil
-}
-
 func saveMembers(members []*sdk.Member) error {
 	err := os.MkdirAll(defaultMemberFileDir, 0777)
 	if err != nil {
@@ -419,31 +319,65 @@ func main() {
 	out, err := chooseOutput(output)
 	check("Problems with output file:", err)
 
+	reportOut := out
+	if reportPath != defaultStdoutPath {
+		reportOut, err = chooseOutput(reportPath)
+		check("Problems with report file:", err)
+	}
+
 	insSDK, err := sdk.NewSDK(adminAPIURLs, publicAPIURLs, memberKeys)
 	check("SDK is not initialized: ", err)
 
 	err = insSDK.SetLogLevel(logLevelServer)
 	check("Failed to parse log level: ", err)
 
-	crBaPenBefore := addMigrationAddresses(insSDK)
-	check("Error while adding burn addresses: ", err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-	members, crMemPenBefore, err := getMembers(insSDK)
-	check("Error while loading members: ", err)
+	env := NewEnv(insSDK, out)
+	rec := NewRecorder(func(name string, traceID string, err error) {
+		writeToOutput(out, fmt.Sprintf("[%s] Error with traceID: %s. Response: %s.\n", name, traceID, err.Error()))
+	})
+
+	// Balance is checked only for transfers between different members made by concurrent users,
+	// total fee of other scenarios isn't known in advance.
+	var checkedMembers []*sdk.Member
+	var run func(ctx context.Context)
+	if loadConfig != "" {
+		cfg, err := readLoadConfig(loadConfig)
+		check("Problems with load config:", err)
+		m, err := newMix(cfg.Scenarios)
+		check("Problems with load config:", err)
+		err = m.prepare(ctx, env, cfg)
+		check("Scenarios can not be started:", err)
+
+		run = func(ctx context.Context) {
+			runOpenLoop(ctx, rec, cfg, m)
+		}
+	} else {
+		s, err := newScenario(scenarioName)
+		check("Problems with scenario:", err)
+		err = s.Prepare(ctx, env, concurrent, concurrent*repetitions)
+		check(fmt.Sprintf("Scenario %s can not be started:", scenarioName), err)
+
+		if transfer, ok := s.(*transferDifferentMembersScenario); ok {
+			checkedMembers = transfer.members
+		}
+		run = func(ctx context.Context) {
+			runClosedLoop(ctx, rec, scenarioName, s, concurrent, repetitions)
+		}
+	}
 
 	var totalBalanceBefore *big.Int
 	var balancePenRetries int32
-	if !noCheckBalance {
-		totalBalanceBefore, balancePenRetries = getTotalBalance(insSDK, members)
+	if !noCheckBalance && checkedMembers != nil {
+		totalBalanceBefore, balancePenRetries = getTotalBalance(insSDK, checkedMembers)
 	}
-
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	writeToOutput(out, fmt.Sprintf("Preparation pending retries: %d\n", env.PendingRetries()+int(balancePenRetries)))
 
 	var sigChan = make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGHUP)
 
-	s := newScenarios(out, insSDK, members, concurrent, repetitions, crBaPenBefore+crMemPenBefore+balancePenRetries)
 	go func() {
 		stopGracefully := true
 		for {
@@ -451,7 +385,7 @@ func main() {
 
 			switch sig {
 			case syscall.SIGHUP:
-				printResults(s)
+				printResults(out, rec)
 			case syscall.SIGINT:
 				if !stopGracefully {
 					log.Fatal("Force quiting.")
@@ -465,17 +399,20 @@ func main() {
 		}
 	}()
 
-	startScenario(ctx, s)
+	startLoad(ctx, out, rec, run)
+
+	err = writeReports(reportOut, reportFormat, rec.Reports())
+	check("Can't write report:", err)
 
 	// Finish benchmark time
 	t = time.Now()
 	fmt.Printf("\nFinish: %s\n\n", t.String())
 
-	if !noCheckBalance {
+	if !noCheckBalance && checkedMembers != nil {
 		totalBalanceAfter := big.NewInt(0)
 		totalBalanceAfterWithFee := big.NewInt(0)
 		for nretries := 0; nretries < 3; nretries++ {
-			totalBalanceAfter, _ = getTotalBalance(insSDK, members)
+			totalBalanceAfter, _ = getTotalBalance(insSDK, checkedMembers)
 			totalBalanceAfterWithFee = new(big.Int).Add(totalBalanceAfter, big.NewInt(calcFee(transferAmount)*int64(repetitions*concurrent)))
 			if totalBalanceAfterWithFee.Cmp(totalBalanceBefore) == 0 {
 				break
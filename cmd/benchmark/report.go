@@ -0,0 +1,325 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/insolar"
+)
+
+const totalReportName = "Total"
+
+const (
+	formatText = "text"
+	formatJSON = "json"
+	formatCSV  = "csv"
+)
+
+// Error classes of failed operations.
+const (
+	errClassTimeout      = "timeout"
+	errClassPending      = "pending"
+	errClassInvalidState = "invalid_state"
+	errClassOverload     = "overload"
+	errClassCanceled     = "canceled"
+	errClassOther        = "other"
+)
+
+var errClasses = []string{
+	errClassTimeout,
+	errClassPending,
+	errClassInvalidState,
+	errClassOverload,
+	errClassCanceled,
+	errClassOther,
+}
+
+// errOverload is recorded when open loop runner can't start operation because of in-flight limit.
+var errOverload = errors.New("too many operations in flight")
+
+func classifyError(err error) string {
+	cause := errors.Cause(err)
+	if netErr, ok := cause.(net.Error); ok && netErr.Timeout() {
+		return errClassTimeout
+	}
+	switch {
+	case cause == errOverload:
+		return errClassOverload
+	case cause == context.Canceled || cause == context.DeadlineExceeded:
+		return errClassCanceled
+	case strings.Contains(err.Error(), insolar.ErrTooManyPendingRequests.Error()):
+		return errClassPending
+	case strings.Contains(err.Error(), "invalid state record"):
+		return errClassInvalidState
+	default:
+		return errClassOther
+	}
+}
+
+// Latency is a latency distribution of successful operations, durations are in nanoseconds in JSON.
+type Latency struct {
+	Min  time.Duration `json:"min"`
+	Mean time.Duration `json:"mean"`
+	P50  time.Duration `json:"p50"`
+	P90  time.Duration `json:"p90"`
+	P95  time.Duration `json:"p95"`
+	P99  time.Duration `json:"p99"`
+	Max  time.Duration `json:"max"`
+}
+
+// Report is a result of a scenario.
+type Report struct {
+	Scenario       string         `json:"scenario"`
+	Operations     int            `json:"operations"`
+	Successes      int            `json:"successes"`
+	Errors         map[string]int `json:"errors"`
+	PendingRetries int            `json:"pendingRetries"`
+	// RPS is a number of successful operations per second.
+	RPS     float64 `json:"rps"`
+	Latency Latency `json:"latency"`
+}
+
+type scenarioStats struct {
+	latencies []time.Duration
+	errors    map[string]int
+	retries   int
+}
+
+// Recorder collects results of operations. It's safe for concurrent use.
+type Recorder struct {
+	lock   sync.Mutex
+	start  time.Time
+	order  []string
+	stats  map[string]*scenarioStats
+	onFail func(name string, traceID string, err error)
+}
+
+// NewRecorder creates recorder, onFail is called for every failed operation if set. Calls of onFail are serialized.
+func NewRecorder(onFail func(name string, traceID string, err error)) *Recorder {
+	return &Recorder{
+		start:  time.Now(),
+		stats:  map[string]*scenarioStats{},
+		onFail: onFail,
+	}
+}
+
+func (r *Recorder) scenario(name string) *scenarioStats {
+	s, ok := r.stats[name]
+	if !ok {
+		s = &scenarioStats{errors: map[string]int{}}
+		r.stats[name] = s
+		r.order = append(r.order, name)
+	}
+	return s
+}
+
+// Reset starts measuring from now, it's called when load starts.
+func (r *Recorder) Reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.start = time.Now()
+}
+
+// Record registers finished operation of scenario.
+func (r *Recorder) Record(name string, latency time.Duration, traceID string, err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	s := r.scenario(name)
+	if err != nil {
+		s.errors[classifyError(err)]++
+		if r.onFail != nil {
+			r.onFail(name, traceID, err)
+		}
+		return
+	}
+	s.latencies = append(s.latencies, latency)
+}
+
+// AddRetries registers retries of scenario operations caused by too many pending requests.
+func (r *Recorder) AddRetries(name string, retries int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.scenario(name).retries += retries
+}
+
+// Reports returns reports of every scenario in order of their first record and a total report if there are
+// several scenarios.
+func (r *Recorder) Reports() []Report {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	elapsed := time.Since(r.start)
+	total := &scenarioStats{errors: map[string]int{}}
+	var reports []Report
+	for _, name := range r.order {
+		s := r.stats[name]
+		reports = append(reports, makeReport(name, s, elapsed))
+
+		total.latencies = append(total.latencies, s.latencies...)
+		total.retries += s.retries
+		for class, n := range s.errors {
+			total.errors[class] += n
+		}
+	}
+	if len(reports) > 1 {
+		reports = append(reports, makeReport(totalReportName, total, elapsed))
+	}
+	return reports
+}
+
+func makeReport(name string, s *scenarioStats, elapsed time.Duration) Report {
+	rep := Report{
+		Scenario:       name,
+		Successes:      len(s.latencies),
+		Errors:         map[string]int{},
+		PendingRetries: s.retries,
+		Latency:        latencyOf(s.latencies),
+	}
+	rep.Operations = rep.Successes
+	for class, n := range s.errors {
+		rep.Errors[class] = n
+		rep.Operations += n
+	}
+	if elapsed > 0 {
+		rep.RPS = float64(rep.Successes) / elapsed.Seconds()
+	}
+	return rep
+}
+
+func latencyOf(latencies []time.Duration) Latency {
+	if len(latencies) == 0 {
+		return Latency{}
+	}
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var sum time.Duration
+	for _, l := range sorted {
+		sum += l
+	}
+	return Latency{
+		Min:  sorted[0],
+		Mean: sum / time.Duration(len(sorted)),
+		P50:  percentile(sorted, 50),
+		P90:  percentile(sorted, 90),
+		P95:  percentile(sorted, 95),
+		P99:  percentile(sorted, 99),
+		Max:  sorted[len(sorted)-1],
+	}
+}
+
+// percentile returns nearest-rank percentile of sorted values.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// writeReports writes reports in one of text, json or csv formats.
+func writeReports(w io.Writer, format string, reports []Report) error {
+	switch format {
+	case formatText:
+		return writeReportsText(w, reports)
+	case formatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "    ")
+		return errors.Wrap(enc.Encode(reports), "failed to write json report")
+	case formatCSV:
+		return writeReportsCSV(w, reports)
+	default:
+		return errors.Errorf("unknown report format %s", format)
+	}
+}
+
+func writeReportsText(w io.Writer, reports []Report) error {
+	for _, r := range reports {
+		var errs []string
+		for _, class := range errClasses {
+			if n := r.Errors[class]; n > 0 {
+				errs = append(errs, fmt.Sprintf("%s=%d", class, n))
+			}
+		}
+		_, err := fmt.Fprintf(w,
+			"Scenario %s: Speed - %f resp/s \n"+
+				"Scenario %s: Average Request Duration - %s\n"+
+				"Scenario %s: Latency - p50 %s, p90 %s, p95 %s, p99 %s, max %s\n"+
+				"Scenario result:\n\tSuccesses: %d\n\tErrors: %d %s\n\tPending retries: %d\n",
+			r.Scenario, r.RPS,
+			r.Scenario, r.Latency.Mean,
+			r.Scenario, r.Latency.P50, r.Latency.P90, r.Latency.P95, r.Latency.P99, r.Latency.Max,
+			r.Successes, r.Operations-r.Successes, strings.Join(errs, " "), r.PendingRetries,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to write report")
+		}
+	}
+	return nil
+}
+
+func writeReportsCSV(w io.Writer, reports []Report) error {
+	ms := func(d time.Duration) string {
+		return strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', 3, 64)
+	}
+
+	header := []string{
+		"scenario", "operations", "successes", "pending_retries", "rps",
+		"min_ms", "mean_ms", "p50_ms", "p90_ms", "p95_ms", "p99_ms", "max_ms",
+	}
+	for _, class := range errClasses {
+		header = append(header, "errors_"+class)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return errors.Wrap(err, "failed to write csv report")
+	}
+	for _, r := range reports {
+		row := []string{
+			r.Scenario,
+			strconv.Itoa(r.Operations),
+			strconv.Itoa(r.Successes),
+			strconv.Itoa(r.PendingRetries),
+			strconv.FormatFloat(r.RPS, 'f', 3, 64),
+			ms(r.Latency.Min), ms(r.Latency.Mean), ms(r.Latency.P50), ms(r.Latency.P90),
+			ms(r.Latency.P95), ms(r.Latency.P99), ms(r.Latency.Max),
+		}
+		for _, class := range errClasses {
+			row = append(row, strconv.Itoa(r.Errors[class]))
+		}
+		if err := cw.Write(row); err != nil {
+			return errors.Wrap(err, "failed to write csv report")
+		}
+	}
+	cw.Flush()
+	return errors.Wrap(cw.Error(), "failed to write csv report")
+}
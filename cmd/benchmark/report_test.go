@@ -0,0 +1,116 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/insolar"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+var _ net.Error = timeoutError{}
+
+func TestClassifyError(t *testing.T) {
+	for err, class := range map[error]string{
+		errors.Wrap(timeoutError{}, "request was failed"):                errClassTimeout,
+		errors.Wrap(insolar.ErrTooManyPendingRequests, "request failed"): errClassPending,
+		errors.New("invalid state record"):                               errClassInvalidState,
+		errOverload:                                                      errClassOverload,
+		errors.Wrap(context.Canceled, "request was canceled"):            errClassCanceled,
+		errors.New("something else"):                                     errClassOther,
+	} {
+		require.Equal(t, class, classifyError(err), err.Error())
+	}
+}
+
+func TestLatencyOf(t *testing.T) {
+	var latencies []time.Duration
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	require.Equal(t, Latency{
+		Min:  time.Millisecond,
+		Mean: 50500 * time.Microsecond,
+		P50:  50 * time.Millisecond,
+		P90:  90 * time.Millisecond,
+		P95:  95 * time.Millisecond,
+		P99:  99 * time.Millisecond,
+		Max:  100 * time.Millisecond,
+	}, latencyOf(latencies))
+
+	require.Equal(t, Latency{}, latencyOf(nil))
+	single := latencyOf([]time.Duration{time.Second})
+	require.Equal(t, time.Second, single.P50)
+	require.Equal(t, time.Second, single.P99)
+}
+
+func TestWriteReports(t *testing.T) {
+	rec := NewRecorder(nil)
+	rec.Record("a", 10*time.Millisecond, "", nil)
+	rec.Record("a", 0, "", errOverload)
+	rec.Record("b", 20*time.Millisecond, "", nil)
+	rec.AddRetries("b", 2)
+	reports := rec.Reports()
+	require.Len(t, reports, 3)
+
+	t.Run("json", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		require.NoError(t, writeReports(buf, formatJSON, reports))
+		var decoded []Report
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		require.Equal(t, len(reports), len(decoded))
+		require.Equal(t, reports[0].Errors, decoded[0].Errors)
+		require.Equal(t, reports[2].Latency, decoded[2].Latency)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		require.NoError(t, writeReports(buf, formatCSV, reports))
+		rows, err := csv.NewReader(buf).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, 4)
+		require.Equal(t, []string{"a", "2", "1", "0"}, rows[1][:4])
+		require.Equal(t, "10.000", rows[1][7])
+		require.Equal(t, "1", rows[1][len(rows[1])-3])
+		require.Equal(t, []string{"b", "1", "1", "2"}, rows[2][:4])
+		require.Equal(t, []string{totalReportName, "3", "2", "2"}, rows[3][:4])
+	})
+
+	t.Run("text", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		require.NoError(t, writeReports(buf, formatText, reports))
+		require.Contains(t, buf.String(), "Errors: 1 overload=1")
+	})
+
+	require.Error(t, writeReports(&bytes.Buffer{}, "xml", reports))
+}
@@ -0,0 +1,255 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	defaultMaxInFlight = 1000
+	defaultMembersPool = 100
+)
+
+// LoadConfig describes open loop load: operations are started at target rate regardless of how fast the network
+// replies. Rate grows linearly from zero to RPS during RampUp.
+type LoadConfig struct {
+	Duration time.Duration
+	RampUp   time.Duration
+	RPS      float64
+	// MaxInFlight limits operations waiting for reply, operations over the limit are recorded as overload errors.
+	MaxInFlight int
+	// Members is a size of members pool used by scenarios.
+	Members   int
+	Scenarios []WeightedScenario
+}
+
+// WeightedScenario is a part of load mix, operations are distributed between scenarios proportionally to weights.
+type WeightedScenario struct {
+	Name   string
+	Weight int
+}
+
+func readLoadConfig(file string) (*LoadConfig, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read load config")
+	}
+	cfg := &LoadConfig{
+		MaxInFlight: defaultMaxInFlight,
+		Members:     defaultMembersPool,
+	}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, errors.Wrap(err, "failed to parse load config")
+	}
+	return cfg, cfg.validate()
+}
+
+func (c *LoadConfig) validate() error {
+	switch {
+	case c.Duration <= 0:
+		return errors.New("duration should be positive")
+	case c.RampUp < 0:
+		return errors.New("rampup should not be negative")
+	case c.RPS <= 0:
+		return errors.New("rps should be positive")
+	case c.MaxInFlight <= 0:
+		return errors.New("maxinflight should be positive")
+	case c.Members <= 0:
+		return errors.New("members should be positive")
+	case len(c.Scenarios) == 0:
+		return errors.New("no scenarios")
+	}
+	for _, s := range c.Scenarios {
+		if _, ok := scenarios[s.Name]; !ok {
+			return errors.Errorf("unknown scenario %s, known scenarios are %v", s.Name, scenarioNames())
+		}
+		if s.Weight <= 0 {
+			return errors.Errorf("weight of scenario %s should be positive", s.Name)
+		}
+	}
+	return nil
+}
+
+// offset returns time of k-th operation since the start of load.
+func (c *LoadConfig) offset(k int) time.Duration {
+	rampUp := c.RampUp.Seconds()
+	rampUpOps := c.RPS * rampUp / 2
+
+	var sec float64
+	if float64(k) < rampUpOps {
+		sec = math.Sqrt(2 * float64(k) * rampUp / c.RPS)
+	} else {
+		sec = rampUp + (float64(k)-rampUpOps)/c.RPS
+	}
+	return time.Duration(sec * float64(time.Second))
+}
+
+// operations returns a number of operations started during load.
+func (c *LoadConfig) operations() int {
+	d := c.Duration.Seconds()
+	rampUp := c.RampUp.Seconds()
+
+	var ops float64
+	if d <= rampUp {
+		ops = c.RPS * d * d / (2 * rampUp)
+	} else {
+		ops = c.RPS*rampUp/2 + c.RPS*(d-rampUp)
+	}
+	return int(math.Ceil(ops))
+}
+
+type mixItem struct {
+	name     string
+	scenario Scenario
+	weight   int
+	current  int
+}
+
+// mix picks scenarios by smooth weighted round-robin, so the sequence is deterministic and evenly interleaved.
+type mix struct {
+	items []*mixItem
+	total int
+}
+
+func newMix(weighted []WeightedScenario) (*mix, error) {
+	m := &mix{}
+	for _, w := range weighted {
+		s, err := newScenario(w.Name)
+		if err != nil {
+			return nil, err
+		}
+		m.items = append(m.items, &mixItem{name: w.Name, scenario: s, weight: w.Weight})
+		m.total += w.Weight
+	}
+	return m, nil
+}
+
+// prepare prepares every scenario for its share of operations.
+func (m *mix) prepare(ctx context.Context, env *Env, cfg *LoadConfig) error {
+	ops := cfg.operations()
+	for _, it := range m.items {
+		share := (ops*it.weight + m.total - 1) / m.total
+		if err := it.scenario.Prepare(ctx, env, cfg.Members, share); err != nil {
+			return errors.Wrapf(err, "failed to prepare scenario %s", it.name)
+		}
+	}
+	return nil
+}
+
+func (m *mix) next() *mixItem {
+	var best *mixItem
+	for _, it := range m.items {
+		it.current += it.weight
+		if best == nil || it.current > best.current {
+			best = it
+		}
+	}
+	best.current -= m.total
+	return best
+}
+
+func runStep(ctx context.Context, rec *Recorder, name string, s Scenario, n int, start time.Time) {
+	traceID, retries, err := s.Step(ctx, n)
+	latency := time.Since(start)
+	if retries > 0 {
+		rec.AddRetries(name, retries)
+	}
+	rec.Record(name, latency, traceID, err)
+}
+
+// runClosedLoop runs scenario by workers, each worker starts the next operation when the previous one is finished.
+func runClosedLoop(ctx context.Context, rec *Recorder, name string, s Scenario, workers int, repetitions int) {
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func(w int) {
+			defer wg.Done()
+			for j := 0; j < repetitions; j++ {
+				if ctx.Err() != nil {
+					return
+				}
+				runStep(ctx, rec, name, s, w, time.Now())
+			}
+		}(w)
+	}
+	wg.Wait()
+}
+
+// runOpenLoop starts operations of mix on schedule. Latency is measured from the scheduled start, so delays of
+// overloaded benchmark are accounted.
+func runOpenLoop(ctx context.Context, rec *Recorder, cfg *LoadConfig, m *mix) {
+	var wg sync.WaitGroup
+	inFlight := make(chan struct{}, cfg.MaxInFlight)
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	start := time.Now()
+	for k := 0; ; k++ {
+		offset := cfg.offset(k)
+		if offset >= cfg.Duration {
+			break
+		}
+		scheduled := start.Add(offset)
+		if !sleepUntil(ctx, timer, scheduled) {
+			break
+		}
+
+		it := m.next()
+		select {
+		case inFlight <- struct{}{}:
+		default:
+			rec.Record(it.name, 0, "", errOverload)
+			continue
+		}
+		wg.Add(1)
+		go func(it *mixItem, k int) {
+			defer wg.Done()
+			defer func() { <-inFlight }()
+			runStep(ctx, rec, it.name, it.scenario, k, scheduled)
+		}(it, k)
+	}
+	wg.Wait()
+}
+
+// sleepUntil waits for the time using the timer, it returns false if context is done.
+func sleepUntil(ctx context.Context, timer *time.Timer, t time.Time) bool {
+	d := time.Until(t)
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	if !timer.Stop() {
+		select {
+		case <-timer.C:
+		default:
+		}
+	}
+	timer.Reset(d)
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,209 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeScenario struct {
+	lock  sync.Mutex
+	steps []int
+	fail  bool
+	delay time.Duration
+}
+
+func (s *fakeScenario) Prepare(ctx context.Context, env *Env, workers int, operations int) error {
+	return nil
+}
+
+func (s *fakeScenario) Step(ctx context.Context, n int) (string, int, error) {
+	time.Sleep(s.delay)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.steps = append(s.steps, n)
+	if s.fail {
+		return "trace", 1, errors.New("failed")
+	}
+	return "trace", 0, nil
+}
+
+func TestLoadConfig_Schedule(t *testing.T) {
+	t.Run("constant rate", func(t *testing.T) {
+		cfg := &LoadConfig{Duration: time.Second, RPS: 100}
+		require.Equal(t, time.Duration(0), cfg.offset(0))
+		require.Equal(t, 10*time.Millisecond, cfg.offset(1))
+		require.Equal(t, 100, cfg.operations())
+	})
+
+	t.Run("ramp up", func(t *testing.T) {
+		cfg := &LoadConfig{Duration: 2 * time.Second, RampUp: time.Second, RPS: 100}
+		// 50 operations during ramp up and 100 after it.
+		require.Equal(t, 150, cfg.operations())
+		require.Equal(t, time.Second, cfg.offset(50))
+		require.Equal(t, time.Second+10*time.Millisecond, cfg.offset(51))
+		for k := 1; k < 150; k++ {
+			require.True(t, cfg.offset(k) > cfg.offset(k-1))
+		}
+		// Rate grows, so the first half of ramp up has a quarter of its operations.
+		require.True(t, cfg.offset(12) < 500*time.Millisecond)
+		require.True(t, cfg.offset(13) > 500*time.Millisecond)
+	})
+
+	t.Run("shorter than ramp up", func(t *testing.T) {
+		cfg := &LoadConfig{Duration: time.Second, RampUp: 2 * time.Second, RPS: 100}
+		require.Equal(t, 25, cfg.operations())
+	})
+}
+
+func TestReadLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "benchmark")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	write := func(data string) string {
+		path := filepath.Join(dir, "load.yaml")
+		require.NoError(t, ioutil.WriteFile(path, []byte(data), 0600))
+		return path
+	}
+
+	cfg, err := readLoadConfig(write(`
+duration: 1m
+rampup: 10s
+rps: 50
+scenarios:
+  - name: transferDifferentMembers
+    weight: 3
+  - name: getBalance
+    weight: 1
+`))
+	require.NoError(t, err)
+	require.Equal(t, &LoadConfig{
+		Duration:    time.Minute,
+		RampUp:      10 * time.Second,
+		RPS:         50,
+		MaxInFlight: defaultMaxInFlight,
+		Members:     defaultMembersPool,
+		Scenarios: []WeightedScenario{
+			{Name: "transferDifferentMembers", Weight: 3},
+			{Name: "getBalance", Weight: 1},
+		},
+	}, cfg)
+
+	_, err = readLoadConfig(write("duration: 1m\nrps: 50\nscenarios:\n  - name: unknown\n    weight: 1\n"))
+	require.Error(t, err)
+	_, err = readLoadConfig(write("duration: 1m\nrps: 50\nscenarios:\n  - name: getBalance\n"))
+	require.Error(t, err)
+	_, err = readLoadConfig(write("duration: 1m\nrps: 50\nunknown: 1\n"))
+	require.Error(t, err)
+}
+
+func TestMix(t *testing.T) {
+	m, err := newMix([]WeightedScenario{
+		{Name: "transferDifferentMembers", Weight: 2},
+		{Name: "getBalance", Weight: 1},
+	})
+	require.NoError(t, err)
+
+	var names []string
+	for i := 0; i < 6; i++ {
+		names = append(names, m.next().name)
+	}
+	require.Equal(t, []string{
+		"transferDifferentMembers", "getBalance", "transferDifferentMembers",
+		"transferDifferentMembers", "getBalance", "transferDifferentMembers",
+	}, names)
+}
+
+func TestRunClosedLoop(t *testing.T) {
+	s := &fakeScenario{}
+	rec := NewRecorder(nil)
+	runClosedLoop(context.Background(), rec, "fake", s, 3, 4)
+
+	require.Len(t, s.steps, 12)
+	reports := rec.Reports()
+	require.Len(t, reports, 1)
+	require.Equal(t, 12, reports[0].Successes)
+}
+
+func TestRunOpenLoop(t *testing.T) {
+	t.Run("mix", func(t *testing.T) {
+		ok, failing := &fakeScenario{}, &fakeScenario{fail: true}
+		m := &mix{
+			items: []*mixItem{
+				{name: "ok", scenario: ok, weight: 1},
+				{name: "failing", scenario: failing, weight: 1},
+			},
+			total: 2,
+		}
+		var failed []string
+		rec := NewRecorder(func(name string, traceID string, err error) {
+			failed = append(failed, name)
+		})
+
+		cfg := &LoadConfig{Duration: 100 * time.Millisecond, RPS: 100, MaxInFlight: 10}
+		runOpenLoop(context.Background(), rec, cfg, m)
+
+		sort.Ints(ok.steps)
+		require.Equal(t, []int{0, 2, 4, 6, 8}, ok.steps)
+		require.Len(t, failing.steps, 5)
+		reports := rec.Reports()
+		require.Len(t, reports, 3)
+		require.Equal(t, "ok", reports[0].Scenario)
+		require.Equal(t, 5, reports[0].Successes)
+		require.Equal(t, map[string]int{errClassOther: 5}, reports[1].Errors)
+		require.Equal(t, 5, reports[1].PendingRetries)
+		require.Equal(t, totalReportName, reports[2].Scenario)
+		require.Equal(t, 10, reports[2].Operations)
+		require.Len(t, failed, 5)
+	})
+
+	t.Run("overload", func(t *testing.T) {
+		slow := &fakeScenario{delay: 200 * time.Millisecond}
+		m := &mix{items: []*mixItem{{name: "slow", scenario: slow, weight: 1}}, total: 1}
+		rec := NewRecorder(nil)
+
+		cfg := &LoadConfig{Duration: 50 * time.Millisecond, RPS: 100, MaxInFlight: 2}
+		runOpenLoop(context.Background(), rec, cfg, m)
+
+		reports := rec.Reports()
+		require.Equal(t, 2, reports[0].Successes)
+		require.Equal(t, 3, reports[0].Errors[errClassOverload])
+		// Latency is measured from scheduled start.
+		require.True(t, reports[0].Latency.Min >= slow.delay)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		s := &fakeScenario{}
+		m := &mix{items: []*mixItem{{name: "fake", scenario: s, weight: 1}}, total: 1}
+
+		runOpenLoop(ctx, NewRecorder(nil), &LoadConfig{Duration: time.Hour, RPS: 1, MaxInFlight: 1}, m)
+		require.Empty(t, s.steps)
+	})
+}
@@ -18,154 +18,78 @@ package main
 
 import (
 	"context"
-	"fmt"
-	"io"
 	"math/big"
-	"net"
-	"strings"
-	"sync"
-	"sync/atomic"
-	"time"
+	"sort"
 
-	"github.com/insolar/insolar/api/sdk"
-	"github.com/insolar/insolar/insolar"
-	"github.com/insolar/insolar/insolar/backoff"
 	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/api/sdk"
 )
 
 const transferAmount = 101
 const transferFee = 10000000
 
-type scenario interface {
-	canBeStarted() error
-	start(ctx context.Context)
-	getOperationsNumber() int
-	getAverageOperationDuration() time.Duration
-	getOperationPerSecond() float64
-	getName() string
-	getOut() io.Writer
-	printResult()
-}
-
-type transferDifferentMembersScenario struct {
-	name           string
-	concurrent     int
-	repetitions    int
-	out            io.Writer
-	totalTime      int64
-	goroutineTimes []time.Duration
-	successes      uint32
-	errors         uint32
-	timeouts       uint32
-	members        []*sdk.Member
-	insSDK         *sdk.SDK
-	penRetries     int32
-}
+const defaultScenario = "transferDifferentMembers"
 
-func (s *transferDifferentMembersScenario) getOperationsNumber() int {
-	return s.concurrent * s.repetitions
+// Scenario is a benchmark workload.
+type Scenario interface {
+	// Prepare is called once before load. Workers is a number of workers in closed loop or a size of members pool in
+	// open loop, operations is an expected number of operations.
+	Prepare(ctx context.Context, env *Env, workers int, operations int) error
+	// Step performs a single operation, n is a number of worker in closed loop and a number of operation in open loop.
+	// It returns a number of retries made because of too many pending requests.
+	Step(ctx context.Context, n int) (traceID string, retries int, err error)
 }
 
-func (s *transferDifferentMembersScenario) getAverageOperationDuration() time.Duration {
-	return time.Duration(s.totalTime / int64(s.getOperationsNumber()))
+// scenarios are constructors of built-in scenarios by name.
+var scenarios = map[string]func() Scenario{
+	"transferDifferentMembers": func() Scenario { return &transferDifferentMembersScenario{} },
+	"transferSameMember":       func() Scenario { return &transferSameMemberScenario{} },
+	"createMember":             func() Scenario { return &createMemberScenario{} },
+	"depositMigration":         func() Scenario { return &depositMigrationScenario{} },
+	"getBalance":               func() Scenario { return &getBalanceScenario{} },
 }
 
-func (s *transferDifferentMembersScenario) getOperationPerSecond() float64 {
-	if len(s.goroutineTimes) == 0 {
-		return 0
-	}
-
-	max := s.goroutineTimes[0]
-	for _, t := range s.goroutineTimes {
-		if max < t {
-			max = t
-		}
+func newScenario(name string) (Scenario, error) {
+	constructor, ok := scenarios[name]
+	if !ok {
+		return nil, errors.Errorf("unknown scenario %s, known scenarios are %v", name, scenarioNames())
 	}
-	elapsedInSeconds := float64(max) / float64(time.Second)
-	return float64(s.getOperationsNumber()-int(s.timeouts)) / elapsedInSeconds
-}
-
-func (s *transferDifferentMembersScenario) getName() string {
-	return s.name
-}
-
-func (s *transferDifferentMembersScenario) getOut() io.Writer {
-	return s.out
+	return constructor(), nil
 }
 
-func (s *transferDifferentMembersScenario) canBeStarted() error {
-	writeToOutput(s.getOut(), fmt.Sprint("canBeStarted\n"))
-	if len(s.members) < s.concurrent*2 {
-		return fmt.Errorf("not enough members for scenario %s", s.getName())
+func scenarioNames() []string {
+	var names []string
+	for name := range scenarios {
+		names = append(names, name)
 	}
-	return nil
+	sort.Strings(names)
+	return names
 }
 
-func (s *transferDifferentMembersScenario) start(ctx context.Context) {
-	var wg sync.WaitGroup
-	for i := 0; i < s.concurrent*2; i += 2 {
-		wg.Add(1)
-		go s.startMember(ctx, i, &wg)
-	}
-	wg.Wait()
+// transferDifferentMembersScenario transfers money between pairs of members, every worker has its own pair.
+type transferDifferentMembersScenario struct {
+	insSDK  *sdk.SDK
+	members []*sdk.Member
+	pairs   int
 }
 
-func (s *transferDifferentMembersScenario) startMember(ctx context.Context, index int, wg *sync.WaitGroup) {
-	defer wg.Done()
-	goroutineTime := time.Duration(0)
-	for j := 0; j < s.repetitions; j++ {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-		}
-
-		from := s.members[index]
-		to := s.members[index+1]
-
-		var start time.Time
-		var stop time.Duration
-		var traceID string
-		var err error
-
-		bof := backoff.Backoff{Min: 500 * time.Millisecond, Max: 20 * time.Second}
-
-		retry := true
-		for retry && bof.Attempt() < backoffAttemptsCount {
-			start = time.Now()
-			traceID, err = s.insSDK.Transfer(big.NewInt(transferAmount).String(), from, to)
-			stop = time.Since(start)
-
-			if err != nil && strings.Contains(err.Error(), insolar.ErrTooManyPendingRequests.Error()) {
-				time.Sleep(bof.Duration())
-				atomic.AddInt32(&s.penRetries, 1)
-			} else {
-				retry = false
-			}
-		}
-
-		if err == nil {
-			atomic.AddUint32(&s.successes, 1)
-			atomic.AddInt64(&s.totalTime, int64(stop))
-			goroutineTime += stop
-		} else if netErr, ok := errors.Cause(err).(net.Error); ok && netErr.Timeout() {
-			atomic.AddUint32(&s.timeouts, 1)
-			writeToOutput(s.out, fmt.Sprintf("[Member №%d] Transfer error. Timeout. Error: %s \n", index, err.Error()))
-		} else {
-			atomic.AddUint32(&s.errors, 1)
-			atomic.AddInt64(&s.totalTime, int64(stop))
-			goroutineTime += stop
-
-			if strings.Contains(err.Error(), "invalid state record") {
-				writeToOutput(s.out, fmt.Sprintf("[ OK ] Invalid state record.    Trace: %s.\n", traceID))
-			} else {
-				writeToOutput(s.out, fmt.Sprintf("[Member №%d] Transfer error with traceID: %s. Response: %s.\n", index, traceID, err.Error()))
-			}
-		}
+func (s *transferDifferentMembersScenario) Prepare(ctx context.Context, env *Env, workers int, operations int) error {
+	members, err := env.Members(workers * 2)
+	if err != nil {
+		return err
 	}
-	s.goroutineTimes = append(s.goroutineTimes, goroutineTime)
+	s.insSDK = env.SDK
+	s.members = members
+	s.pairs = workers
+	return nil
 }
 
-func (s *transferDifferentMembersScenario) printResult() {
-	writeToOutput(s.out, fmt.Sprintf("Scenario result:\n\tSuccesses: %d\n\tErrors: %d\n\tTimeouts: %d\n\tPending retries: %d\n", s.successes, s.errors, s.timeouts, s.penRetries))
+func (s *transferDifferentMembersScenario) Step(ctx context.Context, n int) (string, int, error) {
+	pair := n % s.pairs
+	from := s.members[pair*2]
+	to := s.members[pair*2+1]
+	return retryOperation(ctx, func() (string, error) {
+		return s.insSDK.Transfer(big.NewInt(transferAmount).String(), from, to)
+	})
 }
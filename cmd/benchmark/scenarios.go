@@ -0,0 +1,190 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"context"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/api/sdk"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/platformpolicy"
+)
+
+const migrationAmount = "1000"
+
+const daemonInactiveStatus = "inactive"
+
+// transferSameMemberScenario transfers money from a single member to others, all operations contend on the sender.
+type transferSameMemberScenario struct {
+	insSDK    *sdk.SDK
+	from      *sdk.Member
+	receivers []*sdk.Member
+}
+
+func (s *transferSameMemberScenario) Prepare(ctx context.Context, env *Env, workers int, operations int) error {
+	members, err := env.Members(workers + 1)
+	if err != nil {
+		return err
+	}
+	s.insSDK = env.SDK
+	s.from = members[0]
+	s.receivers = members[1:]
+	return nil
+}
+
+func (s *transferSameMemberScenario) Step(ctx context.Context, n int) (string, int, error) {
+	to := s.receivers[n%len(s.receivers)]
+	return retryOperation(ctx, func() (string, error) {
+		return s.insSDK.Transfer(big.NewInt(transferAmount).String(), s.from, to)
+	})
+}
+
+// createMemberScenario creates a new member on every operation.
+type createMemberScenario struct {
+	insSDK *sdk.SDK
+}
+
+func (s *createMemberScenario) Prepare(ctx context.Context, env *Env, workers int, operations int) error {
+	s.insSDK = env.SDK
+	return env.AddMigrationAddresses(operations)
+}
+
+func (s *createMemberScenario) Step(ctx context.Context, n int) (string, int, error) {
+	return retryOperation(ctx, func() (string, error) {
+		_, traceID, err := s.insSDK.CreateMember()
+		return traceID, err
+	})
+}
+
+// getBalanceScenario reads balances of members, it doesn't change state.
+type getBalanceScenario struct {
+	insSDK  *sdk.SDK
+	members []*sdk.Member
+}
+
+func (s *getBalanceScenario) Prepare(ctx context.Context, env *Env, workers int, operations int) error {
+	members, err := env.Members(workers)
+	if err != nil {
+		return err
+	}
+	s.insSDK = env.SDK
+	s.members = members
+	return nil
+}
+
+func (s *getBalanceScenario) Step(ctx context.Context, n int) (string, int, error) {
+	m := s.members[n%len(s.members)]
+	return retryOperation(ctx, func() (string, error) {
+		_, err := s.insSDK.GetBalance(m)
+		return "", err
+	})
+}
+
+// depositMigrationScenario migrates a new deposit on every operation. Deposit is confirmed by the number of
+// migration daemons required to release it.
+type depositMigrationScenario struct {
+	env       *Env
+	daemons   []*sdk.Member
+	addresses []string
+	txs       int64
+}
+
+func (s *depositMigrationScenario) Prepare(ctx context.Context, env *Env, workers int, operations int) error {
+	s.env = env
+
+	daemons := env.SDK.MigrationDaemonMembers()
+	if len(daemons) < insolar.GenesisAmountActiveMigrationDaemonMembers {
+		return errors.Errorf("need at least %d migration daemons", insolar.GenesisAmountActiveMigrationDaemonMembers)
+	}
+	s.daemons = daemons[:insolar.GenesisAmountActiveMigrationDaemonMembers]
+	for _, d := range s.daemons {
+		status, err := env.SDK.MigrationCheckDaemon(ctx, sdk.DaemonRequest{Reference: d.Reference})
+		if err != nil {
+			return errors.Wrap(err, "failed to check migration daemon")
+		}
+		if status.Status != daemonInactiveStatus {
+			continue
+		}
+		if _, err := env.SDK.MigrationActivateDaemon(ctx, sdk.DaemonRequest{Reference: d.Reference}); err != nil {
+			return errors.Wrap(err, "failed to activate migration daemon")
+		}
+	}
+
+	if err := env.AddMigrationAddresses(workers); err != nil {
+		return err
+	}
+	ks := platformpolicy.NewKeyProcessor()
+	for i := 0; i < workers; i++ {
+		privateKey, err := ks.GeneratePrivateKey()
+		if err != nil {
+			return errors.Wrap(err, "failed to generate private key")
+		}
+		privateKeyPEM, err := ks.ExportPrivateKeyPEM(privateKey)
+		if err != nil {
+			return errors.Wrap(err, "failed to export private key")
+		}
+		publicKeyPEM, err := ks.ExportPublicKeyPEM(ks.ExtractPublicKey(privateKey))
+		if err != nil {
+			return errors.Wrap(err, "failed to export public key")
+		}
+
+		var res *sdk.MigrationCreateResponse
+		_, err = env.retryPending(func() (string, error) {
+			res, err = env.SDK.MemberMigrationCreate(ctx, sdk.NewMember("", string(privateKeyPEM), string(publicKeyPEM)))
+			if err != nil {
+				return "", err
+			}
+			return res.TraceID, nil
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to create migration member")
+		}
+		s.addresses = append(s.addresses, res.MigrationAddress)
+	}
+	return nil
+}
+
+func (s *depositMigrationScenario) Step(ctx context.Context, n int) (string, int, error) {
+	request := sdk.DepositMigrationRequest{
+		Amount:           migrationAmount,
+		EthTxHash:        s.env.UniqueID("tx", int(atomic.AddInt64(&s.txs, 1))),
+		MigrationAddress: s.addresses[n%len(s.addresses)],
+	}
+
+	var traceID string
+	retries := 0
+	for _, d := range s.daemons {
+		var err error
+		var r int
+		traceID, r, err = retryOperation(ctx, func() (string, error) {
+			res, err := s.env.SDK.DepositMigration(ctx, d, request)
+			if err != nil {
+				return "", err
+			}
+			return res.TraceID, nil
+		})
+		retries += r
+		if err != nil {
+			return traceID, retries, err
+		}
+	}
+	return traceID, retries, nil
+}
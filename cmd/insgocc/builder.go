@@ -138,6 +138,9 @@ func (cb *contractsBuilder) prepare(ctx context.Context, names ...string) error
 		if err != nil {
 			return errors.Wrapf(err, "failed to parse contract %v", name)
 		}
+		for _, issue := range code.DeterminismIssues() {
+			inslog.Warn(issue.String())
+		}
 
 		code.ChangePackageToMain()
 
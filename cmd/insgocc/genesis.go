@@ -21,6 +21,8 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+
+	"github.com/insolar/insolar/logicrunner/preprocessor"
 )
 
 func genesisCompile() *cobra.Command {
@@ -30,6 +32,7 @@ func genesisCompile() *cobra.Command {
 		tmpDir   = ""
 		keepTemp = false
 		noProxy  = false
+		allow    []string
 	)
 
 	var cmd = &cobra.Command{
@@ -38,6 +41,7 @@ func genesisCompile() *cobra.Command {
 		Args:  cobra.ArbitraryArgs,
 		Run: func(cmd *cobra.Command, args []string) {
 			ctx := context.Background()
+			preprocessor.DeterminismAllowlist = append(preprocessor.DeterminismAllowlist, allow...)
 			cb := newContractBuilder(tmpDir, noProxy)
 			cb.setSourcesDir(srcDir)
 			cb.setOutputDir(outDir)
@@ -57,6 +61,7 @@ func genesisCompile() *cobra.Command {
 	// default value for bool flags is not displayed automatically, thus it's done manually here
 	cmd.Flags().BoolVarP(&keepTemp, "keep-temp", "k", false, "keep temp directory (default \"false\")")
 	cmd.Flags().BoolVarP(&noProxy, "no-proxy", "", false, "skip proxy compilation (default \"false\")")
+	cmd.Flags().StringSliceVar(&allow, "allow", nil, "additional deterministic packages or names (<import path>.<Name>)")
 
 	return cmd
 }
@@ -242,6 +242,18 @@ func findContractPath(contractDirPath string) *string {
 	return nil
 }
 
+// lintFile returns determinism issues of contract, contract with errors is not a parse failure here.
+func lintFile(fileName string, machineType insolar.MachineType) ([]preprocessor.DeterminismIssue, error) {
+	parsed, err := preprocessor.ParseFile(fileName, machineType)
+	if err != nil {
+		if detErr, ok := errors.Cause(err).(*preprocessor.DeterminismError); ok {
+			return detErr.Issues, nil
+		}
+		return nil, err
+	}
+	return parsed.DeterminismIssues(), nil
+}
+
 func main() {
 	var reference string
 	output := newOutputFlag("-")
@@ -290,6 +302,24 @@ func main() {
 	cmdWrapper.Flags().VarP(output, "output", "o", "output file (use - for STDOUT)")
 	cmdWrapper.Flags().VarP(machineType, "machine-type", "m", "machine type (one of builtin/go)")
 
+	var cmdABI = &cobra.Command{
+		Use:   "abi [flags] <file name to process>",
+		Short: "Generate contract's ABI in JSON",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			parsed, err := preprocessor.ParseFile(args[0], machineType.Value())
+			if err != nil {
+				fmt.Println(errors.Wrap(err, "couldn't parse"))
+				os.Exit(1)
+			}
+
+			err = parsed.WriteABI(output.writer)
+			checkError(err)
+		},
+	}
+	cmdABI.Flags().VarP(output, "output", "o", "output file (use - for STDOUT)")
+	cmdABI.Flags().VarP(machineType, "machine-type", "m", "machine type (one of builtin/go)")
+
 	var cmdImports = &cobra.Command{
 		Use:   "imports [flags] <file name to process>",
 		Short: "Rewrite imports in contract file",
@@ -339,6 +369,7 @@ func main() {
 							Path:       *contractPath,
 							Parsed:     parsedFile,
 							ImportPath: "github.com/insolar/insolar/" + contractDirPath[len(rootProjectDir)+1:],
+							Version:    parsedFile.Version(),
 						}
 						contractList = append(contractList, contract)
 					}
@@ -372,9 +403,60 @@ func main() {
 		},
 	}
 
+	var cmdCheckMigration = &cobra.Command{
+		Use:   "check-migration [flags] <previous contract file> <new contract file>",
+		Short: "Check that new version of contract converts state of previous one",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			prev, err := preprocessor.ParseFile(args[0], machineType.Value())
+			if err != nil {
+				fmt.Println(errors.Wrap(err, "couldn't parse previous contract"))
+				os.Exit(1)
+			}
+
+			next, err := preprocessor.ParseFile(args[1], machineType.Value())
+			if err != nil {
+				fmt.Println(errors.Wrap(err, "couldn't parse new contract"))
+				os.Exit(1)
+			}
+
+			err = preprocessor.CheckMigration(prev, next)
+			checkError(err)
+		},
+	}
+	cmdCheckMigration.Flags().VarP(machineType, "machine-type", "m", "machine type (one of builtin/go)")
+
+	var allow []string
+	var cmdLint = &cobra.Command{
+		Use:   "lint [flags] <file name to process>...",
+		Short: "Check that contracts are deterministic",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			preprocessor.DeterminismAllowlist = append(preprocessor.DeterminismAllowlist, allow...)
+
+			failed := false
+			for _, fileName := range args {
+				issues, err := lintFile(fileName, machineType.Value())
+				if err != nil {
+					fmt.Println(errors.Wrapf(err, "couldn't parse %s", fileName))
+					os.Exit(1)
+				}
+				for _, issue := range issues {
+					fmt.Println(issue)
+				}
+				failed = failed || preprocessor.HasDeterminismErrors(issues)
+			}
+			if failed {
+				os.Exit(1)
+			}
+		},
+	}
+	cmdLint.Flags().VarP(machineType, "machine-type", "m", "machine type (one of builtin/go)")
+	cmdLint.Flags().StringSliceVar(&allow, "allow", nil, "additional deterministic packages or names (<import path>.<Name>)")
+
 	var rootCmd = &cobra.Command{Use: "insgocc"}
 	rootCmd.AddCommand(
-		cmdProxy, cmdWrapper, cmdImports, cmdGenerateBuiltins, genesisCompile())
+		cmdProxy, cmdWrapper, cmdABI, cmdImports, cmdGenerateBuiltins, cmdCheckMigration, cmdLint, genesisCompile())
 	err := rootCmd.Execute()
 	if err != nil {
 		fmt.Println(err)
@@ -0,0 +1,285 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/insolar/insolar/api/requester"
+	"github.com/insolar/insolar/certificate"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/keystore"
+	"github.com/insolar/insolar/platformpolicy"
+)
+
+// certificateRequest is file with rotation or revocation that is passed between discovery nodes for signing.
+type certificateRequest struct {
+	Rotation   *certificate.Rotation   `json:"rotation,omitempty"`
+	Revocation *certificate.Revocation `json:"revocation,omitempty"`
+}
+
+func certificateCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "certificate",
+		Short: "rotates and revokes keys of nodes",
+	}
+	c.AddCommand(
+		certificateRotateCommand(),
+		certificateRevokeCommand(),
+		certificateSignCommand(),
+		certificateSubmitCommand(),
+		certificateMakeCertCommand(),
+		certificateListCommand(),
+	)
+	return c
+}
+
+func certificateRotateCommand() *cobra.Command {
+	var (
+		certFile    string
+		newKeysFile string
+		output      string
+	)
+	c := &cobra.Command{
+		Use:   "rotate",
+		Short: "creates request to replace key of node by new one",
+		Run: func(cmd *cobra.Command, args []string) {
+			cert := readCertificateFile(certFile)
+
+			ks, err := keystore.NewKeyStore(newKeysFile)
+			check("failed to load new keys", err)
+			privKey, err := ks.GetPrivateKey("")
+			check("failed to load new keys", err)
+			kp := platformpolicy.NewKeyProcessor()
+			pubKey, err := kp.ExportPublicKeyPEM(kp.ExtractPublicKey(privKey))
+			check("failed to export new public key", err)
+
+			writeCertificateRequest(output, certificateRequest{Rotation: &certificate.Rotation{
+				Reference:    cert.Reference,
+				Role:         cert.Role,
+				OldPublicKey: cert.PublicKey,
+				PublicKey:    string(pubKey),
+			}})
+		},
+	}
+	c.Flags().StringVarP(
+		&certFile, "node-cert", "c", "cert.json", "current certificate of node")
+	c.Flags().StringVarP(
+		&newKeysFile, "new-keys", "k", "", "file with new public/private keys of node (see gen-key-pair)")
+	c.Flags().StringVarP(
+		&output, "output", "o", "", "output file for request (default stdout)")
+	return c
+}
+
+func certificateRevokeCommand() *cobra.Command {
+	var (
+		certFile string
+		ref      string
+		reason   string
+		output   string
+	)
+	c := &cobra.Command{
+		Use:   "revoke",
+		Short: "creates request to revoke key of node",
+		Run: func(cmd *cobra.Command, args []string) {
+			revocation := &certificate.Revocation{Reference: ref, Reason: reason}
+			if certFile != "" {
+				cert := readCertificateFile(certFile)
+				revocation.Reference = cert.Reference
+				revocation.PublicKey = cert.PublicKey
+			}
+			if revocation.Reference == "" {
+				check("[ certificate ]", errors.New("node reference or certificate is required"))
+			}
+
+			writeCertificateRequest(output, certificateRequest{Revocation: revocation})
+		},
+	}
+	c.Flags().StringVarP(
+		&certFile, "node-cert", "c", "", "certificate of node, only key from certificate is revoked")
+	c.Flags().StringVarP(
+		&ref, "ref", "r", "", "reference of node, all keys of node are revoked")
+	c.Flags().StringVarP(
+		&reason, "reason", "", "", "why key is revoked")
+	c.Flags().StringVarP(
+		&output, "output", "o", "", "output file for request (default stdout)")
+	return c
+}
+
+func certificateSignCommand() *cobra.Command {
+	var (
+		keysFile     string
+		discoveryRef string
+	)
+	c := &cobra.Command{
+		Use:   "sign REQUEST",
+		Short: "signs rotation or revocation request by discovery node",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			req := readCertificateRequest(args[0])
+
+			ref, err := insolar.NewReferenceFromBase58(discoveryRef)
+			check("bad reference of discovery node", err)
+			ks, err := keystore.NewKeyStore(keysFile)
+			check("failed to load keys of discovery node", err)
+			privKey, err := ks.GetPrivateKey("")
+			check("failed to load keys of discovery node", err)
+			signer := cryptography.NewKeyBoundCryptographyService(privKey)
+
+			if req.Rotation != nil {
+				err = req.Rotation.Sign(signer, *ref)
+			} else {
+				err = req.Revocation.Sign(signer, *ref)
+			}
+			check("failed to sign request", err)
+
+			writeCertificateRequest(args[0], req)
+		},
+	}
+	c.Flags().StringVarP(
+		&keysFile, "keys", "k", "keys.json", "file with public/private keys of discovery node")
+	c.Flags().StringVarP(
+		&discoveryRef, "ref", "r", "", "reference of discovery node")
+	return c
+}
+
+func certificateSubmitCommand() *cobra.Command {
+	var sendURL string
+	c := &cobra.Command{
+		Use:   "submit REQUEST",
+		Short: "announces signed rotation or revocation to network",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			req := readCertificateRequest(args[0])
+			ctx := inslogger.ContextWithTrace(context.Background(), "insolarUtility")
+
+			var (
+				resp *requester.CertificateUpdateResponse
+				err  error
+			)
+			if req.Rotation != nil {
+				resp, err = requester.RotateKey(ctx, sendURL, *req.Rotation)
+			} else {
+				resp, err = requester.RevokeKey(ctx, sendURL, *req.Revocation)
+			}
+			check("[ certificate submit ]", err)
+			fmt.Println("Status :", resp.Status)
+		},
+	}
+	c.Flags().StringVarP(
+		&sendURL, "url", "u", defaultURL(), "admin API URL")
+	return c
+}
+
+func certificateMakeCertCommand() *cobra.Command {
+	var (
+		certFile string
+		output   string
+	)
+	c := &cobra.Command{
+		Use:   "make-cert REQUEST",
+		Short: "makes certificate of node with new key from signed rotation",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			req := readCertificateRequest(args[0])
+			if req.Rotation == nil {
+				check("[ certificate ]", errors.New("request is not a rotation"))
+			}
+
+			cert, err := certificate.RotateCertificate(readCertificateFile(certFile), req.Rotation)
+			check("failed to rotate certificate", err)
+			data, err := cert.Dump()
+			check("failed to dump certificate", err)
+
+			out := io.Writer(os.Stdout)
+			if output != "" {
+				out, err = openFile(output)
+				check("failed to open output file", err)
+			}
+			mustWrite(out, data)
+		},
+	}
+	c.Flags().StringVarP(
+		&certFile, "node-cert", "c", "cert.json", "current certificate of node")
+	c.Flags().StringVarP(
+		&output, "output", "o", "", "output file for new certificate (default stdout)")
+	return c
+}
+
+func certificateListCommand() *cobra.Command {
+	var sendURL string
+	c := &cobra.Command{
+		Use:   "list",
+		Short: "prints rotations and revocations applied by node",
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := inslogger.ContextWithTrace(context.Background(), "insolarUtility")
+			list, err := requester.GetRevocationList(ctx, sendURL)
+			check("[ certificate list ]", err)
+
+			data, err := json.MarshalIndent(list, "", "    ")
+			check("failed to marshal revocation list", err)
+			mustWrite(os.Stdout, string(data)+"\n")
+		},
+	}
+	c.Flags().StringVarP(
+		&sendURL, "url", "u", defaultURL(), "admin API URL")
+	return c
+}
+
+func readCertificateFile(path string) *certificate.Certificate {
+	data, err := ioutil.ReadFile(filepath.Clean(path))
+	check("failed to read certificate", err)
+	cert := &certificate.Certificate{}
+	err = json.Unmarshal(data, cert)
+	check("failed to parse certificate", err)
+	return cert
+}
+
+func readCertificateRequest(path string) certificateRequest {
+	data, err := ioutil.ReadFile(filepath.Clean(path))
+	check("failed to read request", err)
+	req := certificateRequest{}
+	err = json.Unmarshal(data, &req)
+	check("failed to parse request", err)
+	if (req.Rotation == nil) == (req.Revocation == nil) {
+		check("[ certificate ]", errors.New("request must be either rotation or revocation"))
+	}
+	return req
+}
+
+func writeCertificateRequest(path string, req certificateRequest) {
+	data, err := json.MarshalIndent(req, "", "    ")
+	check("failed to marshal request", err)
+
+	out := io.Writer(os.Stdout)
+	if path != "" {
+		out, err = openFile(path)
+		check("failed to open output file", err)
+	}
+	mustWrite(out, string(data)+"\n")
+}
@@ -133,6 +133,7 @@ type NodeConfiguration struct {
 	APIRunner       configuration.APIRunner
 	AdminAPIRunner  configuration.APIRunner
 	KeysPath        string
+	KeyStore        configuration.KeyStore
 	CertificatePath string
 	Tracer          configuration.Tracer
 	Introspection   configuration.Introspection
@@ -151,6 +152,7 @@ func writeNodeConfgi(cfg configuration.Configuration, outputDir string) {
 		APIRunner:       cfg.APIRunner,
 		AdminAPIRunner:  cfg.AdminAPIRunner,
 		KeysPath:        cfg.KeysPath,
+		KeyStore:        cfg.KeyStore,
 		CertificatePath: cfg.CertificatePath,
 		Tracer:          cfg.Tracer,
 		Introspection:   cfg.Introspection,
@@ -0,0 +1,93 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/insolar/insolar/api/requester"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+func decommissionCommand() *cobra.Command {
+	var (
+		sendURL      string
+		statusOnly   bool
+		wait         bool
+		pollInterval time.Duration
+	)
+	c := &cobra.Command{
+		Use:   "decommission",
+		Short: "hands over work of node and takes it out of network",
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := inslogger.ContextWithTrace(context.Background(), "insolarUtility")
+
+			var (
+				status *requester.DecommissionStatusResponse
+				err    error
+			)
+			if statusOnly {
+				status, err = requester.GetDecommissionStatus(ctx, sendURL)
+			} else {
+				status, err = requester.Decommission(ctx, sendURL)
+			}
+			check("[ decommission ]", err)
+			printDecommissionStatus(status)
+
+			for wait && status.State != string(insolar.DecommissionLeft) {
+				time.Sleep(pollInterval)
+				status, err = requester.GetDecommissionStatus(ctx, sendURL)
+				check("[ decommission ]", err)
+				printDecommissionStatus(status)
+			}
+		},
+	}
+	c.Flags().StringVarP(
+		&sendURL, "url", "u", defaultURL(), "admin API URL")
+	c.Flags().BoolVarP(
+		&statusOnly, "status", "s", false, "print decommission status without starting it")
+	c.Flags().BoolVarP(
+		&wait, "wait", "w", false, "wait until node leaves network")
+	c.Flags().DurationVarP(
+		&pollInterval, "poll-interval", "", 2*time.Second, "how often status is requested while waiting")
+	return c
+}
+
+func printDecommissionStatus(status *requester.DecommissionStatusResponse) {
+	fmt.Printf("State : %s\n", status.State)
+	if status.StartedAt != nil {
+		fmt.Printf("Since : %s\n", status.StartedAt.Format(time.RFC3339))
+	}
+	for _, c := range status.Components {
+		progress := "done"
+		if !c.Done {
+			progress = "in progress"
+		}
+		if c.Pending > 0 {
+			progress = fmt.Sprintf("pending %d", c.Pending)
+		}
+		if c.Details != "" {
+			progress += ", " + c.Details
+		}
+		fmt.Printf("  %s : %s\n", c.Component, progress)
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/insolar"
 	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/keystore"
 	"github.com/insolar/insolar/platformpolicy"
 	"github.com/insolar/insolar/version"
 	"github.com/spf13/cobra"
@@ -83,13 +84,17 @@ func main() {
 	addURLFlag(createMemberCmd.Flags())
 	rootCmd.AddCommand(createMemberCmd)
 
+	var passphraseEnv string
 	var genKeysPairCmd = &cobra.Command{
 		Use:   "gen-key-pair",
 		Short: "generates public/private keys pair",
 		Run: func(cmd *cobra.Command, args []string) {
-			generateKeysPair()
+			generateKeysPair(passphraseEnv)
 		},
 	}
+	genKeysPairCmd.Flags().StringVarP(
+		&passphraseEnv, "passphrase-env", "", "",
+		"encrypt private key with passphrase from this environment variable (e.g. INSOLAR_KEYS_PASSPHRASE)")
 	rootCmd.AddCommand(genKeysPairCmd)
 
 	var genMigrationAddressesCmd = &cobra.Command{
@@ -160,6 +165,12 @@ func main() {
 
 	rootCmd.AddCommand(bootstrapCommand())
 
+	rootCmd.AddCommand(decommissionCommand())
+
+	rootCmd.AddCommand(certificateCommand())
+
+	rootCmd.AddCommand(signerCommand())
+
 	var (
 		configsOutputDir string
 	)
@@ -270,7 +281,7 @@ func generateMigrationAddresses() {
 	mustWrite(os.Stdout, string(result))
 }
 
-func generateKeysPair() {
+func generateKeysPair(passphraseEnv string) {
 	ks := platformpolicy.NewKeyProcessor()
 
 	privKey, err := ks.GeneratePrivateKey()
@@ -279,6 +290,15 @@ func generateKeysPair() {
 	privKeyStr, err := ks.ExportPrivateKeyPEM(privKey)
 	check("Problems with serialization of private key:", err)
 
+	if passphraseEnv != "" {
+		passphrase := os.Getenv(passphraseEnv)
+		if passphrase == "" {
+			check("Problems with encryption of private key:", fmt.Errorf("environment variable %s is not set", passphraseEnv))
+		}
+		privKeyStr, err = keystore.EncryptPrivateKey(privKeyStr, []byte(passphrase))
+		check("Problems with encryption of private key:", err)
+	}
+
 	pubKeyStr, err := ks.ExportPublicKeyPEM(ks.ExtractPublicKey(privKey))
 	check("Problems with serialization of public key:", err)
 
@@ -0,0 +1,75 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/insolar/insolar/keystore"
+)
+
+func signerCommand() *cobra.Command {
+	var (
+		keysFile string
+		socket   string
+	)
+	c := &cobra.Command{
+		Use:   "signer",
+		Short: "serves node private key as external signer on unix socket",
+		Long: "Serves node private key as external signer, so node itself never holds the key.\n" +
+			"Configure node with keystore.backend: signer and keystore.signeraddress set to the socket.\n" +
+			"Encrypted key file is decrypted with passphrase from INSOLAR_KEYS_PASSPHRASE.",
+		Run: func(cmd *cobra.Command, args []string) {
+			ks, err := keystore.NewKeyStore(keysFile)
+			check("[ signer ] failed to load keys:", err)
+
+			listener, err := net.Listen("unix", socket)
+			check("[ signer ] failed to listen:", err)
+			err = os.Chmod(socket, 0600)
+			check("[ signer ] failed to restrict socket permissions:", err)
+
+			sig := make(chan os.Signal, 1)
+			stopped := make(chan struct{})
+			signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+			go func() {
+				<-sig
+				close(stopped)
+				// closing listener removes socket file
+				_ = listener.Close()
+			}()
+
+			fmt.Fprintf(os.Stderr, "Serving signer on %s\n", socket)
+			err = keystore.ServeSigner(listener, ks)
+			select {
+			case <-stopped:
+			default:
+				check("[ signer ]", err)
+			}
+		},
+	}
+	c.Flags().StringVarP(
+		&keysFile, "keys-file", "k", "keys.json", "file with node keys")
+	c.Flags().StringVarP(
+		&socket, "socket", "s", "signer.sock", "unix socket to listen on")
+	return c
+}
@@ -0,0 +1,257 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/jet"
+	"github.com/insolar/insolar/insolar/store"
+)
+
+var (
+	dbPath    string
+	backend   string
+	scopes    []string
+	fromPulse uint32
+	toPulse   uint32
+	objectID  string
+	jetID     string
+	format    string
+	limit     int
+	help      bool
+)
+
+func usage() {
+	pflag.Usage()
+	os.Exit(0)
+}
+
+func parseInputParams() {
+	pflag.StringVarP(
+		&dbPath, "db", "d", "", "data directory of a stopped heavy node (required)")
+	pflag.StringVarP(
+		&backend, "backend", "b", configuration.NewLedger().Storage.Backend,
+		"storage backend of the db: "+configuration.StorageBackendBadger+" or "+configuration.StorageBackendSkipList)
+	pflag.StringSliceVarP(
+		&scopes, "scope", "s", nil, "scopes to dump: "+strings.Join(scopeNames(), ", ")+" (default all)")
+	pflag.Uint32VarP(
+		&fromPulse, "from", "f", 0, "lower bound of pulse range (inclusive)")
+	pflag.Uint32VarP(
+		&toPulse, "to", "t", 0, "upper bound of pulse range (inclusive)")
+	pflag.StringVarP(
+		&objectID, "object", "o", "", "object id (base58) to filter records, indexes and lifelines")
+	pflag.StringVarP(
+		&jetID, "jet", "j", "", "jet id as a binary prefix (e.g. 0101) to filter records, drops and jets")
+	pflag.StringVarP(
+		&format, "format", "F", formatTable, "output format: "+formatTable+" or "+formatJSON)
+	pflag.IntVarP(
+		&limit, "limit", "l", 0, "maximum number of entries per scope (0 means no limit)")
+	pflag.BoolVarP(
+		&help, "help", "h", false, "show this help")
+
+	pflag.Parse()
+
+	if help {
+		usage()
+	}
+
+	if len(dbPath) == 0 {
+		println("db is required\n")
+		usage()
+	}
+}
+
+func printError(err error, message string) {
+	println(errors.Wrap(err, "ERROR "+message).Error())
+}
+
+// filter selects entries to print. Zero values mean no filtering.
+type filter struct {
+	from, to insolar.PulseNumber
+	objectID *insolar.ID
+	jetID    *insolar.JetID
+}
+
+func newFilter(from, to uint32, object, jetPrefix string) (*filter, error) {
+	f := &filter{
+		from: insolar.PulseNumber(from),
+		to:   insolar.PulseNumber(to),
+	}
+	if f.to != 0 && f.from > f.to {
+		return nil, errors.Errorf("wrong pulse range %v-%v", f.from, f.to)
+	}
+
+	if object != "" {
+		id, err := insolar.NewIDFromBase58(object)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse object id")
+		}
+		f.objectID = id
+	}
+
+	if jetPrefix != "" {
+		if strings.Trim(jetPrefix, "01") != "" {
+			return nil, errors.Errorf("jet id %q must be a binary prefix", jetPrefix)
+		}
+		id := jet.NewIDFromString(jetPrefix)
+		f.jetID = &id
+	}
+
+	return f, nil
+}
+
+// afterRange returns true if all the next entries of pulse prefixed scope are out of range.
+func (f *filter) afterRange(pn insolar.PulseNumber) bool {
+	return f.to != 0 && pn > f.to
+}
+
+func (f *filter) match(e *entry) bool {
+	if e.Pulse < f.from {
+		return false
+	}
+	if f.afterRange(e.Pulse) {
+		return false
+	}
+
+	if f.objectID != nil {
+		if e.objectID == nil || *e.objectID != *f.objectID {
+			return false
+		}
+	}
+
+	if f.jetID != nil {
+		found := false
+		for _, j := range e.jets {
+			if j.Equal(*f.jetID) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// openDB opens existing db of the storage backend read-only.
+func openDB(cfg configuration.Storage) (store.Engine, error) {
+	if _, err := os.Stat(cfg.DataDirectory); err != nil {
+		return nil, err
+	}
+	return store.NewReadOnlyEngine(cfg)
+}
+
+// dump iterates over provided scopes and prints matched entries.
+func dump(db store.DB, dumpers []scopeDumper, f *filter, p printer, limit int) error {
+	for _, d := range dumpers {
+		pivot := scopeKey{scope: d.scope}
+		if d.pulsePrefixed && f.from != 0 {
+			pivot.id = f.from.Bytes()
+		}
+
+		err := func() error {
+			it := db.NewIterator(pivot, false)
+			defer it.Close()
+
+			printed := 0
+			for it.Next() {
+				if limit > 0 && printed >= limit {
+					return nil
+				}
+
+				value, err := it.Value()
+				if err != nil {
+					return errors.Wrapf(err, "failed to read %s value", d.name)
+				}
+				e, err := d.decode(it.Key(), value)
+				if err != nil {
+					return errors.Wrapf(err, "failed to decode %s key %x", d.name, it.Key())
+				}
+				e.Scope = d.name
+
+				if d.pulsePrefixed && f.afterRange(e.Pulse) {
+					return nil
+				}
+				if !f.match(e) {
+					continue
+				}
+
+				err = p.print(e)
+				if err != nil {
+					return errors.Wrap(err, "failed to print entry")
+				}
+				printed++
+			}
+			return nil
+		}()
+		if err != nil {
+			return err
+		}
+	}
+	return p.flush()
+}
+
+func main() {
+	parseInputParams()
+
+	selected, err := selectDumpers(scopes)
+	if err != nil {
+		printError(err, "wrong scope")
+		os.Exit(1)
+	}
+
+	f, err := newFilter(fromPulse, toPulse, objectID, jetID)
+	if err != nil {
+		printError(err, "wrong filter")
+		os.Exit(1)
+	}
+
+	p, err := newPrinter(format, os.Stdout)
+	if err != nil {
+		printError(err, "wrong format")
+		os.Exit(1)
+	}
+
+	db, err := openDB(configuration.Storage{DataDirectory: dbPath, Backend: backend})
+	if err != nil {
+		printError(err, "failed to open db")
+		os.Exit(1)
+	}
+	closeDB := func() {
+		err := db.Stop(context.Background())
+		if err != nil {
+			printError(err, "failed to close db")
+		}
+	}
+
+	err = dump(db, selected, f, p, limit)
+	closeDB()
+	if err != nil {
+		printError(err, "failed to dump ledger")
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,289 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/gen"
+	"github.com/insolar/insolar/insolar/jet"
+	insolarPulse "github.com/insolar/insolar/insolar/pulse"
+	"github.com/insolar/insolar/insolar/record"
+	"github.com/insolar/insolar/insolar/store"
+	"github.com/insolar/insolar/ledger/drop"
+	"github.com/insolar/insolar/ledger/object"
+	"github.com/insolar/insolar/pulse"
+)
+
+type ledgerFixture struct {
+	storage configuration.Storage
+	pulses  []insolar.PulseNumber
+	objects []insolar.ID
+	jets    []insolar.JetID
+}
+
+// newLedgerFixture fills db of the backend with two pulses, records of two objects in different jets and their drops.
+func newLedgerFixture(t *testing.T, backend string) *ledgerFixture {
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "ledgerdump-")
+	require.NoError(t, err)
+
+	storage := configuration.Storage{DataDirectory: dir, Backend: backend}
+	db, err := store.NewEngine(storage)
+	require.NoError(t, err)
+
+	first := insolar.PulseNumber(pulse.MinTimePulse)
+	f := &ledgerFixture{
+		storage: storage,
+		pulses:  []insolar.PulseNumber{first, first + 10},
+		objects: []insolar.ID{gen.IDWithPulse(first), gen.IDWithPulse(first)},
+		jets:    []insolar.JetID{jet.NewIDFromString("0"), jet.NewIDFromString("1")},
+	}
+
+	pulses := insolarPulse.NewDB(db)
+	records := object.NewRecordDB(db)
+	indexes := object.NewIndexDB(db, records)
+	drops := drop.NewDB(db)
+	jets := jet.NewDBStore(db)
+
+	for _, pn := range f.pulses {
+		err = pulses.Append(ctx, insolar.Pulse{PulseNumber: pn})
+		require.NoError(t, err)
+
+		err = jets.Update(ctx, pn, true, f.jets...)
+		require.NoError(t, err)
+
+		for i, objID := range f.objects {
+			err = records.Set(ctx, record.Material{
+				ID:       gen.IDWithPulse(pn),
+				ObjectID: objID,
+				JetID:    f.jets[i],
+				Virtual:  record.Wrap(&record.IncomingRequest{Method: "Call"}),
+			})
+			require.NoError(t, err)
+
+			err = indexes.SetIndex(ctx, pn, record.Index{ObjID: objID, LifelineLastUsed: pn})
+			require.NoError(t, err)
+
+			err = drops.Set(ctx, drop.Drop{Pulse: pn, JetID: f.jets[i]})
+			require.NoError(t, err)
+		}
+
+		err = indexes.UpdateLastKnownPulse(ctx, pn)
+		require.NoError(t, err)
+	}
+
+	err = db.Stop(ctx)
+	require.NoError(t, err)
+
+	return f
+}
+
+func (f *ledgerFixture) dump(t *testing.T, scopes []string, flt *filter) []map[string]interface{} {
+	db, err := openDB(f.storage)
+	require.NoError(t, err)
+	defer db.Stop(context.Background())
+
+	selected, err := selectDumpers(scopes)
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	p, err := newPrinter(formatJSON, buf)
+	require.NoError(t, err)
+
+	err = dump(db, selected, flt, p, 0)
+	require.NoError(t, err)
+
+	var entries []map[string]interface{}
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		e := map[string]interface{}{}
+		err = json.Unmarshal(scanner.Bytes(), &e)
+		require.NoError(t, err)
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+func countScopes(entries []map[string]interface{}) map[string]int {
+	res := map[string]int{}
+	for _, e := range entries {
+		res[e["scope"].(string)]++
+	}
+	return res
+}
+
+func TestDump(t *testing.T) {
+	f := newLedgerFixture(t, configuration.StorageBackendBadger)
+	defer os.RemoveAll(f.storage.DataDirectory)
+
+	t.Run("all scopes", func(t *testing.T) {
+		entries := f.dump(t, nil, &filter{})
+
+		counts := countScopes(entries)
+		require.Equal(t, 2, counts["pulse"])
+		require.Equal(t, 4, counts["record"])
+		require.Equal(t, 4, counts["drop"])
+		require.Equal(t, 4, counts["index"])
+		require.Equal(t, 2, counts["last-known-index"])
+		require.Equal(t, 2, counts["jet-tree"])
+		require.NotZero(t, counts["record-position"])
+	})
+
+	t.Run("pulse range", func(t *testing.T) {
+		flt, err := newFilter(uint32(f.pulses[1]), uint32(f.pulses[1]), "", "")
+		require.NoError(t, err)
+
+		entries := f.dump(t, []string{"record", "drop", "pulse"}, flt)
+
+		counts := countScopes(entries)
+		require.Equal(t, 1, counts["pulse"])
+		require.Equal(t, 2, counts["record"])
+		require.Equal(t, 2, counts["drop"])
+		for _, e := range entries {
+			require.Equal(t, float64(f.pulses[1]), e["pulse"])
+		}
+	})
+
+	t.Run("object", func(t *testing.T) {
+		flt, err := newFilter(0, 0, f.objects[0].String(), "")
+		require.NoError(t, err)
+
+		entries := f.dump(t, []string{"record", "index", "drop"}, flt)
+
+		counts := countScopes(entries)
+		require.Equal(t, 2, counts["record"])
+		require.Equal(t, 2, counts["index"])
+		require.Zero(t, counts["drop"])
+		for _, e := range entries {
+			if e["scope"] == "record" {
+				require.Equal(t, f.objects[0].String(), e["value"].(map[string]interface{})["ObjectID"])
+			}
+		}
+	})
+
+	t.Run("jet", func(t *testing.T) {
+		flt, err := newFilter(0, 0, "", "1")
+		require.NoError(t, err)
+
+		entries := f.dump(t, []string{"record", "drop"}, flt)
+
+		counts := countScopes(entries)
+		require.Equal(t, 2, counts["record"])
+		require.Equal(t, 2, counts["drop"])
+	})
+}
+
+func TestNewFilter(t *testing.T) {
+	_, err := newFilter(20, 10, "", "")
+	require.Error(t, err)
+
+	_, err = newFilter(0, 0, "not an id", "")
+	require.Error(t, err)
+
+	_, err = newFilter(0, 0, "", "012")
+	require.Error(t, err)
+}
+
+func TestSelectDumpers(t *testing.T) {
+	selected, err := selectDumpers(nil)
+	require.NoError(t, err)
+	require.Len(t, selected, len(dumpers))
+
+	selected, err = selectDumpers([]string{"record", "jet-keeper"})
+	require.NoError(t, err)
+	require.Len(t, selected, 2)
+
+	_, err = selectDumpers([]string{"unknown"})
+	require.Error(t, err)
+}
+
+func TestDump_SkipList(t *testing.T) {
+	f := newLedgerFixture(t, configuration.StorageBackendSkipList)
+	defer os.RemoveAll(f.storage.DataDirectory)
+
+	entries := f.dump(t, []string{"pulse", "record", "drop"}, &filter{})
+
+	counts := countScopes(entries)
+	require.Equal(t, 2, counts["pulse"])
+	require.Equal(t, 4, counts["record"])
+	require.Equal(t, 4, counts["drop"])
+}
+
+func TestOpenDB_Missing(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ledgerdump-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	missing := dir + "/missing"
+	_, err = openDB(configuration.Storage{DataDirectory: missing, Backend: configuration.StorageBackendBadger})
+	require.Error(t, err)
+	_, err = os.Stat(missing)
+	require.True(t, os.IsNotExist(err))
+}
+
+// readDir returns content of every file in dir by its path.
+func readDir(t *testing.T, dir string) map[string][]byte {
+	files := map[string][]byte{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[path] = data
+		return nil
+	})
+	require.NoError(t, err)
+	return files
+}
+
+func TestDump_ReadOnly(t *testing.T) {
+	for _, backend := range []string{configuration.StorageBackendBadger, configuration.StorageBackendSkipList} {
+		t.Run(backend, func(t *testing.T) {
+			f := newLedgerFixture(t, backend)
+			defer os.RemoveAll(f.storage.DataDirectory)
+
+			before := readDir(t, f.storage.DataDirectory)
+
+			entries := f.dump(t, nil, &filter{})
+			require.NotEmpty(t, entries)
+
+			db, err := openDB(f.storage)
+			require.NoError(t, err)
+			err = db.Set(scopeKey{scope: store.ScopePulse}, []byte{1})
+			require.Error(t, err)
+			require.NoError(t, db.Stop(context.Background()))
+
+			require.Equal(t, before, readDir(t, f.storage.DataDirectory))
+		})
+	}
+}
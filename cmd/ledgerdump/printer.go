@@ -0,0 +1,79 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+type printer interface {
+	print(e *entry) error
+	flush() error
+}
+
+func newPrinter(format string, w io.Writer) (printer, error) {
+	switch format {
+	case formatTable:
+		return newTablePrinter(w), nil
+	case formatJSON:
+		return &jsonPrinter{enc: json.NewEncoder(w)}, nil
+	default:
+		return nil, errors.Errorf("unknown format %q", format)
+	}
+}
+
+// jsonPrinter prints one JSON object per line.
+type jsonPrinter struct {
+	enc *json.Encoder
+}
+
+func (p *jsonPrinter) print(e *entry) error {
+	return p.enc.Encode(e)
+}
+
+func (p *jsonPrinter) flush() error {
+	return nil
+}
+
+type tablePrinter struct {
+	w *tabwriter.Writer
+}
+
+func newTablePrinter(w io.Writer) *tablePrinter {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "SCOPE\tPULSE\tKEY\tVALUE")
+	return &tablePrinter{w: tw}
+}
+
+func (p *tablePrinter) print(e *entry) error {
+	_, err := fmt.Fprintf(p.w, "%s\t%v\t%s\t%s\n", e.Scope, e.Pulse, e.Key, e.summary)
+	return err
+}
+
+func (p *tablePrinter) flush() error {
+	return p.w.Flush()
+}
@@ -0,0 +1,452 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/jet"
+	"github.com/insolar/insolar/insolar/record"
+	"github.com/insolar/insolar/insolar/store"
+	"github.com/insolar/insolar/ledger/drop"
+	"github.com/insolar/insolar/ledger/heavy/executor"
+)
+
+// Key prefixes of ScopeRecordPosition, see ledger/object/record_db.go.
+const (
+	recordPositionKeyPrefix          = 0x01
+	lastKnownRecordPositionKeyPrefix = 0x02
+)
+
+// entry is a single decoded key-value pair of the ledger.
+type entry struct {
+	Scope string              `json:"scope"`
+	Pulse insolar.PulseNumber `json:"pulse,omitempty"`
+	Key   string              `json:"key"`
+	Value interface{}         `json:"value"`
+
+	// summary is a short value representation for table output.
+	summary string
+	// objectID and jets are used by filters.
+	objectID *insolar.ID
+	jets     []insolar.JetID
+}
+
+// scopeDumper decodes keys and values of a single store scope.
+type scopeDumper struct {
+	name  string
+	scope store.Scope
+	// pulsePrefixed is true if keys of the scope start with a pulse number,
+	// so the scope can be iterated from the lower bound of pulse range.
+	pulsePrefixed bool
+	decode        func(key, value []byte) (*entry, error)
+}
+
+// scopeKey is a pivot key for iteration over the scope.
+type scopeKey struct {
+	scope store.Scope
+	id    []byte
+}
+
+func (k scopeKey) Scope() store.Scope {
+	return k.scope
+}
+
+func (k scopeKey) ID() []byte {
+	return k.id
+}
+
+var dumpers = []scopeDumper{
+	{name: "pulse", scope: store.ScopePulse, pulsePrefixed: true, decode: decodePulse},
+	{name: "record", scope: store.ScopeRecord, pulsePrefixed: true, decode: decodeRecord},
+	{name: "drop", scope: store.ScopeJetDrop, pulsePrefixed: true, decode: decodeDrop},
+	{name: "index", scope: store.ScopeIndex, pulsePrefixed: true, decode: decodeIndex},
+	{name: "last-known-index", scope: store.ScopeLastKnownIndexPN, pulsePrefixed: true, decode: decodeLastKnownIndex},
+	{name: "genesis", scope: store.ScopeGenesis, decode: decodeGenesis},
+	{name: "jet-tree", scope: store.ScopeJetTree, pulsePrefixed: true, decode: decodeJetTree},
+	{name: "jet-keeper", scope: store.ScopeJetKeeper, pulsePrefixed: true, decode: decodeJetKeeper},
+	{name: "sync-pulse", scope: store.ScopeJetKeeperSyncPulse, decode: decodeSyncPulse},
+	{name: "record-position", scope: store.ScopeRecordPosition, decode: decodeRecordPosition},
+}
+
+func scopeNames() []string {
+	names := make([]string, 0, len(dumpers))
+	for _, d := range dumpers {
+		names = append(names, d.name)
+	}
+	return names
+}
+
+// selectDumpers returns dumpers for provided scope names. All the dumpers are returned for empty list.
+func selectDumpers(names []string) ([]scopeDumper, error) {
+	if len(names) == 0 {
+		return dumpers, nil
+	}
+
+	var selected []scopeDumper
+	for _, name := range names {
+		found := false
+		for _, d := range dumpers {
+			if d.name == name {
+				selected = append(selected, d)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.Errorf("unknown scope %q, available scopes: %s", name, strings.Join(scopeNames(), ", "))
+		}
+	}
+	return selected, nil
+}
+
+func parsePulse(raw []byte) (insolar.PulseNumber, error) {
+	if len(raw) < insolar.PulseNumberSize {
+		return 0, errors.Errorf("wrong pulse number length %d", len(raw))
+	}
+	return insolar.NewPulseNumber(raw), nil
+}
+
+// pulseNode mirrors storage layout of insolar/pulse.DB.
+type pulseNode struct {
+	Pulse      insolar.Pulse
+	Prev, Next *insolar.PulseNumber
+}
+
+type pulseView struct {
+	PulseNumber      insolar.PulseNumber
+	PrevPulseNumber  insolar.PulseNumber
+	NextPulseNumber  insolar.PulseNumber
+	PulseTimestamp   int64
+	EpochPulseNumber int
+	Prev             *insolar.PulseNumber `json:",omitempty"`
+	Next             *insolar.PulseNumber `json:",omitempty"`
+}
+
+func decodePulse(key, value []byte) (*entry, error) {
+	pn, err := parsePulse(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nd := pulseNode{}
+	err = insolar.Deserialize(value, &nd)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode pulse")
+	}
+
+	return &entry{
+		Pulse: pn,
+		Key:   pn.String(),
+		Value: pulseView{
+			PulseNumber:      nd.Pulse.PulseNumber,
+			PrevPulseNumber:  nd.Pulse.PrevPulseNumber,
+			NextPulseNumber:  nd.Pulse.NextPulseNumber,
+			PulseTimestamp:   nd.Pulse.PulseTimestamp,
+			EpochPulseNumber: nd.Pulse.EpochPulseNumber,
+			Prev:             nd.Prev,
+			Next:             nd.Next,
+		},
+		summary: fmt.Sprintf("prev=%v next=%v timestamp=%d", nd.Pulse.PrevPulseNumber, nd.Pulse.NextPulseNumber, nd.Pulse.PulseTimestamp),
+	}, nil
+}
+
+type recordView struct {
+	ID       insolar.ID
+	ObjectID insolar.ID
+	JetID    string
+	Type     string
+	Record   record.Record `json:",omitempty"`
+}
+
+func decodeRecord(key, value []byte) (*entry, error) {
+	pn, err := parsePulse(key)
+	if err != nil {
+		return nil, err
+	}
+	id := *insolar.NewIDFromBytes(key)
+
+	rec := record.Material{}
+	err = rec.Unmarshal(value)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode record")
+	}
+
+	view := recordView{
+		ID:       rec.ID,
+		ObjectID: rec.ObjectID,
+		JetID:    rec.JetID.DebugString(),
+		Type:     "Unknown",
+	}
+	if rec.Virtual.Union != nil {
+		view.Record = record.Unwrap(&rec.Virtual)
+		view.Type = strings.TrimPrefix(fmt.Sprintf("%T", view.Record), "*record.")
+	}
+
+	return &entry{
+		Pulse:    pn,
+		Key:      id.String(),
+		Value:    &view,
+		summary:  fmt.Sprintf("%s object=%s jet=%s", view.Type, rec.ObjectID.String(), view.JetID),
+		objectID: &rec.ObjectID,
+		jets:     []insolar.JetID{rec.JetID},
+	}, nil
+}
+
+type dropView struct {
+	Pulse                  insolar.PulseNumber
+	JetID                  string
+	SplitThresholdExceeded int64
+	Split                  bool
+}
+
+func decodeDrop(key, value []byte) (*entry, error) {
+	pn, err := parsePulse(key)
+	if err != nil {
+		return nil, err
+	}
+
+	d := drop.Drop{}
+	err = d.Unmarshal(value)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode drop")
+	}
+
+	return &entry{
+		Pulse: pn,
+		Key:   fmt.Sprintf("%v %s", pn, d.JetID.DebugString()),
+		Value: dropView{
+			Pulse:                  d.Pulse,
+			JetID:                  d.JetID.DebugString(),
+			SplitThresholdExceeded: d.SplitThresholdExceeded,
+			Split:                  d.Split,
+		},
+		summary: fmt.Sprintf("jet=%s split=%v threshold=%d", d.JetID.DebugString(), d.Split, d.SplitThresholdExceeded),
+		jets:    []insolar.JetID{d.JetID},
+	}, nil
+}
+
+func decodeIndex(key, value []byte) (*entry, error) {
+	pn, err := parsePulse(key)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := record.Index{}
+	err = idx.Unmarshal(value)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode index")
+	}
+
+	latestState := "<nil>"
+	if idx.Lifeline.LatestState != nil {
+		latestState = idx.Lifeline.LatestState.String()
+	}
+
+	return &entry{
+		Pulse:    pn,
+		Key:      fmt.Sprintf("%v %s", pn, idx.ObjID.String()),
+		Value:    &idx,
+		summary:  fmt.Sprintf("state=%d latest=%s pendings=%d", idx.Lifeline.StateID, latestState, len(idx.PendingRecords)),
+		objectID: &idx.ObjID,
+	}, nil
+}
+
+func decodeLastKnownIndex(key, value []byte) (*entry, error) {
+	pn, err := parsePulse(key)
+	if err != nil {
+		return nil, err
+	}
+	objID := *insolar.NewID(pn, key[pn.Size():])
+
+	lastKnown, err := parsePulse(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry{
+		Pulse:    pn,
+		Key:      objID.String(),
+		Value:    lastKnown,
+		summary:  lastKnown.String(),
+		objectID: &objID,
+	}, nil
+}
+
+func decodeGenesis(key, value []byte) (*entry, error) {
+	pn, err := parsePulse(key)
+	if err != nil {
+		return nil, err
+	}
+
+	ref := insolar.NewReferenceFromBytes(value)
+	if ref == nil {
+		return nil, errors.New("failed to decode genesis reference")
+	}
+
+	return &entry{
+		Pulse:   pn,
+		Key:     pn.String(),
+		Value:   ref,
+		summary: ref.String(),
+	}, nil
+}
+
+type jetTreeView struct {
+	Leaves []string
+	Tree   string
+}
+
+func decodeJetTree(key, value []byte) (*entry, error) {
+	pn, err := parsePulse(key)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := jet.Tree{}
+	err = tree.Unmarshal(value)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode jet tree")
+	}
+
+	leaves := tree.LeafIDs()
+	view := jetTreeView{Tree: tree.String()}
+	for _, id := range leaves {
+		view.Leaves = append(view.Leaves, id.DebugString())
+	}
+
+	return &entry{
+		Pulse:   pn,
+		Key:     pn.String(),
+		Value:   view,
+		summary: strings.Join(view.Leaves, " "),
+		jets:    leaves,
+	}, nil
+}
+
+type jetInfoView struct {
+	JetID           string
+	HotConfirmed    []string
+	DropConfirmed   bool
+	BackupConfirmed bool
+	Split           bool
+	IsSplitSet      bool
+}
+
+func decodeJetKeeper(key, value []byte) (*entry, error) {
+	pn, err := parsePulse(key)
+	if err != nil {
+		return nil, err
+	}
+
+	info := executor.JetsInfo{}
+	err = info.Unmarshal(value)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode jets info")
+	}
+
+	var (
+		views   []jetInfoView
+		jets    []insolar.JetID
+		summary []string
+	)
+	for _, j := range info.Jets {
+		v := jetInfoView{
+			JetID:           j.JetID.DebugString(),
+			DropConfirmed:   j.DropConfirmed,
+			BackupConfirmed: j.BackupConfirmed,
+			Split:           j.Split,
+			IsSplitSet:      j.IsSplitSet,
+		}
+		for _, hot := range j.HotConfirmed {
+			v.HotConfirmed = append(v.HotConfirmed, hot.DebugString())
+		}
+		views = append(views, v)
+		jets = append(jets, j.JetID)
+		summary = append(summary, fmt.Sprintf("%s(hot=%d drop=%v backup=%v)", v.JetID, len(v.HotConfirmed), v.DropConfirmed, v.BackupConfirmed))
+	}
+
+	return &entry{
+		Pulse:   pn,
+		Key:     pn.String(),
+		Value:   views,
+		summary: strings.Join(summary, " "),
+		jets:    jets,
+	}, nil
+}
+
+func decodeSyncPulse(key, value []byte) (*entry, error) {
+	pn, err := parsePulse(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry{
+		Pulse:   pn,
+		Key:     "top-sync-pulse",
+		Value:   pn,
+		summary: pn.String(),
+	}, nil
+}
+
+func decodeRecordPosition(key, value []byte) (*entry, error) {
+	if len(key) == 0 {
+		return nil, errors.New("empty record position key")
+	}
+
+	pn, err := parsePulse(key[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	switch key[0] {
+	case recordPositionKeyPrefix:
+		tail := key[1+pn.Size():]
+		if len(tail) != 4 {
+			return nil, errors.Errorf("wrong record position length %d", len(tail))
+		}
+		number := binary.BigEndian.Uint32(tail)
+		id := insolar.NewIDFromBytes(value)
+		if id == nil {
+			return nil, errors.New("failed to decode record id")
+		}
+		return &entry{
+			Pulse:   pn,
+			Key:     fmt.Sprintf("%v #%d", pn, number),
+			Value:   id,
+			summary: id.String(),
+		}, nil
+	case lastKnownRecordPositionKeyPrefix:
+		if len(value) != 4 {
+			return nil, errors.Errorf("wrong last known position length %d", len(value))
+		}
+		position := binary.BigEndian.Uint32(value)
+		return &entry{
+			Pulse:   pn,
+			Key:     fmt.Sprintf("%v last", pn),
+			Value:   position,
+			summary: fmt.Sprintf("%d", position),
+		}, nil
+	default:
+		return nil, errors.Errorf("unknown record position key prefix %d", key[0])
+	}
+}
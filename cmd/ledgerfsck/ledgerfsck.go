@@ -0,0 +1,163 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/jet"
+	insolarPulse "github.com/insolar/insolar/insolar/pulse"
+	"github.com/insolar/insolar/insolar/store"
+	"github.com/insolar/insolar/ledger/drop"
+	"github.com/insolar/insolar/ledger/heavy/executor"
+	"github.com/insolar/insolar/ledger/object"
+)
+
+var (
+	dbPath    string
+	backend   string
+	fromPulse uint32
+	toPulse   uint32
+	repair    bool
+	help      bool
+)
+
+func usage() {
+	pflag.Usage()
+	os.Exit(0)
+}
+
+func parseInputParams() {
+	pflag.StringVarP(
+		&dbPath, "db", "d", "", "data directory of a stopped heavy node (required)")
+	pflag.StringVarP(
+		&backend, "backend", "b", configuration.NewLedger().Storage.Backend,
+		"storage backend of the db: "+configuration.StorageBackendBadger+" or "+configuration.StorageBackendSkipList)
+	pflag.Uint32VarP(
+		&fromPulse, "from", "f", 0, "first pulse to check (default first pulse)")
+	pflag.Uint32VarP(
+		&toPulse, "to", "t", 0, "last pulse to check (default top sync pulse)")
+	pflag.BoolVarP(
+		&repair, "repair", "r", false, "repair indexes that can be derived from records")
+	pflag.BoolVarP(
+		&help, "help", "h", false, "show this help")
+
+	pflag.Parse()
+
+	if help {
+		usage()
+	}
+
+	if len(dbPath) == 0 {
+		println("db is required\n")
+		usage()
+	}
+}
+
+func printError(err error, message string) {
+	println(errors.Wrap(err, "ERROR "+message).Error())
+}
+
+func printViolations(violations []executor.Violation) error {
+	enc := json.NewEncoder(os.Stdout)
+	for _, v := range violations {
+		err := enc.Encode(v)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// check prints violations as JSON lines and returns true if the db is consistent.
+func check(ctx context.Context, db store.DB) (bool, error) {
+	pulses := insolarPulse.NewDB(db)
+	records := object.NewRecordDB(db)
+	indexes := object.NewIndexDB(db, records)
+	jets := jet.NewDBStore(db)
+	jetKeeper := executor.NewJetKeeper(jets, db, pulses)
+	checker := executor.NewConsistencyChecker(pulses, records, indexes, drop.NewDB(db), jets, jetKeeper)
+
+	from, to := insolar.PulseNumber(fromPulse), insolar.PulseNumber(toPulse)
+	violations, err := checker.Check(ctx, from, to)
+	if err != nil {
+		return false, errors.Wrap(err, "check failed")
+	}
+
+	if repair && len(violations) > 0 {
+		err = checker.Repair(ctx, violations)
+		if err != nil {
+			return false, errors.Wrap(err, "repair failed")
+		}
+		fmt.Fprintf(os.Stderr, "repaired, checking again\n")
+
+		violations, err = checker.Check(ctx, from, to)
+		if err != nil {
+			return false, errors.Wrap(err, "check failed")
+		}
+	}
+
+	err = printViolations(violations)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to print violations")
+	}
+	fmt.Fprintf(os.Stderr, "top sync pulse: %v, violations found: %d\n", jetKeeper.TopSyncPulse(), len(violations))
+
+	return len(violations) == 0, nil
+}
+
+func main() {
+	parseInputParams()
+
+	ctx := context.Background()
+
+	if _, err := os.Stat(dbPath); err != nil {
+		printError(err, "failed to open db")
+		os.Exit(2)
+	}
+	// db is written on repair only
+	open := store.NewReadOnlyEngine
+	if repair {
+		open = store.NewEngine
+	}
+	db, err := open(configuration.Storage{DataDirectory: dbPath, Backend: backend})
+	if err != nil {
+		printError(err, "failed to open db")
+		os.Exit(2)
+	}
+
+	consistent, err := check(ctx, db)
+
+	if stopErr := db.Stop(ctx); stopErr != nil {
+		printError(stopErr, "failed to close db")
+	}
+	if err != nil {
+		printError(err, "failed to check ledger")
+		os.Exit(2)
+	}
+	if !consistent {
+		os.Exit(1)
+	}
+}
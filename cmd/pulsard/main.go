@@ -19,6 +19,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -30,7 +31,7 @@ import (
 	"github.com/insolar/insolar/component"
 	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/cryptography"
-	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/store"
 	"github.com/insolar/insolar/insolar/utils"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/instrumentation/instracer"
@@ -41,7 +42,7 @@ import (
 	"github.com/insolar/insolar/platformpolicy"
 	"github.com/insolar/insolar/pulsar"
 	"github.com/insolar/insolar/pulsar/entropygenerator"
-	"github.com/insolar/insolar/pulse"
+	"github.com/insolar/insolar/pulsar/storage"
 	"github.com/insolar/insolar/version"
 )
 
@@ -97,11 +98,19 @@ func main() {
 	}
 	defer jaegerflush()
 
-	cm, server := initPulsar(ctx, cfgHolder.Configuration)
-	pulseTicker := runPulsar(ctx, server, cfgHolder.Configuration.Pulsar)
+	cm, server, stopServer := initPulsar(ctx, cfgHolder.Configuration)
+	var stopPulsar func()
+	if server.Federation != nil {
+		stopPulsar = runFederatedPulsar(ctx, server, cfgHolder.Configuration.Pulsar)
+	} else {
+		stopPulsar = runPulsar(ctx, server, cfgHolder.Configuration.Pulsar)
+	}
+	stopAdmin := runAdmin(ctx, server, cfgHolder.Configuration.Pulsar)
 
 	defer func() {
-		pulseTicker.Stop()
+		stopAdmin()
+		stopPulsar()
+		stopServer()
 		err = cm.Stop(ctx)
 		if err != nil {
 			inslog.Error(err)
@@ -115,7 +124,7 @@ func main() {
 	<-gracefulStop
 }
 
-func initPulsar(ctx context.Context, cfg configuration.Configuration) (*component.Manager, *pulsar.Pulsar) {
+func initPulsar(ctx context.Context, cfg configuration.Configuration) (*component.Manager, *pulsar.Pulsar, func()) {
 	fmt.Println("Version: ", version.GetFullVersion())
 	fmt.Println("Starts with configuration:\n", configuration.ToString(cfg))
 
@@ -133,7 +142,8 @@ func initPulsar(ctx context.Context, cfg configuration.Configuration) (*componen
 	}
 
 	cm := &component.Manager{}
-	cm.Register(cryptographyScheme, keyStore, keyProcessor, transport.NewFactory(cfg.Pulsar.DistributionTransport))
+	cm.Register(cryptographyScheme, keyStore, keyProcessor, transport.NewFactory(cfg.Pulsar.DistributionTransport),
+		transport.NewPulsarKeyResolver())
 	cm.Inject(cryptographyService, pulseDistributor)
 
 	if err = cm.Init(ctx); err != nil {
@@ -153,12 +163,49 @@ func initPulsar(ctx context.Context, cfg configuration.Configuration) (*componen
 		&entropygenerator.StandardEntropyGenerator{},
 	)
 
-	return cm, server
+	var stoppers []func() error
+	stop := func() {
+		for _, s := range stoppers {
+			if err := s(); err != nil {
+				inslogger.FromContext(ctx).Error(err)
+			}
+		}
+	}
+
+	if cfg.Pulsar.Storage.DataDirectory != "" {
+		db, err := store.NewEngine(cfg.Pulsar.Storage)
+		if err != nil {
+			panic(err)
+		}
+		stoppers = append(stoppers, func() error { return db.Stop(ctx) })
+
+		server.Storage = storage.NewDB(db)
+		if err = server.Recover(ctx); err != nil {
+			panic(err)
+		}
+		inslogger.FromContext(ctx).Infof("last sent pulse: %v", server.LastPN())
+	}
+
+	if len(cfg.Pulsar.Federation.Neighbours) == 0 {
+		return cm, server, stop
+	}
+
+	transport := pulsar.NewFederationRPC(cfg.Pulsar.Federation.ListenAddress)
+	server.Federation, err = pulsar.NewFederation(cfg.Pulsar.Federation, server, transport)
+	if err != nil {
+		panic(err)
+	}
+	if err = transport.Start(server.Federation); err != nil {
+		panic(err)
+	}
+	// Federation is stopped before storage.
+	stoppers = append([]func() error{transport.Stop}, stoppers...)
+
+	return cm, server, stop
 }
 
-func runPulsar(ctx context.Context, server *pulsar.Pulsar, cfg configuration.Pulsar) *time.Ticker {
-	nextPulseNumber := insolar.PulseNumber(pulse.OfNow())
-	err := server.Send(ctx, nextPulseNumber)
+func runPulsar(ctx context.Context, server *pulsar.Pulsar, cfg configuration.Pulsar) func() {
+	err := server.Send(ctx, pulsar.NextPulseNumber(server.LastPN(), cfg.NumberDelta, time.Now()))
 	if err != nil {
 		panic(err)
 	}
@@ -166,12 +213,66 @@ func runPulsar(ctx context.Context, server *pulsar.Pulsar, cfg configuration.Pul
 	pulseTicker := time.NewTicker(time.Duration(cfg.PulseTime) * time.Millisecond)
 	go func() {
 		for range pulseTicker.C {
-			err := server.Send(ctx, server.LastPN()+insolar.PulseNumber(cfg.NumberDelta))
+			err := server.Send(ctx, pulsar.NextPulseNumber(server.LastPN(), cfg.NumberDelta, time.Now()))
+			if err != nil {
+				panic(err)
+			}
+		}
+	}()
+
+	return pulseTicker.Stop
+}
+
+// runFederatedPulsar starts pulse rounds at time of every NumberDelta-th pulse number,
+// so all pulsars of federation start the same rounds.
+func runFederatedPulsar(ctx context.Context, server *pulsar.Pulsar, cfg configuration.Pulsar) func() {
+	logger := inslogger.FromContext(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			next := pulsar.NextFederatedPulseNumber(cfg.NumberDelta, time.Now())
+			start, err := next.AsApproximateTime()
 			if err != nil {
 				panic(err)
 			}
+
+			select {
+			case <-time.After(time.Until(start)):
+			case <-done:
+				return
+			}
+
+			err = server.Send(ctx, next)
+			if err != nil {
+				logger.Error(err)
+			}
 		}
 	}()
 
-	return pulseTicker
+	return func() { close(done) }
+}
+
+// runAdmin starts http endpoint which reports pulsar state.
+func runAdmin(ctx context.Context, server *pulsar.Pulsar, cfg configuration.Pulsar) func() {
+	if cfg.AdminAddress == "" {
+		return func() {}
+	}
+
+	logger := inslogger.FromContext(ctx)
+	router := http.NewServeMux()
+	router.Handle("/state", pulsar.StateHandler(server))
+	admin := &http.Server{Addr: cfg.AdminAddress, Handler: router}
+
+	go func() {
+		if err := admin.ListenAndServe(); err != http.ErrServerClosed {
+			logger.Error("admin endpoint failed: ", err)
+		}
+	}()
+
+	return func() {
+		if err := admin.Shutdown(ctx); err != nil {
+			logger.Error("failed to stop admin endpoint: ", err)
+		}
+	}
 }
@@ -0,0 +1,291 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	pulsewatcher "github.com/insolar/insolar/cmd/pulsewatcher/config"
+)
+
+const (
+	alertStuck   = "node_stuck"
+	alertSplit   = "active_list_split"
+	alertMissing = "node_missing"
+)
+
+const (
+	alertFiring   = "firing"
+	alertResolved = "resolved"
+)
+
+// Alert is a notification about network condition sent to webhooks.
+type Alert struct {
+	Name    string    `json:"name"`
+	Node    string    `json:"node,omitempty"`
+	Status  string    `json:"status"`
+	Message string    `json:"message"`
+	Since   time.Time `json:"since"`
+	Time    time.Time `json:"time"`
+}
+
+type condition struct {
+	alert    Alert
+	fired    bool
+	notified time.Time
+}
+
+type pulseSeen struct {
+	pulse uint32
+	since time.Time
+}
+
+// Detector tracks network conditions between ticks and decides when to fire and resolve alerts.
+type Detector struct {
+	cfg pulsewatcher.AlertsConfig
+
+	pulses     map[string]pulseSeen
+	conditions map[string]*condition
+}
+
+func NewDetector(cfg pulsewatcher.AlertsConfig) *Detector {
+	return &Detector{
+		cfg:        cfg,
+		pulses:     map[string]pulseSeen{},
+		conditions: map[string]*condition{},
+	}
+}
+
+// Check evaluates conditions on tick results and returns alerts which should be notified about.
+func (d *Detector) Check(now time.Time, results []nodeStatus) []Alert {
+	holding := map[string]Alert{}
+	d.checkStuck(now, results, holding)
+	d.checkActiveLists(now, results, holding)
+
+	var alerts []Alert
+	for key, a := range holding {
+		cond, ok := d.conditions[key]
+		if !ok {
+			cond = &condition{alert: a}
+			d.conditions[key] = cond
+		}
+		cond.alert.Message = a.Message
+
+		if now.Sub(cond.alert.Since) < d.threshold(a.Name) {
+			continue
+		}
+		if cond.fired && (d.cfg.RepeatInterval == 0 || now.Sub(cond.notified) < d.cfg.RepeatInterval) {
+			continue
+		}
+		cond.fired = true
+		cond.notified = now
+		alerts = append(alerts, d.makeAlert(cond, alertFiring, now))
+	}
+
+	for key, cond := range d.conditions {
+		if _, ok := holding[key]; ok {
+			continue
+		}
+		if cond.fired {
+			alerts = append(alerts, d.makeAlert(cond, alertResolved, now))
+		}
+		delete(d.conditions, key)
+	}
+
+	sortAlerts(alerts)
+	return alerts
+}
+
+// Firing returns alerts fired and not resolved yet.
+func (d *Detector) Firing() []Alert {
+	var alerts []Alert
+	for _, cond := range d.conditions {
+		if cond.fired {
+			alerts = append(alerts, d.makeAlert(cond, alertFiring, cond.notified))
+		}
+	}
+	sortAlerts(alerts)
+	return alerts
+}
+
+func (d *Detector) makeAlert(cond *condition, status string, now time.Time) Alert {
+	a := cond.alert
+	a.Status = status
+	a.Time = now
+	return a
+}
+
+func (d *Detector) threshold(name string) time.Duration {
+	switch name {
+	case alertStuck:
+		return d.cfg.StuckTimeout
+	case alertSplit:
+		return d.cfg.SplitTimeout
+	case alertMissing:
+		return d.cfg.MissingTimeout
+	default:
+		panic("unknown alert " + name)
+	}
+}
+
+// since returns time condition holds from, it's now for new conditions.
+func (d *Detector) since(key string, now time.Time) time.Time {
+	if cond, ok := d.conditions[key]; ok {
+		return cond.alert.Since
+	}
+	return now
+}
+
+func (d *Detector) checkStuck(now time.Time, results []nodeStatus, holding map[string]Alert) {
+	for _, r := range results {
+		if r.errStr != "" {
+			continue
+		}
+		seen, ok := d.pulses[r.url]
+		if !ok || seen.pulse != r.reply.PulseNumber {
+			d.pulses[r.url] = pulseSeen{pulse: r.reply.PulseNumber, since: now}
+			continue
+		}
+		if d.cfg.StuckTimeout == 0 {
+			continue
+		}
+		holding[alertStuck+":"+r.url] = Alert{
+			Name:    alertStuck,
+			Node:    r.url,
+			Message: fmt.Sprintf("node is on pulse %d", seen.pulse),
+			Since:   seen.since,
+		}
+	}
+}
+
+func (d *Detector) checkActiveLists(now time.Time, results []nodeStatus, holding map[string]Alert) {
+	groups := map[string][]string{}
+	lists := map[string]map[string]bool{}
+	reporters := 0
+	for _, r := range results {
+		if r.errStr != "" || len(r.reply.Nodes) == 0 {
+			continue
+		}
+		refs := make([]string, 0, len(r.reply.Nodes))
+		set := make(map[string]bool, len(r.reply.Nodes))
+		for _, n := range r.reply.Nodes {
+			refs = append(refs, n.Reference)
+			set[n.Reference] = true
+		}
+		sort.Strings(refs)
+		key := strings.Join(refs, ",")
+
+		groups[key] = append(groups[key], r.url)
+		lists[key] = set
+		reporters++
+	}
+
+	if len(groups) > 1 && d.cfg.SplitTimeout > 0 {
+		key := alertSplit
+		holding[key] = Alert{
+			Name:    alertSplit,
+			Message: fmt.Sprintf("%d nodes report %d different active lists", reporters, len(groups)),
+			Since:   d.since(key, now),
+		}
+	}
+
+	var majority map[string]bool
+	for key, urls := range groups {
+		if len(urls)*2 > reporters {
+			majority = lists[key]
+		}
+	}
+	if majority == nil || d.cfg.MissingTimeout == 0 {
+		return
+	}
+	for _, r := range results {
+		ref := r.reply.Origin.Reference
+		if ref == "" || majority[ref] {
+			continue
+		}
+		key := alertMissing + ":" + r.url
+		holding[key] = Alert{
+			Name:    alertMissing,
+			Node:    r.url,
+			Message: fmt.Sprintf("node %s is absent from active list of majority", ref),
+			Since:   d.since(key, now),
+		}
+	}
+}
+
+func sortAlerts(alerts []Alert) {
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].Name != alerts[j].Name {
+			return alerts[i].Name < alerts[j].Name
+		}
+		return alerts[i].Node < alerts[j].Node
+	})
+}
+
+// Notifier posts alerts to webhooks.
+type Notifier struct {
+	client   *http.Client
+	webhooks []string
+}
+
+func NewNotifier(client *http.Client, webhooks []string) *Notifier {
+	return &Notifier{
+		client:   client,
+		webhooks: webhooks,
+	}
+}
+
+// Notify sends alerts to every webhook in background, failures are logged.
+func (n *Notifier) Notify(alerts []Alert) {
+	if len(alerts) == 0 || len(n.webhooks) == 0 {
+		return
+	}
+	body, err := json.Marshal(struct {
+		Alerts []Alert `json:"alerts"`
+	}{Alerts: alerts})
+	if err != nil {
+		panic(err) // should never happen
+	}
+	for _, url := range n.webhooks {
+		go func(url string) {
+			if err := n.post(url, body); err != nil {
+				log.Println(err)
+			}
+		}(url)
+	}
+}
+
+func (n *Notifier) post(url string, body []byte) error {
+	res, err := n.client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "failed to send alerts to %s", url)
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= http.StatusBadRequest {
+		return errors.Errorf("failed to send alerts to %s: %s", url, res.Status)
+	}
+	return nil
+}
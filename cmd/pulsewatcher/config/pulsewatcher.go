@@ -27,6 +27,45 @@ type Config struct {
 	Nodes    []string
 	Interval time.Duration
 	Timeout  time.Duration
+	Daemon   DaemonConfig
+}
+
+// DaemonConfig configures pulsewatcher running as a long-living monitoring daemon.
+type DaemonConfig struct {
+	// Listen is an address of HTTP endpoint serving history (/history), current state (/status)
+	// and Prometheus metrics (/metrics).
+	Listen string
+	// HistorySize is a number of samples kept for every node.
+	HistorySize int
+	Alerts      AlertsConfig
+}
+
+// AlertsConfig configures detection of network problems and webhook notifications.
+// Condition fires only if it holds for longer than its threshold, zero threshold disables the condition.
+type AlertsConfig struct {
+	// Webhooks are URLs receiving alerts as JSON POST requests.
+	Webhooks []string
+	// StuckTimeout is a time node can stay on the same pulse.
+	StuckTimeout time.Duration
+	// SplitTimeout is a time nodes can report different active lists.
+	SplitTimeout time.Duration
+	// MissingTimeout is a time node can be absent from active list of majority.
+	MissingTimeout time.Duration
+	// RepeatInterval is an interval of repeating notifications for conditions still holding, zero means notify once.
+	RepeatInterval time.Duration
+}
+
+// NewDaemonConfig creates daemon config with default thresholds.
+func NewDaemonConfig() DaemonConfig {
+	return DaemonConfig{
+		Listen:      "127.0.0.1:8090",
+		HistorySize: 1000,
+		Alerts: AlertsConfig{
+			StuckTimeout:   time.Minute,
+			SplitTimeout:   30 * time.Second,
+			MissingTimeout: 30 * time.Second,
+		},
+	}
 }
 
 func WriteConfig(file string, conf Config) error {
@@ -38,7 +77,7 @@ func WriteConfig(file string, conf Config) error {
 }
 
 func ReadConfig(file string) (*Config, error) {
-	var conf Config
+	conf := Config{Daemon: NewDaemonConfig()}
 	data, err := ioutil.ReadFile(file)
 	if err != nil {
 		return nil, err
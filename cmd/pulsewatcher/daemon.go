@@ -0,0 +1,223 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	pulsewatcher "github.com/insolar/insolar/cmd/pulsewatcher/config"
+	"github.com/insolar/insolar/insolar"
+)
+
+const metricsNamespace = "pulsewatcher"
+
+type daemonMetrics struct {
+	registry *prometheus.Registry
+
+	up                 *prometheus.GaugeVec
+	ready              *prometheus.GaugeVec
+	pulseNumber        *prometheus.GaugeVec
+	networkPulseNumber *prometheus.GaugeVec
+	pulseLag           *prometheus.GaugeVec
+	activeListSize     *prometheus.GaugeVec
+	workingListSize    *prometheus.GaugeVec
+	alertsFiring       *prometheus.GaugeVec
+	notifications      *prometheus.CounterVec
+}
+
+func newDaemonMetrics() *daemonMetrics {
+	nodeGauge := func(name, help string) *prometheus.GaugeVec {
+		return prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      name,
+			Help:      help,
+		}, []string{"node"})
+	}
+
+	m := &daemonMetrics{
+		registry: prometheus.NewRegistry(),
+
+		up:                 nodeGauge("node_up", "Whether node responds to status requests"),
+		ready:              nodeGauge("node_ready", "Whether node is in CompleteNetworkState"),
+		pulseNumber:        nodeGauge("node_pulse_number", "Current pulse of node"),
+		networkPulseNumber: nodeGauge("node_network_pulse_number", "Current network pulse of node"),
+		pulseLag:           nodeGauge("node_pulse_lag", "Difference between the highest pulse in network and node pulse"),
+		activeListSize:     nodeGauge("node_active_list_size", "Size of node active list"),
+		workingListSize:    nodeGauge("node_working_list_size", "Size of node working list"),
+		alertsFiring: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "alerts_firing",
+			Help:      "Alerts fired and not resolved yet",
+		}, []string{"name", "node"}),
+		notifications: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "alert_notifications_total",
+			Help:      "Total number of alert notifications",
+		}, []string{"name", "status"}),
+	}
+	m.registry.MustRegister(
+		m.up,
+		m.ready,
+		m.pulseNumber,
+		m.networkPulseNumber,
+		m.pulseLag,
+		m.activeListSize,
+		m.workingListSize,
+		m.alertsFiring,
+		m.notifications,
+	)
+	return m
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Daemon keeps history of node statuses, exposes it over HTTP and notifies about detected problems.
+type Daemon struct {
+	history  *History
+	metrics  *daemonMetrics
+	notifier *Notifier
+
+	lock     sync.Mutex
+	detector *Detector
+	last     time.Time
+	ready    bool
+}
+
+func NewDaemon(conf pulsewatcher.DaemonConfig, client *http.Client) *Daemon {
+	return &Daemon{
+		history:  NewHistory(conf.HistorySize),
+		metrics:  newDaemonMetrics(),
+		notifier: NewNotifier(client, conf.Alerts.Webhooks),
+		detector: NewDetector(conf.Alerts),
+	}
+}
+
+// Observe records tick results, updates metrics and sends alerts.
+func (d *Daemon) Observe(now time.Time, results []nodeStatus, ready bool) {
+	for i, s := range makeSamples(now, results) {
+		node := results[i].url
+		d.history.Add(node, s)
+
+		d.metrics.up.WithLabelValues(node).Set(boolToFloat(s.Error == ""))
+		d.metrics.ready.WithLabelValues(node).Set(boolToFloat(s.NetworkState == insolar.CompleteNetworkState.String()))
+		d.metrics.pulseNumber.WithLabelValues(node).Set(float64(s.PulseNumber))
+		d.metrics.networkPulseNumber.WithLabelValues(node).Set(float64(s.NetworkPulseNumber))
+		d.metrics.pulseLag.WithLabelValues(node).Set(float64(s.PulseLag))
+		d.metrics.activeListSize.WithLabelValues(node).Set(float64(s.ActiveListSize))
+		d.metrics.workingListSize.WithLabelValues(node).Set(float64(s.WorkingListSize))
+	}
+
+	d.lock.Lock()
+	alerts := d.detector.Check(now, results)
+	firing := d.detector.Firing()
+	d.last = now
+	d.ready = ready
+	d.lock.Unlock()
+
+	d.metrics.alertsFiring.Reset()
+	for _, a := range firing {
+		d.metrics.alertsFiring.WithLabelValues(a.Name, a.Node).Set(1)
+	}
+	for _, a := range alerts {
+		d.metrics.notifications.WithLabelValues(a.Name, a.Status).Inc()
+		log.Printf("alert %s %s %s: %s", a.Status, a.Name, a.Node, a.Message)
+	}
+	d.notifier.Notify(alerts)
+}
+
+// Handler returns HTTP handler serving history, current state and metrics.
+func (d *Daemon) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/history", d.serveHistory)
+	mux.HandleFunc("/status", d.serveStatus)
+	mux.Handle("/metrics", promhttp.HandlerFor(d.metrics.registry, promhttp.HandlerOpts{}))
+	return mux
+}
+
+func (d *Daemon) serveHistory(w http.ResponseWriter, r *http.Request) {
+	node := r.URL.Query().Get("node")
+	if node == "" {
+		writeJSON(w, d.history.All())
+		return
+	}
+	samples := d.history.Node(node)
+	if len(samples) == 0 {
+		http.Error(w, "unknown node "+node, http.StatusNotFound)
+		return
+	}
+	writeJSON(w, samples)
+}
+
+func (d *Daemon) serveStatus(w http.ResponseWriter, r *http.Request) {
+	type status struct {
+		Time   time.Time         `json:"time"`
+		Ready  bool              `json:"ready"`
+		Nodes  map[string]Sample `json:"nodes"`
+		Alerts []Alert           `json:"alerts"`
+	}
+
+	d.lock.Lock()
+	res := status{
+		Time:   d.last,
+		Ready:  d.ready,
+		Nodes:  map[string]Sample{},
+		Alerts: d.detector.Firing(),
+	}
+	d.lock.Unlock()
+
+	for node, samples := range d.history.All() {
+		res.Nodes[node] = samples[len(samples)-1]
+	}
+	writeJSON(w, res)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Println(err)
+	}
+}
+
+func runDaemon(conf *pulsewatcher.Config) {
+	d := NewDaemon(conf.Daemon, &client)
+
+	go func() {
+		log.Println("pulsewatcher daemon listens on", conf.Daemon.Listen)
+		log.Fatal(http.ListenAndServe(conf.Daemon.Listen, d.Handler()))
+	}()
+
+	var results []nodeStatus
+	var ready bool
+	for {
+		results, ready = collectNodesStatuses(conf, results)
+		d.Observe(time.Now(), results, ready)
+
+		time.Sleep(conf.Interval)
+	}
+}
@@ -0,0 +1,211 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/api/requester"
+	pulsewatcher "github.com/insolar/insolar/cmd/pulsewatcher/config"
+	"github.com/insolar/insolar/insolar"
+)
+
+func status(url string, pulse uint32, origin string, active ...string) nodeStatus {
+	reply := requester.StatusResponse{
+		NetworkState:   insolar.CompleteNetworkState.String(),
+		Origin:         requester.Node{Reference: origin},
+		ActiveListSize: len(active),
+		PulseNumber:    pulse,
+	}
+	for _, ref := range active {
+		reply.Nodes = append(reply.Nodes, requester.Node{Reference: ref})
+	}
+	return nodeStatus{url: url, reply: reply}
+}
+
+func alertNames(alerts []Alert) []string {
+	var names []string
+	for _, a := range alerts {
+		names = append(names, a.Status+" "+a.Name+" "+a.Node)
+	}
+	return names
+}
+
+func TestHistory(t *testing.T) {
+	h := NewHistory(2)
+	for pn := uint32(1); pn <= 3; pn++ {
+		h.Add("a", Sample{PulseNumber: pn})
+	}
+	h.Add("b", Sample{PulseNumber: 10})
+
+	require.Equal(t, []Sample{{PulseNumber: 2}, {PulseNumber: 3}}, h.Node("a"))
+	require.Equal(t, map[string][]Sample{
+		"a": {{PulseNumber: 2}, {PulseNumber: 3}},
+		"b": {{PulseNumber: 10}},
+	}, h.All())
+	require.Empty(t, h.Node("c"))
+}
+
+func TestMakeSamples(t *testing.T) {
+	down := status("c", 0, "C")
+	down.errStr = "NODE IS DOWN"
+
+	samples := makeSamples(time.Now(), []nodeStatus{
+		status("a", 20, "A"),
+		status("b", 10, "B"),
+		down,
+	})
+
+	require.Equal(t, uint32(0), samples[0].PulseLag)
+	require.Equal(t, uint32(10), samples[1].PulseLag)
+	require.Equal(t, uint32(0), samples[2].PulseLag)
+	require.Equal(t, "NODE IS DOWN", samples[2].Error)
+}
+
+func TestDetector(t *testing.T) {
+	cfg := pulsewatcher.AlertsConfig{
+		StuckTimeout:   10 * time.Second,
+		SplitTimeout:   5 * time.Second,
+		MissingTimeout: 5 * time.Second,
+	}
+	start := time.Now()
+	at := func(sec int) time.Time {
+		return start.Add(time.Duration(sec) * time.Second)
+	}
+
+	t.Run("stuck", func(t *testing.T) {
+		d := NewDetector(cfg)
+		require.Empty(t, d.Check(at(0), []nodeStatus{status("a", 10, "")}))
+		require.Empty(t, d.Check(at(9), []nodeStatus{status("a", 10, "")}))
+
+		alerts := d.Check(at(10), []nodeStatus{status("a", 10, "")})
+		require.Equal(t, []string{"firing node_stuck a"}, alertNames(alerts))
+		require.Equal(t, at(0), alerts[0].Since)
+		require.Len(t, d.Firing(), 1)
+
+		require.Empty(t, d.Check(at(20), []nodeStatus{status("a", 10, "")}))
+		require.Equal(t, []string{"resolved node_stuck a"}, alertNames(d.Check(at(21), []nodeStatus{status("a", 20, "")})))
+		require.Empty(t, d.Firing())
+	})
+
+	t.Run("repeat", func(t *testing.T) {
+		repeating := cfg
+		repeating.RepeatInterval = 5 * time.Second
+		d := NewDetector(repeating)
+		d.Check(at(0), []nodeStatus{status("a", 10, "")})
+		require.Len(t, d.Check(at(10), []nodeStatus{status("a", 10, "")}), 1)
+		require.Empty(t, d.Check(at(12), []nodeStatus{status("a", 10, "")}))
+		require.Len(t, d.Check(at(15), []nodeStatus{status("a", 10, "")}), 1)
+	})
+
+	t.Run("split and missing", func(t *testing.T) {
+		d := NewDetector(cfg)
+		split := []nodeStatus{
+			status("a", 10, "A", "A", "B"),
+			status("b", 10, "B", "A", "B"),
+			status("c", 10, "C", "A", "B", "C"),
+		}
+		require.Empty(t, d.Check(at(0), split))
+
+		split[0].reply.PulseNumber, split[1].reply.PulseNumber, split[2].reply.PulseNumber = 20, 20, 20
+		alerts := d.Check(at(5), split)
+		require.Equal(t, []string{"firing active_list_split ", "firing node_missing c"}, alertNames(alerts))
+
+		joined := []nodeStatus{
+			status("a", 30, "A", "A", "B", "C"),
+			status("b", 30, "B", "A", "B", "C"),
+			status("c", 30, "C", "A", "B", "C"),
+		}
+		alerts = d.Check(at(6), joined)
+		require.Equal(t, []string{"resolved active_list_split ", "resolved node_missing c"}, alertNames(alerts))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		d := NewDetector(pulsewatcher.AlertsConfig{})
+		split := []nodeStatus{
+			status("a", 10, "A", "A"),
+			status("b", 10, "B", "A", "B"),
+			status("c", 10, "C", "A", "B"),
+		}
+		d.Check(at(0), split)
+		require.Empty(t, d.Check(at(100), split))
+	})
+}
+
+func TestDaemon(t *testing.T) {
+	received := make(chan []Alert, 1)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Alerts []Alert
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		received <- body.Alerts
+	}))
+	defer webhook.Close()
+
+	conf := pulsewatcher.NewDaemonConfig()
+	conf.Alerts.Webhooks = []string{webhook.URL}
+	d := NewDaemon(conf, &http.Client{Timeout: time.Second})
+	server := httptest.NewServer(d.Handler())
+	defer server.Close()
+
+	start := time.Now()
+	d.Observe(start, []nodeStatus{status("a", 10, "A", "A")}, true)
+	d.Observe(start.Add(time.Hour), []nodeStatus{status("a", 10, "A", "A")}, true)
+
+	select {
+	case alerts := <-received:
+		require.Equal(t, []string{"firing node_stuck a"}, alertNames(alerts))
+	case <-time.After(10 * time.Second):
+		t.Fatal("webhook wasn't called")
+	}
+
+	get := func(path string) string {
+		res, err := http.Get(server.URL + path)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		data, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	var history []Sample
+	require.NoError(t, json.Unmarshal([]byte(get("/history?node=a")), &history))
+	require.Len(t, history, 2)
+
+	var st struct {
+		Ready  bool
+		Nodes  map[string]Sample
+		Alerts []Alert
+	}
+	require.NoError(t, json.Unmarshal([]byte(get("/status")), &st))
+	require.True(t, st.Ready)
+	require.Equal(t, uint32(10), st.Nodes["a"].PulseNumber)
+	require.Equal(t, []string{"firing node_stuck a"}, alertNames(st.Alerts))
+
+	metrics := get("/metrics")
+	require.True(t, strings.Contains(metrics, `pulsewatcher_node_pulse_number{node="a"} 10`), metrics)
+	require.True(t, strings.Contains(metrics, `pulsewatcher_alerts_firing{name="node_stuck",node="a"} 1`), metrics)
+}
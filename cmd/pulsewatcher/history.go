@@ -0,0 +1,116 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// Sample is a state of a node observed on a single tick.
+type Sample struct {
+	Time               time.Time `json:"time"`
+	NetworkState       string    `json:"networkState"`
+	Origin             string    `json:"origin"`
+	ID                 uint32    `json:"id"`
+	PulseNumber        uint32    `json:"pulseNumber"`
+	NetworkPulseNumber uint32    `json:"networkPulseNumber"`
+	// PulseLag is a difference between the highest pulse number seen in the network and the node pulse number.
+	PulseLag        uint32 `json:"pulseLag"`
+	ActiveListSize  int    `json:"activeListSize"`
+	WorkingListSize int    `json:"workingListSize"`
+	Error           string `json:"error,omitempty"`
+}
+
+// makeSamples converts tick results to samples. Pulse lag is calculated only for responding nodes.
+func makeSamples(now time.Time, results []nodeStatus) []Sample {
+	var top uint32
+	for _, r := range results {
+		if r.errStr == "" && r.reply.PulseNumber > top {
+			top = r.reply.PulseNumber
+		}
+	}
+
+	samples := make([]Sample, len(results))
+	for i, r := range results {
+		samples[i] = Sample{
+			Time:               now,
+			NetworkState:       r.reply.NetworkState,
+			Origin:             r.reply.Origin.Reference,
+			ID:                 r.reply.Origin.ID,
+			PulseNumber:        r.reply.PulseNumber,
+			NetworkPulseNumber: r.reply.NetworkPulseNumber,
+			ActiveListSize:     r.reply.ActiveListSize,
+			WorkingListSize:    r.reply.WorkingListSize,
+			Error:              r.errStr,
+		}
+		if r.errStr == "" {
+			samples[i].PulseLag = top - r.reply.PulseNumber
+		}
+	}
+	return samples
+}
+
+// History keeps last samples of every node.
+type History struct {
+	size int
+
+	lock  sync.RWMutex
+	nodes map[string][]Sample
+}
+
+func NewHistory(size int) *History {
+	if size <= 0 {
+		size = 1
+	}
+	return &History{
+		size:  size,
+		nodes: map[string][]Sample{},
+	}
+}
+
+// Add appends node sample dropping the oldest one if history is full.
+func (h *History) Add(node string, s Sample) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	samples := append(h.nodes[node], s)
+	if len(samples) > h.size {
+		samples = append(samples[:0:0], samples[len(samples)-h.size:]...)
+	}
+	h.nodes[node] = samples
+}
+
+// Node returns a copy of node samples from the oldest to the newest.
+func (h *History) Node(node string) []Sample {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	return append([]Sample(nil), h.nodes[node]...)
+}
+
+// All returns a copy of samples of all nodes.
+func (h *History) All() map[string][]Sample {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	res := make(map[string][]Sample, len(h.nodes))
+	for node, samples := range h.nodes {
+		res[node] = append([]Sample(nil), samples...)
+	}
+	return res
+}
@@ -306,9 +306,11 @@ func main() {
 	var configFile string
 	var useJSONFormat bool
 	var singleOutput bool
+	var daemonMode bool
 	pflag.StringVarP(&configFile, "config", "c", "", "config file")
 	pflag.BoolVarP(&useJSONFormat, "json", "j", false, "use JSON format")
 	pflag.BoolVarP(&singleOutput, "single", "s", false, "single output")
+	pflag.BoolVarP(&daemonMode, "daemon", "d", false, "run as monitoring daemon with HTTP endpoint and alerts")
 	pflag.Parse()
 
 	conf, err := pulsewatcher.ReadConfig(configFile)
@@ -330,6 +332,11 @@ func main() {
 		Timeout:   conf.Timeout,
 	}
 
+	if daemonMode {
+		runDaemon(conf)
+		return
+	}
+
 	emoji = NewEmoji()
 	var results []nodeStatus
 	var ready bool
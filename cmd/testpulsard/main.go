@@ -116,7 +116,8 @@ func initPulsar(ctx context.Context, cfg configuration.Configuration) *pulsar.Te
 	}
 
 	cm := &component.Manager{}
-	cm.Register(cryptographyScheme, keyStore, keyProcessor, transport.NewFactory(cfg.Pulsar.DistributionTransport))
+	cm.Register(cryptographyScheme, keyStore, keyProcessor, transport.NewFactory(cfg.Pulsar.DistributionTransport),
+		transport.NewPulsarKeyResolver())
 	cm.Inject(cryptographyService, pulseDistributor)
 
 	if err = cm.Init(ctx); err != nil {
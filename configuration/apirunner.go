@@ -26,6 +26,31 @@ type APIRunner struct {
 	RPC     string
 	// IsAdmin indicates status of api (internal or external)
 	IsAdmin bool
+	// Events is configuration of server-sent events endpoint
+	Events APIEvents
+}
+
+// APIEvents holds configuration of server-sent events endpoint of api
+type APIEvents struct {
+	// Path is url path of endpoint, empty path disables it
+	Path string
+	// MaxConnections limits number of simultaneously connected clients
+	MaxConnections int
+	// MaxSubscriptions limits number of request and object subscriptions of one connection
+	MaxSubscriptions int
+	// BufferSize is a number of events queued for a connection. Connection which doesn't read events is closed
+	// when its buffer is full.
+	BufferSize int
+}
+
+// NewAPIEvents creates new api events config
+func NewAPIEvents(path string) APIEvents {
+	return APIEvents{
+		Path:             path,
+		MaxConnections:   1000,
+		MaxSubscriptions: 100,
+		BufferSize:       64,
+	}
 }
 
 // NewAPIRunner creates new api config
@@ -35,16 +60,18 @@ func NewAPIRunner(admin bool) APIRunner {
 			Address: "localhost:19001",
 			RPC:     "/admin-api/rpc",
 			IsAdmin: true,
+			Events:  NewAPIEvents("/admin-api/events"),
 		}
 	}
 	return APIRunner{
 		Address: "localhost:19101",
 		RPC:     "/api/rpc",
 		IsAdmin: false,
+		Events:  NewAPIEvents("/api/events"),
 	}
 }
 
 func (ar *APIRunner) String() string {
-	res := fmt.Sprintln("Addr ->", ar.Address, ", RPC ->", ar.RPC, ", IsAdmin ->", ar.IsAdmin)
+	res := fmt.Sprintln("Addr ->", ar.Address, ", RPC ->", ar.RPC, ", IsAdmin ->", ar.IsAdmin, ", Events ->", ar.Events.Path)
 	return res
 }
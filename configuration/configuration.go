@@ -38,6 +38,7 @@ type Configuration struct {
 	AdminAPIRunner  APIRunner
 	Pulsar          Pulsar
 	KeysPath        string
+	KeyStore        KeyStore
 	CertificatePath string
 	Tracer          Tracer
 	Introspection   Introspection
@@ -64,6 +65,7 @@ func NewConfiguration() Configuration {
 		AdminAPIRunner:  NewAPIRunner(true),
 		Pulsar:          NewPulsar(),
 		KeysPath:        "./",
+		KeyStore:        NewKeyStore(),
 		CertificatePath: "",
 		Tracer:          NewTracer(),
 		Introspection:   NewIntrospection(),
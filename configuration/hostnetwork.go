@@ -18,7 +18,7 @@ package configuration
 
 // Transport holds transport protocol configuration for HostNetwork
 type Transport struct {
-	// protocol type
+	// protocol type: TCP, or SecureTCP to authenticate nodes by certificate keys and encrypt stream traffic
 	Protocol string
 	// Address to listen
 	Address string
@@ -34,6 +34,8 @@ type HostNetwork struct {
 	TimeoutMult         int   // bootstrap timout multiplier
 	SignMessages        bool  // signing a messages if true
 	HandshakeSessionTTL int32 // ms
+	// TrustAnyPulsar accepts pulses from any pulsar if certificate has no pulsar keys, for test networks only
+	TrustAnyPulsar bool
 }
 
 // NewHostNetwork creates new default HostNetwork configuration
@@ -48,5 +50,6 @@ func NewHostNetwork() HostNetwork {
 		TimeoutMult:         2,
 		SignMessages:        false,
 		HandshakeSessionTTL: 5000,
+		TrustAnyPulsar:      false,
 	}
 }
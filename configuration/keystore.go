@@ -0,0 +1,36 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+// KeyStore holds configuration of node private key storage.
+type KeyStore struct {
+	// Backend is "file" (private key is read from KeysPath) or "signer" (signing is delegated to external process).
+	Backend string
+	// PassphraseEnv is a name of environment variable with passphrase for encrypted key files.
+	PassphraseEnv string
+	// SignerAddress is a path of the external signer unix socket.
+	SignerAddress string
+}
+
+// NewKeyStore creates new default configuration of node private key storage.
+func NewKeyStore() KeyStore {
+	return KeyStore{
+		Backend:       "file",
+		PassphraseEnv: "INSOLAR_KEYS_PASSPHRASE",
+		SignerAddress: "",
+	}
+}
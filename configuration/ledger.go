@@ -16,10 +16,22 @@
 
 package configuration
 
+// Storage backends.
+const (
+	// StorageBackendBadger stores data in badger.
+	StorageBackendBadger = "badger"
+	// StorageBackendSkipList keeps data in memory and persists it in an append-only log.
+	// Whole dataset including tombstones of deleted keys must fit into memory of the node,
+	// so it suits test networks and small installations only.
+	StorageBackendSkipList = "skiplist"
+)
+
 // Storage configures Ledger's storage.
 type Storage struct {
 	// DataDirectory is a directory where database's files live.
 	DataDirectory string
+	// Backend is a key-value engine heavy keeps its data in: "badger" or "skiplist".
+	Backend string
 }
 
 // JetSplit holds configuration for jet split.
@@ -53,6 +65,15 @@ type Ledger struct {
 	// CleanerDelay holds value of pulses, that should happen before end of LightChainLimit and start
 	// of LME's data cleaning
 	CleanerDelay int
+
+	// ConsistencyCheck holds configuration of heavy storage consistency checker
+	ConsistencyCheck ConsistencyCheck
+}
+
+// ConsistencyCheck holds configuration of online heavy storage checking.
+type ConsistencyCheck struct {
+	// Enabled switches on checking of every new synced pulse. Violations are logged.
+	Enabled bool
 }
 
 // Backup holds configuration for backuping.
@@ -100,6 +121,7 @@ func NewLedger() Ledger {
 	return Ledger{
 		Storage: Storage{
 			DataDirectory: "./data",
+			Backend:       StorageBackendBadger,
 		},
 
 		JetSplit: JetSplit{
@@ -24,6 +24,28 @@ type Pulsar struct {
 
 	DistributionTransport Transport
 	PulseDistributor      PulseDistributor
+
+	// Federation is a config of federated mode. Pulsar works standalone if it has no neighbours.
+	Federation PulsarFederation
+
+	// Storage is a config of storage for sent pulses. Pulses are kept only in memory if DataDirectory is empty.
+	Storage Storage
+	// AdminAddress is an address of http endpoint which reports pulsar state. Empty address disables it.
+	AdminAddress string
+}
+
+// PulsarFederation holds configuration of federated pulsar mode. Federated pulsars make pulses together,
+// so pulse numbers are aligned by time: a pulse starts every NumberDelta seconds and PulseTime is not used.
+type PulsarFederation struct {
+	// ListenAddress is an address for messages of other pulsars
+	ListenAddress string
+	// Neighbours are other pulsars of federation
+	Neighbours []PulsarNodeAddress
+	// Quorum is a number of pulsars, including this one, needed to make a pulse.
+	// Zero means more than two thirds of federation.
+	Quorum int
+	// StepTimeout is a max duration of every step of pulse round
+	StepTimeout int32 // ms
 }
 
 type PulseDistributor struct {
@@ -50,5 +72,14 @@ func NewPulsar() Pulsar {
 			BootstrapHosts:      []string{"localhost:53837"},
 			PulseRequestTimeout: 1000,
 		},
+		Federation: PulsarFederation{
+			ListenAddress: "0.0.0.0:18090",
+			StepTimeout:   1000,
+		},
+		Storage: Storage{
+			DataDirectory: "./.artifacts/pulsar_data",
+			Backend:       StorageBackendBadger,
+		},
+		AdminAddress: "127.0.0.1:18092",
 	}
 }
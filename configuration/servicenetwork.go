@@ -16,14 +16,39 @@
 
 package configuration
 
+import (
+	"time"
+)
+
 // ServiceNetwork is configuration for ServiceNetwork.
 type ServiceNetwork struct {
 	CacheDirectory string
+	// Blame is configuration of journal of misbehavior reports about other nodes.
+	Blame Blame
+	// RevocationListPath is file where rotated and revoked keys of nodes are kept. Kept in memory only if empty.
+	RevocationListPath string
+}
+
+// Blame holds configuration of misbehavior reports journal.
+type Blame struct {
+	// Storage is where reports are persisted. Reports are kept in memory only if DataDirectory is empty.
+	Storage Storage
+	// MaxEntries limits number of kept reports, the oldest ones are dropped first.
+	MaxEntries int
+	// MaxAge is how long reports are kept.
+	MaxAge time.Duration
 }
 
 // NewServiceNetwork creates a new ServiceNetwork configuration.
 func NewServiceNetwork() ServiceNetwork {
 	return ServiceNetwork{
 		CacheDirectory: "network_cache",
+		Blame: Blame{
+			Storage: Storage{
+				Backend: StorageBackendBadger,
+			},
+			MaxEntries: 10000,
+			MaxAge:     7 * 24 * time.Hour,
+		},
 	}
 }
@@ -61,11 +61,16 @@ type ContractRequester struct {
 	ResultMutex sync.Mutex
 	ResultMap   map[[insolar.RecordHashSize]byte]chan *payload.ReturnResults
 
+	resultHooks []ResultHook
+
 	// callTimeout is mainly needed for unit tests which
 	// sometimes may unpredictably fail on CI with a default timeout
 	callTimeout time.Duration
 }
 
+// ResultHook is called for every result of request received by ContractRequester. It must not block.
+type ResultHook func(ctx context.Context, res *payload.ReturnResults)
+
 // New creates new ContractRequester
 func New(
 	sender bus.Sender,
@@ -101,6 +106,11 @@ func New(
 	return cr, nil
 }
 
+// AddResultHook adds hook called for every received result. Hooks should be added before messages processing starts.
+func (cr *ContractRequester) AddResultHook(hook ResultHook) {
+	cr.resultHooks = append(cr.resultHooks, hook)
+}
+
 func randomUint64() uint64 {
 	buf := make([]byte, 8)
 	_, err := rand.Read(buf)
@@ -412,5 +422,9 @@ func (cr *ContractRequester) handleMessage(ctx context.Context, payloadMeta *pay
 		return errors.Wrap(err, "[ ReceiveResult ]")
 	}
 
+	for _, hook := range cr.resultHooks {
+		hook(ctx, &res)
+	}
+
 	return nil
 }
@@ -325,6 +325,11 @@ func TestReceiveResult_WantedResult(t *testing.T) {
 		chanResult <- <-cReq.ResultMap[reqHash]
 	}()
 
+	var hooked *payload.ReturnResults
+	cReq.AddResultHook(func(_ context.Context, res *payload.ReturnResults) {
+		hooked = res
+	})
+
 	res, err := serializeReply(msg)
 	require.NoError(t, err)
 	err = cReq.ReceiveResult(ctx, res)
@@ -332,6 +337,7 @@ func TestReceiveResult_WantedResult(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 0, len(cReq.ResultMap))
 	require.Equal(t, msgPayload, <-chanResult)
+	require.Equal(t, msgPayload, hooked)
 }
 
 func TestReceiveResult_UnwantedResultWithError(t *testing.T) {
@@ -92,6 +92,7 @@ func main() {
 	// transport related
 	cfg.Host.Transport.Address = insolardTransportListen
 	cfg.Host.Transport.FixedPublicAddress = insolardTransportFixedAddress
+	cfg.Host.TrustAnyPulsar = true
 	// logger related
 	cfg.Log.Level = insolardLogLevel
 	// metrics related
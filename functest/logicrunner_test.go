@@ -1810,3 +1810,117 @@ func (r *Two) NoWaitGet(OneRef insolar.Reference) (int, error) {
 		require.Equal(syncT, float64(n), res.ExtractedReply)
 	})
 }
+
+func TestContractMigration(t *testing.T) {
+	var contractV1Code = `
+package main
+
+import "github.com/insolar/insolar/logicrunner/builtin/foundation"
+
+// ins:version(1)
+type Counter struct {
+	foundation.BaseContract
+	Number int
+}
+
+func New() (*Counter, error) {
+	return &Counter{Number: 21}, nil
+}
+
+var INSATTR_Get_API = true
+func (c *Counter) Get() (int, error) {
+	return c.Number, nil
+}
+`
+	var contractV2Code = `
+package main
+
+import "github.com/insolar/insolar/logicrunner/builtin/foundation"
+
+// ins:version(2)
+type Counter struct {
+	foundation.BaseContract
+	Number int
+	Label  string
+}
+
+type CounterV1 struct {
+	Number int
+}
+
+// ins:migrate(1)
+func MigrateFromV1(old *CounterV1) (*Counter, error) {
+	return &Counter{Number: old.Number * 2, Label: "migrated"}, nil
+}
+
+func New() (*Counter, error) {
+	return &Counter{}, nil
+}
+
+var INSATTR_Get_API = true
+func (c *Counter) Get() (int, error) {
+	return c.Number, nil
+}
+
+var INSATTR_GetLabel_API = true
+func (c *Counter) GetLabel() (string, error) {
+	return c.Label, nil
+}
+`
+	var contractV3Code = `
+package main
+
+import "github.com/insolar/insolar/logicrunner/builtin/foundation"
+
+// ins:version(3)
+type Counter struct {
+	foundation.BaseContract
+	Number int
+	Label  string
+}
+
+type CounterV2 struct {
+	Number int
+	Label  string
+}
+
+// ins:migrate(2)
+func MigrateFromV2(old *CounterV2) (*Counter, error) {
+	return &Counter{Number: old.Number + 1, Label: old.Label}, nil
+}
+
+func New() (*Counter, error) {
+	return &Counter{}, nil
+}
+
+var INSATTR_Get_API = true
+func (c *Counter) Get() (int, error) {
+	return c.Number, nil
+}
+`
+	objectRef := callConstructor(t, uploadContractOnce(t, "migration_v1", contractV1Code), "New")
+	protoV2 := uploadContractOnce(t, "migration_v2", contractV2Code)
+	protoV3 := uploadContractOnce(t, "migration_v3", contractV3Code)
+
+	// version 3 converts state of version 2 only
+	migrationErr := migrateObject(t, objectRef, protoV3)
+	require.Contains(t, migrationErr, "no migration from version of object state")
+	result := callMethod(t, objectRef, "Get")
+	require.Equal(t, float64(21), result.ExtractedReply)
+
+	migrationErr = migrateObject(t, objectRef, protoV2)
+	require.Empty(t, migrationErr)
+
+	result = callMethod(t, objectRef, "Get")
+	require.Equal(t, float64(42), result.ExtractedReply)
+	result = callMethod(t, objectRef, "GetLabel")
+	require.Equal(t, "migrated", result.ExtractedReply)
+
+	migrationErr = migrateObject(t, objectRef, protoV2)
+	require.Contains(t, migrationErr, "object already has requested prototype")
+
+	migrationErr = migrateObject(t, objectRef, protoV3)
+	require.Empty(t, migrationErr)
+	result = callMethod(t, objectRef, "Get")
+	require.Equal(t, float64(43), result.ExtractedReply)
+}
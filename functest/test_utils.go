@@ -522,6 +522,32 @@ func callMethodNoChecks(t testing.TB, objectRef *insolar.Reference, method strin
 	return callRes
 }
 
+// migrateObject moves object to prototype by contract.migrate and returns error message of the migration
+func migrateObject(t testing.TB, objectRef *insolar.Reference, prototypeRef *insolar.Reference) string {
+	respBody := getRPSResponseBody(t, launchnet.TestRPCUrl, postParams{
+		"jsonrpc": "2.0",
+		"method":  "contract.migrate",
+		"id":      "",
+		"params": map[string]interface{}{
+			"objectReference":    objectRef.String(),
+			"prototypeReference": prototypeRef.String(),
+		},
+	})
+	require.NotEmpty(t, respBody)
+
+	res := struct {
+		Result requester.MigrateResponse `json:"result"`
+		Error  *json2.Error              `json:"error"`
+	}{}
+	err := json.Unmarshal(respBody, &res)
+	require.NoError(t, err)
+	if res.Error != nil {
+		return res.Error.Message
+	}
+	require.Equal(t, objectRef.String(), res.Result.ObjectReference)
+	return ""
+}
+
 func waitUntilRequestProcessed(
 	customFunction func() api.CallMethodReply,
 	functionTimeout time.Duration,
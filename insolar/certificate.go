@@ -36,6 +36,9 @@ type Certificate interface {
 
 	GetMajorityRule() int
 	GetMinRoles() (virtual uint, heavyMaterial uint, lightMaterial uint)
+	// GetPulsarPublicKeys returns keys of pulsars which sign pulses. Empty list means no pulsar is trusted
+	// unless host.trustanypulsar is set in node config.
+	GetPulsarPublicKeys() []crypto.PublicKey
 }
 
 //go:generate minimock -i github.com/insolar/insolar/insolar.DiscoveryNode -o ../testutils -s _mock.go -g
@@ -51,6 +51,9 @@ type NetworkStatus interface {
 type Leaver interface {
 	// Leave notify other nodes that this node want to leave and doesn't want to receive new tasks
 	Leave(ctx context.Context, ETA PulseNumber)
+	// PrepareLeave notify other nodes that this node doesn't want to receive new tasks, but stays in network.
+	// Returned channel is closed when network stops to assign tasks to the node.
+	PrepareLeave(ctx context.Context) <-chan struct{}
 }
 
 //go:generate minimock -i github.com/insolar/insolar/insolar.CertificateGetter -o ../testutils -s _mock.go -g
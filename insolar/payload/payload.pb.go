@@ -1218,11 +1218,12 @@ func (m *GetRequestInfo) GetPolymorph() uint32 {
 }
 
 type RequestInfo struct {
-	Polymorph uint32                                `protobuf:"varint,16,opt,name=Polymorph,proto3" json:"Polymorph,omitempty"`
-	ObjectID  github_com_insolar_insolar_insolar.ID `protobuf:"bytes,20,opt,name=ObjectID,proto3,customtype=github.com/insolar/insolar/insolar.ID" json:"ObjectID"`
-	RequestID github_com_insolar_insolar_insolar.ID `protobuf:"bytes,21,opt,name=RequestID,proto3,customtype=github.com/insolar/insolar/insolar.ID" json:"RequestID"`
-	Request   []byte                                `protobuf:"bytes,22,opt,name=Request,proto3" json:"Request,omitempty"`
-	Result    []byte                                `protobuf:"bytes,23,opt,name=Result,proto3" json:"Result,omitempty"`
+	Polymorph        uint32                                `protobuf:"varint,16,opt,name=Polymorph,proto3" json:"Polymorph,omitempty"`
+	ObjectID         github_com_insolar_insolar_insolar.ID `protobuf:"bytes,20,opt,name=ObjectID,proto3,customtype=github.com/insolar/insolar/insolar.ID" json:"ObjectID"`
+	RequestID        github_com_insolar_insolar_insolar.ID `protobuf:"bytes,21,opt,name=RequestID,proto3,customtype=github.com/insolar/insolar/insolar.ID" json:"RequestID"`
+	Request          []byte                                `protobuf:"bytes,22,opt,name=Request,proto3" json:"Request,omitempty"`
+	Result           []byte                                `protobuf:"bytes,23,opt,name=Result,proto3" json:"Result,omitempty"`
+	OutgoingRequests [][]byte                              `protobuf:"bytes,24,rep,name=OutgoingRequests,proto3" json:"OutgoingRequests,omitempty"`
 }
 
 func (m *RequestInfo) Reset()      { *m = RequestInfo{} }
@@ -1278,6 +1279,13 @@ func (m *RequestInfo) GetResult() []byte {
 	return nil
 }
 
+func (m *RequestInfo) GetOutgoingRequests() [][]byte {
+	if m != nil {
+		return m.OutgoingRequests
+	}
+	return nil
+}
+
 type GotHotConfirmation struct {
 	Polymorph uint32                                         `protobuf:"varint,16,opt,name=Polymorph,proto3" json:"Polymorph,omitempty"`
 	JetID     github_com_insolar_insolar_insolar.JetID       `protobuf:"bytes,20,opt,name=JetID,proto3,customtype=github.com/insolar/insolar/insolar.JetID" json:"JetID"`
@@ -2549,118 +2557,118 @@ func init() {
 func init() { proto.RegisterFile("insolar/payload/payload.proto", fileDescriptor_33334fec96407f54) }
 
 var fileDescriptor_33334fec96407f54 = []byte{
-	// 1771 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x5a, 0xbd, 0x6f, 0x1b, 0xc9,
-	0x15, 0xd7, 0x52, 0xe2, 0xd7, 0xa3, 0x65, 0x9d, 0x37, 0x24, 0xc5, 0x18, 0x39, 0x5a, 0x58, 0x5c,
-	0x02, 0x03, 0x89, 0xa9, 0x3b, 0x5b, 0x70, 0x8a, 0xe4, 0x60, 0x48, 0xa6, 0x4d, 0xf1, 0x42, 0x49,
-	0xbc, 0xa1, 0xce, 0x38, 0x24, 0x40, 0x80, 0xd5, 0xee, 0x13, 0xb5, 0xc9, 0x72, 0x87, 0xd9, 0x1d,
-	0x2a, 0x76, 0x17, 0xe4, 0x9a, 0x20, 0xd5, 0x15, 0x49, 0x91, 0x3f, 0xe0, 0x80, 0x14, 0xa9, 0x93,
-	0x22, 0x45, 0x80, 0xab, 0x0c, 0x5c, 0xe3, 0xd2, 0x48, 0x61, 0xc4, 0x72, 0x93, 0xf2, 0x52, 0x04,
-	0x48, 0x91, 0x22, 0x98, 0x8f, 0x5d, 0x2e, 0x75, 0x3e, 0xef, 0x8a, 0xa4, 0x99, 0xbb, 0x46, 0xdc,
-	0x99, 0x9d, 0xf7, 0x7b, 0x6f, 0xde, 0xd7, 0xcc, 0x7b, 0x2b, 0x78, 0xd3, 0xf1, 0x02, 0xea, 0x9a,
-	0xfe, 0xe6, 0xd0, 0x7c, 0xe4, 0x52, 0xd3, 0x0e, 0x7f, 0x1b, 0x43, 0x9f, 0x32, 0xaa, 0xe7, 0xd5,
-	0xf0, 0xea, 0x8d, 0xbe, 0xc3, 0x4e, 0x46, 0x47, 0x0d, 0x8b, 0x0e, 0x36, 0xfb, 0xb4, 0x4f, 0x37,
-	0xc5, 0xfb, 0xa3, 0xd1, 0xb1, 0x18, 0x89, 0x81, 0x78, 0x92, 0x74, 0x57, 0x6f, 0xc7, 0x96, 0x87,
-	0x1c, 0xce, 0xff, 0xfa, 0x68, 0x51, 0xdf, 0x56, 0x3f, 0x8a, 0x6e, 0x2b, 0x05, 0xdd, 0x70, 0xe4,
-	0x06, 0x28, 0xff, 0x2a, 0xaa, 0x77, 0x5e, 0x41, 0xe5, 0xa2, 0xdd, 0x47, 0x7f, 0xd3, 0xf6, 0xe9,
-	0x50, 0xfc, 0x91, 0x24, 0xc6, 0xbf, 0x32, 0xb0, 0xb2, 0x87, 0xcc, 0xd4, 0xbf, 0x05, 0xc5, 0x2e,
-	0x75, 0x1f, 0x0d, 0xa8, 0x3f, 0x3c, 0xa9, 0xbd, 0xb1, 0xa1, 0x5d, 0x5f, 0x25, 0xe3, 0x09, 0xbd,
-	0x06, 0xf9, 0xae, 0xd4, 0x40, 0xad, 0xbc, 0xa1, 0x5d, 0xbf, 0x44, 0xc2, 0xa1, 0xde, 0x81, 0x5c,
-	0x0f, 0x3d, 0x1b, 0xfd, 0x5a, 0x85, 0xbf, 0xd8, 0xd9, 0x7a, 0xfc, 0xec, 0xda, 0xd2, 0xdf, 0x9f,
-	0x5d, 0xfb, 0x5e, 0xf2, 0x0e, 0x1a, 0x04, 0x8f, 0xd1, 0x47, 0xcf, 0x42, 0xa2, 0x30, 0xf4, 0x2e,
-	0x14, 0x08, 0x5a, 0xe8, 0x9c, 0xa2, 0x5f, 0xab, 0xce, 0x80, 0x17, 0xa1, 0xe8, 0x1d, 0xc8, 0x76,
-	0xb9, 0x8a, 0x6a, 0xeb, 0x02, 0xee, 0xb6, 0x82, 0x6b, 0xa4, 0x80, 0x13, 0x74, 0xfb, 0xa3, 0xc1,
-	0x11, 0xfa, 0x44, 0x82, 0xe8, 0x97, 0x21, 0xd3, 0x6e, 0xd6, 0x6a, 0x42, 0x05, 0x99, 0x76, 0x53,
-	0xbf, 0x05, 0x70, 0xe0, 0x3b, 0x7d, 0xc7, 0xdb, 0x35, 0x83, 0x93, 0xda, 0x37, 0x05, 0x8b, 0x6f,
-	0x28, 0x16, 0xa5, 0x3d, 0x0c, 0x02, 0xb3, 0x8f, 0xfc, 0x15, 0x89, 0x2d, 0x33, 0xf6, 0x20, 0x7b,
-	0xcf, 0xf7, 0xa9, 0x9f, 0xa0, 0x73, 0x1d, 0x56, 0xee, 0x52, 0x1b, 0x85, 0xc2, 0x57, 0x89, 0x78,
-	0xe6, 0x73, 0x87, 0xf8, 0x90, 0x09, 0x5d, 0x17, 0x89, 0x78, 0x36, 0x18, 0x14, 0x5b, 0xc8, 0x0e,
-	0x8e, 0x7e, 0x86, 0x16, 0x4b, 0x80, 0x6c, 0x43, 0x41, 0xae, 0x6b, 0x37, 0xa5, 0x1d, 0x77, 0x6e,
-	0x28, 0x61, 0xbf, 0x9d, 0x42, 0x1f, 0xed, 0x26, 0x89, 0xc8, 0x0d, 0x0f, 0xf2, 0x2d, 0x64, 0x42,
-	0xa8, 0x57, 0xf3, 0xbc, 0x07, 0x39, 0xbe, 0x6a, 0x5a, 0x8e, 0x8a, 0xd8, 0xf8, 0xad, 0x06, 0xc5,
-	0xae, 0x19, 0x04, 0x3d, 0x66, 0xb2, 0x24, 0x96, 0x55, 0xc8, 0x49, 0x75, 0x2b, 0x67, 0x55, 0x23,
-	0xbd, 0x05, 0x79, 0x41, 0xde, 0x6e, 0x2a, 0x67, 0xbd, 0xa0, 0x2c, 0x21, 0xb5, 0xf1, 0x43, 0x58,
-	0xe1, 0xb2, 0x4c, 0x27, 0x86, 0x71, 0x07, 0xf2, 0xbd, 0x54, 0xaa, 0xab, 0x42, 0x8e, 0x88, 0xac,
-	0x10, 0x02, 0xc8, 0x91, 0xf1, 0x03, 0xc8, 0xb6, 0x3d, 0x1b, 0x1f, 0x26, 0x90, 0x97, 0xd5, 0x32,
-	0x45, 0x2d, 0x07, 0x5c, 0xf6, 0x19, 0x58, 0xbf, 0x0b, 0xd9, 0x94, 0x16, 0x78, 0x29, 0xb9, 0xc9,
-	0xe3, 0x27, 0x81, 0xf6, 0x5d, 0x11, 0x63, 0x53, 0x39, 0x4b, 0xa6, 0xdd, 0x34, 0x6c, 0x58, 0x6e,
-	0x37, 0x93, 0x4c, 0x73, 0x47, 0x2c, 0xaa, 0x95, 0x37, 0x96, 0x2f, 0xce, 0x84, 0x53, 0x1a, 0x1f,
-	0x69, 0xb0, 0xfc, 0x1e, 0x26, 0xc5, 0xdb, 0x7d, 0xc8, 0xbe, 0x87, 0xe3, 0x60, 0x7b, 0x5b, 0x31,
-	0xba, 0x9e, 0x82, 0x91, 0xa0, 0x23, 0x92, 0x9c, 0xab, 0x73, 0xdb, 0x62, 0x23, 0xd3, 0x15, 0x7e,
-	0x5b, 0x20, 0x6a, 0x64, 0x58, 0xa0, 0xf7, 0x90, 0xb5, 0x3d, 0x8b, 0x0e, 0x1c, 0xaf, 0x4f, 0xf0,
-	0x17, 0x23, 0x0c, 0x92, 0x64, 0xda, 0x84, 0xbc, 0x5a, 0x28, 0xa4, 0x2a, 0xdd, 0x5c, 0x6b, 0xa8,
-	0xa3, 0xe7, 0x81, 0xe3, 0x73, 0xd4, 0x9d, 0x15, 0x2e, 0x26, 0x09, 0x57, 0x29, 0x26, 0x07, 0x23,
-	0xd6, 0xa7, 0xaf, 0x8f, 0xc9, 0x7f, 0x35, 0xb8, 0xda, 0x33, 0xfb, 0xe6, 0x5d, 0xd3, 0x75, 0xb7,
-	0x2d, 0x0b, 0x87, 0x6c, 0x9f, 0x32, 0xe7, 0xd8, 0xb1, 0x4c, 0xe6, 0x50, 0x6f, 0x61, 0x69, 0x4d,
-	0xff, 0x09, 0x5c, 0x69, 0x22, 0x33, 0xad, 0x13, 0xb4, 0x95, 0x68, 0xd3, 0x26, 0x8b, 0x2f, 0xe2,
-	0xf0, 0x53, 0x34, 0xd4, 0x4a, 0x55, 0x9e, 0xa2, 0xe1, 0xf6, 0xb7, 0xa1, 0xd8, 0x43, 0x46, 0x30,
-	0x18, 0xb9, 0x2c, 0x4d, 0x68, 0xf1, 0x75, 0xe3, 0xd0, 0xe2, 0x23, 0xe3, 0x43, 0x28, 0x6c, 0x5b,
-	0xcc, 0x39, 0x9d, 0x3a, 0x38, 0x63, 0xc8, 0x95, 0x09, 0xe4, 0x1f, 0x03, 0x34, 0xd1, 0x7c, 0x3d,
-	0xd8, 0x0f, 0x20, 0xf7, 0xc1, 0xd0, 0x9e, 0x3f, 0xee, 0x1f, 0x32, 0x50, 0x6a, 0x21, 0xbb, 0xef,
-	0xb8, 0xe6, 0x00, 0xbd, 0xc5, 0x9d, 0x8b, 0xfa, 0x8f, 0xa0, 0xd8, 0x63, 0xa6, 0xcf, 0xee, 0xfb,
-	0x74, 0x30, 0x9d, 0xe3, 0x8c, 0xe9, 0xf5, 0x43, 0x28, 0x12, 0x34, 0xed, 0x0f, 0x3c, 0xe6, 0xb8,
-	0xea, 0x3e, 0x34, 0xed, 0x05, 0x66, 0x0c, 0x64, 0xfc, 0x55, 0x83, 0xb5, 0x50, 0x31, 0x3d, 0xec,
-	0x2f, 0x56, 0x3f, 0x77, 0x78, 0x0c, 0x70, 0xd3, 0x05, 0xb5, 0xca, 0xc6, 0xf2, 0xf5, 0xd2, 0xcd,
-	0x6b, 0x61, 0x66, 0xb8, 0x4b, 0x07, 0x43, 0x1a, 0x38, 0x0c, 0x43, 0xd9, 0xe4, 0xba, 0x71, 0xa6,
-	0x10, 0x54, 0xc6, 0xef, 0x32, 0x70, 0xb9, 0xc5, 0x63, 0x45, 0x46, 0x95, 0x77, 0x4c, 0x17, 0x6a,
-	0xdc, 0x19, 0xb3, 0xc2, 0x98, 0x7e, 0x7c, 0x33, 0xad, 0xce, 0xe1, 0x66, 0x6a, 0xfc, 0x5b, 0x83,
-	0xd2, 0xd7, 0x5f, 0x27, 0x5f, 0x9a, 0x21, 0x63, 0x81, 0xbe, 0x3e, 0x11, 0xe8, 0xcf, 0x34, 0xd0,
-	0x5b, 0x94, 0xed, 0x52, 0x76, 0x97, 0x7a, 0xc7, 0x8e, 0x3f, 0x48, 0x73, 0x60, 0xcc, 0xeb, 0x5c,
-	0x8e, 0x4c, 0x58, 0x99, 0x47, 0x71, 0x51, 0x86, 0x6c, 0x6f, 0xe8, 0x3a, 0x72, 0xeb, 0x05, 0x22,
-	0x07, 0xc6, 0x53, 0x0d, 0x40, 0xee, 0x75, 0xb1, 0x76, 0x6d, 0xf3, 0x52, 0x4c, 0xb0, 0x9d, 0xd2,
-	0xac, 0x11, 0x79, 0xcc, 0x76, 0xd5, 0x09, 0xdb, 0xfd, 0x3e, 0x03, 0xb0, 0x4b, 0x55, 0xe9, 0x12,
-	0x2c, 0xda, 0x66, 0xf3, 0x08, 0x3b, 0xfd, 0x2d, 0x58, 0x69, 0xfa, 0x74, 0x28, 0x34, 0x54, 0xba,
-	0x09, 0x0d, 0x51, 0x5a, 0xf3, 0x19, 0x95, 0xb6, 0xc4, 0x5b, 0xfd, 0x06, 0xe4, 0xc5, 0xe5, 0x1b,
-	0x83, 0xda, 0xba, 0x48, 0x7a, 0xab, 0x61, 0xd2, 0x13, 0xd3, 0x61, 0x8a, 0x53, 0x6b, 0x8c, 0x4f,
-	0x35, 0x80, 0x71, 0x8a, 0xfb, 0x7a, 0x86, 0xb2, 0xf1, 0x89, 0x16, 0xc5, 0x72, 0xc2, 0x0e, 0x26,
-	0xd8, 0x96, 0x67, 0xcc, 0x20, 0xb1, 0x9b, 0x67, 0x25, 0xd5, 0xcd, 0xf3, 0x53, 0x0d, 0x4a, 0x3d,
-	0xf4, 0x4f, 0x1d, 0x0b, 0x9b, 0x66, 0x62, 0x23, 0xa4, 0x0e, 0xd0, 0xa1, 0xfd, 0x43, 0xdf, 0xb4,
-	0xc2, 0x8a, 0xb6, 0x48, 0x62, 0x33, 0xfa, 0x01, 0x14, 0x3a, 0xb4, 0xdf, 0xc1, 0x53, 0x94, 0x77,
-	0xf5, 0xd5, 0x9d, 0x5b, 0x6a, 0x2b, 0xdf, 0x4d, 0xb1, 0x95, 0x90, 0x94, 0x44, 0x20, 0xfa, 0x5b,
-	0xb0, 0x2a, 0xb0, 0x7b, 0x43, 0xd3, 0xe3, 0xf2, 0xa9, 0x10, 0x9a, 0x9c, 0x34, 0xfe, 0xa3, 0x41,
-	0xe5, 0xde, 0x43, 0xb4, 0x46, 0x3c, 0xf9, 0xbd, 0x3f, 0xc2, 0x11, 0xde, 0x73, 0x31, 0xc5, 0xc1,
-	0x7e, 0xc8, 0x73, 0x8b, 0xd0, 0x03, 0xc1, 0x63, 0xa5, 0xfb, 0xe9, 0x3a, 0x2e, 0x31, 0x1c, 0xfd,
-	0x16, 0x14, 0xc2, 0x9a, 0x44, 0x19, 0x61, 0x7d, 0xec, 0xef, 0x13, 0xb5, 0x0a, 0x89, 0x16, 0xea,
-	0xb7, 0x27, 0xcc, 0x20, 0xb6, 0x59, 0xba, 0x59, 0x6e, 0x84, 0x7d, 0xb8, 0xd8, 0x3b, 0x12, 0x5f,
-	0x68, 0x7c, 0x96, 0x81, 0x55, 0x82, 0x6c, 0xe4, 0x7b, 0x32, 0xab, 0x24, 0xe5, 0x91, 0x0e, 0xe4,
-	0x0e, 0x4d, 0xbf, 0x8f, 0x6c, 0xa6, 0xed, 0x2a, 0x8c, 0x73, 0x0a, 0xac, 0xcc, 0x49, 0x81, 0x1d,
-	0x9e, 0x30, 0xcd, 0x80, 0x7a, 0x33, 0x35, 0xc1, 0x14, 0x06, 0x3f, 0x57, 0x08, 0x0e, 0xdd, 0x47,
-	0xea, 0xe4, 0x94, 0x03, 0x3e, 0x2b, 0xba, 0x50, 0xa2, 0x9b, 0x55, 0x24, 0x72, 0x60, 0xfc, 0x45,
-	0x03, 0xe0, 0x35, 0xd8, 0x1e, 0xb2, 0x13, 0x6a, 0x27, 0xa8, 0xf2, 0x9d, 0xf3, 0x55, 0xde, 0x97,
-	0x9a, 0x39, 0xca, 0x04, 0x1f, 0x42, 0x29, 0x96, 0x45, 0x55, 0x88, 0x4c, 0x9b, 0x83, 0xe3, 0x50,
-	0xc6, 0xc7, 0xcb, 0xb0, 0x26, 0x43, 0x80, 0xfa, 0xa9, 0x3d, 0x81, 0x6f, 0x15, 0xfd, 0xd9, 0x3c,
-	0x41, 0x62, 0xe8, 0x84, 0x67, 0x31, 0xbe, 0xf9, 0x59, 0x1d, 0x61, 0x0c, 0xa3, 0x6f, 0x41, 0x56,
-	0x04, 0x73, 0xad, 0x2a, 0x4e, 0x8d, 0x7a, 0x14, 0x0d, 0x2f, 0x8d, 0x75, 0x22, 0x17, 0xeb, 0x5b,
-	0x50, 0xe9, 0x88, 0x6e, 0xef, 0xae, 0x19, 0xec, 0x51, 0x1f, 0x95, 0xee, 0x03, 0x61, 0xff, 0x02,
-	0x79, 0xf9, 0x4b, 0xfd, 0x7d, 0xc8, 0x77, 0xd1, 0xb3, 0x79, 0xcc, 0x72, 0x8f, 0xc8, 0xee, 0x7c,
-	0x5f, 0x49, 0xbf, 0x99, 0xc6, 0x2a, 0x92, 0x52, 0xb4, 0x86, 0x48, 0x88, 0x63, 0x7c, 0xa4, 0xc1,
-	0x9a, 0x7a, 0xbe, 0xef, 0x78, 0x4e, 0x70, 0x82, 0x49, 0x1e, 0x45, 0xa0, 0x28, 0x4f, 0xa3, 0x59,
-	0xd3, 0xd1, 0x18, 0xc6, 0xf8, 0xf3, 0x32, 0x18, 0xdb, 0xb6, 0xed, 0x70, 0x75, 0x99, 0x2e, 0xb7,
-	0x16, 0xaf, 0xad, 0xba, 0x3e, 0x9e, 0x3a, 0x74, 0x14, 0x84, 0x2e, 0x93, 0x20, 0xd8, 0x4f, 0x61,
-	0x2d, 0x42, 0x94, 0x2c, 0x66, 0x12, 0xef, 0x3c, 0x58, 0x5c, 0xfb, 0x95, 0xf9, 0x68, 0xff, 0x5c,
-	0x6a, 0xaa, 0xce, 0x29, 0x35, 0xc5, 0x62, 0x7e, 0x3d, 0x65, 0xcc, 0x9f, 0xcb, 0xec, 0xb5, 0xb4,
-	0x99, 0xfd, 0x4f, 0x19, 0xb8, 0xdc, 0x63, 0x8e, 0xeb, 0x2a, 0x6f, 0xf7, 0xfa, 0x8b, 0xf7, 0x1e,
-	0xbd, 0x0b, 0x85, 0xd0, 0x45, 0x66, 0x8a, 0xea, 0x08, 0x45, 0x7f, 0x10, 0x15, 0x6a, 0x04, 0x8f,
-	0x03, 0x11, 0xda, 0xd3, 0x82, 0xc6, 0x81, 0x8c, 0x53, 0xd1, 0xf1, 0x50, 0xd6, 0x0f, 0x16, 0xf7,
-	0x25, 0xe0, 0x14, 0x4a, 0xbb, 0x66, 0xb0, 0x78, 0xbe, 0xfb, 0x70, 0x29, 0x64, 0x9a, 0xa2, 0x32,
-	0xda, 0x98, 0x90, 0x52, 0xf0, 0x2e, 0x90, 0xf8, 0x94, 0xf1, 0x38, 0xc3, 0x0d, 0x33, 0x74, 0xd3,
-	0xf5, 0x1c, 0xbf, 0x9a, 0x25, 0x64, 0xac, 0xd0, 0xa8, 0x26, 0x17, 0x1a, 0xfa, 0xdb, 0xe3, 0x66,
-	0x8c, 0xac, 0x4b, 0xde, 0x08, 0x97, 0xef, 0x99, 0x0c, 0x7d, 0x27, 0x7e, 0x5b, 0x16, 0xcb, 0xa2,
-	0x7a, 0xa7, 0xf6, 0xaa, 0x7a, 0xc7, 0xf8, 0x4c, 0x83, 0x5c, 0x0b, 0x59, 0x72, 0x83, 0x7c, 0x8e,
-	0xc5, 0xcb, 0xeb, 0xbb, 0x59, 0xfc, 0x46, 0x83, 0x37, 0xb7, 0x8f, 0x4c, 0xcf, 0xa6, 0x5e, 0xd4,
-	0xcd, 0x0d, 0xfe, 0x2f, 0xed, 0x69, 0xe3, 0xd7, 0x1a, 0x94, 0x5b, 0xc8, 0x3a, 0x4e, 0xff, 0x84,
-	0xb5, 0x3d, 0x87, 0x39, 0xa6, 0x9b, 0xe6, 0x73, 0xcc, 0x5c, 0x9d, 0xcc, 0xf8, 0x24, 0x03, 0x57,
-	0x2e, 0x2a, 0x81, 0x01, 0x97, 0xf6, 0x91, 0xfd, 0x92, 0xfa, 0x3f, 0x17, 0xdd, 0x4d, 0x15, 0x7f,
-	0x13, 0x73, 0xfa, 0x2e, 0xe4, 0x44, 0x4c, 0xc8, 0xce, 0xe0, 0x34, 0x31, 0xa5, 0xe8, 0xf5, 0xef,
-	0x40, 0x96, 0xfb, 0x61, 0x18, 0x04, 0x5f, 0x74, 0x53, 0xf9, 0xfa, 0x82, 0x75, 0xb9, 0x7e, 0x23,
-	0x54, 0xa3, 0xf4, 0xfe, 0x2b, 0x0d, 0xf9, 0xf1, 0x5d, 0xcc, 0x75, 0x7d, 0xca, 0x68, 0x88, 0x2e,
-	0xf5, 0x14, 0x40, 0xa1, 0x85, 0x2c, 0xcd, 0x97, 0xba, 0x39, 0x7a, 0xc8, 0xdf, 0x34, 0x28, 0xca,
-	0x8e, 0x7a, 0x72, 0xf4, 0x45, 0x6e, 0x51, 0x9e, 0x47, 0xee, 0x89, 0x32, 0x62, 0x65, 0xa6, 0x8c,
-	0xb8, 0xb3, 0xf5, 0xe4, 0x79, 0x7d, 0xe9, 0xe9, 0xf3, 0xfa, 0xd2, 0xe7, 0xcf, 0xeb, 0xda, 0xaf,
-	0xce, 0xea, 0xda, 0x1f, 0xcf, 0xea, 0xda, 0xe3, 0xb3, 0xba, 0xf6, 0xe4, 0xac, 0xae, 0xfd, 0xe3,
-	0xac, 0xae, 0xfd, 0xf3, 0xac, 0xbe, 0xf4, 0xf9, 0x59, 0x5d, 0xfb, 0xf8, 0x45, 0x7d, 0xe9, 0xc9,
-	0x8b, 0xfa, 0xd2, 0xd3, 0x17, 0xf5, 0xa5, 0xa3, 0x9c, 0xf8, 0x7f, 0x86, 0x5b, 0xff, 0x0b, 0x00,
-	0x00, 0xff, 0xff, 0xf5, 0xbf, 0x6a, 0x07, 0xc9, 0x21, 0x00, 0x00,
+	// 1776 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x5a, 0x3d, 0x6c, 0x1b, 0xd9,
+	0x11, 0xd6, 0x52, 0xe2, 0xdf, 0xd0, 0xb2, 0xec, 0x17, 0x92, 0xda, 0x18, 0x39, 0x5a, 0x58, 0x5c,
+	0x02, 0x23, 0x89, 0xa5, 0x3b, 0x5b, 0x70, 0x8a, 0xe4, 0x60, 0x48, 0xa6, 0x4d, 0xf1, 0x42, 0xd9,
+	0xba, 0x47, 0x9d, 0x71, 0x48, 0x80, 0x00, 0x2b, 0xee, 0x88, 0xda, 0x64, 0xb5, 0x8f, 0xd9, 0x7d,
+	0x54, 0xec, 0x2e, 0x48, 0x9a, 0x20, 0xd5, 0x15, 0x49, 0x91, 0x32, 0xc5, 0x01, 0x29, 0x52, 0x27,
+	0x45, 0x8a, 0x00, 0x57, 0x19, 0xb8, 0xc6, 0xa5, 0x91, 0xc2, 0x88, 0xe5, 0x26, 0xe5, 0xa5, 0x4b,
+	0x91, 0x22, 0x78, 0x3f, 0xbb, 0x5c, 0xca, 0x3e, 0xef, 0x9a, 0xa4, 0x99, 0xbb, 0x46, 0xdc, 0xf7,
+	0x76, 0xe6, 0x9b, 0x79, 0xf3, 0x66, 0xe6, 0xbd, 0x99, 0x15, 0xbc, 0xe5, 0xfa, 0x21, 0xf3, 0xec,
+	0x60, 0x63, 0x60, 0x3f, 0xf4, 0x98, 0xed, 0x44, 0xbf, 0xeb, 0x83, 0x80, 0x71, 0x46, 0x8a, 0x7a,
+	0x78, 0xe9, 0x6a, 0xdf, 0xe5, 0x47, 0xc3, 0x83, 0xf5, 0x1e, 0x3b, 0xde, 0xe8, 0xb3, 0x3e, 0xdb,
+	0x90, 0xef, 0x0f, 0x86, 0x87, 0x72, 0x24, 0x07, 0xf2, 0x49, 0xf1, 0x5d, 0xba, 0x91, 0x20, 0x8f,
+	0x24, 0x9c, 0xfd, 0x0d, 0xb0, 0xc7, 0x02, 0x47, 0xff, 0x68, 0xbe, 0xcd, 0x0c, 0x7c, 0x83, 0xa1,
+	0x17, 0xa2, 0xfa, 0xab, 0xb9, 0xde, 0x7d, 0x05, 0x97, 0x87, 0x4e, 0x1f, 0x83, 0x0d, 0x27, 0x60,
+	0x03, 0xf9, 0x47, 0xb1, 0x58, 0xff, 0xce, 0xc1, 0xd2, 0x2e, 0x72, 0x9b, 0x7c, 0x03, 0xca, 0x7b,
+	0xcc, 0x7b, 0x78, 0xcc, 0x82, 0xc1, 0x91, 0x79, 0x61, 0xcd, 0xb8, 0xb2, 0x4c, 0x47, 0x13, 0xc4,
+	0x84, 0xe2, 0x9e, 0xb2, 0x80, 0x59, 0x5d, 0x33, 0xae, 0x9c, 0xa3, 0xd1, 0x90, 0x74, 0xa0, 0xd0,
+	0x45, 0xdf, 0xc1, 0xc0, 0xac, 0x89, 0x17, 0xdb, 0x9b, 0x8f, 0x9e, 0x5e, 0x5e, 0xf8, 0xc7, 0xd3,
+	0xcb, 0xdf, 0x4d, 0x5f, 0xc1, 0x3a, 0xc5, 0x43, 0x0c, 0xd0, 0xef, 0x21, 0xd5, 0x18, 0x64, 0x0f,
+	0x4a, 0x14, 0x7b, 0xe8, 0x9e, 0x60, 0x60, 0xd6, 0xa7, 0xc0, 0x8b, 0x51, 0x48, 0x07, 0xf2, 0x7b,
+	0xc2, 0x44, 0xe6, 0xaa, 0x84, 0xbb, 0xa1, 0xe1, 0xd6, 0x33, 0xc0, 0x49, 0xbe, 0xbb, 0xc3, 0xe3,
+	0x03, 0x0c, 0xa8, 0x02, 0x21, 0xe7, 0x21, 0xd7, 0x6e, 0x9a, 0xa6, 0x34, 0x41, 0xae, 0xdd, 0x24,
+	0xd7, 0x01, 0xee, 0x05, 0x6e, 0xdf, 0xf5, 0x77, 0xec, 0xf0, 0xc8, 0xfc, 0xba, 0x14, 0xf1, 0x35,
+	0x2d, 0xa2, 0xb2, 0x8b, 0x61, 0x68, 0xf7, 0x51, 0xbc, 0xa2, 0x09, 0x32, 0x6b, 0x17, 0xf2, 0xb7,
+	0x83, 0x80, 0x05, 0x29, 0x36, 0x27, 0xb0, 0x74, 0x8b, 0x39, 0x28, 0x0d, 0xbe, 0x4c, 0xe5, 0xb3,
+	0x98, 0xdb, 0xc7, 0x07, 0x5c, 0xda, 0xba, 0x4c, 0xe5, 0xb3, 0xc5, 0xa1, 0xdc, 0x42, 0x7e, 0xef,
+	0xe0, 0xa7, 0xd8, 0xe3, 0x29, 0x90, 0x6d, 0x28, 0x29, 0xba, 0x76, 0x53, 0xed, 0xe3, 0xf6, 0x55,
+	0xad, 0xec, 0x37, 0x33, 0xd8, 0xa3, 0xdd, 0xa4, 0x31, 0xbb, 0xe5, 0x43, 0xb1, 0x85, 0x5c, 0x2a,
+	0xf5, 0x6a, 0x99, 0xb7, 0xa1, 0x20, 0xa8, 0x26, 0x95, 0xa8, 0x99, 0xad, 0xdf, 0x1a, 0x50, 0xde,
+	0xb3, 0xc3, 0xb0, 0xcb, 0x6d, 0x9e, 0x26, 0xb2, 0x0e, 0x05, 0x65, 0x6e, 0xed, 0xac, 0x7a, 0x44,
+	0x5a, 0x50, 0x94, 0xec, 0xed, 0xa6, 0x59, 0x9b, 0x44, 0x97, 0x88, 0xdb, 0xfa, 0x01, 0x2c, 0x09,
+	0x5d, 0x26, 0x53, 0xc3, 0xba, 0x09, 0xc5, 0x6e, 0x26, 0xd3, 0xd5, 0xa1, 0x40, 0x65, 0x56, 0x88,
+	0x00, 0xd4, 0xc8, 0xfa, 0x3e, 0xe4, 0xdb, 0xbe, 0x83, 0x0f, 0x52, 0xd8, 0xab, 0x9a, 0x4c, 0x73,
+	0xab, 0x81, 0xd0, 0x7d, 0x0a, 0xd1, 0xef, 0x41, 0x3e, 0xe3, 0x0e, 0xbc, 0x94, 0xdd, 0x16, 0xf1,
+	0x93, 0xc2, 0xfb, 0x9e, 0x8c, 0xb1, 0x89, 0x9c, 0x25, 0xd7, 0x6e, 0x5a, 0x0e, 0x2c, 0xb6, 0x9b,
+	0x69, 0x5b, 0x73, 0x53, 0x12, 0x99, 0xd5, 0xb5, 0xc5, 0xd7, 0x17, 0x22, 0x38, 0xad, 0x5f, 0x1b,
+	0xb0, 0xf8, 0x3e, 0xa6, 0xc5, 0xdb, 0x1d, 0xc8, 0xbf, 0x8f, 0xa3, 0x60, 0x7b, 0x47, 0x0b, 0xba,
+	0x92, 0x41, 0x90, 0xe4, 0xa3, 0x8a, 0x5d, 0x98, 0x73, 0xab, 0xc7, 0x87, 0xb6, 0x27, 0xfd, 0xb6,
+	0x44, 0xf5, 0xc8, 0xea, 0x01, 0xe9, 0x22, 0x6f, 0xfb, 0x3d, 0x76, 0xec, 0xfa, 0x7d, 0x8a, 0x3f,
+	0x1f, 0x62, 0x98, 0xa6, 0xd3, 0x06, 0x14, 0x35, 0xa1, 0xd4, 0xaa, 0x72, 0x6d, 0x65, 0x5d, 0x1f,
+	0x3d, 0xf7, 0xdd, 0x40, 0xa0, 0x6e, 0x2f, 0x09, 0x35, 0x69, 0x44, 0xa5, 0x85, 0xdc, 0x1b, 0xf2,
+	0x3e, 0x7b, 0x73, 0x42, 0xfe, 0x6b, 0xc0, 0xa5, 0xae, 0xdd, 0xb7, 0x6f, 0xd9, 0x9e, 0xb7, 0xd5,
+	0xeb, 0xe1, 0x80, 0xdf, 0x65, 0xdc, 0x3d, 0x74, 0x7b, 0x36, 0x77, 0x99, 0x3f, 0xb7, 0xb4, 0x46,
+	0x7e, 0x0c, 0x17, 0x9b, 0xc8, 0xed, 0xde, 0x11, 0x3a, 0x5a, 0xb5, 0x49, 0x93, 0xc5, 0x8b, 0x38,
+	0xe2, 0x14, 0x8d, 0xac, 0x52, 0x57, 0xa7, 0x68, 0xb4, 0xfc, 0x2d, 0x28, 0x77, 0x91, 0x53, 0x0c,
+	0x87, 0x1e, 0xcf, 0x12, 0x5a, 0x82, 0x6e, 0x14, 0x5a, 0x62, 0x64, 0x7d, 0x04, 0xa5, 0xad, 0x1e,
+	0x77, 0x4f, 0x26, 0x0e, 0xce, 0x04, 0x72, 0x6d, 0x0c, 0xf9, 0x47, 0x00, 0x4d, 0xb4, 0xdf, 0x0c,
+	0xf6, 0x7d, 0x28, 0x7c, 0x38, 0x70, 0x66, 0x8f, 0xfb, 0x87, 0x1c, 0x54, 0x5a, 0xc8, 0xef, 0xb8,
+	0x9e, 0x7d, 0x8c, 0xfe, 0xfc, 0xce, 0x45, 0xf2, 0x43, 0x28, 0x77, 0xb9, 0x1d, 0xf0, 0x3b, 0x01,
+	0x3b, 0x9e, 0xcc, 0x71, 0x46, 0xfc, 0x64, 0x1f, 0xca, 0x14, 0x6d, 0xe7, 0x43, 0x9f, 0xbb, 0x9e,
+	0x59, 0x9f, 0xea, 0x02, 0x33, 0x02, 0xb2, 0xfe, 0x66, 0xc0, 0x4a, 0x64, 0x98, 0x2e, 0xf6, 0xe7,
+	0x6b, 0x9f, 0x9b, 0x50, 0x54, 0x5b, 0x17, 0x9a, 0xb5, 0xb5, 0xc5, 0x2b, 0x95, 0x6b, 0x97, 0xa3,
+	0xcc, 0x70, 0x8b, 0x1d, 0x0f, 0x58, 0xe8, 0x72, 0x8c, 0x74, 0x53, 0x74, 0xa3, 0x4c, 0x21, 0xb9,
+	0xac, 0xdf, 0xe5, 0xe0, 0x7c, 0x0b, 0xb9, 0x8e, 0x9c, 0xb6, 0x7f, 0xc8, 0xe6, 0xba, 0xb9, 0x53,
+	0x66, 0x85, 0x11, 0xff, 0xe8, 0x66, 0x5a, 0x9f, 0xc1, 0xcd, 0xd4, 0xfa, 0x63, 0x0e, 0x2a, 0x5f,
+	0x7d, 0x9b, 0x7c, 0x61, 0x86, 0x4c, 0x04, 0xfa, 0x6a, 0x32, 0xd0, 0xc9, 0xb7, 0xe1, 0xc2, 0x99,
+	0xa3, 0x29, 0x34, 0x4d, 0x71, 0xac, 0xd3, 0x17, 0xe6, 0xad, 0xa7, 0x06, 0x90, 0x16, 0xe3, 0x3b,
+	0x8c, 0xdf, 0x62, 0xfe, 0xa1, 0x1b, 0x1c, 0x67, 0x39, 0x5c, 0x66, 0x75, 0x86, 0xc7, 0xdb, 0x5d,
+	0x9b, 0x45, 0x21, 0x52, 0x85, 0x7c, 0x77, 0xe0, 0xb9, 0xca, 0x4c, 0x25, 0xaa, 0x06, 0xd6, 0x13,
+	0x03, 0x40, 0xd9, 0x65, 0xbe, 0x3e, 0xd0, 0x86, 0x92, 0x16, 0x3b, 0xa1, 0x0b, 0xc4, 0xec, 0x89,
+	0x7d, 0xae, 0x8f, 0x25, 0xf4, 0xdf, 0xe7, 0x00, 0x76, 0x98, 0x2e, 0x73, 0xc2, 0x79, 0xef, 0xd9,
+	0x2c, 0x42, 0x94, 0xbc, 0x0d, 0x4b, 0xcd, 0x80, 0x0d, 0xa4, 0x85, 0x2a, 0xd7, 0x60, 0x5d, 0x96,
+	0xe1, 0x62, 0x46, 0xa7, 0x38, 0xf9, 0x96, 0x5c, 0x85, 0xa2, 0xbc, 0xa8, 0x63, 0x68, 0xae, 0xca,
+	0x04, 0xb9, 0x1c, 0x25, 0x48, 0x39, 0x1d, 0xa5, 0x43, 0x4d, 0x63, 0x7d, 0x6a, 0x00, 0x8c, 0xd2,
+	0xe1, 0x57, 0x33, 0xec, 0xad, 0x4f, 0x0c, 0x28, 0x66, 0x5b, 0xc1, 0x98, 0xd8, 0xea, 0x94, 0xd9,
+	0x26, 0x71, 0x4b, 0xad, 0x65, 0xba, 0xa5, 0x7e, 0x6a, 0x40, 0xa5, 0x8b, 0xc1, 0x89, 0xdb, 0xc3,
+	0xa6, 0x9d, 0xda, 0x34, 0x69, 0x00, 0x74, 0x58, 0x7f, 0x3f, 0xb0, 0x7b, 0x51, 0xf5, 0x5b, 0xa6,
+	0x89, 0x19, 0x72, 0x0f, 0x4a, 0x1d, 0xd6, 0xef, 0xe0, 0x09, 0xaa, 0x7b, 0xfd, 0xf2, 0xf6, 0x75,
+	0xbd, 0x94, 0xef, 0x64, 0x58, 0x4a, 0xc4, 0x4a, 0x63, 0x10, 0xf2, 0x36, 0x2c, 0x4b, 0xec, 0xee,
+	0xc0, 0xf6, 0x85, 0x7e, 0x3a, 0x84, 0xc6, 0x27, 0xad, 0xff, 0x18, 0x50, 0xbb, 0xfd, 0x00, 0x7b,
+	0x43, 0x91, 0xfc, 0x3e, 0x18, 0xe2, 0x10, 0x6f, 0x7b, 0x98, 0xe1, 0x12, 0xb0, 0x0f, 0xa0, 0xed,
+	0x40, 0xf1, 0xd0, 0xac, 0x4e, 0xd1, 0x9d, 0x49, 0xe0, 0x90, 0xeb, 0x50, 0x8a, 0xea, 0x17, 0xbd,
+	0x09, 0xab, 0x23, 0x7f, 0x1f, 0xab, 0x6b, 0x68, 0x4c, 0x48, 0x6e, 0x8c, 0x6d, 0x83, 0x5c, 0x66,
+	0xe5, 0x5a, 0x75, 0x3d, 0xea, 0xd9, 0x25, 0xde, 0xd1, 0x24, 0xa1, 0xf5, 0x59, 0x0e, 0x96, 0x29,
+	0xf2, 0x61, 0xe0, 0xab, 0xac, 0x92, 0x96, 0x47, 0x3a, 0x50, 0xd8, 0xb7, 0x83, 0x3e, 0xf2, 0xa9,
+	0x96, 0xab, 0x31, 0xce, 0x18, 0xb0, 0x36, 0x23, 0x03, 0x76, 0x44, 0xc2, 0xb4, 0x43, 0xe6, 0x4f,
+	0xd5, 0x30, 0xd3, 0x18, 0xe2, 0x5c, 0xa1, 0x38, 0xf0, 0x1e, 0xea, 0x53, 0x56, 0x0d, 0x48, 0x55,
+	0x77, 0xac, 0x64, 0xe7, 0xab, 0x4c, 0xd5, 0xc0, 0xfa, 0xab, 0x01, 0x20, 0xea, 0xb5, 0x5d, 0xe4,
+	0x47, 0xcc, 0x49, 0x31, 0xe5, 0xbb, 0x67, 0x2b, 0xc2, 0x2f, 0xdc, 0xe6, 0x38, 0x13, 0x7c, 0x04,
+	0x95, 0x44, 0x16, 0xd5, 0x21, 0x32, 0x69, 0x0e, 0x4e, 0x42, 0x59, 0x1f, 0x2f, 0xc2, 0x8a, 0x0a,
+	0x01, 0x16, 0x64, 0xf6, 0x04, 0xb1, 0x54, 0x0c, 0xa6, 0xf3, 0x04, 0x85, 0x41, 0xa8, 0xc8, 0x62,
+	0x62, 0xf1, 0xd3, 0x3a, 0xc2, 0x08, 0x86, 0x6c, 0x42, 0x5e, 0x06, 0xb3, 0x59, 0x97, 0xa7, 0x46,
+	0x23, 0x8e, 0x86, 0x97, 0xc6, 0x3a, 0x55, 0xc4, 0x64, 0x13, 0x6a, 0x1d, 0xd9, 0x19, 0xde, 0xb1,
+	0xc3, 0x5d, 0x16, 0x60, 0x7c, 0x87, 0x5a, 0x95, 0xf7, 0x8a, 0x97, 0xbf, 0x24, 0x1f, 0x40, 0x71,
+	0x0f, 0x7d, 0x47, 0xc4, 0xac, 0xf0, 0x88, 0xfc, 0xf6, 0xf7, 0xb4, 0xf6, 0x1b, 0x59, 0x76, 0x45,
+	0x71, 0xca, 0x36, 0x12, 0x8d, 0x70, 0x44, 0x43, 0x65, 0x45, 0x3f, 0xdf, 0x71, 0x7d, 0x37, 0x3c,
+	0xc2, 0x34, 0x8f, 0xa2, 0x50, 0x56, 0xa7, 0xd1, 0xb4, 0xe9, 0x68, 0x04, 0x63, 0xfd, 0x65, 0x11,
+	0xac, 0x2d, 0xc7, 0x71, 0x85, 0xb9, 0x6c, 0x4f, 0xec, 0x96, 0xa8, 0xc3, 0xf6, 0x02, 0x3c, 0x71,
+	0xd9, 0x30, 0x8c, 0x5c, 0x26, 0x45, 0xb1, 0x9f, 0xc0, 0x4a, 0x8c, 0xa8, 0x44, 0x4c, 0xa5, 0xde,
+	0x59, 0xb0, 0xa4, 0xf5, 0x6b, 0xb3, 0xb1, 0xfe, 0x99, 0xd4, 0x54, 0x9f, 0x51, 0x6a, 0x4a, 0xc4,
+	0xfc, 0x6a, 0xc6, 0x98, 0x3f, 0x93, 0xd9, 0xcd, 0xac, 0x99, 0xfd, 0xcf, 0x39, 0x38, 0xdf, 0xe5,
+	0xae, 0xe7, 0x69, 0x6f, 0xf7, 0xfb, 0xf3, 0xf7, 0x1e, 0xf1, 0xf5, 0x22, 0x72, 0x91, 0xa9, 0xa2,
+	0x3a, 0x46, 0x21, 0xf7, 0xe3, 0xa2, 0x8e, 0xe2, 0x61, 0x28, 0x43, 0x7b, 0x52, 0xd0, 0x24, 0x90,
+	0x75, 0x22, 0xbb, 0x23, 0x7a, 0xf7, 0xc3, 0xf9, 0x7d, 0x35, 0x38, 0x81, 0xca, 0x8e, 0x1d, 0xce,
+	0x5f, 0xee, 0x5d, 0x38, 0x17, 0x09, 0xcd, 0x50, 0x19, 0xad, 0x8d, 0x69, 0x29, 0x65, 0x97, 0x68,
+	0x72, 0xca, 0x7a, 0x24, 0xab, 0xed, 0x81, 0x97, 0xad, 0x3f, 0xf9, 0xe5, 0x2c, 0x21, 0x13, 0x85,
+	0x46, 0x3d, 0xbd, 0xd0, 0x20, 0xef, 0x8c, 0x1a, 0x37, 0xaa, 0x2e, 0xb9, 0x10, 0x91, 0xef, 0xda,
+	0x1c, 0x03, 0x37, 0x79, 0x5b, 0x96, 0x64, 0x71, 0xbd, 0x63, 0xbe, 0xaa, 0xde, 0xb1, 0x3e, 0x33,
+	0xa0, 0xd0, 0x42, 0x9e, 0xde, 0x4c, 0x9f, 0x61, 0xf1, 0xf2, 0xe6, 0x6e, 0x16, 0xbf, 0x31, 0xe0,
+	0xad, 0xad, 0x03, 0xdb, 0x77, 0x98, 0x1f, 0x77, 0x7e, 0xc3, 0xff, 0x4b, 0x2b, 0xdb, 0xfa, 0x95,
+	0x01, 0xd5, 0x16, 0xf2, 0x8e, 0xdb, 0x3f, 0xe2, 0x6d, 0xdf, 0xe5, 0xae, 0xed, 0x65, 0xf9, 0x74,
+	0x33, 0x53, 0x27, 0xb3, 0x3e, 0xc9, 0xc1, 0xc5, 0xd7, 0xd5, 0xc0, 0x82, 0x73, 0x77, 0x91, 0xff,
+	0x82, 0x05, 0x3f, 0x93, 0x9d, 0x50, 0x1d, 0x7f, 0x63, 0x73, 0x64, 0x07, 0x0a, 0x32, 0x26, 0x54,
+	0x17, 0x71, 0x92, 0x98, 0xd2, 0xfc, 0xe4, 0x5b, 0x90, 0x17, 0x7e, 0x18, 0x05, 0xc1, 0x8b, 0x6e,
+	0xaa, 0x5e, 0xbf, 0x66, 0x5d, 0x4e, 0xae, 0x46, 0x66, 0x54, 0xde, 0x7f, 0x71, 0x5d, 0x7d, 0xa8,
+	0x97, 0x73, 0x7b, 0x01, 0xe3, 0x2c, 0x42, 0x57, 0x76, 0x0a, 0xa1, 0xd4, 0x42, 0x9e, 0xe5, 0xab,
+	0xde, 0x0c, 0x3d, 0xe4, 0xef, 0x06, 0x94, 0x55, 0xf7, 0x3d, 0x3d, 0xfa, 0x62, 0xb7, 0xa8, 0xce,
+	0x22, 0xf7, 0xc4, 0x19, 0xb1, 0x36, 0x55, 0x46, 0xdc, 0xde, 0x7c, 0xfc, 0xac, 0xb1, 0xf0, 0xe4,
+	0x59, 0x63, 0xe1, 0xf3, 0x67, 0x0d, 0xe3, 0x97, 0xa7, 0x0d, 0xe3, 0x4f, 0xa7, 0x0d, 0xe3, 0xd1,
+	0x69, 0xc3, 0x78, 0x7c, 0xda, 0x30, 0xfe, 0x79, 0xda, 0x30, 0xfe, 0x75, 0xda, 0x58, 0xf8, 0xfc,
+	0xb4, 0x61, 0x7c, 0xfc, 0xbc, 0xb1, 0xf0, 0xf8, 0x79, 0x63, 0xe1, 0xc9, 0xf3, 0xc6, 0xc2, 0x41,
+	0x41, 0xfe, 0xef, 0xc3, 0xf5, 0xff, 0x0d, 0x00, 0x16, 0x3e, 0xfa, 0x7c, 0xf5, 0x21, 0x00, 0x00,
 }
 
 func (this *Meta) Equal(that interface{}) bool {
@@ -3382,6 +3390,14 @@ func (this *RequestInfo) Equal(that interface{}) bool {
 	if !bytes.Equal(this.Result, that1.Result) {
 		return false
 	}
+	if len(this.OutgoingRequests) != len(that1.OutgoingRequests) {
+		return false
+	}
+	for i := range this.OutgoingRequests {
+		if !bytes.Equal(this.OutgoingRequests[i], that1.OutgoingRequests[i]) {
+			return false
+		}
+	}
 	return true
 }
 func (this *GotHotConfirmation) Equal(that interface{}) bool {
@@ -4440,13 +4456,14 @@ func (this *RequestInfo) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 9)
+	s := make([]string, 0, 10)
 	s = append(s, "&payload.RequestInfo{")
 	s = append(s, "Polymorph: "+fmt.Sprintf("%#v", this.Polymorph)+",\n")
 	s = append(s, "ObjectID: "+fmt.Sprintf("%#v", this.ObjectID)+",\n")
 	s = append(s, "RequestID: "+fmt.Sprintf("%#v", this.RequestID)+",\n")
 	s = append(s, "Request: "+fmt.Sprintf("%#v", this.Request)+",\n")
 	s = append(s, "Result: "+fmt.Sprintf("%#v", this.Result)+",\n")
+	s = append(s, "OutgoingRequests: "+fmt.Sprintf("%#v", this.OutgoingRequests)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -5807,6 +5824,16 @@ func (m *RequestInfo) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintPayload(dAtA, i, uint64(len(m.Result)))
 		i += copy(dAtA[i:], m.Result)
 	}
+	if len(m.OutgoingRequests) > 0 {
+		for _, b := range m.OutgoingRequests {
+			dAtA[i] = 0xc2
+			i++
+			dAtA[i] = 0x1
+			i++
+			i = encodeVarintPayload(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
 	return i, nil
 }
 
@@ -7452,6 +7479,12 @@ func (m *RequestInfo) Size() (n int) {
 	if l > 0 {
 		n += 2 + l + sovPayload(uint64(l))
 	}
+	if len(m.OutgoingRequests) > 0 {
+		for _, b := range m.OutgoingRequests {
+			l = len(b)
+			n += 2 + l + sovPayload(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -8208,6 +8241,7 @@ func (this *RequestInfo) String() string {
 		`RequestID:` + fmt.Sprintf("%v", this.RequestID) + `,`,
 		`Request:` + fmt.Sprintf("%v", this.Request) + `,`,
 		`Result:` + fmt.Sprintf("%v", this.Result) + `,`,
+		`OutgoingRequests:` + fmt.Sprintf("%v", this.OutgoingRequests) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -11693,6 +11727,38 @@ func (m *RequestInfo) Unmarshal(dAtA []byte) error {
 				m.Result = []byte{}
 			}
 			iNdEx = postIndex
+		case 24:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OutgoingRequests", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPayload
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthPayload
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPayload
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OutgoingRequests = append(m.OutgoingRequests, make([]byte, postIndex-iNdEx))
+			copy(m.OutgoingRequests[len(m.OutgoingRequests)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPayload(dAtA[iNdEx:])
@@ -38,6 +38,7 @@ type BadgerDB struct {
 
 // NewBadgerDB creates new BadgerDB instance.
 // Creates new badger.DB instance with provided working dir and use it as backend for BadgerDB.
+// Values GC isn't run for db opened read-only.
 func NewBadgerDB(ops badger.Options) (*BadgerDB, error) {
 	bdb, err := badger.Open(ops)
 	if err != nil {
@@ -45,7 +46,9 @@ func NewBadgerDB(ops badger.Options) (*BadgerDB, error) {
 	}
 
 	b := &BadgerDB{backend: bdb}
-	b.runGC(context.Background())
+	if !ops.ReadOnly {
+		b.runGC(context.Background())
+	}
 	return b, nil
 }
 
@@ -130,6 +133,9 @@ func (b *BadgerDB) runGC(ctx context.Context) {
 
 // ForceValueGC forces badger values garbage collection.
 func (b *BadgerDB) ForceValueGC(ctx context.Context) {
+	if b.forceGC == nil {
+		return
+	}
 	fin := make(chan struct{})
 	b.forceGC <- fin
 	<-fin
@@ -140,9 +146,11 @@ func (b *BadgerDB) Stop(ctx context.Context) error {
 	logger := inslogger.FromContext(ctx)
 	defer logger.Info("BadgerDB: database closed")
 
-	logger.Info("BadgerDB: wait values GC")
-	close(b.stopGC)
-	<-b.doneGC
+	if b.stopGC != nil {
+		logger.Info("BadgerDB: wait values GC")
+		close(b.stopGC)
+		<-b.doneGC
+	}
 
 	logger.Info("BadgerDB: closing database...")
 
@@ -152,46 +160,39 @@ func (b *BadgerDB) Stop(ctx context.Context) error {
 // Get returns value for specified key or an error. A copy of a value will be returned (i.e. getting large value can be
 // long).
 func (b *BadgerDB) Get(key Key) (value []byte, err error) {
-	fullKey := append(key.Scope().Bytes(), key.ID()...)
-
 	err = b.backend.View(func(txn *badger.Txn) error {
-		item, err := txn.Get(fullKey)
-		if err != nil {
-			return err
-		}
-		value, err = item.ValueCopy(nil)
+		value, err = badgerTxn{txn: txn}.Get(key)
 		return err
 	})
-
-	if err != nil {
-		if err == badger.ErrKeyNotFound {
-			return nil, ErrNotFound
-		}
-		return nil, err
-	}
-
 	return
 }
 
 // Set stores value for a key.
 func (b *BadgerDB) Set(key Key, value []byte) error {
-	fullKey := append(key.Scope().Bytes(), key.ID()...)
-
-	err := b.backend.Update(func(txn *badger.Txn) error {
-		return txn.Set(fullKey, value)
+	return b.backend.Update(func(txn *badger.Txn) error {
+		return badgerTxn{txn: txn}.Set(key, value)
 	})
-
-	return err
 }
 
 // Delete deletes value for a key.
 func (b *BadgerDB) Delete(key Key) error {
-	fullKey := append(key.Scope().Bytes(), key.ID()...)
-	err := b.backend.Update(func(txn *badger.Txn) error {
-		return txn.Delete(fullKey)
+	return b.backend.Update(func(txn *badger.Txn) error {
+		return badgerTxn{txn: txn}.Delete(key)
 	})
+}
+
+// View runs fn within a read-only badger transaction.
+func (b *BadgerDB) View(fn func(txn Txn) error) error {
+	return b.backend.View(func(txn *badger.Txn) error {
+		return fn(badgerTxn{txn: txn})
+	})
+}
 
-	return err
+// Update runs fn within a read-write badger transaction.
+func (b *BadgerDB) Update(fn func(txn Txn) error) error {
+	return b.backend.Update(func(txn *badger.Txn) error {
+		return fn(badgerTxn{txn: txn})
+	})
 }
 
 // Backup creates backup.
@@ -199,24 +200,45 @@ func (b *BadgerDB) Backup(w io.Writer, since uint64) (uint64, error) {
 	return b.backend.Backup(w, since)
 }
 
-// NewReadIterator returns new Iterator over the store.
-func NewReadIterator(db *badger.DB, pivot Key, reverse bool) Iterator {
-	bi := badgerIterator{pivot: pivot, reverse: reverse}
-	bi.txn = db.NewTransaction(false)
-	opts := badger.DefaultIteratorOptions
-	opts.Reverse = reverse
-	bi.it = bi.txn.NewIterator(opts)
-	return &bi
+// Load applies backup created by Backup.
+func (b *BadgerDB) Load(r io.Reader, workers int) error {
+	return b.backend.Load(r, workers)
 }
 
 // NewIterator returns new Iterator over the store.
 func (b *BadgerDB) NewIterator(pivot Key, reverse bool) Iterator {
-	bi := badgerIterator{pivot: pivot, reverse: reverse}
-	bi.txn = b.backend.NewTransaction(false)
-	opts := badger.DefaultIteratorOptions
-	opts.Reverse = reverse
-	bi.it = bi.txn.NewIterator(opts)
-	return &bi
+	return newBadgerIterator(b.backend.NewTransaction(false), true, pivot, reverse)
+}
+
+type badgerTxn struct {
+	txn *badger.Txn
+}
+
+func (t badgerTxn) Get(key Key) ([]byte, error) {
+	fullKey := append(key.Scope().Bytes(), key.ID()...)
+
+	item, err := t.txn.Get(fullKey)
+	if err != nil {
+		if err == badger.ErrKeyNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return item.ValueCopy(nil)
+}
+
+func (t badgerTxn) Set(key Key, value []byte) error {
+	fullKey := append(key.Scope().Bytes(), key.ID()...)
+	return t.txn.Set(fullKey, value)
+}
+
+func (t badgerTxn) Delete(key Key) error {
+	fullKey := append(key.Scope().Bytes(), key.ID()...)
+	return t.txn.Delete(fullKey)
+}
+
+func (t badgerTxn) NewIterator(pivot Key, reverse bool) Iterator {
+	return newBadgerIterator(t.txn, false, pivot, reverse)
 }
 
 type badgerIterator struct {
@@ -224,14 +246,26 @@ type badgerIterator struct {
 	pivot     Key
 	reverse   bool
 	txn       *badger.Txn
+	ownTxn    bool
 	it        *badger.Iterator
 	prevKey   []byte
 	prevValue []byte
 }
 
+// newBadgerIterator creates iterator within txn. Iterator discards txn on close if ownTxn is set.
+func newBadgerIterator(txn *badger.Txn, ownTxn bool, pivot Key, reverse bool) *badgerIterator {
+	bi := badgerIterator{pivot: pivot, reverse: reverse, txn: txn, ownTxn: ownTxn}
+	opts := badger.DefaultIteratorOptions
+	opts.Reverse = reverse
+	bi.it = bi.txn.NewIterator(opts)
+	return &bi
+}
+
 func (bi *badgerIterator) Close() {
 	bi.it.Close()
-	bi.txn.Discard()
+	if bi.ownTxn {
+		bi.txn.Discard()
+	}
 }
 
 func (bi *badgerIterator) Next() bool {
@@ -16,7 +16,10 @@
 
 package store
 
-import "io"
+import (
+	"context"
+	"io"
+)
 
 //go:generate minimock -i github.com/insolar/insolar/insolar/store.DB -o ./ -s _gen_mock.go -g
 
@@ -28,6 +31,20 @@ type DB interface {
 	Set(key Key, value []byte) error
 	Delete(key Key) error
 	NewIterator(pivot Key, reverse bool) Iterator
+
+	// View runs fn within a read-only transaction.
+	View(fn func(txn Txn) error) error
+	// Update runs fn within a read-write transaction. Changes are committed atomically if fn returns nil
+	// and discarded otherwise.
+	Update(fn func(txn Txn) error) error
+}
+
+// Txn provides access to the store within a transaction. It must not be used after the transaction function returns.
+type Txn interface {
+	Get(key Key) (value []byte, err error)
+	Set(key Key, value []byte) error
+	Delete(key Key) error
+	NewIterator(pivot Key, reverse bool) Iterator
 }
 
 // Backuper provides interface for making backups
@@ -36,6 +53,17 @@ type Backuper interface {
 	// It returns a timestamp indicating when the entries were dumped which can be passed into a
 	// later invocation to generate an incremental dump.
 	Backup(to io.Writer, since uint64) (uint64, error)
+	// Load applies backup made by Backup. Backups are backend specific.
+	Load(from io.Reader, workers int) error
+}
+
+// Engine is a storage backend heavy node keeps its data in.
+type Engine interface {
+	DB
+	Backuper
+
+	// Stop gracefully stops all disk writes.
+	Stop(ctx context.Context) error
 }
 
 //go:generate minimock -i github.com/insolar/insolar/insolar/store.Iterator -o ./ -s _gen_mock.go -g
@@ -94,4 +122,6 @@ const (
 	ScopeJetKeeperSyncPulse Scope = 9
 	// ScopeRecordPosition is the scope for records' positions.
 	ScopeRecordPosition Scope = 10
+	// ScopeBlame is the scope for misbehavior reports about network nodes.
+	ScopeBlame Scope = 11
 )
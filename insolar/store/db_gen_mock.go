@@ -37,6 +37,18 @@ type DBMock struct {
 	afterSetCounter  uint64
 	beforeSetCounter uint64
 	SetMock          mDBMockSet
+
+	funcUpdate          func(fn func(txn Txn) error) (err error)
+	inspectFuncUpdate   func(fn func(txn Txn) error)
+	afterUpdateCounter  uint64
+	beforeUpdateCounter uint64
+	UpdateMock          mDBMockUpdate
+
+	funcView          func(fn func(txn Txn) error) (err error)
+	inspectFuncView   func(fn func(txn Txn) error)
+	afterViewCounter  uint64
+	beforeViewCounter uint64
+	ViewMock          mDBMockView
 }
 
 // NewDBMock returns a mock for DB
@@ -58,6 +70,12 @@ func NewDBMock(t minimock.Tester) *DBMock {
 	m.SetMock = mDBMockSet{mock: m}
 	m.SetMock.callArgs = []*DBMockSetParams{}
 
+	m.UpdateMock = mDBMockUpdate{mock: m}
+	m.UpdateMock.callArgs = []*DBMockUpdateParams{}
+
+	m.ViewMock = mDBMockView{mock: m}
+	m.ViewMock.callArgs = []*DBMockViewParams{}
+
 	return m
 }
 
@@ -924,6 +942,436 @@ func (m *DBMock) MinimockSetInspect() {
 	}
 }
 
+type mDBMockUpdate struct {
+	mock               *DBMock
+	defaultExpectation *DBMockUpdateExpectation
+	expectations       []*DBMockUpdateExpectation
+
+	callArgs []*DBMockUpdateParams
+	mutex    sync.RWMutex
+}
+
+// DBMockUpdateExpectation specifies expectation struct of the DB.Update
+type DBMockUpdateExpectation struct {
+	mock    *DBMock
+	params  *DBMockUpdateParams
+	results *DBMockUpdateResults
+	Counter uint64
+}
+
+// DBMockUpdateParams contains parameters of the DB.Update
+type DBMockUpdateParams struct {
+	fn func(txn Txn) error
+}
+
+// DBMockUpdateResults contains results of the DB.Update
+type DBMockUpdateResults struct {
+	err error
+}
+
+// Expect sets up expected params for DB.Update
+func (mmUpdate *mDBMockUpdate) Expect(fn func(txn Txn) error) *mDBMockUpdate {
+	if mmUpdate.mock.funcUpdate != nil {
+		mmUpdate.mock.t.Fatalf("DBMock.Update mock is already set by Set")
+	}
+
+	if mmUpdate.defaultExpectation == nil {
+		mmUpdate.defaultExpectation = &DBMockUpdateExpectation{}
+	}
+
+	mmUpdate.defaultExpectation.params = &DBMockUpdateParams{fn}
+	for _, e := range mmUpdate.expectations {
+		if minimock.Equal(e.params, mmUpdate.defaultExpectation.params) {
+			mmUpdate.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmUpdate.defaultExpectation.params)
+		}
+	}
+
+	return mmUpdate
+}
+
+// Inspect accepts an inspector function that has same arguments as the DB.Update
+func (mmUpdate *mDBMockUpdate) Inspect(f func(fn func(txn Txn) error)) *mDBMockUpdate {
+	if mmUpdate.mock.inspectFuncUpdate != nil {
+		mmUpdate.mock.t.Fatalf("Inspect function is already set for DBMock.Update")
+	}
+
+	mmUpdate.mock.inspectFuncUpdate = f
+
+	return mmUpdate
+}
+
+// Return sets up results that will be returned by DB.Update
+func (mmUpdate *mDBMockUpdate) Return(err error) *DBMock {
+	if mmUpdate.mock.funcUpdate != nil {
+		mmUpdate.mock.t.Fatalf("DBMock.Update mock is already set by Set")
+	}
+
+	if mmUpdate.defaultExpectation == nil {
+		mmUpdate.defaultExpectation = &DBMockUpdateExpectation{mock: mmUpdate.mock}
+	}
+	mmUpdate.defaultExpectation.results = &DBMockUpdateResults{err}
+	return mmUpdate.mock
+}
+
+//Set uses given function f to mock the DB.Update method
+func (mmUpdate *mDBMockUpdate) Set(f func(fn func(txn Txn) error) (err error)) *DBMock {
+	if mmUpdate.defaultExpectation != nil {
+		mmUpdate.mock.t.Fatalf("Default expectation is already set for the DB.Update method")
+	}
+
+	if len(mmUpdate.expectations) > 0 {
+		mmUpdate.mock.t.Fatalf("Some expectations are already set for the DB.Update method")
+	}
+
+	mmUpdate.mock.funcUpdate = f
+	return mmUpdate.mock
+}
+
+// When sets expectation for the DB.Update which will trigger the result defined by the following
+// Then helper
+func (mmUpdate *mDBMockUpdate) When(fn func(txn Txn) error) *DBMockUpdateExpectation {
+	if mmUpdate.mock.funcUpdate != nil {
+		mmUpdate.mock.t.Fatalf("DBMock.Update mock is already set by Set")
+	}
+
+	expectation := &DBMockUpdateExpectation{
+		mock:   mmUpdate.mock,
+		params: &DBMockUpdateParams{fn},
+	}
+	mmUpdate.expectations = append(mmUpdate.expectations, expectation)
+	return expectation
+}
+
+// Then sets up DB.Update return parameters for the expectation previously defined by the When method
+func (e *DBMockUpdateExpectation) Then(err error) *DBMock {
+	e.results = &DBMockUpdateResults{err}
+	return e.mock
+}
+
+// Update implements DB
+func (mmUpdate *DBMock) Update(fn func(txn Txn) error) (err error) {
+	mm_atomic.AddUint64(&mmUpdate.beforeUpdateCounter, 1)
+	defer mm_atomic.AddUint64(&mmUpdate.afterUpdateCounter, 1)
+
+	if mmUpdate.inspectFuncUpdate != nil {
+		mmUpdate.inspectFuncUpdate(fn)
+	}
+
+	params := &DBMockUpdateParams{fn}
+
+	// Record call args
+	mmUpdate.UpdateMock.mutex.Lock()
+	mmUpdate.UpdateMock.callArgs = append(mmUpdate.UpdateMock.callArgs, params)
+	mmUpdate.UpdateMock.mutex.Unlock()
+
+	for _, e := range mmUpdate.UpdateMock.expectations {
+		if minimock.Equal(e.params, params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmUpdate.UpdateMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmUpdate.UpdateMock.defaultExpectation.Counter, 1)
+		want := mmUpdate.UpdateMock.defaultExpectation.params
+		got := DBMockUpdateParams{fn}
+		if want != nil && !minimock.Equal(*want, got) {
+			mmUpdate.t.Errorf("DBMock.Update got unexpected parameters, want: %#v, got: %#v%s\n", *want, got, minimock.Diff(*want, got))
+		}
+
+		results := mmUpdate.UpdateMock.defaultExpectation.results
+		if results == nil {
+			mmUpdate.t.Fatal("No results are set for the DBMock.Update")
+		}
+		return (*results).err
+	}
+	if mmUpdate.funcUpdate != nil {
+		return mmUpdate.funcUpdate(fn)
+	}
+	mmUpdate.t.Fatalf("Unexpected call to DBMock.Update. %v", fn)
+	return
+}
+
+// UpdateAfterCounter returns a count of finished DBMock.Update invocations
+func (mmUpdate *DBMock) UpdateAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmUpdate.afterUpdateCounter)
+}
+
+// UpdateBeforeCounter returns a count of DBMock.Update invocations
+func (mmUpdate *DBMock) UpdateBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmUpdate.beforeUpdateCounter)
+}
+
+// Calls returns a list of arguments used in each call to DBMock.Update.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmUpdate *mDBMockUpdate) Calls() []*DBMockUpdateParams {
+	mmUpdate.mutex.RLock()
+
+	argCopy := make([]*DBMockUpdateParams, len(mmUpdate.callArgs))
+	copy(argCopy, mmUpdate.callArgs)
+
+	mmUpdate.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockUpdateDone returns true if the count of the Update invocations corresponds
+// the number of defined expectations
+func (m *DBMock) MinimockUpdateDone() bool {
+	for _, e := range m.UpdateMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.UpdateMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterUpdateCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcUpdate != nil && mm_atomic.LoadUint64(&m.afterUpdateCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockUpdateInspect logs each unmet expectation
+func (m *DBMock) MinimockUpdateInspect() {
+	for _, e := range m.UpdateMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to DBMock.Update with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.UpdateMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterUpdateCounter) < 1 {
+		if m.UpdateMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to DBMock.Update")
+		} else {
+			m.t.Errorf("Expected call to DBMock.Update with params: %#v", *m.UpdateMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcUpdate != nil && mm_atomic.LoadUint64(&m.afterUpdateCounter) < 1 {
+		m.t.Error("Expected call to DBMock.Update")
+	}
+}
+
+type mDBMockView struct {
+	mock               *DBMock
+	defaultExpectation *DBMockViewExpectation
+	expectations       []*DBMockViewExpectation
+
+	callArgs []*DBMockViewParams
+	mutex    sync.RWMutex
+}
+
+// DBMockViewExpectation specifies expectation struct of the DB.View
+type DBMockViewExpectation struct {
+	mock    *DBMock
+	params  *DBMockViewParams
+	results *DBMockViewResults
+	Counter uint64
+}
+
+// DBMockViewParams contains parameters of the DB.View
+type DBMockViewParams struct {
+	fn func(txn Txn) error
+}
+
+// DBMockViewResults contains results of the DB.View
+type DBMockViewResults struct {
+	err error
+}
+
+// Expect sets up expected params for DB.View
+func (mmView *mDBMockView) Expect(fn func(txn Txn) error) *mDBMockView {
+	if mmView.mock.funcView != nil {
+		mmView.mock.t.Fatalf("DBMock.View mock is already set by Set")
+	}
+
+	if mmView.defaultExpectation == nil {
+		mmView.defaultExpectation = &DBMockViewExpectation{}
+	}
+
+	mmView.defaultExpectation.params = &DBMockViewParams{fn}
+	for _, e := range mmView.expectations {
+		if minimock.Equal(e.params, mmView.defaultExpectation.params) {
+			mmView.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmView.defaultExpectation.params)
+		}
+	}
+
+	return mmView
+}
+
+// Inspect accepts an inspector function that has same arguments as the DB.View
+func (mmView *mDBMockView) Inspect(f func(fn func(txn Txn) error)) *mDBMockView {
+	if mmView.mock.inspectFuncView != nil {
+		mmView.mock.t.Fatalf("Inspect function is already set for DBMock.View")
+	}
+
+	mmView.mock.inspectFuncView = f
+
+	return mmView
+}
+
+// Return sets up results that will be returned by DB.View
+func (mmView *mDBMockView) Return(err error) *DBMock {
+	if mmView.mock.funcView != nil {
+		mmView.mock.t.Fatalf("DBMock.View mock is already set by Set")
+	}
+
+	if mmView.defaultExpectation == nil {
+		mmView.defaultExpectation = &DBMockViewExpectation{mock: mmView.mock}
+	}
+	mmView.defaultExpectation.results = &DBMockViewResults{err}
+	return mmView.mock
+}
+
+//Set uses given function f to mock the DB.View method
+func (mmView *mDBMockView) Set(f func(fn func(txn Txn) error) (err error)) *DBMock {
+	if mmView.defaultExpectation != nil {
+		mmView.mock.t.Fatalf("Default expectation is already set for the DB.View method")
+	}
+
+	if len(mmView.expectations) > 0 {
+		mmView.mock.t.Fatalf("Some expectations are already set for the DB.View method")
+	}
+
+	mmView.mock.funcView = f
+	return mmView.mock
+}
+
+// When sets expectation for the DB.View which will trigger the result defined by the following
+// Then helper
+func (mmView *mDBMockView) When(fn func(txn Txn) error) *DBMockViewExpectation {
+	if mmView.mock.funcView != nil {
+		mmView.mock.t.Fatalf("DBMock.View mock is already set by Set")
+	}
+
+	expectation := &DBMockViewExpectation{
+		mock:   mmView.mock,
+		params: &DBMockViewParams{fn},
+	}
+	mmView.expectations = append(mmView.expectations, expectation)
+	return expectation
+}
+
+// Then sets up DB.View return parameters for the expectation previously defined by the When method
+func (e *DBMockViewExpectation) Then(err error) *DBMock {
+	e.results = &DBMockViewResults{err}
+	return e.mock
+}
+
+// View implements DB
+func (mmView *DBMock) View(fn func(txn Txn) error) (err error) {
+	mm_atomic.AddUint64(&mmView.beforeViewCounter, 1)
+	defer mm_atomic.AddUint64(&mmView.afterViewCounter, 1)
+
+	if mmView.inspectFuncView != nil {
+		mmView.inspectFuncView(fn)
+	}
+
+	params := &DBMockViewParams{fn}
+
+	// Record call args
+	mmView.ViewMock.mutex.Lock()
+	mmView.ViewMock.callArgs = append(mmView.ViewMock.callArgs, params)
+	mmView.ViewMock.mutex.Unlock()
+
+	for _, e := range mmView.ViewMock.expectations {
+		if minimock.Equal(e.params, params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmView.ViewMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmView.ViewMock.defaultExpectation.Counter, 1)
+		want := mmView.ViewMock.defaultExpectation.params
+		got := DBMockViewParams{fn}
+		if want != nil && !minimock.Equal(*want, got) {
+			mmView.t.Errorf("DBMock.View got unexpected parameters, want: %#v, got: %#v%s\n", *want, got, minimock.Diff(*want, got))
+		}
+
+		results := mmView.ViewMock.defaultExpectation.results
+		if results == nil {
+			mmView.t.Fatal("No results are set for the DBMock.View")
+		}
+		return (*results).err
+	}
+	if mmView.funcView != nil {
+		return mmView.funcView(fn)
+	}
+	mmView.t.Fatalf("Unexpected call to DBMock.View. %v", fn)
+	return
+}
+
+// ViewAfterCounter returns a count of finished DBMock.View invocations
+func (mmView *DBMock) ViewAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmView.afterViewCounter)
+}
+
+// ViewBeforeCounter returns a count of DBMock.View invocations
+func (mmView *DBMock) ViewBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmView.beforeViewCounter)
+}
+
+// Calls returns a list of arguments used in each call to DBMock.View.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmView *mDBMockView) Calls() []*DBMockViewParams {
+	mmView.mutex.RLock()
+
+	argCopy := make([]*DBMockViewParams, len(mmView.callArgs))
+	copy(argCopy, mmView.callArgs)
+
+	mmView.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockViewDone returns true if the count of the View invocations corresponds
+// the number of defined expectations
+func (m *DBMock) MinimockViewDone() bool {
+	for _, e := range m.ViewMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ViewMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterViewCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcView != nil && mm_atomic.LoadUint64(&m.afterViewCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockViewInspect logs each unmet expectation
+func (m *DBMock) MinimockViewInspect() {
+	for _, e := range m.ViewMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to DBMock.View with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ViewMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterViewCounter) < 1 {
+		if m.ViewMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to DBMock.View")
+		} else {
+			m.t.Errorf("Expected call to DBMock.View with params: %#v", *m.ViewMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcView != nil && mm_atomic.LoadUint64(&m.afterViewCounter) < 1 {
+		m.t.Error("Expected call to DBMock.View")
+	}
+}
+
 // MinimockFinish checks that all mocked methods have been called the expected number of times
 func (m *DBMock) MinimockFinish() {
 	if !m.minimockDone() {
@@ -934,6 +1382,10 @@ func (m *DBMock) MinimockFinish() {
 		m.MinimockNewIteratorInspect()
 
 		m.MinimockSetInspect()
+
+		m.MinimockUpdateInspect()
+
+		m.MinimockViewInspect()
 		m.t.FailNow()
 	}
 }
@@ -960,5 +1412,7 @@ func (m *DBMock) minimockDone() bool {
 		m.MinimockDeleteDone() &&
 		m.MinimockGetDone() &&
 		m.MinimockNewIteratorDone() &&
-		m.MinimockSetDone()
+		m.MinimockSetDone() &&
+		m.MinimockUpdateDone() &&
+		m.MinimockViewDone()
 }
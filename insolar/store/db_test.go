@@ -0,0 +1,597 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package store
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"io/ioutil"
+	rand2 "math/rand"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/dgraph-io/badger"
+	fuzz "github.com/google/gofuzz"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+func BadgerDefaultOptions(dir string) badger.Options {
+	ops := badger.DefaultOptions(dir)
+	ops.CompactL0OnClose = false
+	ops.SyncWrites = false
+
+	return ops
+}
+
+var testBackends = []struct {
+	name string
+	open func(dir string) (Engine, error)
+}{
+	{
+		name: "badger",
+		open: func(dir string) (Engine, error) {
+			return NewBadgerDB(BadgerDefaultOptions(dir))
+		},
+	},
+	{
+		name: "skiplist",
+		open: func(dir string) (Engine, error) {
+			return NewSkipListDB(SkipListOptions{Dir: dir})
+		},
+	},
+}
+
+// forEachBackend runs test on a new empty db of every backend.
+func forEachBackend(t *testing.T, test func(t *testing.T, db Engine)) {
+	for _, b := range testBackends {
+		b := b
+		t.Run(b.name, func(t *testing.T) {
+			ctx := inslogger.TestContext(t)
+
+			tmpdir, err := ioutil.TempDir("", "bdb-test-")
+			defer os.RemoveAll(tmpdir)
+			require.NoError(t, err)
+
+			db, err := b.open(tmpdir)
+			require.NoError(t, err)
+			defer db.Stop(ctx)
+
+			test(t, db)
+		})
+	}
+}
+
+func setRaw(db DB, key Key, value []byte) error {
+	return db.Update(func(txn Txn) error {
+		return txn.Set(key, value)
+	})
+}
+
+type testBadgerKey struct {
+	id    []byte
+	scope Scope
+}
+
+func (k testBadgerKey) Scope() Scope {
+	return k.scope
+}
+
+func (k testBadgerKey) ID() []byte {
+	return k.id
+}
+
+func TestDB_Get(t *testing.T) {
+	t.Parallel()
+
+	forEachBackend(t, func(t *testing.T, db Engine) {
+		var (
+			key           testBadgerKey
+			expectedValue []byte
+		)
+		f := fuzz.New().NilChance(0)
+		f.Fuzz(&key)
+		f.Fuzz(&expectedValue)
+		err := setRaw(db, key, expectedValue)
+		require.NoError(t, err)
+		value, err := db.Get(key)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedValue, value)
+	})
+}
+
+func TestDB_Set(t *testing.T) {
+	t.Parallel()
+
+	forEachBackend(t, func(t *testing.T, db Engine) {
+		var (
+			key           testBadgerKey
+			expectedValue []byte
+			value         []byte
+		)
+		f := fuzz.New().NilChance(0)
+		f.Fuzz(&key)
+		f.Fuzz(&expectedValue)
+		err := db.Set(key, expectedValue)
+		assert.NoError(t, err)
+
+		err = db.View(func(txn Txn) error {
+			value, err = txn.Get(key)
+			require.NoError(t, err)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, expectedValue, value)
+	})
+}
+
+func TestDB_Delete(t *testing.T) {
+	t.Parallel()
+
+	forEachBackend(t, func(t *testing.T, db Engine) {
+		var (
+			key           testBadgerKey
+			expectedValue []byte
+			value         []byte
+		)
+		f := fuzz.New().NilChance(0)
+		f.Fuzz(&key)
+		f.Fuzz(&expectedValue)
+		err := db.Set(key, expectedValue)
+		assert.NoError(t, err)
+
+		value, err = db.Get(key)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedValue, value)
+
+		err = db.Delete(key)
+		assert.NoError(t, err)
+
+		_, err = db.Get(key)
+		require.Equal(t, ErrNotFound, err)
+	})
+}
+
+func TestDB_Update(t *testing.T) {
+	t.Parallel()
+
+	forEachBackend(t, func(t *testing.T, db Engine) {
+		first := testBadgerKey{id: []byte{1}, scope: ScopeRecord}
+		second := testBadgerKey{id: []byte{2}, scope: ScopeRecord}
+		err := db.Set(first, []byte{1})
+		require.NoError(t, err)
+
+		t.Run("commit", func(t *testing.T) {
+			err := db.Update(func(txn Txn) error {
+				value, err := txn.Get(first)
+				require.NoError(t, err)
+				require.NoError(t, txn.Set(second, value))
+				require.NoError(t, txn.Set(first, []byte{2}))
+
+				value, err = txn.Get(first)
+				require.NoError(t, err)
+				require.Equal(t, []byte{2}, value)
+				return nil
+			})
+			require.NoError(t, err)
+
+			value, err := db.Get(first)
+			require.NoError(t, err)
+			require.Equal(t, []byte{2}, value)
+			value, err = db.Get(second)
+			require.NoError(t, err)
+			require.Equal(t, []byte{1}, value)
+		})
+
+		t.Run("discard on error", func(t *testing.T) {
+			third := testBadgerKey{id: []byte{3}, scope: ScopeRecord}
+			expectedErr := errors.New("test")
+			err := db.Update(func(txn Txn) error {
+				require.NoError(t, txn.Set(first, []byte{3}))
+				require.NoError(t, txn.Delete(second))
+				require.NoError(t, txn.Set(third, []byte{3}))
+				return expectedErr
+			})
+			require.Equal(t, expectedErr, err)
+
+			value, err := db.Get(first)
+			require.NoError(t, err)
+			require.Equal(t, []byte{2}, value)
+			_, err = db.Get(second)
+			require.NoError(t, err)
+			_, err = db.Get(third)
+			require.Equal(t, ErrNotFound, err)
+		})
+
+		t.Run("iterator", func(t *testing.T) {
+			var keys [][]byte
+			err := db.View(func(txn Txn) error {
+				it := txn.NewIterator(first, false)
+				defer it.Close()
+				for it.Next() {
+					keys = append(keys, it.Key())
+				}
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, [][]byte{{1}, {2}}, keys)
+		})
+	})
+}
+
+func TestDB_NewIterator(t *testing.T) {
+	t.Parallel()
+
+	forEachBackend(t, func(t *testing.T, db Engine) {
+		type kv struct {
+			k testBadgerKey
+			v []byte
+		}
+
+		var (
+			commonScope  Scope
+			commonPrefix []byte
+
+			expected   []kv
+			unexpected []kv
+		)
+
+		const (
+			ArrayLength = 100
+		)
+
+		fuzz.New().NilChance(0).Fuzz(&commonScope)
+		fuzz.New().NilChance(0).NumElements(ArrayLength, ArrayLength).Fuzz(&commonPrefix)
+
+		f := fuzz.New().NilChance(0).NumElements(ArrayLength, ArrayLength).Funcs(
+			func(key *testBadgerKey, c fuzz.Continue) {
+				var id []byte
+				c.Fuzz(&id)
+				key.id = append(commonPrefix, id...)
+				key.id[0] = commonPrefix[0] + 1
+				key.scope = commonScope
+			},
+			func(pair *kv, c fuzz.Continue) {
+				c.Fuzz(&pair.k)
+				c.Fuzz(&pair.v)
+			},
+		)
+		f.Fuzz(&unexpected)
+
+		f = fuzz.New().NilChance(0).NumElements(ArrayLength, ArrayLength).Funcs(
+			func(key *testBadgerKey, c fuzz.Continue) {
+				var id []byte
+				c.Fuzz(&id)
+				key.id = append(commonPrefix, id...)
+				key.scope = commonScope
+			},
+			func(pair *kv, c fuzz.Continue) {
+				c.Fuzz(&pair.k)
+				c.Fuzz(&pair.v)
+			},
+		)
+		f.Fuzz(&expected)
+
+		sort.Slice(expected, func(i, j int) bool {
+			return bytes.Compare(expected[i].k.ID(), expected[j].k.ID()) == -1
+		})
+
+		err := db.Update(func(txn Txn) error {
+			for i := 0; i < ArrayLength; i++ {
+				err := txn.Set(unexpected[i].k, unexpected[i].v)
+				if err != nil {
+					return err
+				}
+			}
+			for i := 0; i < ArrayLength; i++ {
+				err := txn.Set(expected[i].k, expected[i].v)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		require.NoError(t, err)
+
+		// test logic
+		pivot := testBadgerKey{id: commonPrefix, scope: commonScope}
+		it := db.NewIterator(pivot, false)
+		defer it.Close()
+		i := 0
+		for it.Next() && i < len(expected) {
+			require.Equal(t, expected[i].k.ID(), it.Key())
+			val, err := it.Value()
+			require.NoError(t, err)
+			require.Equal(t, expected[i].v, val)
+			i++
+		}
+		require.Equal(t, len(expected), i)
+	})
+}
+
+func TestDB_NewReverseIterator(t *testing.T) {
+	t.Parallel()
+
+	forEachBackend(t, func(t *testing.T, db Engine) {
+		type kv struct {
+			k testBadgerKey
+			v []byte
+		}
+
+		var (
+			commonScope  Scope
+			commonPrefix []byte
+
+			expected   []kv
+			unexpected []kv
+		)
+
+		const (
+			ArrayLength  = 100
+			ReverseOrder = true
+		)
+
+		fuzz.New().NilChance(0).Fuzz(&commonScope)
+		fuzz.New().NilChance(0).NumElements(ArrayLength, ArrayLength).Fuzz(&commonPrefix)
+
+		f := fuzz.New().NilChance(0).NumElements(ArrayLength, ArrayLength).Funcs(
+			func(key *testBadgerKey, c fuzz.Continue) {
+				var id []byte
+				c.Fuzz(&id)
+				key.id = append(commonPrefix, id...)
+				key.id[0] = commonPrefix[0] + 1
+				key.scope = commonScope
+			},
+			func(pair *kv, c fuzz.Continue) {
+				c.Fuzz(&pair.k)
+				c.Fuzz(&pair.v)
+			},
+		)
+		f.Fuzz(&unexpected)
+
+		f = fuzz.New().NilChance(0).NumElements(ArrayLength, ArrayLength).Funcs(
+			func(key *testBadgerKey, c fuzz.Continue) {
+				var id []byte
+				c.Fuzz(&id)
+				key.id = append(commonPrefix, id...)
+				key.scope = commonScope
+			},
+			func(pair *kv, c fuzz.Continue) {
+				c.Fuzz(&pair.k)
+				c.Fuzz(&pair.v)
+			},
+		)
+		f.Fuzz(&expected)
+
+		sort.Slice(expected, func(i, j int) bool {
+			return bytes.Compare(expected[i].k.ID(), expected[j].k.ID()) == -1
+		})
+
+		err := db.Update(func(txn Txn) error {
+			for i := 0; i < ArrayLength; i++ {
+				err := txn.Set(unexpected[i].k, unexpected[i].v)
+				if err != nil {
+					return err
+				}
+			}
+			for i := 0; i < ArrayLength; i++ {
+				err := txn.Set(expected[i].k, expected[i].v)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		require.NoError(t, err)
+
+		// test logic
+		prefix := fillPrefix(commonPrefix, ArrayLength*2)
+		pivot := testBadgerKey{id: prefix, scope: commonScope}
+		it := db.NewIterator(pivot, ReverseOrder)
+		defer it.Close()
+		i := 0
+		for it.Next() && i < len(expected) {
+			require.Equal(t, expected[len(expected)-i-1].k.ID(), it.Key())
+			val, err := it.Value()
+			require.NoError(t, err)
+			require.Equal(t, expected[len(expected)-i-1].v, val)
+			i++
+		}
+		require.Equal(t, len(expected), i)
+	})
+}
+
+func TestDB_SimpleReverse(t *testing.T) {
+	t.Parallel()
+
+	forEachBackend(t, func(t *testing.T, db Engine) {
+		count := 100
+		length := 10
+		prefixes := make([][]byte, count)
+		keys := make([][]byte, count)
+		for i := 0; i < count; i++ {
+			prefixes[i] = make([]byte, length)
+			keys[i] = make([]byte, length)
+			_, err := rand.Read(prefixes[i])
+			require.NoError(t, err)
+			_, err = rand.Read(keys[i])
+			require.NoError(t, err)
+			keys[i][0] = 0xFF
+			keys[i] = append(prefixes[i], keys[i]...)
+			err = db.Set(testBadgerKey{keys[i], ScopeRecord}, nil)
+			require.NoError(t, err)
+		}
+
+		t.Run("ASC iteration", func(t *testing.T) {
+			asc := make([][]byte, count)
+			copy(asc, keys)
+			sort.Slice(keys, func(i, j int) bool {
+				return bytes.Compare(keys[i], keys[j]) == -1
+			})
+			sort.Slice(prefixes, func(i, j int) bool {
+				return bytes.Compare(prefixes[i], prefixes[j]) == -1
+			})
+
+			seek := rand2.Intn(count)
+			pivot := testBadgerKey{id: prefixes[seek], scope: ScopeRecord}
+			it := db.NewIterator(pivot, false)
+			defer it.Close()
+			var actual [][]byte
+			for it.Next() {
+				actual = append(actual, it.Key())
+			}
+			require.Equal(t, count-seek, len(actual))
+			require.Equal(t, keys[seek:], actual)
+		})
+
+		t.Run("DESC iteration", func(t *testing.T) {
+			desc := make([][]byte, count)
+			copy(desc, keys)
+			sort.Slice(keys, func(i, j int) bool {
+				return bytes.Compare(keys[i], keys[j]) >= 0
+			})
+			sort.Slice(prefixes, func(i, j int) bool {
+				return bytes.Compare(prefixes[i], prefixes[j]) >= 0
+			})
+
+			seek := rand2.Intn(count)
+			prefix := fillPrefix(prefixes[seek], length*2)
+			pivot := testBadgerKey{id: prefix, scope: ScopeRecord}
+			it := db.NewIterator(pivot, true)
+			defer it.Close()
+			var actual [][]byte
+			for it.Next() {
+				actual = append(actual, it.Key())
+			}
+			require.Equal(t, count-seek, len(actual))
+			require.Equal(t, keys[seek:], actual)
+		})
+	})
+}
+
+func TestDB_BackupLoad(t *testing.T) {
+	t.Parallel()
+
+	for _, b := range testBackends {
+		b := b
+		t.Run(b.name, func(t *testing.T) {
+			ctx := inslogger.TestContext(t)
+
+			srcDir, err := ioutil.TempDir("", "bdb-test-")
+			defer os.RemoveAll(srcDir)
+			require.NoError(t, err)
+			dstDir, err := ioutil.TempDir("", "bdb-test-")
+			defer os.RemoveAll(dstDir)
+			require.NoError(t, err)
+
+			src, err := b.open(srcDir)
+			require.NoError(t, err)
+			defer src.Stop(ctx)
+			dst, err := b.open(dstDir)
+			require.NoError(t, err)
+			defer dst.Stop(ctx)
+
+			first := testBadgerKey{id: []byte{1}, scope: ScopeRecord}
+			second := testBadgerKey{id: []byte{2}, scope: ScopeRecord}
+
+			require.NoError(t, src.Set(first, []byte{1}))
+			full := &bytes.Buffer{}
+			since, err := src.Backup(full, 0)
+			require.NoError(t, err)
+
+			require.NoError(t, src.Set(second, []byte{2}))
+			incr := &bytes.Buffer{}
+			_, err = src.Backup(incr, since)
+			require.NoError(t, err)
+
+			require.NoError(t, dst.Load(full, 1))
+			_, err = dst.Get(second)
+			require.Equal(t, ErrNotFound, err)
+
+			require.NoError(t, dst.Load(incr, 1))
+			value, err := dst.Get(first)
+			require.NoError(t, err)
+			require.Equal(t, []byte{1}, value)
+			value, err = dst.Get(second)
+			require.NoError(t, err)
+			require.Equal(t, []byte{2}, value)
+		})
+	}
+}
+
+func BenchmarkDB(b *testing.B) {
+	ctx := context.Background()
+
+	for _, backend := range testBackends {
+		tmpdir, err := ioutil.TempDir("", "bdb-bench-")
+		require.NoError(b, err)
+		db, err := backend.open(tmpdir)
+		require.NoError(b, err)
+
+		value := make([]byte, 256)
+		key := func(i int) testBadgerKey {
+			id := make([]byte, 8)
+			binary.BigEndian.PutUint64(id, uint64(i))
+			return testBadgerKey{id: id, scope: ScopeRecord}
+		}
+
+		b.Run(backend.name+"/set", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				err := db.Set(key(i), value)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(backend.name+"/get", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, err := db.Get(key(i))
+				if err != nil && err != ErrNotFound {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(backend.name+"/iterate", func(b *testing.B) {
+			it := db.NewIterator(key(0), false)
+			for i := 0; i < b.N; i++ {
+				if !it.Next() {
+					it.Close()
+					it = db.NewIterator(key(0), false)
+				}
+			}
+			it.Close()
+		})
+
+		db.Stop(ctx)
+		os.RemoveAll(tmpdir)
+	}
+}
+
+func fillPrefix(prefix []byte, keyLen int) []byte {
+	rest := keyLen - len(prefix)
+	filler := make([]byte, rest)
+	for i := range filler {
+		filler[i] = 0xFF
+	}
+	return bytes.Join([][]byte{prefix, filler}, nil)
+}
@@ -0,0 +1,65 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package store
+
+import (
+	"path/filepath"
+
+	"github.com/dgraph-io/badger"
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/configuration"
+)
+
+// NewEngine opens storage backend selected in config in its data directory.
+func NewEngine(cfg configuration.Storage) (Engine, error) {
+	return newEngine(cfg, false)
+}
+
+// NewReadOnlyEngine opens existing db of storage backend selected in config without writing to it.
+// Badger is opened read-only without values GC, skiplist log is replayed without truncation and compaction.
+func NewReadOnlyEngine(cfg configuration.Storage) (Engine, error) {
+	return newEngine(cfg, true)
+}
+
+func newEngine(cfg configuration.Storage, readOnly bool) (Engine, error) {
+	dir, err := filepath.Abs(cfg.DataDirectory)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get absolute path for DataDirectory")
+	}
+
+	switch cfg.Backend {
+	case configuration.StorageBackendBadger, "":
+		ops := badger.DefaultOptions(dir)
+		ops.ReadOnly = readOnly
+		db, err := NewBadgerDB(ops)
+		if err != nil {
+			return nil, err
+		}
+		return db, nil
+	case configuration.StorageBackendSkipList:
+		ops := DefaultSkipListOptions(dir)
+		ops.ReadOnly = readOnly
+		db, err := NewSkipListDB(ops)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to open skiplist db")
+		}
+		return db, nil
+	default:
+		return nil, errors.Errorf("unknown storage backend %q", cfg.Backend)
+	}
+}
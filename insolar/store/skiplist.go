@@ -0,0 +1,216 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package store
+
+import (
+	"bytes"
+	"math/rand"
+	"time"
+)
+
+const skipListMaxLevel = 24
+
+type skipNode struct {
+	key     []byte
+	value   []byte
+	version uint64
+	// deleted marks a tombstone. Tombstones keep version of deletion for incremental backups.
+	deleted bool
+	next    []*skipNode
+}
+
+// skipEntry is a state of a key saved for transaction rollback.
+type skipEntry struct {
+	key     []byte
+	value   []byte
+	version uint64
+	deleted bool
+	existed bool
+}
+
+// skipList is an ordered in-memory map of byte keys. It's not safe for concurrent use.
+// Node keys are never modified, so they can be kept as iteration positions.
+// Deleted keys are kept as tombstones, they are skipped by get and seek.
+type skipList struct {
+	head  *skipNode
+	level int
+	// len is a number of live keys.
+	len int
+	// tombstones is a number of deleted keys.
+	tombstones int
+	rnd        *rand.Rand
+}
+
+func newSkipList() *skipList {
+	return &skipList{
+		head:  &skipNode{next: make([]*skipNode, skipListMaxLevel)},
+		level: 1,
+		rnd:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (l *skipList) randomLevel() int {
+	lvl := 1
+	for lvl < skipListMaxLevel && l.rnd.Intn(4) == 0 {
+		lvl++
+	}
+	return lvl
+}
+
+// findGE returns the first node with key greater or equal to provided key.
+// If prev is not nil it's filled with the last nodes before key on every level.
+func (l *skipList) findGE(key []byte, prev []*skipNode) *skipNode {
+	x := l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for x.next[i] != nil && bytes.Compare(x.next[i].key, key) < 0 {
+			x = x.next[i]
+		}
+		if prev != nil {
+			prev[i] = x
+		}
+	}
+	return x.next[0]
+}
+
+// get returns live node of the key.
+func (l *skipList) get(key []byte) *skipNode {
+	n := l.findGE(key, nil)
+	if n == nil || n.deleted || !bytes.Equal(n.key, key) {
+		return nil
+	}
+	return n
+}
+
+// set inserts or replaces value for key and returns previous state of the key.
+func (l *skipList) set(key, value []byte, version uint64) skipEntry {
+	return l.put(key, value, version, false)
+}
+
+// delete replaces key with a tombstone and returns its previous state. Absent keys are left untouched.
+func (l *skipList) delete(key []byte, version uint64) skipEntry {
+	n := l.findGE(key, nil)
+	if n == nil || !bytes.Equal(n.key, key) {
+		return skipEntry{key: key}
+	}
+	old := skipEntry{key: n.key, value: n.value, version: n.version, deleted: n.deleted, existed: true}
+	if !n.deleted {
+		l.len--
+		l.tombstones++
+	}
+	n.value, n.version, n.deleted = nil, version, true
+	return old
+}
+
+func (l *skipList) put(key, value []byte, version uint64, deleted bool) skipEntry {
+	var prev [skipListMaxLevel]*skipNode
+	n := l.findGE(key, prev[:])
+	if n != nil && bytes.Equal(n.key, key) {
+		old := skipEntry{key: n.key, value: n.value, version: n.version, deleted: n.deleted, existed: true}
+		l.count(n.deleted, -1)
+		l.count(deleted, 1)
+		n.value, n.version, n.deleted = value, version, deleted
+		return old
+	}
+
+	lvl := l.randomLevel()
+	for i := l.level; i < lvl; i++ {
+		prev[i] = l.head
+	}
+	if lvl > l.level {
+		l.level = lvl
+	}
+
+	n = &skipNode{key: key, value: value, version: version, deleted: deleted, next: make([]*skipNode, lvl)}
+	for i := 0; i < lvl; i++ {
+		n.next[i] = prev[i].next[i]
+		prev[i].next[i] = n
+	}
+	l.count(deleted, 1)
+	return skipEntry{key: key}
+}
+
+func (l *skipList) count(deleted bool, delta int) {
+	if deleted {
+		l.tombstones += delta
+		return
+	}
+	l.len += delta
+}
+
+// remove unlinks node of the key, including tombstone.
+func (l *skipList) remove(key []byte) {
+	var prev [skipListMaxLevel]*skipNode
+	n := l.findGE(key, prev[:])
+	if n == nil || !bytes.Equal(n.key, key) {
+		return
+	}
+
+	for i := range n.next {
+		prev[i].next[i] = n.next[i]
+	}
+	for l.level > 1 && l.head.next[l.level-1] == nil {
+		l.level--
+	}
+	l.count(n.deleted, -1)
+}
+
+// restore brings key back to the saved state.
+func (l *skipList) restore(e skipEntry) {
+	if e.existed {
+		l.put(e.key, e.value, e.version, e.deleted)
+		return
+	}
+	l.remove(e.key)
+}
+
+// seek returns the first live node after key in iteration order. Node with equal key is returned if inclusive is set.
+func (l *skipList) seek(key []byte, reverse, inclusive bool) *skipNode {
+	n := l.seekAny(key, reverse, inclusive)
+	for n != nil && n.deleted {
+		if reverse {
+			n = l.seekAny(n.key, true, false)
+		} else {
+			n = n.next[0]
+		}
+	}
+	return n
+}
+
+func (l *skipList) seekAny(key []byte, reverse, inclusive bool) *skipNode {
+	if !reverse {
+		n := l.findGE(key, nil)
+		if !inclusive && n != nil && bytes.Equal(n.key, key) {
+			n = n.next[0]
+		}
+		return n
+	}
+
+	var prev [skipListMaxLevel]*skipNode
+	n := l.findGE(key, prev[:])
+	if inclusive && n != nil && bytes.Equal(n.key, key) {
+		return n
+	}
+	if prev[0] == l.head {
+		return nil
+	}
+	return prev[0]
+}
+
+// first returns the first node, including tombstone.
+func (l *skipList) first() *skipNode {
+	return l.head.next[0]
+}
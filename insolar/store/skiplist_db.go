@@ -0,0 +1,645 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package store
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+const (
+	skipListLogFile = "skiplist.log"
+
+	logOpSet    byte = 1
+	logOpDelete byte = 2
+
+	// logHeaderSize is a size of payload length and checksum preceding every batch.
+	logHeaderSize = 8
+)
+
+var (
+	// ErrReadOnlyTxn is returned on writes within read-only transaction.
+	ErrReadOnlyTxn = errors.New("transaction is read-only")
+	// ErrReadOnlyDB is returned on writes to db opened read-only.
+	ErrReadOnlyDB = errors.New("db is opened read-only")
+
+	errCorruptedLog = errors.New("corrupted log record")
+)
+
+// SkipListOptions holds options of SkipListDB.
+type SkipListOptions struct {
+	// Dir is a directory for the log file.
+	Dir string
+	// SyncWrites syncs log file after every committed transaction.
+	SyncWrites bool
+	// CompactionLogSize is a log size in bytes after which log is compacted on commit
+	// if it mostly contains overwritten data. Zero disables compaction on commit.
+	CompactionLogSize int64
+	// ReadOnly opens existing log for reading only, it's neither truncated nor compacted.
+	ReadOnly bool
+}
+
+// DefaultSkipListOptions returns default options for SkipListDB in dir.
+func DefaultSkipListOptions(dir string) SkipListOptions {
+	return SkipListOptions{
+		Dir:               dir,
+		SyncWrites:        true,
+		CompactionLogSize: 64 << 20,
+	}
+}
+
+// SkipListDB is a pure Go DB implementation. All data is kept in memory in an ordered skip list,
+// so the dataset is limited by available memory. Every committed transaction is appended to a log file,
+// which is replayed on start. Log is compacted when it grows over CompactionLogSize and on stop.
+//
+// Deleted keys are kept as tombstones with version of deletion, so incremental backups contain deletes.
+// Tombstones are never purged.
+//
+// Transactions are serialized. Iterators don't hold a snapshot, they see changes made after their creation.
+type SkipListDB struct {
+	lock    sync.RWMutex
+	data    *skipList
+	version uint64
+
+	options SkipListOptions
+	log     *os.File
+	logSize int64
+	// logOps is a number of operations in log, used for compaction.
+	logOps int
+	closed bool
+}
+
+// NewSkipListDB creates new SkipListDB instance and loads data from its log.
+// Incomplete record at the end of the log is truncated, so the transaction written on crash is lost.
+// Damaged record followed by other records isn't truncated, opening such log fails.
+func NewSkipListDB(ops SkipListOptions) (*SkipListDB, error) {
+	if ops.ReadOnly {
+		return openReadOnlySkipListDB(ops)
+	}
+
+	err := os.MkdirAll(ops.Dir, 0700)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create %s", ops.Dir)
+	}
+
+	f, err := os.OpenFile(filepath.Join(ops.Dir, skipListLogFile), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open log")
+	}
+
+	db := &SkipListDB{data: newSkipList(), options: ops, log: f}
+	valid, err := db.replay(f)
+	if err != nil {
+		f.Close()
+		return nil, errors.Wrap(err, "failed to replay log")
+	}
+	err = f.Truncate(valid)
+	if err != nil {
+		f.Close()
+		return nil, errors.Wrap(err, "failed to truncate log")
+	}
+	_, err = f.Seek(valid, io.SeekStart)
+	if err != nil {
+		f.Close()
+		return nil, errors.Wrap(err, "failed to seek log")
+	}
+	db.logSize = valid
+
+	return db, nil
+}
+
+func openReadOnlySkipListDB(ops SkipListOptions) (*SkipListDB, error) {
+	f, err := os.Open(filepath.Join(ops.Dir, skipListLogFile))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open log")
+	}
+
+	db := &SkipListDB{data: newSkipList(), options: ops, log: f}
+	valid, err := db.replay(f)
+	if err != nil {
+		f.Close()
+		return nil, errors.Wrap(err, "failed to replay log")
+	}
+	db.logSize = valid
+
+	return db, nil
+}
+
+// replay applies log records and returns size of the valid part of the log.
+// Only damaged record reaching the end of the log is dropped, it's written on crash.
+func (db *SkipListDB) replay(r io.Reader) (int64, error) {
+	br := bufio.NewReader(r)
+	var valid int64
+	for {
+		b, size, err := readLogBatch(br)
+		if err == io.EOF {
+			return valid, nil
+		}
+		if err == errCorruptedLog {
+			if _, perr := br.Peek(1); perr != io.EOF {
+				return 0, errors.Wrapf(err, "log is damaged at offset %d, records after it can't be replayed", valid)
+			}
+			inslogger.FromContext(context.Background()).Warnf(
+				"SkipListDB: incomplete record at the end of log is dropped, log is cut at offset %d", valid)
+			return valid, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		db.apply(b)
+		db.logOps += len(b.ops)
+		valid += size
+	}
+}
+
+func (db *SkipListDB) apply(b *logBatch) {
+	for _, op := range b.ops {
+		if op.delete {
+			db.data.delete(op.key, b.version)
+			continue
+		}
+		db.data.set(op.key, op.value, b.version)
+	}
+	if b.version > db.version {
+		db.version = b.version
+	}
+}
+
+func (db *SkipListDB) appendLog(b *logBatch) error {
+	if db.closed {
+		return errors.New("db is closed")
+	}
+	if db.options.ReadOnly {
+		return ErrReadOnlyDB
+	}
+	size, err := writeLogBatch(db.log, b)
+	if err == nil && db.options.SyncWrites {
+		err = db.log.Sync()
+	}
+	if err != nil {
+		// Cut partially written batch, otherwise it hides the next ones on replay.
+		if terr := db.log.Truncate(db.logSize); terr != nil {
+			return errors.Wrapf(err, "failed to write log, truncate failed with %v", terr)
+		}
+		if _, serr := db.log.Seek(db.logSize, io.SeekStart); serr != nil {
+			return errors.Wrapf(err, "failed to write log, seek failed with %v", serr)
+		}
+		return errors.Wrap(err, "failed to write log")
+	}
+	db.logSize += size
+	db.logOps += len(b.ops)
+	return nil
+}
+
+// Get returns a copy of value for specified key or ErrNotFound.
+func (db *SkipListDB) Get(key Key) (value []byte, err error) {
+	err = db.View(func(txn Txn) error {
+		value, err = txn.Get(key)
+		return err
+	})
+	return
+}
+
+// Set stores value for a key.
+func (db *SkipListDB) Set(key Key, value []byte) error {
+	return db.Update(func(txn Txn) error {
+		return txn.Set(key, value)
+	})
+}
+
+// Delete deletes value for a key.
+func (db *SkipListDB) Delete(key Key) error {
+	return db.Update(func(txn Txn) error {
+		return txn.Delete(key)
+	})
+}
+
+// NewIterator returns new Iterator over the store.
+func (db *SkipListDB) NewIterator(pivot Key, reverse bool) Iterator {
+	return &skipListIterator{db: db, pivot: pivot, reverse: reverse}
+}
+
+// View runs fn within a read-only transaction. Writes are blocked until fn returns.
+func (db *SkipListDB) View(fn func(txn Txn) error) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	return fn(&skipListTxn{db: db})
+}
+
+// Update runs fn within a read-write transaction. Other transactions are blocked until fn returns.
+func (db *SkipListDB) Update(fn func(txn Txn) error) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	txn := &skipListTxn{db: db, writable: true, batch: logBatch{version: db.version + 1}}
+	err := fn(txn)
+	if err == nil && len(txn.batch.ops) > 0 {
+		err = db.appendLog(&txn.batch)
+	}
+	if err != nil {
+		txn.rollback()
+		return err
+	}
+
+	if len(txn.batch.ops) > 0 {
+		db.version = txn.batch.version
+		db.compactIfNeeded()
+	}
+	return nil
+}
+
+// Backup writes all the keys changed or deleted since provided version to w.
+// It returns current version, which can be passed into a later invocation to generate an incremental dump.
+func (db *SkipListDB) Backup(w io.Writer, since uint64) (uint64, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	bw := bufio.NewWriter(w)
+	for n := db.data.first(); n != nil; n = n.next[0] {
+		if n.version <= since {
+			continue
+		}
+		_, err := writeLogBatch(bw, &logBatch{version: n.version, ops: []logOp{nodeOp(n)}})
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to write backup")
+		}
+	}
+	return db.version, bw.Flush()
+}
+
+func nodeOp(n *skipNode) logOp {
+	if n.deleted {
+		return logOp{key: n.key, delete: true}
+	}
+	return logOp{key: n.key, value: n.value}
+}
+
+// Load applies backup created by Backup. Records are applied sequentially, workers are ignored.
+func (db *SkipListDB) Load(r io.Reader, workers int) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	br := bufio.NewReader(r)
+	for {
+		b, _, err := readLogBatch(br)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to read backup")
+		}
+		err = db.appendLog(b)
+		if err != nil {
+			return err
+		}
+		db.apply(b)
+		db.compactIfNeeded()
+	}
+}
+
+// Stop compacts the log if it mostly contains overwritten data and closes it.
+func (db *SkipListDB) Stop(ctx context.Context) error {
+	logger := inslogger.FromContext(ctx)
+	defer logger.Info("SkipListDB: database closed")
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return nil
+	}
+	db.closed = true
+
+	if !db.options.ReadOnly && db.needsCompaction() {
+		logger.Info("SkipListDB: compacting log...")
+		err := db.compact()
+		if err != nil {
+			db.log.Close()
+			return errors.Wrap(err, "failed to compact log")
+		}
+	}
+
+	return db.log.Close()
+}
+
+// needsCompaction returns true if log mostly contains overwritten data.
+func (db *SkipListDB) needsCompaction() bool {
+	return db.logOps > 2*(db.data.len+db.data.tombstones)
+}
+
+// compactIfNeeded compacts log which grew over CompactionLogSize. Failed compaction keeps the old log,
+// so the error is only logged.
+func (db *SkipListDB) compactIfNeeded() {
+	if db.options.CompactionLogSize == 0 || db.logSize < db.options.CompactionLogSize || !db.needsCompaction() {
+		return
+	}
+	err := db.compact()
+	if err != nil {
+		inslogger.FromContext(context.Background()).Error(errors.Wrap(err, "SkipListDB: failed to compact log"))
+	}
+}
+
+// compact rewrites log with the current state of keys and continues appending to the new log.
+func (db *SkipListDB) compact() error {
+	path := filepath.Join(db.options.Dir, skipListLogFile)
+	tmp, err := os.OpenFile(path+".tmp", os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	var size int64
+	bw := bufio.NewWriter(tmp)
+	for n := db.data.first(); n != nil; n = n.next[0] {
+		var written int64
+		written, err = writeLogBatch(bw, &logBatch{version: n.version, ops: []logOp{nodeOp(n)}})
+		if err != nil {
+			tmp.Close()
+			return err
+		}
+		size += written
+	}
+	err = bw.Flush()
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if err == nil {
+		err = os.Rename(path+".tmp", path)
+	}
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+
+	// Renamed file is the log now, the old one is unlinked.
+	old := db.log
+	db.log = tmp
+	db.logSize = size
+	db.logOps = db.data.len + db.data.tombstones
+	return old.Close()
+}
+
+type skipListTxn struct {
+	db       *SkipListDB
+	writable bool
+	batch    logBatch
+	undo     []skipEntry
+}
+
+func (t *skipListTxn) Get(key Key) ([]byte, error) {
+	n := t.db.data.get(append(key.Scope().Bytes(), key.ID()...))
+	if n == nil {
+		return nil, ErrNotFound
+	}
+	return append([]byte(nil), n.value...), nil
+}
+
+func (t *skipListTxn) Set(key Key, value []byte) error {
+	if !t.writable {
+		return ErrReadOnlyTxn
+	}
+	fullKey := append(key.Scope().Bytes(), key.ID()...)
+	value = append([]byte{}, value...)
+
+	t.undo = append(t.undo, t.db.data.set(fullKey, value, t.batch.version))
+	t.batch.ops = append(t.batch.ops, logOp{key: fullKey, value: value})
+	return nil
+}
+
+func (t *skipListTxn) Delete(key Key) error {
+	if !t.writable {
+		return ErrReadOnlyTxn
+	}
+	fullKey := append(key.Scope().Bytes(), key.ID()...)
+
+	if t.db.data.get(fullKey) == nil {
+		return nil
+	}
+	t.undo = append(t.undo, t.db.data.delete(fullKey, t.batch.version))
+	t.batch.ops = append(t.batch.ops, logOp{key: fullKey, delete: true})
+	return nil
+}
+
+func (t *skipListTxn) NewIterator(pivot Key, reverse bool) Iterator {
+	return &skipListIterator{db: t.db, pivot: pivot, reverse: reverse, locked: true}
+}
+
+func (t *skipListTxn) rollback() {
+	for i := len(t.undo) - 1; i >= 0; i-- {
+		t.db.data.restore(t.undo[i])
+	}
+}
+
+type skipListIterator struct {
+	db      *SkipListDB
+	pivot   Key
+	reverse bool
+	// locked is set for iterators within transaction, which already holds the lock.
+	locked bool
+
+	started bool
+	done    bool
+	// position is a full key of the current node.
+	position []byte
+	key      []byte
+	value    []byte
+}
+
+func (it *skipListIterator) Next() bool {
+	if it.done {
+		return false
+	}
+	if !it.locked {
+		it.db.lock.RLock()
+		defer it.db.lock.RUnlock()
+	}
+
+	var n *skipNode
+	if !it.started {
+		it.started = true
+		n = it.db.data.seek(append(it.pivot.Scope().Bytes(), it.pivot.ID()...), it.reverse, true)
+	} else {
+		n = it.db.data.seek(it.position, it.reverse, false)
+	}
+	if n == nil || n.key[0] != byte(it.pivot.Scope()) {
+		it.done = true
+		return false
+	}
+
+	it.position = n.key
+	it.key = append([]byte(nil), n.key[1:]...)
+	it.value = append([]byte(nil), n.value...)
+	return true
+}
+
+func (it *skipListIterator) Close() {
+	it.done = true
+}
+
+func (it *skipListIterator) Key() []byte {
+	return it.key
+}
+
+func (it *skipListIterator) Value() ([]byte, error) {
+	return it.value, nil
+}
+
+type logOp struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+// logBatch is a committed transaction. Batch is stored as payload length, payload crc32 and payload:
+// version, number of operations and operations. Every operation is its type, key and value for set.
+type logBatch struct {
+	version uint64
+	ops     []logOp
+}
+
+func appendUvarint(buf []byte, v uint64) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], v)
+	return append(buf, tmp[:n]...)
+}
+
+// writeLogBatch writes batch and returns its size.
+func writeLogBatch(w io.Writer, b *logBatch) (int64, error) {
+	payload := appendUvarint(nil, b.version)
+	payload = appendUvarint(payload, uint64(len(b.ops)))
+	for _, op := range b.ops {
+		if op.delete {
+			payload = append(payload, logOpDelete)
+			payload = appendUvarint(payload, uint64(len(op.key)))
+			payload = append(payload, op.key...)
+			continue
+		}
+		payload = append(payload, logOpSet)
+		payload = appendUvarint(payload, uint64(len(op.key)))
+		payload = append(payload, op.key...)
+		payload = appendUvarint(payload, uint64(len(op.value)))
+		payload = append(payload, op.value...)
+	}
+
+	header := make([]byte, logHeaderSize)
+	binary.BigEndian.PutUint32(header, uint32(len(payload)))
+	binary.BigEndian.PutUint32(header[4:], crc32.ChecksumIEEE(payload))
+	n, err := w.Write(append(header, payload...))
+	return int64(n), err
+}
+
+// readLogBatch reads the next batch and returns it with its size. It returns io.EOF if there are no more batches
+// and errCorruptedLog if batch is incomplete or damaged.
+func readLogBatch(r io.Reader) (*logBatch, int64, error) {
+	header := make([]byte, logHeaderSize)
+	_, err := io.ReadFull(r, header)
+	if err == io.EOF {
+		return nil, 0, io.EOF
+	}
+	if err == io.ErrUnexpectedEOF {
+		return nil, 0, errCorruptedLog
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	payload := make([]byte, binary.BigEndian.Uint32(header))
+	_, err = io.ReadFull(r, payload)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return nil, 0, errCorruptedLog
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(header[4:]) {
+		return nil, 0, errCorruptedLog
+	}
+
+	b, err := decodeLogBatch(payload)
+	if err != nil {
+		return nil, 0, err
+	}
+	return b, int64(logHeaderSize + len(payload)), nil
+}
+
+func decodeLogBatch(payload []byte) (*logBatch, error) {
+	rest := payload
+	next := func() (uint64, bool) {
+		v, n := binary.Uvarint(rest)
+		if n <= 0 {
+			return 0, false
+		}
+		rest = rest[n:]
+		return v, true
+	}
+	bytesOf := func() ([]byte, bool) {
+		l, ok := next()
+		if !ok || uint64(len(rest)) < l {
+			return nil, false
+		}
+		v := rest[:l]
+		rest = rest[l:]
+		return v, true
+	}
+
+	b := &logBatch{}
+	version, ok := next()
+	if !ok {
+		return nil, errCorruptedLog
+	}
+	count, ok := next()
+	if !ok {
+		return nil, errCorruptedLog
+	}
+	b.version = version
+
+	for i := uint64(0); i < count; i++ {
+		if len(rest) == 0 {
+			return nil, errCorruptedLog
+		}
+		kind := rest[0]
+		rest = rest[1:]
+
+		key, ok := bytesOf()
+		if !ok {
+			return nil, errCorruptedLog
+		}
+		switch kind {
+		case logOpDelete:
+			b.ops = append(b.ops, logOp{key: key, delete: true})
+		case logOpSet:
+			value, ok := bytesOf()
+			if !ok {
+				return nil, errCorruptedLog
+			}
+			b.ops = append(b.ops, logOp{key: key, value: value})
+		default:
+			return nil, errCorruptedLog
+		}
+	}
+	return b, nil
+}
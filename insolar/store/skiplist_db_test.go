@@ -0,0 +1,300 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package store
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+func TestSkipListDB_Reopen(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+
+	tmpdir, err := ioutil.TempDir("", "skiplist-test-")
+	defer os.RemoveAll(tmpdir)
+	require.NoError(t, err)
+
+	kept := testBadgerKey{id: []byte{1}, scope: ScopeRecord}
+	deleted := testBadgerKey{id: []byte{2}, scope: ScopeRecord}
+	overwritten := testBadgerKey{id: []byte{3}, scope: ScopeRecord}
+
+	db, err := NewSkipListDB(SkipListOptions{Dir: tmpdir})
+	require.NoError(t, err)
+	require.NoError(t, db.Set(kept, []byte{1}))
+	require.NoError(t, db.Set(deleted, []byte{2}))
+	require.NoError(t, db.Delete(deleted))
+	for i := byte(0); i < 10; i++ {
+		require.NoError(t, db.Set(overwritten, []byte{i}))
+	}
+	require.NoError(t, db.Stop(ctx))
+
+	check := func(db *SkipListDB) {
+		value, err := db.Get(kept)
+		require.NoError(t, err)
+		require.Equal(t, []byte{1}, value)
+		_, err = db.Get(deleted)
+		require.Equal(t, ErrNotFound, err)
+		value, err = db.Get(overwritten)
+		require.NoError(t, err)
+		require.Equal(t, []byte{9}, value)
+	}
+
+	t.Run("compacted log", func(t *testing.T) {
+		db, err := NewSkipListDB(SkipListOptions{Dir: tmpdir})
+		require.NoError(t, err)
+		defer db.Stop(ctx)
+
+		// Live keys and the tombstone of deleted key.
+		require.Equal(t, 3, db.logOps)
+		check(db)
+	})
+
+	t.Run("torn tail", func(t *testing.T) {
+		path := filepath.Join(tmpdir, skipListLogFile)
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+		require.NoError(t, err)
+		_, err = f.Write([]byte{0, 0, 0, 10, 1, 2})
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		db, err := NewSkipListDB(SkipListOptions{Dir: tmpdir})
+		require.NoError(t, err)
+		check(db)
+
+		truncated, err := os.Stat(path)
+		require.NoError(t, err)
+		require.Equal(t, info.Size(), truncated.Size())
+
+		added := testBadgerKey{id: []byte{4}, scope: ScopeRecord}
+		require.NoError(t, db.Set(added, []byte{4}))
+		require.NoError(t, db.Stop(ctx))
+
+		db, err = NewSkipListDB(SkipListOptions{Dir: tmpdir})
+		require.NoError(t, err)
+		defer db.Stop(ctx)
+		check(db)
+		_, err = db.Get(added)
+		require.NoError(t, err)
+	})
+}
+
+func TestSkipListDB_DamagedLog(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+
+	tmpdir, err := ioutil.TempDir("", "skiplist-test-")
+	defer os.RemoveAll(tmpdir)
+	require.NoError(t, err)
+
+	db, err := NewSkipListDB(SkipListOptions{Dir: tmpdir})
+	require.NoError(t, err)
+	for i := byte(0); i < 10; i++ {
+		require.NoError(t, db.Set(testBadgerKey{id: []byte{i}, scope: ScopeRecord}, []byte{i}))
+	}
+	// closed without compaction
+	require.NoError(t, db.log.Close())
+
+	path := filepath.Join(tmpdir, skipListLogFile)
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	// last payload byte of the first record
+	first := logHeaderSize + int(binary.BigEndian.Uint32(data))
+	damaged := append([]byte{}, data...)
+	damaged[first-1] ^= 0xff
+	require.NoError(t, ioutil.WriteFile(path, damaged, 0600))
+
+	_, err = NewSkipListDB(SkipListOptions{Dir: tmpdir})
+	require.Error(t, err)
+
+	// log is kept for recovery
+	kept, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, damaged, kept)
+
+	// damaged record at the end of log is written on crash and dropped
+	damaged = append([]byte{}, data...)
+	damaged[len(damaged)-1] ^= 0xff
+	require.NoError(t, ioutil.WriteFile(path, damaged, 0600))
+
+	db, err = NewSkipListDB(SkipListOptions{Dir: tmpdir})
+	require.NoError(t, err)
+	defer db.Stop(ctx)
+	_, err = db.Get(testBadgerKey{id: []byte{8}, scope: ScopeRecord})
+	require.NoError(t, err)
+	_, err = db.Get(testBadgerKey{id: []byte{9}, scope: ScopeRecord})
+	require.Equal(t, ErrNotFound, err)
+}
+
+func TestSkipListDB_ReadOnlyTxn(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+
+	tmpdir, err := ioutil.TempDir("", "skiplist-test-")
+	defer os.RemoveAll(tmpdir)
+	require.NoError(t, err)
+
+	db, err := NewSkipListDB(SkipListOptions{Dir: tmpdir})
+	require.NoError(t, err)
+	defer db.Stop(ctx)
+
+	err = db.View(func(txn Txn) error {
+		return txn.Set(testBadgerKey{id: []byte{1}, scope: ScopeRecord}, nil)
+	})
+	require.Equal(t, ErrReadOnlyTxn, err)
+}
+
+func TestSkipListDB_DeleteWhileIterating(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+
+	tmpdir, err := ioutil.TempDir("", "skiplist-test-")
+	defer os.RemoveAll(tmpdir)
+	require.NoError(t, err)
+
+	db, err := NewSkipListDB(SkipListOptions{Dir: tmpdir})
+	require.NoError(t, err)
+	defer db.Stop(ctx)
+
+	for i := byte(0); i < 100; i++ {
+		require.NoError(t, db.Set(testBadgerKey{id: []byte{i}, scope: ScopeRecord}, []byte{i}))
+	}
+
+	it := db.NewIterator(testBadgerKey{id: []byte{50}, scope: ScopeRecord}, false)
+	deleted := 0
+	for it.Next() {
+		require.NoError(t, db.Delete(testBadgerKey{id: it.Key(), scope: ScopeRecord}))
+		deleted++
+	}
+	it.Close()
+
+	require.Equal(t, 50, deleted)
+	require.Equal(t, 50, db.data.len)
+}
+
+func TestSkipListDB_IncrementalBackupWithDeletes(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+
+	tmpdir, err := ioutil.TempDir("", "skiplist-test-")
+	defer os.RemoveAll(tmpdir)
+	require.NoError(t, err)
+
+	kept := testBadgerKey{id: []byte{1}, scope: ScopeRecord}
+	removed := testBadgerKey{id: []byte{2}, scope: ScopeRecord}
+	last := testBadgerKey{id: []byte{3}, scope: ScopeRecord}
+
+	db, err := NewSkipListDB(SkipListOptions{Dir: filepath.Join(tmpdir, "source")})
+	require.NoError(t, err)
+	defer db.Stop(ctx)
+	require.NoError(t, db.Set(kept, []byte{1}))
+	require.NoError(t, db.Set(removed, []byte{2}))
+	require.NoError(t, db.Set(last, []byte{3}))
+
+	full := &bytes.Buffer{}
+	since, err := db.Backup(full, 0)
+	require.NoError(t, err)
+
+	require.NoError(t, db.Delete(removed))
+	incremental := &bytes.Buffer{}
+	_, err = db.Backup(incremental, since)
+	require.NoError(t, err)
+
+	restored, err := NewSkipListDB(SkipListOptions{Dir: filepath.Join(tmpdir, "restored")})
+	require.NoError(t, err)
+	defer restored.Stop(ctx)
+	require.NoError(t, restored.Load(full, 1))
+	require.NoError(t, restored.Load(incremental, 1))
+
+	_, err = restored.Get(removed)
+	require.Equal(t, ErrNotFound, err)
+	value, err := restored.Get(kept)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1}, value)
+
+	t.Run("iterators skip deleted keys", func(t *testing.T) {
+		var keys [][]byte
+		it := restored.NewIterator(testBadgerKey{id: []byte{3}, scope: ScopeRecord}, true)
+		for it.Next() {
+			keys = append(keys, it.Key())
+		}
+		it.Close()
+		require.Equal(t, [][]byte{{3}, {1}}, keys)
+	})
+}
+
+func TestSkipListDB_RollbackDelete(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+
+	tmpdir, err := ioutil.TempDir("", "skiplist-test-")
+	defer os.RemoveAll(tmpdir)
+	require.NoError(t, err)
+
+	db, err := NewSkipListDB(SkipListOptions{Dir: tmpdir})
+	require.NoError(t, err)
+	defer db.Stop(ctx)
+
+	key := testBadgerKey{id: []byte{1}, scope: ScopeRecord}
+	require.NoError(t, db.Set(key, []byte{1}))
+
+	err = db.Update(func(txn Txn) error {
+		require.NoError(t, txn.Delete(key))
+		return errors.New("rollback")
+	})
+	require.Error(t, err)
+
+	value, err := db.Get(key)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1}, value)
+	require.Equal(t, 1, db.data.len)
+	require.Equal(t, 0, db.data.tombstones)
+}
+
+func TestSkipListDB_CompactionOnCommit(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+
+	tmpdir, err := ioutil.TempDir("", "skiplist-test-")
+	defer os.RemoveAll(tmpdir)
+	require.NoError(t, err)
+
+	ops := SkipListOptions{Dir: tmpdir, CompactionLogSize: 1024}
+	db, err := NewSkipListDB(ops)
+	require.NoError(t, err)
+
+	key := testBadgerKey{id: []byte{1}, scope: ScopeRecord}
+	value := make([]byte, 100)
+	for i := 0; i < 100; i++ {
+		value[0] = byte(i)
+		require.NoError(t, db.Set(key, value))
+	}
+	require.True(t, db.logSize < 2*ops.CompactionLogSize)
+	require.NoError(t, db.Stop(ctx))
+
+	db, err = NewSkipListDB(ops)
+	require.NoError(t, err)
+	defer db.Stop(ctx)
+	stored, err := db.Get(key)
+	require.NoError(t, err)
+	require.Equal(t, byte(99), stored[0])
+}
@@ -18,6 +18,7 @@ package insolar
 
 import (
 	"context"
+	"time"
 )
 
 type LeaveApproved struct{}
@@ -33,4 +34,50 @@ type TerminationHandler interface {
 	Abort(reason string)
 	// Terminating is an accessor
 	Terminating() bool
+
+	// Decommission starts to take node out of network in background. Node stops to receive new tasks,
+	// waits until all drainers hand over their work and only then leaves network.
+	Decommission(context.Context) error
+	// DecommissionStatus reports progress of decommission.
+	DecommissionStatus() DecommissionStatus
+}
+
+// DecommissionState is a stage of node decommission.
+type DecommissionState string
+
+const (
+	// DecommissionNone means node works as usual.
+	DecommissionNone DecommissionState = "none"
+	// DecommissionDraining means node waits until network stops to assign new tasks to it.
+	DecommissionDraining DecommissionState = "draining"
+	// DecommissionHandoff means node doesn't receive new tasks and waits until its work is handed over.
+	DecommissionHandoff DecommissionState = "handoff"
+	// DecommissionLeaving means node waits until network accepts leaving claim.
+	DecommissionLeaving DecommissionState = "leaving"
+	// DecommissionLeft means node has left network and can be stopped.
+	DecommissionLeft DecommissionState = "left"
+)
+
+// DecommissionStatus is a progress of node decommission.
+type DecommissionStatus struct {
+	State     DecommissionState
+	StartedAt time.Time
+	// Components are reports of drainers, they are updated on every check during handoff.
+	Components []DrainProgress
+}
+
+// DrainProgress is a report of component about work it has to finish before node leaves network.
+type DrainProgress struct {
+	Component string
+	Done      bool
+	// Pending is a number of work items left, if component can count them.
+	Pending int
+	Details string
+}
+
+//go:generate minimock -i github.com/insolar/insolar/insolar.Drainer -o ../testutils -s _mock.go -g
+
+// Drainer is a component that has to hand over its work before node leaves network.
+type Drainer interface {
+	DrainProgress(ctx context.Context) DrainProgress
 }
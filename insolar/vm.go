@@ -36,6 +36,14 @@ func (m MachineType) Equal(other MachineType) bool {
 	return m == other
 }
 
+// MigrateMethod is a reserved name of a method that converts object state written by previous version of contract.
+// Request with this method is executed by code of request prototype and moves object to this prototype.
+const MigrateMethod = "INSMIGRATE"
+
+// VersionMethod is a reserved name of a method that returns version of contract, it's used to choose migration
+// of object state.
+const VersionMethod = "INSVERSION"
+
 //go:generate minimock -i github.com/insolar/insolar/insolar.MachineLogicExecutor -o ../testutils -s _mock.go -g
 
 // MachineLogicExecutor is an interface for implementers of one particular machine type
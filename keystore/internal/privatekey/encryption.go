@@ -0,0 +1,141 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package privatekey
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// EncryptedBlockType is a PEM block type of passphrase protected private keys.
+	EncryptedBlockType = "INSOLAR ENCRYPTED PRIVATE KEY"
+	// PlainBlockType is a PEM block type of unprotected private keys.
+	PlainBlockType = "PRIVATE KEY"
+
+	headerCipher    = "Cipher"
+	headerKDF       = "KDF"
+	headerKDFParams = "KDF-Params"
+	headerSalt      = "Salt"
+	headerNonce     = "Nonce"
+
+	cipherAES256GCM = "AES-256-GCM"
+	kdfScrypt       = "scrypt"
+
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+	saltLen      = 32
+)
+
+// Encrypt seals DER encoded private key with the key derived from passphrase (scrypt + AES-256-GCM).
+// KDF parameters are stored in PEM headers and authenticated along with the key.
+func Encrypt(der []byte, passphrase []byte) (*pem.Block, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("[ Encrypt ] empty passphrase")
+	}
+
+	salt := make([]byte, saltLen)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, errors.Wrap(err, "[ Encrypt ] failed to generate salt")
+	}
+
+	kdfParams := fmt.Sprintf("%d,%d,%d", scryptN, scryptR, scryptP)
+	aead, err := newAEAD(passphrase, salt, scryptN, scryptR, scryptP)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Encrypt ] failed to derive key")
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Wrap(err, "[ Encrypt ] failed to generate nonce")
+	}
+
+	return &pem.Block{
+		Type: EncryptedBlockType,
+		Headers: map[string]string{
+			headerCipher:    cipherAES256GCM,
+			headerKDF:       kdfScrypt,
+			headerKDFParams: kdfParams,
+			headerSalt:      hex.EncodeToString(salt),
+			headerNonce:     hex.EncodeToString(nonce),
+		},
+		Bytes: aead.Seal(nil, nonce, der, []byte(kdfParams)),
+	}, nil
+}
+
+// Decrypt opens private key sealed by Encrypt and returns it DER encoded.
+func Decrypt(block *pem.Block, passphrase []byte) ([]byte, error) {
+	if block.Type != EncryptedBlockType {
+		return nil, errors.Errorf("[ Decrypt ] unexpected block type %s", block.Type)
+	}
+	if block.Headers[headerCipher] != cipherAES256GCM {
+		return nil, errors.Errorf("[ Decrypt ] unsupported cipher %s", block.Headers[headerCipher])
+	}
+	if block.Headers[headerKDF] != kdfScrypt {
+		return nil, errors.Errorf("[ Decrypt ] unsupported kdf %s", block.Headers[headerKDF])
+	}
+
+	kdfParams := block.Headers[headerKDFParams]
+	var n, r, p int
+	if _, err := fmt.Sscanf(kdfParams, "%d,%d,%d", &n, &r, &p); err != nil {
+		return nil, errors.Wrap(err, "[ Decrypt ] failed to parse kdf params")
+	}
+	salt, err := hex.DecodeString(block.Headers[headerSalt])
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Decrypt ] failed to parse salt")
+	}
+	nonce, err := hex.DecodeString(block.Headers[headerNonce])
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Decrypt ] failed to parse nonce")
+	}
+
+	aead, err := newAEAD(passphrase, salt, n, r, p)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Decrypt ] failed to derive key")
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, errors.Errorf("[ Decrypt ] wrong nonce length: %d", len(nonce))
+	}
+
+	der, err := aead.Open(nil, nonce, block.Bytes, []byte(kdfParams))
+	if err != nil {
+		return nil, errors.New("[ Decrypt ] wrong passphrase or corrupted key")
+	}
+	return der, nil
+}
+
+func newAEAD(passphrase, salt []byte, n, r, p int) (cipher.AEAD, error) {
+	key, err := scrypt.Key(passphrase, salt, n, r, p, scryptKeyLen)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
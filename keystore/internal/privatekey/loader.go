@@ -27,13 +27,24 @@ import (
 	"github.com/pkg/errors"
 )
 
+// PassphraseFunc returns passphrase of encrypted key files.
+type PassphraseFunc func() ([]byte, error)
+
 type keyLoader struct {
-	parseFunc func(key []byte) (crypto.PrivateKey, error)
+	parseFunc  func(key []byte) (crypto.PrivateKey, error)
+	passphrase PassphraseFunc
 }
 
 func NewLoader() Loader {
+	return NewLoaderWithPassphrase(nil)
+}
+
+// NewLoaderWithPassphrase creates loader which is able to load encrypted key files.
+// Passphrase is requested only if key file is encrypted.
+func NewLoaderWithPassphrase(passphrase PassphraseFunc) Loader {
 	return &keyLoader{
-		parseFunc: pemParse,
+		parseFunc:  pemParse,
+		passphrase: passphrase,
 	}
 }
 
@@ -43,6 +54,11 @@ func (p *keyLoader) Load(file string) (crypto.PrivateKey, error) {
 		return nil, errors.Wrap(err, "[ Load ] Could't read private key")
 	}
 
+	key, err = p.decrypt(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Load ] Could't decrypt private key")
+	}
+
 	signer, err := p.parseFunc(key)
 	if err != nil {
 		return nil, errors.Wrap(err, "[ Load ] Could't parse private key")
@@ -51,6 +67,27 @@ func (p *keyLoader) Load(file string) (crypto.PrivateKey, error) {
 }
 
 // TODO: deprecated, use PEM format
+func (p *keyLoader) decrypt(key []byte) ([]byte, error) {
+	block, _ := pem.Decode(key)
+	if block == nil || block.Type != EncryptedBlockType {
+		return key, nil
+	}
+
+	if p.passphrase == nil {
+		return nil, errors.New("[ decrypt ] key is encrypted, but passphrase is not provided")
+	}
+	passphrase, err := p.passphrase()
+	if err != nil {
+		return nil, errors.Wrap(err, "[ decrypt ] failed to get passphrase")
+	}
+
+	der, err := Decrypt(block, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: PlainBlockType, Bytes: der}), nil
+}
+
 func readJSON(path string) ([]byte, error) {
 	data, err := ioutil.ReadFile(filepath.Clean(path))
 	if err != nil {
@@ -19,13 +19,23 @@ package keystore
 import (
 	"context"
 	"crypto"
+	"encoding/pem"
+	"os"
 
 	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/insolar"
 	"github.com/insolar/insolar/keystore/internal/privatekey"
 	"github.com/pkg/errors"
 )
 
+const (
+	// BackendFile reads private key from key file, possibly encrypted.
+	BackendFile = "file"
+	// BackendSigner delegates signing to external signer process, node never holds private key.
+	BackendSigner = "signer"
+)
+
 type keyStore struct {
 	Loader privatekey.Loader `inject:""`
 	file   string
@@ -82,7 +92,56 @@ func (ks *cachedKeyStore) Start(ctx context.Context) error {
 	return nil
 }
 
+// NewKeyStore creates key store for key file at path. Encrypted key files are decrypted
+// with passphrase from environment variable configured by default (see configuration.KeyStore).
 func NewKeyStore(path string) (insolar.KeyStore, error) {
+	return newFileKeyStore(path, envPassphrase(configuration.NewKeyStore().PassphraseEnv))
+}
+
+// NewEncryptedKeyStore creates key store for key file encrypted with passphrase.
+func NewEncryptedKeyStore(path string, passphrase []byte) (insolar.KeyStore, error) {
+	return newFileKeyStore(path, func() ([]byte, error) {
+		return passphrase, nil
+	})
+}
+
+// NewKeyStoreFromConfig creates key store with backend selected by configuration.
+func NewKeyStoreFromConfig(path string, cfg configuration.KeyStore) (insolar.KeyStore, error) {
+	switch cfg.Backend {
+	case "", BackendFile:
+		return newFileKeyStore(path, envPassphrase(cfg.PassphraseEnv))
+	case BackendSigner:
+		return NewSignerKeyStore(cfg.SignerAddress)
+	default:
+		return nil, errors.Errorf("[ NewKeyStoreFromConfig ] unknown key store backend %s", cfg.Backend)
+	}
+}
+
+// EncryptPrivateKey encrypts PEM encoded private key with passphrase.
+func EncryptPrivateKey(pemKey []byte, passphrase []byte) ([]byte, error) {
+	block, _ := pem.Decode(pemKey)
+	if block == nil {
+		return nil, errors.New("[ EncryptPrivateKey ] failed to decode private key")
+	}
+
+	encrypted, err := privatekey.Encrypt(block.Bytes, passphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ EncryptPrivateKey ] failed to encrypt private key")
+	}
+	return pem.EncodeToMemory(encrypted), nil
+}
+
+func envPassphrase(name string) privatekey.PassphraseFunc {
+	return func() ([]byte, error) {
+		passphrase := os.Getenv(name)
+		if passphrase == "" {
+			return nil, errors.Errorf("environment variable %s is not set", name)
+		}
+		return []byte(passphrase), nil
+	}
+}
+
+func newFileKeyStore(path string, passphrase privatekey.PassphraseFunc) (insolar.KeyStore, error) {
 	keyStore := &keyStore{
 		file: path,
 	}
@@ -95,7 +154,7 @@ func NewKeyStore(path string) (insolar.KeyStore, error) {
 	manager.Inject(
 		cachedKeyStore,
 		keyStore,
-		privatekey.NewLoader(),
+		privatekey.NewLoaderWithPassphrase(passphrase),
 	)
 
 	if err := manager.Start(context.Background()); err != nil {
@@ -18,8 +18,15 @@ package keystore
 
 import (
 	"crypto/ecdsa"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/platformpolicy"
 	"github.com/stretchr/testify/require"
 )
 
@@ -61,3 +68,124 @@ func TestKeyStore_GetPrivateKeyReturnsECDSA(t *testing.T) {
 	require.NotNil(t, ecdsaPK)
 	require.True(t, ok)
 }
+
+func writeEncryptedKeys(t *testing.T, dir string, passphrase []byte) string {
+	data, err := ioutil.ReadFile(testKeys)
+	require.NoError(t, err)
+	keys := map[string]string{}
+	require.NoError(t, json.Unmarshal(data, &keys))
+
+	encrypted, err := EncryptPrivateKey([]byte(keys["private_key"]), passphrase)
+	require.NoError(t, err)
+	require.NotContains(t, string(encrypted), keys["private_key"])
+	keys["private_key"] = string(encrypted)
+
+	data, err = json.Marshal(keys)
+	require.NoError(t, err)
+	path := filepath.Join(dir, "encrypted_keys.json")
+	require.NoError(t, ioutil.WriteFile(path, data, 0600))
+	return path
+}
+
+func TestEncryptedKeyStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystore")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	passphrase := []byte("secret passphrase")
+	path := writeEncryptedKeys(t, dir, passphrase)
+
+	plain, err := NewKeyStore(testKeys)
+	require.NoError(t, err)
+	expected, err := plain.GetPrivateKey("")
+	require.NoError(t, err)
+
+	t.Run("passphrase", func(t *testing.T) {
+		ks, err := NewEncryptedKeyStore(path, passphrase)
+		require.NoError(t, err)
+
+		pk, err := ks.GetPrivateKey("")
+		require.NoError(t, err)
+		require.Equal(t, expected, pk)
+	})
+
+	t.Run("wrong passphrase", func(t *testing.T) {
+		ks, err := NewEncryptedKeyStore(path, []byte("wrong"))
+		require.Error(t, err)
+		require.Nil(t, ks)
+	})
+
+	t.Run("passphrase from env", func(t *testing.T) {
+		cfg := configuration.NewKeyStore()
+		cfg.PassphraseEnv = "INSOLAR_TEST_KEYS_PASSPHRASE"
+
+		_, err := NewKeyStoreFromConfig(path, cfg)
+		require.Error(t, err)
+
+		require.NoError(t, os.Setenv(cfg.PassphraseEnv, string(passphrase)))
+		defer os.Unsetenv(cfg.PassphraseEnv)
+
+		ks, err := NewKeyStoreFromConfig(path, cfg)
+		require.NoError(t, err)
+		pk, err := ks.GetPrivateKey("")
+		require.NoError(t, err)
+		require.Equal(t, expected, pk)
+	})
+}
+
+func TestSignerKeyStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystore")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fileKS, err := NewKeyStore(testKeys)
+	require.NoError(t, err)
+	privateKey, err := fileKS.GetPrivateKey("")
+	require.NoError(t, err)
+
+	address := filepath.Join(dir, "signer.sock")
+	listener, err := net.Listen("unix", address)
+	require.NoError(t, err)
+	defer listener.Close()
+	go ServeSigner(listener, fileKS) // nolint: errcheck
+
+	cfg := configuration.NewKeyStore()
+	cfg.Backend = BackendSigner
+	cfg.SignerAddress = address
+	ks, err := NewKeyStoreFromConfig(testBadKeys, cfg)
+	require.NoError(t, err)
+
+	pk, err := ks.GetPrivateKey("")
+	require.NoError(t, err)
+	_, isECDSA := pk.(*ecdsa.PrivateKey)
+	require.False(t, isECDSA)
+
+	kp := platformpolicy.NewKeyProcessor()
+	require.Equal(t, kp.ExtractPublicKey(privateKey), kp.ExtractPublicKey(pk))
+
+	scheme := platformpolicy.NewPlatformCryptographyScheme()
+	data := []byte("data to sign")
+	signature, err := scheme.DataSigner(pk, scheme.IntegrityHasher()).Sign(data)
+	require.NoError(t, err)
+	verifier := scheme.DataVerifier(kp.ExtractPublicKey(privateKey), scheme.IntegrityHasher())
+	require.True(t, verifier.Verify(*signature, data))
+}
+
+func TestSignerKeyStore_Fails(t *testing.T) {
+	cfg := configuration.NewKeyStore()
+	cfg.Backend = BackendSigner
+
+	ks, err := NewKeyStoreFromConfig(testKeys, cfg)
+	require.Error(t, err)
+	require.Nil(t, ks)
+
+	cfg.SignerAddress = filepath.Join(os.TempDir(), "insolar-missing-signer.sock")
+	ks, err = NewKeyStoreFromConfig(testKeys, cfg)
+	require.Error(t, err)
+	require.Nil(t, ks)
+
+	cfg.Backend = "unknown"
+	ks, err = NewKeyStoreFromConfig(testKeys, cfg)
+	require.Error(t, err)
+	require.Nil(t, ks)
+}
@@ -0,0 +1,243 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package keystore
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/asn1"
+	"io"
+	"math/big"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/pkg/errors"
+)
+
+// External signer protocol is JSON-RPC 1.0 over unix socket, so signer may be implemented
+// in any language (e.g. on top of HSM). Byte slices are base64 encoded.
+const (
+	signerServiceName   = "Signer"
+	signerPublicKeyCall = signerServiceName + ".PublicKey"
+	signerSignCall      = signerServiceName + ".Sign"
+
+	signerCallTimeout = 5 * time.Second
+)
+
+// PublicKeyArgs is request of Signer.PublicKey call.
+type PublicKeyArgs struct{}
+
+// PublicKeyReply is response of Signer.PublicKey call.
+type PublicKeyReply struct {
+	// PublicKey is PKIX DER encoded public key.
+	PublicKey []byte
+}
+
+// SignArgs is request of Signer.Sign call.
+type SignArgs struct {
+	Digest []byte
+}
+
+// SignReply is response of Signer.Sign call.
+type SignReply struct {
+	// Signature is ASN.1 DER encoded ecdsa signature of the digest.
+	Signature []byte
+}
+
+type signerService struct {
+	signer crypto.Signer
+}
+
+func (s *signerService) PublicKey(_ *PublicKeyArgs, reply *PublicKeyReply) error {
+	publicKey, err := x509.MarshalPKIXPublicKey(s.signer.Public())
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal public key")
+	}
+	reply.PublicKey = publicKey
+	return nil
+}
+
+func (s *signerService) Sign(args *SignArgs, reply *SignReply) error {
+	signature, err := signDigest(s.signer, args.Digest)
+	if err != nil {
+		return errors.Wrap(err, "failed to sign digest")
+	}
+	reply.Signature = signature
+	return nil
+}
+
+// signDigest signs digest of any length, crypto.Signer of ecdsa keys requires known hash function.
+func signDigest(signer crypto.Signer, digest []byte) ([]byte, error) {
+	privateKey, ok := signer.(*ecdsa.PrivateKey)
+	if !ok {
+		return signer.Sign(rand.Reader, digest, crypto.Hash(0))
+	}
+
+	r, s, err := ecdsa.Sign(rand.Reader, privateKey, digest)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(struct {
+		R, S *big.Int
+	}{r, s})
+}
+
+// ServeSigner serves external signer protocol for private key of key store on listener.
+// It returns when listener is closed.
+func ServeSigner(l net.Listener, ks insolar.KeyStore) error {
+	privateKey, err := ks.GetPrivateKey("")
+	if err != nil {
+		return errors.Wrap(err, "[ ServeSigner ] failed to get private key")
+	}
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return errors.New("[ ServeSigner ] private key can't sign")
+	}
+
+	server := rpc.NewServer()
+	if err := server.RegisterName(signerServiceName, &signerService{signer: signer}); err != nil {
+		return errors.Wrap(err, "[ ServeSigner ] failed to register signer service")
+	}
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go server.ServeCodec(jsonrpc.NewServerCodec(conn))
+	}
+}
+
+// signerKeyStore provides private key which delegates signing to external signer.
+type signerKeyStore struct {
+	address string
+	key     *signerKey
+
+	lock   sync.Mutex
+	client *rpc.Client
+}
+
+// NewSignerKeyStore creates key store backed by external signer listening on unix socket address.
+func NewSignerKeyStore(address string) (insolar.KeyStore, error) {
+	if address == "" {
+		return nil, errors.New("[ NewSignerKeyStore ] signer address is not set")
+	}
+
+	ks := &signerKeyStore{
+		address: address,
+	}
+
+	reply := PublicKeyReply{}
+	if err := ks.call(signerPublicKeyCall, &PublicKeyArgs{}, &reply); err != nil {
+		return nil, errors.Wrap(err, "[ NewSignerKeyStore ] failed to get public key")
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(reply.PublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NewSignerKeyStore ] failed to parse public key")
+	}
+	ecdsaPublicKey, ok := publicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("[ NewSignerKeyStore ] signer key is not ecdsa key")
+	}
+
+	ks.key = &signerKey{
+		keyStore:  ks,
+		publicKey: ecdsaPublicKey,
+	}
+	return ks, nil
+}
+
+func (ks *signerKeyStore) GetPrivateKey(string) (crypto.PrivateKey, error) {
+	return ks.key, nil
+}
+
+func (ks *signerKeyStore) getClient() (*rpc.Client, error) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	if ks.client != nil {
+		return ks.client, nil
+	}
+
+	conn, err := net.DialTimeout("unix", ks.address, signerCallTimeout)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to signer %s", ks.address)
+	}
+	ks.client = jsonrpc.NewClient(conn)
+	return ks.client, nil
+}
+
+func (ks *signerKeyStore) resetClient(client *rpc.Client) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	if ks.client == client {
+		_ = ks.client.Close()
+		ks.client = nil
+	}
+}
+
+func (ks *signerKeyStore) call(method string, args interface{}, reply interface{}) error {
+	err := ks.callOnce(method, args, reply)
+	if err == rpc.ErrShutdown || err == io.ErrUnexpectedEOF {
+		// signer was restarted, reconnect once
+		err = ks.callOnce(method, args, reply)
+	}
+	return err
+}
+
+func (ks *signerKeyStore) callOnce(method string, args interface{}, reply interface{}) error {
+	client, err := ks.getClient()
+	if err != nil {
+		return err
+	}
+
+	select {
+	case call := <-client.Go(method, args, reply, make(chan *rpc.Call, 1)).Done:
+		if call.Error == rpc.ErrShutdown || call.Error == io.ErrUnexpectedEOF {
+			ks.resetClient(client)
+		}
+		return call.Error
+	case <-time.After(signerCallTimeout):
+		ks.resetClient(client)
+		return errors.Errorf("signer call %s timed out", method)
+	}
+}
+
+// signerKey is a private key held by external signer.
+type signerKey struct {
+	keyStore  *signerKeyStore
+	publicKey *ecdsa.PublicKey
+}
+
+func (k *signerKey) Public() crypto.PublicKey {
+	return k.publicKey
+}
+
+func (k *signerKey) Sign(_ io.Reader, digest []byte, _ crypto.SignerOpts) ([]byte, error) {
+	reply := SignReply{}
+	if err := k.keyStore.call(signerSignCall, &SignArgs{Digest: digest}, &reply); err != nil {
+		return nil, errors.Wrap(err, "[ Sign ] external signer failed")
+	}
+	return reply.Signature, nil
+}
@@ -92,6 +92,32 @@ func (ds *DB) Set(ctx context.Context, drop Drop) error {
 	return ds.db.Set(&k, encoded)
 }
 
+// All returns all the drops stored for a provided pulse.
+func (ds *DB) All(ctx context.Context, pulse insolar.PulseNumber) ([]Drop, error) {
+	it := ds.db.NewIterator(&dropDbKey{jetPrefix: []byte{}, pn: pulse}, false)
+	defer it.Close()
+
+	var drops []Drop
+	for it.Next() {
+		key := newDropDbKey(it.Key())
+		if key.pn != pulse {
+			break
+		}
+
+		buf, err := it.Value()
+		if err != nil {
+			return nil, errors.Wrapf(err, "can't read drop: %+v", key)
+		}
+		drop := Drop{}
+		err = drop.Unmarshal(buf)
+		if err != nil {
+			return nil, errors.Wrapf(err, "can't unmarshal drop: %+v", key)
+		}
+		drops = append(drops, drop)
+	}
+	return drops, nil
+}
+
 // TruncateHead remove all records after lastPulse
 func (ds *DB) TruncateHead(ctx context.Context, from insolar.PulseNumber) error {
 	it := ds.db.NewIterator(&dropDbKey{jetPrefix: []byte{}, pn: from}, false)
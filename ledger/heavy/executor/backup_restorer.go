@@ -0,0 +1,212 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/jet"
+	insolarPulse "github.com/insolar/insolar/insolar/pulse"
+	"github.com/insolar/insolar/insolar/store"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/drop"
+	"github.com/insolar/insolar/ledger/object"
+)
+
+// BackupIncrement is an incremental backup made by BackupMakerDefault
+type BackupIncrement struct {
+	// Dir is directory of the backup
+	Dir string
+	// Info is meta info of the backup
+	Info BackupInfo
+}
+
+// readBackupInfo reads meta info of backup in dir. It returns os.IsNotExist error if dir contains no meta info.
+func readBackupInfo(dir string, config configuration.Backup) (*BackupInfo, error) {
+	raw, err := ioutil.ReadFile(filepath.Join(dir, config.MetaInfoFile))
+	if err != nil {
+		return nil, err
+	}
+
+	info := &BackupInfo{}
+	err = json.Unmarshal(raw, info)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't unmarshal backup info in %s", dir)
+	}
+	return info, nil
+}
+
+// FindBackupChain discovers backups in config.TargetDirectory and returns increments needed to restore pulse upTo.
+// Zero upTo means the latest backuped pulse. Chain starts with the latest full backup (made with Since == 0),
+// which is the first backup made after heavy start.
+func FindBackupChain(config configuration.Backup, upTo insolar.PulseNumber) ([]BackupIncrement, error) {
+	dirs, err := ioutil.ReadDir(config.TargetDirectory)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't read backup directory %s", config.TargetDirectory)
+	}
+
+	var all []BackupIncrement
+	for _, d := range dirs {
+		if !d.IsDir() {
+			continue
+		}
+		dir := filepath.Join(config.TargetDirectory, d.Name())
+		info, err := readBackupInfo(dir, config)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if expected := fmt.Sprintf(config.DirNameTemplate, info.Pulse); d.Name() != expected {
+			return nil, errors.Errorf("backup of pulse %d is in %s, expected %s", info.Pulse, d.Name(), expected)
+		}
+		all = append(all, BackupIncrement{Dir: dir, Info: *info})
+	}
+	if len(all) == 0 {
+		return nil, errors.Errorf("no backups found in %s", config.TargetDirectory)
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Info.Pulse < all[j].Info.Pulse
+	})
+
+	last := len(all) - 1
+	if upTo != 0 {
+		last = sort.Search(len(all), func(i int) bool {
+			return all[i].Info.Pulse >= upTo
+		})
+		if last == len(all) || all[last].Info.Pulse != upTo {
+			return nil, errors.Errorf("no backup for pulse %d", upTo)
+		}
+	}
+
+	for first := last; first >= 0; first-- {
+		if all[first].Info.Since == 0 {
+			return all[first : last+1], nil
+		}
+	}
+	return nil, errors.Errorf("no full backup found before pulse %d", all[last].Info.Pulse)
+}
+
+// VerifyBackupChain checks hashes of backup files and that every increment continues the previous one.
+func VerifyBackupChain(chain []BackupIncrement, config configuration.Backup) error {
+	if len(chain) == 0 {
+		return errors.New("backup chain is empty")
+	}
+	if chain[0].Info.Since != 0 {
+		return errors.Errorf("backup of pulse %d is not a full backup", chain[0].Info.Pulse)
+	}
+
+	for i, incr := range chain {
+		if i > 0 {
+			prev := chain[i-1].Info
+			if incr.Info.Pulse <= prev.Pulse {
+				return errors.Errorf("backup of pulse %d follows backup of pulse %d", incr.Info.Pulse, prev.Pulse)
+			}
+			if incr.Info.Since != prev.LastBackupedVersion {
+				return errors.Errorf(
+					"backup of pulse %d starts from version %d, but backup of pulse %d ends with version %d",
+					incr.Info.Pulse, incr.Info.Since, prev.Pulse, prev.LastBackupedVersion,
+				)
+			}
+		}
+
+		err := verifyBackupFile(incr, config)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func verifyBackupFile(incr BackupIncrement, config configuration.Backup) error {
+	f, err := os.Open(filepath.Join(incr.Dir, config.BackupFile))
+	if err != nil {
+		return errors.Wrapf(err, "can't open backup of pulse %d", incr.Info.Pulse)
+	}
+	defer f.Close()
+
+	hash, err := calculateFileHash(f)
+	if err != nil {
+		return errors.Wrapf(err, "can't calculate hash of backup of pulse %d", incr.Info.Pulse)
+	}
+	if hash != incr.Info.SHA256 {
+		return errors.Errorf("backup of pulse %d is corrupted: hash %s, expected %s", incr.Info.Pulse, hash, incr.Info.SHA256)
+	}
+	return nil
+}
+
+// RestoreBackup loads verified chain of increments into db and removes all data which is not finalized
+// in the last backuped pulse, so heavy can be started on db.
+func RestoreBackup(ctx context.Context, db store.Engine, chain []BackupIncrement, config configuration.Backup, workers int) error {
+	logger := inslogger.FromContext(ctx)
+	if len(chain) == 0 {
+		return errors.New("backup chain is empty")
+	}
+
+	for _, incr := range chain {
+		err := loadBackupFile(db, incr, config, workers)
+		if err != nil {
+			return err
+		}
+		logger.Infof("backup of pulse %d is loaded", incr.Info.Pulse)
+	}
+
+	pulses := insolarPulse.NewDB(db)
+	jets := jet.NewDBStore(db)
+	records := object.NewRecordDB(db)
+	indexes := object.NewIndexDB(db, records)
+	jetKeeper := NewJetKeeper(jets, db, pulses)
+
+	// Backup is made before pulse gets backup confirmation, so the restored pulse has to be confirmed here.
+	target := chain[len(chain)-1].Info.Pulse
+	if jetKeeper.TopSyncPulse() < target {
+		err := jetKeeper.AddBackupConfirmation(ctx, target)
+		if err != nil {
+			return errors.Wrapf(err, "can't add backup confirmation for pulse %d", target)
+		}
+	}
+	if top := jetKeeper.TopSyncPulse(); top != target {
+		return errors.Errorf("top sync pulse is %d after restore, expected %d", top, target)
+	}
+
+	rollback := NewDBRollback(jetKeeper, drop.NewDB(db), records, indexes, jets, pulses, jetKeeper)
+	return errors.Wrap(rollback.Start(ctx), "can't truncate data after restored pulse")
+}
+
+func loadBackupFile(db store.Backuper, incr BackupIncrement, config configuration.Backup, workers int) error {
+	f, err := os.Open(filepath.Join(incr.Dir, config.BackupFile))
+	if err != nil {
+		return errors.Wrapf(err, "can't open backup of pulse %d", incr.Info.Pulse)
+	}
+	defer f.Close()
+
+	err = db.Load(f, workers)
+	return errors.Wrapf(err, "can't load backup of pulse %d", incr.Info.Pulse)
+}
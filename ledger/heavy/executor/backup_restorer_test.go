@@ -0,0 +1,243 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package executor_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/gen"
+	"github.com/insolar/insolar/insolar/jet"
+	"github.com/insolar/insolar/insolar/pulse"
+	"github.com/insolar/insolar/insolar/record"
+	"github.com/insolar/insolar/insolar/store"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/drop"
+	"github.com/insolar/insolar/ledger/heavy/executor"
+	"github.com/insolar/insolar/ledger/object"
+)
+
+type restoreFixture struct {
+	cfg     configuration.Backup
+	pulses  []insolar.PulseNumber
+	records map[insolar.PulseNumber]insolar.ID
+	// head is a pulse which data got into the last backup, but was not finalized
+	head insolar.PulseNumber
+}
+
+// newRestoreFixture finalizes two pulses with backups like heavy does.
+func newRestoreFixture(t *testing.T) (*restoreFixture, func()) {
+	ctx := inslogger.TestContext(t)
+
+	root, err := ioutil.TempDir("", "restore-test-")
+	require.NoError(t, err)
+	cfg := configuration.NewLedger().Backup
+	cfg.Enabled = true
+	cfg.TargetDirectory = filepath.Join(root, "target")
+	cfg.TmpDirectory = filepath.Join(root, "tmp")
+	cfg.ConfirmFile = "BACKUPED"
+	cfg.BackupWaitPeriod = 1
+	cfg.PostProcessBackupCmd = []string{"bash", "-c", "touch $INSOLAR_CURRENT_BACKUP_DIR/BACKUPED"}
+	require.NoError(t, os.Mkdir(cfg.TargetDirectory, 0777))
+	require.NoError(t, os.Mkdir(cfg.TmpDirectory, 0777))
+
+	db, err := store.NewBadgerDB(BadgerDefaultOptions(filepath.Join(root, "db")))
+	require.NoError(t, err)
+	cleanup := func() {
+		db.Stop(ctx)
+		os.RemoveAll(root)
+	}
+
+	pulses := pulse.NewDB(db)
+	records := object.NewRecordDB(db)
+	drops := drop.NewDB(db)
+	jets := jet.NewDBStore(db)
+	jetKeeper := executor.NewJetKeeper(jets, db, pulses)
+	backupMaker, err := executor.NewBackupMaker(ctx, db, cfg, insolar.GenesisPulse.PulseNumber)
+	require.NoError(t, err)
+
+	f := &restoreFixture{
+		cfg:     cfg,
+		pulses:  []insolar.PulseNumber{insolar.GenesisPulse.PulseNumber + 10, insolar.GenesisPulse.PulseNumber + 20},
+		records: map[insolar.PulseNumber]insolar.ID{},
+		head:    insolar.GenesisPulse.PulseNumber + 30,
+	}
+
+	addPulse := func(pn, prev insolar.PulseNumber) {
+		err := pulses.Append(ctx, insolar.Pulse{PulseNumber: pn, PrevPulseNumber: prev})
+		require.NoError(t, err)
+		id := gen.IDWithPulse(pn)
+		err = records.Set(ctx, record.Material{ID: id, Virtual: record.Wrap(&record.IncomingRequest{})})
+		require.NoError(t, err)
+		f.records[pn] = id
+	}
+
+	err = pulses.Append(ctx, insolar.Pulse{PulseNumber: insolar.GenesisPulse.PulseNumber})
+	require.NoError(t, err)
+	prev := insolar.GenesisPulse.PulseNumber
+	for i, pn := range f.pulses {
+		addPulse(pn, prev)
+		prev = pn
+
+		err = jets.Update(ctx, pn, true, insolar.ZeroJetID)
+		require.NoError(t, err)
+		err = drops.Set(ctx, drop.Drop{Pulse: pn, JetID: insolar.ZeroJetID})
+		require.NoError(t, err)
+		err = jetKeeper.AddHotConfirmation(ctx, pn, insolar.ZeroJetID, false)
+		require.NoError(t, err)
+		err = jetKeeper.AddDropConfirmation(ctx, pn, insolar.ZeroJetID, false)
+		require.NoError(t, err)
+
+		if i == len(f.pulses)-1 {
+			addPulse(f.head, pn)
+		}
+
+		err = backupMaker.MakeBackup(ctx, pn)
+		require.NoError(t, err)
+		err = jetKeeper.AddBackupConfirmation(ctx, pn)
+		require.NoError(t, err)
+		require.Equal(t, pn, jetKeeper.TopSyncPulse())
+	}
+
+	return f, cleanup
+}
+
+func (f *restoreFixture) restore(t *testing.T, upTo insolar.PulseNumber) (*store.BadgerDB, func()) {
+	ctx := inslogger.TestContext(t)
+
+	chain, err := executor.FindBackupChain(f.cfg, upTo)
+	require.NoError(t, err)
+	err = executor.VerifyBackupChain(chain, f.cfg)
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "restored-")
+	require.NoError(t, err)
+	db, err := store.NewBadgerDB(BadgerDefaultOptions(dir))
+	require.NoError(t, err)
+	cleanup := func() {
+		db.Stop(ctx)
+		os.RemoveAll(dir)
+	}
+
+	err = executor.RestoreBackup(ctx, db, chain, f.cfg, 1)
+	require.NoError(t, err)
+	return db, cleanup
+}
+
+func (f *restoreFixture) backupDir(pn insolar.PulseNumber) string {
+	return filepath.Join(f.cfg.TargetDirectory, fmt.Sprintf(f.cfg.DirNameTemplate, pn))
+}
+
+func TestRestoreBackup(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	f, cleanup := newRestoreFixture(t)
+	defer cleanup()
+
+	t.Run("latest pulse", func(t *testing.T) {
+		db, cleanup := f.restore(t, 0)
+		defer cleanup()
+
+		pulses := pulse.NewDB(db)
+		jetKeeper := executor.NewJetKeeper(jet.NewDBStore(db), db, pulses)
+		require.Equal(t, f.pulses[1], jetKeeper.TopSyncPulse())
+
+		records := object.NewRecordDB(db)
+		for _, pn := range f.pulses {
+			_, err := records.ForID(ctx, f.records[pn])
+			require.NoError(t, err)
+		}
+		_, err := records.ForID(ctx, f.records[f.head])
+		require.Equal(t, object.ErrNotFound, err)
+		_, err = pulses.ForPulseNumber(ctx, f.head)
+		require.Equal(t, pulse.ErrNotFound, err)
+	})
+
+	t.Run("previous pulse", func(t *testing.T) {
+		db, cleanup := f.restore(t, f.pulses[0])
+		defer cleanup()
+
+		jetKeeper := executor.NewJetKeeper(jet.NewDBStore(db), db, pulse.NewDB(db))
+		require.Equal(t, f.pulses[0], jetKeeper.TopSyncPulse())
+
+		records := object.NewRecordDB(db)
+		_, err := records.ForID(ctx, f.records[f.pulses[0]])
+		require.NoError(t, err)
+		_, err = records.ForID(ctx, f.records[f.pulses[1]])
+		require.Equal(t, object.ErrNotFound, err)
+	})
+}
+
+func TestFindBackupChain(t *testing.T) {
+	f, cleanup := newRestoreFixture(t)
+	defer cleanup()
+
+	chain, err := executor.FindBackupChain(f.cfg, 0)
+	require.NoError(t, err)
+	require.Len(t, chain, 2)
+	require.Equal(t, f.pulses[0], chain[0].Info.Pulse)
+	require.Equal(t, f.pulses[1], chain[1].Info.Pulse)
+
+	chain, err = executor.FindBackupChain(f.cfg, f.pulses[0])
+	require.NoError(t, err)
+	require.Len(t, chain, 1)
+
+	_, err = executor.FindBackupChain(f.cfg, f.pulses[0]+1)
+	require.Error(t, err)
+}
+
+func TestVerifyBackupChain(t *testing.T) {
+	t.Run("corrupted file", func(t *testing.T) {
+		f, cleanup := newRestoreFixture(t)
+		defer cleanup()
+
+		err := ioutil.WriteFile(filepath.Join(f.backupDir(f.pulses[1]), f.cfg.BackupFile), []byte("garbage"), 0600)
+		require.NoError(t, err)
+
+		chain, err := executor.FindBackupChain(f.cfg, 0)
+		require.NoError(t, err)
+		err = executor.VerifyBackupChain(chain, f.cfg)
+		require.Contains(t, err.Error(), "corrupted")
+	})
+
+	t.Run("version gap", func(t *testing.T) {
+		f, cleanup := newRestoreFixture(t)
+		defer cleanup()
+
+		chain, err := executor.FindBackupChain(f.cfg, 0)
+		require.NoError(t, err)
+
+		info := chain[1].Info
+		info.Since++
+		raw, err := json.Marshal(info)
+		require.NoError(t, err)
+		err = ioutil.WriteFile(filepath.Join(f.backupDir(f.pulses[1]), f.cfg.MetaInfoFile), raw, 0600)
+		require.NoError(t, err)
+
+		chain, err = executor.FindBackupChain(f.cfg, 0)
+		require.NoError(t, err)
+		err = executor.VerifyBackupChain(chain, f.cfg)
+		require.Contains(t, err.Error(), "starts from version")
+	})
+}
@@ -0,0 +1,486 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/stats"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/jet"
+	insolarPulse "github.com/insolar/insolar/insolar/pulse"
+	"github.com/insolar/insolar/insolar/store"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/drop"
+	"github.com/insolar/insolar/ledger/object"
+)
+
+// ViolationKind is a kind of inconsistency found in heavy storage.
+type ViolationKind string
+
+const (
+	// ViolationPulseGap means that pulses are not linked with each other.
+	ViolationPulseGap ViolationKind = "pulse-gap"
+	// ViolationMissingPosition means that there is a gap in record positions of the pulse.
+	ViolationMissingPosition ViolationKind = "missing-position"
+	// ViolationDanglingPosition means that record position points to not existing record.
+	ViolationDanglingPosition ViolationKind = "dangling-position"
+	// ViolationUnresolvedState means that Lifeline.LatestState points to not existing record.
+	ViolationUnresolvedState ViolationKind = "unresolved-latest-state"
+	// ViolationUnresolvedRequest means that Lifeline.LatestRequest points to not existing record.
+	ViolationUnresolvedRequest ViolationKind = "unresolved-latest-request"
+	// ViolationStaleLastKnownIndex means that last known pulse of the object is behind its finalized index.
+	ViolationStaleLastKnownIndex ViolationKind = "stale-last-known-index"
+	// ViolationDropOutsideTree means that drop's jet is not a leaf of the jet tree of the pulse.
+	ViolationDropOutsideTree ViolationKind = "drop-outside-jet-tree"
+	// ViolationMissingDrop means that finalized pulse has no drop for a jet.
+	ViolationMissingDrop ViolationKind = "missing-drop"
+	// ViolationMissingJets means that finalized pulse has no jets in jet keeper.
+	ViolationMissingJets ViolationKind = "missing-jets"
+	// ViolationNotConfirmedJet means that finalized pulse has a jet without hot or drop confirmation.
+	ViolationNotConfirmedJet ViolationKind = "not-confirmed-jet"
+)
+
+// Repairable returns true if the violation can be fixed by ConsistencyChecker.Repair.
+// Such violations are in indexes, that can be derived from records alone.
+func (k ViolationKind) Repairable() bool {
+	switch k {
+	case ViolationMissingPosition, ViolationDanglingPosition, ViolationStaleLastKnownIndex:
+		return true
+	default:
+		return false
+	}
+}
+
+// Violation describes single inconsistency found in heavy storage.
+type Violation struct {
+	Kind       ViolationKind       `json:"kind"`
+	Pulse      insolar.PulseNumber `json:"pulse"`
+	Key        string              `json:"key,omitempty"`
+	Message    string              `json:"message"`
+	Repairable bool                `json:"repairable"`
+}
+
+func newViolation(kind ViolationKind, pn insolar.PulseNumber, key string, format string, args ...interface{}) Violation {
+	return Violation{
+		Kind:       kind,
+		Pulse:      pn,
+		Key:        key,
+		Message:    fmt.Sprintf(format, args...),
+		Repairable: kind.Repairable(),
+	}
+}
+
+type pulseStorage interface {
+	insolarPulse.Accessor
+	insolarPulse.Calculator
+}
+
+// consistencyRetryInterval is a delay before online check of synced pulses is repeated after failure.
+const consistencyRetryInterval = 10 * time.Second
+
+// ConsistencyChecker verifies that heavy storage is internally consistent.
+// Only finalized data (up to top sync pulse) is checked.
+// It can be used offline on a stopped node's db or online, checking every new synced pulse.
+type ConsistencyChecker struct {
+	pulses    pulseStorage
+	records   *object.RecordDB
+	indexes   *object.IndexDB
+	drops     *drop.DB
+	jets      jet.Accessor
+	jetKeeper JetKeeper
+
+	once sync.Once
+	done chan struct{}
+}
+
+// NewConsistencyChecker creates new instance of ConsistencyChecker.
+func NewConsistencyChecker(
+	pulses pulseStorage,
+	records *object.RecordDB,
+	indexes *object.IndexDB,
+	drops *drop.DB,
+	jets jet.Accessor,
+	jetKeeper JetKeeper,
+) *ConsistencyChecker {
+	return &ConsistencyChecker{
+		pulses:    pulses,
+		records:   records,
+		indexes:   indexes,
+		drops:     drops,
+		jets:      jets,
+		jetKeeper: jetKeeper,
+		done:      make(chan struct{}),
+	}
+}
+
+// Check verifies pulses in provided range. Zero bounds mean the first pulse and top sync pulse respectively.
+// The upper bound is limited by top sync pulse.
+func (c *ConsistencyChecker) Check(ctx context.Context, from, to insolar.PulseNumber) ([]Violation, error) {
+	top := c.jetKeeper.TopSyncPulse()
+	if to == 0 || to > top {
+		to = top
+	}
+	if from == 0 {
+		from = insolar.GenesisPulse.PulseNumber
+	}
+
+	current, err := c.pulses.ForPulseNumber(ctx, from)
+	if err == insolarPulse.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch pulse %v", from)
+	}
+
+	var violations []Violation
+	for current.PulseNumber <= to {
+		found, err := c.checkPulse(ctx, current)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to check pulse %v", current.PulseNumber)
+		}
+		violations = append(violations, found...)
+
+		next, err := c.pulses.Forwards(ctx, current.PulseNumber, 1)
+		if err == insolarPulse.ErrNotFound {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch pulse after %v", current.PulseNumber)
+		}
+
+		// The first pulse after genesis is not linked to genesis pulse.
+		if next.PrevPulseNumber != current.PulseNumber && current.PulseNumber != insolar.GenesisPulse.PulseNumber {
+			violations = append(violations, newViolation(
+				ViolationPulseGap, next.PulseNumber, "",
+				"previous pulse is %v, but %v is stored before", next.PrevPulseNumber, current.PulseNumber,
+			))
+		}
+
+		current = next
+	}
+
+	return violations, nil
+}
+
+func (c *ConsistencyChecker) checkPulse(ctx context.Context, p insolar.Pulse) ([]Violation, error) {
+	var violations []Violation
+
+	found, err := c.checkPositions(ctx, p.PulseNumber)
+	if err != nil {
+		return nil, err
+	}
+	violations = append(violations, found...)
+
+	found, err = c.checkIndexes(ctx, p.PulseNumber)
+	if err != nil {
+		return nil, err
+	}
+	violations = append(violations, found...)
+
+	// There are no drops and jets for genesis pulse.
+	if p.PulseNumber == insolar.GenesisPulse.PulseNumber {
+		return violations, nil
+	}
+
+	found, err = c.checkJets(ctx, p.PulseNumber)
+	if err != nil {
+		return nil, err
+	}
+	violations = append(violations, found...)
+
+	return violations, nil
+}
+
+// checkPositions verifies that every record position of the pulse points to an existing record.
+func (c *ConsistencyChecker) checkPositions(ctx context.Context, pn insolar.PulseNumber) ([]Violation, error) {
+	last, err := c.records.LastKnownPosition(pn)
+	if err == object.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch last known position")
+	}
+
+	var violations []Violation
+	for position := uint32(1); position <= last; position++ {
+		key := fmt.Sprintf("%d", position)
+
+		id, err := c.records.AtPosition(pn, position)
+		if err == object.ErrNotFound {
+			violations = append(violations, newViolation(
+				ViolationMissingPosition, pn, key, "position is missing, last known position is %d", last,
+			))
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch position %d", position)
+		}
+
+		_, err = c.records.ForID(ctx, id)
+		if err == object.ErrNotFound {
+			violations = append(violations, newViolation(
+				ViolationDanglingPosition, pn, key, "record %s not found", id.DebugString(),
+			))
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch record %s", id.DebugString())
+		}
+	}
+	return violations, nil
+}
+
+// checkIndexes verifies that lifelines of the pulse point to existing records
+// and last known pulses of finalized objects are up to date.
+func (c *ConsistencyChecker) checkIndexes(ctx context.Context, pn insolar.PulseNumber) ([]Violation, error) {
+	indexes, err := c.indexes.ForPulseExact(ctx, pn)
+	if err == object.ErrIndexNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch indexes")
+	}
+
+	resolve := func(id *insolar.ID) (bool, error) {
+		if id == nil {
+			return true, nil
+		}
+		_, err := c.records.ForID(ctx, *id)
+		if err == object.ErrNotFound {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	var violations []Violation
+	for _, idx := range indexes {
+		key := idx.ObjID.String()
+
+		ok, err := resolve(idx.Lifeline.LatestState)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch latest state of %s", idx.ObjID.DebugString())
+		}
+		if !ok {
+			violations = append(violations, newViolation(
+				ViolationUnresolvedState, pn, key, "latest state %s not found", idx.Lifeline.LatestState.DebugString(),
+			))
+		}
+
+		ok, err = resolve(idx.Lifeline.LatestRequest)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch latest request of %s", idx.ObjID.DebugString())
+		}
+		if !ok {
+			violations = append(violations, newViolation(
+				ViolationUnresolvedRequest, pn, key, "latest request %s not found", idx.Lifeline.LatestRequest.DebugString(),
+			))
+		}
+
+		lastKnown, err := c.indexes.LastKnownPulse(ctx, idx.ObjID)
+		if err != nil && err != object.ErrIndexNotFound {
+			return nil, errors.Wrapf(err, "failed to fetch last known pulse of %s", idx.ObjID.DebugString())
+		}
+		if err == object.ErrIndexNotFound || lastKnown < pn {
+			violations = append(violations, newViolation(
+				ViolationStaleLastKnownIndex, pn, key, "last known pulse is behind the finalized index",
+			))
+		}
+	}
+	return violations, nil
+}
+
+// checkJets verifies that drops of the pulse match the jet tree and jet keeper has all the confirmations.
+func (c *ConsistencyChecker) checkJets(ctx context.Context, pn insolar.PulseNumber) ([]Violation, error) {
+	drops, err := c.drops.All(ctx, pn)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch drops")
+	}
+	dropJets := make(map[insolar.JetID]struct{}, len(drops))
+	for _, d := range drops {
+		dropJets[d.JetID] = struct{}{}
+	}
+
+	leaves := c.jets.All(ctx, pn)
+	treeJets := make(map[insolar.JetID]struct{}, len(leaves))
+	for _, id := range leaves {
+		treeJets[id] = struct{}{}
+	}
+
+	var violations []Violation
+	for _, d := range drops {
+		if _, ok := treeJets[d.JetID]; !ok {
+			violations = append(violations, newViolation(
+				ViolationDropOutsideTree, pn, d.JetID.DebugString(), "drop's jet is not in the jet tree",
+			))
+		}
+	}
+
+	infos, err := c.jetKeeper.Confirmations(ctx, pn)
+	if err == store.ErrNotFound {
+		violations = append(violations, newViolation(
+			ViolationMissingJets, pn, "", "finalized pulse has no jets",
+		))
+		return violations, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	expected := make(map[insolar.JetID]struct{}, len(leaves)+len(infos))
+	for _, id := range leaves {
+		expected[id] = struct{}{}
+	}
+	for _, info := range infos {
+		expected[info.JetID] = struct{}{}
+		if !info.isConfirmed(ctx, false) {
+			violations = append(violations, newViolation(
+				ViolationNotConfirmedJet, pn, info.JetID.DebugString(),
+				"jet is not confirmed: hot=%s drop=%v", insolar.JetIDCollection(info.HotConfirmed).DebugString(), info.DropConfirmed,
+			))
+		}
+	}
+
+	for id := range expected {
+		if _, ok := dropJets[id]; !ok {
+			violations = append(violations, newViolation(
+				ViolationMissingDrop, pn, id.DebugString(), "finalized pulse has no drop for the jet",
+			))
+		}
+	}
+
+	return violations, nil
+}
+
+// Repair fixes repairable violations by rebuilding record positions and last known pulses of indexes.
+func (c *ConsistencyChecker) Repair(ctx context.Context, violations []Violation) error {
+	logger := inslogger.FromContext(ctx)
+
+	positions := map[insolar.PulseNumber]struct{}{}
+	var staleFrom insolar.PulseNumber
+	for _, v := range violations {
+		switch v.Kind {
+		case ViolationMissingPosition, ViolationDanglingPosition:
+			positions[v.Pulse] = struct{}{}
+		case ViolationStaleLastKnownIndex:
+			if staleFrom == 0 || v.Pulse < staleFrom {
+				staleFrom = v.Pulse
+			}
+		}
+	}
+
+	for pn := range positions {
+		logger.Infof("ConsistencyChecker. Rebuilding record positions. Pulse: %v", pn)
+		err := c.records.RebuildPositions(ctx, pn)
+		if err != nil {
+			return errors.Wrapf(err, "failed to rebuild record positions for pulse %v", pn)
+		}
+	}
+
+	if staleFrom == 0 {
+		return nil
+	}
+
+	// Last known pulses are updated in order of sync, so the latest finalized bucket wins.
+	top := c.jetKeeper.TopSyncPulse()
+	for pn := staleFrom; pn <= top; {
+		logger.Infof("ConsistencyChecker. Updating last known pulse of indexes. Pulse: %v", pn)
+		err := c.indexes.UpdateLastKnownPulse(ctx, pn)
+		if err != nil {
+			return errors.Wrapf(err, "failed to update last known pulse for pulse %v", pn)
+		}
+
+		next, err := c.pulses.Forwards(ctx, pn, 1)
+		if err == insolarPulse.ErrNotFound {
+			break
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to fetch pulse after %v", pn)
+		}
+		pn = next.PulseNumber
+	}
+	return nil
+}
+
+// Start runs online checking of every new synced pulse in background.
+func (c *ConsistencyChecker) Start(ctx context.Context) {
+	go c.watch(ctx)
+}
+
+// Stop stops online checking.
+func (c *ConsistencyChecker) Stop() {
+	c.once.Do(func() {
+		close(c.done)
+	})
+}
+
+func (c *ConsistencyChecker) watch(ctx context.Context) {
+	logger := inslogger.FromContext(ctx)
+	checked := c.jetKeeper.TopSyncPulse()
+
+	for {
+		// Subscribe before reading top sync pulse to not miss the change.
+		changed := c.jetKeeper.TopSyncChanged()
+		top := c.jetKeeper.TopSyncPulse()
+
+		// Failed range is checked again, so no pulse is left unchecked.
+		var retry <-chan time.Time
+		if top > checked {
+			if err := c.checkSynced(ctx, checked, top); err != nil {
+				logger.Error(err)
+				retry = time.After(consistencyRetryInterval)
+			} else {
+				checked = top
+			}
+		}
+
+		select {
+		case <-changed:
+		case <-retry:
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// checkSynced checks pulses after checked up to top and logs found violations.
+func (c *ConsistencyChecker) checkSynced(ctx context.Context, checked, top insolar.PulseNumber) error {
+	logger := inslogger.FromContext(ctx)
+
+	next, err := c.pulses.Forwards(ctx, checked, 1)
+	if err != nil {
+		return errors.Wrapf(err, "ConsistencyChecker. Failed to fetch pulse after %v", checked)
+	}
+	violations, err := c.Check(ctx, next.PulseNumber, top)
+	if err != nil {
+		return errors.Wrap(err, "ConsistencyChecker. Check failed")
+	}
+	for _, v := range violations {
+		logger.WithFields(map[string]interface{}{
+			"kind":  v.Kind,
+			"pulse": v.Pulse,
+			"key":   v.Key,
+		}).Error("ConsistencyChecker. Violation found: ", v.Message)
+	}
+	stats.Record(ctx, statConsistencyViolations.M(int64(len(violations))))
+	return nil
+}
@@ -0,0 +1,241 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package executor_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/gen"
+	"github.com/insolar/insolar/insolar/jet"
+	"github.com/insolar/insolar/insolar/pulse"
+	"github.com/insolar/insolar/insolar/record"
+	"github.com/insolar/insolar/insolar/store"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/drop"
+	"github.com/insolar/insolar/ledger/heavy/executor"
+	"github.com/insolar/insolar/ledger/object"
+)
+
+type rawKey struct {
+	scope store.Scope
+	id    []byte
+}
+
+func (k rawKey) Scope() store.Scope {
+	return k.scope
+}
+
+func (k rawKey) ID() []byte {
+	return k.id
+}
+
+type checkerFixture struct {
+	db      *store.BadgerDB
+	checker *executor.ConsistencyChecker
+
+	pulse    insolar.PulseNumber
+	objID    insolar.ID
+	stateID  insolar.ID
+	requests []insolar.ID
+}
+
+type checkerDamage struct {
+	skipDrop          bool
+	skipLastKnown     bool
+	missingState      bool
+	deleteRequestData bool
+}
+
+// newCheckerFixture stores a synced pulse with an object, its state, requests and a drop.
+func newCheckerFixture(t *testing.T, damage checkerDamage) (*checkerFixture, func()) {
+	ctx := inslogger.TestContext(t)
+
+	tmpdir, err := ioutil.TempDir("", "bdb-test-")
+	require.NoError(t, err)
+	db, err := store.NewBadgerDB(BadgerDefaultOptions(tmpdir))
+	require.NoError(t, err)
+	cleanup := func() {
+		db.Stop(ctx)
+		os.RemoveAll(tmpdir)
+	}
+
+	pulses := pulse.NewDB(db)
+	records := object.NewRecordDB(db)
+	indexes := object.NewIndexDB(db, records)
+	drops := drop.NewDB(db)
+	jets := jet.NewDBStore(db)
+	jetKeeper := executor.NewJetKeeper(jets, db, pulses)
+
+	f := &checkerFixture{
+		db:      db,
+		pulse:   insolar.GenesisPulse.PulseNumber + 10,
+		objID:   gen.ID(),
+		checker: executor.NewConsistencyChecker(pulses, records, indexes, drops, jets, jetKeeper),
+	}
+
+	err = pulses.Append(ctx, insolar.Pulse{PulseNumber: insolar.GenesisPulse.PulseNumber})
+	require.NoError(t, err)
+	err = pulses.Append(ctx, insolar.Pulse{PulseNumber: f.pulse, PrevPulseNumber: insolar.GenesisPulse.PulseNumber})
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		reqID := gen.IDWithPulse(f.pulse)
+		err = records.Set(ctx, record.Material{ID: reqID, ObjectID: f.objID, Virtual: record.Wrap(&record.IncomingRequest{})})
+		require.NoError(t, err)
+		f.requests = append(f.requests, reqID)
+	}
+
+	f.stateID = gen.IDWithPulse(f.pulse)
+	if !damage.missingState {
+		err = records.Set(ctx, record.Material{ID: f.stateID, ObjectID: f.objID, Virtual: record.Wrap(&record.Activate{})})
+		require.NoError(t, err)
+	}
+
+	err = indexes.SetIndex(ctx, f.pulse, record.Index{
+		ObjID: f.objID,
+		Lifeline: record.Lifeline{
+			LatestState:   &f.stateID,
+			LatestRequest: &f.requests[1],
+		},
+	})
+	require.NoError(t, err)
+
+	err = jets.Update(ctx, f.pulse, true, insolar.ZeroJetID)
+	require.NoError(t, err)
+	if !damage.skipDrop {
+		err = drops.Set(ctx, drop.Drop{Pulse: f.pulse, JetID: insolar.ZeroJetID})
+		require.NoError(t, err)
+	}
+
+	err = jetKeeper.AddHotConfirmation(ctx, f.pulse, insolar.ZeroJetID, false)
+	require.NoError(t, err)
+	err = jetKeeper.AddDropConfirmation(ctx, f.pulse, insolar.ZeroJetID, false)
+	require.NoError(t, err)
+	err = jetKeeper.AddBackupConfirmation(ctx, f.pulse)
+	require.NoError(t, err)
+	require.Equal(t, f.pulse, jetKeeper.TopSyncPulse())
+
+	if !damage.skipLastKnown {
+		err = indexes.UpdateLastKnownPulse(ctx, f.pulse)
+		require.NoError(t, err)
+	}
+
+	if damage.deleteRequestData {
+		err = db.Delete(rawKey{scope: store.ScopeRecord, id: f.requests[0].AsBytes()})
+		require.NoError(t, err)
+	}
+
+	return f, cleanup
+}
+
+func violationKinds(violations []executor.Violation) []executor.ViolationKind {
+	var kinds []executor.ViolationKind
+	for _, v := range violations {
+		kinds = append(kinds, v.Kind)
+	}
+	return kinds
+}
+
+func TestConsistencyChecker_Check(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+
+	t.Run("consistent db", func(t *testing.T) {
+		f, cleanup := newCheckerFixture(t, checkerDamage{})
+		defer cleanup()
+
+		violations, err := f.checker.Check(ctx, 0, 0)
+
+		require.NoError(t, err)
+		require.Empty(t, violations)
+	})
+
+	t.Run("missing drop", func(t *testing.T) {
+		f, cleanup := newCheckerFixture(t, checkerDamage{skipDrop: true})
+		defer cleanup()
+
+		violations, err := f.checker.Check(ctx, 0, 0)
+
+		require.NoError(t, err)
+		require.Equal(t, []executor.ViolationKind{executor.ViolationMissingDrop}, violationKinds(violations))
+		require.Equal(t, f.pulse, violations[0].Pulse)
+		require.False(t, violations[0].Repairable)
+	})
+
+	t.Run("unresolved latest state", func(t *testing.T) {
+		f, cleanup := newCheckerFixture(t, checkerDamage{missingState: true})
+		defer cleanup()
+
+		violations, err := f.checker.Check(ctx, 0, 0)
+
+		require.NoError(t, err)
+		require.Equal(t, []executor.ViolationKind{executor.ViolationUnresolvedState}, violationKinds(violations))
+		require.Equal(t, f.objID.String(), violations[0].Key)
+	})
+
+	t.Run("pulse range excludes violations", func(t *testing.T) {
+		f, cleanup := newCheckerFixture(t, checkerDamage{skipDrop: true})
+		defer cleanup()
+
+		violations, err := f.checker.Check(ctx, 0, insolar.GenesisPulse.PulseNumber)
+
+		require.NoError(t, err)
+		require.Empty(t, violations)
+	})
+}
+
+func TestConsistencyChecker_Repair(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+
+	t.Run("stale last known index", func(t *testing.T) {
+		f, cleanup := newCheckerFixture(t, checkerDamage{skipLastKnown: true})
+		defer cleanup()
+
+		violations, err := f.checker.Check(ctx, 0, 0)
+		require.NoError(t, err)
+		require.Equal(t, []executor.ViolationKind{executor.ViolationStaleLastKnownIndex}, violationKinds(violations))
+		require.True(t, violations[0].Repairable)
+
+		err = f.checker.Repair(ctx, violations)
+		require.NoError(t, err)
+
+		violations, err = f.checker.Check(ctx, 0, 0)
+		require.NoError(t, err)
+		require.Empty(t, violations)
+	})
+
+	t.Run("dangling record position", func(t *testing.T) {
+		f, cleanup := newCheckerFixture(t, checkerDamage{deleteRequestData: true})
+		defer cleanup()
+
+		violations, err := f.checker.Check(ctx, 0, 0)
+		require.NoError(t, err)
+		require.Equal(t, []executor.ViolationKind{executor.ViolationDanglingPosition}, violationKinds(violations))
+		require.Equal(t, "1", violations[0].Key)
+
+		err = f.checker.Repair(ctx, violations)
+		require.NoError(t, err)
+
+		violations, err = f.checker.Check(ctx, 0, 0)
+		require.NoError(t, err)
+		require.Empty(t, violations)
+	})
+}
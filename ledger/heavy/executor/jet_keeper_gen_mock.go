@@ -35,6 +35,12 @@ type JetKeeperMock struct {
 	beforeAddHotConfirmationCounter uint64
 	AddHotConfirmationMock          mJetKeeperMockAddHotConfirmation
 
+	funcConfirmations          func(ctx context.Context, pn insolar.PulseNumber) (ja1 []JetInfo, err error)
+	inspectFuncConfirmations   func(ctx context.Context, pn insolar.PulseNumber)
+	afterConfirmationsCounter  uint64
+	beforeConfirmationsCounter uint64
+	ConfirmationsMock          mJetKeeperMockConfirmations
+
 	funcHasAllJetConfirms          func(ctx context.Context, pn insolar.PulseNumber) (b1 bool)
 	inspectFuncHasAllJetConfirms   func(ctx context.Context, pn insolar.PulseNumber)
 	afterHasAllJetConfirmsCounter  uint64
@@ -47,6 +53,13 @@ type JetKeeperMock struct {
 	beforeStorageCounter uint64
 	StorageMock          mJetKeeperMockStorage
 
+	funcTopSyncChanged func() (ch1 <-chan struct {
+	})
+	inspectFuncTopSyncChanged   func()
+	afterTopSyncChangedCounter  uint64
+	beforeTopSyncChangedCounter uint64
+	TopSyncChangedMock          mJetKeeperMockTopSyncChanged
+
 	funcTopSyncPulse          func() (p1 insolar.PulseNumber)
 	inspectFuncTopSyncPulse   func()
 	afterTopSyncPulseCounter  uint64
@@ -70,11 +83,16 @@ func NewJetKeeperMock(t minimock.Tester) *JetKeeperMock {
 	m.AddHotConfirmationMock = mJetKeeperMockAddHotConfirmation{mock: m}
 	m.AddHotConfirmationMock.callArgs = []*JetKeeperMockAddHotConfirmationParams{}
 
+	m.ConfirmationsMock = mJetKeeperMockConfirmations{mock: m}
+	m.ConfirmationsMock.callArgs = []*JetKeeperMockConfirmationsParams{}
+
 	m.HasAllJetConfirmsMock = mJetKeeperMockHasAllJetConfirms{mock: m}
 	m.HasAllJetConfirmsMock.callArgs = []*JetKeeperMockHasAllJetConfirmsParams{}
 
 	m.StorageMock = mJetKeeperMockStorage{mock: m}
 
+	m.TopSyncChangedMock = mJetKeeperMockTopSyncChanged{mock: m}
+
 	m.TopSyncPulseMock = mJetKeeperMockTopSyncPulse{mock: m}
 
 	return m
@@ -732,6 +750,223 @@ func (m *JetKeeperMock) MinimockAddHotConfirmationInspect() {
 	}
 }
 
+type mJetKeeperMockConfirmations struct {
+	mock               *JetKeeperMock
+	defaultExpectation *JetKeeperMockConfirmationsExpectation
+	expectations       []*JetKeeperMockConfirmationsExpectation
+
+	callArgs []*JetKeeperMockConfirmationsParams
+	mutex    sync.RWMutex
+}
+
+// JetKeeperMockConfirmationsExpectation specifies expectation struct of the JetKeeper.Confirmations
+type JetKeeperMockConfirmationsExpectation struct {
+	mock    *JetKeeperMock
+	params  *JetKeeperMockConfirmationsParams
+	results *JetKeeperMockConfirmationsResults
+	Counter uint64
+}
+
+// JetKeeperMockConfirmationsParams contains parameters of the JetKeeper.Confirmations
+type JetKeeperMockConfirmationsParams struct {
+	ctx context.Context
+	pn  insolar.PulseNumber
+}
+
+// JetKeeperMockConfirmationsResults contains results of the JetKeeper.Confirmations
+type JetKeeperMockConfirmationsResults struct {
+	ja1 []JetInfo
+	err error
+}
+
+// Expect sets up expected params for JetKeeper.Confirmations
+func (mmConfirmations *mJetKeeperMockConfirmations) Expect(ctx context.Context, pn insolar.PulseNumber) *mJetKeeperMockConfirmations {
+	if mmConfirmations.mock.funcConfirmations != nil {
+		mmConfirmations.mock.t.Fatalf("JetKeeperMock.Confirmations mock is already set by Set")
+	}
+
+	if mmConfirmations.defaultExpectation == nil {
+		mmConfirmations.defaultExpectation = &JetKeeperMockConfirmationsExpectation{}
+	}
+
+	mmConfirmations.defaultExpectation.params = &JetKeeperMockConfirmationsParams{ctx, pn}
+	for _, e := range mmConfirmations.expectations {
+		if minimock.Equal(e.params, mmConfirmations.defaultExpectation.params) {
+			mmConfirmations.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmConfirmations.defaultExpectation.params)
+		}
+	}
+
+	return mmConfirmations
+}
+
+// Inspect accepts an inspector function that has same arguments as the JetKeeper.Confirmations
+func (mmConfirmations *mJetKeeperMockConfirmations) Inspect(f func(ctx context.Context, pn insolar.PulseNumber)) *mJetKeeperMockConfirmations {
+	if mmConfirmations.mock.inspectFuncConfirmations != nil {
+		mmConfirmations.mock.t.Fatalf("Inspect function is already set for JetKeeperMock.Confirmations")
+	}
+
+	mmConfirmations.mock.inspectFuncConfirmations = f
+
+	return mmConfirmations
+}
+
+// Return sets up results that will be returned by JetKeeper.Confirmations
+func (mmConfirmations *mJetKeeperMockConfirmations) Return(ja1 []JetInfo, err error) *JetKeeperMock {
+	if mmConfirmations.mock.funcConfirmations != nil {
+		mmConfirmations.mock.t.Fatalf("JetKeeperMock.Confirmations mock is already set by Set")
+	}
+
+	if mmConfirmations.defaultExpectation == nil {
+		mmConfirmations.defaultExpectation = &JetKeeperMockConfirmationsExpectation{mock: mmConfirmations.mock}
+	}
+	mmConfirmations.defaultExpectation.results = &JetKeeperMockConfirmationsResults{ja1, err}
+	return mmConfirmations.mock
+}
+
+//Set uses given function f to mock the JetKeeper.Confirmations method
+func (mmConfirmations *mJetKeeperMockConfirmations) Set(f func(ctx context.Context, pn insolar.PulseNumber) (ja1 []JetInfo, err error)) *JetKeeperMock {
+	if mmConfirmations.defaultExpectation != nil {
+		mmConfirmations.mock.t.Fatalf("Default expectation is already set for the JetKeeper.Confirmations method")
+	}
+
+	if len(mmConfirmations.expectations) > 0 {
+		mmConfirmations.mock.t.Fatalf("Some expectations are already set for the JetKeeper.Confirmations method")
+	}
+
+	mmConfirmations.mock.funcConfirmations = f
+	return mmConfirmations.mock
+}
+
+// When sets expectation for the JetKeeper.Confirmations which will trigger the result defined by the following
+// Then helper
+func (mmConfirmations *mJetKeeperMockConfirmations) When(ctx context.Context, pn insolar.PulseNumber) *JetKeeperMockConfirmationsExpectation {
+	if mmConfirmations.mock.funcConfirmations != nil {
+		mmConfirmations.mock.t.Fatalf("JetKeeperMock.Confirmations mock is already set by Set")
+	}
+
+	expectation := &JetKeeperMockConfirmationsExpectation{
+		mock:   mmConfirmations.mock,
+		params: &JetKeeperMockConfirmationsParams{ctx, pn},
+	}
+	mmConfirmations.expectations = append(mmConfirmations.expectations, expectation)
+	return expectation
+}
+
+// Then sets up JetKeeper.Confirmations return parameters for the expectation previously defined by the When method
+func (e *JetKeeperMockConfirmationsExpectation) Then(ja1 []JetInfo, err error) *JetKeeperMock {
+	e.results = &JetKeeperMockConfirmationsResults{ja1, err}
+	return e.mock
+}
+
+// Confirmations implements JetKeeper
+func (mmConfirmations *JetKeeperMock) Confirmations(ctx context.Context, pn insolar.PulseNumber) (ja1 []JetInfo, err error) {
+	mm_atomic.AddUint64(&mmConfirmations.beforeConfirmationsCounter, 1)
+	defer mm_atomic.AddUint64(&mmConfirmations.afterConfirmationsCounter, 1)
+
+	if mmConfirmations.inspectFuncConfirmations != nil {
+		mmConfirmations.inspectFuncConfirmations(ctx, pn)
+	}
+
+	params := &JetKeeperMockConfirmationsParams{ctx, pn}
+
+	// Record call args
+	mmConfirmations.ConfirmationsMock.mutex.Lock()
+	mmConfirmations.ConfirmationsMock.callArgs = append(mmConfirmations.ConfirmationsMock.callArgs, params)
+	mmConfirmations.ConfirmationsMock.mutex.Unlock()
+
+	for _, e := range mmConfirmations.ConfirmationsMock.expectations {
+		if minimock.Equal(e.params, params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.ja1, e.results.err
+		}
+	}
+
+	if mmConfirmations.ConfirmationsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmConfirmations.ConfirmationsMock.defaultExpectation.Counter, 1)
+		want := mmConfirmations.ConfirmationsMock.defaultExpectation.params
+		got := JetKeeperMockConfirmationsParams{ctx, pn}
+		if want != nil && !minimock.Equal(*want, got) {
+			mmConfirmations.t.Errorf("JetKeeperMock.Confirmations got unexpected parameters, want: %#v, got: %#v%s\n", *want, got, minimock.Diff(*want, got))
+		}
+
+		results := mmConfirmations.ConfirmationsMock.defaultExpectation.results
+		if results == nil {
+			mmConfirmations.t.Fatal("No results are set for the JetKeeperMock.Confirmations")
+		}
+		return (*results).ja1, (*results).err
+	}
+	if mmConfirmations.funcConfirmations != nil {
+		return mmConfirmations.funcConfirmations(ctx, pn)
+	}
+	mmConfirmations.t.Fatalf("Unexpected call to JetKeeperMock.Confirmations. %v %v", ctx, pn)
+	return
+}
+
+// ConfirmationsAfterCounter returns a count of finished JetKeeperMock.Confirmations invocations
+func (mmConfirmations *JetKeeperMock) ConfirmationsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmConfirmations.afterConfirmationsCounter)
+}
+
+// ConfirmationsBeforeCounter returns a count of JetKeeperMock.Confirmations invocations
+func (mmConfirmations *JetKeeperMock) ConfirmationsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmConfirmations.beforeConfirmationsCounter)
+}
+
+// Calls returns a list of arguments used in each call to JetKeeperMock.Confirmations.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmConfirmations *mJetKeeperMockConfirmations) Calls() []*JetKeeperMockConfirmationsParams {
+	mmConfirmations.mutex.RLock()
+
+	argCopy := make([]*JetKeeperMockConfirmationsParams, len(mmConfirmations.callArgs))
+	copy(argCopy, mmConfirmations.callArgs)
+
+	mmConfirmations.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockConfirmationsDone returns true if the count of the Confirmations invocations corresponds
+// the number of defined expectations
+func (m *JetKeeperMock) MinimockConfirmationsDone() bool {
+	for _, e := range m.ConfirmationsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ConfirmationsMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterConfirmationsCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcConfirmations != nil && mm_atomic.LoadUint64(&m.afterConfirmationsCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockConfirmationsInspect logs each unmet expectation
+func (m *JetKeeperMock) MinimockConfirmationsInspect() {
+	for _, e := range m.ConfirmationsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to JetKeeperMock.Confirmations with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ConfirmationsMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterConfirmationsCounter) < 1 {
+		if m.ConfirmationsMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to JetKeeperMock.Confirmations")
+		} else {
+			m.t.Errorf("Expected call to JetKeeperMock.Confirmations with params: %#v", *m.ConfirmationsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcConfirmations != nil && mm_atomic.LoadUint64(&m.afterConfirmationsCounter) < 1 {
+		m.t.Error("Expected call to JetKeeperMock.Confirmations")
+	}
+}
+
 type mJetKeeperMockHasAllJetConfirms struct {
 	mock               *JetKeeperMock
 	defaultExpectation *JetKeeperMockHasAllJetConfirmsExpectation
@@ -1091,6 +1326,153 @@ func (m *JetKeeperMock) MinimockStorageInspect() {
 	}
 }
 
+type mJetKeeperMockTopSyncChanged struct {
+	mock               *JetKeeperMock
+	defaultExpectation *JetKeeperMockTopSyncChangedExpectation
+	expectations       []*JetKeeperMockTopSyncChangedExpectation
+}
+
+// JetKeeperMockTopSyncChangedExpectation specifies expectation struct of the JetKeeper.TopSyncChanged
+type JetKeeperMockTopSyncChangedExpectation struct {
+	mock *JetKeeperMock
+
+	results *JetKeeperMockTopSyncChangedResults
+	Counter uint64
+}
+
+// JetKeeperMockTopSyncChangedResults contains results of the JetKeeper.TopSyncChanged
+type JetKeeperMockTopSyncChangedResults struct {
+	ch1 <-chan struct {
+	}
+}
+
+// Expect sets up expected params for JetKeeper.TopSyncChanged
+func (mmTopSyncChanged *mJetKeeperMockTopSyncChanged) Expect() *mJetKeeperMockTopSyncChanged {
+	if mmTopSyncChanged.mock.funcTopSyncChanged != nil {
+		mmTopSyncChanged.mock.t.Fatalf("JetKeeperMock.TopSyncChanged mock is already set by Set")
+	}
+
+	if mmTopSyncChanged.defaultExpectation == nil {
+		mmTopSyncChanged.defaultExpectation = &JetKeeperMockTopSyncChangedExpectation{}
+	}
+
+	return mmTopSyncChanged
+}
+
+// Inspect accepts an inspector function that has same arguments as the JetKeeper.TopSyncChanged
+func (mmTopSyncChanged *mJetKeeperMockTopSyncChanged) Inspect(f func()) *mJetKeeperMockTopSyncChanged {
+	if mmTopSyncChanged.mock.inspectFuncTopSyncChanged != nil {
+		mmTopSyncChanged.mock.t.Fatalf("Inspect function is already set for JetKeeperMock.TopSyncChanged")
+	}
+
+	mmTopSyncChanged.mock.inspectFuncTopSyncChanged = f
+
+	return mmTopSyncChanged
+}
+
+// Return sets up results that will be returned by JetKeeper.TopSyncChanged
+func (mmTopSyncChanged *mJetKeeperMockTopSyncChanged) Return(ch1 <-chan struct {
+}) *JetKeeperMock {
+	if mmTopSyncChanged.mock.funcTopSyncChanged != nil {
+		mmTopSyncChanged.mock.t.Fatalf("JetKeeperMock.TopSyncChanged mock is already set by Set")
+	}
+
+	if mmTopSyncChanged.defaultExpectation == nil {
+		mmTopSyncChanged.defaultExpectation = &JetKeeperMockTopSyncChangedExpectation{mock: mmTopSyncChanged.mock}
+	}
+	mmTopSyncChanged.defaultExpectation.results = &JetKeeperMockTopSyncChangedResults{ch1}
+	return mmTopSyncChanged.mock
+}
+
+//Set uses given function f to mock the JetKeeper.TopSyncChanged method
+func (mmTopSyncChanged *mJetKeeperMockTopSyncChanged) Set(f func() (ch1 <-chan struct {
+})) *JetKeeperMock {
+	if mmTopSyncChanged.defaultExpectation != nil {
+		mmTopSyncChanged.mock.t.Fatalf("Default expectation is already set for the JetKeeper.TopSyncChanged method")
+	}
+
+	if len(mmTopSyncChanged.expectations) > 0 {
+		mmTopSyncChanged.mock.t.Fatalf("Some expectations are already set for the JetKeeper.TopSyncChanged method")
+	}
+
+	mmTopSyncChanged.mock.funcTopSyncChanged = f
+	return mmTopSyncChanged.mock
+}
+
+// TopSyncChanged implements JetKeeper
+func (mmTopSyncChanged *JetKeeperMock) TopSyncChanged() (ch1 <-chan struct {
+}) {
+	mm_atomic.AddUint64(&mmTopSyncChanged.beforeTopSyncChangedCounter, 1)
+	defer mm_atomic.AddUint64(&mmTopSyncChanged.afterTopSyncChangedCounter, 1)
+
+	if mmTopSyncChanged.inspectFuncTopSyncChanged != nil {
+		mmTopSyncChanged.inspectFuncTopSyncChanged()
+	}
+
+	if mmTopSyncChanged.TopSyncChangedMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmTopSyncChanged.TopSyncChangedMock.defaultExpectation.Counter, 1)
+
+		results := mmTopSyncChanged.TopSyncChangedMock.defaultExpectation.results
+		if results == nil {
+			mmTopSyncChanged.t.Fatal("No results are set for the JetKeeperMock.TopSyncChanged")
+		}
+		return (*results).ch1
+	}
+	if mmTopSyncChanged.funcTopSyncChanged != nil {
+		return mmTopSyncChanged.funcTopSyncChanged()
+	}
+	mmTopSyncChanged.t.Fatalf("Unexpected call to JetKeeperMock.TopSyncChanged.")
+	return
+}
+
+// TopSyncChangedAfterCounter returns a count of finished JetKeeperMock.TopSyncChanged invocations
+func (mmTopSyncChanged *JetKeeperMock) TopSyncChangedAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmTopSyncChanged.afterTopSyncChangedCounter)
+}
+
+// TopSyncChangedBeforeCounter returns a count of JetKeeperMock.TopSyncChanged invocations
+func (mmTopSyncChanged *JetKeeperMock) TopSyncChangedBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmTopSyncChanged.beforeTopSyncChangedCounter)
+}
+
+// MinimockTopSyncChangedDone returns true if the count of the TopSyncChanged invocations corresponds
+// the number of defined expectations
+func (m *JetKeeperMock) MinimockTopSyncChangedDone() bool {
+	for _, e := range m.TopSyncChangedMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.TopSyncChangedMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterTopSyncChangedCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcTopSyncChanged != nil && mm_atomic.LoadUint64(&m.afterTopSyncChangedCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockTopSyncChangedInspect logs each unmet expectation
+func (m *JetKeeperMock) MinimockTopSyncChangedInspect() {
+	for _, e := range m.TopSyncChangedMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Error("Expected call to JetKeeperMock.TopSyncChanged")
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.TopSyncChangedMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterTopSyncChangedCounter) < 1 {
+		m.t.Error("Expected call to JetKeeperMock.TopSyncChanged")
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcTopSyncChanged != nil && mm_atomic.LoadUint64(&m.afterTopSyncChangedCounter) < 1 {
+		m.t.Error("Expected call to JetKeeperMock.TopSyncChanged")
+	}
+}
+
 type mJetKeeperMockTopSyncPulse struct {
 	mock               *JetKeeperMock
 	defaultExpectation *JetKeeperMockTopSyncPulseExpectation
@@ -1243,10 +1625,14 @@ func (m *JetKeeperMock) MinimockFinish() {
 
 		m.MinimockAddHotConfirmationInspect()
 
+		m.MinimockConfirmationsInspect()
+
 		m.MinimockHasAllJetConfirmsInspect()
 
 		m.MinimockStorageInspect()
 
+		m.MinimockTopSyncChangedInspect()
+
 		m.MinimockTopSyncPulseInspect()
 		m.t.FailNow()
 	}
@@ -1274,7 +1660,9 @@ func (m *JetKeeperMock) minimockDone() bool {
 		m.MinimockAddBackupConfirmationDone() &&
 		m.MinimockAddDropConfirmationDone() &&
 		m.MinimockAddHotConfirmationDone() &&
+		m.MinimockConfirmationsDone() &&
 		m.MinimockHasAllJetConfirmsDone() &&
 		m.MinimockStorageDone() &&
+		m.MinimockTopSyncChangedDone() &&
 		m.MinimockTopSyncPulseDone()
 }
@@ -43,17 +43,23 @@ type JetKeeper interface {
 	AddBackupConfirmation(ctx context.Context, pn insolar.PulseNumber) error
 	// TopSyncPulse provides access to highest synced (replicated) pulse.
 	TopSyncPulse() insolar.PulseNumber
+	// TopSyncChanged returns a channel that will be closed when top synced pulse moves forward.
+	TopSyncChanged() <-chan struct{}
 	// HasAllJetConfirms says if given pulse has drop and hot confirms. Ignore backups
 	HasAllJetConfirms(ctx context.Context, pn insolar.PulseNumber) bool
 	// Storage returns jets storage
 	Storage() jet.Storage
+	// Confirmations returns jets of the pulse with their hot, drop and backup confirmations.
+	// Returns store.ErrNotFound if there are no jets for the pulse.
+	Confirmations(ctx context.Context, pn insolar.PulseNumber) ([]JetInfo, error)
 }
 
 func NewJetKeeper(jets jet.Storage, db store.DB, pulses insolarPulse.Calculator) *DBJetKeeper {
 	return &DBJetKeeper{
-		jetTrees: jets,
-		db:       db,
-		pulses:   pulses,
+		jetTrees:    jets,
+		db:          db,
+		pulses:      pulses,
+		syncChanged: make(chan struct{}),
 	}
 }
 
@@ -62,6 +68,8 @@ type DBJetKeeper struct {
 	jetTrees jet.Storage
 	pulses   insolarPulse.Calculator
 	db       store.DB
+
+	syncChanged chan struct{}
 }
 
 func (jk *DBJetKeeper) Storage() jet.Storage {
@@ -154,6 +162,14 @@ func (jk *DBJetKeeper) HasAllJetConfirms(ctx context.Context, pulse insolar.Puls
 	return jk.checkPulseConsistency(ctx, pulse, false)
 }
 
+// Confirmations returns jets of the pulse with their hot, drop and backup confirmations.
+func (jk *DBJetKeeper) Confirmations(ctx context.Context, pn insolar.PulseNumber) ([]JetInfo, error) {
+	jk.lock.RLock()
+	defer jk.lock.RUnlock()
+
+	return jk.get(pn)
+}
+
 // TopSyncPulse provides access to highest synced (replicated) pulse.
 func (jk *DBJetKeeper) TopSyncPulse() insolar.PulseNumber {
 	jk.lock.RLock()
@@ -162,6 +178,15 @@ func (jk *DBJetKeeper) TopSyncPulse() insolar.PulseNumber {
 	return jk.topSyncPulse()
 }
 
+// TopSyncChanged returns a channel that will be closed when top synced pulse moves forward.
+// Subscribe before reading TopSyncPulse to not miss the change.
+func (jk *DBJetKeeper) TopSyncChanged() <-chan struct{} {
+	jk.lock.RLock()
+	defer jk.lock.RUnlock()
+
+	return jk.syncChanged
+}
+
 func (jk *DBJetKeeper) topSyncPulse() insolar.PulseNumber {
 	val, err := jk.db.Get(syncPulseKey{})
 	if err != nil {
@@ -416,7 +441,13 @@ func (jk *DBJetKeeper) set(pn insolar.PulseNumber, jets []JetInfo) error {
 
 func (jk *DBJetKeeper) updateSyncPulse(pn insolar.PulseNumber) error {
 	err := jk.db.Set(syncPulseKey{}, pn.Bytes())
-	return errors.Wrapf(err, "failed to set up new sync pulse")
+	if err != nil {
+		return errors.Wrapf(err, "failed to set up new sync pulse")
+	}
+
+	close(jk.syncChanged)
+	jk.syncChanged = make(chan struct{})
+	return nil
 }
 
 func (jk *DBJetKeeper) TruncateHead(ctx context.Context, from insolar.PulseNumber) error {
@@ -103,6 +103,41 @@ func TestJetInfoIsConfirmed_OneDropOneHot(t *testing.T) {
 	require.Equal(t, testPulse, ji.TopSyncPulse())
 }
 
+func TestJetKeeper_TopSyncChanged(t *testing.T) {
+	t.Parallel()
+	ctx := inslogger.TestContext(t)
+	testPulse := insolar.GenesisPulse.PulseNumber + 10
+	ji, tmpDir, db, jets, _ := initDB(t, testPulse)
+	defer os.RemoveAll(tmpDir)
+	defer db.Stop(ctx)
+
+	testJet := insolar.ZeroJetID
+	changed := ji.TopSyncChanged()
+
+	err := jets.Update(ctx, testPulse, true, testJet)
+	require.NoError(t, err)
+	err = ji.AddHotConfirmation(ctx, testPulse, testJet, false)
+	require.NoError(t, err)
+	err = ji.AddDropConfirmation(ctx, testPulse, testJet, false)
+	require.NoError(t, err)
+
+	select {
+	case <-changed:
+		t.Fatal("top sync pulse is not changed yet")
+	default:
+	}
+
+	err = ji.AddBackupConfirmation(ctx, testPulse)
+	require.NoError(t, err)
+
+	select {
+	case <-changed:
+	default:
+		t.Fatal("channel should be closed after top sync pulse change")
+	}
+	require.NotEqual(t, changed, ji.TopSyncChanged())
+}
+
 func Test_DifferentSplitFlagsInDropsAndHots(t *testing.T) {
 	t.Parallel()
 	ctx := inslogger.TestContext(t)
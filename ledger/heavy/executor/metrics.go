@@ -28,6 +28,11 @@ var (
 		"last pulse with fully finalized data",
 		stats.UnitDimensionless,
 	)
+	statConsistencyViolations = stats.Int64(
+		"heavy_consistency_violations",
+		"inconsistencies found in finalized data",
+		stats.UnitDimensionless,
+	)
 )
 
 func init() {
@@ -44,6 +49,12 @@ func init() {
 			Measure:     statJets,
 			Aggregation: view.LastValue(),
 		},
+		&view.View{
+			Name:        statConsistencyViolations.Name(),
+			Description: statConsistencyViolations.Description(),
+			Measure:     statConsistencyViolations,
+			Aggregation: view.Sum(),
+		},
 	)
 	if err != nil {
 		panic(err)
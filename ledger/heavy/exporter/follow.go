@@ -0,0 +1,81 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package exporter
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/ledger/heavy/executor"
+)
+
+// defaultHeartbeatInterval is a period of keep-alive messages for streams in follow mode.
+const defaultHeartbeatInterval = 10 * time.Second
+
+// syncWaiter blocks export streams in follow mode until top sync pulse moves forward.
+type syncWaiter struct {
+	jetKeeper executor.JetKeeper
+	heartbeat time.Duration
+
+	stopOnce sync.Once
+	stopped  chan struct{}
+}
+
+func newSyncWaiter(jetKeeper executor.JetKeeper) *syncWaiter {
+	return &syncWaiter{
+		jetKeeper: jetKeeper,
+		heartbeat: defaultHeartbeatInterval,
+		stopped:   make(chan struct{}),
+	}
+}
+
+// waitAfter blocks until top sync pulse becomes greater than provided pulse.
+// The heartbeat func is called periodically while waiting.
+// Returns false if the stream is closed by client or the server is stopped.
+func (w *syncWaiter) waitAfter(ctx context.Context, pn insolar.PulseNumber, heartbeat func() error) (bool, error) {
+	ticker := time.NewTicker(w.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		// Subscribe before reading top sync pulse to not miss the change.
+		changed := w.jetKeeper.TopSyncChanged()
+		if w.jetKeeper.TopSyncPulse() > pn {
+			return true, nil
+		}
+
+		select {
+		case <-changed:
+		case <-ticker.C:
+			if err := heartbeat(); err != nil {
+				return false, err
+			}
+		case <-ctx.Done():
+			return false, nil
+		case <-w.stopped:
+			return false, nil
+		}
+	}
+}
+
+// stop releases all the waiting streams. Streams in follow mode end without error.
+func (w *syncWaiter) stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopped)
+	})
+}
@@ -0,0 +1,94 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package exporter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/ledger/heavy/executor"
+	"github.com/insolar/insolar/pulse"
+)
+
+func TestSyncWaiter_WaitAfter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	pn := insolar.PulseNumber(pulse.MinTimePulse)
+
+	t.Run("returns immediately if top sync pulse is already greater", func(t *testing.T) {
+		jetKeeper := executor.NewJetKeeperMock(t)
+		jetKeeper.TopSyncChangedMock.Return(make(chan struct{}))
+		jetKeeper.TopSyncPulseMock.Return(pn + 1)
+
+		ok, err := newSyncWaiter(jetKeeper).waitAfter(ctx, pn, func() error {
+			t.Error("it shouldn't be called")
+			return nil
+		})
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+
+	t.Run("sends heartbeats while waiting", func(t *testing.T) {
+		jetKeeper := executor.NewJetKeeperMock(t)
+		jetKeeper.TopSyncChangedMock.Return(make(chan struct{}))
+		jetKeeper.TopSyncPulseMock.Return(pn)
+
+		waiter := newSyncWaiter(jetKeeper)
+		waiter.heartbeat = time.Millisecond
+
+		heartbeatErr := errors.New("stream is broken")
+		ok, err := waiter.waitAfter(ctx, pn, func() error {
+			return heartbeatErr
+		})
+		require.Equal(t, heartbeatErr, err)
+		require.False(t, ok)
+	})
+
+	t.Run("returns false on stop", func(t *testing.T) {
+		jetKeeper := executor.NewJetKeeperMock(t)
+		jetKeeper.TopSyncChangedMock.Return(make(chan struct{}))
+		jetKeeper.TopSyncPulseMock.Return(pn)
+
+		waiter := newSyncWaiter(jetKeeper)
+		waiter.stop()
+		// Second stop doesn't panic.
+		waiter.stop()
+
+		ok, err := waiter.waitAfter(ctx, pn, func() error { return nil })
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("returns false if context is done", func(t *testing.T) {
+		jetKeeper := executor.NewJetKeeperMock(t)
+		jetKeeper.TopSyncChangedMock.Return(make(chan struct{}))
+		jetKeeper.TopSyncPulseMock.Return(pn)
+
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		ok, err := newSyncWaiter(jetKeeper).waitAfter(ctx, pn, func() error { return nil })
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+}
@@ -0,0 +1,1383 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: ledger/heavy/exporter/object_history.proto
+
+package exporter
+
+import (
+	context "context"
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	github_com_insolar_insolar_insolar "github.com/insolar/insolar/insolar"
+	record "github.com/insolar/insolar/insolar/record"
+	grpc "google.golang.org/grpc"
+	io "io"
+	math "math"
+	reflect "reflect"
+	strings "strings"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+type GetChain struct {
+	Polymorph    uint32                                `protobuf:"varint,16,opt,name=Polymorph,proto3" json:"Polymorph,omitempty"`
+	ObjectID     github_com_insolar_insolar_insolar.ID `protobuf:"bytes,20,opt,name=ObjectID,proto3,customtype=github.com/insolar/insolar/insolar.ID" json:"ObjectID"`
+	WithRequests bool                                  `protobuf:"varint,21,opt,name=WithRequests,proto3" json:"WithRequests,omitempty"`
+}
+
+func (m *GetChain) Reset()      { *m = GetChain{} }
+func (*GetChain) ProtoMessage() {}
+func (*GetChain) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3c7d19aa626745bc, []int{0}
+}
+func (m *GetChain) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetChain) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetChain.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetChain) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetChain.Merge(m, src)
+}
+func (m *GetChain) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetChain) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetChain.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetChain proto.InternalMessageInfo
+
+func (m *GetChain) GetPolymorph() uint32 {
+	if m != nil {
+		return m.Polymorph
+	}
+	return 0
+}
+
+func (m *GetChain) GetWithRequests() bool {
+	if m != nil {
+		return m.WithRequests
+	}
+	return false
+}
+
+type GetStateAt struct {
+	Polymorph    uint32                                         `protobuf:"varint,16,opt,name=Polymorph,proto3" json:"Polymorph,omitempty"`
+	ObjectID     github_com_insolar_insolar_insolar.ID          `protobuf:"bytes,20,opt,name=ObjectID,proto3,customtype=github.com/insolar/insolar/insolar.ID" json:"ObjectID"`
+	PulseNumber  github_com_insolar_insolar_insolar.PulseNumber `protobuf:"bytes,21,opt,name=PulseNumber,proto3,customtype=github.com/insolar/insolar/insolar.PulseNumber" json:"PulseNumber"`
+	WithRequests bool                                           `protobuf:"varint,22,opt,name=WithRequests,proto3" json:"WithRequests,omitempty"`
+}
+
+func (m *GetStateAt) Reset()      { *m = GetStateAt{} }
+func (*GetStateAt) ProtoMessage() {}
+func (*GetStateAt) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3c7d19aa626745bc, []int{1}
+}
+func (m *GetStateAt) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetStateAt) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetStateAt.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetStateAt) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateAt.Merge(m, src)
+}
+func (m *GetStateAt) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetStateAt) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateAt.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateAt proto.InternalMessageInfo
+
+func (m *GetStateAt) GetPolymorph() uint32 {
+	if m != nil {
+		return m.Polymorph
+	}
+	return 0
+}
+
+func (m *GetStateAt) GetWithRequests() bool {
+	if m != nil {
+		return m.WithRequests
+	}
+	return false
+}
+
+type State struct {
+	Polymorph uint32           `protobuf:"varint,16,opt,name=Polymorph,proto3" json:"Polymorph,omitempty"`
+	State     record.Material  `protobuf:"bytes,20,opt,name=State,proto3" json:"State"`
+	Request   *record.Material `protobuf:"bytes,21,opt,name=Request,proto3" json:"Request,omitempty"`
+	Result    *record.Material `protobuf:"bytes,22,opt,name=Result,proto3" json:"Result,omitempty"`
+}
+
+func (m *State) Reset()      { *m = State{} }
+func (*State) ProtoMessage() {}
+func (*State) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3c7d19aa626745bc, []int{2}
+}
+func (m *State) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *State) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_State.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *State) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_State.Merge(m, src)
+}
+func (m *State) XXX_Size() int {
+	return m.Size()
+}
+func (m *State) XXX_DiscardUnknown() {
+	xxx_messageInfo_State.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_State proto.InternalMessageInfo
+
+func (m *State) GetPolymorph() uint32 {
+	if m != nil {
+		return m.Polymorph
+	}
+	return 0
+}
+
+func (m *State) GetState() record.Material {
+	if m != nil {
+		return m.State
+	}
+	return record.Material{}
+}
+
+func (m *State) GetRequest() *record.Material {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *State) GetResult() *record.Material {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*GetChain)(nil), "exporter.GetChain")
+	proto.RegisterType((*GetStateAt)(nil), "exporter.GetStateAt")
+	proto.RegisterType((*State)(nil), "exporter.State")
+}
+
+func init() {
+	proto.RegisterFile("ledger/heavy/exporter/object_history.proto", fileDescriptor_3c7d19aa626745bc)
+}
+
+var fileDescriptor_3c7d19aa626745bc = []byte{
+	// 440 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x52, 0x41, 0x6f, 0xd3, 0x30,
+	0x18, 0xb5, 0x25, 0xb6, 0x05, 0xaf, 0x13, 0x93, 0xb5, 0x4d, 0x51, 0x85, 0xbc, 0x2a, 0x12, 0x52,
+	0x34, 0xb1, 0x64, 0x2a, 0x68, 0x77, 0xc2, 0xa4, 0xd1, 0x03, 0x30, 0x99, 0x03, 0xdc, 0x50, 0xd2,
+	0x7d, 0x24, 0x41, 0xe9, 0x5c, 0x1c, 0x07, 0xd1, 0x1b, 0x3f, 0x81, 0x1b, 0x7f, 0x01, 0xfe, 0xc9,
+	0x8e, 0x3d, 0x4e, 0x1c, 0x2a, 0x9a, 0x5e, 0x38, 0x56, 0xe2, 0x0f, 0x20, 0x1c, 0x87, 0xb6, 0x6a,
+	0x51, 0x39, 0x71, 0x72, 0xfc, 0xf9, 0xbd, 0xe7, 0xf7, 0xe2, 0x47, 0x8e, 0x32, 0xb8, 0x8c, 0x41,
+	0xfa, 0x09, 0x84, 0xef, 0x07, 0x3e, 0x7c, 0xe8, 0x0b, 0xa9, 0x40, 0xfa, 0x22, 0x7a, 0x0b, 0x5d,
+	0xf5, 0x3a, 0x49, 0x73, 0x25, 0xe4, 0xc0, 0xeb, 0x4b, 0xa1, 0x04, 0xb5, 0xea, 0xe3, 0xe6, 0x71,
+	0x9c, 0xaa, 0xa4, 0x88, 0xbc, 0xae, 0xe8, 0xf9, 0xb1, 0x88, 0x85, 0xaf, 0x01, 0x51, 0xf1, 0x46,
+	0xef, 0xf4, 0x46, 0x7f, 0x55, 0xc4, 0xe6, 0xe9, 0x1c, 0x3c, 0xbd, 0xca, 0x45, 0x16, 0xca, 0xa5,
+	0x55, 0x42, 0x57, 0xc8, 0x4b, 0xb3, 0x54, 0x3c, 0xe7, 0x33, 0x26, 0xd6, 0x39, 0xa8, 0xc7, 0x49,
+	0x98, 0x5e, 0xd1, 0xbb, 0xe4, 0xf6, 0x85, 0xc8, 0x06, 0x3d, 0x21, 0xfb, 0x89, 0xbd, 0xdb, 0xc2,
+	0xee, 0x0e, 0x9f, 0x0d, 0x68, 0x87, 0x58, 0xcf, 0xb5, 0xe7, 0xce, 0x99, 0xbd, 0xd7, 0xc2, 0x6e,
+	0x23, 0x38, 0xbe, 0x1e, 0x1d, 0xa2, 0x6f, 0xa3, 0xc3, 0x7b, 0xeb, 0x2f, 0xf7, 0x3a, 0x67, 0xfc,
+	0x0f, 0x9d, 0x3a, 0xa4, 0xf1, 0x32, 0x55, 0x09, 0x87, 0x77, 0x05, 0xe4, 0x2a, 0xb7, 0xf7, 0x5b,
+	0xd8, 0xb5, 0xf8, 0xc2, 0xcc, 0xf9, 0x89, 0x09, 0x39, 0x07, 0xf5, 0x42, 0x85, 0x0a, 0x1e, 0xa9,
+	0xff, 0xe7, 0xed, 0x15, 0xd9, 0xbe, 0x28, 0xb2, 0x1c, 0x9e, 0x15, 0xbd, 0x08, 0xa4, 0xb6, 0xd6,
+	0x08, 0x4e, 0x8d, 0x9a, 0xf7, 0x0f, 0x6a, 0x73, 0x6c, 0x3e, 0x2f, 0xb5, 0x94, 0xfa, 0x60, 0x45,
+	0xea, 0xaf, 0x98, 0x6c, 0xe8, 0xc8, 0x6b, 0x02, 0xdf, 0x37, 0x30, 0x9d, 0x76, 0xbb, 0xbd, 0xeb,
+	0x99, 0x57, 0x7d, 0x1a, 0x2a, 0x90, 0x69, 0x98, 0x05, 0xb7, 0x7e, 0x3b, 0xe6, 0x46, 0xeb, 0x88,
+	0x6c, 0x99, 0x1b, 0xec, 0xfd, 0xd5, 0x78, 0x5e, 0x03, 0xa8, 0x4b, 0x36, 0x39, 0xe4, 0x45, 0xa6,
+	0xec, 0x83, 0xbf, 0x40, 0xcd, 0x79, 0xbb, 0x20, 0x3b, 0xd5, 0x5f, 0x7b, 0x52, 0x75, 0x98, 0x9e,
+	0x90, 0x8d, 0xaa, 0x48, 0xd4, 0xab, 0x7b, 0xec, 0xd5, 0xe5, 0x6a, 0xde, 0x99, 0xcd, 0xb4, 0x29,
+	0x07, 0x9d, 0x60, 0xda, 0x26, 0x5b, 0xf5, 0x03, 0xef, 0x2d, 0x70, 0xcc, 0x74, 0x05, 0x2b, 0x78,
+	0x38, 0x1c, 0x33, 0x74, 0x33, 0x66, 0x68, 0x3a, 0x66, 0xf8, 0x63, 0xc9, 0xf0, 0x97, 0x92, 0xe1,
+	0xeb, 0x92, 0xe1, 0x61, 0xc9, 0xf0, 0xf7, 0x92, 0xe1, 0x1f, 0x25, 0x43, 0xd3, 0x92, 0xe1, 0x4f,
+	0x13, 0x86, 0x86, 0x13, 0x86, 0x6e, 0x26, 0x0c, 0x45, 0x9b, 0xba, 0xef, 0x0f, 0x7e, 0x0d, 0x00,
+	0xf2, 0x49, 0xbe, 0x2e, 0x8e, 0x03, 0x00, 0x00,
+}
+
+func (this *GetChain) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*GetChain)
+	if !ok {
+		that2, ok := that.(GetChain)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Polymorph != that1.Polymorph {
+		return false
+	}
+	if !this.ObjectID.Equal(that1.ObjectID) {
+		return false
+	}
+	if this.WithRequests != that1.WithRequests {
+		return false
+	}
+	return true
+}
+func (this *GetStateAt) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*GetStateAt)
+	if !ok {
+		that2, ok := that.(GetStateAt)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Polymorph != that1.Polymorph {
+		return false
+	}
+	if !this.ObjectID.Equal(that1.ObjectID) {
+		return false
+	}
+	if !this.PulseNumber.Equal(that1.PulseNumber) {
+		return false
+	}
+	if this.WithRequests != that1.WithRequests {
+		return false
+	}
+	return true
+}
+func (this *State) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*State)
+	if !ok {
+		that2, ok := that.(State)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Polymorph != that1.Polymorph {
+		return false
+	}
+	if !this.State.Equal(&that1.State) {
+		return false
+	}
+	if !this.Request.Equal(that1.Request) {
+		return false
+	}
+	if !this.Result.Equal(that1.Result) {
+		return false
+	}
+	return true
+}
+func (this *GetChain) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&exporter.GetChain{")
+	s = append(s, "Polymorph: "+fmt.Sprintf("%#v", this.Polymorph)+",\n")
+	s = append(s, "ObjectID: "+fmt.Sprintf("%#v", this.ObjectID)+",\n")
+	s = append(s, "WithRequests: "+fmt.Sprintf("%#v", this.WithRequests)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *GetStateAt) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&exporter.GetStateAt{")
+	s = append(s, "Polymorph: "+fmt.Sprintf("%#v", this.Polymorph)+",\n")
+	s = append(s, "ObjectID: "+fmt.Sprintf("%#v", this.ObjectID)+",\n")
+	s = append(s, "PulseNumber: "+fmt.Sprintf("%#v", this.PulseNumber)+",\n")
+	s = append(s, "WithRequests: "+fmt.Sprintf("%#v", this.WithRequests)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *State) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&exporter.State{")
+	s = append(s, "Polymorph: "+fmt.Sprintf("%#v", this.Polymorph)+",\n")
+	s = append(s, "State: "+strings.Replace(this.State.GoString(), `&`, ``, 1)+",\n")
+	if this.Request != nil {
+		s = append(s, "Request: "+fmt.Sprintf("%#v", this.Request)+",\n")
+	}
+	if this.Result != nil {
+		s = append(s, "Result: "+fmt.Sprintf("%#v", this.Result)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringObjectHistory(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ObjectHistoryClient is the client API for ObjectHistory service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ObjectHistoryClient interface {
+	Chain(ctx context.Context, in *GetChain, opts ...grpc.CallOption) (ObjectHistory_ChainClient, error)
+	StateAt(ctx context.Context, in *GetStateAt, opts ...grpc.CallOption) (*State, error)
+}
+
+type objectHistoryClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewObjectHistoryClient(cc *grpc.ClientConn) ObjectHistoryClient {
+	return &objectHistoryClient{cc}
+}
+
+func (c *objectHistoryClient) Chain(ctx context.Context, in *GetChain, opts ...grpc.CallOption) (ObjectHistory_ChainClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ObjectHistory_serviceDesc.Streams[0], "/exporter.ObjectHistory/Chain", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &objectHistoryChainClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ObjectHistory_ChainClient interface {
+	Recv() (*State, error)
+	grpc.ClientStream
+}
+
+type objectHistoryChainClient struct {
+	grpc.ClientStream
+}
+
+func (x *objectHistoryChainClient) Recv() (*State, error) {
+	m := new(State)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *objectHistoryClient) StateAt(ctx context.Context, in *GetStateAt, opts ...grpc.CallOption) (*State, error) {
+	out := new(State)
+	err := c.cc.Invoke(ctx, "/exporter.ObjectHistory/StateAt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ObjectHistoryServer is the server API for ObjectHistory service.
+type ObjectHistoryServer interface {
+	Chain(*GetChain, ObjectHistory_ChainServer) error
+	StateAt(context.Context, *GetStateAt) (*State, error)
+}
+
+func RegisterObjectHistoryServer(s *grpc.Server, srv ObjectHistoryServer) {
+	s.RegisterService(&_ObjectHistory_serviceDesc, srv)
+}
+
+func _ObjectHistory_Chain_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetChain)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ObjectHistoryServer).Chain(m, &objectHistoryChainServer{stream})
+}
+
+type ObjectHistory_ChainServer interface {
+	Send(*State) error
+	grpc.ServerStream
+}
+
+type objectHistoryChainServer struct {
+	grpc.ServerStream
+}
+
+func (x *objectHistoryChainServer) Send(m *State) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ObjectHistory_StateAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateAt)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ObjectHistoryServer).StateAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/exporter.ObjectHistory/StateAt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ObjectHistoryServer).StateAt(ctx, req.(*GetStateAt))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ObjectHistory_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "exporter.ObjectHistory",
+	HandlerType: (*ObjectHistoryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "StateAt",
+			Handler:    _ObjectHistory_StateAt_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Chain",
+			Handler:       _ObjectHistory_Chain_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "ledger/heavy/exporter/object_history.proto",
+}
+
+func (m *GetChain) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetChain) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Polymorph != 0 {
+		dAtA[i] = 0x80
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintObjectHistory(dAtA, i, uint64(m.Polymorph))
+	}
+	dAtA[i] = 0xa2
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintObjectHistory(dAtA, i, uint64(m.ObjectID.Size()))
+	n1, err := m.ObjectID.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n1
+	if m.WithRequests {
+		dAtA[i] = 0xa8
+		i++
+		dAtA[i] = 0x1
+		i++
+		if m.WithRequests {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *GetStateAt) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetStateAt) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Polymorph != 0 {
+		dAtA[i] = 0x80
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintObjectHistory(dAtA, i, uint64(m.Polymorph))
+	}
+	dAtA[i] = 0xa2
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintObjectHistory(dAtA, i, uint64(m.ObjectID.Size()))
+	n2, err := m.ObjectID.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n2
+	dAtA[i] = 0xaa
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintObjectHistory(dAtA, i, uint64(m.PulseNumber.Size()))
+	n3, err := m.PulseNumber.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n3
+	if m.WithRequests {
+		dAtA[i] = 0xb0
+		i++
+		dAtA[i] = 0x1
+		i++
+		if m.WithRequests {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *State) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *State) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Polymorph != 0 {
+		dAtA[i] = 0x80
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintObjectHistory(dAtA, i, uint64(m.Polymorph))
+	}
+	dAtA[i] = 0xa2
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintObjectHistory(dAtA, i, uint64(m.State.Size()))
+	n4, err := m.State.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n4
+	if m.Request != nil {
+		dAtA[i] = 0xaa
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintObjectHistory(dAtA, i, uint64(m.Request.Size()))
+		n5, err := m.Request.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
+	}
+	if m.Result != nil {
+		dAtA[i] = 0xb2
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintObjectHistory(dAtA, i, uint64(m.Result.Size()))
+		n6, err := m.Result.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
+	}
+	return i, nil
+}
+
+func encodeVarintObjectHistory(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *GetChain) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Polymorph != 0 {
+		n += 2 + sovObjectHistory(uint64(m.Polymorph))
+	}
+	l = m.ObjectID.Size()
+	n += 2 + l + sovObjectHistory(uint64(l))
+	if m.WithRequests {
+		n += 3
+	}
+	return n
+}
+
+func (m *GetStateAt) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Polymorph != 0 {
+		n += 2 + sovObjectHistory(uint64(m.Polymorph))
+	}
+	l = m.ObjectID.Size()
+	n += 2 + l + sovObjectHistory(uint64(l))
+	l = m.PulseNumber.Size()
+	n += 2 + l + sovObjectHistory(uint64(l))
+	if m.WithRequests {
+		n += 3
+	}
+	return n
+}
+
+func (m *State) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Polymorph != 0 {
+		n += 2 + sovObjectHistory(uint64(m.Polymorph))
+	}
+	l = m.State.Size()
+	n += 2 + l + sovObjectHistory(uint64(l))
+	if m.Request != nil {
+		l = m.Request.Size()
+		n += 2 + l + sovObjectHistory(uint64(l))
+	}
+	if m.Result != nil {
+		l = m.Result.Size()
+		n += 2 + l + sovObjectHistory(uint64(l))
+	}
+	return n
+}
+
+func sovObjectHistory(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozObjectHistory(x uint64) (n int) {
+	return sovObjectHistory(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *GetChain) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GetChain{`,
+		`Polymorph:` + fmt.Sprintf("%v", this.Polymorph) + `,`,
+		`ObjectID:` + fmt.Sprintf("%v", this.ObjectID) + `,`,
+		`WithRequests:` + fmt.Sprintf("%v", this.WithRequests) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GetStateAt) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GetStateAt{`,
+		`Polymorph:` + fmt.Sprintf("%v", this.Polymorph) + `,`,
+		`ObjectID:` + fmt.Sprintf("%v", this.ObjectID) + `,`,
+		`PulseNumber:` + fmt.Sprintf("%v", this.PulseNumber) + `,`,
+		`WithRequests:` + fmt.Sprintf("%v", this.WithRequests) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *State) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&State{`,
+		`Polymorph:` + fmt.Sprintf("%v", this.Polymorph) + `,`,
+		`State:` + strings.Replace(strings.Replace(this.State.String(), "Material", "record.Material", 1), `&`, ``, 1) + `,`,
+		`Request:` + strings.Replace(fmt.Sprintf("%v", this.Request), "Material", "record.Material", 1) + `,`,
+		`Result:` + strings.Replace(fmt.Sprintf("%v", this.Result), "Material", "record.Material", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringObjectHistory(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *GetChain) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowObjectHistory
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetChain: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetChain: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Polymorph", wireType)
+			}
+			m.Polymorph = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowObjectHistory
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Polymorph |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowObjectHistory
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthObjectHistory
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthObjectHistory
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ObjectID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 21:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WithRequests", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowObjectHistory
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.WithRequests = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipObjectHistory(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthObjectHistory
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthObjectHistory
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetStateAt) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowObjectHistory
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetStateAt: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetStateAt: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Polymorph", wireType)
+			}
+			m.Polymorph = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowObjectHistory
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Polymorph |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowObjectHistory
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthObjectHistory
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthObjectHistory
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ObjectID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 21:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PulseNumber", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowObjectHistory
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthObjectHistory
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthObjectHistory
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.PulseNumber.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 22:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WithRequests", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowObjectHistory
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.WithRequests = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipObjectHistory(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthObjectHistory
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthObjectHistory
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *State) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowObjectHistory
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: State: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: State: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Polymorph", wireType)
+			}
+			m.Polymorph = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowObjectHistory
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Polymorph |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowObjectHistory
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthObjectHistory
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthObjectHistory
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.State.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 21:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Request", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowObjectHistory
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthObjectHistory
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthObjectHistory
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Request == nil {
+				m.Request = &record.Material{}
+			}
+			if err := m.Request.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 22:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowObjectHistory
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthObjectHistory
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthObjectHistory
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Result == nil {
+				m.Result = &record.Material{}
+			}
+			if err := m.Result.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipObjectHistory(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthObjectHistory
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthObjectHistory
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipObjectHistory(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowObjectHistory
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowObjectHistory
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+			return iNdEx, nil
+		case 1:
+			iNdEx += 8
+			return iNdEx, nil
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowObjectHistory
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthObjectHistory
+			}
+			iNdEx += length
+			if iNdEx < 0 {
+				return 0, ErrInvalidLengthObjectHistory
+			}
+			return iNdEx, nil
+		case 3:
+			for {
+				var innerWire uint64
+				var start int = iNdEx
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return 0, ErrIntOverflowObjectHistory
+					}
+					if iNdEx >= l {
+						return 0, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					innerWire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				innerWireType := int(innerWire & 0x7)
+				if innerWireType == 4 {
+					break
+				}
+				next, err := skipObjectHistory(dAtA[start:])
+				if err != nil {
+					return 0, err
+				}
+				iNdEx = start + next
+				if iNdEx < 0 {
+					return 0, ErrInvalidLengthObjectHistory
+				}
+			}
+			return iNdEx, nil
+		case 4:
+			return iNdEx, nil
+		case 5:
+			iNdEx += 4
+			return iNdEx, nil
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+	}
+	panic("unreachable")
+}
+
+var (
+	ErrInvalidLengthObjectHistory = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowObjectHistory   = fmt.Errorf("proto: integer overflow")
+)
@@ -0,0 +1,263 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package exporter
+
+import (
+	"context"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/record"
+	"github.com/insolar/insolar/ledger/heavy/executor"
+	"github.com/insolar/insolar/ledger/object"
+
+	"github.com/pkg/errors"
+)
+
+// HistoryServer returns object states stored on heavy.
+// Only finalized data (up to top sync pulse) is returned.
+type HistoryServer struct {
+	indexes        object.IndexAccessor
+	recordAccessor object.RecordAccessor
+	jetKeeper      executor.JetKeeper
+}
+
+func NewHistoryServer(
+	indexes object.IndexAccessor,
+	recordAccessor object.RecordAccessor,
+	jetKeeper executor.JetKeeper,
+) *HistoryServer {
+	return &HistoryServer{
+		indexes:        indexes,
+		recordAccessor: recordAccessor,
+		jetKeeper:      jetKeeper,
+	}
+}
+
+// Chain streams object states from the latest one down to activation.
+func (h *HistoryServer) Chain(getChain *GetChain, stream ObjectHistory_ChainServer) error {
+	ctx := stream.Context()
+
+	chain, err := h.newChain(ctx, getChain.ObjectID, getChain.WithRequests)
+	if err != nil {
+		return err
+	}
+
+	for {
+		state, err := chain.next(ctx)
+		if err != nil {
+			return err
+		}
+		if state == nil {
+			return nil
+		}
+		if err := stream.Send(state); err != nil {
+			return err
+		}
+	}
+}
+
+// StateAt returns the latest object state with pulse not greater than provided one.
+func (h *HistoryServer) StateAt(ctx context.Context, getStateAt *GetStateAt) (*State, error) {
+	if !getStateAt.PulseNumber.IsTimePulse() {
+		return nil, errors.Errorf("invalid pulse %v", getStateAt.PulseNumber)
+	}
+
+	chain, err := h.newChain(ctx, getStateAt.ObjectID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		state, err := chain.next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if state == nil {
+			return nil, errors.Errorf("object %s has no state at pulse %v", getStateAt.ObjectID.DebugString(), getStateAt.PulseNumber)
+		}
+		if state.State.ID.Pulse() > getStateAt.PulseNumber {
+			continue
+		}
+
+		if getStateAt.WithRequests {
+			err = chain.filament.attach(ctx, state)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return state, nil
+	}
+}
+
+func (h *HistoryServer) newChain(ctx context.Context, objID insolar.ID, withRequests bool) (*stateChain, error) {
+	if objID.IsEmpty() {
+		return nil, errors.New("object id is empty")
+	}
+
+	topSyncPulse := h.jetKeeper.TopSyncPulse()
+	idx, err := h.indexes.ForID(ctx, topSyncPulse, objID)
+	if err == object.ErrIndexNotFound {
+		return nil, errors.Errorf("object %s not found", objID.DebugString())
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch object index")
+	}
+
+	chain := &stateChain{
+		records:      h.recordAccessor,
+		topSyncPulse: topSyncPulse,
+		current:      idx.Lifeline.LatestState,
+		withRequests: withRequests,
+		filament: &filamentReader{
+			records:      h.recordAccessor,
+			topSyncPulse: topSyncPulse,
+			current:      idx.Lifeline.LatestRequest,
+			requests:     map[insolar.ID]record.Material{},
+			results:      map[insolar.ID]record.Material{},
+		},
+	}
+	return chain, nil
+}
+
+// stateChain walks object states backwards using PrevStateID links.
+type stateChain struct {
+	records      object.RecordAccessor
+	topSyncPulse insolar.PulseNumber
+	current      *insolar.ID
+	withRequests bool
+	filament     *filamentReader
+}
+
+// next returns the previous state of the object. Returns nil when activation is passed.
+func (c *stateChain) next(ctx context.Context) (*State, error) {
+	for c.current != nil {
+		id := *c.current
+		rec, err := c.records.ForID(ctx, id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch state %s", id.DebugString())
+		}
+		virtual := record.Unwrap(&rec.Virtual)
+		stateRec, ok := virtual.(record.State)
+		if !ok {
+			return nil, errors.Errorf("record %s is not a state: %T", id.DebugString(), virtual)
+		}
+		c.current = stateRec.PrevStateID()
+
+		// Skip states that are not finalized yet.
+		if id.Pulse() > c.topSyncPulse {
+			continue
+		}
+
+		state := &State{State: rec}
+		if c.withRequests {
+			err = c.filament.attach(ctx, state)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return state, nil
+	}
+	return nil, nil
+}
+
+// filamentReader walks object filament (pending filament records) backwards.
+// Results are stored after their requests, so by the time a request is found its result is already read.
+type filamentReader struct {
+	records      object.RecordAccessor
+	topSyncPulse insolar.PulseNumber
+	current      *insolar.ID
+
+	requests map[insolar.ID]record.Material
+	// results are indexed by id of their requests.
+	results map[insolar.ID]record.Material
+}
+
+// attach fills request and result that caused the state transition.
+func (f *filamentReader) attach(ctx context.Context, state *State) error {
+	reqRef := requestOf(&state.State)
+	if reqRef == nil || reqRef.IsEmpty() {
+		return nil
+	}
+	reqID := *reqRef.GetLocal()
+
+	req, err := f.find(ctx, reqID)
+	if err != nil {
+		return err
+	}
+	if req == nil {
+		return nil
+	}
+	state.Request = req
+	if res, ok := f.results[reqID]; ok {
+		state.Result = &res
+	}
+	return nil
+}
+
+// find reads filament until the request is found. Returns nil if there is no such request.
+func (f *filamentReader) find(ctx context.Context, reqID insolar.ID) (*record.Material, error) {
+	for {
+		if req, ok := f.requests[reqID]; ok {
+			return &req, nil
+		}
+		if f.current == nil {
+			return nil, nil
+		}
+
+		metaID := *f.current
+		meta, err := f.records.ForID(ctx, metaID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch filament %s", metaID.DebugString())
+		}
+		filament, ok := record.Unwrap(&meta.Virtual).(*record.PendingFilament)
+		if !ok {
+			return nil, errors.Errorf("record %s is not a filament", metaID.DebugString())
+		}
+		f.current = filament.PreviousRecord
+
+		if filament.RecordID.Pulse() > f.topSyncPulse {
+			continue
+		}
+
+		rec, err := f.records.ForID(ctx, filament.RecordID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch filament record %s", filament.RecordID.DebugString())
+		}
+		switch v := record.Unwrap(&rec.Virtual).(type) {
+		case *record.IncomingRequest, *record.OutgoingRequest:
+			f.requests[filament.RecordID] = rec
+		case *record.Result:
+			if local := v.Request.GetLocal(); local != nil {
+				f.results[*local] = rec
+			}
+		}
+	}
+}
+
+// requestOf returns reference to the request that produced the state.
+func requestOf(rec *record.Material) *insolar.Reference {
+	switch v := rec.Virtual.Union.(type) {
+	case *record.Virtual_Activate:
+		return &v.Activate.Request
+	case *record.Virtual_Amend:
+		return &v.Amend.Request
+	case *record.Virtual_Deactivate:
+		return &v.Deactivate.Request
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,240 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package exporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/gen"
+	"github.com/insolar/insolar/insolar/record"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/heavy/executor"
+	"github.com/insolar/insolar/ledger/object"
+	"github.com/insolar/insolar/pulse"
+)
+
+type chainStreamMock struct {
+	checker func(*State) error
+}
+
+func (s chainStreamMock) Send(state *State) error {
+	return s.checker(state)
+}
+
+func (s chainStreamMock) SetHeader(metadata.MD) error {
+	panic("implement me")
+}
+
+func (s chainStreamMock) SendHeader(metadata.MD) error {
+	panic("implement me")
+}
+
+func (s chainStreamMock) SetTrailer(metadata.MD) {
+	panic("implement me")
+}
+
+func (s chainStreamMock) Context() context.Context {
+	return context.Background()
+}
+
+func (s chainStreamMock) SendMsg(m interface{}) error {
+	panic("implement me")
+}
+
+func (s chainStreamMock) RecvMsg(m interface{}) error {
+	panic("implement me")
+}
+
+// historyFixture stores object with activation, two amends and filament of their requests.
+// The last amend is not finalized yet.
+type historyFixture struct {
+	objID    insolar.ID
+	pulses   []insolar.PulseNumber
+	states   []insolar.ID
+	requests []insolar.ID
+	results  []insolar.ID
+
+	server *HistoryServer
+}
+
+func newHistoryFixture(t *testing.T) *historyFixture {
+	ctx := inslogger.TestContext(t)
+	records := object.NewRecordMemory()
+
+	f := &historyFixture{objID: gen.ID()}
+	first := insolar.PulseNumber(pulse.MinTimePulse)
+	f.pulses = []insolar.PulseNumber{first + 10, first + 20, first + 30}
+	topSyncPulse := f.pulses[1]
+
+	set := func(id insolar.ID, virtual record.Virtual) {
+		err := records.SetAtomic(ctx, record.Material{ID: id, ObjectID: f.objID, Virtual: virtual})
+		require.NoError(t, err)
+	}
+
+	var (
+		prevState    *insolar.ID
+		prevFilament *insolar.ID
+	)
+	addFilament := func(pn insolar.PulseNumber, recID insolar.ID) {
+		id := gen.IDWithPulse(pn)
+		set(id, record.Wrap(&record.PendingFilament{RecordID: recID, PreviousRecord: prevFilament}))
+		prevFilament = &id
+	}
+
+	for i, pn := range f.pulses {
+		reqID := gen.IDWithPulse(pn)
+		set(reqID, record.Wrap(&record.IncomingRequest{Method: "Call"}))
+		addFilament(pn, reqID)
+
+		resID := gen.IDWithPulse(pn)
+		set(resID, record.Wrap(&record.Result{Object: f.objID, Request: *insolar.NewReference(reqID)}))
+		addFilament(pn, resID)
+
+		stateID := gen.IDWithPulse(pn)
+		if i == 0 {
+			set(stateID, record.Wrap(&record.Activate{Request: *insolar.NewReference(reqID)}))
+		} else {
+			set(stateID, record.Wrap(&record.Amend{Request: *insolar.NewReference(reqID), PrevState: *prevState}))
+		}
+		prevState = &stateID
+
+		f.requests = append(f.requests, reqID)
+		f.results = append(f.results, resID)
+		f.states = append(f.states, stateID)
+	}
+
+	indexes := object.NewIndexAccessorMock(t)
+	indexes.ForIDMock.Set(func(_ context.Context, pn insolar.PulseNumber, objID insolar.ID) (record.Index, error) {
+		if objID != f.objID {
+			return record.Index{}, object.ErrIndexNotFound
+		}
+		return record.Index{
+			ObjID: objID,
+			Lifeline: record.Lifeline{
+				LatestState:   prevState,
+				LatestRequest: prevFilament,
+			},
+		}, nil
+	})
+
+	jetKeeper := executor.NewJetKeeperMock(t)
+	jetKeeper.TopSyncPulseMock.Return(topSyncPulse)
+
+	f.server = NewHistoryServer(indexes, records, jetKeeper)
+	return f
+}
+
+func TestHistoryServer_Chain(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unknown object", func(t *testing.T) {
+		f := newHistoryFixture(t)
+
+		err := f.server.Chain(&GetChain{ObjectID: gen.ID()}, &chainStreamMock{})
+
+		require.Error(t, err)
+	})
+
+	t.Run("returns finalized states only", func(t *testing.T) {
+		f := newHistoryFixture(t)
+
+		var states []*State
+		stream := &chainStreamMock{checker: func(s *State) error {
+			states = append(states, s)
+			return nil
+		}}
+
+		err := f.server.Chain(&GetChain{ObjectID: f.objID}, stream)
+
+		require.NoError(t, err)
+		require.Len(t, states, 2)
+		require.Equal(t, f.states[1], states[0].State.ID)
+		require.Equal(t, f.states[0], states[1].State.ID)
+		require.Nil(t, states[0].Request)
+		require.Nil(t, states[0].Result)
+	})
+
+	t.Run("with requests", func(t *testing.T) {
+		f := newHistoryFixture(t)
+
+		var states []*State
+		stream := &chainStreamMock{checker: func(s *State) error {
+			states = append(states, s)
+			return nil
+		}}
+
+		err := f.server.Chain(&GetChain{ObjectID: f.objID, WithRequests: true}, stream)
+
+		require.NoError(t, err)
+		require.Len(t, states, 2)
+		for i, s := range states {
+			expected := len(states) - 1 - i
+			require.NotNil(t, s.Request)
+			require.Equal(t, f.requests[expected], s.Request.ID)
+			require.NotNil(t, s.Result)
+			require.Equal(t, f.results[expected], s.Result.ID)
+		}
+	})
+}
+
+func TestHistoryServer_StateAt(t *testing.T) {
+	t.Parallel()
+
+	ctx := inslogger.TestContext(t)
+
+	t.Run("invalid pulse", func(t *testing.T) {
+		f := newHistoryFixture(t)
+
+		_, err := f.server.StateAt(ctx, &GetStateAt{ObjectID: f.objID})
+
+		require.Error(t, err)
+	})
+
+	t.Run("before activation", func(t *testing.T) {
+		f := newHistoryFixture(t)
+
+		_, err := f.server.StateAt(ctx, &GetStateAt{ObjectID: f.objID, PulseNumber: f.pulses[0] - 1})
+
+		require.Error(t, err)
+	})
+
+	t.Run("returns state at pulse", func(t *testing.T) {
+		f := newHistoryFixture(t)
+
+		state, err := f.server.StateAt(ctx, &GetStateAt{ObjectID: f.objID, PulseNumber: f.pulses[0] + 5})
+
+		require.NoError(t, err)
+		require.Equal(t, f.states[0], state.State.ID)
+		require.Nil(t, state.Request)
+	})
+
+	t.Run("ignores not finalized states", func(t *testing.T) {
+		f := newHistoryFixture(t)
+
+		state, err := f.server.StateAt(ctx, &GetStateAt{ObjectID: f.objID, PulseNumber: f.pulses[2], WithRequests: true})
+
+		require.NoError(t, err)
+		require.Equal(t, f.states[1], state.State.ID)
+		require.Equal(t, f.requests[1], state.Request.ID)
+		require.Equal(t, f.results[1], state.Result.ID)
+	})
+}
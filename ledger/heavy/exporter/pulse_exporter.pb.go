@@ -31,6 +31,7 @@ type GetPulses struct {
 	Polymorph   uint32                                         `protobuf:"varint,16,opt,name=Polymorph,proto3" json:"Polymorph,omitempty"`
 	PulseNumber github_com_insolar_insolar_insolar.PulseNumber `protobuf:"bytes,20,opt,name=PulseNumber,proto3,customtype=github.com/insolar/insolar/insolar.PulseNumber" json:"PulseNumber"`
 	Count       uint32                                         `protobuf:"varint,22,opt,name=Count,proto3" json:"Count,omitempty"`
+	Follow      bool                                           `protobuf:"varint,23,opt,name=Follow,proto3" json:"Follow,omitempty"`
 }
 
 func (m *GetPulses) Reset()      { *m = GetPulses{} }
@@ -79,11 +80,19 @@ func (m *GetPulses) GetCount() uint32 {
 	return 0
 }
 
+func (m *GetPulses) GetFollow() bool {
+	if m != nil {
+		return m.Follow
+	}
+	return false
+}
+
 type Pulse struct {
 	Polymorph      uint32                                         `protobuf:"varint,16,opt,name=Polymorph,proto3" json:"Polymorph,omitempty"`
 	PulseNumber    github_com_insolar_insolar_insolar.PulseNumber `protobuf:"bytes,20,opt,name=PulseNumber,proto3,customtype=github.com/insolar/insolar/insolar.PulseNumber" json:"PulseNumber"`
 	Entropy        github_com_insolar_insolar_insolar.Entropy     `protobuf:"bytes,21,opt,name=Entropy,proto3,customtype=github.com/insolar/insolar/insolar.Entropy" json:"Entropy"`
 	PulseTimestamp int64                                          `protobuf:"varint,22,opt,name=PulseTimestamp,proto3" json:"PulseTimestamp,omitempty"`
+	Heartbeat      bool                                           `protobuf:"varint,23,opt,name=Heartbeat,proto3" json:"Heartbeat,omitempty"`
 }
 
 func (m *Pulse) Reset()      { *m = Pulse{} }
@@ -132,6 +141,13 @@ func (m *Pulse) GetPulseTimestamp() int64 {
 	return 0
 }
 
+func (m *Pulse) GetHeartbeat() bool {
+	if m != nil {
+		return m.Heartbeat
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*GetPulses)(nil), "exporter.GetPulses")
 	proto.RegisterType((*Pulse)(nil), "exporter.Pulse")
@@ -142,29 +158,31 @@ func init() {
 }
 
 var fileDescriptor_c59ef702cec231ca = []byte{
-	// 343 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xd2, 0xca, 0x49, 0x4d, 0x49,
-	0x4f, 0x2d, 0xd2, 0xcf, 0x48, 0x4d, 0x2c, 0xab, 0xd4, 0x4f, 0xad, 0x28, 0xc8, 0x2f, 0x2a, 0x49,
-	0x2d, 0xd2, 0x2f, 0x28, 0xcd, 0x29, 0x4e, 0x8d, 0x87, 0x71, 0xf5, 0x0a, 0x8a, 0xf2, 0x4b, 0xf2,
-	0x85, 0x38, 0x60, 0x7c, 0x29, 0xdd, 0xf4, 0xcc, 0x92, 0x8c, 0xd2, 0x24, 0xbd, 0xe4, 0xfc, 0x5c,
-	0xfd, 0xf4, 0xfc, 0xf4, 0x7c, 0x7d, 0xb0, 0x82, 0xa4, 0xd2, 0x34, 0x30, 0x0f, 0xcc, 0x01, 0xb3,
-	0x20, 0x1a, 0x95, 0x66, 0x32, 0x72, 0x71, 0xba, 0xa7, 0x96, 0x04, 0x80, 0x0c, 0x2d, 0x16, 0x92,
-	0xe1, 0xe2, 0x0c, 0xc8, 0xcf, 0xa9, 0xcc, 0xcd, 0x2f, 0x2a, 0xc8, 0x90, 0x10, 0x50, 0x60, 0xd4,
-	0xe0, 0x0d, 0x42, 0x08, 0x08, 0x45, 0x70, 0x71, 0x83, 0xd5, 0xf9, 0x95, 0xe6, 0x26, 0xa5, 0x16,
-	0x49, 0x88, 0x28, 0x30, 0x6a, 0xf0, 0x38, 0x99, 0x9d, 0xb8, 0x27, 0xcf, 0x70, 0xeb, 0x9e, 0xbc,
-	0x1e, 0x92, 0xbd, 0x99, 0x79, 0xc5, 0xf9, 0x39, 0x89, 0x45, 0xe8, 0xb4, 0x1e, 0x92, 0xee, 0x20,
-	0x64, 0xa3, 0x84, 0x44, 0xb8, 0x58, 0x9d, 0xf3, 0x4b, 0xf3, 0x4a, 0x24, 0xc4, 0xc0, 0x76, 0x42,
-	0x38, 0x4a, 0x5f, 0x19, 0xb9, 0x58, 0xc1, 0xaa, 0x06, 0xcc, 0x5d, 0x3e, 0x5c, 0xec, 0xae, 0x79,
-	0x25, 0x45, 0xf9, 0x05, 0x95, 0x12, 0xa2, 0x60, 0x53, 0x8d, 0xa0, 0xa6, 0x6a, 0x11, 0x61, 0x2a,
-	0x54, 0x67, 0x10, 0xcc, 0x08, 0x21, 0x35, 0x2e, 0x3e, 0xb0, 0xe1, 0x21, 0x99, 0xb9, 0xa9, 0xc5,
-	0x25, 0x89, 0xb9, 0x05, 0x60, 0xef, 0x32, 0x07, 0xa1, 0x89, 0x1a, 0x39, 0x73, 0xf1, 0x82, 0x45,
-	0x5c, 0xa1, 0x71, 0x2a, 0x64, 0xc4, 0xc5, 0x06, 0x61, 0x0b, 0x09, 0xeb, 0xc1, 0x23, 0x1e, 0x1e,
-	0x6b, 0x52, 0xfc, 0x08, 0x41, 0xb0, 0x88, 0x12, 0x83, 0x01, 0xa3, 0x93, 0xc9, 0x85, 0x87, 0x72,
-	0x0c, 0x37, 0x1e, 0xca, 0x31, 0x7c, 0x78, 0x28, 0xc7, 0xd8, 0xf0, 0x48, 0x8e, 0x71, 0xc5, 0x23,
-	0x39, 0xc6, 0x13, 0x8f, 0xe4, 0x18, 0x2f, 0x3c, 0x92, 0x63, 0x7c, 0xf0, 0x48, 0x8e, 0xf1, 0xc5,
-	0x23, 0x39, 0x86, 0x0f, 0x8f, 0xe4, 0x18, 0x27, 0x3c, 0x96, 0x63, 0xb8, 0xf0, 0x58, 0x8e, 0xe1,
-	0xc6, 0x63, 0x39, 0x86, 0x24, 0x36, 0x70, 0xaa, 0x30, 0x06, 0x04, 0x00, 0x00, 0xff, 0xff, 0x1d,
-	0xf8, 0x34, 0xb3, 0x7c, 0x02, 0x00, 0x00,
+	// 375 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x92, 0xc1, 0x4e, 0xf2, 0x40,
+	0x14, 0x85, 0x67, 0xfe, 0x3f, 0x20, 0x8c, 0xa2, 0x66, 0x44, 0x6c, 0x88, 0x19, 0x08, 0x0b, 0x43,
+	0x48, 0x6c, 0x0d, 0x1a, 0x1f, 0x00, 0x82, 0xba, 0x30, 0x86, 0x34, 0x2e, 0xdc, 0x99, 0x56, 0xc7,
+	0x42, 0xd2, 0x32, 0xcd, 0x74, 0xaa, 0xb2, 0xf3, 0x11, 0x4c, 0x7c, 0x09, 0xb7, 0xbe, 0x05, 0x4b,
+	0x96, 0xc4, 0x05, 0x91, 0x61, 0xe3, 0x92, 0x47, 0x30, 0x0c, 0x05, 0x1a, 0x56, 0xee, 0x5c, 0xf5,
+	0x9e, 0x93, 0x7b, 0xbe, 0xdb, 0xde, 0x5e, 0x54, 0x71, 0xe9, 0xbd, 0x43, 0xb9, 0xd1, 0xa2, 0xd6,
+	0x63, 0xd7, 0xa0, 0xcf, 0x3e, 0xe3, 0x82, 0x72, 0xc3, 0x0f, 0xdd, 0x80, 0xde, 0xce, 0xa5, 0xee,
+	0x73, 0x26, 0x18, 0x4e, 0xcd, 0x75, 0xfe, 0xd0, 0x69, 0x8b, 0x56, 0x68, 0xeb, 0x77, 0xcc, 0x33,
+	0x1c, 0xe6, 0x30, 0x43, 0x35, 0xd8, 0xe1, 0x83, 0x52, 0x4a, 0xa8, 0x6a, 0x16, 0x2c, 0x7d, 0x40,
+	0x94, 0x3e, 0xa7, 0xa2, 0x39, 0x85, 0x06, 0x78, 0x1f, 0xa5, 0x9b, 0xcc, 0xed, 0x7a, 0x8c, 0xfb,
+	0x2d, 0x6d, 0xbb, 0x08, 0xcb, 0x19, 0x73, 0x69, 0xe0, 0x1b, 0xb4, 0xae, 0xfa, 0xae, 0x42, 0xcf,
+	0xa6, 0x5c, 0xcb, 0x16, 0x61, 0x79, 0xa3, 0x76, 0xda, 0x1b, 0x16, 0xc0, 0xe7, 0xb0, 0xa0, 0xc7,
+	0xe6, 0xb6, 0x3b, 0x01, 0x73, 0x2d, 0xbe, 0xfa, 0xd4, 0x63, 0x69, 0x33, 0x8e, 0xc2, 0x59, 0x94,
+	0xa8, 0xb3, 0xb0, 0x23, 0xb4, 0x9c, 0x9a, 0x39, 0x13, 0x38, 0x87, 0x92, 0x67, 0xcc, 0x75, 0xd9,
+	0x93, 0xb6, 0x57, 0x84, 0xe5, 0x94, 0x19, 0xa9, 0xd2, 0xdb, 0x3f, 0x94, 0x50, 0xe9, 0x3f, 0x7b,
+	0xdf, 0x4b, 0xb4, 0xd6, 0xe8, 0x08, 0xce, 0xfc, 0xae, 0xb6, 0xab, 0xa8, 0xd5, 0x88, 0x5a, 0xf9,
+	0x05, 0x35, 0x4a, 0x9a, 0x73, 0x04, 0x3e, 0x40, 0x9b, 0x0a, 0x7e, 0xdd, 0xf6, 0x68, 0x20, 0x2c,
+	0xcf, 0x57, 0x6b, 0xf8, 0x6f, 0xae, 0xb8, 0xd3, 0xaf, 0xbd, 0xa0, 0x16, 0x17, 0x36, 0xb5, 0x44,
+	0xb4, 0x92, 0xa5, 0x51, 0xad, 0xa3, 0x8c, 0xea, 0x6f, 0x44, 0x97, 0x80, 0xab, 0x28, 0x39, 0xab,
+	0xf1, 0x8e, 0xbe, 0x38, 0x97, 0xc5, 0xbf, 0xce, 0x6f, 0x2d, 0x4d, 0xe5, 0x94, 0xc0, 0x11, 0xac,
+	0x9d, 0xf4, 0x47, 0x04, 0x0c, 0x46, 0x04, 0x4c, 0x46, 0x04, 0xbe, 0x48, 0x02, 0xdf, 0x25, 0x81,
+	0x3d, 0x49, 0x60, 0x5f, 0x12, 0xf8, 0x25, 0x09, 0xfc, 0x96, 0x04, 0x4c, 0x24, 0x81, 0xaf, 0x63,
+	0x02, 0xfa, 0x63, 0x02, 0x06, 0x63, 0x02, 0xec, 0xa4, 0xba, 0xa5, 0xe3, 0x9f, 0x01, 0x00, 0x86,
+	0xcd, 0x7d, 0x4c, 0xb2, 0x02, 0x00, 0x00,
 }
 
 func (this *GetPulses) Equal(that interface{}) bool {
@@ -195,6 +213,9 @@ func (this *GetPulses) Equal(that interface{}) bool {
 	if this.Count != that1.Count {
 		return false
 	}
+	if this.Follow != that1.Follow {
+		return false
+	}
 	return true
 }
 func (this *Pulse) Equal(that interface{}) bool {
@@ -228,17 +249,21 @@ func (this *Pulse) Equal(that interface{}) bool {
 	if this.PulseTimestamp != that1.PulseTimestamp {
 		return false
 	}
+	if this.Heartbeat != that1.Heartbeat {
+		return false
+	}
 	return true
 }
 func (this *GetPulses) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 7)
+	s := make([]string, 0, 8)
 	s = append(s, "&exporter.GetPulses{")
 	s = append(s, "Polymorph: "+fmt.Sprintf("%#v", this.Polymorph)+",\n")
 	s = append(s, "PulseNumber: "+fmt.Sprintf("%#v", this.PulseNumber)+",\n")
 	s = append(s, "Count: "+fmt.Sprintf("%#v", this.Count)+",\n")
+	s = append(s, "Follow: "+fmt.Sprintf("%#v", this.Follow)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -246,12 +271,13 @@ func (this *Pulse) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 8)
+	s := make([]string, 0, 9)
 	s = append(s, "&exporter.Pulse{")
 	s = append(s, "Polymorph: "+fmt.Sprintf("%#v", this.Polymorph)+",\n")
 	s = append(s, "PulseNumber: "+fmt.Sprintf("%#v", this.PulseNumber)+",\n")
 	s = append(s, "Entropy: "+fmt.Sprintf("%#v", this.Entropy)+",\n")
 	s = append(s, "PulseTimestamp: "+fmt.Sprintf("%#v", this.PulseTimestamp)+",\n")
+	s = append(s, "Heartbeat: "+fmt.Sprintf("%#v", this.Heartbeat)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -402,6 +428,18 @@ func (m *GetPulses) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintPulseExporter(dAtA, i, uint64(m.Count))
 	}
+	if m.Follow {
+		dAtA[i] = 0xb8
+		i++
+		dAtA[i] = 0x1
+		i++
+		if m.Follow {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -454,6 +492,18 @@ func (m *Pulse) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintPulseExporter(dAtA, i, uint64(m.PulseTimestamp))
 	}
+	if m.Heartbeat {
+		dAtA[i] = 0xb8
+		i++
+		dAtA[i] = 0x1
+		i++
+		if m.Heartbeat {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -480,6 +530,9 @@ func (m *GetPulses) Size() (n int) {
 	if m.Count != 0 {
 		n += 2 + sovPulseExporter(uint64(m.Count))
 	}
+	if m.Follow {
+		n += 3
+	}
 	return n
 }
 
@@ -499,6 +552,9 @@ func (m *Pulse) Size() (n int) {
 	if m.PulseTimestamp != 0 {
 		n += 2 + sovPulseExporter(uint64(m.PulseTimestamp))
 	}
+	if m.Heartbeat {
+		n += 3
+	}
 	return n
 }
 
@@ -523,6 +579,7 @@ func (this *GetPulses) String() string {
 		`Polymorph:` + fmt.Sprintf("%v", this.Polymorph) + `,`,
 		`PulseNumber:` + fmt.Sprintf("%v", this.PulseNumber) + `,`,
 		`Count:` + fmt.Sprintf("%v", this.Count) + `,`,
+		`Follow:` + fmt.Sprintf("%v", this.Follow) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -536,6 +593,7 @@ func (this *Pulse) String() string {
 		`PulseNumber:` + fmt.Sprintf("%v", this.PulseNumber) + `,`,
 		`Entropy:` + fmt.Sprintf("%v", this.Entropy) + `,`,
 		`PulseTimestamp:` + fmt.Sprintf("%v", this.PulseTimestamp) + `,`,
+		`Heartbeat:` + fmt.Sprintf("%v", this.Heartbeat) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -648,6 +706,26 @@ func (m *GetPulses) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 23:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Follow", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPulseExporter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Follow = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPulseExporter(dAtA[iNdEx:])
@@ -805,6 +883,26 @@ func (m *Pulse) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 23:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Heartbeat", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPulseExporter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Heartbeat = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPulseExporter(dAtA[iNdEx:])
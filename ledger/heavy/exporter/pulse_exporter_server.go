@@ -29,20 +29,27 @@ import (
 type PulseServer struct {
 	pulses    insolarPulse.Calculator
 	jetKeeper executor.JetKeeper
+	waiter    *syncWaiter
 }
 
 func NewPulseServer(pulses insolarPulse.Calculator, jetKeeper executor.JetKeeper) *PulseServer {
 	return &PulseServer{
 		pulses:    pulses,
 		jetKeeper: jetKeeper,
+		waiter:    newSyncWaiter(jetKeeper),
 	}
 }
 
+// Stop ends all the streams in follow mode.
+func (p *PulseServer) Stop() {
+	p.waiter.stop()
+}
+
 func (p *PulseServer) Export(getPulses *GetPulses, stream PulseExporter_ExportServer) error {
 	ctx := stream.Context()
 	logger := inslogger.FromContext(ctx)
 
-	if getPulses.Count == 0 {
+	if getPulses.Count == 0 && !getPulses.Follow {
 		return errors.New("count can't be 0")
 	}
 
@@ -61,10 +68,24 @@ func (p *PulseServer) Export(getPulses *GetPulses, stream PulseExporter_ExportSe
 		read++
 	}
 	currentPN := getPulses.PulseNumber
-	for read < getPulses.Count {
+	for getPulses.Count == 0 || read < getPulses.Count {
 		topPulse := p.jetKeeper.TopSyncPulse()
 		if currentPN >= topPulse {
-			return nil
+			if !getPulses.Follow {
+				return nil
+			}
+
+			ok, err := p.waiter.waitAfter(ctx, currentPN, func() error {
+				return stream.Send(&Pulse{Heartbeat: true})
+			})
+			if err != nil {
+				logger.Error(err)
+				return err
+			}
+			if !ok {
+				return nil
+			}
+			continue
 		}
 
 		pulse, err := p.pulses.Forwards(ctx, currentPN, 1)
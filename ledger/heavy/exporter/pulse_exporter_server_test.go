@@ -18,6 +18,7 @@ package exporter
 
 import (
 	"context"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -162,4 +163,55 @@ func TestPulseServer_Export(t *testing.T) {
 		require.Equal(t, 1, len(pulses))
 		require.Equal(t, pulse.MinTimePulse, int(pulses[0]))
 	})
+
+	t.Run("follow mode. pushes new pulses and ends on stop", func(t *testing.T) {
+		received := make(chan insolar.PulseNumber, 10)
+		pulseGatherer := func(p *Pulse) error {
+			if !p.Heartbeat {
+				received <- p.PulseNumber
+			}
+			return nil
+		}
+		stream := pulseStreamMock{checker: pulseGatherer}
+
+		pulseCalculator := network.NewPulseCalculatorMock(t)
+		pulseCalculator.ForwardsMock.When(context.TODO(), pulse.MinTimePulse, 1).Then(insolar.Pulse{PulseNumber: pulse.MinTimePulse + 1}, nil)
+
+		var (
+			lock    sync.Mutex
+			top     = insolar.PulseNumber(pulse.MinTimePulse)
+			changed = make(chan struct{})
+		)
+		jetKeeper := executor.NewJetKeeperMock(t)
+		jetKeeper.TopSyncPulseMock.Set(func() insolar.PulseNumber {
+			lock.Lock()
+			defer lock.Unlock()
+			return top
+		})
+		jetKeeper.TopSyncChangedMock.Set(func() <-chan struct{} {
+			lock.Lock()
+			defer lock.Unlock()
+			return changed
+		})
+
+		server := NewPulseServer(pulseCalculator, jetKeeper)
+
+		done := make(chan error)
+		go func() {
+			done <- server.Export(&GetPulses{PulseNumber: 0, Follow: true}, &stream)
+		}()
+
+		require.Equal(t, insolar.PulseNumber(pulse.MinTimePulse), <-received)
+
+		lock.Lock()
+		top = pulse.MinTimePulse + 1
+		close(changed)
+		changed = make(chan struct{})
+		lock.Unlock()
+
+		require.Equal(t, insolar.PulseNumber(pulse.MinTimePulse+1), <-received)
+
+		server.Stop()
+		require.NoError(t, <-done)
+	})
 }
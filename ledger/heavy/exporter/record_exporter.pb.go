@@ -4,6 +4,7 @@
 package exporter
 
 import (
+	bytes "bytes"
 	context "context"
 	fmt "fmt"
 	_ "github.com/gogo/protobuf/gogoproto"
@@ -14,6 +15,7 @@ import (
 	io "io"
 	math "math"
 	reflect "reflect"
+	strconv "strconv"
 	strings "strings"
 )
 
@@ -28,11 +30,62 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
+type RecordType int32
+
+const (
+	TypeUnknown         RecordType = 0
+	TypeGenesis         RecordType = 1
+	TypeIncomingRequest RecordType = 2
+	TypeOutgoingRequest RecordType = 3
+	TypeResult          RecordType = 4
+	TypeCode            RecordType = 5
+	TypeActivate        RecordType = 6
+	TypeAmend           RecordType = 7
+	TypeDeactivate      RecordType = 8
+	TypePendingFilament RecordType = 9
+)
+
+var RecordType_name = map[int32]string{
+	0: "TypeUnknown",
+	1: "TypeGenesis",
+	2: "TypeIncomingRequest",
+	3: "TypeOutgoingRequest",
+	4: "TypeResult",
+	5: "TypeCode",
+	6: "TypeActivate",
+	7: "TypeAmend",
+	8: "TypeDeactivate",
+	9: "TypePendingFilament",
+}
+
+var RecordType_value = map[string]int32{
+	"TypeUnknown":         0,
+	"TypeGenesis":         1,
+	"TypeIncomingRequest": 2,
+	"TypeOutgoingRequest": 3,
+	"TypeResult":          4,
+	"TypeCode":            5,
+	"TypeActivate":        6,
+	"TypeAmend":           7,
+	"TypeDeactivate":      8,
+	"TypePendingFilament": 9,
+}
+
+func (RecordType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_dfb4fbd68f50939d, []int{0}
+}
+
 type GetRecords struct {
 	Polymorph    uint32                                         `protobuf:"varint,16,opt,name=Polymorph,proto3" json:"Polymorph,omitempty"`
 	PulseNumber  github_com_insolar_insolar_insolar.PulseNumber `protobuf:"bytes,20,opt,name=PulseNumber,proto3,customtype=github.com/insolar/insolar/insolar.PulseNumber" json:"PulseNumber"`
 	RecordNumber uint32                                         `protobuf:"varint,21,opt,name=RecordNumber,proto3" json:"RecordNumber,omitempty"`
 	Count        uint32                                         `protobuf:"varint,22,opt,name=Count,proto3" json:"Count,omitempty"`
+	Cursor       []byte                                         `protobuf:"bytes,23,opt,name=Cursor,proto3" json:"Cursor,omitempty"`
+	ObjectIDs    []github_com_insolar_insolar_insolar.ID        `protobuf:"bytes,24,rep,name=ObjectIDs,proto3,customtype=github.com/insolar/insolar/insolar.ID" json:"ObjectIDs"`
+	Prototypes   []github_com_insolar_insolar_insolar.Reference `protobuf:"bytes,25,rep,name=Prototypes,proto3,customtype=github.com/insolar/insolar/insolar.Reference" json:"Prototypes"`
+	RecordTypes  []RecordType                                   `protobuf:"varint,26,rep,packed,name=RecordTypes,proto3,enum=exporter.RecordType" json:"RecordTypes,omitempty"`
+	JetIDs       []github_com_insolar_insolar_insolar.JetID     `protobuf:"bytes,27,rep,name=JetIDs,proto3,customtype=github.com/insolar/insolar/insolar.JetID" json:"JetIDs"`
+	Follow       bool                                           `protobuf:"varint,28,opt,name=Follow,proto3" json:"Follow,omitempty"`
 }
 
 func (m *GetRecords) Reset()      { *m = GetRecords{} }
@@ -88,10 +141,33 @@ func (m *GetRecords) GetCount() uint32 {
 	return 0
 }
 
+func (m *GetRecords) GetCursor() []byte {
+	if m != nil {
+		return m.Cursor
+	}
+	return nil
+}
+
+func (m *GetRecords) GetRecordTypes() []RecordType {
+	if m != nil {
+		return m.RecordTypes
+	}
+	return nil
+}
+
+func (m *GetRecords) GetFollow() bool {
+	if m != nil {
+		return m.Follow
+	}
+	return false
+}
+
 type Record struct {
 	Polymorph    uint32          `protobuf:"varint,16,opt,name=Polymorph,proto3" json:"Polymorph,omitempty"`
 	RecordNumber uint32          `protobuf:"varint,20,opt,name=RecordNumber,proto3" json:"RecordNumber,omitempty"`
 	Record       record.Material `protobuf:"bytes,21,opt,name=Record,proto3" json:"Record"`
+	Cursor       []byte          `protobuf:"bytes,22,opt,name=Cursor,proto3" json:"Cursor,omitempty"`
+	Heartbeat    bool            `protobuf:"varint,23,opt,name=Heartbeat,proto3" json:"Heartbeat,omitempty"`
 }
 
 func (m *Record) Reset()      { *m = Record{} }
@@ -147,9 +223,77 @@ func (m *Record) GetRecord() record.Material {
 	return record.Material{}
 }
 
+func (m *Record) GetCursor() []byte {
+	if m != nil {
+		return m.Cursor
+	}
+	return nil
+}
+
+func (m *Record) GetHeartbeat() bool {
+	if m != nil {
+		return m.Heartbeat
+	}
+	return false
+}
+
+type Cursor struct {
+	Polymorph    uint32                                         `protobuf:"varint,16,opt,name=Polymorph,proto3" json:"Polymorph,omitempty"`
+	PulseNumber  github_com_insolar_insolar_insolar.PulseNumber `protobuf:"bytes,20,opt,name=PulseNumber,proto3,customtype=github.com/insolar/insolar/insolar.PulseNumber" json:"PulseNumber"`
+	RecordNumber uint32                                         `protobuf:"varint,21,opt,name=RecordNumber,proto3" json:"RecordNumber,omitempty"`
+}
+
+func (m *Cursor) Reset()      { *m = Cursor{} }
+func (*Cursor) ProtoMessage() {}
+func (*Cursor) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfb4fbd68f50939d, []int{2}
+}
+func (m *Cursor) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Cursor) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Cursor.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Cursor) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Cursor.Merge(m, src)
+}
+func (m *Cursor) XXX_Size() int {
+	return m.Size()
+}
+func (m *Cursor) XXX_DiscardUnknown() {
+	xxx_messageInfo_Cursor.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Cursor proto.InternalMessageInfo
+
+func (m *Cursor) GetPolymorph() uint32 {
+	if m != nil {
+		return m.Polymorph
+	}
+	return 0
+}
+
+func (m *Cursor) GetRecordNumber() uint32 {
+	if m != nil {
+		return m.RecordNumber
+	}
+	return 0
+}
+
 func init() {
+	proto.RegisterEnum("exporter.RecordType", RecordType_name, RecordType_value)
 	proto.RegisterType((*GetRecords)(nil), "exporter.GetRecords")
 	proto.RegisterType((*Record)(nil), "exporter.Record")
+	proto.RegisterType((*Cursor)(nil), "exporter.Cursor")
 }
 
 func init() {
@@ -157,32 +301,57 @@ func init() {
 }
 
 var fileDescriptor_dfb4fbd68f50939d = []byte{
-	// 354 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xd2, 0xce, 0x49, 0x4d, 0x49,
-	0x4f, 0x2d, 0xd2, 0xcf, 0x48, 0x4d, 0x2c, 0xab, 0xd4, 0x4f, 0xad, 0x28, 0xc8, 0x2f, 0x2a, 0x49,
-	0x2d, 0xd2, 0x2f, 0x4a, 0x4d, 0xce, 0x2f, 0x4a, 0x89, 0x87, 0xf1, 0xf5, 0x0a, 0x8a, 0xf2, 0x4b,
-	0xf2, 0x85, 0x38, 0x60, 0x7c, 0x29, 0xdd, 0xf4, 0xcc, 0x92, 0x8c, 0xd2, 0x24, 0xbd, 0xe4, 0xfc,
-	0x5c, 0xfd, 0xf4, 0xfc, 0xf4, 0x7c, 0x7d, 0xb0, 0x82, 0xa4, 0xd2, 0x34, 0x30, 0x0f, 0xcc, 0x01,
-	0xb3, 0x20, 0x1a, 0xa5, 0xcc, 0x90, 0x94, 0x67, 0xe6, 0x15, 0xe7, 0xe7, 0x24, 0x16, 0x61, 0xd0,
-	0x10, 0x2b, 0xa1, 0x14, 0x44, 0x9f, 0xd2, 0x3e, 0x46, 0x2e, 0x2e, 0xf7, 0xd4, 0x92, 0x20, 0xb0,
-	0x58, 0xb1, 0x90, 0x0c, 0x17, 0x67, 0x40, 0x7e, 0x4e, 0x65, 0x6e, 0x7e, 0x51, 0x41, 0x86, 0x84,
-	0x80, 0x02, 0xa3, 0x06, 0x6f, 0x10, 0x42, 0x40, 0x28, 0x82, 0x8b, 0x3b, 0xa0, 0x34, 0xa7, 0x38,
-	0xd5, 0xaf, 0x34, 0x37, 0x29, 0xb5, 0x48, 0x42, 0x44, 0x81, 0x51, 0x83, 0xc7, 0xc9, 0xec, 0xc4,
-	0x3d, 0x79, 0x86, 0x5b, 0xf7, 0xe4, 0xf5, 0x08, 0xbb, 0x40, 0x0f, 0x49, 0x77, 0x10, 0xb2, 0x51,
-	0x42, 0x4a, 0x5c, 0x3c, 0x10, 0x27, 0x40, 0x8d, 0x16, 0x05, 0x5b, 0x8d, 0x22, 0x26, 0x24, 0xc2,
-	0xc5, 0xea, 0x9c, 0x5f, 0x9a, 0x57, 0x22, 0x21, 0x06, 0x96, 0x84, 0x70, 0x94, 0xaa, 0xb8, 0xd8,
-	0x20, 0xaa, 0x08, 0xb8, 0x1d, 0xdd, 0x06, 0x11, 0x2c, 0x36, 0xe8, 0xc1, 0xcc, 0x02, 0xdb, 0xcf,
-	0x6d, 0x24, 0xa0, 0x07, 0x0d, 0x2b, 0xdf, 0xc4, 0x92, 0xd4, 0xa2, 0xcc, 0xc4, 0x1c, 0x27, 0x16,
-	0x90, 0x67, 0x83, 0xa0, 0xaa, 0x8c, 0xdc, 0xb8, 0xf8, 0x20, 0x2c, 0x57, 0x68, 0xac, 0x09, 0x99,
-	0x70, 0xb1, 0x41, 0xd8, 0x42, 0x22, 0x7a, 0xf0, 0xa8, 0x45, 0x84, 0xaf, 0x94, 0x00, 0x42, 0x14,
-	0x22, 0xa4, 0xc4, 0x60, 0xc0, 0xe8, 0x64, 0x72, 0xe1, 0xa1, 0x1c, 0xc3, 0x8d, 0x87, 0x72, 0x0c,
-	0x1f, 0x1e, 0xca, 0x31, 0x36, 0x3c, 0x92, 0x63, 0x5c, 0xf1, 0x48, 0x8e, 0xf1, 0xc4, 0x23, 0x39,
-	0xc6, 0x0b, 0x8f, 0xe4, 0x18, 0x1f, 0x3c, 0x92, 0x63, 0x7c, 0xf1, 0x48, 0x8e, 0xe1, 0xc3, 0x23,
-	0x39, 0xc6, 0x09, 0x8f, 0xe5, 0x18, 0x2e, 0x3c, 0x96, 0x63, 0xb8, 0xf1, 0x58, 0x8e, 0x21, 0x89,
-	0x0d, 0x1c, 0x83, 0xc6, 0x80, 0x00, 0x00, 0x00, 0xff, 0xff, 0x4a, 0x91, 0xa4, 0xb9, 0x61, 0x02,
-	0x00, 0x00,
+	// 644 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x54, 0xcd, 0x6e, 0xd3, 0x4a,
+	0x14, 0xf6, 0xdc, 0xb6, 0xb9, 0xc9, 0x49, 0x9a, 0x6b, 0xcd, 0x0d, 0xad, 0x09, 0x95, 0x1b, 0x45,
+	0x42, 0x8a, 0x80, 0x26, 0x55, 0xa9, 0xba, 0x27, 0x2d, 0xfd, 0x01, 0x41, 0xa3, 0x51, 0x91, 0xd8,
+	0x21, 0x27, 0x39, 0x75, 0x0d, 0x8e, 0x27, 0x8c, 0xc7, 0x2d, 0xdd, 0xf1, 0x08, 0x48, 0xbc, 0x02,
+	0x0b, 0x96, 0x3c, 0x46, 0x17, 0x2c, 0xba, 0xac, 0x58, 0x54, 0xd4, 0xdd, 0xb0, 0xec, 0x23, 0x20,
+	0x8f, 0x6d, 0xec, 0x16, 0xa4, 0x76, 0xc9, 0x6a, 0xe6, 0x3b, 0x3f, 0xdf, 0xf9, 0xce, 0x9c, 0xa3,
+	0x81, 0xfb, 0x2e, 0x0e, 0x6d, 0x14, 0x9d, 0x3d, 0xb4, 0xf6, 0x0f, 0x3b, 0xf8, 0x6e, 0xcc, 0x85,
+	0x44, 0xd1, 0x11, 0x38, 0xe0, 0x62, 0xf8, 0x2a, 0xc5, 0xed, 0xb1, 0xe0, 0x92, 0xd3, 0x62, 0x8a,
+	0xeb, 0x0b, 0xb6, 0x23, 0xf7, 0x82, 0x7e, 0x7b, 0xc0, 0x47, 0x1d, 0x9b, 0xdb, 0xbc, 0xa3, 0x02,
+	0xfa, 0xc1, 0xae, 0x42, 0x0a, 0xa8, 0x5b, 0x9c, 0x58, 0x5f, 0xc9, 0x85, 0x3b, 0x9e, 0xcf, 0x5d,
+	0x4b, 0xfc, 0x76, 0xc6, 0x25, 0x93, 0x23, 0xce, 0x6b, 0x7e, 0x9c, 0x04, 0xd8, 0x40, 0xc9, 0x94,
+	0xcd, 0xa7, 0x73, 0x50, 0xea, 0x71, 0xf7, 0x70, 0xc4, 0xc5, 0x78, 0xcf, 0xd0, 0x1b, 0xa4, 0x35,
+	0xcd, 0x32, 0x03, 0x7d, 0x09, 0xe5, 0x5e, 0xe0, 0xfa, 0xf8, 0x3c, 0x18, 0xf5, 0x51, 0x18, 0xb5,
+	0x06, 0x69, 0x55, 0xba, 0x2b, 0x47, 0xa7, 0xf3, 0xda, 0xb7, 0xd3, 0xf9, 0xf6, 0xf5, 0x0a, 0xda,
+	0xb9, 0x6c, 0x96, 0xa7, 0xa2, 0x4d, 0xa8, 0xc4, 0x12, 0x12, 0xea, 0x5b, 0xaa, 0xf4, 0x25, 0x1b,
+	0xad, 0xc1, 0xd4, 0x2a, 0x0f, 0x3c, 0x69, 0xcc, 0x28, 0x67, 0x0c, 0xe8, 0x0c, 0x14, 0x56, 0x03,
+	0xe1, 0x73, 0x61, 0xcc, 0x46, 0x72, 0x58, 0x82, 0xe8, 0x53, 0x28, 0x6d, 0xf7, 0x5f, 0xe3, 0x40,
+	0x6e, 0xad, 0xf9, 0x86, 0xd1, 0x98, 0x68, 0x55, 0xba, 0x0b, 0x89, 0xd2, 0xbb, 0x37, 0x50, 0xba,
+	0xb5, 0xc6, 0xb2, 0x7c, 0xba, 0x03, 0xd0, 0x8b, 0x9e, 0x4b, 0x1e, 0x8e, 0xd1, 0x37, 0x6e, 0x2b,
+	0xb6, 0xe5, 0x84, 0xed, 0xc1, 0x0d, 0xd8, 0x18, 0xee, 0xa2, 0x40, 0x6f, 0x80, 0x2c, 0xc7, 0x43,
+	0x57, 0xa0, 0x1c, 0x37, 0xb8, 0xa3, 0x68, 0xeb, 0x8d, 0x89, 0x56, 0x75, 0xa9, 0xd6, 0xfe, 0xb5,
+	0x12, 0x99, 0x93, 0xe5, 0x03, 0xe9, 0x26, 0x14, 0x9e, 0xa0, 0xea, 0xeb, 0x8e, 0x52, 0xb2, 0x98,
+	0x28, 0x69, 0xdd, 0x40, 0x89, 0x4a, 0x64, 0x49, 0x7e, 0xf4, 0x78, 0xeb, 0xdc, 0x75, 0xf9, 0x81,
+	0x31, 0xd7, 0x20, 0xad, 0x22, 0x4b, 0x50, 0xf3, 0x0b, 0x81, 0x42, 0x5c, 0xf1, 0x9a, 0x8d, 0xb8,
+	0x3a, 0xb7, 0xda, 0x1f, 0xe6, 0xd6, 0x4e, 0xb9, 0xd4, 0x54, 0xcb, 0x4b, 0x7a, 0x3b, 0xd9, 0xc0,
+	0x67, 0x96, 0x44, 0xe1, 0x58, 0x6e, 0x77, 0x32, 0x6a, 0x80, 0xa5, 0x15, 0xb3, 0x89, 0xce, 0x5c,
+	0x9a, 0xe8, 0x1c, 0x94, 0x36, 0xd1, 0x12, 0xb2, 0x8f, 0x96, 0x54, 0xc3, 0x2e, 0xb2, 0xcc, 0xd0,
+	0xfc, 0x44, 0x20, 0x17, 0xf8, 0xb7, 0x2e, 0xf1, 0xbd, 0xaf, 0x04, 0x20, 0x9b, 0x25, 0xfd, 0x0f,
+	0xca, 0xd1, 0xf9, 0xc2, 0x7b, 0xe3, 0xf1, 0x03, 0x4f, 0xd7, 0x52, 0xc3, 0x06, 0x7a, 0xe8, 0x3b,
+	0xbe, 0x4e, 0xe8, 0x2c, 0xfc, 0x1f, 0x19, 0xb6, 0xbc, 0x01, 0x1f, 0x39, 0x9e, 0xcd, 0xf0, 0x6d,
+	0x80, 0xbe, 0xd4, 0xff, 0x49, 0x1d, 0xdb, 0x81, 0xb4, 0x79, 0xce, 0x31, 0x41, 0xab, 0x00, 0x91,
+	0x83, 0xa1, 0x1f, 0xb8, 0x52, 0x9f, 0xa4, 0x15, 0x28, 0x46, 0x78, 0x95, 0x0f, 0x51, 0x9f, 0xa2,
+	0x3a, 0x54, 0x22, 0xf4, 0x68, 0x20, 0x9d, 0x7d, 0x4b, 0xa2, 0x5e, 0xa0, 0xd3, 0x50, 0x52, 0x96,
+	0x11, 0x7a, 0x43, 0xfd, 0x5f, 0x4a, 0xa1, 0x1a, 0xc1, 0x35, 0xb4, 0xd2, 0x90, 0x62, 0x5a, 0xab,
+	0x87, 0xde, 0xd0, 0xf1, 0xec, 0x75, 0xc7, 0xb5, 0x46, 0xe8, 0x49, 0xbd, 0xb4, 0xb4, 0x0e, 0xd5,
+	0xb8, 0x9b, 0xc7, 0xc9, 0xd2, 0xd2, 0x65, 0x28, 0xc4, 0x77, 0x9a, 0xdb, 0xe4, 0xec, 0x87, 0xa9,
+	0xeb, 0x57, 0xf7, 0xbb, 0xa9, 0x2d, 0x92, 0xee, 0xf2, 0xf1, 0x99, 0xa9, 0x9d, 0x9c, 0x99, 0xda,
+	0xc5, 0x99, 0x49, 0xde, 0x87, 0x26, 0xf9, 0x1c, 0x9a, 0xe4, 0x28, 0x34, 0xc9, 0x71, 0x68, 0x92,
+	0xef, 0xa1, 0x49, 0x7e, 0x84, 0xa6, 0x76, 0x11, 0x9a, 0xe4, 0xc3, 0xb9, 0xa9, 0x1d, 0x9f, 0x9b,
+	0xda, 0xc9, 0xb9, 0xa9, 0xf5, 0x0b, 0xea, 0x0f, 0x7b, 0xf8, 0x73, 0x00, 0x44, 0x63, 0x1d, 0x00,
+	0x63, 0x05, 0x00, 0x00,
 }
 
+func (x RecordType) String() string {
+	s, ok := RecordType_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
 func (this *GetRecords) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
@@ -214,6 +383,44 @@ func (this *GetRecords) Equal(that interface{}) bool {
 	if this.Count != that1.Count {
 		return false
 	}
+	if !bytes.Equal(this.Cursor, that1.Cursor) {
+		return false
+	}
+	if len(this.ObjectIDs) != len(that1.ObjectIDs) {
+		return false
+	}
+	for i := range this.ObjectIDs {
+		if !this.ObjectIDs[i].Equal(that1.ObjectIDs[i]) {
+			return false
+		}
+	}
+	if len(this.Prototypes) != len(that1.Prototypes) {
+		return false
+	}
+	for i := range this.Prototypes {
+		if !this.Prototypes[i].Equal(that1.Prototypes[i]) {
+			return false
+		}
+	}
+	if len(this.RecordTypes) != len(that1.RecordTypes) {
+		return false
+	}
+	for i := range this.RecordTypes {
+		if this.RecordTypes[i] != that1.RecordTypes[i] {
+			return false
+		}
+	}
+	if len(this.JetIDs) != len(that1.JetIDs) {
+		return false
+	}
+	for i := range this.JetIDs {
+		if !this.JetIDs[i].Equal(that1.JetIDs[i]) {
+			return false
+		}
+	}
+	if this.Follow != that1.Follow {
+		return false
+	}
 	return true
 }
 func (this *Record) Equal(that interface{}) bool {
@@ -244,18 +451,60 @@ func (this *Record) Equal(that interface{}) bool {
 	if !this.Record.Equal(&that1.Record) {
 		return false
 	}
+	if !bytes.Equal(this.Cursor, that1.Cursor) {
+		return false
+	}
+	if this.Heartbeat != that1.Heartbeat {
+		return false
+	}
+	return true
+}
+func (this *Cursor) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Cursor)
+	if !ok {
+		that2, ok := that.(Cursor)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Polymorph != that1.Polymorph {
+		return false
+	}
+	if !this.PulseNumber.Equal(that1.PulseNumber) {
+		return false
+	}
+	if this.RecordNumber != that1.RecordNumber {
+		return false
+	}
 	return true
 }
 func (this *GetRecords) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 8)
+	s := make([]string, 0, 14)
 	s = append(s, "&exporter.GetRecords{")
 	s = append(s, "Polymorph: "+fmt.Sprintf("%#v", this.Polymorph)+",\n")
 	s = append(s, "PulseNumber: "+fmt.Sprintf("%#v", this.PulseNumber)+",\n")
 	s = append(s, "RecordNumber: "+fmt.Sprintf("%#v", this.RecordNumber)+",\n")
 	s = append(s, "Count: "+fmt.Sprintf("%#v", this.Count)+",\n")
+	s = append(s, "Cursor: "+fmt.Sprintf("%#v", this.Cursor)+",\n")
+	s = append(s, "ObjectIDs: "+fmt.Sprintf("%#v", this.ObjectIDs)+",\n")
+	s = append(s, "Prototypes: "+fmt.Sprintf("%#v", this.Prototypes)+",\n")
+	s = append(s, "RecordTypes: "+fmt.Sprintf("%#v", this.RecordTypes)+",\n")
+	s = append(s, "JetIDs: "+fmt.Sprintf("%#v", this.JetIDs)+",\n")
+	s = append(s, "Follow: "+fmt.Sprintf("%#v", this.Follow)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -263,11 +512,25 @@ func (this *Record) GoString() string {
 	if this == nil {
 		return "nil"
 	}
-	s := make([]string, 0, 7)
+	s := make([]string, 0, 9)
 	s = append(s, "&exporter.Record{")
 	s = append(s, "Polymorph: "+fmt.Sprintf("%#v", this.Polymorph)+",\n")
 	s = append(s, "RecordNumber: "+fmt.Sprintf("%#v", this.RecordNumber)+",\n")
 	s = append(s, "Record: "+strings.Replace(this.Record.GoString(), `&`, ``, 1)+",\n")
+	s = append(s, "Cursor: "+fmt.Sprintf("%#v", this.Cursor)+",\n")
+	s = append(s, "Heartbeat: "+fmt.Sprintf("%#v", this.Heartbeat)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Cursor) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 7)
+	s = append(s, "&exporter.Cursor{")
+	s = append(s, "Polymorph: "+fmt.Sprintf("%#v", this.Polymorph)+",\n")
+	s = append(s, "PulseNumber: "+fmt.Sprintf("%#v", this.PulseNumber)+",\n")
+	s = append(s, "RecordNumber: "+fmt.Sprintf("%#v", this.RecordNumber)+",\n")
 	s = append(s, "}")
 	return strings.Join(s, "")
 }
@@ -425,6 +688,87 @@ func (m *GetRecords) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintRecordExporter(dAtA, i, uint64(m.Count))
 	}
+	if len(m.Cursor) > 0 {
+		dAtA[i] = 0xba
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintRecordExporter(dAtA, i, uint64(len(m.Cursor)))
+		i += copy(dAtA[i:], m.Cursor)
+	}
+	if len(m.ObjectIDs) > 0 {
+		for _, msg := range m.ObjectIDs {
+			dAtA[i] = 0xc2
+			i++
+			dAtA[i] = 0x1
+			i++
+			i = encodeVarintRecordExporter(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Prototypes) > 0 {
+		for _, msg := range m.Prototypes {
+			dAtA[i] = 0xca
+			i++
+			dAtA[i] = 0x1
+			i++
+			i = encodeVarintRecordExporter(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.RecordTypes) > 0 {
+		dAtA3 := make([]byte, len(m.RecordTypes)*10)
+		var j2 int
+		for _, num := range m.RecordTypes {
+			for num >= 1<<7 {
+				dAtA3[j2] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j2++
+			}
+			dAtA3[j2] = uint8(num)
+			j2++
+		}
+		dAtA[i] = 0xd2
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintRecordExporter(dAtA, i, uint64(j2))
+		i += copy(dAtA[i:], dAtA3[:j2])
+	}
+	if len(m.JetIDs) > 0 {
+		for _, msg := range m.JetIDs {
+			dAtA[i] = 0xda
+			i++
+			dAtA[i] = 0x1
+			i++
+			i = encodeVarintRecordExporter(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Follow {
+		dAtA[i] = 0xe0
+		i++
+		dAtA[i] = 0x1
+		i++
+		if m.Follow {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -462,11 +806,73 @@ func (m *Record) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintRecordExporter(dAtA, i, uint64(m.Record.Size()))
-	n2, err := m.Record.MarshalTo(dAtA[i:])
+	n4, err := m.Record.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n4
+	if len(m.Cursor) > 0 {
+		dAtA[i] = 0xb2
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintRecordExporter(dAtA, i, uint64(len(m.Cursor)))
+		i += copy(dAtA[i:], m.Cursor)
+	}
+	if m.Heartbeat {
+		dAtA[i] = 0xb8
+		i++
+		dAtA[i] = 0x1
+		i++
+		if m.Heartbeat {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *Cursor) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Cursor) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Polymorph != 0 {
+		dAtA[i] = 0x80
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintRecordExporter(dAtA, i, uint64(m.Polymorph))
+	}
+	dAtA[i] = 0xa2
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRecordExporter(dAtA, i, uint64(m.PulseNumber.Size()))
+	n5, err := m.PulseNumber.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n2
+	i += n5
+	if m.RecordNumber != 0 {
+		dAtA[i] = 0xa8
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintRecordExporter(dAtA, i, uint64(m.RecordNumber))
+	}
 	return i, nil
 }
 
@@ -496,6 +902,38 @@ func (m *GetRecords) Size() (n int) {
 	if m.Count != 0 {
 		n += 2 + sovRecordExporter(uint64(m.Count))
 	}
+	l = len(m.Cursor)
+	if l > 0 {
+		n += 2 + l + sovRecordExporter(uint64(l))
+	}
+	if len(m.ObjectIDs) > 0 {
+		for _, e := range m.ObjectIDs {
+			l = e.Size()
+			n += 2 + l + sovRecordExporter(uint64(l))
+		}
+	}
+	if len(m.Prototypes) > 0 {
+		for _, e := range m.Prototypes {
+			l = e.Size()
+			n += 2 + l + sovRecordExporter(uint64(l))
+		}
+	}
+	if len(m.RecordTypes) > 0 {
+		l = 0
+		for _, e := range m.RecordTypes {
+			l += sovRecordExporter(uint64(e))
+		}
+		n += 2 + sovRecordExporter(uint64(l)) + l
+	}
+	if len(m.JetIDs) > 0 {
+		for _, e := range m.JetIDs {
+			l = e.Size()
+			n += 2 + l + sovRecordExporter(uint64(l))
+		}
+	}
+	if m.Follow {
+		n += 3
+	}
 	return n
 }
 
@@ -513,6 +951,30 @@ func (m *Record) Size() (n int) {
 	}
 	l = m.Record.Size()
 	n += 2 + l + sovRecordExporter(uint64(l))
+	l = len(m.Cursor)
+	if l > 0 {
+		n += 2 + l + sovRecordExporter(uint64(l))
+	}
+	if m.Heartbeat {
+		n += 3
+	}
+	return n
+}
+
+func (m *Cursor) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Polymorph != 0 {
+		n += 2 + sovRecordExporter(uint64(m.Polymorph))
+	}
+	l = m.PulseNumber.Size()
+	n += 2 + l + sovRecordExporter(uint64(l))
+	if m.RecordNumber != 0 {
+		n += 2 + sovRecordExporter(uint64(m.RecordNumber))
+	}
 	return n
 }
 
@@ -538,6 +1000,12 @@ func (this *GetRecords) String() string {
 		`PulseNumber:` + fmt.Sprintf("%v", this.PulseNumber) + `,`,
 		`RecordNumber:` + fmt.Sprintf("%v", this.RecordNumber) + `,`,
 		`Count:` + fmt.Sprintf("%v", this.Count) + `,`,
+		`Cursor:` + fmt.Sprintf("%v", this.Cursor) + `,`,
+		`ObjectIDs:` + fmt.Sprintf("%v", this.ObjectIDs) + `,`,
+		`Prototypes:` + fmt.Sprintf("%v", this.Prototypes) + `,`,
+		`RecordTypes:` + fmt.Sprintf("%v", this.RecordTypes) + `,`,
+		`JetIDs:` + fmt.Sprintf("%v", this.JetIDs) + `,`,
+		`Follow:` + fmt.Sprintf("%v", this.Follow) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -550,6 +1018,20 @@ func (this *Record) String() string {
 		`Polymorph:` + fmt.Sprintf("%v", this.Polymorph) + `,`,
 		`RecordNumber:` + fmt.Sprintf("%v", this.RecordNumber) + `,`,
 		`Record:` + strings.Replace(strings.Replace(this.Record.String(), "Material", "record.Material", 1), `&`, ``, 1) + `,`,
+		`Cursor:` + fmt.Sprintf("%v", this.Cursor) + `,`,
+		`Heartbeat:` + fmt.Sprintf("%v", this.Heartbeat) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Cursor) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Cursor{`,
+		`Polymorph:` + fmt.Sprintf("%v", this.Polymorph) + `,`,
+		`PulseNumber:` + fmt.Sprintf("%v", this.PulseNumber) + `,`,
+		`RecordNumber:` + fmt.Sprintf("%v", this.RecordNumber) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -681,9 +1163,237 @@ func (m *GetRecords) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRecordExporter(dAtA[iNdEx:])
+		case 23:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cursor", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecordExporter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRecordExporter
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRecordExporter
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Cursor = append(m.Cursor[:0], dAtA[iNdEx:postIndex]...)
+			if m.Cursor == nil {
+				m.Cursor = []byte{}
+			}
+			iNdEx = postIndex
+		case 24:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectIDs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecordExporter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRecordExporter
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRecordExporter
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_insolar_insolar_insolar.ID
+			m.ObjectIDs = append(m.ObjectIDs, v)
+			if err := m.ObjectIDs[len(m.ObjectIDs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 25:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prototypes", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecordExporter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRecordExporter
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRecordExporter
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_insolar_insolar_insolar.Reference
+			m.Prototypes = append(m.Prototypes, v)
+			if err := m.Prototypes[len(m.Prototypes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 26:
+			if wireType == 0 {
+				var v RecordType
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRecordExporter
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= RecordType(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.RecordTypes = append(m.RecordTypes, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRecordExporter
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthRecordExporter
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthRecordExporter
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				if elementCount != 0 && len(m.RecordTypes) == 0 {
+					m.RecordTypes = make([]RecordType, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v RecordType
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRecordExporter
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= RecordType(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.RecordTypes = append(m.RecordTypes, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordTypes", wireType)
+			}
+		case 27:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field JetIDs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecordExporter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRecordExporter
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRecordExporter
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			var v github_com_insolar_insolar_insolar.JetID
+			m.JetIDs = append(m.JetIDs, v)
+			if err := m.JetIDs[len(m.JetIDs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 28:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Follow", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecordExporter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Follow = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRecordExporter(dAtA[iNdEx:])
 			if err != nil {
 				return err
 			}
@@ -805,6 +1515,184 @@ func (m *Record) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 22:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cursor", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecordExporter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRecordExporter
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRecordExporter
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Cursor = append(m.Cursor[:0], dAtA[iNdEx:postIndex]...)
+			if m.Cursor == nil {
+				m.Cursor = []byte{}
+			}
+			iNdEx = postIndex
+		case 23:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Heartbeat", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecordExporter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Heartbeat = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRecordExporter(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRecordExporter
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRecordExporter
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Cursor) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRecordExporter
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Cursor: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Cursor: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Polymorph", wireType)
+			}
+			m.Polymorph = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecordExporter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Polymorph |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PulseNumber", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecordExporter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRecordExporter
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRecordExporter
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.PulseNumber.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 21:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordNumber", wireType)
+			}
+			m.RecordNumber = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecordExporter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RecordNumber |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRecordExporter(dAtA[iNdEx:])
@@ -18,6 +18,7 @@ package exporter
 
 import (
 	"context"
+	"math"
 
 	"github.com/insolar/insolar/insolar"
 	insolarPulse "github.com/insolar/insolar/insolar/pulse"
@@ -34,6 +35,7 @@ type RecordServer struct {
 	recordIndex     object.RecordPositionAccessor
 	recordAccessor  object.RecordAccessor
 	jetKeeper       executor.JetKeeper
+	waiter          *syncWaiter
 }
 
 func NewRecordServer(
@@ -47,17 +49,37 @@ func NewRecordServer(
 		recordIndex:     recordIndex,
 		recordAccessor:  recordAccessor,
 		jetKeeper:       jetKeeper,
+		waiter:          newSyncWaiter(jetKeeper),
 	}
 }
 
+// Stop ends all the streams in follow mode.
+func (r *RecordServer) Stop() {
+	r.waiter.stop()
+}
+
 func (r *RecordServer) Export(getRecords *GetRecords, stream RecordExporter_ExportServer) error {
 	ctx := stream.Context()
 	logger := inslogger.FromContext(ctx)
 
-	if getRecords.Count == 0 {
+	if getRecords.Count == 0 && !getRecords.Follow {
 		return errors.New("count can't be 0")
 	}
 
+	if len(getRecords.Cursor) != 0 {
+		cursor, err := parseCursor(getRecords.Cursor)
+		if err != nil {
+			return err
+		}
+		getRecords.PulseNumber = cursor.PulseNumber
+		getRecords.RecordNumber = cursor.RecordNumber
+	}
+
+	filter, err := newRecordFilter(getRecords)
+	if err != nil {
+		return err
+	}
+
 	if getRecords.PulseNumber != 0 {
 		topPulse := r.jetKeeper.TopSyncPulse()
 		if topPulse < getRecords.PulseNumber {
@@ -67,31 +89,62 @@ func (r *RecordServer) Export(getRecords *GetRecords, stream RecordExporter_Expo
 		getRecords.PulseNumber = pulse.MinTimePulse
 	}
 
+	count := getRecords.Count
+	if count == 0 {
+		// Follow mode without limit.
+		count = math.MaxUint32
+	}
+
 	iter := newRecordIterator(
 		getRecords.PulseNumber,
 		getRecords.RecordNumber,
-		getRecords.Count,
+		count,
+		filter,
 		r.recordIndex,
 		r.recordAccessor,
 		r.jetKeeper,
 		r.pulseCalculator,
 	)
 
-	for iter.HasNext(stream.Context()) {
-		record, err := iter.Next(stream.Context())
-		if err != nil {
-			logger.Error(err)
-			return err
+	for {
+		topPulse := r.jetKeeper.TopSyncPulse()
+		for iter.HasNext(ctx) {
+			record, err := iter.Next(ctx)
+			if err != nil {
+				logger.Error(err)
+				return err
+			}
+			if record == nil {
+				break
+			}
+
+			err = stream.Send(record)
+			if err != nil {
+				logger.Error(err)
+				return err
+			}
+		}
+
+		if !getRecords.Follow || iter.done() {
+			return nil
 		}
 
-		err = stream.Send(record)
+		// All synced data is read, wait for the next synced pulse.
+		ok, err := r.waiter.waitAfter(ctx, topPulse, func() error {
+			cursor, err := newCursor(iter.currentPulse, iter.currentPosition)
+			if err != nil {
+				return err
+			}
+			return stream.Send(&Record{Heartbeat: true, Cursor: cursor})
+		})
 		if err != nil {
 			logger.Error(err)
 			return err
 		}
+		if !ok {
+			return nil
+		}
 	}
-
-	return nil
 }
 
 type recordIterator struct {
@@ -100,6 +153,7 @@ type recordIterator struct {
 
 	read       uint32
 	needToRead uint32
+	filter     *recordFilter
 
 	recordIndex     object.RecordPositionAccessor
 	recordAccessor  object.RecordAccessor
@@ -111,6 +165,7 @@ func newRecordIterator(
 	pn insolar.PulseNumber,
 	lastPosition uint32,
 	takeCount uint32,
+	filter *recordFilter,
 	recordIndex object.RecordPositionAccessor,
 	recordAccessor object.RecordAccessor,
 	jetKeeper executor.JetKeeper,
@@ -118,6 +173,7 @@ func newRecordIterator(
 ) *recordIterator {
 	return &recordIterator{
 		needToRead:      takeCount,
+		filter:          filter,
 		currentPosition: lastPosition,
 		currentPulse:    pn,
 		recordIndex:     recordIndex,
@@ -127,8 +183,13 @@ func newRecordIterator(
 	}
 }
 
+// done returns true if the iterator has read all the requested records.
+func (r *recordIterator) done() bool {
+	return r.read >= r.needToRead
+}
+
 func (r *recordIterator) HasNext(ctx context.Context) bool {
-	if r.read >= r.needToRead {
+	if r.done() {
 		return false
 	}
 
@@ -165,7 +226,27 @@ func (r *recordIterator) checkNextPulse(ctx context.Context) bool {
 	}
 }
 
+// Next returns the next record that passes the filter.
+// Returns nil if there are no more matching records in synced pulses.
 func (r *recordIterator) Next(ctx context.Context) (*Record, error) {
+	for {
+		rec, err := r.next(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if r.filter == nil || r.filter.Match(&rec.Record) {
+			r.read++
+			return rec, nil
+		}
+
+		if !r.HasNext(ctx) {
+			return nil, nil
+		}
+	}
+}
+
+func (r *recordIterator) next(ctx context.Context) (*Record, error) {
 	r.currentPosition++
 
 	lastKnown, err := r.recordIndex.LastKnownPosition(r.currentPulse)
@@ -186,11 +267,15 @@ func (r *recordIterator) Next(ctx context.Context) (*Record, error) {
 		return nil, errors.Wrap(err, "iterator failed to find record")
 	}
 
-	r.read++
+	cursor, err := newCursor(r.currentPulse, r.currentPosition)
+	if err != nil {
+		return nil, errors.Wrap(err, "iterator failed to create cursor")
+	}
 
 	return &Record{
 		RecordNumber: r.currentPosition,
 		Record:       rec,
+		Cursor:       cursor,
 	}, nil
 }
 
@@ -57,7 +57,7 @@ func TestRecordIterator_HasNext(t *testing.T) {
 		positionAccessor := object.NewRecordPositionAccessorMock(t)
 		positionAccessor.LastKnownPositionMock.Expect(pn).Return(0, errors.New("some error"))
 
-		iter := newRecordIterator(pn, 0, 0, positionAccessor, nil, nil, nil)
+		iter := newRecordIterator(pn, 0, 0, nil, positionAccessor, nil, nil, nil)
 
 		hasNext := iter.HasNext(ctx)
 
@@ -69,7 +69,7 @@ func TestRecordIterator_HasNext(t *testing.T) {
 		positionAccessor := object.NewRecordPositionAccessorMock(t)
 		positionAccessor.LastKnownPositionMock.Expect(pn).Return(156, nil)
 
-		iter := newRecordIterator(pn, 0, 10, positionAccessor, nil, nil, nil)
+		iter := newRecordIterator(pn, 0, 10, nil, positionAccessor, nil, nil, nil)
 		// bigger case
 		iter.read = 11
 
@@ -77,7 +77,7 @@ func TestRecordIterator_HasNext(t *testing.T) {
 
 		require.False(t, hasNext)
 
-		iter = newRecordIterator(pn, 0, 10, positionAccessor, nil, nil, nil)
+		iter = newRecordIterator(pn, 0, 10, nil, positionAccessor, nil, nil, nil)
 		// equal case
 		iter.read = 10
 
@@ -91,7 +91,7 @@ func TestRecordIterator_HasNext(t *testing.T) {
 		positionAccessor := object.NewRecordPositionAccessorMock(t)
 		positionAccessor.LastKnownPositionMock.Expect(pn).Return(156, nil)
 
-		iter := newRecordIterator(pn, 0, 10, positionAccessor, nil, nil, nil)
+		iter := newRecordIterator(pn, 0, 10, nil, positionAccessor, nil, nil, nil)
 		iter.read = 9
 
 		hasNext := iter.HasNext(ctx)
@@ -108,7 +108,7 @@ func TestRecordIterator_HasNext(t *testing.T) {
 			pulseCalculator := network.NewPulseCalculatorMock(t)
 			pulseCalculator.ForwardsMock.Expect(ctx, pn, 1).Return(insolar.Pulse{}, store.ErrNotFound)
 
-			iter := newRecordIterator(pn, 0, 0, positionAccessor, nil, nil, pulseCalculator)
+			iter := newRecordIterator(pn, 0, 0, nil, positionAccessor, nil, nil, pulseCalculator)
 			iter.currentPosition = 2
 
 			hasNext := iter.HasNext(ctx)
@@ -127,7 +127,7 @@ func TestRecordIterator_HasNext(t *testing.T) {
 			jetKeeper := executor.NewJetKeeperMock(t)
 			jetKeeper.TopSyncPulseMock.Return(99)
 
-			iter := newRecordIterator(pn, 0, 0, positionAccessor, nil, jetKeeper, pulseCalculator)
+			iter := newRecordIterator(pn, 0, 0, nil, positionAccessor, nil, jetKeeper, pulseCalculator)
 			iter.currentPosition = 2
 
 			hasNext := iter.HasNext(ctx)
@@ -148,7 +148,7 @@ func TestRecordIterator_HasNext(t *testing.T) {
 			positionAccessor.LastKnownPositionMock.When(99).Then(2, nil)
 			positionAccessor.LastKnownPositionMock.Expect(100).Return(1, nil)
 
-			iter := newRecordIterator(pn, 2, 0, positionAccessor, nil, jetKeeper, pulseCalculator)
+			iter := newRecordIterator(pn, 2, 0, nil, positionAccessor, nil, jetKeeper, pulseCalculator)
 			iter.read = 10
 			iter.needToRead = 100
 
@@ -168,7 +168,7 @@ func TestRecordIterator_HasNext(t *testing.T) {
 			jetKeeper := executor.NewJetKeeperMock(t)
 			jetKeeper.TopSyncPulseMock.Return(101)
 
-			iter := newRecordIterator(pn, 2, 0, positionAccessor, nil, jetKeeper, pulseCalculator)
+			iter := newRecordIterator(pn, 2, 0, nil, positionAccessor, nil, jetKeeper, pulseCalculator)
 			iter.read = 10
 			iter.needToRead = 10
 
@@ -189,7 +189,7 @@ func TestRecordIterator_Next(t *testing.T) {
 		positionAccessor.LastKnownPositionMock.Expect(pn).Return(10, nil)
 		positionAccessor.AtPositionMock.Expect(pn, uint32(2)).Return(insolar.ID{}, store.ErrNotFound)
 
-		iter := newRecordIterator(pn, 1, 0, positionAccessor, nil, nil, nil)
+		iter := newRecordIterator(pn, 1, 0, nil, positionAccessor, nil, nil, nil)
 
 		_, err := iter.Next(ctx)
 
@@ -207,7 +207,7 @@ func TestRecordIterator_Next(t *testing.T) {
 		recordsAccessor := object.NewRecordAccessorMock(t)
 		recordsAccessor.ForIDMock.Expect(ctx, id).Return(record.Material{}, store.ErrNotFound)
 
-		iter := newRecordIterator(pn, 1, 0, positionAccessor, recordsAccessor, nil, nil)
+		iter := newRecordIterator(pn, 1, 0, nil, positionAccessor, recordsAccessor, nil, nil)
 
 		_, err := iter.Next(ctx)
 
@@ -230,7 +230,7 @@ func TestRecordIterator_Next(t *testing.T) {
 		recordsAccessor := object.NewRecordAccessorMock(t)
 		recordsAccessor.ForIDMock.Expect(ctx, id).Return(record, nil)
 
-		iter := newRecordIterator(pn, 1, 0, positionAccessor, recordsAccessor, nil, nil)
+		iter := newRecordIterator(pn, 1, 0, nil, positionAccessor, recordsAccessor, nil, nil)
 		next, err := iter.Next(ctx)
 
 		require.NoError(t, err)
@@ -250,7 +250,7 @@ func TestRecordIterator_Next(t *testing.T) {
 			pulseCalculator := network.NewPulseCalculatorMock(t)
 			pulseCalculator.ForwardsMock.Expect(ctx, pn, 1).Return(insolar.Pulse{}, store.ErrNotFound)
 
-			iter := newRecordIterator(pn, 1, 0, positionAccessor, nil, nil, pulseCalculator)
+			iter := newRecordIterator(pn, 1, 0, nil, positionAccessor, nil, nil, pulseCalculator)
 
 			_, err := iter.Next(ctx)
 
@@ -282,7 +282,7 @@ func TestRecordIterator_Next(t *testing.T) {
 			pulseCalculator := network.NewPulseCalculatorMock(t)
 			pulseCalculator.ForwardsMock.Expect(ctx, firstPN, 1).Return(insolar.Pulse{PulseNumber: nextPN}, nil)
 
-			iter := newRecordIterator(firstPN, 10, 0, positionAccessor, recordsAccessor, jetKeeper, pulseCalculator)
+			iter := newRecordIterator(firstPN, 10, 0, nil, positionAccessor, recordsAccessor, jetKeeper, pulseCalculator)
 
 			next, err := iter.Next(ctx)
 
@@ -433,6 +433,7 @@ func TestRecordServer_Export_Composite(t *testing.T) {
 	secondID := gen.IDWithPulse(firstPN)
 	secondRec := getMaterialRecord()
 	secondRec.ID = secondID
+	secondRec.ObjectID = gen.ID()
 
 	thirdID := gen.IDWithPulse(secondPN)
 	thirdRec := getMaterialRecord()
@@ -578,6 +579,68 @@ func TestRecordServer_Export_Composite(t *testing.T) {
 		require.Equal(t, secondRec, resRecord.Record)
 	})
 
+	t.Run("export by object filter", func(t *testing.T) {
+		var recs []*Record
+		streamMock := &streamMock{checker: func(i *Record) error {
+			recs = append(recs, i)
+			return nil
+		}}
+
+		err := recordServer.Export(&GetRecords{
+			Count:     5,
+			ObjectIDs: []insolar.ID{secondRec.ObjectID},
+		}, streamMock)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(recs))
+		require.Equal(t, uint32(2), recs[0].RecordNumber)
+		require.Equal(t, secondRec, recs[0].Record)
+	})
+
+	t.Run("export by type filter. nothing matches", func(t *testing.T) {
+		streamMock := &streamMock{checker: func(i *Record) error {
+			t.Error("it shouldn't be called")
+			return nil
+		}}
+
+		err := recordServer.Export(&GetRecords{
+			Count:       5,
+			RecordTypes: []RecordType{TypeResult},
+		}, streamMock)
+		require.NoError(t, err)
+	})
+
+	t.Run("resume from cursor", func(t *testing.T) {
+		var recs []*Record
+		streamMock := &streamMock{checker: func(i *Record) error {
+			recs = append(recs, i)
+			return nil
+		}}
+
+		err := recordServer.Export(&GetRecords{
+			PulseNumber: firstPN,
+			Count:       2,
+		}, streamMock)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(recs))
+
+		cursor := recs[1].Cursor
+		recs = nil
+		err = recordServer.Export(&GetRecords{
+			Count:  5,
+			Cursor: cursor,
+		}, streamMock)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(recs))
+		require.Equal(t, thirdRec, recs[0].Record)
+	})
+
+	t.Run("broken cursor returns error", func(t *testing.T) {
+		err := recordServer.Export(&GetRecords{
+			Count:  5,
+			Cursor: []byte{1, 2, 3},
+		}, &streamMock{})
+		require.Error(t, err)
+	})
 }
 
 func TestRecordServer_Export_Composite_BatchVersion(t *testing.T) {
@@ -0,0 +1,190 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package exporter
+
+import (
+	"bytes"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/bits"
+	"github.com/insolar/insolar/insolar/record"
+
+	"github.com/pkg/errors"
+)
+
+// recordFilter checks exported records against filters of GetRecords request.
+// Different kinds of filters are combined with AND, values of one filter are combined with OR.
+type recordFilter struct {
+	objects    map[insolar.ID]struct{}
+	prototypes map[insolar.Reference]struct{}
+	types      map[RecordType]struct{}
+	jets       []insolar.JetID
+}
+
+func newRecordFilter(getRecords *GetRecords) (*recordFilter, error) {
+	f := &recordFilter{}
+
+	if len(getRecords.ObjectIDs) > 0 {
+		f.objects = make(map[insolar.ID]struct{}, len(getRecords.ObjectIDs))
+		for _, id := range getRecords.ObjectIDs {
+			f.objects[id] = struct{}{}
+		}
+	}
+
+	if len(getRecords.Prototypes) > 0 {
+		f.prototypes = make(map[insolar.Reference]struct{}, len(getRecords.Prototypes))
+		for _, ref := range getRecords.Prototypes {
+			f.prototypes[ref] = struct{}{}
+		}
+	}
+
+	if len(getRecords.RecordTypes) > 0 {
+		f.types = make(map[RecordType]struct{}, len(getRecords.RecordTypes))
+		for _, t := range getRecords.RecordTypes {
+			if _, ok := RecordType_name[int32(t)]; !ok || t == TypeUnknown {
+				return nil, errors.Errorf("unknown record type %d", t)
+			}
+			f.types[t] = struct{}{}
+		}
+	}
+
+	for _, jetID := range getRecords.JetIDs {
+		if !jetID.IsValid() {
+			return nil, errors.Errorf("invalid jet id %s", jetID.DebugString())
+		}
+		f.jets = append(f.jets, jetID)
+	}
+
+	return f, nil
+}
+
+// Match returns true if record passes all the filters.
+func (f *recordFilter) Match(rec *record.Material) bool {
+	if f.objects != nil {
+		if _, ok := f.objects[rec.ObjectID]; !ok {
+			return false
+		}
+	}
+
+	if f.types != nil {
+		if _, ok := f.types[TypeOf(rec)]; !ok {
+			return false
+		}
+	}
+
+	if f.prototypes != nil {
+		proto := prototypeOf(rec)
+		if proto == nil {
+			return false
+		}
+		if _, ok := f.prototypes[*proto]; !ok {
+			return false
+		}
+	}
+
+	if len(f.jets) > 0 && !f.matchJet(rec) {
+		return false
+	}
+
+	return true
+}
+
+// matchJet checks that record belongs to one of the filter jets or to their children.
+// Jet affinity is calculated by object id, so records stored before or after jet split still match.
+func (f *recordFilter) matchJet(rec *record.Material) bool {
+	var hash []byte
+	if !rec.ObjectID.IsEmpty() {
+		hash = rec.ObjectID.Hash()
+	} else {
+		hash = rec.JetID.Prefix()
+	}
+
+	for _, jetID := range f.jets {
+		depth, prefix := jetID.Depth(), jetID.Prefix()
+		objectPrefix := hash
+		if len(objectPrefix) > len(prefix) {
+			objectPrefix = objectPrefix[:len(prefix)]
+		}
+		if bytes.Equal(bits.ResetBits(objectPrefix, depth), bits.ResetBits(prefix, depth)) {
+			return true
+		}
+	}
+	return false
+}
+
+// TypeOf returns type of the virtual record wrapped into material record.
+func TypeOf(rec *record.Material) RecordType {
+	switch rec.Virtual.Union.(type) {
+	case *record.Virtual_Genesis:
+		return TypeGenesis
+	case *record.Virtual_IncomingRequest:
+		return TypeIncomingRequest
+	case *record.Virtual_OutgoingRequest:
+		return TypeOutgoingRequest
+	case *record.Virtual_Result:
+		return TypeResult
+	case *record.Virtual_Code:
+		return TypeCode
+	case *record.Virtual_Activate:
+		return TypeActivate
+	case *record.Virtual_Amend:
+		return TypeAmend
+	case *record.Virtual_Deactivate:
+		return TypeDeactivate
+	case *record.Virtual_PendingFilament:
+		return TypePendingFilament
+	default:
+		return TypeUnknown
+	}
+}
+
+// prototypeOf returns prototype reference of a request or an object state.
+// Returns nil for records without prototype.
+func prototypeOf(rec *record.Material) *insolar.Reference {
+	switch v := rec.Virtual.Union.(type) {
+	case *record.Virtual_IncomingRequest:
+		return v.IncomingRequest.Prototype
+	case *record.Virtual_OutgoingRequest:
+		return v.OutgoingRequest.Prototype
+	case *record.Virtual_Activate:
+		return &v.Activate.Image
+	case *record.Virtual_Amend:
+		return &v.Amend.Image
+	default:
+		return nil
+	}
+}
+
+func newCursor(pn insolar.PulseNumber, recordNumber uint32) ([]byte, error) {
+	c := Cursor{
+		PulseNumber:  pn,
+		RecordNumber: recordNumber,
+	}
+	return c.Marshal()
+}
+
+func parseCursor(raw []byte) (*Cursor, error) {
+	c := Cursor{}
+	err := c.Unmarshal(raw)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse cursor")
+	}
+	if !c.PulseNumber.IsTimePulse() {
+		return nil, errors.Errorf("cursor contains invalid pulse %v", c.PulseNumber)
+	}
+	return &c, nil
+}
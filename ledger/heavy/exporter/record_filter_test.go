@@ -0,0 +1,144 @@
+/*
+ *    Copyright 2019 Insolar Technologies
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package exporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/gen"
+	"github.com/insolar/insolar/insolar/jet"
+	"github.com/insolar/insolar/insolar/record"
+	"github.com/insolar/insolar/pulse"
+)
+
+func TestRecordFilter_Match(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty filter matches everything", func(t *testing.T) {
+		f, err := newRecordFilter(&GetRecords{})
+		require.NoError(t, err)
+
+		rec := getMaterialRecord()
+		require.True(t, f.Match(&rec))
+	})
+
+	t.Run("object filter", func(t *testing.T) {
+		rec := getMaterialRecord()
+		rec.ObjectID = gen.ID()
+
+		f, err := newRecordFilter(&GetRecords{ObjectIDs: []insolar.ID{gen.ID(), rec.ObjectID}})
+		require.NoError(t, err)
+		require.True(t, f.Match(&rec))
+
+		f, err = newRecordFilter(&GetRecords{ObjectIDs: []insolar.ID{gen.ID()}})
+		require.NoError(t, err)
+		require.False(t, f.Match(&rec))
+	})
+
+	t.Run("type filter", func(t *testing.T) {
+		rec := getMaterialRecord()
+
+		f, err := newRecordFilter(&GetRecords{RecordTypes: []RecordType{TypeIncomingRequest}})
+		require.NoError(t, err)
+		require.True(t, f.Match(&rec))
+
+		f, err = newRecordFilter(&GetRecords{RecordTypes: []RecordType{TypeActivate, TypeAmend}})
+		require.NoError(t, err)
+		require.False(t, f.Match(&rec))
+	})
+
+	t.Run("unknown type returns error", func(t *testing.T) {
+		_, err := newRecordFilter(&GetRecords{RecordTypes: []RecordType{TypeUnknown}})
+		require.Error(t, err)
+
+		_, err = newRecordFilter(&GetRecords{RecordTypes: []RecordType{RecordType(100)}})
+		require.Error(t, err)
+	})
+
+	t.Run("prototype filter", func(t *testing.T) {
+		image := gen.Reference()
+		rec := record.Material{
+			Virtual: record.Wrap(&record.Activate{Image: image}),
+		}
+
+		f, err := newRecordFilter(&GetRecords{Prototypes: []insolar.Reference{image}})
+		require.NoError(t, err)
+		require.True(t, f.Match(&rec))
+
+		res := record.Material{
+			Virtual: record.Wrap(&record.Result{}),
+		}
+		require.False(t, f.Match(&res))
+	})
+
+	t.Run("jet filter", func(t *testing.T) {
+		objID := *insolar.NewID(pulse.MinTimePulse, []byte{0xA0})
+		rec := getMaterialRecord()
+		rec.ObjectID = objID
+
+		f, err := newRecordFilter(&GetRecords{JetIDs: []insolar.JetID{jet.NewIDFromString("101")}})
+		require.NoError(t, err)
+		require.True(t, f.Match(&rec))
+
+		f, err = newRecordFilter(&GetRecords{JetIDs: []insolar.JetID{jet.NewIDFromString("0")}})
+		require.NoError(t, err)
+		require.False(t, f.Match(&rec))
+
+		f, err = newRecordFilter(&GetRecords{JetIDs: []insolar.JetID{*insolar.NewJetID(0, nil)}})
+		require.NoError(t, err)
+		require.True(t, f.Match(&rec))
+
+		f, err = newRecordFilter(&GetRecords{JetIDs: []insolar.JetID{
+			jet.NewIDFromString("0"),
+			jet.NewIDFromString("11"),
+			jet.NewIDFromString("101"),
+		}})
+		require.NoError(t, err)
+		require.True(t, f.Match(&rec))
+	})
+
+	t.Run("filters are combined", func(t *testing.T) {
+		rec := getMaterialRecord()
+		rec.ObjectID = gen.ID()
+
+		f, err := newRecordFilter(&GetRecords{
+			ObjectIDs:   []insolar.ID{rec.ObjectID},
+			RecordTypes: []RecordType{TypeResult},
+		})
+		require.NoError(t, err)
+		require.False(t, f.Match(&rec))
+	})
+}
+
+func TestCursor(t *testing.T) {
+	t.Parallel()
+
+	pn := gen.PulseNumber()
+	raw, err := newCursor(pn, 42)
+	require.NoError(t, err)
+
+	cursor, err := parseCursor(raw)
+	require.NoError(t, err)
+	require.Equal(t, pn, cursor.PulseNumber)
+	require.Equal(t, uint32(42), cursor.RecordNumber)
+
+	_, err = parseCursor([]byte{0xFF})
+	require.Error(t, err)
+}
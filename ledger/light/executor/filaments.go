@@ -78,7 +78,8 @@ type FilamentCalculator interface {
 		err error,
 	)
 
-	// RequestInfo is searching for request and result by objectID, requestID and pulse number
+	// RequestInfo is searching for request and result by objectID, requestID and pulse number.
+	// It also returns outgoing requests of the object which reason is the request.
 	RequestInfo(
 		ctx context.Context,
 		objectID insolar.ID,
@@ -87,6 +88,7 @@ type FilamentCalculator interface {
 	) (
 		foundRequest *record.CompositeFilamentRecord,
 		foundResult *record.CompositeFilamentRecord,
+		foundOutgoings []record.CompositeFilamentRecord,
 		err error,
 	)
 }
@@ -385,6 +387,7 @@ func (c *FilamentCalculatorDefault) RequestInfo(
 ) (
 	*record.CompositeFilamentRecord,
 	*record.CompositeFilamentRecord,
+	[]record.CompositeFilamentRecord,
 	error,
 ) {
 	logger := inslogger.FromContext(ctx).WithFields(map[string]interface{}{
@@ -397,11 +400,11 @@ func (c *FilamentCalculatorDefault) RequestInfo(
 
 	idx, err := c.indexes.ForID(ctx, pulse, objectID)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, fmt.Sprintf("object: %s", objectID.DebugString()))
+		return nil, nil, nil, errors.Wrap(err, fmt.Sprintf("object: %s", objectID.DebugString()))
 	}
 
 	if idx.Lifeline.LatestRequest == nil {
-		return nil, nil, errors.Wrap(err, "latest request in lifeline is empty")
+		return nil, nil, nil, errors.Wrap(err, "latest request in lifeline is empty")
 	}
 
 	logger.Debugf("latest request from index %s", idx.Lifeline.LatestRequest.DebugString())
@@ -423,11 +426,12 @@ func (c *FilamentCalculatorDefault) RequestInfo(
 
 	var foundRequest *record.CompositeFilamentRecord
 	var foundResult *record.CompositeFilamentRecord
+	var foundOutgoings []record.CompositeFilamentRecord
 
 	for iter.HasPrev() {
 		rec, err := iter.Prev(ctx)
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "failed to calculate filament")
+			return nil, nil, nil, errors.Wrap(err, "failed to calculate filament")
 		}
 
 		if rec.RecordID == requestID {
@@ -443,9 +447,14 @@ func (c *FilamentCalculatorDefault) RequestInfo(
 				logger.Debugf("found result %s", rec.RecordID.DebugString())
 			}
 		}
+		if r, ok := virtual.(*record.OutgoingRequest); ok {
+			if reason := r.ReasonRef(); *reason.GetLocal() == requestID {
+				foundOutgoings = append(foundOutgoings, rec)
+			}
+		}
 	}
 
-	return foundRequest, foundResult, nil
+	return foundRequest, foundResult, foundOutgoings, nil
 }
 
 func (c *FilamentCalculatorDefault) Clear(objID insolar.ID) {
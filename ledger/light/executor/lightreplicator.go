@@ -18,7 +18,9 @@ package executor
 
 import (
 	"context"
+	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"github.com/insolar/insolar/insolar"
 	"github.com/insolar/insolar/insolar/bus"
@@ -61,6 +63,9 @@ type LightReplicatorDefault struct {
 	jetAccessor  jet.Accessor
 
 	syncWaitingPulses chan insolar.PulseNumber
+
+	// lastReplicated is the latest pulse all data of which is sent to heavy
+	lastReplicated uint32
 }
 
 // NewReplicatorDefault creates new instance of LightReplicator
@@ -157,6 +162,7 @@ func (lr *LightReplicatorDefault) sync(ctx context.Context) {
 		}
 		lr.cleaner.NotifyAboutPulse(ctx, pn)
 
+		atomic.StoreUint32(&lr.lastReplicated, uint32(pn))
 		stats.Record(ctx, statLastReplicatedPulse.M(int64(pn)))
 	}
 
@@ -174,6 +180,11 @@ func (lr *LightReplicatorDefault) sync(ctx context.Context) {
 	}
 }
 
+// LastReplicated returns the latest pulse all data of which is sent to heavy.
+func (lr *LightReplicatorDefault) LastReplicated() insolar.PulseNumber {
+	return insolar.PulseNumber(atomic.LoadUint32(&lr.lastReplicated))
+}
+
 func (lr *LightReplicatorDefault) sendToHeavy(ctx context.Context, pl payload.Replication) error {
 	msg, err := payload.NewMessage(&pl)
 	if err != nil {
@@ -226,3 +237,57 @@ func (lr *LightReplicatorDefault) heavyPayload(
 		Records: records,
 	}, nil
 }
+
+// ReplicationDrainer reports if light node has sent all its data to heavy. Data of a pulse is replicated
+// when the next pulse comes, so node can leave network only after the previous pulse is replicated.
+type ReplicationDrainer struct {
+	replicator *LightReplicatorDefault
+	pulses     pulse.Accessor
+	calculator pulse.Calculator
+}
+
+// NewReplicationDrainer creates new instance of ReplicationDrainer.
+func NewReplicationDrainer(
+	replicator *LightReplicatorDefault,
+	pulses pulse.Accessor,
+	calculator pulse.Calculator,
+) *ReplicationDrainer {
+	return &ReplicationDrainer{
+		replicator: replicator,
+		pulses:     pulses,
+		calculator: calculator,
+	}
+}
+
+// DrainProgress checks if data of the previous pulse is replicated.
+func (d *ReplicationDrainer) DrainProgress(ctx context.Context) insolar.DrainProgress {
+	progress := insolar.DrainProgress{Component: "LightReplicator"}
+
+	latest, err := d.pulses.Latest(ctx)
+	if err == pulse.ErrNotFound {
+		progress.Done = true
+		return progress
+	}
+	if err != nil {
+		progress.Details = errors.Wrap(err, "failed to fetch latest pulse").Error()
+		return progress
+	}
+
+	prev, err := d.calculator.Backwards(ctx, latest.PulseNumber, 1)
+	if err == pulse.ErrNotFound {
+		progress.Done = true
+		return progress
+	}
+	if err != nil {
+		progress.Details = errors.Wrap(err, "failed to calculate previous pulse").Error()
+		return progress
+	}
+
+	replicated := d.replicator.LastReplicated()
+	if replicated >= prev.PulseNumber {
+		progress.Done = true
+		return progress
+	}
+	progress.Details = fmt.Sprintf("replicated up to pulse %d, waiting for pulse %d", replicated, prev.PulseNumber)
+	return progress
+}
@@ -120,3 +120,44 @@ func Test_NotifyAboutPulse(t *testing.T) {
 	mc.Wait(time.Minute)
 	mc.Finish()
 }
+
+func TestReplicationDrainer_DrainProgress(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	latest := insolar.Pulse{PulseNumber: 2835341959}
+	prev := insolar.Pulse{PulseNumber: 2835341949}
+
+	table := []struct {
+		name       string
+		latestErr  error
+		prevErr    error
+		replicated insolar.PulseNumber
+		done       bool
+	}{
+		{name: "no pulses", latestErr: pulse.ErrNotFound, done: true},
+		{name: "no previous pulse", prevErr: pulse.ErrNotFound, done: true},
+		{name: "previous pulse is not replicated", replicated: prev.PulseNumber - 10},
+		{name: "previous pulse is replicated", replicated: prev.PulseNumber, done: true},
+	}
+
+	for _, test := range table {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			mc := minimock.NewController(t)
+			defer mc.Finish()
+
+			pulses := pulse.NewAccessorMock(mc).LatestMock.Return(latest, test.latestErr)
+			calc := pulse.NewCalculatorMock(mc)
+			if test.latestErr == nil {
+				calc.BackwardsMock.Expect(ctx, latest.PulseNumber, 1).Return(prev, test.prevErr)
+			}
+
+			r := &LightReplicatorDefault{lastReplicated: uint32(test.replicated)}
+			progress := NewReplicationDrainer(r, pulses, calc).DrainProgress(ctx)
+			require.Equal(t, test.done, progress.Done)
+			if !test.done {
+				require.Contains(t, progress.Details, "waiting for pulse")
+			}
+		})
+	}
+}
@@ -272,7 +272,7 @@ func (c *RequestCheckerDefault) getRequestLocal(
 		reqBuf []byte
 		resBuf []byte
 	)
-	foundRequest, foundResult, err := c.filaments.RequestInfo(ctx, reasonObjectID, reasonID, currentPulse)
+	foundRequest, foundResult, _, err := c.filaments.RequestInfo(ctx, reasonObjectID, reasonID, currentPulse)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get local request info")
 	}
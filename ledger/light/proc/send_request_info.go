@@ -90,10 +90,11 @@ func (p *SendRequestInfo) Proceed(ctx context.Context) error {
 	defer p.dep.locker.Unlock(p.objectID)
 
 	var (
-		reqBuf []byte
-		resBuf []byte
+		reqBuf  []byte
+		resBuf  []byte
+		outBufs [][]byte
 	)
-	foundRequest, foundResult, err := p.dep.filament.RequestInfo(ctx, p.objectID, p.requestID, p.pulse)
+	foundRequest, foundResult, foundOutgoings, err := p.dep.filament.RequestInfo(ctx, p.objectID, p.requestID, p.pulse)
 	if err != nil {
 		return errors.Wrap(err, "failed to get request info")
 	}
@@ -110,12 +111,20 @@ func (p *SendRequestInfo) Proceed(ctx context.Context) error {
 			return errors.Wrap(err, "failed to marshal result record")
 		}
 	}
+	for _, outgoing := range foundOutgoings {
+		buf, err := outgoing.Record.Marshal()
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal outgoing request record")
+		}
+		outBufs = append(outBufs, buf)
+	}
 
 	msg, err := payload.NewMessage(&payload.RequestInfo{
-		ObjectID:  p.objectID,
-		RequestID: p.requestID,
-		Request:   reqBuf,
-		Result:    resBuf,
+		ObjectID:         p.objectID,
+		RequestID:        p.requestID,
+		Request:          reqBuf,
+		Result:           resBuf,
+		OutgoingRequests: outBufs,
 	})
 	if err != nil {
 		return errors.Wrap(err, "failed to create reply")
@@ -126,6 +135,7 @@ func (p *SendRequestInfo) Proceed(ctx context.Context) error {
 	logger.WithFields(map[string]interface{}{
 		"request":    foundRequest != nil,
 		"has_result": foundResult != nil,
+		"outgoings":  len(foundOutgoings),
 	}).Debug("send request info finished")
 	return nil
 }
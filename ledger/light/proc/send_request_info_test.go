@@ -79,6 +79,7 @@ func TestSendRequestInfo_Proceed(t *testing.T) {
 
 		reqID := gen.ID()
 		resID := gen.ID()
+		outID := gen.ID()
 		objID := reqID
 		msg := payload.Meta{}
 
@@ -90,17 +91,23 @@ func TestSendRequestInfo_Proceed(t *testing.T) {
 			Record:   record.Material{ID: resID, ObjectID: objID},
 			RecordID: resID,
 		}
+		outgoing := record.CompositeFilamentRecord{
+			Record:   record.Material{ID: outID, ObjectID: objID},
+			RecordID: outID,
+		}
 		reqBuf, err := request.Record.Marshal()
 		resBuf, err := result.Record.Marshal()
+		outBuf, err := outgoing.Record.Marshal()
 
 		replyMsg, _ := payload.NewMessage(&payload.RequestInfo{
-			ObjectID:  objID,
-			RequestID: reqID,
-			Request:   reqBuf,
-			Result:    resBuf,
+			ObjectID:         objID,
+			RequestID:        reqID,
+			Request:          reqBuf,
+			Result:           resBuf,
+			OutgoingRequests: [][]byte{outBuf},
 		})
 
-		filament.RequestInfoMock.Return(&request, &result, nil)
+		filament.RequestInfoMock.Return(&request, &result, []record.CompositeFilamentRecord{outgoing}, nil)
 
 		sender.ReplyMock.Inspect(func(ctx context.Context, origin payload.Meta, reply *message.Message) {
 			assert.Equal(t, reply.Payload, replyMsg.Payload)
@@ -164,6 +164,16 @@ func (i *IndexDB) ForID(ctx context.Context, pn insolar.PulseNumber, objID insol
 }
 
 func (i *IndexDB) ForPulse(ctx context.Context, pn insolar.PulseNumber) ([]record.Index, error) {
+	return i.forPulse(pn, false)
+}
+
+// ForPulseExact returns indexes saved in the pulse pn.
+// Unlike ForPulse it doesn't continue to the buckets of the following pulses.
+func (i *IndexDB) ForPulseExact(ctx context.Context, pn insolar.PulseNumber) ([]record.Index, error) {
+	return i.forPulse(pn, true)
+}
+
+func (i *IndexDB) forPulse(pn insolar.PulseNumber, exact bool) ([]record.Index, error) {
 	indexes := make([]record.Index, 0)
 
 	key := &indexKey{objID: insolar.ID{}, pn: pn}
@@ -171,6 +181,9 @@ func (i *IndexDB) ForPulse(ctx context.Context, pn insolar.PulseNumber) ([]recor
 	defer it.Close()
 
 	for it.Next() {
+		if exact && insolar.NewPulseNumber(it.Key()) != pn {
+			break
+		}
 		index := record.Index{}
 		rawIndex, err := it.Value()
 		err = index.Unmarshal(rawIndex)
@@ -211,6 +224,15 @@ func (i *IndexDB) getBucket(pn insolar.PulseNumber, objID insolar.ID) (*record.I
 	return &bucket, err
 }
 
+// LastKnownPulse returns the last pulse with a bucket of the object, that is considered as finalized.
+func (i *IndexDB) LastKnownPulse(ctx context.Context, objID insolar.ID) (insolar.PulseNumber, error) {
+	pn, err := i.getLastKnownPN(objID)
+	if err == store.ErrNotFound {
+		return pn, ErrIndexNotFound
+	}
+	return pn, err
+}
+
 func (i *IndexDB) setLastKnownPN(pn insolar.PulseNumber, objID insolar.ID) error {
 	key := lastKnownIndexPNKey{objID: objID}
 	return i.db.Set(key, pn.Bytes())
@@ -266,6 +266,42 @@ func TestDBIndexStorage_ForPulse(t *testing.T) {
 	})
 }
 
+func TestDBIndexStorage_ForPulseExact(t *testing.T) {
+	t.Parallel()
+
+	ctx := inslogger.TestContext(t)
+
+	tmpdir, err := ioutil.TempDir("", "bdb-test-")
+	defer os.RemoveAll(tmpdir)
+	require.NoError(t, err)
+
+	db, err := store.NewBadgerDB(BadgerDefaultOptions(tmpdir))
+	require.NoError(t, err)
+	defer db.Stop(context.Background())
+	storage := NewIndexDB(db, nil)
+
+	pn := gen.PulseNumber()
+	current := record.Index{ObjID: gen.ID()}
+	err = storage.SetIndex(ctx, pn, current)
+	require.NoError(t, err)
+	next := record.Index{ObjID: gen.ID()}
+	err = storage.SetIndex(ctx, pn+10, next)
+	require.NoError(t, err)
+
+	indexes, err := storage.ForPulseExact(ctx, pn)
+	require.NoError(t, err)
+	require.Equal(t, []record.Index{current}, indexes)
+
+	indexes, err = storage.ForPulseExact(ctx, pn+1)
+	require.Equal(t, ErrIndexNotFound, err)
+	require.Nil(t, indexes)
+
+	// ForPulse continues to the buckets of the following pulses.
+	indexes, err = storage.ForPulse(ctx, pn)
+	require.NoError(t, err)
+	require.Len(t, indexes, 2)
+}
+
 func TestDBIndex_SetBucket(t *testing.T) {
 	t.Parallel()
 
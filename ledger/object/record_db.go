@@ -23,7 +23,6 @@ import (
 	"fmt"
 	"sync"
 
-	"github.com/dgraph-io/badger"
 	"github.com/pkg/errors"
 
 	"github.com/insolar/insolar/insolar"
@@ -36,7 +35,7 @@ import (
 type RecordDB struct {
 	batchLock sync.Mutex
 
-	db *store.BadgerDB
+	db store.DB
 }
 
 type recordKey insolar.ID
@@ -122,7 +121,7 @@ func (k lastKnownRecordPositionKey) ID() []byte {
 }
 
 // NewRecordDB creates new DB storage instance.
-func NewRecordDB(db *store.BadgerDB) *RecordDB {
+func NewRecordDB(db store.DB) *RecordDB {
 	return &RecordDB{db: db}
 }
 
@@ -148,7 +147,7 @@ func (r *RecordDB) BatchSet(ctx context.Context, recs []record.Material) error {
 	lastKnowPulse := insolar.PulseNumber(0)
 	position := uint32(0)
 
-	err := r.db.Backend().Update(func(txn *badger.Txn) error {
+	err := r.db.Update(func(txn store.Txn) error {
 		for _, rec := range recs {
 			if rec.ID.IsEmpty() {
 				return errors.New("id is empty")
@@ -200,68 +199,51 @@ func (r *RecordDB) BatchSet(ctx context.Context, recs []record.Material) error {
 }
 
 // setRecord is a helper method for storaging record to db in scope of txn.
-func setRecord(txn *badger.Txn, key store.Key, record record.Material) error {
+func setRecord(txn store.Txn, key store.Key, record record.Material) error {
 	data, err := record.Marshal()
 	if err != nil {
 		return err
 	}
 
-	fullKey := append(key.Scope().Bytes(), key.ID()...)
-
-	_, err = txn.Get(fullKey)
-	if err != nil && err != badger.ErrKeyNotFound {
+	_, err = txn.Get(key)
+	if err != nil && err != store.ErrNotFound {
 		return err
 	}
 	if err == nil {
 		return ErrOverride
 	}
 
-	return txn.Set(fullKey, data)
+	return txn.Set(key, data)
 }
 
 // setRecord is a helper method for getting last known position of record to db in scope of txn and pulse.
-func getLastKnownPosition(txn *badger.Txn, pn insolar.PulseNumber) (uint32, error) {
-	key := lastKnownRecordPositionKey{pn: pn}
-
-	fullKey := append(key.Scope().Bytes(), key.ID()...)
-
-	item, err := txn.Get(fullKey)
+func getLastKnownPosition(txn store.Txn, pn insolar.PulseNumber) (uint32, error) {
+	buff, err := txn.Get(lastKnownRecordPositionKey{pn: pn})
 	if err != nil {
-		if err == badger.ErrKeyNotFound {
+		if err == store.ErrNotFound {
 			return 0, ErrNotFound
 		}
 		return 0, err
 	}
 
-	buff, err := item.ValueCopy(nil)
-	if err != nil {
-		return 0, err
-	}
-
 	return binary.BigEndian.Uint32(buff), nil
 }
 
 // setLastKnownPosition is a helper method for setting last known position of record to db in scope of txn and pulse.
-func setLastKnownPosition(txn *badger.Txn, pn insolar.PulseNumber, position uint32) error {
-	lastPositionKey := lastKnownRecordPositionKey{pn: pn}
+func setLastKnownPosition(txn store.Txn, pn insolar.PulseNumber, position uint32) error {
 	parsedPosition := make([]byte, 4)
 	binary.BigEndian.PutUint32(parsedPosition, position)
 
-	fullKey := append(lastPositionKey.Scope().Bytes(), lastPositionKey.ID()...)
-
-	return txn.Set(fullKey, parsedPosition)
+	return txn.Set(lastKnownRecordPositionKey{pn: pn}, parsedPosition)
 }
 
-func setPosition(txn *badger.Txn, recID insolar.ID, position uint32) error {
-	positionKey := newRecordPositionKey(recID.Pulse(), position)
-	fullKey := append(positionKey.Scope().Bytes(), positionKey.ID()...)
-
-	return txn.Set(fullKey, recID.Bytes())
+func setPosition(txn store.Txn, recID insolar.ID, position uint32) error {
+	return txn.Set(newRecordPositionKey(recID.Pulse(), position), recID.Bytes())
 }
 
 func (r *RecordDB) truncateRecordsHead(ctx context.Context, from insolar.PulseNumber) error {
 	keyFrom := recordKey(*insolar.NewID(from, nil))
-	it := store.NewReadIterator(r.db.Backend(), keyFrom, false)
+	it := r.db.NewIterator(keyFrom, false)
 	defer it.Close()
 
 	var hasKeys bool
@@ -285,7 +267,7 @@ func (r *RecordDB) truncateRecordsHead(ctx context.Context, from insolar.PulseNu
 }
 
 func (r *RecordDB) truncatePositionRecordHead(ctx context.Context, from store.Key, prefix byte) error {
-	it := store.NewReadIterator(r.db.Backend(), from, false)
+	it := r.db.NewIterator(from, false)
 	defer it.Close()
 
 	var hasKeys bool
@@ -347,23 +329,10 @@ func (r *RecordDB) ForID(ctx context.Context, id insolar.ID) (record.Material, e
 
 // get loads record.Material from DB
 func (r *RecordDB) get(id insolar.ID) (record.Material, error) {
-	var buff []byte
-	var err error
-	err = r.db.Backend().View(func(txn *badger.Txn) error {
-		key := recordKey(id)
-		fullKey := append(key.Scope().Bytes(), key.ID()...)
-
-		item, err := txn.Get(fullKey)
-		if err != nil {
-			if err == badger.ErrKeyNotFound {
-				return ErrNotFound
-			}
-			return err
-		}
-
-		buff, err = item.ValueCopy(nil)
-		return err
-	})
+	buff, err := r.db.Get(recordKey(id))
+	if err == store.ErrNotFound {
+		return record.Material{}, ErrNotFound
+	}
 	if err != nil {
 		return record.Material{}, err
 	}
@@ -379,7 +348,7 @@ func (r *RecordDB) LastKnownPosition(pn insolar.PulseNumber) (uint32, error) {
 	var position uint32
 	var err error
 
-	err = r.db.Backend().View(func(txn *badger.Txn) error {
+	err = r.db.View(func(txn store.Txn) error {
 		position, err = getLastKnownPosition(txn, pn)
 		return err
 	})
@@ -390,7 +359,7 @@ func (r *RecordDB) LastKnownPosition(pn insolar.PulseNumber) (uint32, error) {
 // AtPosition returns record ID for a specific pulse and a position
 func (r *RecordDB) AtPosition(pn insolar.PulseNumber, position uint32) (insolar.ID, error) {
 	var recID insolar.ID
-	err := r.db.Backend().View(func(txn *badger.Txn) error {
+	err := r.db.View(func(txn store.Txn) error {
 		lastKnownPosition, err := getLastKnownPosition(txn, pn)
 		if err != nil {
 			return err
@@ -399,23 +368,91 @@ func (r *RecordDB) AtPosition(pn insolar.PulseNumber, position uint32) (insolar.
 		if position > lastKnownPosition {
 			return ErrNotFound
 		}
-		positionKey := newRecordPositionKey(pn, position)
-		fullKey := append(positionKey.Scope().Bytes(), positionKey.ID()...)
-
-		item, err := txn.Get(fullKey)
+		rawID, err := txn.Get(newRecordPositionKey(pn, position))
 		if err != nil {
-			if err == badger.ErrKeyNotFound {
+			if err == store.ErrNotFound {
 				return ErrNotFound
 			}
 			return err
 		}
-		rawID, err := item.ValueCopy(nil)
-		if err != nil {
-			return err
-		}
 
 		recID = *insolar.NewIDFromBytes(rawID)
 		return nil
 	})
 	return recID, err
 }
+
+// RebuildPositions restores positions of records for a provided pulse from the stored records.
+// Positions pointing to the stored records keep their order, dangling positions are removed
+// and records without position are appended to the end.
+func (r *RecordDB) RebuildPositions(ctx context.Context, pn insolar.PulseNumber) error {
+	r.batchLock.Lock()
+	defer r.batchLock.Unlock()
+
+	return r.db.Update(func(txn store.Txn) error {
+		stored := map[insolar.ID]struct{}{}
+		var ids []insolar.ID
+
+		it := txn.NewIterator(recordKey(*insolar.NewID(pn, nil)), false)
+		for it.Next() && insolar.NewPulseNumber(it.Key()) == pn {
+			id := insolar.ID(newRecordKey(it.Key()))
+			stored[id] = struct{}{}
+			ids = append(ids, id)
+		}
+		it.Close()
+
+		lastKnownPosition, err := getLastKnownPosition(txn, pn)
+		if err != nil && err != ErrNotFound {
+			return err
+		}
+
+		positioned := make(map[insolar.ID]struct{}, len(ids))
+		ordered := make([]insolar.ID, 0, len(ids))
+		for position := uint32(1); position <= lastKnownPosition; position++ {
+			positionKey := newRecordPositionKey(pn, position)
+
+			rawID, err := txn.Get(positionKey)
+			if err == store.ErrNotFound {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			err = txn.Delete(positionKey)
+			if err != nil {
+				return err
+			}
+
+			id := *insolar.NewIDFromBytes(rawID)
+			if _, ok := stored[id]; !ok {
+				inslogger.FromContext(ctx).Infof("RebuildPositions. Dangling position %d removed: %s", position, id.DebugString())
+				continue
+			}
+			if _, ok := positioned[id]; ok {
+				continue
+			}
+			positioned[id] = struct{}{}
+			ordered = append(ordered, id)
+		}
+
+		for _, id := range ids {
+			if _, ok := positioned[id]; ok {
+				continue
+			}
+			inslogger.FromContext(ctx).Infof("RebuildPositions. Position added: %s", id.DebugString())
+			ordered = append(ordered, id)
+		}
+
+		for i, id := range ordered {
+			err := setPosition(txn, id, uint32(i+1))
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(ordered) == 0 {
+			return txn.Delete(lastKnownRecordPositionKey{pn: pn})
+		}
+		return setLastKnownPosition(txn, pn, uint32(len(ordered)))
+	})
+}
@@ -39,6 +39,9 @@ type Client interface {
 	// GetAbandonedRequest returns an incoming or outgoing request for an object.
 	GetAbandonedRequest(ctx context.Context, objectRef, reqRef insolar.Reference) (record.Request, error)
 
+	// GetRequestInfo returns request, its result and outgoing requests spawned by it from the filament of an object.
+	GetRequestInfo(ctx context.Context, objectRef, reqRef insolar.Reference) (*payload.RequestInfo, error)
+
 	// GetPendings returns pending request IDs of an object.
 	GetPendings(ctx context.Context, objectRef insolar.Reference) ([]insolar.Reference, error)
 
@@ -405,6 +405,49 @@ func (m *client) GetAbandonedRequest(
 	return result, nil
 }
 
+// GetRequestInfo returns request, its result and outgoing requests spawned by it from the filament of an object.
+func (m *client) GetRequestInfo(
+	ctx context.Context, object, reqRef insolar.Reference,
+) (*payload.RequestInfo, error) {
+	var err error
+	instrumenter := instrument(ctx, "GetRequestInfo").err(&err)
+	ctx, span := instracer.StartSpan(ctx, "artifacts.GetRequestInfo")
+	defer func() {
+		if err != nil {
+			instracer.AddError(span, err)
+		}
+		span.End()
+		instrumenter.end()
+	}()
+
+	currentPN, err := m.pulse(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get current pulse")
+	}
+
+	getRequestInfoPl := &payload.GetRequestInfo{
+		ObjectID:  *object.GetLocal(),
+		RequestID: *reqRef.GetLocal(),
+		Pulse:     currentPN,
+	}
+
+	pl, err := m.sendToLight(ctx, m.sender, getRequestInfoPl, object)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to send GetRequestInfo")
+	}
+
+	switch concrete := pl.(type) {
+	case *payload.RequestInfo:
+		return concrete, nil
+	case *payload.Error:
+		err = errors.New(concrete.Text)
+		return nil, err
+	default:
+		err = fmt.Errorf("unexpected reply %T", pl)
+		return nil, err
+	}
+}
+
 // GetPendings returns a list of pending requests
 func (m *client) GetPendings(ctx context.Context, object insolar.Reference) ([]insolar.Reference, error) {
 	var err error
@@ -54,6 +54,12 @@ type ClientMock struct {
 	beforeGetPendingsCounter uint64
 	GetPendingsMock          mClientMockGetPendings
 
+	funcGetRequestInfo          func(ctx context.Context, objectRef insolar.Reference, reqRef insolar.Reference) (rp1 *payload.RequestInfo, err error)
+	inspectFuncGetRequestInfo   func(ctx context.Context, objectRef insolar.Reference, reqRef insolar.Reference)
+	afterGetRequestInfoCounter  uint64
+	beforeGetRequestInfoCounter uint64
+	GetRequestInfoMock          mClientMockGetRequestInfo
+
 	funcHasPendings          func(ctx context.Context, object insolar.Reference) (b1 bool, err error)
 	inspectFuncHasPendings   func(ctx context.Context, object insolar.Reference)
 	afterHasPendingsCounter  uint64
@@ -128,6 +134,9 @@ func NewClientMock(t minimock.Tester) *ClientMock {
 	m.GetPendingsMock = mClientMockGetPendings{mock: m}
 	m.GetPendingsMock.callArgs = []*ClientMockGetPendingsParams{}
 
+	m.GetRequestInfoMock = mClientMockGetRequestInfo{mock: m}
+	m.GetRequestInfoMock.callArgs = []*ClientMockGetRequestInfoParams{}
+
 	m.HasPendingsMock = mClientMockHasPendings{mock: m}
 	m.HasPendingsMock.callArgs = []*ClientMockHasPendingsParams{}
 
@@ -1461,6 +1470,224 @@ func (m *ClientMock) MinimockGetPendingsInspect() {
 	}
 }
 
+type mClientMockGetRequestInfo struct {
+	mock               *ClientMock
+	defaultExpectation *ClientMockGetRequestInfoExpectation
+	expectations       []*ClientMockGetRequestInfoExpectation
+
+	callArgs []*ClientMockGetRequestInfoParams
+	mutex    sync.RWMutex
+}
+
+// ClientMockGetRequestInfoExpectation specifies expectation struct of the Client.GetRequestInfo
+type ClientMockGetRequestInfoExpectation struct {
+	mock    *ClientMock
+	params  *ClientMockGetRequestInfoParams
+	results *ClientMockGetRequestInfoResults
+	Counter uint64
+}
+
+// ClientMockGetRequestInfoParams contains parameters of the Client.GetRequestInfo
+type ClientMockGetRequestInfoParams struct {
+	ctx       context.Context
+	objectRef insolar.Reference
+	reqRef    insolar.Reference
+}
+
+// ClientMockGetRequestInfoResults contains results of the Client.GetRequestInfo
+type ClientMockGetRequestInfoResults struct {
+	rp1 *payload.RequestInfo
+	err error
+}
+
+// Expect sets up expected params for Client.GetRequestInfo
+func (mmGetRequestInfo *mClientMockGetRequestInfo) Expect(ctx context.Context, objectRef insolar.Reference, reqRef insolar.Reference) *mClientMockGetRequestInfo {
+	if mmGetRequestInfo.mock.funcGetRequestInfo != nil {
+		mmGetRequestInfo.mock.t.Fatalf("ClientMock.GetRequestInfo mock is already set by Set")
+	}
+
+	if mmGetRequestInfo.defaultExpectation == nil {
+		mmGetRequestInfo.defaultExpectation = &ClientMockGetRequestInfoExpectation{}
+	}
+
+	mmGetRequestInfo.defaultExpectation.params = &ClientMockGetRequestInfoParams{ctx, objectRef, reqRef}
+	for _, e := range mmGetRequestInfo.expectations {
+		if minimock.Equal(e.params, mmGetRequestInfo.defaultExpectation.params) {
+			mmGetRequestInfo.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetRequestInfo.defaultExpectation.params)
+		}
+	}
+
+	return mmGetRequestInfo
+}
+
+// Inspect accepts an inspector function that has same arguments as the Client.GetRequestInfo
+func (mmGetRequestInfo *mClientMockGetRequestInfo) Inspect(f func(ctx context.Context, objectRef insolar.Reference, reqRef insolar.Reference)) *mClientMockGetRequestInfo {
+	if mmGetRequestInfo.mock.inspectFuncGetRequestInfo != nil {
+		mmGetRequestInfo.mock.t.Fatalf("Inspect function is already set for ClientMock.GetRequestInfo")
+	}
+
+	mmGetRequestInfo.mock.inspectFuncGetRequestInfo = f
+
+	return mmGetRequestInfo
+}
+
+// Return sets up results that will be returned by Client.GetRequestInfo
+func (mmGetRequestInfo *mClientMockGetRequestInfo) Return(rp1 *payload.RequestInfo, err error) *ClientMock {
+	if mmGetRequestInfo.mock.funcGetRequestInfo != nil {
+		mmGetRequestInfo.mock.t.Fatalf("ClientMock.GetRequestInfo mock is already set by Set")
+	}
+
+	if mmGetRequestInfo.defaultExpectation == nil {
+		mmGetRequestInfo.defaultExpectation = &ClientMockGetRequestInfoExpectation{mock: mmGetRequestInfo.mock}
+	}
+	mmGetRequestInfo.defaultExpectation.results = &ClientMockGetRequestInfoResults{rp1, err}
+	return mmGetRequestInfo.mock
+}
+
+//Set uses given function f to mock the Client.GetRequestInfo method
+func (mmGetRequestInfo *mClientMockGetRequestInfo) Set(f func(ctx context.Context, objectRef insolar.Reference, reqRef insolar.Reference) (rp1 *payload.RequestInfo, err error)) *ClientMock {
+	if mmGetRequestInfo.defaultExpectation != nil {
+		mmGetRequestInfo.mock.t.Fatalf("Default expectation is already set for the Client.GetRequestInfo method")
+	}
+
+	if len(mmGetRequestInfo.expectations) > 0 {
+		mmGetRequestInfo.mock.t.Fatalf("Some expectations are already set for the Client.GetRequestInfo method")
+	}
+
+	mmGetRequestInfo.mock.funcGetRequestInfo = f
+	return mmGetRequestInfo.mock
+}
+
+// When sets expectation for the Client.GetRequestInfo which will trigger the result defined by the following
+// Then helper
+func (mmGetRequestInfo *mClientMockGetRequestInfo) When(ctx context.Context, objectRef insolar.Reference, reqRef insolar.Reference) *ClientMockGetRequestInfoExpectation {
+	if mmGetRequestInfo.mock.funcGetRequestInfo != nil {
+		mmGetRequestInfo.mock.t.Fatalf("ClientMock.GetRequestInfo mock is already set by Set")
+	}
+
+	expectation := &ClientMockGetRequestInfoExpectation{
+		mock:   mmGetRequestInfo.mock,
+		params: &ClientMockGetRequestInfoParams{ctx, objectRef, reqRef},
+	}
+	mmGetRequestInfo.expectations = append(mmGetRequestInfo.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Client.GetRequestInfo return parameters for the expectation previously defined by the When method
+func (e *ClientMockGetRequestInfoExpectation) Then(rp1 *payload.RequestInfo, err error) *ClientMock {
+	e.results = &ClientMockGetRequestInfoResults{rp1, err}
+	return e.mock
+}
+
+// GetRequestInfo implements Client
+func (mmGetRequestInfo *ClientMock) GetRequestInfo(ctx context.Context, objectRef insolar.Reference, reqRef insolar.Reference) (rp1 *payload.RequestInfo, err error) {
+	mm_atomic.AddUint64(&mmGetRequestInfo.beforeGetRequestInfoCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetRequestInfo.afterGetRequestInfoCounter, 1)
+
+	if mmGetRequestInfo.inspectFuncGetRequestInfo != nil {
+		mmGetRequestInfo.inspectFuncGetRequestInfo(ctx, objectRef, reqRef)
+	}
+
+	params := &ClientMockGetRequestInfoParams{ctx, objectRef, reqRef}
+
+	// Record call args
+	mmGetRequestInfo.GetRequestInfoMock.mutex.Lock()
+	mmGetRequestInfo.GetRequestInfoMock.callArgs = append(mmGetRequestInfo.GetRequestInfoMock.callArgs, params)
+	mmGetRequestInfo.GetRequestInfoMock.mutex.Unlock()
+
+	for _, e := range mmGetRequestInfo.GetRequestInfoMock.expectations {
+		if minimock.Equal(e.params, params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.rp1, e.results.err
+		}
+	}
+
+	if mmGetRequestInfo.GetRequestInfoMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetRequestInfo.GetRequestInfoMock.defaultExpectation.Counter, 1)
+		want := mmGetRequestInfo.GetRequestInfoMock.defaultExpectation.params
+		got := ClientMockGetRequestInfoParams{ctx, objectRef, reqRef}
+		if want != nil && !minimock.Equal(*want, got) {
+			mmGetRequestInfo.t.Errorf("ClientMock.GetRequestInfo got unexpected parameters, want: %#v, got: %#v%s\n", *want, got, minimock.Diff(*want, got))
+		}
+
+		results := mmGetRequestInfo.GetRequestInfoMock.defaultExpectation.results
+		if results == nil {
+			mmGetRequestInfo.t.Fatal("No results are set for the ClientMock.GetRequestInfo")
+		}
+		return (*results).rp1, (*results).err
+	}
+	if mmGetRequestInfo.funcGetRequestInfo != nil {
+		return mmGetRequestInfo.funcGetRequestInfo(ctx, objectRef, reqRef)
+	}
+	mmGetRequestInfo.t.Fatalf("Unexpected call to ClientMock.GetRequestInfo. %v %v %v", ctx, objectRef, reqRef)
+	return
+}
+
+// GetRequestInfoAfterCounter returns a count of finished ClientMock.GetRequestInfo invocations
+func (mmGetRequestInfo *ClientMock) GetRequestInfoAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetRequestInfo.afterGetRequestInfoCounter)
+}
+
+// GetRequestInfoBeforeCounter returns a count of ClientMock.GetRequestInfo invocations
+func (mmGetRequestInfo *ClientMock) GetRequestInfoBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetRequestInfo.beforeGetRequestInfoCounter)
+}
+
+// Calls returns a list of arguments used in each call to ClientMock.GetRequestInfo.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetRequestInfo *mClientMockGetRequestInfo) Calls() []*ClientMockGetRequestInfoParams {
+	mmGetRequestInfo.mutex.RLock()
+
+	argCopy := make([]*ClientMockGetRequestInfoParams, len(mmGetRequestInfo.callArgs))
+	copy(argCopy, mmGetRequestInfo.callArgs)
+
+	mmGetRequestInfo.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetRequestInfoDone returns true if the count of the GetRequestInfo invocations corresponds
+// the number of defined expectations
+func (m *ClientMock) MinimockGetRequestInfoDone() bool {
+	for _, e := range m.GetRequestInfoMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetRequestInfoMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetRequestInfoCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetRequestInfo != nil && mm_atomic.LoadUint64(&m.afterGetRequestInfoCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockGetRequestInfoInspect logs each unmet expectation
+func (m *ClientMock) MinimockGetRequestInfoInspect() {
+	for _, e := range m.GetRequestInfoMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to ClientMock.GetRequestInfo with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetRequestInfoMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetRequestInfoCounter) < 1 {
+		if m.GetRequestInfoMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to ClientMock.GetRequestInfo")
+		} else {
+			m.t.Errorf("Expected call to ClientMock.GetRequestInfo with params: %#v", *m.GetRequestInfoMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetRequestInfo != nil && mm_atomic.LoadUint64(&m.afterGetRequestInfoCounter) < 1 {
+		m.t.Error("Expected call to ClientMock.GetRequestInfo")
+	}
+}
+
 type mClientMockHasPendings struct {
 	mock               *ClientMock
 	defaultExpectation *ClientMockHasPendingsExpectation
@@ -2998,6 +3225,8 @@ func (m *ClientMock) MinimockFinish() {
 
 		m.MinimockGetPendingsInspect()
 
+		m.MinimockGetRequestInfoInspect()
+
 		m.MinimockHasPendingsInspect()
 
 		m.MinimockInjectCodeDescriptorInspect()
@@ -3042,6 +3271,7 @@ func (m *ClientMock) minimockDone() bool {
 		m.MinimockGetCodeDone() &&
 		m.MinimockGetObjectDone() &&
 		m.MinimockGetPendingsDone() &&
+		m.MinimockGetRequestInfoDone() &&
 		m.MinimockHasPendingsDone() &&
 		m.MinimockInjectCodeDescriptorDone() &&
 		m.MinimockInjectFinishDone() &&
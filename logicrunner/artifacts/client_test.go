@@ -206,6 +206,58 @@ func (s *amSuite) TestLedgerArtifactManager_GetPendings_Success() {
 	require.Equal(s.T(), []insolar.Reference{requestRef}, res)
 }
 
+func (s *amSuite) TestLedgerArtifactManager_GetRequestInfo_Success() {
+	// Arrange
+	mc := minimock.NewController(s.T())
+	defer mc.Finish()
+	objectRef := gen.Reference()
+	requestRef := gen.RecordReference()
+
+	pulseAccessor := pulse.NewAccessorMock(s.T())
+	pulseAccessor.LatestMock.Return(*insolar.GenesisPulse, nil)
+
+	requestInfo := &payload.RequestInfo{
+		ObjectID:         *objectRef.GetLocal(),
+		RequestID:        *requestRef.GetLocal(),
+		Request:          []byte{1},
+		Result:           []byte{2},
+		OutgoingRequests: [][]byte{{3}},
+	}
+	resMsg, err := payload.NewMessage(requestInfo)
+	require.NoError(s.T(), err)
+
+	sender := bus.NewSenderMock(s.T())
+	sender.SendRoleMock.Set(func(p context.Context, msg *wmMessage.Message, role insolar.DynamicRole, ref insolar.Reference) (r <-chan *wmMessage.Message, r1 func()) {
+		getRequestInfo := payload.GetRequestInfo{}
+		err := getRequestInfo.Unmarshal(msg.Payload)
+		require.NoError(s.T(), err)
+
+		require.Equal(s.T(), *objectRef.GetLocal(), getRequestInfo.ObjectID)
+		require.Equal(s.T(), *requestRef.GetLocal(), getRequestInfo.RequestID)
+		require.Equal(s.T(), insolar.GenesisPulse.PulseNumber, getRequestInfo.Pulse)
+
+		meta := payload.Meta{Payload: resMsg.Payload}
+		buf, err := meta.Marshal()
+		require.NoError(s.T(), err)
+		resMsg.Payload = buf
+		ch := make(chan *wmMessage.Message, 1)
+		ch <- resMsg
+		return ch, func() {}
+	})
+
+	am := NewClient(sender)
+	am.PulseAccessor = pulseAccessor
+
+	// Act
+	res, err := am.GetRequestInfo(inslogger.TestContext(s.T()), objectRef, requestRef)
+
+	// Assert
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), requestInfo.Request, res.Request)
+	require.Equal(s.T(), requestInfo.Result, res.Result)
+	require.Equal(s.T(), requestInfo.OutgoingRequests, res.OutgoingRequests)
+}
+
 func (s *amSuite) TestLedgerArtifactManager_HasPendings_Success() {
 	// Arrange
 	mc := minimock.NewController(s.T())
@@ -89,6 +89,23 @@ func INSMETHOD_GetPrototype(object []byte, data []byte) ([]byte, []byte, error)
 	return state, ret, err
 }
 
+var INSATTR_INSVERSION_API = true
+
+func INSMETHOD_INSVERSION(object []byte, data []byte) ([]byte, []byte, error) {
+	ph := common.CurrentProxyCtx
+
+	result := []byte{}
+	err := ph.Serialize(
+		foundation.Result{Returns: []interface{}{0}},
+		&result,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return object, result, nil
+}
+
 func INSMETHOD_Accept(object []byte, data []byte) ([]byte, []byte, error) {
 	ph := common.CurrentProxyCtx
 	ph.SetSystemError(nil)
@@ -402,6 +419,7 @@ func Initialize() XXX_insolar.ContractWrapper {
 			"TransferToAccount": INSMETHOD_TransferToAccount,
 			"TransferToDeposit": INSMETHOD_TransferToDeposit,
 			"GetBalance":        INSMETHOD_GetBalance,
+			"INSVERSION":        INSMETHOD_INSVERSION,
 		},
 		Constructors: XXX_insolar.ContractConstructors{
 			"New": INSCONSTRUCTOR_New,
@@ -81,6 +81,23 @@ func INSMETHOD_GetPrototype(object []byte, data []byte) ([]byte, []byte, error)
 	return state, ret, err
 }
 
+var INSATTR_INSVERSION_API = true
+
+func INSMETHOD_INSVERSION(object []byte, data []byte) ([]byte, []byte, error) {
+	ph := common.CurrentProxyCtx
+
+	result := []byte{}
+	err := ph.Serialize(
+		foundation.Result{Returns: []interface{}{0}},
+		&result,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return object, result, nil
+}
+
 func INSMETHOD_GetFeeAccount(object []byte, data []byte) ([]byte, []byte, error) {
 	ph := common.CurrentProxyCtx
 	ph.SetSystemError(nil)
@@ -234,6 +251,7 @@ func Initialize() XXX_insolar.ContractWrapper {
 		Methods: XXX_insolar.ContractMethods{
 			"GetFeeAccount": INSMETHOD_GetFeeAccount,
 			"CalcFee":       INSMETHOD_CalcFee,
+			"INSVERSION":    INSMETHOD_INSVERSION,
 		},
 		Constructors: XXX_insolar.ContractConstructors{
 			"New": INSCONSTRUCTOR_New,
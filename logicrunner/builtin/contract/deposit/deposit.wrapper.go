@@ -89,6 +89,23 @@ func INSMETHOD_GetPrototype(object []byte, data []byte) ([]byte, []byte, error)
 	return state, ret, err
 }
 
+var INSATTR_INSVERSION_API = true
+
+func INSMETHOD_INSVERSION(object []byte, data []byte) ([]byte, []byte, error) {
+	ph := common.CurrentProxyCtx
+
+	result := []byte{}
+	err := ph.Serialize(
+		foundation.Result{Returns: []interface{}{0}},
+		&result,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return object, result, nil
+}
+
 func INSMETHOD_GetTxHash(object []byte, data []byte) ([]byte, []byte, error) {
 	ph := common.CurrentProxyCtx
 	ph.SetSystemError(nil)
@@ -512,6 +529,7 @@ func Initialize() XXX_insolar.ContractWrapper {
 			"Confirm":        INSMETHOD_Confirm,
 			"Transfer":       INSMETHOD_Transfer,
 			"Accept":         INSMETHOD_Accept,
+			"INSVERSION":     INSMETHOD_INSVERSION,
 		},
 		Constructors: XXX_insolar.ContractConstructors{
 			"New": INSCONSTRUCTOR_New,
@@ -81,6 +81,23 @@ func INSMETHOD_GetPrototype(object []byte, data []byte) ([]byte, []byte, error)
 	return state, ret, err
 }
 
+var INSATTR_INSVERSION_API = true
+
+func INSMETHOD_INSVERSION(object []byte, data []byte) ([]byte, []byte, error) {
+	ph := common.CurrentProxyCtx
+
+	result := []byte{}
+	err := ph.Serialize(
+		foundation.Result{Returns: []interface{}{0}},
+		&result,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return object, result, nil
+}
+
 func INSMETHOD_RegisterNode(object []byte, data []byte) ([]byte, []byte, error) {
 	ph := common.CurrentProxyCtx
 	ph.SetSystemError(nil)
@@ -288,6 +305,7 @@ func Initialize() XXX_insolar.ContractWrapper {
 			"RegisterNode":          INSMETHOD_RegisterNode,
 			"GetNodeRefByPublicKey": INSMETHOD_GetNodeRefByPublicKey,
 			"RemoveNode":            INSMETHOD_RemoveNode,
+			"INSVERSION":            INSMETHOD_INSVERSION,
 		},
 		Constructors: XXX_insolar.ContractConstructors{
 			"NewNodeDomain": INSCONSTRUCTOR_NewNodeDomain,
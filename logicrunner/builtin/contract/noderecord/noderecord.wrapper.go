@@ -80,6 +80,23 @@ func INSMETHOD_GetPrototype(object []byte, data []byte) ([]byte, []byte, error)
 	return state, ret, err
 }
 
+var INSATTR_INSVERSION_API = true
+
+func INSMETHOD_INSVERSION(object []byte, data []byte) ([]byte, []byte, error) {
+	ph := common.CurrentProxyCtx
+
+	result := []byte{}
+	err := ph.Serialize(
+		foundation.Result{Returns: []interface{}{0}},
+		&result,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return object, result, nil
+}
+
 func INSMETHOD_GetNodeInfo(object []byte, data []byte) ([]byte, []byte, error) {
 	ph := common.CurrentProxyCtx
 	ph.SetSystemError(nil)
@@ -333,6 +350,7 @@ func Initialize() XXX_insolar.ContractWrapper {
 			"GetPublicKey": INSMETHOD_GetPublicKey,
 			"GetRole":      INSMETHOD_GetRole,
 			"Destroy":      INSMETHOD_Destroy,
+			"INSVERSION":   INSMETHOD_INSVERSION,
 		},
 		Constructors: XXX_insolar.ContractConstructors{
 			"NewNodeRecord": INSCONSTRUCTOR_NewNodeRecord,
@@ -81,6 +81,23 @@ func INSMETHOD_GetPrototype(object []byte, data []byte) ([]byte, []byte, error)
 	return state, ret, err
 }
 
+var INSATTR_INSVERSION_API = true
+
+func INSMETHOD_INSVERSION(object []byte, data []byte) ([]byte, []byte, error) {
+	ph := common.CurrentProxyCtx
+
+	result := []byte{}
+	err := ph.Serialize(
+		foundation.Result{Returns: []interface{}{0}},
+		&result,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return object, result, nil
+}
+
 func INSMETHOD_GetMemberByPublicKey(object []byte, data []byte) ([]byte, []byte, error) {
 	ph := common.CurrentProxyCtx
 	ph.SetSystemError(nil)
@@ -292,6 +309,7 @@ func Initialize() XXX_insolar.ContractWrapper {
 			"GetNodeDomainRef":           INSMETHOD_GetNodeDomainRef,
 			"AddNewMemberToPublicKeyMap": INSMETHOD_AddNewMemberToPublicKeyMap,
 			"CreateHelloWorld":           INSMETHOD_CreateHelloWorld,
+			"INSVERSION":                 INSMETHOD_INSVERSION,
 		},
 		Constructors: XXX_insolar.ContractConstructors{},
 	}
@@ -171,6 +171,26 @@ func InitializeCodeDescriptors() []XXX_artifacts.CodeDescriptor {
 	return rv
 }
 
+func InitializePrototypeABIs() map[XXX_insolar.Reference]string {
+	rv := make(map[XXX_insolar.Reference]string, 0)
+
+	rv[shouldLoadRef("0111A62X73fkPeY5vK6NjcXgmL9d37DgRRNtHNLGaEse")] = "{\"contract\":\"Account\",\"version\":0,\"constructors\":[{\"name\":\"New\",\"arguments\":[{\"name\":\"balance\",\"type\":\"string\"}],\"results\":[\"*Account\",\"error\"]}],\"methods\":[{\"name\":\"Accept\",\"arguments\":[{\"name\":\"amountStr\",\"type\":\"string\"}],\"results\":[\"error\"],\"saga\":{\"rollback\":\"INS_FLAG_NO_ROLLBACK_METHOD\"}},{\"name\":\"RollBack\",\"arguments\":[{\"name\":\"amountStr\",\"type\":\"string\"}],\"results\":[\"error\"]},{\"name\":\"TransferToAccount\",\"arguments\":[{\"name\":\"amountStr\",\"type\":\"string\"},{\"name\":\"toAccount\",\"type\":\"insolar.Reference\"}],\"results\":[\"error\"]},{\"name\":\"TransferToDeposit\",\"arguments\":[{\"name\":\"amountStr\",\"type\":\"string\"},{\"name\":\"toDeposit\",\"type\":\"insolar.Reference\"}],\"results\":[\"error\"]},{\"name\":\"GetBalance\",\"arguments\":[],\"results\":[\"string\",\"error\"],\"immutable\":true}],\"types\":[{\"name\":\"destination\",\"type\":\"interface {\\n\\tAccept(string) error\\n}\"}]}"
+	rv[shouldLoadRef("0111A62HrJvAimG7M1r8XdeBVMw4X6ge8hGzVStfnn4e")] = "{\"contract\":\"CostCenter\",\"version\":0,\"constructors\":[{\"name\":\"New\",\"arguments\":[{\"name\":\"feeAccount\",\"type\":\"insolar.Reference\"}],\"results\":[\"*CostCenter\",\"error\"]}],\"methods\":[{\"name\":\"GetFeeAccount\",\"arguments\":[],\"results\":[\"insolar.Reference\",\"error\"],\"immutable\":true},{\"name\":\"CalcFee\",\"arguments\":[{\"name\":\"amountStr\",\"type\":\"string\"}],\"results\":[\"string\",\"error\"],\"immutable\":true}]}"
+	rv[shouldLoadRef("0111A7ctasuNUug8BoK4VJNuAFJ73rnH8bH5zqd5HrDj")] = "{\"contract\":\"Deposit\",\"version\":0,\"constructors\":[{\"name\":\"New\",\"arguments\":[{\"name\":\"migrationDaemonRef\",\"type\":\"insolar.Reference\"},{\"name\":\"txHash\",\"type\":\"string\"},{\"name\":\"amount\",\"type\":\"string\"},{\"name\":\"lockup\",\"type\":\"int64\"},{\"name\":\"vesting\",\"type\":\"int64\"},{\"name\":\"vestingStep\",\"type\":\"int64\"}],\"results\":[\"*Deposit\",\"error\"]}],\"methods\":[{\"name\":\"GetTxHash\",\"arguments\":[],\"results\":[\"string\",\"error\"],\"immutable\":true},{\"name\":\"GetAmount\",\"arguments\":[],\"results\":[\"string\",\"error\"],\"immutable\":true},{\"name\":\"GetPulseUnHold\",\"arguments\":[],\"results\":[\"insolar.PulseNumber\",\"error\"],\"immutable\":true},{\"name\":\"Itself\",\"arguments\":[],\"results\":[\"interface{}\",\"error\"],\"immutable\":true},{\"name\":\"Confirm\",\"arguments\":[{\"name\":\"migrationDaemonRef\",\"type\":\"string\"},{\"name\":\"txHash\",\"type\":\"string\"},{\"name\":\"amountStr\",\"type\":\"string\"}],\"results\":[\"error\"]},{\"name\":\"Transfer\",\"arguments\":[{\"name\":\"amountStr\",\"type\":\"string\"},{\"name\":\"wallerRef\",\"type\":\"insolar.Reference\"}],\"results\":[\"interface{}\",\"error\"]},{\"name\":\"Accept\",\"arguments\":[{\"name\":\"amountStr\",\"type\":\"string\"}],\"results\":[\"error\"],\"saga\":{\"rollback\":\"INS_FLAG_NO_ROLLBACK_METHOD\"}}]}"
+	rv[shouldLoadRef("0111A85JAZugtAkQErbDe3eAaTw56DPLku8QGymJUCt2")] = "{\"contract\":\"HelloWorld\",\"version\":0,\"constructors\":[{\"name\":\"New\",\"arguments\":[],\"results\":[\"*HelloWorld\",\"error\"]}],\"methods\":[{\"name\":\"ReturnObj\",\"arguments\":[],\"results\":[\"interface{}\",\"error\"]},{\"name\":\"Greet\",\"arguments\":[{\"name\":\"name\",\"type\":\"string\"}],\"results\":[\"interface{}\",\"error\"],\"api\":true},{\"name\":\"Count\",\"arguments\":[],\"results\":[\"interface{}\",\"error\"]},{\"name\":\"Errored\",\"arguments\":[],\"results\":[\"interface{}\",\"error\"]},{\"name\":\"PulseNumber\",\"arguments\":[],\"results\":[\"insolar.PulseNumber\",\"error\"]},{\"name\":\"CreateChild\",\"arguments\":[],\"results\":[\"interface{}\",\"error\"]},{\"name\":\"Call\",\"arguments\":[{\"name\":\"signedRequest\",\"type\":\"[]byte\"}],\"results\":[\"interface{}\",\"error\"]}],\"types\":[{\"name\":\"HwMessage\",\"type\":\"struct\",\"fields\":[{\"name\":\"Message\",\"type\":\"Text\"}]},{\"name\":\"Params\",\"type\":\"struct\",\"fields\":[{\"name\":\"Seed\",\"type\":\"string\"},{\"name\":\"CallSite\",\"type\":\"string\"},{\"name\":\"CallParams\",\"type\":\"interface{}\"},{\"name\":\"Reference\",\"type\":\"string\"},{\"name\":\"PublicKey\",\"type\":\"string\"}]},{\"name\":\"Request\",\"type\":\"struct\",\"fields\":[{\"name\":\"JsonRpc\",\"type\":\"string\"},{\"name\":\"Id\",\"type\":\"int\"},{\"name\":\"Method\",\"type\":\"string\"},{\"name\":\"Params\",\"type\":\"Params\"},{\"name\":\"LogLevel\",\"type\":\"string\"}]},{\"name\":\"Text\",\"type\":\"struct\",\"fields\":[{\"name\":\"SomeText\",\"type\":\"string\"}]}]}"
+	rv[shouldLoadRef("0111A7UqbgvFXj9vkCAaNYSAkWLapu62eU5AUSv3y4JY")] = "{\"contract\":\"Member\",\"version\":0,\"constructors\":[{\"name\":\"New\",\"arguments\":[{\"name\":\"rootDomain\",\"type\":\"insolar.Reference\"},{\"name\":\"name\",\"type\":\"string\"},{\"name\":\"key\",\"type\":\"string\"},{\"name\":\"migrationAddress\",\"type\":\"string\"},{\"name\":\"walletRef\",\"type\":\"insolar.Reference\"}],\"results\":[\"*Member\",\"error\"]}],\"methods\":[{\"name\":\"GetName\",\"arguments\":[],\"results\":[\"string\",\"error\"],\"immutable\":true},{\"name\":\"GetWallet\",\"arguments\":[],\"results\":[\"*insolar.Reference\",\"error\"],\"immutable\":true},{\"name\":\"GetAccount\",\"arguments\":[{\"name\":\"assetName\",\"type\":\"string\"}],\"results\":[\"*insolar.Reference\",\"error\"],\"immutable\":true},{\"name\":\"GetPublicKey\",\"arguments\":[],\"results\":[\"string\",\"error\"],\"immutable\":true,\"api\":true},{\"name\":\"Call\",\"arguments\":[{\"name\":\"signedRequest\",\"type\":\"[]byte\"}],\"results\":[\"interface{}\",\"error\"],\"immutable\":true,\"api\":true},{\"name\":\"GetMigrationAddress\",\"arguments\":[],\"results\":[\"string\",\"error\"],\"immutable\":true}],\"types\":[{\"name\":\"CreateResponse\",\"type\":\"struct\",\"fields\":[{\"name\":\"Reference\",\"type\":\"string\"}]},{\"name\":\"DepositMigrationResult\",\"type\":\"struct\",\"fields\":[{\"name\":\"Reference\",\"type\":\"string\"}]},{\"name\":\"GetBalanceResponse\",\"type\":\"struct\",\"fields\":[{\"name\":\"Balance\",\"type\":\"string\"},{\"name\":\"Deposits\",\"type\":\"map[string]interface{}\"}]},{\"name\":\"GetResponse\",\"type\":\"struct\",\"fields\":[{\"name\":\"Reference\",\"type\":\"string\"},{\"name\":\"MigrationAddress\",\"type\":\"string\"}]},{\"name\":\"MigrationCreateResponse\",\"type\":\"struct\",\"fields\":[{\"name\":\"Reference\",\"type\":\"string\"},{\"name\":\"MigrationAddress\",\"type\":\"string\"}]},{\"name\":\"Params\",\"type\":\"struct\",\"fields\":[{\"name\":\"Seed\",\"type\":\"string\"},{\"name\":\"CallSite\",\"type\":\"string\"},{\"name\":\"CallParams\",\"type\":\"interface{}\"},{\"name\":\"Reference\",\"type\":\"string\"},{\"name\":\"PublicKey\",\"type\":\"string\"},{\"name\":\"LogLevel\",\"type\":\"string\"},{\"name\":\"Test\",\"type\":\"string\"}]},{\"name\":\"Request\",\"type\":\"struct\",\"fields\":[{\"name\":\"JSONRPC\",\"type\":\"string\"},{\"name\":\"ID\",\"type\":\"uint64\"},{\"name\":\"Method\",\"type\":\"string\"},{\"name\":\"Params\",\"type\":\"Params\"}]},{\"name\":\"TransferResponse\",\"type\":\"struct\",\"fields\":[{\"name\":\"Fee\",\"type\":\"string\"}]}]}"
+	rv[shouldLoadRef("0111A8DhUhw5pzyvzVg1qXomNEHXs7kDtJRQGSD1PUpc")] = "{\"contract\":\"MigrationAdmin\",\"version\":0,\"constructors\":[],\"methods\":[{\"name\":\"MigrationAdminCall\",\"arguments\":[{\"name\":\"params\",\"type\":\"map[string]interface{}\"},{\"name\":\"nameMethod\",\"type\":\"string\"},{\"name\":\"caller\",\"type\":\"insolar.Reference\"}],\"results\":[\"interface{}\",\"error\"]},{\"name\":\"GetDepositParameters\",\"arguments\":[],\"results\":[\"*VestingParams\",\"error\"]},{\"name\":\"GetMemberByMigrationAddress\",\"arguments\":[{\"name\":\"migrationAddress\",\"type\":\"string\"}],\"results\":[\"*insolar.Reference\",\"error\"],\"immutable\":true},{\"name\":\"GetFreeMigrationAddress\",\"arguments\":[{\"name\":\"publicKey\",\"type\":\"string\"}],\"results\":[\"string\",\"error\"],\"immutable\":true},{\"name\":\"AddNewMigrationAddressToMaps\",\"arguments\":[{\"name\":\"migrationAddress\",\"type\":\"string\"},{\"name\":\"memberRef\",\"type\":\"insolar.Reference\"}],\"results\":[\"error\"],\"immutable\":true}],\"types\":[{\"name\":\"CheckDaemonResponse\",\"type\":\"struct\",\"fields\":[{\"name\":\"Status\",\"type\":\"string\"}]},{\"name\":\"VestingParams\",\"type\":\"struct\",\"fields\":[{\"name\":\"Lockup\",\"type\":\"int64\"},{\"name\":\"Vesting\",\"type\":\"int64\"},{\"name\":\"VestingStep\",\"type\":\"int64\"}]}]}"
+	rv[shouldLoadRef("0111A7jZX41e1SpH9oW3F2dgUvVQdjSqXEAGQSxhbqmD")] = "{\"contract\":\"MigrationDaemon\",\"version\":0,\"constructors\":[],\"methods\":[{\"name\":\"SetActivationStatus\",\"arguments\":[{\"name\":\"status\",\"type\":\"bool\"}],\"results\":[\"error\"]},{\"name\":\"GetActivationStatus\",\"arguments\":[],\"results\":[\"bool\",\"error\"],\"immutable\":true},{\"name\":\"GetMigrationDaemonMember\",\"arguments\":[],\"results\":[\"insolar.Reference\",\"error\"],\"immutable\":true}]}"
+	rv[shouldLoadRef("0111A7FNYLZLYXYWZPbkMhCAPwV9nYrWWE7L57CtdJCj")] = "{\"contract\":\"MigrationShard\",\"version\":0,\"constructors\":[{\"name\":\"New\",\"arguments\":[{\"name\":\"migrationAddresses\",\"type\":\"[]string\"}],\"results\":[\"*MigrationShard\",\"error\"]}],\"methods\":[{\"name\":\"GetMigrationAddressesAmount\",\"arguments\":[{\"name\":\"migrationAddresses\",\"type\":\"[]string\"}],\"results\":[\"int\",\"error\"],\"immutable\":true},{\"name\":\"AddFreeMigrationAddresses\",\"arguments\":[{\"name\":\"migrationAddresses\",\"type\":\"[]string\"}],\"results\":[\"error\"]},{\"name\":\"GetFreeMigrationAddress\",\"arguments\":[],\"results\":[\"string\",\"error\"]},{\"name\":\"GetRef\",\"arguments\":[{\"name\":\"key\",\"type\":\"string\"}],\"results\":[\"string\",\"error\"],\"immutable\":true},{\"name\":\"SetRef\",\"arguments\":[{\"name\":\"ma\",\"type\":\"string\"},{\"name\":\"ref\",\"type\":\"string\"}],\"results\":[\"error\"]}]}"
+	rv[shouldLoadRef("0111A6NKbCjpzFr9MttfcWV8vX8eFjiyGPPfSH1AMtwN")] = "{\"contract\":\"NodeDomain\",\"version\":0,\"constructors\":[{\"name\":\"NewNodeDomain\",\"arguments\":[],\"results\":[\"*NodeDomain\",\"error\"]}],\"methods\":[{\"name\":\"RegisterNode\",\"arguments\":[{\"name\":\"publicKey\",\"type\":\"string\"},{\"name\":\"role\",\"type\":\"string\"}],\"results\":[\"string\",\"error\"]},{\"name\":\"GetNodeRefByPublicKey\",\"arguments\":[{\"name\":\"publicKey\",\"type\":\"string\"}],\"results\":[\"string\",\"error\"],\"immutable\":true},{\"name\":\"RemoveNode\",\"arguments\":[{\"name\":\"nodeRef\",\"type\":\"insolar.Reference\"}],\"results\":[\"error\"]}]}"
+	rv[shouldLoadRef("0111A5fZeApbGhcsLrbfGy82kKLgapF93GhNPMLSYaPY")] = "{\"contract\":\"NodeRecord\",\"version\":0,\"constructors\":[{\"name\":\"NewNodeRecord\",\"arguments\":[{\"name\":\"publicKey\",\"type\":\"string\"},{\"name\":\"roleStr\",\"type\":\"string\"}],\"results\":[\"*NodeRecord\",\"error\"]}],\"methods\":[{\"name\":\"GetNodeInfo\",\"arguments\":[],\"results\":[\"RecordInfo\",\"error\"],\"immutable\":true,\"api\":true},{\"name\":\"GetPublicKey\",\"arguments\":[],\"results\":[\"string\",\"error\"],\"immutable\":true,\"api\":true},{\"name\":\"GetRole\",\"arguments\":[],\"results\":[\"insolar.StaticRole\",\"error\"],\"immutable\":true},{\"name\":\"Destroy\",\"arguments\":[],\"results\":[\"error\"]}],\"types\":[{\"name\":\"RecordInfo\",\"type\":\"struct\",\"fields\":[{\"name\":\"PublicKey\",\"type\":\"string\"},{\"name\":\"Role\",\"type\":\"insolar.StaticRole\"}]}]}"
+	rv[shouldLoadRef("0111A5x8N1VJTm7BKYgzSe6TWHcFi98QZgw3AnkYiKML")] = "{\"contract\":\"PKShard\",\"version\":0,\"constructors\":[{\"name\":\"New\",\"arguments\":[{\"name\":\"members\",\"type\":\"foundation.StableMap\"}],\"results\":[\"*PKShard\",\"error\"]}],\"methods\":[{\"name\":\"GetRef\",\"arguments\":[{\"name\":\"key\",\"type\":\"string\"}],\"results\":[\"string\",\"error\"],\"immutable\":true},{\"name\":\"SetRef\",\"arguments\":[{\"name\":\"key\",\"type\":\"string\"},{\"name\":\"ref\",\"type\":\"string\"}],\"results\":[\"error\"]}]}"
+	rv[shouldLoadRef("0111A84uiiTD1LXAHNP4GMA6YJFjbnCdkRia2pCqwBV5")] = "{\"contract\":\"RootDomain\",\"version\":0,\"constructors\":[],\"methods\":[{\"name\":\"GetMemberByPublicKey\",\"arguments\":[{\"name\":\"publicKey\",\"type\":\"string\"}],\"results\":[\"*insolar.Reference\",\"error\"],\"immutable\":true},{\"name\":\"GetNodeDomainRef\",\"arguments\":[],\"results\":[\"insolar.Reference\",\"error\"],\"immutable\":true},{\"name\":\"AddNewMemberToPublicKeyMap\",\"arguments\":[{\"name\":\"publicKey\",\"type\":\"string\"},{\"name\":\"memberRef\",\"type\":\"insolar.Reference\"}],\"results\":[\"error\"],\"immutable\":true},{\"name\":\"CreateHelloWorld\",\"arguments\":[],\"results\":[\"string\",\"error\"]}]}"
+	rv[shouldLoadRef("0111A5gmRD1ZbHjQh7DgH9SrCK4a1qfwEUP5xAir6i8L")] = "{\"contract\":\"Wallet\",\"version\":0,\"constructors\":[{\"name\":\"New\",\"arguments\":[{\"name\":\"accountReference\",\"type\":\"insolar.Reference\"}],\"results\":[\"*Wallet\",\"error\"]}],\"methods\":[{\"name\":\"GetAccount\",\"arguments\":[{\"name\":\"assetName\",\"type\":\"string\"}],\"results\":[\"*insolar.Reference\",\"error\"]},{\"name\":\"Transfer\",\"arguments\":[{\"name\":\"rootDomainRef\",\"type\":\"insolar.Reference\"},{\"name\":\"assetName\",\"type\":\"string\"},{\"name\":\"amountStr\",\"type\":\"string\"},{\"name\":\"toMember\",\"type\":\"*insolar.Reference\"}],\"results\":[\"interface{}\",\"error\"]},{\"name\":\"GetBalance\",\"arguments\":[{\"name\":\"assetName\",\"type\":\"string\"}],\"results\":[\"string\",\"error\"]},{\"name\":\"Accept\",\"arguments\":[{\"name\":\"amountStr\",\"type\":\"string\"},{\"name\":\"assetName\",\"type\":\"string\"}],\"results\":[\"error\"]},{\"name\":\"AddDeposit\",\"arguments\":[{\"name\":\"txId\",\"type\":\"string\"},{\"name\":\"deposit\",\"type\":\"insolar.Reference\"}],\"results\":[\"error\"]},{\"name\":\"GetDeposits\",\"arguments\":[],\"results\":[\"map[string]interface{}\",\"error\"],\"immutable\":true},{\"name\":\"FindDeposit\",\"arguments\":[{\"name\":\"transactionHash\",\"type\":\"string\"}],\"results\":[\"bool\",\"*insolar.Reference\",\"error\"],\"immutable\":true}]}"
+
+	return rv
+}
+
 func InitializePrototypeDescriptors() []XXX_artifacts.ObjectDescriptor {
 	rv := make([]XXX_artifacts.ObjectDescriptor, 0)
 
@@ -72,6 +72,10 @@ func (le *logicExecutor) ExecuteMethod(ctx context.Context, transcript *common.T
 
 	objDesc := transcript.ObjectDescriptor
 
+	if request.Method == insolar.MigrateMethod {
+		return le.executeMigration(ctx, transcript)
+	}
+
 	protoDesc, codeDesc, err := le.DescriptorsCache.ByObjectDescriptor(ctx, objDesc)
 	if err != nil {
 		return nil, errors.Wrap(err, "couldn't get descriptors")
@@ -125,6 +129,116 @@ func (le *logicExecutor) ExecuteMethod(ctx context.Context, transcript *common.T
 	return res, nil
 }
 
+// executeMigration converts object state by code of request prototype and moves object to this prototype.
+// Version of the state is taken from current prototype of the object, arguments of request are ignored.
+func (le *logicExecutor) executeMigration(ctx context.Context, transcript *common.Transcript) (artifacts.RequestResult, error) {
+	request := transcript.Request
+	objDesc := transcript.ObjectDescriptor
+
+	// contracts can't migrate objects, only administrator through API
+	if !request.Caller.IsEmpty() {
+		return migrationErrorResult(objDesc, errors.New("migration can't be requested by contract"))
+	}
+
+	if request.Prototype == nil {
+		return nil, errors.New("prototype reference is required")
+	}
+
+	currentProto, err := objDesc.Prototype()
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't get prototype of object")
+	}
+	if currentProto.Equal(*request.Prototype) {
+		return migrationErrorResult(objDesc, errors.New("object already has requested prototype"))
+	}
+
+	fromVersion, err := le.objectVersion(ctx, transcript)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't get version of object")
+	}
+
+	args, err := insolar.Serialize([]interface{}{fromVersion})
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't serialize migration arguments")
+	}
+
+	protoDesc, codeDesc, err := le.DescriptorsCache.ByPrototypeRef(ctx, *request.Prototype)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't get descriptors")
+	}
+
+	executor, err := le.MachinesManager.GetExecutor(codeDesc.MachineType())
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't get executor")
+	}
+
+	transcript.LogicContext = le.genLogicCallContext(ctx, transcript, protoDesc, codeDesc)
+
+	// migration of new prototype refuses versions it doesn't convert
+	newData, result, err := executor.CallMethod(
+		ctx, transcript.LogicContext, *codeDesc.Ref(), objDesc.Memory(), insolar.MigrateMethod, args,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "executor error")
+	}
+	if len(result) == 0 {
+		return nil, errors.New("return of migration is empty")
+	}
+
+	res := requestresult.New(result, *objDesc.HeadRef())
+	// empty state means logical error in migration, object stays as is
+	if len(newData) != 0 {
+		res.SetMigrate(objDesc, *request.Prototype, newData)
+	}
+	return res, nil
+}
+
+// objectVersion returns version of contract the object state is written by, it's asked from code
+// of current prototype of the object.
+func (le *logicExecutor) objectVersion(ctx context.Context, transcript *common.Transcript) (int, error) {
+	protoDesc, codeDesc, err := le.DescriptorsCache.ByObjectDescriptor(ctx, transcript.ObjectDescriptor)
+	if err != nil {
+		return 0, errors.Wrap(err, "couldn't get descriptors")
+	}
+
+	executor, err := le.MachinesManager.GetExecutor(codeDesc.MachineType())
+	if err != nil {
+		return 0, errors.Wrap(err, "couldn't get executor")
+	}
+
+	args, err := insolar.Serialize([]interface{}{})
+	if err != nil {
+		return 0, errors.Wrap(err, "couldn't serialize arguments")
+	}
+
+	_, result, err := executor.CallMethod(
+		ctx, le.genLogicCallContext(ctx, transcript, protoDesc, codeDesc), *codeDesc.Ref(),
+		transcript.ObjectDescriptor.Memory(), insolar.VersionMethod, args,
+	)
+	if err != nil {
+		return 0, errors.Wrap(err, "executor error")
+	}
+
+	var version int
+	logicErr, err := foundation.UnmarshalMethodResult(result, &version)
+	if err != nil {
+		return 0, errors.Wrap(err, "couldn't unmarshal version")
+	}
+	if logicErr != nil {
+		return 0, logicErr
+	}
+	return version, nil
+}
+
+func migrationErrorResult(objDesc artifacts.ObjectDescriptor, err error) (artifacts.RequestResult, error) {
+	errResBuf, err := foundation.MarshalMethodErrorResult(err)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't marshal result")
+	}
+
+	return requestresult.New(errResBuf, *objDesc.HeadRef()), nil
+}
+
 func (le *logicExecutor) ExecuteConstructor(
 	ctx context.Context, transcript *common.Transcript,
 ) (
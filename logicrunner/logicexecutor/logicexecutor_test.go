@@ -421,6 +421,259 @@ func TestLogicExecutor_ExecuteMethod(t *testing.T) {
 	}
 }
 
+func TestLogicExecutor_ExecuteMigration(t *testing.T) {
+	objRef := gen.Reference()
+	objRecordID := gen.ID()
+	oldProtoRef := gen.Reference()
+	oldCodeRef := gen.Reference()
+	newProtoRef := gen.Reference()
+	codeRef := gen.Reference()
+
+	versionResult, err := foundation.MarshalMethodResult(1)
+	require.NoError(t, err)
+	migrationArgs, err := insolar.Serialize([]interface{}{1})
+	require.NoError(t, err)
+
+	objectDescriptor := func(mc minimock.Tester, protoRef insolar.Reference) *artifacts.ObjectDescriptorMock {
+		return artifacts.NewObjectDescriptorMock(mc).
+			ParentMock.Return(nil).
+			MemoryMock.Return([]byte{1, 2, 3}).
+			HeadRefMock.Return(&objRef).
+			PrototypeMock.Return(&protoRef, nil)
+	}
+	descriptorsCache := func(mc minimock.Tester) *artifacts.DescriptorsCacheMock {
+		return artifacts.NewDescriptorsCacheMock(mc).
+			ByObjectDescriptorMock.
+			Return(
+				artifacts.NewObjectDescriptorMock(mc).
+					HeadRefMock.Return(&oldProtoRef),
+				artifacts.NewCodeDescriptorMock(mc).
+					RefMock.Return(&oldCodeRef).
+					MachineTypeMock.Return(insolar.MachineTypeBuiltin),
+				nil,
+			).
+			ByPrototypeRefMock.
+			Inspect(func(ctx context.Context, protoRef insolar.Reference) {
+				require.Equal(t, newProtoRef, protoRef)
+			}).
+			Return(
+				artifacts.NewObjectDescriptorMock(mc).
+					HeadRefMock.Return(&newProtoRef),
+				artifacts.NewCodeDescriptorMock(mc).
+					RefMock.Return(&codeRef).
+					MachineTypeMock.Return(insolar.MachineTypeBuiltin),
+				nil,
+			)
+	}
+	machinesManager := func(mc minimock.Tester, migrate func() ([]byte, insolar.Arguments, error)) *machinesmanager.MachinesManagerMock {
+		return machinesmanager.NewMachinesManagerMock(mc).
+			GetExecutorMock.
+			Return(
+				testutils.NewMachineLogicExecutorMock(mc).
+					CallMethodMock.Set(
+					func(ctx context.Context, callCtx *insolar.LogicCallContext, code insolar.Reference, data []byte, method string, args insolar.Arguments) ([]byte, insolar.Arguments, error) {
+						require.Equal(t, []byte{1, 2, 3}, data)
+						if method == insolar.VersionMethod {
+							require.Equal(t, oldCodeRef, code)
+							return data, versionResult, nil
+						}
+						require.Equal(t, codeRef, code)
+						require.Equal(t, insolar.MigrateMethod, method)
+						// version is taken from current prototype, not from request
+						require.Equal(t, insolar.Arguments(migrationArgs), args)
+						return migrate()
+					}),
+				nil,
+			)
+	}
+
+	tests := []struct {
+		name  string
+		mocks func(ctx context.Context, t minimock.Tester) (LogicExecutor, *common.Transcript)
+		error bool
+		res   artifacts.RequestResult
+	}{
+		{
+			name: "success",
+			mocks: func(ctx context.Context, mc minimock.Tester) (LogicExecutor, *common.Transcript) {
+				tr := &common.Transcript{
+					ObjectDescriptor: objectDescriptor(mc, oldProtoRef).
+						StateIDMock.Return(&objRecordID),
+					Request: &record.IncomingRequest{
+						Method:    insolar.MigrateMethod,
+						Prototype: &newProtoRef,
+						Arguments: []byte{5},
+					},
+				}
+				mm := machinesManager(mc, func() ([]byte, insolar.Arguments, error) {
+					return []byte{3, 2, 1}, []byte{1}, nil
+				})
+				return &logicExecutor{MachinesManager: mm, DescriptorsCache: descriptorsCache(mc)}, tr
+			},
+			res: &requestresult.RequestResult{
+				SideEffectType:     artifacts.RequestSideEffectAmend,
+				RawResult:          []byte{1},
+				RawObjectReference: objRef,
+				ObjectImage:        newProtoRef,
+				ObjectStateID:      objRecordID,
+				Memory:             []byte{3, 2, 1},
+			},
+		},
+		{
+			name: "logic error in migration",
+			mocks: func(ctx context.Context, mc minimock.Tester) (LogicExecutor, *common.Transcript) {
+				tr := &common.Transcript{
+					ObjectDescriptor: objectDescriptor(mc, oldProtoRef),
+					Request: &record.IncomingRequest{
+						Method:    insolar.MigrateMethod,
+						Prototype: &newProtoRef,
+					},
+				}
+				mm := machinesManager(mc, func() ([]byte, insolar.Arguments, error) {
+					return nil, []byte{1}, nil
+				})
+				return &logicExecutor{MachinesManager: mm, DescriptorsCache: descriptorsCache(mc)}, tr
+			},
+			res: &requestresult.RequestResult{
+				SideEffectType:     artifacts.RequestSideEffectNone,
+				RawResult:          []byte{1},
+				RawObjectReference: objRef,
+			},
+		},
+		{
+			name: "requested by contract",
+			mocks: func(ctx context.Context, mc minimock.Tester) (LogicExecutor, *common.Transcript) {
+				tr := &common.Transcript{
+					ObjectDescriptor: artifacts.NewObjectDescriptorMock(mc).HeadRefMock.Return(&objRef),
+					Request: &record.IncomingRequest{
+						Method:    insolar.MigrateMethod,
+						Prototype: &newProtoRef,
+						Caller:    gen.Reference(),
+					},
+				}
+				mm := machinesmanager.NewMachinesManagerMock(mc)
+				dc := artifacts.NewDescriptorsCacheMock(mc)
+				return &logicExecutor{MachinesManager: mm, DescriptorsCache: dc}, tr
+			},
+			res: &requestresult.RequestResult{
+				RawResult: func() []byte {
+					errResBuf, err := foundation.MarshalMethodErrorResult(errors.New("migration can't be requested by contract"))
+					require.NoError(t, err)
+					return errResBuf
+				}(),
+				RawObjectReference: objRef,
+			},
+		},
+		{
+			name: "object already has prototype",
+			mocks: func(ctx context.Context, mc minimock.Tester) (LogicExecutor, *common.Transcript) {
+				tr := &common.Transcript{
+					ObjectDescriptor: artifacts.NewObjectDescriptorMock(mc).
+						HeadRefMock.Return(&objRef).
+						PrototypeMock.Return(&newProtoRef, nil),
+					Request: &record.IncomingRequest{
+						Method:    insolar.MigrateMethod,
+						Prototype: &newProtoRef,
+					},
+				}
+				mm := machinesmanager.NewMachinesManagerMock(mc)
+				dc := artifacts.NewDescriptorsCacheMock(mc)
+				return &logicExecutor{MachinesManager: mm, DescriptorsCache: dc}, tr
+			},
+			res: &requestresult.RequestResult{
+				RawResult: func() []byte {
+					errResBuf, err := foundation.MarshalMethodErrorResult(errors.New("object already has requested prototype"))
+					require.NoError(t, err)
+					return errResBuf
+				}(),
+				RawObjectReference: objRef,
+			},
+		},
+		{
+			name: "error, nil prototype",
+			mocks: func(ctx context.Context, mc minimock.Tester) (LogicExecutor, *common.Transcript) {
+				tr := &common.Transcript{
+					ObjectDescriptor: artifacts.NewObjectDescriptorMock(mc),
+					Request: &record.IncomingRequest{
+						Method: insolar.MigrateMethod,
+					},
+				}
+				mm := machinesmanager.NewMachinesManagerMock(mc)
+				dc := artifacts.NewDescriptorsCacheMock(mc)
+				return &logicExecutor{MachinesManager: mm, DescriptorsCache: dc}, tr
+			},
+			error: true,
+		},
+		{
+			name: "error, no version of current prototype",
+			mocks: func(ctx context.Context, mc minimock.Tester) (LogicExecutor, *common.Transcript) {
+				tr := &common.Transcript{
+					ObjectDescriptor: objectDescriptor(mc, oldProtoRef),
+					Request: &record.IncomingRequest{
+						Method:    insolar.MigrateMethod,
+						Prototype: &newProtoRef,
+					},
+				}
+				mm := machinesmanager.NewMachinesManagerMock(mc).
+					GetExecutorMock.
+					Return(
+						testutils.NewMachineLogicExecutorMock(mc).
+							CallMethodMock.Return(nil, nil, errors.New("no method")),
+						nil,
+					)
+				dc := artifacts.NewDescriptorsCacheMock(mc).
+					ByObjectDescriptorMock.
+					Return(
+						artifacts.NewObjectDescriptorMock(mc).
+							HeadRefMock.Return(&oldProtoRef),
+						artifacts.NewCodeDescriptorMock(mc).
+							RefMock.Return(&oldCodeRef).
+							MachineTypeMock.Return(insolar.MachineTypeGoPlugin),
+						nil,
+					)
+				return &logicExecutor{MachinesManager: mm, DescriptorsCache: dc}, tr
+			},
+			error: true,
+		},
+		{
+			name: "error, execution failed",
+			mocks: func(ctx context.Context, mc minimock.Tester) (LogicExecutor, *common.Transcript) {
+				tr := &common.Transcript{
+					ObjectDescriptor: objectDescriptor(mc, oldProtoRef),
+					Request: &record.IncomingRequest{
+						Method:    insolar.MigrateMethod,
+						Prototype: &newProtoRef,
+					},
+				}
+				mm := machinesManager(mc, func() ([]byte, insolar.Arguments, error) {
+					return nil, nil, errors.New("some")
+				})
+				return &logicExecutor{MachinesManager: mm, DescriptorsCache: descriptorsCache(mc)}, tr
+			},
+			error: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mc := minimock.NewController(t)
+			ctx := inslogger.TestContext(t)
+
+			le, tr := test.mocks(ctx, mc)
+			res, err := le.Execute(ctx, tr)
+			if test.error {
+				require.Error(t, err)
+				require.Nil(t, res)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+
+			mc.Wait(1 * time.Minute)
+			mc.Finish()
+		})
+	}
+}
+
 func TestLogicExecutor_ExecuteConstructor(t *testing.T) {
 	protoRef := gen.Reference()
 	callerRef := gen.Reference()
@@ -19,8 +19,10 @@ package logicrunner
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"sync"
+	"sync/atomic"
 
 	watermillMsg "github.com/ThreeDotsLabs/watermill/message"
 	"github.com/pkg/errors"
@@ -72,6 +74,9 @@ type LogicRunner struct {
 	Cfg *configuration.LogicRunner
 
 	rpc *lrCommon.RPC
+
+	// number of on pulse messages that are not sent to next executors yet
+	pendingHandoffs int64
 }
 
 // NewLogicRunner is constructor for LogicRunner
@@ -252,6 +257,9 @@ func (lr *LogicRunner) OnPulse(ctx context.Context, oldPulse insolar.Pulse, newP
 	}
 
 	if len(messages) > 0 {
+		for _, msg := range messages {
+			atomic.AddInt64(&lr.pendingHandoffs, int64(len(msg)))
+		}
 		go lr.sendOnPulseMessagesAsync(ctx, messages)
 	}
 
@@ -260,6 +268,26 @@ func (lr *LogicRunner) OnPulse(ctx context.Context, oldPulse insolar.Pulse, newP
 	return nil
 }
 
+// DrainProgress reports if there are executions in progress or state of executions
+// that is not handed over to next executors yet.
+func (lr *LogicRunner) DrainProgress(ctx context.Context) insolar.DrainProgress {
+	pending := int(atomic.LoadInt64(&lr.pendingHandoffs))
+	progress := insolar.DrainProgress{
+		Component: "LogicRunner",
+		Pending:   pending,
+	}
+
+	switch {
+	case !lr.StateStorage.IsEmpty():
+		progress.Details = "executions are in progress"
+	case pending > 0:
+		progress.Details = fmt.Sprintf("%d messages are being sent to next executors", pending)
+	default:
+		progress.Done = true
+	}
+	return progress
+}
+
 func (lr *LogicRunner) stopIfNeeded(ctx context.Context) {
 	lr.ShutdownFlag.Done(ctx, func() bool {
 		return lr.StateStorage.IsEmpty()
@@ -285,6 +313,7 @@ func (lr *LogicRunner) sendOnPulseMessagesAsync(ctx context.Context, messages ma
 
 func (lr *LogicRunner) sendOnPulseMessage(ctx context.Context, objectRef insolar.Reference, payloadObj payload.Payload, sendWg *sync.WaitGroup) {
 	defer sendWg.Done()
+	defer atomic.AddInt64(&lr.pendingHandoffs, -1)
 
 	msg, err := payload.NewMessage(payloadObj)
 	if err != nil {
@@ -532,6 +532,53 @@ func TestLogicRunner_OnPulse_Order(t *testing.T) {
 	}
 }
 
+func TestLogicRunner_DrainProgress(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	lr, err := NewLogicRunner(&configuration.LogicRunner{}, nil, nil)
+	require.NoError(t, err)
+
+	empty := false
+	lr.StateStorage = NewStateStorageMock(mc).
+		IsEmptyMock.Set(func() bool { return empty }).
+		OnPulseMock.Return(map[insolar.Reference][]payload.Payload{gen.Reference(): {&payload.ExecutorResults{}}})
+	lr.WriteController = writecontroller.NewWriteController()
+	require.NoError(t, lr.WriteController.Open(ctx, pulse.MinTimePulse))
+	lr.ShutdownFlag = shutdown.NewFlagMock(mc).DoneMock.Return()
+	lr.ResultsMatcher = newResultsMatcher(lr.Sender, lr.PulseAccessor)
+
+	progress := lr.DrainProgress(ctx)
+	require.False(t, progress.Done)
+	require.Equal(t, "executions are in progress", progress.Details)
+
+	release := make(chan struct{})
+	sent := make(chan struct{})
+	lr.Sender = bus.NewSenderMock(mc).SendRoleMock.Set(
+		func(ctx context.Context, msg *message2.Message, role insolar.DynamicRole, obj insolar.Reference) (<-chan *message2.Message, func()) {
+			<-release
+			return nil, func() { close(sent) }
+		})
+
+	empty = true
+	err = lr.OnPulse(ctx, insolar.Pulse{PulseNumber: pulse.MinTimePulse}, insolar.Pulse{PulseNumber: pulse.MinTimePulse + 1})
+	require.NoError(t, err)
+
+	progress = lr.DrainProgress(ctx)
+	require.False(t, progress.Done)
+	require.Equal(t, 1, progress.Pending)
+
+	close(release)
+	<-sent
+	for i := 0; i < 100 && !progress.Done; i++ {
+		time.Sleep(10 * time.Millisecond)
+		progress = lr.DrainProgress(ctx)
+	}
+	require.True(t, progress.Done)
+	require.Equal(t, 0, progress.Pending)
+}
+
 func (suite *LogicRunnerTestSuite) TestImmutableOrder() {
 	er := executionregistry.NewExecutionRegistryMock(suite.mc).
 		RegisterMock.Return(nil).
@@ -0,0 +1,188 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package preprocessor
+
+import (
+	"encoding/json"
+	"go/ast"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var apiAttrStart = "INSATTR_"
+var apiAttrEnd = "_API"
+
+// ABI is machine readable description of contract interface. Types are written as in Go source code of the contract.
+type ABI struct {
+	Contract     string         `json:"contract"`
+	Version      int            `json:"version"`
+	Constructors []ABIFunction  `json:"constructors"`
+	Methods      []ABIFunction  `json:"methods"`
+	Types        []ABIType      `json:"types,omitempty"`
+	Migrations   []ABIMigration `json:"migrations,omitempty"`
+}
+
+// ABIFunction describes a method or a constructor of contract.
+type ABIFunction struct {
+	Name      string        `json:"name"`
+	Arguments []ABIArgument `json:"arguments"`
+	Results   []string      `json:"results"`
+	Immutable bool          `json:"immutable,omitempty"`
+	// API is true if method can be called through API (marked with INSATTR_<Method>_API).
+	API  bool     `json:"api,omitempty"`
+	Saga *ABISaga `json:"saga,omitempty"`
+}
+
+// ABIArgument is a named argument of a function.
+type ABIArgument struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// ABISaga describes saga method, see SagaInfo.
+type ABISaga struct {
+	Rollback string `json:"rollback"`
+}
+
+// ABIType describes a type declared in the contract file, Fields are set for structures only.
+type ABIType struct {
+	Name   string        `json:"name"`
+	Type   string        `json:"type"`
+	Fields []ABIArgument `json:"fields,omitempty"`
+}
+
+// ABIMigration describes a function converting state of previous version, see MigrationInfo.
+type ABIMigration struct {
+	Version int    `json:"version"`
+	State   string `json:"state"`
+}
+
+// ABI returns description of the contract interface.
+func (pf *ParsedFile) ABI() *ABI {
+	api := pf.apiMethods()
+
+	abi := &ABI{
+		Contract:     pf.contract,
+		Version:      pf.version,
+		Constructors: pf.abiFunctions(pf.constructors[pf.contract], nil),
+		Methods:      pf.abiFunctions(pf.methods[pf.contract], api),
+	}
+
+	names := make([]string, 0, len(pf.types))
+	for name := range pf.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		spec := pf.types[name]
+		t := ABIType{Name: name, Type: pf.codeOfNode(spec.Type)}
+		if st, ok := spec.Type.(*ast.StructType); ok {
+			t.Type = "struct"
+			t.Fields = pf.abiFields(st.Fields)
+		}
+		abi.Types = append(abi.Types, t)
+	}
+
+	for _, m := range pf.migrations {
+		abi.Migrations = append(abi.Migrations, ABIMigration{Version: m.Version, State: m.StateType})
+	}
+
+	return abi
+}
+
+// WriteABI writes ABI of the contract into `out` as JSON
+func (pf *ParsedFile) WriteABI(out io.Writer) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	err := encoder.Encode(pf.ABI())
+	return errors.Wrap(err, "couldn't write ABI")
+}
+
+// MarshalABI returns compact JSON of the contract ABI
+func (pf *ParsedFile) MarshalABI() ([]byte, error) {
+	data, err := json.Marshal(pf.ABI())
+	return data, errors.Wrap(err, "couldn't marshal ABI")
+}
+
+func (pf *ParsedFile) abiFunctions(list []*ast.FuncDecl, api map[string]bool) []ABIFunction {
+	res := make([]ABIFunction, 0, len(list))
+	for _, fun := range list {
+		f := ABIFunction{
+			Name:      fun.Name.Name,
+			Arguments: pf.abiFields(fun.Type.Params),
+			Results:   make([]string, 0, fun.Type.Results.NumFields()),
+			Immutable: isImmutable(fun),
+			API:       api[fun.Name.Name],
+		}
+		for _, field := range pf.abiFields(fun.Type.Results) {
+			f.Results = append(f.Results, field.Type)
+		}
+		if info := sagaInfo(pf, fun); info.IsSaga {
+			f.Saga = &ABISaga{Rollback: info.RollbackMethodName}
+		}
+		res = append(res, f)
+	}
+	return res
+}
+
+// abiFields flattens list of fields, so `a, b int` becomes two fields and unnamed field has an empty name
+func (pf *ParsedFile) abiFields(list *ast.FieldList) []ABIArgument {
+	res := make([]ABIArgument, 0, list.NumFields())
+	if list == nil {
+		return res
+	}
+	for _, field := range list.List {
+		typ := pf.codeOfNode(field.Type)
+		if len(field.Names) == 0 {
+			res = append(res, ABIArgument{Type: typ})
+			continue
+		}
+		for _, name := range field.Names {
+			res = append(res, ABIArgument{Name: name.Name, Type: typ})
+		}
+	}
+	return res
+}
+
+// apiMethods finds methods marked with `var INSATTR_<Method>_API = true`
+func (pf *ParsedFile) apiMethods() map[string]bool {
+	res := make(map[string]bool)
+	for _, decl := range pf.node.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok || len(vs.Names) != len(vs.Values) {
+				continue
+			}
+			for i, name := range vs.Names {
+				if !strings.HasPrefix(name.Name, apiAttrStart) || !strings.HasSuffix(name.Name, apiAttrEnd) {
+					continue
+				}
+				if value, ok := vs.Values[i].(*ast.Ident); ok && value.Name == "true" {
+					res[strings.TrimSuffix(strings.TrimPrefix(name.Name, apiAttrStart), apiAttrEnd)] = true
+				}
+			}
+		}
+	}
+	return res
+}
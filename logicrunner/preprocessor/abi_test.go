@@ -0,0 +1,132 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// +build slowtest
+
+package preprocessor
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/logicrunner/goplugin/goplugintestutils"
+)
+
+func TestABI(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "test-")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	err = goplugintestutils.WriteFile(tmpDir, "/test.go", `
+package main
+
+import (
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/logicrunner/builtin/foundation"
+)
+
+// ins:version(2)
+type Wallet struct {
+	foundation.BaseContract
+	Balance int
+}
+
+type WalletV1 struct {
+	Balance int
+}
+
+type Transfer struct {
+	To     insolar.Reference
+	Amount int `+"`json:\"amount\"`"+`
+}
+
+// ins:migrate(1)
+func MigrateFromV1(old *WalletV1) (*Wallet, error) {
+	return &Wallet{Balance: old.Balance}, nil
+}
+
+func New(balance int) (*Wallet, error) {
+	return &Wallet{Balance: balance}, nil
+}
+
+var INSATTR_GetBalance_API = true
+
+// ins:immutable
+func (w *Wallet) GetBalance() (int, error) {
+	return w.Balance, nil
+}
+
+//ins:saga(Rollback)
+func (w *Wallet) Accept(t Transfer) error {
+	return nil
+}
+
+func (w *Wallet) Rollback(t Transfer) error {
+	return nil
+}
+
+func (w *Wallet) Split(a, b int, _ string) (int, int, error) {
+	return a, b, nil
+}
+`)
+	require.NoError(t, err)
+
+	parsed, err := ParseFile(tmpDir+"/test.go", insolar.MachineTypeBuiltin)
+	require.NoError(t, err)
+
+	transfer := []ABIArgument{{Name: "t", Type: "Transfer"}}
+	require.Equal(t, &ABI{
+		Contract: "Wallet",
+		Version:  2,
+		Constructors: []ABIFunction{
+			{Name: "New", Arguments: []ABIArgument{{Name: "balance", Type: "int"}}, Results: []string{"*Wallet", "error"}},
+		},
+		Methods: []ABIFunction{
+			{Name: "GetBalance", Arguments: []ABIArgument{}, Results: []string{"int", "error"}, Immutable: true, API: true},
+			{Name: "Accept", Arguments: transfer, Results: []string{"error"}, Saga: &ABISaga{Rollback: "Rollback"}},
+			{Name: "Rollback", Arguments: transfer, Results: []string{"error"}},
+			{
+				Name:      "Split",
+				Arguments: []ABIArgument{{Name: "a", Type: "int"}, {Name: "b", Type: "int"}, {Name: "_", Type: "string"}},
+				Results:   []string{"int", "int", "error"},
+			},
+		},
+		Types: []ABIType{
+			{Name: "Transfer", Type: "struct", Fields: []ABIArgument{
+				{Name: "To", Type: "insolar.Reference"},
+				{Name: "Amount", Type: "int"},
+			}},
+			{Name: "WalletV1", Type: "struct", Fields: []ABIArgument{{Name: "Balance", Type: "int"}}},
+		},
+		Migrations: []ABIMigration{{Version: 1, State: "WalletV1"}},
+	}, parsed.ABI())
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, parsed.WriteABI(buf))
+	compact, err := parsed.MarshalABI()
+	require.NoError(t, err)
+	require.JSONEq(t, buf.String(), string(compact))
+
+	var decoded ABI
+	require.NoError(t, json.Unmarshal(compact, &decoded))
+	require.Equal(t, parsed.ABI(), &decoded)
+}
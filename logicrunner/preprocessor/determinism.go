@@ -0,0 +1,323 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package preprocessor
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/insolar/insolar/insolar"
+)
+
+// Severity of determinism issue.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// DeterminismIssue is a construct in contract source that can make result of execution
+// differ between executor and validators.
+type DeterminismIssue struct {
+	Pos      token.Position
+	Severity string
+	Rule     string
+	Message  string
+}
+
+func (i DeterminismIssue) String() string {
+	return fmt.Sprintf("%s: %s: %s [%s]", i.Pos, i.Severity, i.Message, i.Rule)
+}
+
+// DeterminismAllowlist lists packages and qualified names ("<import path>.<Name>")
+// that are known to be deterministic. Uses of them are never reported.
+var DeterminismAllowlist = []string{
+	foundationPath + ".StableMap",
+	foundationPath + "/safemath",
+}
+
+// deniedImports are packages that give access to environment of executor.
+// Entry ending with "/" denies all subpackages as well.
+var deniedImports = []string{
+	"os", "os/",
+	"net", "net/",
+	"syscall",
+	"unsafe",
+	"runtime", "runtime/",
+	"plugin",
+	"math/rand",
+	"crypto/rand",
+	"io/ioutil",
+	"sync", "sync/",
+}
+
+// deniedCalls are functions that depend on wall clock or start timers.
+var deniedCalls = map[string]string{
+	"time.Now":       "reads wall clock",
+	"time.Since":     "reads wall clock",
+	"time.Until":     "reads wall clock",
+	"time.After":     "starts a timer",
+	"time.AfterFunc": "starts a timer",
+	"time.Tick":      "starts a timer",
+	"time.NewTicker": "starts a timer",
+	"time.NewTimer":  "starts a timer",
+}
+
+type determinismChecker struct {
+	fileSet   *token.FileSet
+	node      *ast.File
+	info      *types.Info
+	imports   map[string]string // local name -> import path
+	allowlist map[string]bool
+	issues    []DeterminismIssue
+}
+
+// CheckDeterminism analyzes parsed contract and returns found issues sorted by position.
+// Types declared in other packages are opaque for the analysis, so ranging over
+// a map is only detected for maps that are declared in the contract itself.
+func CheckDeterminism(fileSet *token.FileSet, node *ast.File, contractSpec *ast.TypeSpec, allowlist []string) []DeterminismIssue {
+	c := &determinismChecker{
+		fileSet:   fileSet,
+		node:      node,
+		imports:   make(map[string]string),
+		allowlist: make(map[string]bool),
+	}
+	for _, a := range allowlist {
+		c.allowlist[a] = true
+	}
+
+	c.typeCheck()
+	c.checkImports()
+	ast.Inspect(node, c.inspect)
+	if contractSpec != nil {
+		c.checkState(contractSpec)
+	}
+
+	sort.SliceStable(c.issues, func(i, j int) bool {
+		a, b := c.issues[i].Pos, c.issues[j].Pos
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return c.issues
+}
+
+// typeCheck collects type information. Imported packages are replaced with empty ones
+// and type errors are ignored, contract is compiled for real later.
+func (c *determinismChecker) typeCheck() {
+	c.info = &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	conf := types.Config{
+		Importer: stubImporter{},
+		Error:    func(error) {},
+	}
+	_, _ = conf.Check(c.node.Name.Name, c.fileSet, []*ast.File{c.node}, c.info)
+}
+
+type stubImporter struct{}
+
+func (stubImporter) Import(importPath string) (*types.Package, error) {
+	pkg := types.NewPackage(importPath, path.Base(importPath))
+	pkg.MarkComplete()
+	return pkg, nil
+}
+
+func (c *determinismChecker) report(n ast.Node, severity string, rule string, format string, args ...interface{}) {
+	c.issues = append(c.issues, DeterminismIssue{
+		Pos:      c.fileSet.Position(n.Pos()),
+		Severity: severity,
+		Rule:     rule,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (c *determinismChecker) allowed(importPath string, name string) bool {
+	return c.allowlist[importPath] || (name != "" && c.allowlist[importPath+"."+name])
+}
+
+func (c *determinismChecker) checkImports() {
+	for _, imp := range c.node.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		name := path.Base(importPath)
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		c.imports[name] = importPath
+
+		if c.allowed(importPath, "") {
+			continue
+		}
+		for _, denied := range deniedImports {
+			if importPath == denied || (strings.HasSuffix(denied, "/") && strings.HasPrefix(importPath, denied)) {
+				c.report(imp, SeverityError, "import", "import of %q is not allowed in contracts", importPath)
+				break
+			}
+		}
+	}
+}
+
+// qualifiedName returns import path and name of expression like `pkg.Name`.
+func (c *determinismChecker) qualifiedName(expr ast.Expr) (string, string, bool) {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return "", "", false
+	}
+	x, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return "", "", false
+	}
+	if _, isPkg := c.info.Uses[x].(*types.PkgName); !isPkg {
+		return "", "", false
+	}
+	importPath, ok := c.imports[x.Name]
+	return importPath, sel.Sel.Name, ok
+}
+
+func (c *determinismChecker) inspect(n ast.Node) bool {
+	switch n := n.(type) {
+	case *ast.GoStmt:
+		c.report(n, SeverityError, "goroutine", "goroutines are not allowed in contracts")
+	case *ast.SelectStmt:
+		c.report(n, SeverityError, "channel", "select statement is not allowed in contracts")
+	case *ast.SendStmt:
+		c.report(n, SeverityError, "channel", "channel send is not allowed in contracts")
+	case *ast.UnaryExpr:
+		if n.Op == token.ARROW {
+			c.report(n, SeverityError, "channel", "channel receive is not allowed in contracts")
+		}
+	case *ast.ChanType:
+		c.report(n, SeverityError, "channel", "channels are not allowed in contracts")
+	case *ast.CallExpr:
+		c.checkCall(n)
+	case *ast.RangeStmt:
+		c.checkRange(n)
+	case *ast.BasicLit:
+		if n.Kind == token.FLOAT || n.Kind == token.IMAG {
+			c.report(n, SeverityWarning, "float", "floating point literal %s, result may differ between platforms", n.Value)
+		}
+	case *ast.Ident:
+		c.checkFloatType(n)
+	}
+	return true
+}
+
+func (c *determinismChecker) checkCall(call *ast.CallExpr) {
+	importPath, name, ok := c.qualifiedName(call.Fun)
+	if !ok || c.allowed(importPath, name) {
+		return
+	}
+	if reason, denied := deniedCalls[importPath+"."+name]; denied {
+		c.report(call, SeverityError, "time", "call of %s.%s is not allowed in contracts, it %s", importPath, name, reason)
+	}
+}
+
+func (c *determinismChecker) checkRange(rng *ast.RangeStmt) {
+	tv, ok := c.info.Types[rng.X]
+	if !ok || tv.Type == nil {
+		return
+	}
+	if named, ok := tv.Type.(*types.Named); ok {
+		obj := named.Obj()
+		if obj.Pkg() != nil && c.allowed(obj.Pkg().Path(), obj.Name()) {
+			return
+		}
+	}
+	if _, isMap := tv.Type.Underlying().(*types.Map); isMap {
+		c.report(rng, SeverityError, "map-range", "iteration order over map %s is random, sort keys first", types.ExprString(rng.X))
+	}
+}
+
+func (c *determinismChecker) checkFloatType(ident *ast.Ident) {
+	obj, ok := c.info.Uses[ident].(*types.TypeName)
+	if !ok || obj.Parent() != types.Universe {
+		return
+	}
+	basic, ok := obj.Type().(*types.Basic)
+	if ok && basic.Info()&(types.IsFloat|types.IsComplex) != 0 {
+		c.report(ident, SeverityWarning, "float", "floating point type %s, result may differ between platforms", ident.Name)
+	}
+}
+
+// checkState reports state fields of maps, because they are serialized in random order.
+func (c *determinismChecker) checkState(contractSpec *ast.TypeSpec) {
+	st, ok := contractSpec.Type.(*ast.StructType)
+	if !ok {
+		return
+	}
+	for _, field := range st.Fields.List {
+		if _, isMap := field.Type.(*ast.MapType); !isMap {
+			continue
+		}
+		for _, name := range field.Names {
+			c.report(field, SeverityError, "map-state",
+				"state field %s is a map, it is serialized in random order, use foundation.StableMap", name.Name)
+		}
+	}
+}
+
+// DeterminismIssues returns issues found in the contract by determinism analysis.
+func (pf *ParsedFile) DeterminismIssues() []DeterminismIssue {
+	return pf.determinismIssues
+}
+
+// DeterminismError is returned by ParseFile when contract executed by goplugin
+// is not deterministic. Issues include warnings as well.
+type DeterminismError struct {
+	Issues []DeterminismIssue
+}
+
+func (e *DeterminismError) Error() string {
+	msgs := []string{"contract is not deterministic:"}
+	for _, issue := range e.Issues {
+		if issue.Severity == SeverityError {
+			msgs = append(msgs, issue.String())
+		}
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// HasDeterminismErrors checks if there is at least one issue with error severity.
+func HasDeterminismErrors(issues []DeterminismIssue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// checkDeterminism runs determinism analysis. Contracts executed by goplugin are rejected
+// if there is at least one error, builtin contracts are trusted.
+func (pf *ParsedFile) checkDeterminism() error {
+	pf.determinismIssues = CheckDeterminism(pf.fileSet, pf.node, pf.contractSpec, DeterminismAllowlist)
+	if pf.machineType == insolar.MachineTypeGoPlugin && HasDeterminismErrors(pf.determinismIssues) {
+		return &DeterminismError{Issues: pf.determinismIssues}
+	}
+	return nil
+}
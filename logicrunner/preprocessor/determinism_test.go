@@ -0,0 +1,212 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// +build slowtest
+
+package preprocessor
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/logicrunner/goplugin/goplugintestutils"
+)
+
+type DeterminismSuite struct {
+	suite.Suite
+}
+
+const determinismTestHeader = `
+package main
+
+import (
+	"github.com/insolar/insolar/logicrunner/builtin/foundation"
+	"github.com/insolar/insolar/logicrunner/builtin/foundation/safemath"
+	clock "time"
+	"math/rand"
+)
+
+type One struct {
+	foundation.BaseContract
+	Counters foundation.StableMap
+}
+`
+
+func (s *DeterminismSuite) parse(code string, machineType insolar.MachineType) (*ParsedFile, error) {
+	tmpDir, err := ioutil.TempDir("", "test-")
+	s.Require().NoError(err)
+	defer os.RemoveAll(tmpDir)
+
+	err = goplugintestutils.WriteFile(tmpDir, "/test.go", code)
+	s.Require().NoError(err)
+
+	return ParseFile(tmpDir+"/test.go", machineType)
+}
+
+type issueKey struct {
+	Line     int
+	Severity string
+	Rule     string
+}
+
+func (s *DeterminismSuite) issues(code string) []issueKey {
+	parsed, err := s.parse(code, insolar.MachineTypeBuiltin)
+	s.Require().NoError(err)
+
+	var res []issueKey
+	for _, issue := range parsed.DeterminismIssues() {
+		s.Contains(issue.Pos.Filename, "test.go")
+		res = append(res, issueKey{Line: issue.Pos.Line, Severity: issue.Severity, Rule: issue.Rule})
+	}
+	return res
+}
+
+func (s *DeterminismSuite) TestRules() {
+	// header takes lines 1-14, function of body starts at line 15
+	table := []struct {
+		name   string
+		body   string
+		issues []issueKey
+	}{
+		{
+			name: "allowed constructs",
+			body: `
+func (c *One) Get(a uint64, b uint64) (uint64, error) {
+	for k := range c.Counters {
+		_ = k
+	}
+	clock.Sleep(clock.Millisecond)
+	return safemath.Add(a, b)
+}`,
+		},
+		{
+			name: "wall clock through alias",
+			body: `
+func (c *One) Get() (int64, error) {
+	return clock.Now().Unix(), nil
+}`,
+			issues: []issueKey{{17, SeverityError, "time"}},
+		},
+		{
+			name: "goroutine and channels",
+			body: `
+func (c *One) Get() (int, error) {
+	ch := make(chan int)
+	go func() { ch <- 1 }()
+	return <-ch, nil
+}`,
+			issues: []issueKey{
+				{17, SeverityError, "channel"},
+				{18, SeverityError, "goroutine"},
+				{18, SeverityError, "channel"},
+				{19, SeverityError, "channel"},
+			},
+		},
+		{
+			name: "range over map",
+			body: `
+func (c *One) Get(m map[string]int) (int, error) {
+	sum := 0
+	for _, v := range m {
+		sum += v
+	}
+	for i := range []int{1, 2} {
+		sum += i
+	}
+	return sum, nil
+}`,
+			issues: []issueKey{{18, SeverityError, "map-range"}},
+		},
+		{
+			name: "floating point",
+			body: `
+func (c *One) Get() (float64, error) {
+	return 1.5, nil
+}`,
+			issues: []issueKey{
+				{16, SeverityWarning, "float"},
+				{17, SeverityWarning, "float"},
+			},
+		},
+	}
+
+	for _, test := range table {
+		s.Run(test.name, func() {
+			code := determinismTestHeader + test.body + "\nvar _ = rand.Int\n"
+			expected := append([]issueKey{{8, SeverityError, "import"}}, test.issues...)
+			s.Equal(expected, s.issues(code))
+		})
+	}
+}
+
+func (s *DeterminismSuite) TestMapInState() {
+	code := `
+package main
+
+import "github.com/insolar/insolar/logicrunner/builtin/foundation"
+
+type One struct {
+	foundation.BaseContract
+	Balances map[string]int
+}
+`
+	s.Equal([]issueKey{{8, SeverityError, "map-state"}}, s.issues(code))
+}
+
+func (s *DeterminismSuite) TestGoPluginIsRejected() {
+	code := determinismTestHeader + `
+func (c *One) Get() (float64, error) {
+	return float64(clock.Now().Unix()), nil
+}
+
+var _ = rand.Int
+`
+	_, err := s.parse(code, insolar.MachineTypeGoPlugin)
+	s.Require().Error(err)
+
+	detErr, ok := errors.Cause(err).(*DeterminismError)
+	s.Require().True(ok)
+	s.Len(detErr.Issues, 4)
+	s.Contains(err.Error(), `test.go:8:2: error: import of "math/rand" is not allowed in contracts [import]`)
+	s.Contains(err.Error(), "test.go:17:17: error: call of time.Now is not allowed in contracts, it reads wall clock [time]")
+	s.NotContains(err.Error(), "warning")
+}
+
+func (s *DeterminismSuite) TestAllowlist() {
+	code := determinismTestHeader + `
+func (c *One) Get() (int64, error) {
+	return clock.Now().Unix(), nil
+}
+
+var _ = rand.Int
+`
+	defer func(orig []string) { DeterminismAllowlist = orig }(DeterminismAllowlist)
+	DeterminismAllowlist = append(DeterminismAllowlist, "math/rand", "time.Now")
+
+	parsed, err := s.parse(code, insolar.MachineTypeGoPlugin)
+	s.Require().NoError(err)
+	s.Empty(parsed.DeterminismIssues())
+}
+
+func TestDeterminism(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(DeterminismSuite))
+}
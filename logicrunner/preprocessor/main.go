@@ -52,6 +52,9 @@ var immutableFlag = "ins:immutable"
 var sagaFlagStart = "ins:saga("
 var sagaFlagEnd = ")"
 var sagaFlagStartLength = len(sagaFlagStart)
+var versionFlagStart = "ins:version("
+var migrateFlagStart = "ins:migrate("
+var intFlagEnd = ")"
 
 const (
 	TemplateDirectory = "templates"
@@ -73,6 +76,16 @@ type SagaInfo struct {
 	NumArguments int
 }
 
+// MigrationInfo stores information about function that converts state written by
+// previous version of a contract. Function marked with //ins:migrate(1) should
+// accept pointer to a structure describing state of version 1 and return
+// the contract and an error.
+type MigrationInfo struct {
+	Version   int
+	Name      string
+	StateType string
+}
+
 // ParsedFile struct with prepared info we extract from source code
 type ParsedFile struct {
 	name        string
@@ -84,7 +97,12 @@ type ParsedFile struct {
 	types        map[string]*ast.TypeSpec
 	methods      map[string][]*ast.FuncDecl
 	constructors map[string][]*ast.FuncDecl
+	migrations   []*MigrationInfo
 	contract     string
+	contractSpec *ast.TypeSpec
+	version      int
+
+	determinismIssues []DeterminismIssue
 }
 
 // ParseFile parses a file as Go source code of a smart contract
@@ -120,6 +138,11 @@ func ParseFile(fileName string, machineType insolar.MachineType) (*ParsedFile, e
 		return nil, errors.New("Only one smart contract must exist")
 	}
 
+	err = res.checkDeterminism()
+	if err != nil {
+		return nil, err
+	}
+
 	return res, nil
 }
 
@@ -133,8 +156,12 @@ func (pf *ParsedFile) parseTypes() error {
 
 		for _, e := range tDecl.Specs {
 			typeNode := e.(*ast.TypeSpec)
+			doc := typeNode.Doc
+			if doc == nil {
+				doc = tDecl.Doc
+			}
 
-			err := pf.parseTypeSpec(typeNode)
+			err := pf.parseTypeSpec(typeNode, doc)
 			if err != nil {
 				return err
 			}
@@ -144,12 +171,24 @@ func (pf *ParsedFile) parseTypes() error {
 	return nil
 }
 
-func (pf *ParsedFile) parseTypeSpec(typeSpec *ast.TypeSpec) error {
+func (pf *ParsedFile) parseTypeSpec(typeSpec *ast.TypeSpec, doc *ast.CommentGroup) error {
 	if isContractTypeSpec(typeSpec) {
 		if pf.contract != "" {
 			return errors.New("more than one contract in a file")
 		}
 		pf.contract = typeSpec.Name.Name
+		pf.contractSpec = typeSpec
+
+		version, ok, err := intFlag(doc, versionFlagStart)
+		if err != nil {
+			return errors.Wrapf(err, "invalid version of contract %q", pf.contract)
+		}
+		if ok {
+			if version <= 0 {
+				return errors.Errorf("version of contract %q should be positive", pf.contract)
+			}
+			pf.version = version
+		}
 	} else {
 		pf.types[typeSpec.Name.Name] = typeSpec
 	}
@@ -168,7 +207,17 @@ func (pf *ParsedFile) parseFunctionsAndMethods() error {
 
 		var err error
 		if fd.Recv == nil || fd.Recv.NumFields() == 0 {
-			err = pf.parseConstructor(fd)
+			var version int
+			var isMigration bool
+			version, isMigration, err = intFlag(fd.Doc, migrateFlagStart)
+			if err != nil {
+				return errors.Wrapf(err, "invalid migration %q", fd.Name.Name)
+			}
+			if isMigration {
+				err = pf.parseMigration(fd, version)
+			} else {
+				err = pf.parseConstructor(fd)
+			}
 		} else {
 			err = pf.parseMethod(fd)
 		}
@@ -202,8 +251,51 @@ func (pf *ParsedFile) parseConstructor(fd *ast.FuncDecl) error {
 	return nil
 }
 
+func (pf *ParsedFile) parseMigration(fd *ast.FuncDecl, version int) error {
+	name := fd.Name.Name
+
+	params := fd.Type.Params
+	if params.NumFields() != 1 {
+		return errors.Errorf("Migration %q should accept exactly one argument", name)
+	}
+	star, ok := params.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return errors.Errorf("Migration %q should accept pointer to a structure of previous state", name)
+	}
+	stateType := pf.typeName(star.X)
+	if spec, ok := pf.types[stateType]; !ok || !isStructTypeSpec(spec) {
+		return errors.Errorf("Migration %q should accept structure declared in the same file, but accepts %q", name, stateType)
+	}
+
+	res := fd.Type.Results
+	if res.NumFields() != 2 ||
+		pf.codeOfNode(res.List[0].Type) != "*"+pf.contract ||
+		pf.typeName(res.List[1].Type) != errorType {
+		return errors.Errorf("Migration %q should return '*%s' and 'error'", name, pf.contract)
+	}
+
+	for _, m := range pf.migrations {
+		if m.Version == version {
+			return errors.Errorf("Migrations %q and %q convert the same version %d", m.Name, name, version)
+		}
+	}
+	pf.migrations = append(pf.migrations, &MigrationInfo{
+		Version:   version,
+		Name:      name,
+		StateType: stateType,
+	})
+	sort.Slice(pf.migrations, func(i, j int) bool {
+		return pf.migrations[i].Version < pf.migrations[j].Version
+	})
+
+	return nil
+}
+
 func (pf *ParsedFile) parseMethod(fd *ast.FuncDecl) error {
 	name := fd.Name.Name
+	if name == insolar.MigrateMethod || name == insolar.VersionMethod {
+		return errors.Errorf("Method name %q is reserved", name)
+	}
 
 	res := fd.Type.Results
 	if res.NumFields() < 1 {
@@ -249,6 +341,16 @@ func (pf *ParsedFile) ContractName() string {
 	return pf.node.Name.Name
 }
 
+// Version returns version of the contract set by //ins:version(N), zero if not set
+func (pf *ParsedFile) Version() int {
+	return pf.version
+}
+
+// Migrations returns functions converting state of previous versions ordered by version
+func (pf *ParsedFile) Migrations() []*MigrationInfo {
+	return pf.migrations
+}
+
 func checkMachineType(machineType insolar.MachineType) error {
 	if machineType != insolar.MachineTypeGoPlugin &&
 		machineType != insolar.MachineTypeBuiltin {
@@ -319,6 +421,11 @@ func (pf *ParsedFile) WriteWrapper(out io.Writer, packageName string) error {
 		return err
 	}
 
+	err = pf.checkMigrationVersions()
+	if err != nil {
+		return err
+	}
+
 	data := map[string]interface{}{
 		"Package":            packageName,
 		"ContractType":       pf.contract,
@@ -328,11 +435,94 @@ func (pf *ParsedFile) WriteWrapper(out io.Writer, packageName string) error {
 		"FoundationPath":     foundationPath,
 		"Imports":            pf.generateImports(true),
 		"GenerateInitialize": pf.machineType == insolar.MachineTypeBuiltin,
+		"Migrations":         pf.migrations,
+		"MigrateMethod":      insolar.MigrateMethod,
+		"VersionMethod":      insolar.VersionMethod,
+		"Version":            pf.version,
 	}
 
 	return formatAndWrite(out, "wrapper", data)
 }
 
+func (pf *ParsedFile) checkMigrationVersions() error {
+	for _, m := range pf.migrations {
+		if m.Version >= pf.version {
+			return fmt.Errorf(
+				"semantic error: '%s' converts state of version %d, but version of '%s' is %d (hint: use //ins:version)",
+				m.Name, m.Version, pf.contract, pf.version)
+		}
+	}
+	return nil
+}
+
+// CheckMigration checks that next version of a contract converts state written by prev version:
+// it should have greater version and a migration accepting structure with the same fields as
+// prev contract has.
+func CheckMigration(prev *ParsedFile, next *ParsedFile) error {
+	if next.version <= prev.version {
+		return errors.Errorf(
+			"version of new contract %d should be greater than version of previous one %d", next.version, prev.version)
+	}
+
+	var migration *MigrationInfo
+	for _, m := range next.migrations {
+		if m.Version == prev.version {
+			migration = m
+			break
+		}
+	}
+	if migration == nil {
+		return errors.Errorf("there is no migration from version %d (hint: use //ins:migrate(%d))", prev.version, prev.version)
+	}
+
+	prevFields := prev.stateFields(prev.contractSpec)
+	stateFields := next.stateFields(next.types[migration.StateType])
+	for name, prevType := range prevFields {
+		stateType, ok := stateFields[name]
+		if !ok {
+			return errors.Errorf("field %q of %q is missing in %q", name, prev.contract, migration.StateType)
+		}
+		if stateType != prevType {
+			return errors.Errorf("field %q of %q has type %q, but it's %q in %q",
+				name, prev.contract, prevType, stateType, migration.StateType)
+		}
+	}
+	for name := range stateFields {
+		if _, ok := prevFields[name]; !ok {
+			return errors.Errorf("field %q of %q is missing in %q", name, migration.StateType, prev.contract)
+		}
+	}
+
+	return nil
+}
+
+// stateFields returns serialized fields of a structure, field tag is a part of type because it affects serialization
+func (pf *ParsedFile) stateFields(typeSpec *ast.TypeSpec) map[string]string {
+	res := make(map[string]string)
+	st := typeSpec.Type.(*ast.StructType)
+	for _, fd := range st.Fields.List {
+		typ := pf.codeOfNode(fd.Type)
+		if fd.Tag != nil {
+			typ += " " + fd.Tag.Value
+		}
+
+		if len(fd.Names) == 0 {
+			if typ == "foundation.BaseContract" {
+				continue // BaseContract has no state
+			}
+			name := pf.typeName(fd.Type)
+			res[name[strings.LastIndex(name, ".")+1:]] = typ
+			continue
+		}
+		for _, name := range fd.Names {
+			if name.IsExported() {
+				res[name.Name] = typ
+			}
+		}
+	}
+	return res
+}
+
 func (pf *ParsedFile) checkSagaIsNotImmutable(methodsInfo []map[string]interface{}) error {
 	for _, mi := range methodsInfo {
 		sagaInfo := mi["SagaInfo"].(*SagaInfo)
@@ -693,6 +883,11 @@ func isContractTypeSpec(typeNode *ast.TypeSpec) bool {
 	return false
 }
 
+func isStructTypeSpec(typeNode *ast.TypeSpec) bool {
+	_, ok := typeNode.Type.(*ast.StructType)
+	return ok
+}
+
 func generateTypes(parsed *ParsedFile) []string {
 	types := make([]string, 0, len(parsed.types))
 	for _, t := range parsed.types {
@@ -903,6 +1098,31 @@ func extractSagaInfoFromComment(comment string, info *SagaInfo) bool {
 	return false
 }
 
+// intFlag looks for '//flag(N)' comment and returns N
+func intFlag(doc *ast.CommentGroup, flagStart string) (int, bool, error) {
+	if doc == nil {
+		return 0, false, nil
+	}
+
+	for _, comment := range doc.List {
+		slice, err := skipCommentBeginning(comment.Text)
+		if err != nil {
+			continue
+		}
+		slice = strings.TrimSpace(slice)
+		if !strings.HasPrefix(slice, flagStart) || !strings.HasSuffix(slice, intFlagEnd) {
+			continue
+		}
+
+		value, err := strconv.Atoi(slice[len(flagStart) : len(slice)-len(intFlagEnd)])
+		if err != nil {
+			return 0, true, errors.Wrapf(err, "can't parse %q", slice)
+		}
+		return value, true, nil
+	}
+	return 0, false, nil
+}
+
 func sagaInfo(pf *ParsedFile, decl *ast.FuncDecl) (info *SagaInfo) {
 	info = &SagaInfo{
 		Arguments:    genFieldList(pf, decl.Type.Params, true),
@@ -936,10 +1156,15 @@ const (
 
 type ContractList []ContractListEntry
 
-func generateContractList(contracts ContractList) interface{} {
+func generateContractList(contracts ContractList) (interface{}, error) {
 	importList := make([]interface{}, 0)
 	for _, contract := range contracts {
+		abi, err := contract.Parsed.MarshalABI()
+		if err != nil {
+			return nil, errors.Wrapf(err, "couldn't generate ABI of %s", contract.Name)
+		}
 		data := map[string]interface{}{
+			"ABI":                string(abi),
 			"Name":               contract.Name,
 			"ImportName":         contract.Name,
 			"ImportPath":         contract.ImportPath,
@@ -948,12 +1173,16 @@ func generateContractList(contracts ContractList) interface{} {
 		}
 		importList = append(importList, data)
 	}
-	return importList
+	return importList, nil
 }
 
 func GenerateInitializationList(out io.Writer, contracts ContractList) error {
+	contractList, err := generateContractList(contracts)
+	if err != nil {
+		return err
+	}
 	data := map[string]interface{}{
-		"Contracts": generateContractList(contracts),
+		"Contracts": contractList,
 		"Package":   "builtin",
 	}
 
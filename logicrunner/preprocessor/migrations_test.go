@@ -0,0 +1,336 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// +build slowtest
+
+package preprocessor
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/logicrunner/goplugin/goplugintestutils"
+)
+
+type MigrationsSuite struct {
+	suite.Suite
+}
+
+var migrationTestContractV1 = `
+package main
+
+import "github.com/insolar/insolar/logicrunner/builtin/foundation"
+
+// ins:version(1)
+type Wallet struct {
+	foundation.BaseContract
+	Balance int
+}
+
+func (w *Wallet) GetBalance() (int, error) {
+	return w.Balance, nil
+}
+`
+
+var migrationTestContractV2 = `
+package main
+
+import "github.com/insolar/insolar/logicrunner/builtin/foundation"
+
+// ins:version(2)
+type Wallet struct {
+	foundation.BaseContract
+	Balance  string
+	Currency string
+}
+
+type WalletV1 struct {
+	Balance int
+}
+
+//ins:migrate(1)
+func MigrateFromV1(old *WalletV1) (*Wallet, error) {
+	return &Wallet{Balance: strconv.Itoa(old.Balance), Currency: "XNS"}, nil
+}
+
+func (w *Wallet) GetBalance() (string, error) {
+	return w.Balance, nil
+}
+`
+
+func (s *MigrationsSuite) parse(code string, machineType insolar.MachineType) (*ParsedFile, error) {
+	tmpDir, err := ioutil.TempDir("", "test-")
+	s.Require().NoError(err)
+	defer os.RemoveAll(tmpDir)
+
+	err = goplugintestutils.WriteFile(tmpDir, "/test.go", code)
+	s.Require().NoError(err)
+
+	return ParseFile(tmpDir+"/test.go", machineType)
+}
+
+func (s *MigrationsSuite) TestVersionAndMigrationsAreParsed() {
+	parsed, err := s.parse(migrationTestContractV2, insolar.MachineTypeGoPlugin)
+	s.Require().NoError(err)
+
+	s.Equal(2, parsed.Version())
+	s.Equal([]*MigrationInfo{{Version: 1, Name: "MigrateFromV1", StateType: "WalletV1"}}, parsed.Migrations())
+	s.Len(parsed.constructors[parsed.contract], 0)
+}
+
+func (s *MigrationsSuite) TestMigrationIsPresentInWrapper() {
+	parsed, err := s.parse(migrationTestContractV2, insolar.MachineTypeBuiltin)
+	s.Require().NoError(err)
+
+	var bufWrapper bytes.Buffer
+	err = parsed.WriteWrapper(&bufWrapper, parsed.ContractName())
+	s.Require().NoError(err)
+	wrapperCode := bufWrapper.String()
+
+	s.Contains(wrapperCode, "func INSMIGRATE_1(object []byte) ([]byte, error) {")
+	s.Contains(wrapperCode, "old := new(WalletV1)")
+	s.Contains(wrapperCode, "self, err := MigrateFromV1(old)")
+	s.Contains(wrapperCode, "var INSATTR_INSMIGRATE_API = true")
+	s.Contains(wrapperCode, "func INSMETHOD_INSMIGRATE(object []byte, data []byte) ([]byte, []byte, error) {")
+	s.Contains(wrapperCode, `
+	case 1:
+		state, err = INSMIGRATE_1(object)
+`)
+	s.Contains(wrapperCode, `"INSMIGRATE": INSMETHOD_INSMIGRATE,`)
+	s.Contains(wrapperCode, "foundation.Result{Returns: []interface{}{2}}")
+	s.Contains(wrapperCode, `"INSVERSION": INSMETHOD_INSVERSION,`)
+}
+
+func (s *MigrationsSuite) TestNoMigrationInWrapperWithoutMigrations() {
+	parsed, err := s.parse(migrationTestContractV1, insolar.MachineTypeBuiltin)
+	s.Require().NoError(err)
+	s.Equal(1, parsed.Version())
+
+	var bufWrapper bytes.Buffer
+	err = parsed.WriteWrapper(&bufWrapper, parsed.ContractName())
+	s.Require().NoError(err)
+	s.NotContains(bufWrapper.String(), "INSMIGRATE")
+	s.Contains(bufWrapper.String(), "foundation.Result{Returns: []interface{}{1}}")
+}
+
+func (s *MigrationsSuite) TestInvalidMigrations() {
+	header := `
+package main
+
+import "github.com/insolar/insolar/logicrunner/builtin/foundation"
+
+type WalletV1 struct {
+	Balance int
+}
+`
+	for name, tc := range map[string]struct {
+		code      string
+		parseErr  string
+		wrapError string
+	}{
+		"not positive version": {
+			code: `
+// ins:version(0)
+type Wallet struct {
+	foundation.BaseContract
+}
+`,
+			parseErr: `version of contract "Wallet" should be positive`,
+		},
+		"invalid version": {
+			code: `
+// ins:version(two)
+type Wallet struct {
+	foundation.BaseContract
+}
+`,
+			parseErr: `invalid version of contract "Wallet"`,
+		},
+		"argument is not a pointer": {
+			code: `
+// ins:version(2)
+type Wallet struct {
+	foundation.BaseContract
+}
+
+// ins:migrate(1)
+func Migrate(old WalletV1) (*Wallet, error) { return nil, nil }
+`,
+			parseErr: `Migration "Migrate" should accept pointer to a structure of previous state`,
+		},
+		"unknown state type": {
+			code: `
+// ins:version(2)
+type Wallet struct {
+	foundation.BaseContract
+}
+
+// ins:migrate(1)
+func Migrate(old *foundation.BaseContract) (*Wallet, error) { return nil, nil }
+`,
+			parseErr: `Migration "Migrate" should accept structure declared in the same file, but accepts "foundation.BaseContract"`,
+		},
+		"wrong results": {
+			code: `
+// ins:version(2)
+type Wallet struct {
+	foundation.BaseContract
+}
+
+// ins:migrate(1)
+func Migrate(old *WalletV1) (Wallet, error) { return Wallet{}, nil }
+`,
+			parseErr: `Migration "Migrate" should return '*Wallet' and 'error'`,
+		},
+		"duplicated version": {
+			code: `
+// ins:version(2)
+type Wallet struct {
+	foundation.BaseContract
+}
+
+// ins:migrate(1)
+func Migrate(old *WalletV1) (*Wallet, error) { return nil, nil }
+
+// ins:migrate(1)
+func MigrateAgain(old *WalletV1) (*Wallet, error) { return nil, nil }
+`,
+			parseErr: `Migrations "Migrate" and "MigrateAgain" convert the same version 1`,
+		},
+		"reserved method": {
+			code: `
+type Wallet struct {
+	foundation.BaseContract
+}
+
+func (w *Wallet) INSMIGRATE() error { return nil }
+`,
+			parseErr: `Method name "INSMIGRATE" is reserved`,
+		},
+		"reserved version method": {
+			code: `
+type Wallet struct {
+	foundation.BaseContract
+}
+
+func (w *Wallet) INSVERSION() (int, error) { return 0, nil }
+`,
+			parseErr: `Method name "INSVERSION" is reserved`,
+		},
+		"migration from the same version": {
+			code: `
+// ins:version(1)
+type Wallet struct {
+	foundation.BaseContract
+}
+
+// ins:migrate(1)
+func Migrate(old *WalletV1) (*Wallet, error) { return nil, nil }
+`,
+			wrapError: "semantic error: 'Migrate' converts state of version 1, but version of 'Wallet' is 1 (hint: use //ins:version)",
+		},
+	} {
+		s.Run(name, func() {
+			parsed, err := s.parse(header+tc.code, insolar.MachineTypeGoPlugin)
+			if tc.parseErr != "" {
+				s.Require().Error(err)
+				s.Contains(err.Error(), tc.parseErr)
+				return
+			}
+			s.Require().NoError(err)
+
+			err = parsed.WriteWrapper(&bytes.Buffer{}, parsed.ContractName())
+			s.EqualError(err, tc.wrapError)
+		})
+	}
+}
+
+func (s *MigrationsSuite) TestCheckMigration() {
+	prev, err := s.parse(migrationTestContractV1, insolar.MachineTypeGoPlugin)
+	s.Require().NoError(err)
+
+	next, err := s.parse(migrationTestContractV2, insolar.MachineTypeGoPlugin)
+	s.Require().NoError(err)
+	s.NoError(CheckMigration(prev, next))
+
+	s.EqualError(CheckMigration(next, prev),
+		"version of new contract 1 should be greater than version of previous one 2")
+
+	for name, tc := range map[string]struct {
+		state string
+		err   string
+	}{
+		"missing field": {
+			state: "type WalletV1 struct {}",
+			err:   `field "Balance" of "Wallet" is missing in "WalletV1"`,
+		},
+		"extra field": {
+			state: "type WalletV1 struct { Balance int; Currency string }",
+			err:   `field "Currency" of "WalletV1" is missing in "Wallet"`,
+		},
+		"type mismatch": {
+			state: "type WalletV1 struct { Balance uint }",
+			err:   `field "Balance" of "Wallet" has type "int", but it's "uint" in "WalletV1"`,
+		},
+		"tag mismatch": {
+			state: "type WalletV1 struct { Balance int `codec:\"b\"` }",
+			err:   "field \"Balance\" of \"Wallet\" has type \"int\", but it's \"int `codec:\\\"b\\\"`\" in \"WalletV1\"",
+		},
+	} {
+		s.Run(name, func() {
+			next, err := s.parse(`
+package main
+
+import "github.com/insolar/insolar/logicrunner/builtin/foundation"
+
+// ins:version(2)
+type Wallet struct {
+	foundation.BaseContract
+}
+
+`+tc.state+`
+
+// ins:migrate(1)
+func MigrateFromV1(old *WalletV1) (*Wallet, error) { return &Wallet{}, nil }
+`, insolar.MachineTypeGoPlugin)
+			s.Require().NoError(err)
+			s.EqualError(CheckMigration(prev, next), tc.err)
+		})
+	}
+
+	unversioned, err := s.parse(`
+package main
+
+import "github.com/insolar/insolar/logicrunner/builtin/foundation"
+
+type Wallet struct {
+	foundation.BaseContract
+	Balance int
+}
+`, insolar.MachineTypeGoPlugin)
+	s.Require().NoError(err)
+	s.EqualError(CheckMigration(unversioned, next), "there is no migration from version 0 (hint: use //ins:migrate(0))")
+}
+
+func TestMigrations(t *testing.T) {
+	t.Parallel()
+	suite.Run(t, new(MigrationsSuite))
+}
@@ -69,6 +69,16 @@ func InitializeCodeDescriptors() []XXX_artifacts.CodeDescriptor {
     return rv
 }
 
+func InitializePrototypeABIs() map[XXX_insolar.Reference]string {
+    rv := make(map[XXX_insolar.Reference]string, 0)
+
+    {{ range $contract := .Contracts -}}
+    rv[shouldLoadRef("{{ $contract.PrototypeReference }}")] = {{ printf "%q" $contract.ABI }}
+    {{ end }}
+
+    return rv
+}
+
 func InitializePrototypeDescriptors() []XXX_artifacts.ObjectDescriptor {
     rv := make([]XXX_artifacts.ObjectDescriptor, 0)
 
@@ -93,6 +93,23 @@ func INSMETHOD_GetPrototype(object []byte, data []byte) ([]byte, []byte, error)
 	return state, ret, err
 }
 
+var INSATTR_{{ $.VersionMethod }}_API = true
+
+func INSMETHOD_{{ $.VersionMethod }}(object []byte, data []byte) ([]byte, []byte, error) {
+	ph := common.CurrentProxyCtx
+
+	result := []byte{}
+	err := ph.Serialize(
+		foundation.Result{Returns:[]interface{}{ {{ $.Version }} }},
+		&result,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return object, result, nil
+}
+
 {{ range $method := .Methods }}
 func INSMETHOD_{{ $method.Name }}(object []byte, data []byte) ([]byte, []byte, error) {
 	ph := common.CurrentProxyCtx
@@ -195,6 +212,85 @@ func INSCONSTRUCTOR_{{ $f.Name }}(data []byte) ([]byte, []byte, error) {
 }
 {{ end }}
 
+{{ range $m := .Migrations }}
+func INSMIGRATE_{{ $m.Version }}(object []byte) ([]byte, error) {
+	ph := common.CurrentProxyCtx
+	ph.SetSystemError(nil)
+	old := new({{ $m.StateType }})
+
+	if len(object) == 0 {
+		return nil, &foundation.Error{ S: "[ Fake{{ $m.Name }} ] ( INSMIGRATE_* ) ( Generated Method ) Object is nil"}
+	}
+
+	err := ph.Deserialize(object, old)
+	if err != nil {
+		e := &foundation.Error{ S: "[ Fake{{ $m.Name }} ] ( INSMIGRATE_* ) ( Generated Method ) Can't deserialize state: " + err.Error() }
+		return nil, e
+	}
+
+	self, err := {{ $m.Name }}(old)
+	if err != nil {
+		return nil, err
+	}
+	if self == nil {
+		return nil, &foundation.Error{ S: "migration returned nil" }
+	}
+
+	if ph.GetSystemError() != nil {
+		return nil, ph.GetSystemError()
+	}
+
+	state := []byte{}
+	err = ph.Serialize(self, &state)
+	if err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+{{ end }}
+
+{{ if .Migrations }}
+var INSATTR_{{ $.MigrateMethod }}_API = true
+
+func INSMETHOD_{{ $.MigrateMethod }}(object []byte, data []byte) ([]byte, []byte, error) {
+	ph := common.CurrentProxyCtx
+	// version of object state is set by logic executor from current prototype of the object
+	args := make([]interface{}, 1)
+	var fromVersion int
+	args[0] = &fromVersion
+	err := ph.Deserialize(data, &args)
+	if err != nil {
+		e := &foundation.Error{ S: "[ Fake{{ $.MigrateMethod }} ] ( INSMETHOD_* ) ( Generated Method ) Can't deserialize args.Arguments: " + err.Error() }
+		return nil, nil, e
+	}
+
+	var state []byte
+	switch fromVersion {
+{{- range $m := .Migrations }}
+	case {{ $m.Version }}:
+		state, err = INSMIGRATE_{{ $m.Version }}(object)
+{{- end }}
+	default:
+		err = &foundation.Error{ S: "no migration from version of object state" }
+	}
+	if ph.GetSystemError() != nil {
+		return nil, nil, ph.GetSystemError()
+	}
+
+	result := []byte{}
+	err = ph.Serialize(
+		foundation.Result{Returns:[]interface{}{ ph.MakeErrorSerializable(err) }},
+		&result,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return state, result, nil
+}
+{{ end }}
+
 {{ if $.GenerateInitialize -}}
 func Initialize() XXX_insolar.ContractWrapper {
     return XXX_insolar.ContractWrapper{
@@ -203,7 +299,11 @@ func Initialize() XXX_insolar.ContractWrapper {
         Methods: XXX_insolar.ContractMethods{
             {{ range $method := .Methods -}}
                     "{{ $method.Name }}": INSMETHOD_{{ $method.Name }},
-            {{ end }}
+            {{ end }}{{ if .Migrations -}}
+                    "{{ $.MigrateMethod }}": INSMETHOD_{{ $.MigrateMethod }},
+            {{ end -}}
+                    "{{ $.VersionMethod }}": INSMETHOD_{{ $.VersionMethod }},
+
         },
         Constructors: XXX_insolar.ContractConstructors{
             {{ range $f := .Functions -}}
@@ -73,6 +73,14 @@ func (s *RequestResult) SetAmend(object artifacts.ObjectDescriptor, memory []byt
 	s.ObjectImage = *prototype
 }
 
+// SetMigrate is SetAmend that also moves object to another prototype.
+func (s *RequestResult) SetMigrate(object artifacts.ObjectDescriptor, prototype insolar.Reference, memory []byte) {
+	s.SideEffectType = artifacts.RequestSideEffectAmend
+	s.Memory = memory
+	s.ObjectStateID = *object.StateID()
+	s.ObjectImage = prototype
+}
+
 func (s *RequestResult) SetDeactivate(object artifacts.ObjectDescriptor) {
 	s.SideEffectType = artifacts.RequestSideEffectDeactivate
 	s.ObjectStateID = *object.StateID()
@@ -40,6 +40,8 @@ func GetInsolarRegistry(nodeRole string) *prometheus.Registry {
 	registerer.MustRegister(NetworkComplete)
 	registerer.MustRegister(NetworkSentSize)
 	registerer.MustRegister(NetworkRecvSize)
+	registerer.MustRegister(NetworkStreamQueueDepth)
+	registerer.MustRegister(NetworkStreamStalls)
 
 	registerer.MustRegister(APIContractExecutionTime)
 
@@ -76,6 +76,22 @@ var NetworkConnections = prometheus.NewGauge(prometheus.GaugeOpts{
 	Subsystem: "network",
 })
 
+// NetworkStreamQueueDepth is current number of messages waiting to be sent to peer
+var NetworkStreamQueueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name:      "stream_queue_depth",
+	Help:      "Current number of messages waiting to be sent to peer",
+	Namespace: insolarNamespace,
+	Subsystem: "network",
+}, []string{"peer", "priority"})
+
+// NetworkStreamStalls is total number of times sending to peer was blocked by flow control
+var NetworkStreamStalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name:      "stream_stalls_total",
+	Help:      "Total number of times sending to peer was blocked by flow control",
+	Namespace: insolarNamespace,
+	Subsystem: "network",
+}, []string{"peer", "priority"})
+
 // NetworkComplete is metric that is committed when the node reaches complete network state
 var NetworkComplete = prometheus.NewGauge(prometheus.GaugeOpts{
 	Name:      "complete_network_state",
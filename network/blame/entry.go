@@ -0,0 +1,120 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package blame
+
+import (
+	"fmt"
+
+	"github.com/insolar/insolar/network/consensus/gcpv2/api/misbehavior"
+)
+
+var categoryNames = map[misbehavior.Category]string{
+	misbehavior.Blame: "blame",
+	misbehavior.Fraud: "fraud",
+}
+
+var typeNames = map[misbehavior.Type]string{
+	misbehavior.Blame.Of(misbehavior.BlameExcessiveIntro):    "excessive_intro",
+	misbehavior.Blame.Of(misbehavior.MismatchedPulsarPacket): "mismatched_pulsar_packet",
+	misbehavior.Blame.Of(misbehavior.ProtocolViolation):      "protocol_violation",
+	misbehavior.Fraud.Of(misbehavior.FraudMultipleNsh):       "multiple_nsh",
+	misbehavior.Fraud.Of(misbehavior.MismatchedRank):         "mismatched_rank",
+	misbehavior.Fraud.Of(misbehavior.MismatchedNeighbour):    "mismatched_neighbour",
+	misbehavior.Fraud.Of(misbehavior.WrongPower):             "wrong_power",
+}
+
+// CategoryName returns name of misbehavior category.
+func CategoryName(c misbehavior.Category) string {
+	if name, ok := categoryNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("category_%d", c)
+}
+
+// TypeName returns name of misbehavior type without category.
+func TypeName(t misbehavior.Type) string {
+	if name, ok := typeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("type_%d", t.Type())
+}
+
+func newEntry(report misbehavior.Report) Entry {
+	t := report.MisbehaviorType()
+	e := Entry{
+		Category: CategoryName(t.Category()),
+		Type:     TypeName(t),
+	}
+
+	if host := report.ViolatorHost(); host.Addr != "" {
+		e.Host = string(host.Addr)
+	}
+
+	if node := report.ViolatorNode(); node != nil {
+		e.NodeID = node.GetNodeID()
+		if static := node.GetStatic(); static != nil {
+			if ext := static.GetExtension(); ext != nil {
+				e.Node = ext.GetReference().String()
+			}
+			if ep := static.GetDefaultEndpoint(); e.Host == "" && ep != nil {
+				e.Host = string(ep.GetNameAddress())
+			}
+		}
+	}
+
+	if err, ok := report.(error); ok {
+		e.Description = err.Error()
+	}
+	for _, d := range report.Details() {
+		e.Evidence = append(e.Evidence, fmt.Sprintf("%+v", d))
+	}
+	return e
+}
@@ -0,0 +1,295 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+// Package blame keeps a journal of misbehavior reports consensus makes about other nodes.
+package blame
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/stats"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/store"
+	"github.com/insolar/insolar/instrumentation/insmetrics"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/consensus/gcpv2/api/misbehavior"
+)
+
+// Entry is a misbehavior report kept in journal.
+type Entry struct {
+	ID    uint64              `json:"id"`
+	Pulse insolar.PulseNumber `json:"pulse"`
+	Time  time.Time           `json:"time"`
+	// Category is "blame" or "fraud".
+	Category string `json:"category"`
+	Type     string `json:"type"`
+	// NodeID is a short id of violator node, it is zero when only violator host is known.
+	NodeID insolar.ShortNodeID `json:"nodeId,omitempty"`
+	// Node is a reference of violator node, it is empty when node has not introduced itself fully.
+	Node        string   `json:"node,omitempty"`
+	Host        string   `json:"host,omitempty"`
+	Description string   `json:"description"`
+	Evidence    []string `json:"evidence,omitempty"`
+}
+
+// Violator returns the most specific known identity of violator.
+func (e Entry) Violator() string {
+	switch {
+	case e.Node != "":
+		return e.Node
+	case e.NodeID != 0:
+		return fmt.Sprint(e.NodeID)
+	default:
+		return e.Host
+	}
+}
+
+// Filter selects journal entries. Empty fields match all entries.
+type Filter struct {
+	// Node matches violator reference, short id or host.
+	Node      string
+	Category  string
+	Type      string
+	FromPulse insolar.PulseNumber
+	// Limit is a max number of returned entries.
+	Limit int
+}
+
+func (f Filter) match(e Entry) bool {
+	if f.Node != "" && f.Node != e.Node && f.Node != e.Host && f.Node != fmt.Sprint(e.NodeID) {
+		return false
+	}
+	if f.Category != "" && f.Category != e.Category {
+		return false
+	}
+	if f.Type != "" && f.Type != e.Type {
+		return false
+	}
+	return e.Pulse >= f.FromPulse
+}
+
+// Accessor provides access to journal entries.
+type Accessor interface {
+	// Find returns entries matching filter, the latest first.
+	Find(ctx context.Context, filter Filter) ([]Entry, error)
+}
+
+type entryKey uint64
+
+func (k entryKey) Scope() store.Scope {
+	return store.ScopeBlame
+}
+
+func (k entryKey) ID() []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(k))
+	return buf
+}
+
+// Journal keeps misbehavior reports in memory and persists them to storage if it is configured.
+// Reports are dropped when they become older than MaxAge or don't fit into MaxEntries.
+type Journal struct {
+	cfg configuration.Blame
+	now func() time.Time
+
+	lock    sync.RWMutex
+	db      store.Engine
+	entries []Entry // the oldest first
+	nextID  uint64
+}
+
+// NewJournal creates journal. Storage is opened on Init.
+func NewJournal(cfg configuration.Blame) *Journal {
+	return &Journal{cfg: cfg, now: time.Now, nextID: 1}
+}
+
+// Init opens storage and loads persisted reports.
+func (j *Journal) Init(ctx context.Context) error {
+	if j.cfg.Storage.DataDirectory == "" {
+		return nil
+	}
+
+	db, err := store.NewEngine(j.cfg.Storage)
+	if err != nil {
+		return errors.Wrap(err, "failed to open blame journal storage")
+	}
+
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	j.db = db
+	it := db.NewIterator(entryKey(0), false)
+	defer it.Close()
+	for it.Next() {
+		buf, err := it.Value()
+		if err != nil {
+			return errors.Wrap(err, "failed to read blame journal entry")
+		}
+		var e Entry
+		err = json.Unmarshal(buf, &e)
+		if err != nil {
+			return errors.Wrap(err, "failed to unmarshal blame journal entry")
+		}
+		j.entries = append(j.entries, e)
+		j.nextID = e.ID + 1
+	}
+
+	return j.prune()
+}
+
+// Stop closes storage.
+func (j *Journal) Stop(ctx context.Context) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.db == nil {
+		return nil
+	}
+	err := j.db.Stop(ctx)
+	j.db = nil
+	return err
+}
+
+// Add saves report made in pulse pn. Report is kept in memory even if it fails to persist.
+func (j *Journal) Add(ctx context.Context, pn insolar.PulseNumber, report misbehavior.Report) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	e := newEntry(report)
+	e.ID = j.nextID
+	e.Pulse = pn
+	e.Time = j.now()
+	j.nextID++
+	j.entries = append(j.entries, e)
+
+	mctx := insmetrics.InsertTag(ctx, network.TagViolator, e.Violator())
+	mctx = insmetrics.InsertTag(mctx, network.TagMisbehavior, e.Category+"/"+e.Type)
+	stats.Record(mctx, network.MisbehaviorReports.M(1))
+
+	if j.db != nil {
+		buf, err := json.Marshal(e)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal blame journal entry")
+		}
+		err = j.db.Set(entryKey(e.ID), buf)
+		if err != nil {
+			return errors.Wrap(err, "failed to save blame journal entry")
+		}
+	}
+
+	return j.prune()
+}
+
+// Find returns entries matching filter, the latest first.
+func (j *Journal) Find(ctx context.Context, filter Filter) ([]Entry, error) {
+	j.lock.RLock()
+	defer j.lock.RUnlock()
+
+	expired := j.expired()
+	res := []Entry{}
+	for i := len(j.entries) - 1; i >= 0; i-- {
+		e := j.entries[i]
+		if !e.Time.After(expired) {
+			break
+		}
+		if !filter.match(e) {
+			continue
+		}
+		res = append(res, e)
+		if filter.Limit > 0 && len(res) >= filter.Limit {
+			break
+		}
+	}
+	return res, nil
+}
+
+func (j *Journal) expired() time.Time {
+	if j.cfg.MaxAge <= 0 {
+		return time.Time{}
+	}
+	return j.now().Add(-j.cfg.MaxAge)
+}
+
+// prune drops the oldest entries beyond retention limits. Must be called under the write lock.
+func (j *Journal) prune() error {
+	expired := j.expired()
+	n := 0
+	for n < len(j.entries) && !j.entries[n].Time.After(expired) {
+		n++
+	}
+	if j.cfg.MaxEntries > 0 && len(j.entries)-n > j.cfg.MaxEntries {
+		n = len(j.entries) - j.cfg.MaxEntries
+	}
+	if n == 0 {
+		return nil
+	}
+
+	dropped := j.entries[:n]
+	j.entries = append([]Entry(nil), j.entries[n:]...)
+
+	if j.db == nil {
+		return nil
+	}
+	for _, e := range dropped {
+		err := j.db.Delete(entryKey(e.ID))
+		if err != nil {
+			return errors.Wrap(err, "failed to delete expired blame journal entry")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package blame
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/gen"
+	"github.com/insolar/insolar/network/consensus/common/endpoints"
+	"github.com/insolar/insolar/network/consensus/gcpv2/api/misbehavior"
+	"github.com/insolar/insolar/network/consensus/gcpv2/api/profiles"
+)
+
+func hostBlame(addr string) misbehavior.Report {
+	host := &endpoints.InboundConnection{Addr: endpoints.Name(addr)}
+	err := misbehavior.NewBlameFactory(nil).NewMismatchedPulsarPacket(host, nil, nil)
+	return &err
+}
+
+func nodeFraud(t *testing.T, id insolar.ShortNodeID, ref insolar.Reference) misbehavior.Report {
+	ext := profiles.NewStaticProfileExtensionMock(t).GetReferenceMock.Return(ref)
+	static := profiles.NewStaticProfileMock(t).
+		GetExtensionMock.Return(ext).
+		GetDefaultEndpointMock.Return(nil)
+	node := profiles.NewBaseNodeMock(t).
+		GetNodeIDMock.Return(id).
+		GetStaticMock.Return(static)
+	err := misbehavior.NewFraudFactory(nil).NewInvalidPowerLevel(node)
+	return &err
+}
+
+func TestJournal_AddFind(t *testing.T) {
+	ctx := context.Background()
+	j := NewJournal(configuration.Blame{})
+	require.NoError(t, j.Init(ctx))
+
+	ref := gen.Reference()
+	require.NoError(t, j.Add(ctx, 65537, hostBlame("127.0.0.1:1")))
+	require.NoError(t, j.Add(ctx, 65538, nodeFraud(t, 7, ref)))
+	require.NoError(t, j.Add(ctx, 65539, hostBlame("127.0.0.1:2")))
+
+	all, err := j.Find(ctx, Filter{})
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	require.Equal(t, []uint64{3, 2, 1}, []uint64{all[0].ID, all[1].ID, all[2].ID})
+
+	fraud := all[1]
+	require.Equal(t, insolar.PulseNumber(65538), fraud.Pulse)
+	require.Equal(t, "fraud", fraud.Category)
+	require.Equal(t, "wrong_power", fraud.Type)
+	require.Equal(t, insolar.ShortNodeID(7), fraud.NodeID)
+	require.Equal(t, ref.String(), fraud.Node)
+	require.NotEmpty(t, fraud.Description)
+
+	blame := all[2]
+	require.Equal(t, "blame", blame.Category)
+	require.Equal(t, "mismatched_pulsar_packet", blame.Type)
+	require.Equal(t, "127.0.0.1:1", blame.Host)
+	require.Equal(t, "127.0.0.1:1", blame.Violator())
+	require.Len(t, blame.Evidence, 2)
+
+	t.Run("filter", func(t *testing.T) {
+		res, err := j.Find(ctx, Filter{Node: ref.String()})
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		require.Equal(t, uint64(2), res[0].ID)
+
+		res, err = j.Find(ctx, Filter{Node: "7"})
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+
+		res, err = j.Find(ctx, Filter{Node: "127.0.0.1:2"})
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		require.Equal(t, uint64(3), res[0].ID)
+
+		res, err = j.Find(ctx, Filter{Category: "blame"})
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+
+		res, err = j.Find(ctx, Filter{Type: "wrong_power"})
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+
+		res, err = j.Find(ctx, Filter{FromPulse: 65538})
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+
+		res, err = j.Find(ctx, Filter{Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		require.Equal(t, uint64(3), res[0].ID)
+	})
+}
+
+func TestJournal_Retention(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("max entries", func(t *testing.T) {
+		j := NewJournal(configuration.Blame{MaxEntries: 2})
+		for i := 0; i < 5; i++ {
+			require.NoError(t, j.Add(ctx, 65537, hostBlame("127.0.0.1:1")))
+		}
+		res, err := j.Find(ctx, Filter{})
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		require.Equal(t, uint64(5), res[0].ID)
+		require.Equal(t, uint64(4), res[1].ID)
+	})
+
+	t.Run("max age", func(t *testing.T) {
+		now := time.Now()
+		j := NewJournal(configuration.Blame{MaxAge: time.Hour})
+		j.now = func() time.Time { return now }
+
+		require.NoError(t, j.Add(ctx, 65537, hostBlame("127.0.0.1:1")))
+		now = now.Add(40 * time.Minute)
+		require.NoError(t, j.Add(ctx, 65538, hostBlame("127.0.0.1:1")))
+		now = now.Add(40 * time.Minute)
+
+		res, err := j.Find(ctx, Filter{})
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		require.Equal(t, uint64(2), res[0].ID)
+
+		require.NoError(t, j.Add(ctx, 65539, hostBlame("127.0.0.1:1")))
+		require.Len(t, j.entries, 2)
+	})
+}
+
+func TestJournal_Persistence(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "blame")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cfg := configuration.Blame{
+		Storage: configuration.Storage{
+			DataDirectory: dir,
+			Backend:       configuration.StorageBackendSkipList,
+		},
+		MaxEntries: 2,
+	}
+
+	j := NewJournal(cfg)
+	require.NoError(t, j.Init(ctx))
+	for i := 0; i < 3; i++ {
+		require.NoError(t, j.Add(ctx, insolar.PulseNumber(65537+i), hostBlame("127.0.0.1:1")))
+	}
+	require.NoError(t, j.Stop(ctx))
+
+	j = NewJournal(cfg)
+	require.NoError(t, j.Init(ctx))
+	res, err := j.Find(ctx, Filter{})
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	require.Equal(t, uint64(3), res[0].ID)
+	require.Equal(t, insolar.PulseNumber(65539), res[0].Pulse)
+	require.Equal(t, uint64(2), res[1].ID)
+
+	require.NoError(t, j.Add(ctx, 65540, hostBlame("127.0.0.1:1")))
+	res, err = j.Find(ctx, Filter{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), res[0].ID)
+	require.NoError(t, j.Stop(ctx))
+}
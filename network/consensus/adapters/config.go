@@ -52,7 +52,6 @@ package adapters
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"time"
 
 	"github.com/insolar/insolar/insolar"
@@ -91,12 +90,10 @@ func NewLocalNodeConfiguration(ctx context.Context, keyStore insolar.KeyStore) *
 		panic(err)
 	}
 
-	ecdsaPrivateKey := privateKey.(*ecdsa.PrivateKey)
-
 	return &LocalNodeConfiguration{
 		ctx:            ctx,
 		timings:        defaultRoundTimings,
-		secretKeyStore: NewECDSASecretKeyStore(ecdsaPrivateKey),
+		secretKeyStore: NewECDSASecretKeyStore(privateKey),
 	}
 }
 
@@ -51,9 +51,12 @@
 package adapters
 
 import (
+	"crypto"
 	"crypto/ecdsa"
 	"io"
 
+	"github.com/pkg/errors"
+
 	"github.com/insolar/insolar/insolar"
 	"github.com/insolar/insolar/longbits"
 	"github.com/insolar/insolar/network/consensus/common/cryptkit"
@@ -105,47 +108,50 @@ func NewECDSAPublicKeyStore(publicKey *ecdsa.PublicKey) *ECDSAPublicKeyStore {
 func (pks *ECDSAPublicKeyStore) PublicKeyStore() {}
 
 type ECDSASecretKeyStore struct {
-	privateKey *ecdsa.PrivateKey
+	// privateKey is either *ecdsa.PrivateKey or crypto.Signer backed by an external signer.
+	privateKey crypto.PrivateKey
+	publicKey  *ecdsa.PublicKey
 }
 
-func NewECDSASecretKeyStore(privateKey *ecdsa.PrivateKey) *ECDSASecretKeyStore {
+func NewECDSASecretKeyStore(privateKey crypto.PrivateKey) *ECDSASecretKeyStore {
 	return &ECDSASecretKeyStore{
 		privateKey: privateKey,
+		publicKey:  privateKey.(crypto.Signer).Public().(*ecdsa.PublicKey),
 	}
 }
 
 func (ks *ECDSASecretKeyStore) PrivateKeyStore() {}
 
 func (ks *ECDSASecretKeyStore) AsPublicKeyStore() cryptkit.PublicKeyStore {
-	return NewECDSAPublicKeyStore(&ks.privateKey.PublicKey)
+	return NewECDSAPublicKeyStore(ks.publicKey)
 }
 
 type ECDSADigestSigner struct {
 	scheme     insolar.PlatformCryptographyScheme
-	privateKey *ecdsa.PrivateKey
+	privateKey crypto.PrivateKey
 }
 
-func NewECDSADigestSigner(privateKey *ecdsa.PrivateKey, scheme insolar.PlatformCryptographyScheme) *ECDSADigestSigner {
+func NewECDSADigestSigner(privateKey crypto.PrivateKey, scheme insolar.PlatformCryptographyScheme) *ECDSADigestSigner {
 	return &ECDSADigestSigner{
 		scheme:     scheme,
 		privateKey: privateKey,
 	}
 }
 
-func (ds *ECDSADigestSigner) SignDigest(digest cryptkit.Digest) cryptkit.Signature {
+func (ds *ECDSADigestSigner) SignDigest(digest cryptkit.Digest) (cryptkit.Signature, error) {
 	digestBytes := digest.AsBytes()
 
 	signer := ds.scheme.DigestSigner(ds.privateKey)
 
 	sig, err := signer.Sign(digestBytes)
 	if err != nil {
-		panic("Failed to create signature")
+		return cryptkit.Signature{}, errors.Wrap(err, "failed to create signature")
 	}
 
 	sigBytes := sig.Bytes()
 	bits := longbits.NewBits512FromBytes(sigBytes)
 
-	return cryptkit.NewSignature(bits, digest.GetDigestMethod().SignedBy(ds.GetSignMethod()))
+	return cryptkit.NewSignature(bits, digest.GetDigestMethod().SignedBy(ds.GetSignMethod())), nil
 }
 
 func (ds *ECDSADigestSigner) GetSignMethod() cryptkit.SignMethod {
@@ -156,6 +162,8 @@ type ECDSASignatureVerifier struct {
 	digester  *Sha3512Digester
 	scheme    insolar.PlatformCryptographyScheme
 	publicKey *ecdsa.PublicKey
+
+	revokedKeys *RevokedKeys
 }
 
 func NewECDSASignatureVerifier(
@@ -188,6 +196,10 @@ func (sv *ECDSASignatureVerifier) IsValidDigestSignature(digest cryptkit.DigestH
 		return false
 	}
 
+	if sv.revokedKeys.IsRevoked(sv.publicKey) {
+		return false
+	}
+
 	digestBytes := digest.AsBytes()
 	signatureBytes := signature.AsBytes()
 
@@ -52,11 +52,14 @@ package adapters
 
 import (
 	"bytes"
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/rand"
 	"io"
 	"testing"
 
+	"github.com/pkg/errors"
+
 	"github.com/insolar/insolar/insolar"
 	"github.com/insolar/insolar/longbits"
 	"github.com/insolar/insolar/network/consensus/common/cryptkit"
@@ -159,7 +162,8 @@ func TestECDSADigestSigner_SignDigest(t *testing.T) {
 	digest := digester.GetDigestOf(reader)
 	digestBytes := digest.AsBytes()
 
-	signature := ds.SignDigest(digest)
+	signature, err := ds.SignDigest(digest)
+	require.NoError(t, err)
 	require.Equal(t, scheme.SignatureSize(), signature.FixedByteSize())
 	require.Equal(t, signature.GetSignatureMethod(), SHA3512Digest.SignedBy(SECP256r1Sign))
 
@@ -168,6 +172,27 @@ func TestECDSADigestSigner_SignDigest(t *testing.T) {
 	require.True(t, verifier.Verify(insolar.SignatureFromBytes(signatureBytes), digestBytes))
 }
 
+// failingSigner is an external signer which is unavailable.
+type failingSigner struct{}
+
+func (failingSigner) Public() crypto.PublicKey {
+	return publicKey
+}
+
+func (failingSigner) Sign(io.Reader, []byte, crypto.SignerOpts) ([]byte, error) {
+	return nil, errors.New("signer is unavailable")
+}
+
+func TestECDSADigestSigner_SignDigestFailure(t *testing.T) {
+	ds := NewECDSADigestSigner(failingSigner{}, scheme)
+	digest := NewSha3512Digester(scheme).GetDigestOf(bytes.NewReader([]byte("data")))
+
+	require.NotPanics(t, func() {
+		_, err := ds.SignDigest(digest)
+		require.Error(t, err)
+	})
+}
+
 func TestECDSADigestSigner_GetSignMethod(t *testing.T) {
 	ds := NewECDSADigestSigner(privateKey, scheme)
 
@@ -231,6 +256,32 @@ func TestECDSASignatureVerifier_IsValidDigestSignature(t *testing.T) {
 	require.True(t, dv.IsValidDigestSignature(digest.AsDigestHolder(), sig.AsSignatureHolder()))
 }
 
+func TestECDSASignatureVerifier_IsValidDigestSignature_RevokedKey(t *testing.T) {
+	digester := NewSha3512Digester(scheme)
+	revokedKeys := NewRevokedKeys()
+	dv := NewECDSASignatureVerifierFactory(digester, scheme, revokedKeys).
+		CreateSignatureVerifierWithPKS(NewECDSAPublicKeyStore(publicKey))
+
+	signer := scheme.DigestSigner(privateKey)
+
+	b := make([]byte, 120)
+	_, _ = rand.Read(b)
+	reader := bytes.NewReader(b)
+
+	digest := digester.GetDigestOf(reader)
+	digestBytes := digest.AsBytes()
+
+	signature, _ := signer.Sign(digestBytes)
+
+	sig := cryptkit.NewSignature(longbits.NewBits512FromBytes(signature.Bytes()), SHA3512Digest.SignedBy(SECP256r1Sign))
+
+	require.True(t, dv.IsValidDigestSignature(digest.AsDigestHolder(), sig.AsSignatureHolder()))
+
+	require.True(t, revokedKeys.Add(publicKey))
+	require.False(t, revokedKeys.Add(publicKey))
+	require.False(t, dv.IsValidDigestSignature(digest.AsDigestHolder(), sig.AsSignatureHolder()))
+}
+
 func TestECDSASignatureVerifier_IsValidDigestSignature_InvalidMethod(t *testing.T) {
 	digester := NewSha3512Digester(scheme)
 	dv := NewECDSASignatureVerifier(digester, scheme, publicKey)
@@ -65,28 +65,34 @@ import (
 )
 
 type ECDSASignatureVerifierFactory struct {
-	digester *Sha3512Digester
-	scheme   insolar.PlatformCryptographyScheme
+	digester    *Sha3512Digester
+	scheme      insolar.PlatformCryptographyScheme
+	revokedKeys *RevokedKeys
 }
 
 func NewECDSASignatureVerifierFactory(
 	digester *Sha3512Digester,
 	scheme insolar.PlatformCryptographyScheme,
+	revokedKeys *RevokedKeys,
 ) *ECDSASignatureVerifierFactory {
 	return &ECDSASignatureVerifierFactory{
-		digester: digester,
-		scheme:   scheme,
+		digester:    digester,
+		scheme:      scheme,
+		revokedKeys: revokedKeys,
 	}
 }
 
 func (vf *ECDSASignatureVerifierFactory) CreateSignatureVerifierWithPKS(pks cryptkit.PublicKeyStore) cryptkit.SignatureVerifier {
 	keyStore := pks.(*ECDSAPublicKeyStore)
 
-	return NewECDSASignatureVerifier(
+	verifier := NewECDSASignatureVerifier(
 		vf.digester,
 		vf.scheme,
 		keyStore.publicKey,
 	)
+	verifier.revokedKeys = vf.revokedKeys
+
+	return verifier
 }
 
 type TransportCryptographyFactory struct {
@@ -95,11 +101,15 @@ type TransportCryptographyFactory struct {
 	scheme          insolar.PlatformCryptographyScheme
 }
 
-func NewTransportCryptographyFactory(scheme insolar.PlatformCryptographyScheme) *TransportCryptographyFactory {
+func NewTransportCryptographyFactory(
+	scheme insolar.PlatformCryptographyScheme,
+	revokedKeys *RevokedKeys,
+) *TransportCryptographyFactory {
 	return &TransportCryptographyFactory{
 		verifierFactory: NewECDSASignatureVerifierFactory(
 			NewSha3512Digester(scheme),
 			scheme,
+			revokedKeys,
 		),
 		digestFactory: NewConsensusDigestFactory(scheme),
 		scheme:        scheme,
@@ -51,7 +51,13 @@
 package adapters
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"sync"
+
 	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/network"
 	"github.com/insolar/insolar/network/consensus/common/cryptkit"
 	"github.com/insolar/insolar/network/consensus/common/endpoints"
@@ -62,13 +68,70 @@ import (
 	"github.com/insolar/insolar/pulse"
 )
 
-type MisbehaviorRegistry struct{}
+type MisbehaviorJournal interface {
+	Add(ctx context.Context, pn insolar.PulseNumber, report misbehavior.Report) error
+}
+
+type MisbehaviorRegistry struct {
+	ctx         context.Context
+	journal     MisbehaviorJournal
+	pulseNumber pulse.Number
+}
 
-func NewMisbehaviorRegistry() *MisbehaviorRegistry {
-	return &MisbehaviorRegistry{}
+func NewMisbehaviorRegistry(ctx context.Context, journal MisbehaviorJournal) *MisbehaviorRegistry {
+	return &MisbehaviorRegistry{
+		ctx:     ctx,
+		journal: journal,
+	}
 }
 
 func (mr *MisbehaviorRegistry) AddReport(report misbehavior.Report) {
+	err := mr.journal.Add(mr.ctx, mr.pulseNumber, report)
+	if err != nil {
+		inslogger.FromContext(mr.ctx).Error("Failed to add misbehavior report to journal: ", err)
+	}
+}
+
+func (mr *MisbehaviorRegistry) forPulse(pn pulse.Number) *MisbehaviorRegistry {
+	cp := *mr
+	cp.pulseNumber = pn
+	return &cp
+}
+
+// RevokedKeys keeps keys of nodes revoked from network. Consensus packets signed by these keys are refused,
+// so nodes with revoked keys are dropped from active list by consensus.
+type RevokedKeys struct {
+	lock sync.RWMutex
+	keys map[string]struct{}
+}
+
+func NewRevokedKeys() *RevokedKeys {
+	return &RevokedKeys{keys: make(map[string]struct{})}
+}
+
+// Add adds key to revoked ones. It returns false if key is already revoked.
+func (rk *RevokedKeys) Add(publicKey *ecdsa.PublicKey) bool {
+	key := string(elliptic.Marshal(publicKey.Curve, publicKey.X, publicKey.Y))
+
+	rk.lock.Lock()
+	defer rk.lock.Unlock()
+	if _, ok := rk.keys[key]; ok {
+		return false
+	}
+	rk.keys[key] = struct{}{}
+	return true
+}
+
+func (rk *RevokedKeys) IsRevoked(publicKey *ecdsa.PublicKey) bool {
+	if rk == nil {
+		return false
+	}
+	key := string(elliptic.Marshal(publicKey.Curve, publicKey.X, publicKey.Y))
+
+	rk.lock.RLock()
+	defer rk.lock.RUnlock()
+	_, ok := rk.keys[key]
+	return ok
 }
 
 type MandateRegistry struct {
@@ -151,6 +214,9 @@ func (c *VersionedRegistries) CommitNextPulse(pd pulse.Data, population census.O
 	pd.EnsurePulseData()
 	cp := *c
 	cp.pulseData = pd
+	if mr, ok := c.misbehaviorRegistry.(*MisbehaviorRegistry); ok {
+		cp.misbehaviorRegistry = mr.forPulse(pd.PulseNumber)
+	}
 	return &cp
 }
 
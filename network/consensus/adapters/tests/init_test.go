@@ -67,6 +67,7 @@ import (
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/keystore"
 	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/blame"
 	"github.com/insolar/insolar/network/consensus"
 	"github.com/insolar/insolar/network/consensus/adapters"
 	"github.com/insolar/insolar/network/consensus/common/endpoints"
@@ -203,6 +204,8 @@ func initNodes(ctx context.Context, mode consensus.Mode, nodes GeneratedNodes, s
 			EphemeralController: &ephemeralController{
 				allowed: true,
 			},
+			MisbehaviorJournal: blame.NewJournal(configuration.NewServiceNetwork().Blame),
+			RevokedKeys:        adapters.NewRevokedKeys(),
 		}).ControllerFor(mode, datagramHandler, pulseHandler)
 
 		ns.controllers[i] = controller
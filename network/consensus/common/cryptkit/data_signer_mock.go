@@ -45,7 +45,7 @@ type DataSignerMock struct {
 	beforeSignDataCounter uint64
 	SignDataMock          mDataSignerMockSignData
 
-	funcSignDigest          func(digest Digest) (s1 Signature)
+	funcSignDigest          func(digest Digest) (s1 Signature, err error)
 	inspectFuncSignDigest   func(digest Digest)
 	afterSignDigestCounter  uint64
 	beforeSignDigestCounter uint64
@@ -960,7 +960,8 @@ type DataSignerMockSignDigestParams struct {
 
 // DataSignerMockSignDigestResults contains results of the DataSigner.SignDigest
 type DataSignerMockSignDigestResults struct {
-	s1 Signature
+	s1  Signature
+	err error
 }
 
 // Expect sets up expected params for DataSigner.SignDigest
@@ -995,7 +996,7 @@ func (mmSignDigest *mDataSignerMockSignDigest) Inspect(f func(digest Digest)) *m
 }
 
 // Return sets up results that will be returned by DataSigner.SignDigest
-func (mmSignDigest *mDataSignerMockSignDigest) Return(s1 Signature) *DataSignerMock {
+func (mmSignDigest *mDataSignerMockSignDigest) Return(s1 Signature, err error) *DataSignerMock {
 	if mmSignDigest.mock.funcSignDigest != nil {
 		mmSignDigest.mock.t.Fatalf("DataSignerMock.SignDigest mock is already set by Set")
 	}
@@ -1003,12 +1004,12 @@ func (mmSignDigest *mDataSignerMockSignDigest) Return(s1 Signature) *DataSignerM
 	if mmSignDigest.defaultExpectation == nil {
 		mmSignDigest.defaultExpectation = &DataSignerMockSignDigestExpectation{mock: mmSignDigest.mock}
 	}
-	mmSignDigest.defaultExpectation.results = &DataSignerMockSignDigestResults{s1}
+	mmSignDigest.defaultExpectation.results = &DataSignerMockSignDigestResults{s1, err}
 	return mmSignDigest.mock
 }
 
 //Set uses given function f to mock the DataSigner.SignDigest method
-func (mmSignDigest *mDataSignerMockSignDigest) Set(f func(digest Digest) (s1 Signature)) *DataSignerMock {
+func (mmSignDigest *mDataSignerMockSignDigest) Set(f func(digest Digest) (s1 Signature, err error)) *DataSignerMock {
 	if mmSignDigest.defaultExpectation != nil {
 		mmSignDigest.mock.t.Fatalf("Default expectation is already set for the DataSigner.SignDigest method")
 	}
@@ -1037,13 +1038,13 @@ func (mmSignDigest *mDataSignerMockSignDigest) When(digest Digest) *DataSignerMo
 }
 
 // Then sets up DataSigner.SignDigest return parameters for the expectation previously defined by the When method
-func (e *DataSignerMockSignDigestExpectation) Then(s1 Signature) *DataSignerMock {
-	e.results = &DataSignerMockSignDigestResults{s1}
+func (e *DataSignerMockSignDigestExpectation) Then(s1 Signature, err error) *DataSignerMock {
+	e.results = &DataSignerMockSignDigestResults{s1, err}
 	return e.mock
 }
 
 // SignDigest implements DataSigner
-func (mmSignDigest *DataSignerMock) SignDigest(digest Digest) (s1 Signature) {
+func (mmSignDigest *DataSignerMock) SignDigest(digest Digest) (s1 Signature, err error) {
 	mm_atomic.AddUint64(&mmSignDigest.beforeSignDigestCounter, 1)
 	defer mm_atomic.AddUint64(&mmSignDigest.afterSignDigestCounter, 1)
 
@@ -1061,7 +1062,7 @@ func (mmSignDigest *DataSignerMock) SignDigest(digest Digest) (s1 Signature) {
 	for _, e := range mmSignDigest.SignDigestMock.expectations {
 		if minimock.Equal(e.params, params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.s1
+			return e.results.s1, e.results.err
 		}
 	}
 
@@ -1077,7 +1078,7 @@ func (mmSignDigest *DataSignerMock) SignDigest(digest Digest) (s1 Signature) {
 		if results == nil {
 			mmSignDigest.t.Fatal("No results are set for the DataSignerMock.SignDigest")
 		}
-		return (*results).s1
+		return (*results).s1, (*results).err
 	}
 	if mmSignDigest.funcSignDigest != nil {
 		return mmSignDigest.funcSignDigest(digest)
@@ -64,7 +64,7 @@ type DigestHolderMock struct {
 	beforeReadCounter uint64
 	ReadMock          mDigestHolderMockRead
 
-	funcSignWith          func(signer DigestSigner) (s1 SignedDigestHolder)
+	funcSignWith          func(signer DigestSigner) (s1 SignedDigestHolder, err error)
 	inspectFuncSignWith   func(signer DigestSigner)
 	afterSignWithCounter  uint64
 	beforeSignWithCounter uint64
@@ -1424,7 +1424,8 @@ type DigestHolderMockSignWithParams struct {
 
 // DigestHolderMockSignWithResults contains results of the DigestHolder.SignWith
 type DigestHolderMockSignWithResults struct {
-	s1 SignedDigestHolder
+	s1  SignedDigestHolder
+	err error
 }
 
 // Expect sets up expected params for DigestHolder.SignWith
@@ -1459,7 +1460,7 @@ func (mmSignWith *mDigestHolderMockSignWith) Inspect(f func(signer DigestSigner)
 }
 
 // Return sets up results that will be returned by DigestHolder.SignWith
-func (mmSignWith *mDigestHolderMockSignWith) Return(s1 SignedDigestHolder) *DigestHolderMock {
+func (mmSignWith *mDigestHolderMockSignWith) Return(s1 SignedDigestHolder, err error) *DigestHolderMock {
 	if mmSignWith.mock.funcSignWith != nil {
 		mmSignWith.mock.t.Fatalf("DigestHolderMock.SignWith mock is already set by Set")
 	}
@@ -1467,12 +1468,12 @@ func (mmSignWith *mDigestHolderMockSignWith) Return(s1 SignedDigestHolder) *Dige
 	if mmSignWith.defaultExpectation == nil {
 		mmSignWith.defaultExpectation = &DigestHolderMockSignWithExpectation{mock: mmSignWith.mock}
 	}
-	mmSignWith.defaultExpectation.results = &DigestHolderMockSignWithResults{s1}
+	mmSignWith.defaultExpectation.results = &DigestHolderMockSignWithResults{s1, err}
 	return mmSignWith.mock
 }
 
 //Set uses given function f to mock the DigestHolder.SignWith method
-func (mmSignWith *mDigestHolderMockSignWith) Set(f func(signer DigestSigner) (s1 SignedDigestHolder)) *DigestHolderMock {
+func (mmSignWith *mDigestHolderMockSignWith) Set(f func(signer DigestSigner) (s1 SignedDigestHolder, err error)) *DigestHolderMock {
 	if mmSignWith.defaultExpectation != nil {
 		mmSignWith.mock.t.Fatalf("Default expectation is already set for the DigestHolder.SignWith method")
 	}
@@ -1501,13 +1502,13 @@ func (mmSignWith *mDigestHolderMockSignWith) When(signer DigestSigner) *DigestHo
 }
 
 // Then sets up DigestHolder.SignWith return parameters for the expectation previously defined by the When method
-func (e *DigestHolderMockSignWithExpectation) Then(s1 SignedDigestHolder) *DigestHolderMock {
-	e.results = &DigestHolderMockSignWithResults{s1}
+func (e *DigestHolderMockSignWithExpectation) Then(s1 SignedDigestHolder, err error) *DigestHolderMock {
+	e.results = &DigestHolderMockSignWithResults{s1, err}
 	return e.mock
 }
 
 // SignWith implements DigestHolder
-func (mmSignWith *DigestHolderMock) SignWith(signer DigestSigner) (s1 SignedDigestHolder) {
+func (mmSignWith *DigestHolderMock) SignWith(signer DigestSigner) (s1 SignedDigestHolder, err error) {
 	mm_atomic.AddUint64(&mmSignWith.beforeSignWithCounter, 1)
 	defer mm_atomic.AddUint64(&mmSignWith.afterSignWithCounter, 1)
 
@@ -1525,7 +1526,7 @@ func (mmSignWith *DigestHolderMock) SignWith(signer DigestSigner) (s1 SignedDige
 	for _, e := range mmSignWith.SignWithMock.expectations {
 		if minimock.Equal(e.params, params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.s1
+			return e.results.s1, e.results.err
 		}
 	}
 
@@ -1541,7 +1542,7 @@ func (mmSignWith *DigestHolderMock) SignWith(signer DigestSigner) (s1 SignedDige
 		if results == nil {
 			mmSignWith.t.Fatal("No results are set for the DigestHolderMock.SignWith")
 		}
-		return (*results).s1
+		return (*results).s1, (*results).err
 	}
 	if mmSignWith.funcSignWith != nil {
 		return mmSignWith.funcSignWith(signer)
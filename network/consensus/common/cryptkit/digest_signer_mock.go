@@ -20,7 +20,7 @@ type DigestSignerMock struct {
 	beforeGetSignMethodCounter uint64
 	GetSignMethodMock          mDigestSignerMockGetSignMethod
 
-	funcSignDigest          func(digest Digest) (s1 Signature)
+	funcSignDigest          func(digest Digest) (s1 Signature, err error)
 	inspectFuncSignDigest   func(digest Digest)
 	afterSignDigestCounter  uint64
 	beforeSignDigestCounter uint64
@@ -209,7 +209,8 @@ type DigestSignerMockSignDigestParams struct {
 
 // DigestSignerMockSignDigestResults contains results of the DigestSigner.SignDigest
 type DigestSignerMockSignDigestResults struct {
-	s1 Signature
+	s1  Signature
+	err error
 }
 
 // Expect sets up expected params for DigestSigner.SignDigest
@@ -244,7 +245,7 @@ func (mmSignDigest *mDigestSignerMockSignDigest) Inspect(f func(digest Digest))
 }
 
 // Return sets up results that will be returned by DigestSigner.SignDigest
-func (mmSignDigest *mDigestSignerMockSignDigest) Return(s1 Signature) *DigestSignerMock {
+func (mmSignDigest *mDigestSignerMockSignDigest) Return(s1 Signature, err error) *DigestSignerMock {
 	if mmSignDigest.mock.funcSignDigest != nil {
 		mmSignDigest.mock.t.Fatalf("DigestSignerMock.SignDigest mock is already set by Set")
 	}
@@ -252,12 +253,12 @@ func (mmSignDigest *mDigestSignerMockSignDigest) Return(s1 Signature) *DigestSig
 	if mmSignDigest.defaultExpectation == nil {
 		mmSignDigest.defaultExpectation = &DigestSignerMockSignDigestExpectation{mock: mmSignDigest.mock}
 	}
-	mmSignDigest.defaultExpectation.results = &DigestSignerMockSignDigestResults{s1}
+	mmSignDigest.defaultExpectation.results = &DigestSignerMockSignDigestResults{s1, err}
 	return mmSignDigest.mock
 }
 
 //Set uses given function f to mock the DigestSigner.SignDigest method
-func (mmSignDigest *mDigestSignerMockSignDigest) Set(f func(digest Digest) (s1 Signature)) *DigestSignerMock {
+func (mmSignDigest *mDigestSignerMockSignDigest) Set(f func(digest Digest) (s1 Signature, err error)) *DigestSignerMock {
 	if mmSignDigest.defaultExpectation != nil {
 		mmSignDigest.mock.t.Fatalf("Default expectation is already set for the DigestSigner.SignDigest method")
 	}
@@ -286,13 +287,13 @@ func (mmSignDigest *mDigestSignerMockSignDigest) When(digest Digest) *DigestSign
 }
 
 // Then sets up DigestSigner.SignDigest return parameters for the expectation previously defined by the When method
-func (e *DigestSignerMockSignDigestExpectation) Then(s1 Signature) *DigestSignerMock {
-	e.results = &DigestSignerMockSignDigestResults{s1}
+func (e *DigestSignerMockSignDigestExpectation) Then(s1 Signature, err error) *DigestSignerMock {
+	e.results = &DigestSignerMockSignDigestResults{s1, err}
 	return e.mock
 }
 
 // SignDigest implements DigestSigner
-func (mmSignDigest *DigestSignerMock) SignDigest(digest Digest) (s1 Signature) {
+func (mmSignDigest *DigestSignerMock) SignDigest(digest Digest) (s1 Signature, err error) {
 	mm_atomic.AddUint64(&mmSignDigest.beforeSignDigestCounter, 1)
 	defer mm_atomic.AddUint64(&mmSignDigest.afterSignDigestCounter, 1)
 
@@ -310,7 +311,7 @@ func (mmSignDigest *DigestSignerMock) SignDigest(digest Digest) (s1 Signature) {
 	for _, e := range mmSignDigest.SignDigestMock.expectations {
 		if minimock.Equal(e.params, params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.s1
+			return e.results.s1, e.results.err
 		}
 	}
 
@@ -326,7 +327,7 @@ func (mmSignDigest *DigestSignerMock) SignDigest(digest Digest) (s1 Signature) {
 		if results == nil {
 			mmSignDigest.t.Fatal("No results are set for the DigestSignerMock.SignDigest")
 		}
-		return (*results).s1
+		return (*results).s1, (*results).err
 	}
 	if mmSignDigest.funcSignDigest != nil {
 		return mmSignDigest.funcSignDigest(digest)
@@ -84,7 +84,7 @@ type DigestFactory interface {
 
 type DigestHolder interface {
 	longbits.FoldableReader
-	SignWith(signer DigestSigner) SignedDigestHolder
+	SignWith(signer DigestSigner) (SignedDigestHolder, error)
 	CopyOfDigest() Digest
 	GetDigestMethod() DigestMethod
 	Equals(other DigestHolder) bool
@@ -137,7 +137,7 @@ type CertificateHolder interface {
 //go:generate minimock -i github.com/insolar/insolar/network/consensus/common/cryptkit.DigestSigner -o . -s _mock.go -g
 
 type DigestSigner interface {
-	SignDigest(digest Digest) Signature
+	SignDigest(digest Digest) (Signature, error)
 	GetSignMethod() SignMethod
 }
 
@@ -270,9 +270,13 @@ func (d *Digest) GetDigestMethod() DigestMethod {
 	return d.digestMethod
 }
 
-func (d *Digest) SignWith(signer DigestSigner) SignedDigestHolder {
-	sd := NewSignedDigest(*d, signer.SignDigest(*d))
-	return &sd
+func (d *Digest) SignWith(signer DigestSigner) (SignedDigestHolder, error) {
+	signature, err := signer.SignDigest(*d)
+	if err != nil {
+		return nil, err
+	}
+	sd := NewSignedDigest(*d, signature)
+	return &sd, nil
 }
 
 func (d Digest) String() string {
@@ -57,6 +57,7 @@ import (
 	"testing"
 
 	"github.com/insolar/insolar/longbits"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -172,10 +173,17 @@ func TestNewDigest(t *testing.T) {
 func TestSignWith(t *testing.T) {
 	ds := NewDigestSignerMock(t)
 	sm := SignatureMethod("test")
-	ds.SignDigestMock.Set(func(Digest) Signature { return Signature{signatureMethod: sm} })
+	ds.SignDigestMock.Set(func(Digest) (Signature, error) { return Signature{signatureMethod: sm}, nil })
 	d := &Digest{}
-	sd := d.SignWith(ds)
+	sd, err := d.SignWith(ds)
+	require.NoError(t, err)
 	require.Equal(t, sm, sd.GetSignatureMethod())
+
+	ds = NewDigestSignerMock(t)
+	ds.SignDigestMock.Return(Signature{}, errors.New("signer is unavailable"))
+	sd, err = d.SignWith(ds)
+	require.Error(t, err)
+	require.Nil(t, sd)
 }
 
 func TestDigestString(t *testing.T) {
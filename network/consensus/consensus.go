@@ -120,6 +120,8 @@ type Dep struct {
 	PulseChanger        adapters.PulseChanger
 	StateUpdater        adapters.StateUpdater
 	EphemeralController adapters.EphemeralController
+	MisbehaviorJournal  adapters.MisbehaviorJournal
+	RevokedKeys         *adapters.RevokedKeys
 }
 
 func (cd *Dep) verify() {
@@ -154,7 +156,7 @@ func newConstructor(ctx context.Context, dep *Dep) *constructor {
 		).AsDigestHolder(),
 		c.consensusConfiguration,
 	)
-	c.misbehaviorRegistry = adapters.NewMisbehaviorRegistry()
+	c.misbehaviorRegistry = adapters.NewMisbehaviorRegistry(ctx, dep.MisbehaviorJournal)
 	c.offlinePopulation = adapters.NewOfflinePopulation(
 		dep.NodeKeeper,
 		dep.CertificateManager,
@@ -171,7 +173,7 @@ func newConstructor(ctx context.Context, dep *Dep) *constructor {
 		dep.KeyStore,
 	)
 	c.roundStrategyFactory = adapters.NewRoundStrategyFactory()
-	c.transportCryptographyFactory = adapters.NewTransportCryptographyFactory(dep.Scheme)
+	c.transportCryptographyFactory = adapters.NewTransportCryptographyFactory(dep.Scheme, dep.RevokedKeys)
 	c.packetBuilder = serialization.NewPacketBuilder(
 		c.transportCryptographyFactory,
 		c.localNodeConfiguration,
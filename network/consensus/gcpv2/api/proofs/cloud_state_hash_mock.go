@@ -65,7 +65,7 @@ type CloudStateHashMock struct {
 	beforeReadCounter uint64
 	ReadMock          mCloudStateHashMockRead
 
-	funcSignWith          func(signer cryptkit.DigestSigner) (s1 cryptkit.SignedDigestHolder)
+	funcSignWith          func(signer cryptkit.DigestSigner) (s1 cryptkit.SignedDigestHolder, err error)
 	inspectFuncSignWith   func(signer cryptkit.DigestSigner)
 	afterSignWithCounter  uint64
 	beforeSignWithCounter uint64
@@ -1425,7 +1425,8 @@ type CloudStateHashMockSignWithParams struct {
 
 // CloudStateHashMockSignWithResults contains results of the CloudStateHash.SignWith
 type CloudStateHashMockSignWithResults struct {
-	s1 cryptkit.SignedDigestHolder
+	s1  cryptkit.SignedDigestHolder
+	err error
 }
 
 // Expect sets up expected params for CloudStateHash.SignWith
@@ -1460,7 +1461,7 @@ func (mmSignWith *mCloudStateHashMockSignWith) Inspect(f func(signer cryptkit.Di
 }
 
 // Return sets up results that will be returned by CloudStateHash.SignWith
-func (mmSignWith *mCloudStateHashMockSignWith) Return(s1 cryptkit.SignedDigestHolder) *CloudStateHashMock {
+func (mmSignWith *mCloudStateHashMockSignWith) Return(s1 cryptkit.SignedDigestHolder, err error) *CloudStateHashMock {
 	if mmSignWith.mock.funcSignWith != nil {
 		mmSignWith.mock.t.Fatalf("CloudStateHashMock.SignWith mock is already set by Set")
 	}
@@ -1468,12 +1469,12 @@ func (mmSignWith *mCloudStateHashMockSignWith) Return(s1 cryptkit.SignedDigestHo
 	if mmSignWith.defaultExpectation == nil {
 		mmSignWith.defaultExpectation = &CloudStateHashMockSignWithExpectation{mock: mmSignWith.mock}
 	}
-	mmSignWith.defaultExpectation.results = &CloudStateHashMockSignWithResults{s1}
+	mmSignWith.defaultExpectation.results = &CloudStateHashMockSignWithResults{s1, err}
 	return mmSignWith.mock
 }
 
 //Set uses given function f to mock the CloudStateHash.SignWith method
-func (mmSignWith *mCloudStateHashMockSignWith) Set(f func(signer cryptkit.DigestSigner) (s1 cryptkit.SignedDigestHolder)) *CloudStateHashMock {
+func (mmSignWith *mCloudStateHashMockSignWith) Set(f func(signer cryptkit.DigestSigner) (s1 cryptkit.SignedDigestHolder, err error)) *CloudStateHashMock {
 	if mmSignWith.defaultExpectation != nil {
 		mmSignWith.mock.t.Fatalf("Default expectation is already set for the CloudStateHash.SignWith method")
 	}
@@ -1502,13 +1503,13 @@ func (mmSignWith *mCloudStateHashMockSignWith) When(signer cryptkit.DigestSigner
 }
 
 // Then sets up CloudStateHash.SignWith return parameters for the expectation previously defined by the When method
-func (e *CloudStateHashMockSignWithExpectation) Then(s1 cryptkit.SignedDigestHolder) *CloudStateHashMock {
-	e.results = &CloudStateHashMockSignWithResults{s1}
+func (e *CloudStateHashMockSignWithExpectation) Then(s1 cryptkit.SignedDigestHolder, err error) *CloudStateHashMock {
+	e.results = &CloudStateHashMockSignWithResults{s1, err}
 	return e.mock
 }
 
 // SignWith implements CloudStateHash
-func (mmSignWith *CloudStateHashMock) SignWith(signer cryptkit.DigestSigner) (s1 cryptkit.SignedDigestHolder) {
+func (mmSignWith *CloudStateHashMock) SignWith(signer cryptkit.DigestSigner) (s1 cryptkit.SignedDigestHolder, err error) {
 	mm_atomic.AddUint64(&mmSignWith.beforeSignWithCounter, 1)
 	defer mm_atomic.AddUint64(&mmSignWith.afterSignWithCounter, 1)
 
@@ -1526,7 +1527,7 @@ func (mmSignWith *CloudStateHashMock) SignWith(signer cryptkit.DigestSigner) (s1
 	for _, e := range mmSignWith.SignWithMock.expectations {
 		if minimock.Equal(e.params, params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.s1
+			return e.results.s1, e.results.err
 		}
 	}
 
@@ -1542,7 +1543,7 @@ func (mmSignWith *CloudStateHashMock) SignWith(signer cryptkit.DigestSigner) (s1
 		if results == nil {
 			mmSignWith.t.Fatal("No results are set for the CloudStateHashMock.SignWith")
 		}
-		return (*results).s1
+		return (*results).s1, (*results).err
 	}
 	if mmSignWith.funcSignWith != nil {
 		return mmSignWith.funcSignWith(signer)
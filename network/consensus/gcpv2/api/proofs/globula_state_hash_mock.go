@@ -65,7 +65,7 @@ type GlobulaStateHashMock struct {
 	beforeReadCounter uint64
 	ReadMock          mGlobulaStateHashMockRead
 
-	funcSignWith          func(signer cryptkit.DigestSigner) (s1 cryptkit.SignedDigestHolder)
+	funcSignWith          func(signer cryptkit.DigestSigner) (s1 cryptkit.SignedDigestHolder, err error)
 	inspectFuncSignWith   func(signer cryptkit.DigestSigner)
 	afterSignWithCounter  uint64
 	beforeSignWithCounter uint64
@@ -1425,7 +1425,8 @@ type GlobulaStateHashMockSignWithParams struct {
 
 // GlobulaStateHashMockSignWithResults contains results of the GlobulaStateHash.SignWith
 type GlobulaStateHashMockSignWithResults struct {
-	s1 cryptkit.SignedDigestHolder
+	s1  cryptkit.SignedDigestHolder
+	err error
 }
 
 // Expect sets up expected params for GlobulaStateHash.SignWith
@@ -1460,7 +1461,7 @@ func (mmSignWith *mGlobulaStateHashMockSignWith) Inspect(f func(signer cryptkit.
 }
 
 // Return sets up results that will be returned by GlobulaStateHash.SignWith
-func (mmSignWith *mGlobulaStateHashMockSignWith) Return(s1 cryptkit.SignedDigestHolder) *GlobulaStateHashMock {
+func (mmSignWith *mGlobulaStateHashMockSignWith) Return(s1 cryptkit.SignedDigestHolder, err error) *GlobulaStateHashMock {
 	if mmSignWith.mock.funcSignWith != nil {
 		mmSignWith.mock.t.Fatalf("GlobulaStateHashMock.SignWith mock is already set by Set")
 	}
@@ -1468,12 +1469,12 @@ func (mmSignWith *mGlobulaStateHashMockSignWith) Return(s1 cryptkit.SignedDigest
 	if mmSignWith.defaultExpectation == nil {
 		mmSignWith.defaultExpectation = &GlobulaStateHashMockSignWithExpectation{mock: mmSignWith.mock}
 	}
-	mmSignWith.defaultExpectation.results = &GlobulaStateHashMockSignWithResults{s1}
+	mmSignWith.defaultExpectation.results = &GlobulaStateHashMockSignWithResults{s1, err}
 	return mmSignWith.mock
 }
 
 //Set uses given function f to mock the GlobulaStateHash.SignWith method
-func (mmSignWith *mGlobulaStateHashMockSignWith) Set(f func(signer cryptkit.DigestSigner) (s1 cryptkit.SignedDigestHolder)) *GlobulaStateHashMock {
+func (mmSignWith *mGlobulaStateHashMockSignWith) Set(f func(signer cryptkit.DigestSigner) (s1 cryptkit.SignedDigestHolder, err error)) *GlobulaStateHashMock {
 	if mmSignWith.defaultExpectation != nil {
 		mmSignWith.mock.t.Fatalf("Default expectation is already set for the GlobulaStateHash.SignWith method")
 	}
@@ -1502,13 +1503,13 @@ func (mmSignWith *mGlobulaStateHashMockSignWith) When(signer cryptkit.DigestSign
 }
 
 // Then sets up GlobulaStateHash.SignWith return parameters for the expectation previously defined by the When method
-func (e *GlobulaStateHashMockSignWithExpectation) Then(s1 cryptkit.SignedDigestHolder) *GlobulaStateHashMock {
-	e.results = &GlobulaStateHashMockSignWithResults{s1}
+func (e *GlobulaStateHashMockSignWithExpectation) Then(s1 cryptkit.SignedDigestHolder, err error) *GlobulaStateHashMock {
+	e.results = &GlobulaStateHashMockSignWithResults{s1, err}
 	return e.mock
 }
 
 // SignWith implements GlobulaStateHash
-func (mmSignWith *GlobulaStateHashMock) SignWith(signer cryptkit.DigestSigner) (s1 cryptkit.SignedDigestHolder) {
+func (mmSignWith *GlobulaStateHashMock) SignWith(signer cryptkit.DigestSigner) (s1 cryptkit.SignedDigestHolder, err error) {
 	mm_atomic.AddUint64(&mmSignWith.beforeSignWithCounter, 1)
 	defer mm_atomic.AddUint64(&mmSignWith.afterSignWithCounter, 1)
 
@@ -1526,7 +1527,7 @@ func (mmSignWith *GlobulaStateHashMock) SignWith(signer cryptkit.DigestSigner) (
 	for _, e := range mmSignWith.SignWithMock.expectations {
 		if minimock.Equal(e.params, params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.s1
+			return e.results.s1, e.results.err
 		}
 	}
 
@@ -1542,7 +1543,7 @@ func (mmSignWith *GlobulaStateHashMock) SignWith(signer cryptkit.DigestSigner) (
 		if results == nil {
 			mmSignWith.t.Fatal("No results are set for the GlobulaStateHashMock.SignWith")
 		}
-		return (*results).s1
+		return (*results).s1, (*results).err
 	}
 	if mmSignWith.funcSignWith != nil {
 		return mmSignWith.funcSignWith(signer)
@@ -65,7 +65,7 @@ type NodeStateHashMock struct {
 	beforeReadCounter uint64
 	ReadMock          mNodeStateHashMockRead
 
-	funcSignWith          func(signer cryptkit.DigestSigner) (s1 cryptkit.SignedDigestHolder)
+	funcSignWith          func(signer cryptkit.DigestSigner) (s1 cryptkit.SignedDigestHolder, err error)
 	inspectFuncSignWith   func(signer cryptkit.DigestSigner)
 	afterSignWithCounter  uint64
 	beforeSignWithCounter uint64
@@ -1425,7 +1425,8 @@ type NodeStateHashMockSignWithParams struct {
 
 // NodeStateHashMockSignWithResults contains results of the NodeStateHash.SignWith
 type NodeStateHashMockSignWithResults struct {
-	s1 cryptkit.SignedDigestHolder
+	s1  cryptkit.SignedDigestHolder
+	err error
 }
 
 // Expect sets up expected params for NodeStateHash.SignWith
@@ -1460,7 +1461,7 @@ func (mmSignWith *mNodeStateHashMockSignWith) Inspect(f func(signer cryptkit.Dig
 }
 
 // Return sets up results that will be returned by NodeStateHash.SignWith
-func (mmSignWith *mNodeStateHashMockSignWith) Return(s1 cryptkit.SignedDigestHolder) *NodeStateHashMock {
+func (mmSignWith *mNodeStateHashMockSignWith) Return(s1 cryptkit.SignedDigestHolder, err error) *NodeStateHashMock {
 	if mmSignWith.mock.funcSignWith != nil {
 		mmSignWith.mock.t.Fatalf("NodeStateHashMock.SignWith mock is already set by Set")
 	}
@@ -1468,12 +1469,12 @@ func (mmSignWith *mNodeStateHashMockSignWith) Return(s1 cryptkit.SignedDigestHol
 	if mmSignWith.defaultExpectation == nil {
 		mmSignWith.defaultExpectation = &NodeStateHashMockSignWithExpectation{mock: mmSignWith.mock}
 	}
-	mmSignWith.defaultExpectation.results = &NodeStateHashMockSignWithResults{s1}
+	mmSignWith.defaultExpectation.results = &NodeStateHashMockSignWithResults{s1, err}
 	return mmSignWith.mock
 }
 
 //Set uses given function f to mock the NodeStateHash.SignWith method
-func (mmSignWith *mNodeStateHashMockSignWith) Set(f func(signer cryptkit.DigestSigner) (s1 cryptkit.SignedDigestHolder)) *NodeStateHashMock {
+func (mmSignWith *mNodeStateHashMockSignWith) Set(f func(signer cryptkit.DigestSigner) (s1 cryptkit.SignedDigestHolder, err error)) *NodeStateHashMock {
 	if mmSignWith.defaultExpectation != nil {
 		mmSignWith.mock.t.Fatalf("Default expectation is already set for the NodeStateHash.SignWith method")
 	}
@@ -1502,13 +1503,13 @@ func (mmSignWith *mNodeStateHashMockSignWith) When(signer cryptkit.DigestSigner)
 }
 
 // Then sets up NodeStateHash.SignWith return parameters for the expectation previously defined by the When method
-func (e *NodeStateHashMockSignWithExpectation) Then(s1 cryptkit.SignedDigestHolder) *NodeStateHashMock {
-	e.results = &NodeStateHashMockSignWithResults{s1}
+func (e *NodeStateHashMockSignWithExpectation) Then(s1 cryptkit.SignedDigestHolder, err error) *NodeStateHashMock {
+	e.results = &NodeStateHashMockSignWithResults{s1, err}
 	return e.mock
 }
 
 // SignWith implements NodeStateHash
-func (mmSignWith *NodeStateHashMock) SignWith(signer cryptkit.DigestSigner) (s1 cryptkit.SignedDigestHolder) {
+func (mmSignWith *NodeStateHashMock) SignWith(signer cryptkit.DigestSigner) (s1 cryptkit.SignedDigestHolder, err error) {
 	mm_atomic.AddUint64(&mmSignWith.beforeSignWithCounter, 1)
 	defer mm_atomic.AddUint64(&mmSignWith.afterSignWithCounter, 1)
 
@@ -1526,7 +1527,7 @@ func (mmSignWith *NodeStateHashMock) SignWith(signer cryptkit.DigestSigner) (s1
 	for _, e := range mmSignWith.SignWithMock.expectations {
 		if minimock.Equal(e.params, params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.s1
+			return e.results.s1, e.results.err
 		}
 	}
 
@@ -1542,7 +1543,7 @@ func (mmSignWith *NodeStateHashMock) SignWith(signer cryptkit.DigestSigner) (s1
 		if results == nil {
 			mmSignWith.t.Fatal("No results are set for the NodeStateHashMock.SignWith")
 		}
-		return (*results).s1
+		return (*results).s1, (*results).err
 	}
 	if mmSignWith.funcSignWith != nil {
 		return mmSignWith.funcSignWith(signer)
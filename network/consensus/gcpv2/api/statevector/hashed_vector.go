@@ -102,11 +102,15 @@ type CalcSubVector struct {
 	CalcStateWithRank
 }
 
-func (v *CalcSubVector) Sign(signer cryptkit.DigestSigner) SubVector {
+func (v *CalcSubVector) Sign(signer cryptkit.DigestSigner) (SubVector, error) {
+	signed, err := v.CalcStateWithRank.Sign(signer)
+	if err != nil {
+		return SubVector{}, err
+	}
 	return SubVector{v.AnnouncementHash,
-		v.CalcStateWithRank.Sign(signer),
+		signed,
 		v.StateHash,
-	}
+	}, nil
 }
 
 type CalcStateWithRank struct {
@@ -114,9 +118,13 @@ type CalcStateWithRank struct {
 	ExpectedRank member.Rank
 }
 
-func (v *CalcStateWithRank) Sign(signer cryptkit.DigestSigner) StateWithRank {
+func (v *CalcStateWithRank) Sign(signer cryptkit.DigestSigner) (StateWithRank, error) {
 	if v.StateHash == nil {
-		return StateWithRank{}
+		return StateWithRank{}, nil
+	}
+	signed, err := v.StateHash.SignWith(signer)
+	if err != nil {
+		return StateWithRank{}, err
 	}
-	return StateWithRank{v.StateHash.SignWith(signer).GetSignatureHolder(), v.ExpectedRank}
+	return StateWithRank{signed.GetSignatureHolder(), v.ExpectedRank}, nil
 }
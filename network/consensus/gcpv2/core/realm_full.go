@@ -492,7 +492,7 @@ func (r *FullRealm) IsLocalStateful() bool {
 	return r.self.IsStateful()
 }
 
-func (r *FullRealm) ApplyLocalState(nsh proofs.NodeStateHash) bool {
+func (r *FullRealm) ApplyLocalState(nsh proofs.NodeStateHash) (bool, error) {
 
 	if (nsh == nil) == r.IsLocalStateful() {
 		panic("illegal value")
@@ -502,7 +502,10 @@ func (r *FullRealm) ApplyLocalState(nsh proofs.NodeStateHash) bool {
 	ma := r.buildLocalMemberAnnouncementDraft(mp)
 
 	if nsh != nil {
-		v := nsh.SignWith(r.signer)
+		v, err := nsh.SignWith(r.signer)
+		if err != nil {
+			return false, err
+		}
 		ma.Membership.StateEvidence = v
 		ma.Membership.AnnounceSignature = v.GetSignatureHolder()
 	} else {
@@ -516,7 +519,7 @@ func (r *FullRealm) ApplyLocalState(nsh proofs.NodeStateHash) bool {
 
 	// TODO Hack! MUST provide announcement hash
 
-	return r.self.SetLocalNodeState(ma)
+	return r.self.SetLocalNodeState(ma), nil
 }
 
 func (r *FullRealm) buildLocalMemberAnnouncementDraft(mp profiles.MembershipProfile) profiles.MemberAnnouncement {
@@ -78,7 +78,7 @@ func (*bypassVectorInspector) PrepareForInspection(ctx context.Context) bool {
 	panic("illegal state")
 }
 
-func (*bypassVectorInspector) CreateVector(cryptkit.DigestSigner) statevector.Vector {
+func (*bypassVectorInspector) CreateVector(cryptkit.DigestSigner) (statevector.Vector, error) {
 	panic("illegal state")
 }
 
@@ -81,7 +81,7 @@ type VectorInspector interface {
 	GetBitset() member.StateBitset
 	/* Must be called before any CreateVector or InspectVector, and before any parallel access */
 	PrepareForInspection(ctx context.Context) bool
-	CreateVector(cryptkit.DigestSigner) statevector.Vector
+	CreateVector(cryptkit.DigestSigner) (statevector.Vector, error)
 	InspectVector(ctx context.Context, sender *population.NodeAppearance, customOptions uint32, otherData statevector.Vector) InspectedVector
 	CreateNextPopulation(nodeset.ConsensusBitsetRow) ([]profiles.PopulationRank, proofs.CloudStateHash, proofs.GlobulaStateHash)
 }
@@ -167,7 +167,7 @@ func (p *vectorIgnorantInspectorImpl) PrepareForInspection(ctx context.Context)
 	return true
 }
 
-func (p *vectorIgnorantInspectorImpl) CreateVector(signer cryptkit.DigestSigner) statevector.Vector {
+func (p *vectorIgnorantInspectorImpl) CreateVector(signer cryptkit.DigestSigner) (statevector.Vector, error) {
 	panic("illegal state")
 }
 
@@ -302,9 +302,17 @@ func (p *vectorInspectorImpl) PrepareForInspection(ctx context.Context) bool {
 	return true
 }
 
-func (p *vectorInspectorImpl) CreateVector(signer cryptkit.DigestSigner) statevector.Vector {
+func (p *vectorInspectorImpl) CreateVector(signer cryptkit.DigestSigner) (statevector.Vector, error) {
 	p.ensureHashes()
-	return statevector.NewVector(p.GetBitset(), p.Trusted.Sign(signer), p.Doubted.Sign(signer))
+	trusted, err := p.Trusted.Sign(signer)
+	if err != nil {
+		return statevector.Vector{}, err
+	}
+	doubted, err := p.Doubted.Sign(signer)
+	if err != nil {
+		return statevector.Vector{}, err
+	}
+	return statevector.NewVector(p.GetBitset(), trusted, doubted), nil
 }
 
 func (p *vectorInspectorImpl) InspectVector(ctx context.Context, sender *population.NodeAppearance, customOptions uint32,
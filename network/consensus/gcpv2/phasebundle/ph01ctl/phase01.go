@@ -224,7 +224,12 @@ func (c *Phase01Controller) workerPhase01(ctx context.Context) {
 		inslogger.FromContext(ctx).Debugf(">>>>>>workerPhase01: NSH is empty: stateful=%v", c.R.IsLocalStateful())
 	}
 	inslogger.FromContext(ctx).Debugf(">>>>>>workerPhase01: before NSH update: nsh=%v, self=%+v", nsh, c.R.GetSelf())
-	updated := c.R.ApplyLocalState(nsh)
+	updated, err := c.R.ApplyLocalState(nsh)
+	if err != nil {
+		// Phase1 isn't sent without signed state, other nodes will consider us as a failed one
+		inslogger.FromContext(ctx).Error(">>>>>>workerPhase01: failed to sign local state: ", err)
+		return
+	}
 	inslogger.FromContext(ctx).Debugf(">>>>>>workerPhase01: after NSH update: updated=%v, nsh=%v, self=%+v", updated, nsh, c.R.GetSelf())
 
 	go c.workerSendPhase1ToFixed(ctx, startIndex, nodes)
@@ -202,9 +202,14 @@ func (c *Phase3Controller) workerPhase3(ctx context.Context) {
 
 	if !c.R.IsJoiner() {
 		// joiner has no vote in consensus, hence there is no reason to send Phase3 from it
-		localHashedVector := localInspector.CreateVector(c.R.GetSigner())
-		inslogger.FromContext(ctx).Debugf(">>>>workerPhase3: calculated local vectors: %+v", localHashedVector)
-		go c.workerSendPhase3(ctx, localHashedVector)
+		localHashedVector, err := localInspector.CreateVector(c.R.GetSigner())
+		if err != nil {
+			// Phase3 isn't sent without signed vectors, other nodes will consider us as a failed one
+			inslogger.FromContext(ctx).Error(">>>>workerPhase3: failed to sign local vectors: ", err)
+		} else {
+			inslogger.FromContext(ctx).Debugf(">>>>workerPhase3: calculated local vectors: %+v", localHashedVector)
+			go c.workerSendPhase3(ctx, localHashedVector)
+		}
 	}
 
 	if !c.workerRecvPhase3(ctx, localInspector) {
@@ -230,7 +230,10 @@ func (ctx *serializeContext) Finalize() (int64, error) {
 
 	readerForSignature := bytes.NewReader(ctx.packetBuffer.Bytes())
 	digest := ctx.digester.GetDigestOf(readerForSignature)
-	signedDigest := digest.SignWith(ctx.signer)
+	signedDigest, err := digest.SignWith(ctx.signer)
+	if err != nil {
+		return totalWrite, ErrPacketSigning(err)
+	}
 	signature := signedDigest.GetSignatureHolder()
 	ctx.setter.setSignature(signature)
 
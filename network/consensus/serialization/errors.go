@@ -73,3 +73,7 @@ func ErrMalformedPacketBody(err error) error {
 func ErrMalformedPacketSignature(err error) error {
 	return errors.Wrap(err, "invalid packet signature")
 }
+
+func ErrPacketSigning(err error) error {
+	return errors.Wrap(err, "failed to sign packet")
+}
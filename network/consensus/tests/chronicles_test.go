@@ -123,7 +123,7 @@ type EmuVersionedRegistries struct {
 }
 
 func (c *EmuVersionedRegistries) GetNearestValidPulseData() pulse.Data {
-	panic("implement me")
+	return c.pd
 }
 
 func (c *EmuVersionedRegistries) GetCloudIdentity() cryptkit.DigestHolder {
@@ -82,6 +82,13 @@ type EmuHostConsensusAdapter struct {
 	hostAddr endpoints.Name
 	inbound  <-chan Packet
 	outbound chan<- Packet
+
+	failureHandler func(hostAddr endpoints.Name, failure interface{})
+}
+
+// SetFailureHandler makes the host to stop on panic and to report it with the handler instead of crashing the process.
+func (h *EmuHostConsensusAdapter) SetFailureHandler(handler func(hostAddr endpoints.Name, failure interface{})) {
+	h.failureHandler = handler
 }
 
 func (h *EmuHostConsensusAdapter) ConnectTo(chronicles api.ConsensusChronicles, network *EmuNetwork,
@@ -106,8 +113,12 @@ func (h *EmuHostConsensusAdapter) ConnectTo(chronicles api.ConsensusChronicles,
 
 func (h *EmuHostConsensusAdapter) run(ctx context.Context) {
 	defer func() {
-		// r := recover()
-		// inslogger.FromContext(ctx).Errorf("host has died: %v, %v", h.hostAddr, r)
+		if h.failureHandler != nil {
+			if r := recover(); r != nil {
+				inslogger.FromContext(ctx).Errorf("host has died: %v, %v", h.hostAddr, r)
+				h.failureHandler(h.hostAddr, r)
+			}
+		}
 		// TODO print stacktrace
 		close(h.outbound)
 	}()
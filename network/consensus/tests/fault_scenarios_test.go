@@ -0,0 +1,202 @@
+// +build slowtest
+
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/insolar/insolar/network/consensus/gcpv2/api/phases"
+)
+
+// 1 heavy, 3 light and 5 virtual nodes: H0000, L0001..L0003, V0004..V0008
+var scenarioNodes = generateNameList(0, 1, 3, 5)
+
+func excludeNames(names []string, excluded ...string) []string {
+	r := make([]string, 0, len(names))
+	for _, n := range names {
+		if !containsName(excluded, n) {
+			r = append(r, n)
+		}
+	}
+	return r
+}
+
+func TestFaultScenario_Baseline(t *testing.T) {
+	Scenario{
+		Seed:   1,
+		Nodes:  scenarioNodes,
+		Pulses: 5,
+		Expect: []Expectation{
+			ExpectLastPulse(),
+			ExpectAgreement(),
+			ExpectPopulation(scenarioNodes...),
+			ExpectMemberPower(242, scenarioNodes...),
+		},
+	}.Run(t)
+}
+
+func TestFaultScenario_NoisyLinks(t *testing.T) {
+	Scenario{
+		Seed:   2,
+		Nodes:  scenarioNodes,
+		Pulses: 6,
+		Faults: []*Fault{
+			Loss(0.01),
+			Duplicate(0.1),
+			Reorder(50 * time.Millisecond).WithProbability(0.3),
+		},
+		Expect: []Expectation{
+			ExpectLastPulse(),
+			ExpectAgreement(),
+			ExpectPopulation(scenarioNodes...),
+		},
+	}.Run(t)
+}
+
+func TestFaultScenario_MinorityPartition(t *testing.T) {
+	minority := []string{"H0000", "L0001"}
+	majority := excludeNames(scenarioNodes, minority...)
+
+	Scenario{
+		Seed:   3,
+		Nodes:  scenarioNodes,
+		Pulses: 6,
+		Faults: []*Fault{
+			Partition(minority, majority).AtPulses(2, 3),
+		},
+		// a node that has lost the majority becomes suspended, consensus doesn't support it yet
+		MayFail: minority,
+		Expect: []Expectation{
+			ExpectLastPulse().On(majority...),
+			ExpectAgreement().On(majority...),
+			ExpectPopulation(majority...).On(majority...),
+		},
+	}.Run(t)
+}
+
+func TestFaultScenario_AsymmetricLink(t *testing.T) {
+	Scenario{
+		Seed:   6,
+		Nodes:  scenarioNodes,
+		Pulses: 6,
+		Faults: []*Fault{
+			Loss(1).From("V0005").To("V0006").Named("one-way link"),
+		},
+		// V0006 misses packets of V0005 and gets evicted, though with other seeds (i.e. other pulsar targets)
+		// it can survive
+		MayFail: []string{"V0006"},
+		Expect: []Expectation{
+			ExpectLastPulse(),
+			ExpectAgreement(),
+			ExpectPopulation(excludeNames(scenarioNodes, "V0006")...),
+		},
+	}.Run(t)
+}
+
+func TestFaultScenario_CrashAndRestart(t *testing.T) {
+	others := excludeNames(scenarioNodes, "V0008")
+
+	Scenario{
+		Seed:   5,
+		Nodes:  scenarioNodes,
+		Pulses: 7,
+		Events: []NodeEvent{
+			Crash("V0008", 2),
+			Restart("V0008", 4),
+		},
+		// the restarted node has a priming census only and can't rejoin without the joining procedure
+		MayFail: []string{"V0008"},
+		Expect: []Expectation{
+			ExpectLastPulse().On(others...),
+			ExpectAgreement().On(others...),
+			ExpectPopulation(others...).On(others...),
+		},
+	}.Run(t)
+}
+
+func TestFaultScenario_ForgedMemberPower(t *testing.T) {
+	honest := excludeNames(scenarioNodes, "V0007")
+
+	Scenario{
+		Seed:   6,
+		Nodes:  scenarioNodes,
+		Pulses: 6,
+		Faults: []*Fault{
+			Mutate("forged power", ForgeMemberPower(1)).From("V0007").AtPulses(2, 5),
+		},
+		MayFail: []string{"V0007"},
+		Expect: []Expectation{
+			ExpectLastPulse().On(honest...),
+			ExpectAgreement().On(honest...),
+			ExpectPopulation(honest...).On(honest...),
+			ExpectMemberPower(242, honest...).On(honest...),
+		},
+	}.Run(t)
+}
+
+func TestFaultScenario_ForgedPulseRelay(t *testing.T) {
+	Scenario{
+		Seed:   7,
+		Nodes:  scenarioNodes,
+		Pulses: 6,
+		Faults: []*Fault{
+			Mutate("forged entropy", ForgePulseEntropy()).From("V0007").InPhases(phases.PacketPhase1).AtPulses(2, 2),
+		},
+		Expect: []Expectation{
+			ExpectLastPulse(),
+			ExpectAgreement(),
+			ExpectPopulation(scenarioNodes...),
+		},
+	}.Run(t)
+}
@@ -0,0 +1,483 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package tests
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/network/consensus/common/endpoints"
+	"github.com/insolar/insolar/network/consensus/gcpv2/api/member"
+	"github.com/insolar/insolar/network/consensus/gcpv2/api/phases"
+	"github.com/insolar/insolar/network/consensus/gcpv2/api/transport"
+	"github.com/insolar/insolar/pulse"
+)
+
+type faultAction uint8
+
+const (
+	faultDrop faultAction = iota
+	faultDuplicate
+	faultDelay
+	faultMutate
+)
+
+func (a faultAction) String() string {
+	switch a {
+	case faultDrop:
+		return "drop"
+	case faultDuplicate:
+		return "duplicate"
+	case faultDelay:
+		return "delay"
+	case faultMutate:
+		return "mutate"
+	}
+	return fmt.Sprintf("action(%d)", uint8(a))
+}
+
+// PacketMutator returns a modified copy of a packet. It must not change the given packet as it can be shared
+// between recipients.
+type PacketMutator func(packet transport.PacketParser) transport.PacketParser
+
+// Fault describes a misbehavior of emulated links. A fault applies to packets that match all of its restrictions,
+// unrestricted fields match everything.
+type Fault struct {
+	name        string
+	action      faultAction
+	probability float64
+	maxDelay    time.Duration
+	mutator     PacketMutator
+
+	from       map[endpoints.Name]struct{}
+	to         map[endpoints.Name]struct{}
+	symmetric  bool
+	firstPulse int
+	lastPulse  int
+	phases     []phases.PacketType
+}
+
+func newFault(action faultAction, name string) *Fault {
+	return &Fault{name: name, action: action, probability: 1, firstPulse: 0, lastPulse: math.MaxInt32}
+}
+
+// Loss drops packets with the given probability.
+func Loss(probability float64) *Fault {
+	return newFault(faultDrop, "loss").WithProbability(probability)
+}
+
+// Duplicate delivers an extra copy of packets with the given probability.
+func Duplicate(probability float64) *Fault {
+	return newFault(faultDuplicate, "duplicate").WithProbability(probability)
+}
+
+// Reorder delays packets by a random duration up to maxDelay, so packets sent later can overtake them.
+func Reorder(maxDelay time.Duration) *Fault {
+	f := newFault(faultDelay, "reorder")
+	f.maxDelay = maxDelay
+	return f
+}
+
+// Mutate replaces packets with a result of the mutator, i.e. emulates a byzantine sender.
+func Mutate(name string, mutator PacketMutator) *Fault {
+	f := newFault(faultMutate, name)
+	f.mutator = mutator
+	return f
+}
+
+// Partition drops all packets between hosts of the given groups in both directions.
+func Partition(groupA []string, groupB []string) *Fault {
+	f := Loss(1).From(groupA...).To(groupB...)
+	f.name = "partition"
+	f.symmetric = true
+	return f
+}
+
+// Named overrides a name of the fault that is used in reports.
+func (f *Fault) Named(name string) *Fault {
+	f.name = name
+	return f
+}
+
+// From restricts the fault to packets sent by the given hosts.
+func (f *Fault) From(hosts ...string) *Fault {
+	f.from = hostSet(f.from, hosts)
+	return f
+}
+
+// To restricts the fault to packets received by the given hosts.
+func (f *Fault) To(hosts ...string) *Fault {
+	f.to = hostSet(f.to, hosts)
+	return f
+}
+
+// AtPulses restricts the fault to packets of pulses with indexes in [first, last]. The first pulse of a scenario has index 0.
+func (f *Fault) AtPulses(first, last int) *Fault {
+	if first < 0 || last < first {
+		panic("illegal pulse range")
+	}
+	f.firstPulse = first
+	f.lastPulse = last
+	return f
+}
+
+// InPhases restricts the fault to packets of the given types. Request, extended and fast packets are matched
+// by their base phase, i.e. PacketPhase1 also matches PacketReqPhase1.
+func (f *Fault) InPhases(types ...phases.PacketType) *Fault {
+	f.phases = append(f.phases, types...)
+	return f
+}
+
+// WithProbability sets a chance for a matched packet to be affected.
+func (f *Fault) WithProbability(probability float64) *Fault {
+	if probability < 0 || probability > 1 {
+		panic("probability must be in [0, 1]")
+	}
+	f.probability = probability
+	return f
+}
+
+func (f *Fault) String() string {
+	return fmt.Sprintf("%s(%v p=%.2f pulses=[%d,%d])", f.name, f.action, f.probability, f.firstPulse, f.lastPulse)
+}
+
+func hostSet(set map[endpoints.Name]struct{}, hosts []string) map[endpoints.Name]struct{} {
+	if set == nil {
+		set = make(map[endpoints.Name]struct{}, len(hosts))
+	}
+	for _, h := range hosts {
+		set[endpoints.Name(h)] = struct{}{}
+	}
+	return set
+}
+
+func hostMatches(set map[endpoints.Name]struct{}, host endpoints.Name) bool {
+	if set == nil {
+		return true
+	}
+	_, ok := set[host]
+	return ok
+}
+
+func (f *Fault) matchesLink(from, to endpoints.Name) bool {
+	if hostMatches(f.from, from) && hostMatches(f.to, to) {
+		return true
+	}
+	return f.symmetric && hostMatches(f.from, to) && hostMatches(f.to, from)
+}
+
+func (f *Fault) matches(p *packetInfo) bool {
+	if !f.matchesLink(p.from, p.to) {
+		return false
+	}
+
+	if f.firstPulse != 0 || f.lastPulse != math.MaxInt32 {
+		if p.pulseIndex < f.firstPulse || p.pulseIndex > f.lastPulse {
+			return false
+		}
+	}
+
+	if len(f.phases) == 0 {
+		return true
+	}
+	for _, pt := range f.phases {
+		if pt == p.packetType || pt == p.packetType.GetPayloadEquivalent() {
+			return true
+		}
+	}
+	return false
+}
+
+type packetInfo struct {
+	from, to   endpoints.Name
+	pn         pulse.Number
+	pulseIndex int
+	packetType phases.PacketType
+	seq        uint64
+}
+
+type linkKey struct {
+	from, to   endpoints.Name
+	pn         pulse.Number
+	packetType phases.PacketType
+}
+
+// FaultNetStrategy applies faults to packets on delivery. Decisions are derived from the seed and from packet
+// attributes (link, pulse, type and a sequence number of such packet on the link) instead of a shared random
+// source, hence a decision for a packet doesn't depend on how deliveries of other links are interleaved.
+type FaultNetStrategy struct {
+	seed       int64
+	firstPulse pulse.Number
+	pulseDelta uint16
+	faults     []*Fault
+	inner      NetStrategy
+
+	mutex   sync.Mutex
+	seqs    map[linkKey]uint64
+	applied []int
+}
+
+func NewFaultNetStrategy(seed int64, firstPulse pulse.Number, pulseDelta uint16, inner NetStrategy, faults ...*Fault) *FaultNetStrategy {
+	return &FaultNetStrategy{
+		seed:       seed,
+		firstPulse: firstPulse,
+		pulseDelta: pulseDelta,
+		faults:     faults,
+		inner:      inner,
+		seqs:       make(map[linkKey]uint64),
+		applied:    make([]int, len(faults)),
+	}
+}
+
+func (s *FaultNetStrategy) GetLinkStrategy(hostAddress endpoints.Name) LinkStrategy {
+	var inner LinkStrategy
+	if s.inner != nil {
+		inner = s.inner.GetLinkStrategy(hostAddress)
+	}
+	if inner == nil {
+		inner = stubLinkStrategyValue
+	}
+	return &faultLinkStrategy{host: hostAddress, strategy: s, inner: inner}
+}
+
+// AppliedCount returns how many times the fault has affected packets.
+func (s *FaultNetStrategy) AppliedCount(f *Fault) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for i, v := range s.faults {
+		if v == f {
+			return s.applied[i]
+		}
+	}
+	return 0
+}
+
+func (s *FaultNetStrategy) pulseIndex(pn pulse.Number) int {
+	if pn < s.firstPulse || s.pulseDelta == 0 {
+		return -1
+	}
+	return int(pn-s.firstPulse) / int(s.pulseDelta)
+}
+
+func (s *FaultNetStrategy) describe(from, to endpoints.Name, parser transport.PacketParser) *packetInfo {
+	p := &packetInfo{
+		from:       from,
+		to:         to,
+		pn:         parser.GetPulseNumber(),
+		packetType: parser.GetPacketType(),
+	}
+	p.pulseIndex = s.pulseIndex(p.pn)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	key := linkKey{from: from, to: to, pn: p.pn, packetType: p.packetType}
+	p.seq = s.seqs[key]
+	s.seqs[key] = p.seq + 1
+	return p
+}
+
+// roll returns a value in [0, 1) that is stable for the given fault, packet and salt.
+func (s *FaultNetStrategy) roll(faultIndex int, p *packetInfo, salt uint8) float64 {
+	h := fnv.New64a()
+	var buf [8]byte
+	writeUint := func(v uint64) {
+		binary.LittleEndian.PutUint64(buf[:], v)
+		_, _ = h.Write(buf[:])
+	}
+	writeUint(uint64(s.seed))
+	writeUint(uint64(faultIndex))
+	_, _ = h.Write([]byte(p.from))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(p.to))
+	writeUint(uint64(p.pn))
+	writeUint(uint64(p.packetType))
+	writeUint(p.seq)
+	_, _ = h.Write([]byte{salt})
+
+	return float64(h.Sum64()>>11) / (1 << 53)
+}
+
+func (s *FaultNetStrategy) markApplied(faultIndex int) {
+	s.mutex.Lock()
+	s.applied[faultIndex]++
+	s.mutex.Unlock()
+}
+
+func (s *FaultNetStrategy) apply(from, to endpoints.Name, packet *Packet, out PacketFunc) {
+	parser := UnwrapPacketParser(packet.Payload)
+	if parser == nil {
+		out(packet)
+		return
+	}
+
+	p := s.describe(from, to, parser)
+	copies := 1
+	delay := time.Duration(0)
+
+	for i, f := range s.faults {
+		if !f.matches(p) || s.roll(i, p, 0) >= f.probability {
+			continue
+		}
+		s.markApplied(i)
+
+		switch f.action {
+		case faultDrop:
+			return
+		case faultDuplicate:
+			copies++
+		case faultDelay:
+			delay += time.Duration(s.roll(i, p, 1) * float64(f.maxDelay))
+		case faultMutate:
+			parser = f.mutator(parser)
+			packet = &Packet{Host: packet.Host, Payload: WrapPacketParser(parser)}
+		}
+	}
+
+	deliver := func() {
+		for i := 0; i < copies; i++ {
+			cp := *packet
+			out(&cp)
+		}
+	}
+
+	if delay > 0 {
+		time.AfterFunc(delay, deliver)
+	} else {
+		deliver()
+	}
+}
+
+type faultLinkStrategy struct {
+	host     endpoints.Name
+	strategy *FaultNetStrategy
+	inner    LinkStrategy
+}
+
+func (l *faultLinkStrategy) BeforeSend(packet *Packet, out PacketFunc) {
+	l.inner.BeforeSend(packet, out)
+}
+
+// BeforeReceive applies faults as only here both ends of a link are known: packet.Host is the sender and
+// l.host is the receiver. Packets of pulsar are also delivered only through this path.
+func (l *faultLinkStrategy) BeforeReceive(packet *Packet, out PacketFunc) {
+	l.strategy.apply(packet.Host, l.host, packet, func(packet *Packet) {
+		l.inner.BeforeReceive(packet, out)
+	})
+}
+
+// ForgeMemberPower makes a sender to announce the given power in its membership profile.
+func ForgeMemberPower(power member.Power) PacketMutator {
+	return func(packet transport.PacketParser) transport.PacketParser {
+		return mutateBasePacket(packet, func(p *basePacket) {
+			p.mp.Power = power
+			p.mp.RequestedPower = power
+		})
+	}
+}
+
+// ForgePulseEntropy replaces entropy of pulse data that is carried by pulsar, Phase0 and Phase1 packets.
+func ForgePulseEntropy() PacketMutator {
+	forge := func(pp *EmuPulsarNetPacket) *EmuPulsarNetPacket {
+		pd := pp.pulseData
+		for i := range pd.PulseEntropy {
+			pd.PulseEntropy[i] ^= 0xFF
+		}
+		return &EmuPulsarNetPacket{pulseData: pd}
+	}
+
+	return func(packet transport.PacketParser) transport.PacketParser {
+		switch p := packet.(type) {
+		case *EmuPulsarNetPacket:
+			return forge(p)
+		case *EmuPhase0NetPacket:
+			if p.pulsePacket != nil {
+				c := *p
+				c.pulsePacket = forge(p.pulsePacket)
+				return &c
+			}
+		case *EmuPhase1NetPacket:
+			if p.pulsePacket != nil {
+				c := *p
+				c.pulsePacket = forge(p.pulsePacket)
+				return &c
+			}
+		}
+		return packet
+	}
+}
+
+func mutateBasePacket(packet transport.PacketParser, fn func(*basePacket)) transport.PacketParser {
+	switch p := packet.(type) {
+	case *EmuPhase0NetPacket:
+		c := *p
+		fn(&c.basePacket)
+		return &c
+	case *EmuPhase1NetPacket:
+		c := *p
+		fn(&c.basePacket)
+		return &c
+	case *EmuPhase2NetPacket:
+		c := *p
+		fn(&c.basePacket)
+		return &c
+	case *EmuPhase3NetPacket:
+		c := *p
+		fn(&c.basePacket)
+		return &c
+	}
+	return packet
+}
@@ -274,7 +274,9 @@ func (emuNet *EmuNetwork) getHostRoute(host endpoints.Name) *EmuRoute {
 func (emuNet *EmuNetwork) internalRemoveHost(route *EmuRoute) {
 	emuNet.hostsSync.Lock()
 	defer emuNet.hostsSync.Unlock()
-	delete(emuNet.hosts, route.host)
+	if emuNet.hosts[route.host] == route { // the host can be re-added after its route was closed
+		delete(emuNet.hosts, route.host)
+	}
 }
 
 func (emuRt *EmuRoute) run(ctx context.Context) {
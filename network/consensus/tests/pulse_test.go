@@ -79,3 +79,9 @@ func CreateGenerator(pulseCount int, pulseDelta uint16, output chan<- interface{
 		time.Sleep(time.Duration(pulseDelta) * time.Second)
 	}
 }
+
+func randBits256From(rnd *rand.Rand) longbits.Bits256 {
+	v := longbits.Bits256{}
+	_, _ = rnd.Read(v[:])
+	return v
+}
@@ -0,0 +1,489 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package tests
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/network/consensus/common/endpoints"
+	"github.com/insolar/insolar/network/consensus/gcpv2/api"
+	"github.com/insolar/insolar/network/consensus/gcpv2/api/member"
+	"github.com/insolar/insolar/network/consensus/gcpv2/api/profiles"
+	"github.com/insolar/insolar/network/consensus/gcpv2/core/coreapi"
+	"github.com/insolar/insolar/pulse"
+)
+
+const (
+	scenarioFirstPulse = pulse.Number(100000)
+	scenarioPulsar     = "pulsar0"
+)
+
+// Scenario is a scripted run of emulated consensus network. Faults and node events are bound to pulse indexes,
+// the first pulse of a scenario has index 0.
+//
+// All randomness of the emulation (pulse entropy, pulsar targets, fault decisions and the global rand used by
+// round and delay strategies) is derived from Seed. Goroutines of nodes are still scheduled by the runtime, so
+// a scenario is reproducible in terms of injected faults but not in terms of exact interleaving of packets.
+type Scenario struct {
+	Seed       int64
+	Nodes      []string
+	Pulses     int
+	PulseDelta uint16
+	Latency    *DelayStrategyConf
+	Faults     []*Fault
+	Events     []NodeEvent
+	Expect     []Expectation
+	// MayFail lists nodes that are allowed to fail, i.e. to panic on an unsupported state. Failure of other nodes
+	// fails the scenario.
+	MayFail []string
+}
+
+type nodeEventType uint8
+
+const (
+	nodeCrash nodeEventType = iota
+	nodeRestart
+)
+
+// NodeEvent changes a node before the pulse with the given index is sent.
+type NodeEvent struct {
+	eventType nodeEventType
+	node      string
+	pulse     int
+}
+
+// Crash disconnects the node from the network.
+func Crash(node string, atPulse int) NodeEvent {
+	return NodeEvent{eventType: nodeCrash, node: node, pulse: atPulse}
+}
+
+// Restart connects a crashed node back as if it was restarted from its initial configuration.
+func Restart(node string, atPulse int) NodeEvent {
+	return NodeEvent{eventType: nodeRestart, node: node, pulse: atPulse}
+}
+
+// NodeState is a view of the active census of a node at the end of a scenario.
+type NodeState struct {
+	Name       string
+	Pulse      pulse.Number
+	Population map[string]member.Power
+}
+
+func (s NodeState) members() string {
+	names := make([]string, 0, len(s.Population))
+	for n := range s.Population {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+type ScenarioResult struct {
+	LastPulse pulse.Number
+	// States contains nodes that were alive at the end of a scenario.
+	States map[string]NodeState
+	// Failures contains panics of failed nodes.
+	Failures map[string]string
+}
+
+// Expectation is a check of a scenario result. By default it is applied to all nodes alive at the end of
+// a scenario, use On to restrict observers.
+type Expectation struct {
+	description string
+	observers   []string
+	check       func(r *ScenarioResult, states []NodeState) error
+}
+
+// On restricts nodes whose census is checked.
+func (e Expectation) On(observers ...string) Expectation {
+	e.observers = observers
+	return e
+}
+
+func perNodeExpectation(description string, check func(r *ScenarioResult, s NodeState) error) Expectation {
+	return Expectation{
+		description: description,
+		check: func(r *ScenarioResult, states []NodeState) error {
+			for _, s := range states {
+				if err := check(r, s); err != nil {
+					return fmt.Errorf("node %s: %v", s.Name, err)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// ExpectLastPulse checks that observers have finished consensus of the last pulse of a scenario.
+func ExpectLastPulse() Expectation {
+	return perNodeExpectation("last pulse", func(r *ScenarioResult, s NodeState) error {
+		if s.Pulse != r.LastPulse {
+			return fmt.Errorf("active pulse %v, expected %v", s.Pulse, r.LastPulse)
+		}
+		return nil
+	})
+}
+
+// ExpectAgreement checks that observers have the same pulse and the same population with the same power of members.
+func ExpectAgreement() Expectation {
+	return Expectation{
+		description: "agreement",
+		check: func(r *ScenarioResult, states []NodeState) error {
+			for _, s := range states[1:] {
+				first := states[0]
+				if s.Pulse != first.Pulse {
+					return fmt.Errorf("node %s has pulse %v, node %s has pulse %v", s.Name, s.Pulse, first.Name, first.Pulse)
+				}
+				if len(s.Population) != len(first.Population) {
+					return fmt.Errorf("node %s has population [%s], node %s has population [%s]",
+						s.Name, s.members(), first.Name, first.members())
+				}
+				for n, pw := range first.Population {
+					if v, ok := s.Population[n]; !ok || v != pw {
+						return fmt.Errorf("node %s has member %s with power %v, node %s has %v", s.Name, n, v, first.Name, pw)
+					}
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// ExpectPopulation checks that the population of observers consists of exactly the given members.
+func ExpectPopulation(members ...string) Expectation {
+	expected := make([]string, len(members))
+	copy(expected, members)
+	sort.Strings(expected)
+
+	return perNodeExpectation("population", func(r *ScenarioResult, s NodeState) error {
+		if actual := s.members(); actual != strings.Join(expected, ",") {
+			return fmt.Errorf("population [%s], expected [%s]", actual, strings.Join(expected, ","))
+		}
+		return nil
+	})
+}
+
+// ExpectMemberPower checks that the given members are present in the population of observers with the given power.
+func ExpectMemberPower(power member.Power, members ...string) Expectation {
+	return perNodeExpectation("member power", func(r *ScenarioResult, s NodeState) error {
+		for _, m := range members {
+			v, ok := s.Population[m]
+			switch {
+			case !ok:
+				return fmt.Errorf("member %s is missing", m)
+			case v != power:
+				return fmt.Errorf("member %s has power %v, expected %v", m, v, power)
+			}
+		}
+		return nil
+	})
+}
+
+type scenarioNode struct {
+	intro      profiles.StaticProfile
+	index      int
+	chronicles api.ConsensusChronicles
+	alive      bool
+}
+
+type scenarioRunner struct {
+	Scenario
+	builder  emuNetworkBuilder
+	faults   *FaultNetStrategy
+	rnd      *rand.Rand
+	intros   []profiles.StaticProfile
+	nodes    map[string]*scenarioNode
+	nodeByID map[insolar.ShortNodeID]string
+
+	failureLock sync.Mutex
+	failures    map[string]string
+}
+
+// Run executes the scenario in real time and checks its expectations.
+func (s Scenario) Run(t *testing.T) *ScenarioResult {
+	if s.PulseDelta == 0 {
+		s.PulseDelta = 2
+	}
+	if s.Pulses == 0 {
+		s.Pulses = 5
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := inslogger.FromContext(ctx).WithCaller(false)
+	logger, _ = logger.WithLevelNumber(insolar.ErrorLevel)
+	ctx = inslogger.SetLogger(ctx, logger)
+
+	// round and delay strategies use the global source
+	rand.Seed(s.Seed)
+
+	r := &scenarioRunner{
+		Scenario: s,
+		rnd:      rand.New(rand.NewSource(s.Seed)),
+		intros:   NewEmuNodeIntros(s.Nodes...),
+		nodes:    make(map[string]*scenarioNode, len(s.Nodes)),
+		nodeByID: make(map[insolar.ShortNodeID]string, len(s.Nodes)),
+		failures: make(map[string]string),
+	}
+
+	var latency NetStrategy
+	if s.Latency != nil {
+		latency = NewDelayNetStrategy(*s.Latency)
+	}
+	r.faults = NewFaultNetStrategy(s.Seed, scenarioFirstPulse, s.PulseDelta, latency, s.Faults...)
+	r.builder = newEmuNetworkBuilder(ctx, r.faults, &EmuRoundStrategyFactory{})
+
+	for i, intro := range r.intros {
+		name := s.Nodes[i]
+		r.nodes[name] = &scenarioNode{intro: intro, index: i}
+		r.nodeByID[intro.GetStaticNodeID()] = name
+		r.connect(name)
+	}
+	r.builder.StartNetwork(ctx)
+
+	result := &ScenarioResult{LastPulse: r.runPulsar(t)}
+	result.States = r.collectStates()
+	result.Failures = r.getFailures()
+	for _, name := range r.aliveNodes() {
+		st := result.States[name]
+		t.Logf("%s: pulse=%v population=%v", name, st.Pulse, st.Population)
+	}
+
+	for name, failure := range result.Failures {
+		if !containsName(s.MayFail, name) {
+			t.Errorf("node %s has failed: %s", name, failure)
+		}
+	}
+
+	for i, f := range s.Faults {
+		if r.faults.AppliedCount(f) == 0 {
+			t.Errorf("fault #%d %v was never applied", i, f)
+		}
+	}
+
+	for _, e := range s.Expect {
+		states, err := r.observedStates(result, e.observers)
+		if err == nil {
+			err = e.check(result, states)
+		}
+		if err != nil {
+			t.Errorf("expectation %q failed: %v", e.description, err)
+		}
+	}
+
+	return result
+}
+
+func (r *scenarioRunner) connect(name string) {
+	n := r.nodes[name]
+	n.chronicles = NewEmuChronicles(r.intros, n.index, false, r.builder.primingCloudStateHash)
+	n.alive = true
+
+	r.failureLock.Lock()
+	delete(r.failures, name)
+	r.failureLock.Unlock()
+
+	host := NewConsensusHost(n.intro.GetDefaultEndpoint().GetNameAddress())
+	host.SetFailureHandler(r.onFailure)
+	host.ConnectTo(n.chronicles, r.builder.network, r.builder.strategyFactory,
+		&coreapi.SequentialCandidateFeeder{}, &EmuControlFeeder{}, nil, r.builder.config)
+}
+
+func (r *scenarioRunner) applyEvents(t *testing.T, pulseIndex int) {
+	for _, e := range r.Events {
+		if e.pulse != pulseIndex {
+			continue
+		}
+		n, ok := r.nodes[e.node]
+		if !ok {
+			t.Fatalf("unknown node in event: %s", e.node)
+		}
+
+		switch e.eventType {
+		case nodeCrash:
+			if !r.isAlive(e.node) {
+				t.Fatalf("node %s is already crashed", e.node)
+			}
+			n.alive = false
+			r.builder.network.DropHost(endpoints.Name(e.node))
+		case nodeRestart:
+			if r.isAlive(e.node) {
+				t.Fatalf("node %s is not crashed", e.node)
+			}
+			// route of a failed node can still be registered
+			r.builder.network.DropHost(endpoints.Name(e.node))
+			r.connect(e.node)
+		}
+	}
+}
+
+func (r *scenarioRunner) runPulsar(t *testing.T) pulse.Number {
+	pn := scenarioFirstPulse
+	attempts := 4 + len(r.Nodes)/10
+
+	for i := 0; i < r.Pulses; i++ {
+		r.applyEvents(t, i)
+
+		if i > 0 {
+			pn += pulse.Number(r.PulseDelta)
+		}
+		prevDelta := r.PulseDelta
+		if i == 0 {
+			prevDelta = 0
+		}
+
+		entropy := randBits256From(r.rnd)
+		payload := WrapPacketParser(&EmuPulsarNetPacket{
+			pulseData: pulse.NewPulsarData(pn, r.PulseDelta, prevDelta, entropy),
+		})
+
+		alive := r.aliveNodes()
+		for j := 0; j < attempts && len(alive) > 0; j++ {
+			sendTo := alive[r.rnd.Intn(len(alive))]
+			r.builder.network.SendToHost(endpoints.Name(sendTo), payload, scenarioPulsar)
+		}
+
+		time.Sleep(time.Duration(r.PulseDelta) * time.Second)
+	}
+	return pn
+}
+
+func (r *scenarioRunner) onFailure(hostAddr endpoints.Name, failure interface{}) {
+	r.failureLock.Lock()
+	defer r.failureLock.Unlock()
+
+	r.failures[string(hostAddr)] = fmt.Sprint(failure)
+}
+
+func (r *scenarioRunner) getFailures() map[string]string {
+	r.failureLock.Lock()
+	defer r.failureLock.Unlock()
+
+	failures := make(map[string]string, len(r.failures))
+	for k, v := range r.failures {
+		failures[k] = v
+	}
+	return failures
+}
+
+func (r *scenarioRunner) isAlive(name string) bool {
+	r.failureLock.Lock()
+	defer r.failureLock.Unlock()
+
+	_, failed := r.failures[name]
+	return r.nodes[name].alive && !failed
+}
+
+func (r *scenarioRunner) aliveNodes() []string {
+	alive := make([]string, 0, len(r.Nodes))
+	for _, name := range r.Nodes {
+		if r.isAlive(name) {
+			alive = append(alive, name)
+		}
+	}
+	return alive
+}
+
+func (r *scenarioRunner) collectStates() map[string]NodeState {
+	states := make(map[string]NodeState)
+	for _, name := range r.aliveNodes() {
+		active := r.nodes[name].chronicles.GetActiveCensus()
+		s := NodeState{Name: name, Pulse: active.GetPulseNumber(), Population: make(map[string]member.Power)}
+
+		for _, p := range active.GetOnlinePopulation().GetProfiles() {
+			memberName, ok := r.nodeByID[p.GetNodeID()]
+			if !ok {
+				memberName = fmt.Sprintf("#%d", p.GetNodeID())
+			}
+			s.Population[memberName] = p.GetDeclaredPower()
+		}
+		states[name] = s
+	}
+	return states
+}
+
+func (r *scenarioRunner) observedStates(result *ScenarioResult, observers []string) ([]NodeState, error) {
+	if len(observers) == 0 {
+		observers = r.aliveNodes()
+	}
+	if len(observers) == 0 {
+		return nil, fmt.Errorf("no nodes to observe")
+	}
+
+	states := make([]NodeState, 0, len(observers))
+	for _, name := range observers {
+		s, ok := result.States[name]
+		if !ok {
+			return nil, fmt.Errorf("node %s is not alive", name)
+		}
+		states = append(states, s)
+	}
+	return states, nil
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -333,8 +333,8 @@ func (r *emuTransportCryptography) IsValidDigestSignature(digest cryptkit.Digest
 	return true
 }
 
-func (r *emuTransportCryptography) SignDigest(digest cryptkit.Digest) cryptkit.Signature {
-	return cryptkit.NewSignature(digest, digest.GetDigestMethod().SignedBy(r.GetSignMethod()))
+func (r *emuTransportCryptography) SignDigest(digest cryptkit.Digest) (cryptkit.Signature, error) {
+	return cryptkit.NewSignature(digest, digest.GetDigestMethod().SignedBy(r.GetSignMethod())), nil
 }
 
 func (r *emuTransportCryptography) GetSignMethod() cryptkit.SignMethod {
@@ -113,7 +113,7 @@ func (r *EmuNodeStateHash) CopyOfDigest() cryptkit.Digest {
 	return cryptkit.NewDigest(&r.Bits64, r.GetDigestMethod())
 }
 
-func (r *EmuNodeStateHash) SignWith(signer cryptkit.DigestSigner) cryptkit.SignedDigestHolder {
+func (r *EmuNodeStateHash) SignWith(signer cryptkit.DigestSigner) (cryptkit.SignedDigestHolder, error) {
 	d := r.CopyOfDigest()
 	return d.SignWith(signer)
 }
@@ -52,6 +52,7 @@ package controller
 
 import (
 	"context"
+	"crypto"
 
 	"github.com/insolar/insolar/component"
 	"github.com/insolar/insolar/insolar"
@@ -74,6 +75,9 @@ type pulseController struct {
 	KeyProcessor        insolar.KeyProcessor               `inject:""`
 	CryptographyService insolar.CryptographyService        `inject:""`
 	Network             network.HostNetwork                `inject:""`
+	CertificateManager  insolar.CertificateManager         `inject:""`
+
+	options *network.Options
 }
 
 func (pc *pulseController) Init(ctx context.Context) error {
@@ -104,10 +108,9 @@ func (pc *pulseController) verifyPulseSign(pulse insolar.Pulse) error {
 	if len(pulse.Signs) == 0 {
 		return errors.New("received empty pulse signs")
 	}
-	hashProvider := pc.CryptographyScheme.IntegrityHasher()
 	for key, psc := range pulse.Signs {
 		payload := pulsar.PulseSenderConfirmationPayload{PulseSenderConfirmation: psc}
-		hash, err := payload.Hash(hashProvider)
+		hash, err := payload.Hash(pc.CryptographyScheme.IntegrityHasher())
 		if err != nil {
 			return errors.Wrap(err, "failed to get hash from pulse payload")
 		}
@@ -122,9 +125,66 @@ func (pc *pulseController) verifyPulseSign(pulse insolar.Pulse) error {
 			return errors.New("cryptographic signature verification failed")
 		}
 	}
+	return pc.verifyPulsarQuorum(pulse)
+}
+
+// verifyPulsarQuorum checks that pulse is confirmed by quorum of pulsars from certificate.
+// Without pulsar keys in certificate pulses are rejected unless TrustAnyPulsar option is set.
+// Signatures of confirmations must be verified before.
+func (pc *pulseController) verifyPulsarQuorum(pulse insolar.Pulse) error {
+	var keys []crypto.PublicKey
+	if pc.CertificateManager != nil {
+		keys = pc.CertificateManager.GetCertificate().GetPulsarPublicKeys()
+	}
+	if len(keys) == 0 {
+		if pc.options != nil && pc.options.TrustAnyPulsar {
+			return nil
+		}
+		return errors.New("no trusted pulsar keys in certificate")
+	}
+
+	trusted := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		pem, err := pc.KeyProcessor.ExportPublicKeyPEM(key)
+		if err != nil {
+			return errors.Wrap(err, "failed to export pulsar public key")
+		}
+		trusted[string(pem)] = struct{}{}
+	}
+
+	var chosen string
+	confirmed := 0
+	for key, psc := range pulse.Signs {
+		if psc.PulseNumber != pulse.PulseNumber || psc.Entropy != pulse.Entropy {
+			return errors.New("pulse confirmation doesn't match pulse")
+		}
+		if chosen == "" {
+			chosen = psc.ChosenPublicKey
+		}
+		if psc.ChosenPublicKey != chosen {
+			return errors.New("pulse confirmations choose different pulsars")
+		}
+
+		pk, err := pc.KeyProcessor.ImportPublicKeyPEM([]byte(key))
+		if err != nil {
+			return errors.Wrap(err, "failed to import public key")
+		}
+		pem, err := pc.KeyProcessor.ExportPublicKeyPEM(pk)
+		if err != nil {
+			return errors.Wrap(err, "failed to export public key")
+		}
+		if _, ok := trusted[string(pem)]; ok {
+			confirmed++
+		}
+	}
+
+	quorum := pulsar.FederationQuorum(len(keys))
+	if confirmed < quorum {
+		return errors.Errorf("pulse is confirmed by %d trusted pulsars, quorum is %d", confirmed, quorum)
+	}
 	return nil
 }
 
-func NewPulseController() PulseController {
-	return &pulseController{}
+func NewPulseController(options *network.Options) PulseController {
+	return &pulseController{options: options}
 }
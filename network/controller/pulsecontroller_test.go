@@ -67,6 +67,7 @@ import (
 	"github.com/insolar/insolar/platformpolicy"
 	"github.com/insolar/insolar/pulsar"
 	"github.com/insolar/insolar/pulsar/entropygenerator"
+	"github.com/insolar/insolar/testutils"
 	"github.com/insolar/insolar/testutils/network"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -88,6 +89,7 @@ func getController(t *testing.T) *pulseController {
 		CryptographyScheme:  platformpolicy.NewPlatformCryptographyScheme(),
 		KeyProcessor:        proc,
 		CryptographyService: cryptography.NewKeyBoundCryptographyService(key),
+		options:             &network2.Options{},
 	}
 }
 
@@ -115,6 +117,8 @@ func signPulsePayload(t *testing.T, psc insolar.PulseSenderConfirmation, key cry
 
 func TestVerifyPulseSignTrue(t *testing.T) {
 	controller := getController(t)
+	// Only signatures are checked here, quorum is checked in TestVerifyPulseSignQuorum.
+	controller.options.TrustAnyPulsar = true
 	keyStr, privateKey := getKeys(t)
 
 	psc := insolar.PulseSenderConfirmation{
@@ -212,31 +216,120 @@ func TestProcessPulseVerifyFailure(t *testing.T) {
 	assert.Error(t, err)
 }
 
-func newSignedPulse(t *testing.T) *insolar.Pulse {
+func newSignedPulse(t *testing.T) (*insolar.Pulse, crypto.PublicKey) {
 	keyStr, privateKey := getKeys(t)
 
+	pulse := pulsar.NewPulse(10, 140, &entropygenerator.StandardEntropyGenerator{})
 	psc := insolar.PulseSenderConfirmation{
-		PulseNumber:     1,
+		PulseNumber:     pulse.PulseNumber,
 		ChosenPublicKey: keyStr,
-		Entropy:         randomEntropy(),
+		Entropy:         pulse.Entropy,
 	}
 
 	psc.Signature = signPulsePayload(t, psc, privateKey)
 
-	pulse := pulsar.NewPulse(10, 140, &entropygenerator.StandardEntropyGenerator{})
 	pulse.Signs = make(map[string]insolar.PulseSenderConfirmation)
 	pulse.Signs[keyStr] = psc
 
-	return pulse
+	return pulse, platformpolicy.NewKeyProcessor().ExtractPublicKey(privateKey)
 }
 
 func TestProcessPulseHappyPath(t *testing.T) {
 	controller := getController(t)
+	p, key := newSignedPulse(t)
+	cert := testutils.NewCertificateMock(t).GetPulsarPublicKeysMock.Return([]crypto.PublicKey{key})
+	controller.CertificateManager = testutils.NewCertificateManagerMock(t).GetCertificateMock.Return(cert)
 
 	request := newPulsePacket(t)
 	request.SetRequest(&packet.PulseRequest{
-		Pulse: pulse.ToProto(newSignedPulse(t)),
+		Pulse: pulse.ToProto(p),
 	})
 	_, err := controller.processPulse(context.Background(), request)
 	assert.NoError(t, err)
 }
+
+func TestVerifyPulseSignNoPulsarKeys(t *testing.T) {
+	p, _ := newSignedPulse(t)
+	cert := testutils.NewCertificateMock(t).GetPulsarPublicKeysMock.Return(nil)
+
+	t.Run("no certificate manager", func(t *testing.T) {
+		controller := getController(t)
+		assert.Error(t, controller.verifyPulseSign(*p))
+	})
+
+	t.Run("rejected by default", func(t *testing.T) {
+		controller := getController(t)
+		controller.CertificateManager = testutils.NewCertificateManagerMock(t).GetCertificateMock.Return(cert)
+		assert.Error(t, controller.verifyPulseSign(*p))
+	})
+
+	t.Run("trust any pulsar", func(t *testing.T) {
+		controller := getController(t)
+		controller.CertificateManager = testutils.NewCertificateManagerMock(t).GetCertificateMock.Return(cert)
+		controller.options = &network2.Options{TrustAnyPulsar: true}
+		assert.NoError(t, controller.verifyPulseSign(*p))
+	})
+}
+
+func TestVerifyPulseSignQuorum(t *testing.T) {
+	proc := platformpolicy.NewKeyProcessor()
+	var (
+		keys    []string
+		private []crypto.PrivateKey
+		trusted []crypto.PublicKey
+	)
+	for i := 0; i < 4; i++ {
+		keyStr, privateKey := getKeys(t)
+		keys = append(keys, keyStr)
+		private = append(private, privateKey)
+		trusted = append(trusted, proc.ExtractPublicKey(privateKey))
+	}
+
+	controller := getController(t)
+	cert := testutils.NewCertificateMock(t).GetPulsarPublicKeysMock.Return(trusted)
+	controller.CertificateManager = testutils.NewCertificateManagerMock(t).GetCertificateMock.Return(cert)
+
+	newPulse := func(signers ...int) insolar.Pulse {
+		p := pulsar.NewPulse(10, 140, &entropygenerator.StandardEntropyGenerator{})
+		p.Signs = make(map[string]insolar.PulseSenderConfirmation)
+		for _, i := range signers {
+			psc := insolar.PulseSenderConfirmation{
+				PulseNumber:     p.PulseNumber,
+				ChosenPublicKey: keys[0],
+				Entropy:         p.Entropy,
+			}
+			psc.Signature = signPulsePayload(t, psc, private[i])
+			p.Signs[keys[i]] = psc
+		}
+		return *p
+	}
+
+	t.Run("quorum", func(t *testing.T) {
+		assert.NoError(t, controller.verifyPulseSign(newPulse(0, 1, 2)))
+		assert.NoError(t, controller.verifyPulseSign(newPulse(0, 1, 2, 3)))
+	})
+
+	t.Run("no quorum", func(t *testing.T) {
+		assert.Error(t, controller.verifyPulseSign(newPulse(1, 3)))
+
+		p := newPulse(0, 1)
+		untrustedKey, untrustedPrivate := getKeys(t)
+		psc := p.Signs[keys[0]]
+		psc.Signature = signPulsePayload(t, psc, untrustedPrivate)
+		p.Signs[untrustedKey] = psc
+		assert.Error(t, controller.verifyPulseSign(p))
+	})
+
+	t.Run("confirmations don't match pulse", func(t *testing.T) {
+		p := newPulse(0, 1, 2)
+		p.Entropy = randomEntropy()
+		assert.Error(t, controller.verifyPulseSign(p))
+
+		p = newPulse(0, 1, 2)
+		psc := p.Signs[keys[2]]
+		psc.ChosenPublicKey = keys[1]
+		psc.Signature = signPulsePayload(t, psc, private[2])
+		p.Signs[keys[2]] = psc
+		assert.Error(t, controller.verifyPulseSign(p))
+	})
+}
@@ -56,6 +56,7 @@ import (
 
 	"github.com/insolar/insolar/network/consensus"
 	"github.com/insolar/insolar/network/consensus/adapters"
+	"github.com/insolar/insolar/network/consensus/adapters/candidate"
 	"github.com/insolar/insolar/network/consensus/gcpv2/api/profiles"
 	"github.com/insolar/insolar/network/rules"
 	"github.com/insolar/insolar/network/storage"
@@ -97,6 +98,9 @@ type Base struct {
 	ConsensusController   consensus.Controller
 	ConsensusPulseHandler network.PulseHandler
 
+	// Revocations are rotated and revoked keys of nodes, they are not allowed to join network.
+	Revocations *certificate.RevocationList
+
 	Options         *network.Options
 	bootstrapTimer  *time.Timer // nolint
 	bootstrapETA    time.Duration
@@ -207,7 +211,12 @@ func (g *Base) GetCert(ctx context.Context, ref *insolar.Reference) (insolar.Cer
 
 // ValidateCert validates node certificate
 func (g *Base) ValidateCert(ctx context.Context, authCert insolar.AuthorizationCertificate) (bool, error) {
-	return certificate.VerifyAuthorizationCertificate(g.CryptographyService, g.CertificateManager.GetCertificate().GetDiscoveryNodes(), authCert)
+	return certificate.VerifyAuthorizationCertificate(
+		g.CryptographyService,
+		g.CertificateManager.GetCertificate().GetDiscoveryNodes(),
+		g.Revocations,
+		authCert,
+	)
 }
 
 // ============= Bootstrap =======
@@ -288,6 +297,12 @@ func (g *Base) HandleNodeBootstrapRequest(ctx context.Context, request network.R
 		return g.HostNetwork.BuildResponse(ctx, request, &packet.BootstrapResponse{Code: packet.Reject}), nil
 	}
 
+	err = g.checkCandidateRevoked(data.CandidateProfile)
+	if err != nil {
+		inslogger.FromContext(ctx).Warnf("Rejected bootstrap request from node %s: %s", request.GetSender(), err.Error())
+		return g.HostNetwork.BuildResponse(ctx, request, &packet.BootstrapResponse{Code: packet.Reject}), nil
+	}
+
 	type candidate struct {
 		profiles.StaticProfile
 		profiles.StaticProfileExtension
@@ -311,6 +326,15 @@ func (g *Base) HandleNodeBootstrapRequest(ctx context.Context, request network.R
 		}), nil
 }
 
+// checkCandidateRevoked returns error if key of joining node is revoked or rotated.
+func (g *Base) checkCandidateRevoked(profile candidate.Profile) error {
+	publicKey, err := g.KeyProcessor.ImportPublicKeyBinary(profile.PublicKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to import public key of candidate")
+	}
+	return g.Revocations.Check(profile.Ref, publicKey)
+}
+
 // validateTimestamp returns true if difference between timestamp ant current UTC < delta
 func validateTimestamp(timestamp int64, delta time.Duration) bool {
 	return time.Now().UTC().Sub(time.Unix(timestamp, 0)) < delta
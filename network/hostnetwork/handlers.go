@@ -51,6 +51,7 @@
 package hostnetwork
 
 import (
+	"bytes"
 	"context"
 	"io"
 
@@ -62,8 +63,11 @@ import (
 	"github.com/insolar/insolar/instrumentation/instracer"
 	"github.com/insolar/insolar/metrics"
 	"github.com/insolar/insolar/network/hostnetwork/future"
+	"github.com/insolar/insolar/network/hostnetwork/mux"
 	"github.com/insolar/insolar/network/hostnetwork/packet"
+	"github.com/insolar/insolar/network/hostnetwork/packet/types"
 	"github.com/insolar/insolar/network/hostnetwork/pool"
+	"github.com/insolar/insolar/network/transport"
 )
 
 // RequestHandler is callback function for request handling
@@ -83,58 +87,76 @@ func NewStreamHandler(requestHandler RequestHandler, responseHandler future.Pack
 	}
 }
 
+// HandleStream reads packets from stream. If stream peer is authenticated by transport,
+// packets of another sender are dropped.
 func (s *StreamHandler) HandleStream(ctx context.Context, address string, reader io.ReadWriteCloser) {
 	mainLogger := inslogger.FromContext(ctx)
+	authenticated, isAuthenticated := reader.(transport.AuthenticatedStream)
 
 	logLevel := inslogger.GetLoggerLevel(ctx)
 	// get only log level from context, discard TraceID in favor of packet TraceID
 	packetCtx := inslogger.WithLoggerLevel(context.Background(), logLevel)
 
-	closer := make(chan struct{})
-	go func() {
-		select {
-		// transport is stopping
-		case <-ctx.Done():
-		// stream end by remote end
-		case <-closer:
-		}
-
-		network.CloseVerbose(reader)
-	}()
-
-	for {
-		p, err := packet.DeserializePacket(mainLogger, reader)
-
+	session := mux.NewSession(reader, address, func(priority mux.Priority, message []byte) {
+		p, err := packet.DeserializePacket(mainLogger, bytes.NewReader(message))
 		if err != nil {
-			if err == io.EOF || err == io.ErrUnexpectedEOF {
-				mainLogger.Debug("[ HandleStream ] Connection closed by peer")
-				close(closer)
-				return
-			}
+			mainLogger.Warnf("[ HandleStream ] Failed to deserialize packet: ", err.Error())
+			return
+		}
+		if isAuthenticated && (p.Sender == nil || !p.Sender.NodeID.Equal(authenticated.Peer())) {
+			mainLogger.Warnf("[ HandleStream ] Packet from %s is dropped, sender doesn't match authenticated peer %s",
+				address, authenticated.Peer())
+			return
+		}
 
-			if network.IsConnectionClosed(err) || network.IsClosedPipe(err) {
-				mainLogger.Info("[ HandleStream ] Connection closed.")
-				return
-			}
+		packetCtx, logger := inslogger.WithTraceField(packetCtx, p.TraceID)
+		span, err := instracer.Deserialize(p.TraceSpanData)
+		if err == nil {
+			packetCtx = instracer.WithParentSpan(packetCtx, span)
+		} else {
+			inslogger.FromContext(packetCtx).Warn("Incoming packet without span")
+		}
+		logger.Debugf("[ HandleStream ] Handling packet RequestID = %d", p.RequestID)
 
-			mainLogger.Warnf("[ HandleStream ] Failed to deserialize packet: ", err.Error())
+		if p.IsResponse() {
+			go s.responseHandler.Handle(packetCtx, p)
 		} else {
-			packetCtx, logger := inslogger.WithTraceField(packetCtx, p.TraceID)
-			span, err := instracer.Deserialize(p.TraceSpanData)
-			if err == nil {
-				packetCtx = instracer.WithParentSpan(packetCtx, span)
-			} else {
-				inslogger.FromContext(packetCtx).Warn("Incoming packet without span")
-			}
-			logger.Debugf("[ HandleStream ] Handling packet RequestID = %d", p.RequestID)
-
-			if p.IsResponse() {
-				go s.responseHandler.Handle(packetCtx, p)
-			} else {
-				go s.requestHandler(packetCtx, p)
-			}
+			go s.requestHandler(packetCtx, p)
 		}
+	})
+
+	select {
+	// transport is stopping
+	case <-ctx.Done():
+		network.CloseVerbose(session)
+		<-session.Done()
+		mainLogger.Info("[ HandleStream ] Connection closed.")
+	// stream end by remote end
+	case <-session.Done():
+		err := errors.Cause(session.Err())
+		if err == io.EOF || err == io.ErrUnexpectedEOF || network.IsConnectionClosed(err) || network.IsClosedPipe(err) {
+			mainLogger.Debug("[ HandleStream ] Connection closed by peer")
+			return
+		}
+		mainLogger.Warn("[ HandleStream ] Connection closed: ", err)
+	}
+}
+
+// bulkPacketSize is a size of RPC packet that is sent with low priority,
+// so big ledger payloads don't delay other requests to the same node.
+const bulkPacketSize = 64 * 1024
+
+// packetPriority returns priority of packet in multiplexed connection. Pulses and network control packets
+// have high priority, RPC packets have normal priority unless they are bigger than bulkPacketSize.
+func packetPriority(p *packet.Packet, size int) mux.Priority {
+	switch p.GetType() {
+	case types.Pulse, types.Bootstrap, types.Authorize, types.Disconnect, types.SignCert, types.UpdateSchedule, types.Reconnect:
+		return mux.PriorityHigh
+	}
+	if size > bulkPacketSize {
+		return mux.PriorityLow
 	}
+	return mux.PriorityNormal
 }
 
 // SendPacket sends packet using connection from pool
@@ -143,26 +165,27 @@ func SendPacket(ctx context.Context, pool pool.ConnectionPool, p *packet.Packet)
 	if err != nil {
 		return errors.Wrap(err, "Failed to serialize packet")
 	}
+	priority := packetPriority(p, len(data))
 
 	conn, err := pool.GetConnection(ctx, p.Receiver)
 	if err != nil {
 		return errors.Wrap(err, "Failed to get connection")
 	}
 
-	n, err := conn.Write(data)
+	err = conn.Send(priority, data)
 	if err != nil {
 		// retry
-		inslogger.FromContext(ctx).Warn("[ SendPacket ] retry conn.Write")
+		inslogger.FromContext(ctx).Warn("[ SendPacket ] retry conn.Send")
 		pool.CloseConnection(ctx, p.Receiver)
 		conn, err = pool.GetConnection(ctx, p.Receiver)
 
 		if err != nil {
 			return errors.Wrap(err, "[ SendPacket ] Failed to get connection")
 		}
-		n, err = conn.Write(data)
+		err = conn.Send(priority, data)
 	}
 	if err == nil {
-		metrics.NetworkSentSize.Add(float64(n))
+		metrics.NetworkSentSize.Add(float64(len(data)))
 		return nil
 	}
 	return errors.Wrap(err, "[ SendPacket ] Failed to write data")
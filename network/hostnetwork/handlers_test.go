@@ -53,15 +53,29 @@ package hostnetwork
 import (
 	"context"
 	"net"
+	"os"
 	"testing"
 	"time"
 
 	"github.com/fortytw2/leaktest"
+	"github.com/stretchr/testify/require"
 
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/gen"
 	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/network/hostnetwork/host"
+	"github.com/insolar/insolar/network/hostnetwork/mux"
 	"github.com/insolar/insolar/network/hostnetwork/packet"
+	"github.com/insolar/insolar/network/hostnetwork/packet/types"
 )
 
+func TestMain(m *testing.M) {
+	// global logger starts its writer goroutine on init, let it park before tests run,
+	// otherwise leaktest reports it as leaked by the first test that checks goroutines
+	time.Sleep(100 * time.Millisecond)
+	os.Exit(m.Run())
+}
+
 func TestNewStreamHandler(t *testing.T) {
 	defer leaktest.Check(t)()
 
@@ -90,3 +104,53 @@ func TestNewStreamHandler(t *testing.T) {
 		t.Fail()
 	}
 }
+
+type authenticatedConn struct {
+	net.Conn
+	peer insolar.Reference
+}
+
+func (c authenticatedConn) Peer() insolar.Reference {
+	return c.peer
+}
+
+func TestStreamHandler_AuthenticatedSender(t *testing.T) {
+	peer := gen.Reference()
+	received := make(chan *packet.ReceivedPacket, 2)
+	h := NewStreamHandler(func(ctx context.Context, p *packet.ReceivedPacket) {
+		received <- p
+	}, nil)
+
+	con1, con2 := net.Pipe()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go h.HandleStream(ctx, "127.0.0.1:8080", authenticatedConn{Conn: con1, peer: peer})
+
+	session := mux.NewSession(con2, "127.0.0.1:8080", func(mux.Priority, []byte) {})
+	defer session.Close()
+
+	receiver, err := host.NewHostN("127.0.0.1:8081", gen.Reference())
+	require.NoError(t, err)
+	send := func(sender insolar.Reference, id uint64) {
+		from, err := host.NewHostN("127.0.0.1:8080", sender)
+		require.NoError(t, err)
+		data, err := packet.SerializePacket(packet.NewPacket(from, receiver, types.RPC, id))
+		require.NoError(t, err)
+		require.NoError(t, session.Send(mux.PriorityNormal, data))
+	}
+	send(gen.Reference(), 1)
+	send(peer, 2)
+
+	select {
+	case p := <-received:
+		require.Equal(t, uint64(2), p.RequestID)
+		require.Equal(t, peer, p.GetSender())
+	case <-time.After(5 * time.Second):
+		t.Fatal("packet of authenticated peer isn't handled")
+	}
+	select {
+	case p := <-received:
+		t.Fatalf("packet from %s is handled", p.GetSender())
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,501 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+// Package mux multiplexes messages of different priorities over one stream connection.
+// Each message is sent as a separate stream split into frames, so a large message doesn't
+// block smaller messages of higher priority. Every stream has its own flow control window.
+package mux
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/metrics"
+)
+
+// Priority of a message. Messages with higher priority are sent first.
+type Priority uint8
+
+const (
+	// PriorityHigh is for pulses and network control packets.
+	PriorityHigh Priority = iota
+	// PriorityNormal is for ordinary requests.
+	PriorityNormal
+	// PriorityLow is for bulk data such as ledger replication.
+	PriorityLow
+
+	prioritiesCount
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityHigh:
+		return "high"
+	case PriorityNormal:
+		return "normal"
+	case PriorityLow:
+		return "low"
+	default:
+		return "unknown"
+	}
+}
+
+const (
+	frameData byte = iota + 1
+	frameWindowUpdate
+)
+
+const (
+	flagFin byte = 1
+
+	// header is type(1) + priority(1) + flags(1) + stream id(4) + length(4)
+	frameHeaderSize = 11
+)
+
+type config struct {
+	// frameSize is a maximum payload of one data frame.
+	frameSize int
+	// streamWindow is initial flow control window of every stream.
+	streamWindow uint32
+	// receiveBuffer limits buffered bytes of incomplete messages of one priority.
+	// When it is exceeded, receiver stops to extend windows of streams with this priority.
+	receiveBuffer int
+	// maxStreams limits number of incomplete incoming messages.
+	maxStreams int
+}
+
+var defaultConfig = config{
+	frameSize:     16 * 1024,
+	streamWindow:  128 * 1024,
+	receiveBuffer: 16 * 1024 * 1024,
+	maxStreams:    256,
+}
+
+// MessageHandler is called for every received message in order of message completion.
+type MessageHandler func(priority Priority, message []byte)
+
+type sendStream struct {
+	id       uint32
+	priority Priority
+	data     []byte
+	window   uint32
+	stalled  bool
+	result   chan error
+}
+
+type recvStream struct {
+	priority Priority
+	data     []byte
+	// window is a number of bytes remote side is allowed to send.
+	window uint32
+}
+
+type credit struct {
+	id     uint32
+	amount uint32
+}
+
+// Session multiplexes messages over connection. Both sides of connection should use Session.
+type Session struct {
+	conn    io.ReadWriteCloser
+	peer    string
+	handler MessageHandler
+	cfg     config
+
+	mu            sync.Mutex
+	cond          *sync.Cond
+	nextID        uint32
+	queues        [prioritiesCount][]*sendStream
+	sending       map[uint32]*sendStream
+	windowUpdates []credit
+	closed        bool
+	err           error
+
+	// receiving state is used only by reader goroutine
+	receiving map[uint32]*recvStream
+	buffered  [prioritiesCount]int
+	deferred  [prioritiesCount][]credit
+
+	done chan struct{}
+}
+
+// NewSession starts to serve connection to peer. Received messages are passed to handler.
+func NewSession(conn io.ReadWriteCloser, peer string, handler MessageHandler) *Session {
+	return newSession(conn, peer, handler, defaultConfig)
+}
+
+func newSession(conn io.ReadWriteCloser, peer string, handler MessageHandler, cfg config) *Session {
+	s := &Session{
+		conn:      conn,
+		peer:      peer,
+		handler:   handler,
+		cfg:       cfg,
+		sending:   make(map[uint32]*sendStream),
+		receiving: make(map[uint32]*recvStream),
+		done:      make(chan struct{}),
+	}
+	s.cond = sync.NewCond(&s.mu)
+
+	go s.readLoop()
+	go s.writeLoop()
+	return s
+}
+
+// Send queues message and waits until it is written to connection.
+func (s *Session) Send(priority Priority, data []byte) error {
+	if priority >= prioritiesCount {
+		return errors.Errorf("invalid priority %d", priority)
+	}
+
+	st := &sendStream{
+		priority: priority,
+		data:     data,
+		window:   s.cfg.streamWindow,
+		result:   make(chan error, 1),
+	}
+
+	s.mu.Lock()
+	if s.closed {
+		err := s.err
+		s.mu.Unlock()
+		return errors.Wrap(err, "session is closed")
+	}
+	st.id = s.nextID
+	s.nextID++
+	s.queues[priority] = append(s.queues[priority], st)
+	s.sending[st.id] = st
+	metrics.NetworkStreamQueueDepth.WithLabelValues(s.peer, priority.String()).Inc()
+	s.cond.Signal()
+	s.mu.Unlock()
+
+	return <-st.result
+}
+
+// Close closes session and connection, all queued messages fail.
+func (s *Session) Close() error {
+	s.fail(errors.New("session closed"))
+	return nil
+}
+
+// Done returns channel that is closed when session is finished.
+func (s *Session) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err returns reason why session is finished.
+func (s *Session) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+func (s *Session) fail(err error) {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	s.err = err
+	streams := s.sending
+	s.sending = nil
+	s.queues = [prioritiesCount][]*sendStream{}
+	s.cond.Broadcast()
+	s.mu.Unlock()
+
+	_ = s.conn.Close()
+	for _, st := range streams {
+		metrics.NetworkStreamQueueDepth.WithLabelValues(s.peer, st.priority.String()).Dec()
+		st.result <- errors.Wrap(err, "session is closed")
+	}
+	for p := Priority(0); p < prioritiesCount; p++ {
+		metrics.NetworkStreamQueueDepth.DeleteLabelValues(s.peer, p.String())
+	}
+}
+
+// nextFrame picks stream with highest priority that has window to send. Streams of the same priority
+// are sent in round robin. Must be called under lock.
+func (s *Session) nextFrame() (*sendStream, []byte, bool) {
+	for p := range s.queues {
+		queue := s.queues[p]
+		for i, st := range queue {
+			if st.window == 0 && len(st.data) > 0 {
+				if !st.stalled {
+					st.stalled = true
+					metrics.NetworkStreamStalls.WithLabelValues(s.peer, st.priority.String()).Inc()
+				}
+				continue
+			}
+
+			size := len(st.data)
+			if size > s.cfg.frameSize {
+				size = s.cfg.frameSize
+			}
+			if uint32(size) > st.window {
+				size = int(st.window)
+			}
+			chunk := st.data[:size]
+			st.data = st.data[size:]
+			st.window -= uint32(size)
+
+			queue = append(queue[:i:i], queue[i+1:]...)
+			fin := len(st.data) == 0
+			if !fin {
+				queue = append(queue, st)
+			}
+			s.queues[p] = queue
+			return st, chunk, fin
+		}
+	}
+	return nil, nil, false
+}
+
+func (s *Session) writeLoop() {
+	for {
+		s.mu.Lock()
+		var (
+			st      *sendStream
+			chunk   []byte
+			fin     bool
+			updates []credit
+		)
+		for !s.closed {
+			updates = s.windowUpdates
+			s.windowUpdates = nil
+			st, chunk, fin = s.nextFrame()
+			if st != nil || len(updates) > 0 {
+				break
+			}
+			s.cond.Wait()
+		}
+		if s.closed {
+			s.mu.Unlock()
+			return
+		}
+		s.mu.Unlock()
+
+		buf := make([]byte, 0, frameHeaderSize*(len(updates)+1)+len(chunk))
+		for _, u := range updates {
+			buf = appendFrame(buf, frameWindowUpdate, 0, 0, u.id, u.amount, nil)
+		}
+		if st != nil {
+			var flags byte
+			if fin {
+				flags = flagFin
+			}
+			buf = appendFrame(buf, frameData, st.priority, flags, st.id, uint32(len(chunk)), chunk)
+		}
+
+		if err := s.write(buf); err != nil {
+			s.fail(errors.Wrap(err, "failed to write frame"))
+			return
+		}
+
+		if fin {
+			s.mu.Lock()
+			// stream is already failed if session was closed during write
+			if _, ok := s.sending[st.id]; ok {
+				delete(s.sending, st.id)
+				metrics.NetworkStreamQueueDepth.WithLabelValues(s.peer, st.priority.String()).Dec()
+				st.result <- nil
+			}
+			s.mu.Unlock()
+		}
+	}
+}
+
+func (s *Session) write(buf []byte) error {
+	n, err := s.conn.Write(buf)
+	if err == nil && n < len(buf) {
+		err = io.ErrShortWrite
+	}
+	return err
+}
+
+func appendFrame(buf []byte, typ byte, priority Priority, flags byte, id uint32, length uint32, payload []byte) []byte {
+	var header [frameHeaderSize]byte
+	header[0] = typ
+	header[1] = byte(priority)
+	header[2] = flags
+	binary.BigEndian.PutUint32(header[3:7], id)
+	binary.BigEndian.PutUint32(header[7:11], length)
+	buf = append(buf, header[:]...)
+	return append(buf, payload...)
+}
+
+func (s *Session) readLoop() {
+	defer close(s.done)
+
+	// bufio stops reading from connection that returns no data without error
+	r := bufio.NewReader(s.conn)
+	header := make([]byte, frameHeaderSize)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			s.fail(err)
+			return
+		}
+
+		typ, priority, flags := header[0], Priority(header[1]), header[2]
+		id := binary.BigEndian.Uint32(header[3:7])
+		length := binary.BigEndian.Uint32(header[7:11])
+
+		var err error
+		switch typ {
+		case frameData:
+			err = s.receiveData(r, priority, flags, id, length)
+		case frameWindowUpdate:
+			s.extendWindow(id, length)
+		default:
+			err = errors.Errorf("unknown frame type %d", typ)
+		}
+		if err != nil {
+			s.fail(err)
+			return
+		}
+	}
+}
+
+func (s *Session) receiveData(r io.Reader, priority Priority, flags byte, id uint32, length uint32) error {
+	if priority >= prioritiesCount {
+		return errors.Errorf("invalid priority %d", priority)
+	}
+	if length > uint32(s.cfg.frameSize) {
+		return errors.Errorf("frame size %d exceeds limit %d", length, s.cfg.frameSize)
+	}
+
+	st, ok := s.receiving[id]
+	if !ok {
+		if len(s.receiving) >= s.cfg.maxStreams {
+			return errors.New("too many incoming streams")
+		}
+		st = &recvStream{priority: priority, window: s.cfg.streamWindow}
+		s.receiving[id] = st
+	}
+	if st.priority != priority {
+		return errors.Errorf("priority of stream %d is changed from %s to %s", id, st.priority, priority)
+	}
+	if length > st.window {
+		return errors.Errorf("frame size %d exceeds window %d of stream %d", length, st.window, id)
+	}
+	st.window -= length
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return err
+	}
+	st.data = append(st.data, payload...)
+	s.buffered[priority] += int(length)
+
+	if flags&flagFin == 0 {
+		s.grant(priority, id, length)
+		return nil
+	}
+
+	delete(s.receiving, id)
+	s.buffered[priority] -= len(st.data)
+	s.handler(priority, st.data)
+
+	// delivered message frees buffer, deferred windows can be extended now
+	deferred := s.deferred[priority]
+	s.deferred[priority] = nil
+	for _, c := range deferred {
+		if _, ok := s.receiving[c.id]; ok {
+			s.grant(priority, c.id, c.amount)
+		}
+	}
+	return nil
+}
+
+// grant extends window of remote stream if there is free space in receive buffer.
+// The oldest incomplete stream of priority always gets window, so it can't deadlock
+// on message bigger than the buffer.
+func (s *Session) grant(priority Priority, id uint32, amount uint32) {
+	if amount == 0 {
+		return
+	}
+	if s.buffered[priority] > s.cfg.receiveBuffer && !s.isOldest(priority, id) {
+		s.deferred[priority] = append(s.deferred[priority], credit{id: id, amount: amount})
+		return
+	}
+
+	if st, ok := s.receiving[id]; ok {
+		st.window += amount
+	}
+	s.mu.Lock()
+	s.windowUpdates = append(s.windowUpdates, credit{id: id, amount: amount})
+	s.cond.Signal()
+	s.mu.Unlock()
+}
+
+func (s *Session) isOldest(priority Priority, id uint32) bool {
+	for other, st := range s.receiving {
+		if st.priority == priority && other < id {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Session) extendWindow(id uint32, amount uint32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, ok := s.sending[id]
+	if !ok {
+		return
+	}
+	st.window += amount
+	st.stalled = false
+	s.cond.Signal()
+}
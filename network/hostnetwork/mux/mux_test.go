@@ -0,0 +1,309 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package mux
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type received struct {
+	priority Priority
+	message  []byte
+}
+
+func newReceiver(conn net.Conn, cfg config) (*Session, chan received) {
+	messages := make(chan received, 100)
+	s := newSession(conn, "receiver", func(priority Priority, message []byte) {
+		messages <- received{priority: priority, message: message}
+	}, cfg)
+	return s, messages
+}
+
+func noHandler(Priority, []byte) {}
+
+func payload(size int, b byte) []byte {
+	return bytes.Repeat([]byte{b}, size)
+}
+
+func waitQueued(t *testing.T, s *Session, count int) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		s.mu.Lock()
+		queued := len(s.sending)
+		s.mu.Unlock()
+		if queued == count {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("%d messages are not queued", count)
+}
+
+func sendAsync(s *Session, priority Priority, data []byte) chan error {
+	result := make(chan error, 1)
+	go func() {
+		result <- s.Send(priority, data)
+	}()
+	return result
+}
+
+func TestSession_RoundTrip(t *testing.T) {
+	c1, c2 := net.Pipe()
+	cfg := config{frameSize: 1024, streamWindow: 4096, receiveBuffer: 8192, maxStreams: 16}
+
+	sender := newSession(c1, "sender", noHandler, cfg)
+	defer sender.Close()
+	receiver, messages := newReceiver(c2, cfg)
+	defer receiver.Close()
+
+	tests := []struct {
+		name     string
+		priority Priority
+		data     []byte
+	}{
+		{"empty", PriorityNormal, []byte{}},
+		{"one frame", PriorityHigh, payload(100, 1)},
+		{"exact frame", PriorityNormal, payload(1024, 2)},
+		{"bigger than window and buffer", PriorityLow, payload(20000, 3)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.NoError(t, sender.Send(test.priority, test.data))
+
+			msg := <-messages
+			require.Equal(t, test.priority, msg.priority)
+			require.Equal(t, len(test.data), len(msg.message))
+			require.True(t, bytes.Equal(test.data, msg.message))
+		})
+	}
+
+	t.Run("both directions", func(t *testing.T) {
+		result := sendAsync(receiver, PriorityNormal, payload(5000, 4))
+		require.NoError(t, sender.Send(PriorityNormal, payload(5000, 5)))
+		require.NoError(t, <-result)
+		require.Equal(t, payload(5000, 5), (<-messages).message)
+	})
+}
+
+func TestSession_HighPriorityOvertakesBulk(t *testing.T) {
+	c1, c2 := net.Pipe()
+	cfg := config{frameSize: 1024, streamWindow: 1 << 20, receiveBuffer: 1 << 20, maxStreams: 16}
+
+	sender := newSession(c1, "sender", noHandler, cfg)
+	defer sender.Close()
+
+	// nobody reads yet, so writer is blocked on the first frame of bulk message
+	bulk := sendAsync(sender, PriorityLow, payload(64*1024, 1))
+	waitQueued(t, sender, 1)
+	small := sendAsync(sender, PriorityHigh, payload(10, 2))
+	waitQueued(t, sender, 2)
+
+	receiver, messages := newReceiver(c2, cfg)
+	defer receiver.Close()
+
+	first := <-messages
+	require.Equal(t, PriorityHigh, first.priority)
+	require.Equal(t, payload(10, 2), first.message)
+	require.NoError(t, <-small)
+
+	second := <-messages
+	require.Equal(t, PriorityLow, second.priority)
+	require.Len(t, second.message, 64*1024)
+	require.NoError(t, <-bulk)
+}
+
+func readFrame(t *testing.T, conn net.Conn) (byte, byte, uint32, []byte) {
+	header := make([]byte, frameHeaderSize)
+	_, err := io.ReadFull(conn, header)
+	require.NoError(t, err)
+
+	data := make([]byte, binary.BigEndian.Uint32(header[7:11]))
+	_, err = io.ReadFull(conn, data)
+	require.NoError(t, err)
+	return header[0], header[2], binary.BigEndian.Uint32(header[3:7]), data
+}
+
+func TestSession_FlowControl(t *testing.T) {
+	c1, c2 := net.Pipe()
+	defer c2.Close()
+	cfg := config{frameSize: 1024, streamWindow: 4096, receiveBuffer: 8192, maxStreams: 16}
+
+	sender := newSession(c1, "sender", noHandler, cfg)
+	defer sender.Close()
+
+	result := sendAsync(sender, PriorityLow, payload(10000, 1))
+
+	var id uint32
+	total := 0
+	for total < int(cfg.streamWindow) {
+		typ, flags, streamID, data := readFrame(t, c2)
+		require.Equal(t, frameData, typ)
+		require.Zero(t, flags&flagFin)
+		id = streamID
+		total += len(data)
+	}
+	require.Equal(t, int(cfg.streamWindow), total)
+
+	// window is exhausted, sender waits for window update
+	require.NoError(t, c2.SetReadDeadline(time.Now().Add(100*time.Millisecond)))
+	_, err := c2.Read(make([]byte, 1))
+	require.Error(t, err)
+	require.True(t, err.(net.Error).Timeout())
+	require.NoError(t, c2.SetReadDeadline(time.Time{}))
+
+	sender.mu.Lock()
+	stalled := sender.sending[id].stalled
+	sender.mu.Unlock()
+	require.True(t, stalled)
+
+	update := appendFrame(nil, frameWindowUpdate, 0, 0, id, 10000, nil)
+	_, err = c2.Write(update)
+	require.NoError(t, err)
+
+	for {
+		typ, flags, _, data := readFrame(t, c2)
+		require.Equal(t, frameData, typ)
+		total += len(data)
+		if flags&flagFin != 0 {
+			break
+		}
+	}
+	require.Equal(t, 10000, total)
+	require.NoError(t, <-result)
+}
+
+func TestSession_Close(t *testing.T) {
+	c1, c2 := net.Pipe()
+	defer c2.Close()
+
+	s := NewSession(c1, "peer", noHandler)
+
+	// nobody reads from connection, so message stays queued
+	result := sendAsync(s, PriorityNormal, payload(10, 1))
+	waitQueued(t, s, 1)
+
+	require.NoError(t, s.Close())
+	require.Error(t, <-result)
+
+	select {
+	case <-s.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("session is not done after close")
+	}
+	require.Error(t, s.Err())
+	require.Error(t, s.Send(PriorityHigh, payload(10, 2)))
+}
+
+func TestSession_RemoteClose(t *testing.T) {
+	c1, c2 := net.Pipe()
+	s := NewSession(c1, "peer", noHandler)
+	defer s.Close()
+
+	require.NoError(t, c2.Close())
+
+	select {
+	case <-s.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("session is not done after remote close")
+	}
+	require.Equal(t, io.EOF, s.Err())
+}
+
+func TestSession_InvalidPriority(t *testing.T) {
+	c1, c2 := net.Pipe()
+	defer c2.Close()
+
+	s := NewSession(c1, "peer", noHandler)
+	defer s.Close()
+
+	require.Error(t, s.Send(prioritiesCount, nil))
+}
+
+func TestSession_WindowExceeded(t *testing.T) {
+	c1, c2 := net.Pipe()
+	defer c2.Close()
+
+	cfg := defaultConfig
+	cfg.frameSize = 4
+	cfg.streamWindow = 8
+	cfg.receiveBuffer = 0
+	s := newSession(c1, "receiver", noHandler, cfg)
+	defer s.Close()
+
+	go func() {
+		_, _ = io.Copy(ioutil.Discard, c2)
+	}()
+
+	// receive buffer is full, so only the oldest stream gets window, the other one is limited by initial window
+	buf := appendFrame(nil, frameData, PriorityNormal, 0, 1, 4, payload(4, 1))
+	for i := 0; i < 3; i++ {
+		buf = appendFrame(buf, frameData, PriorityNormal, 0, 2, 4, payload(4, 2))
+	}
+	go func() {
+		_, _ = c2.Write(buf)
+	}()
+
+	select {
+	case <-s.Done():
+		require.Contains(t, s.Err().Error(), "exceeds window")
+	case <-time.After(5 * time.Second):
+		t.Fatal("session is not failed")
+	}
+}
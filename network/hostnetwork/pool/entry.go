@@ -60,6 +60,7 @@ import (
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/network"
 	"github.com/insolar/insolar/network/hostnetwork/host"
+	"github.com/insolar/insolar/network/hostnetwork/mux"
 	"github.com/insolar/insolar/network/transport"
 )
 
@@ -70,35 +71,29 @@ type entry struct {
 	transport transport.StreamTransport
 	host      *host.Host
 	onClose   onClose
-	conn      io.ReadWriteCloser
+	session   *mux.Session
 }
 
-func newEntry(t transport.StreamTransport, conn io.ReadWriteCloser, host *host.Host, onClose onClose) *entry {
+func newEntry(t transport.StreamTransport, session *mux.Session, host *host.Host, onClose onClose) *entry {
 	return &entry{
 		transport: t,
-		conn:      conn,
+		session:   session,
 		host:      host,
 		onClose:   onClose,
 	}
 }
 
-func (e *entry) watchRemoteClose(ctx context.Context) {
-	b := make([]byte, 1)
-	_, err := e.conn.Read(b)
-	if err != nil {
-		inslogger.FromContext(ctx).Infof("[ watchRemoteClose ] remote host 'closed' connection to %s: %s", e.host.String(), err)
-		e.onClose(ctx, e.host)
-		return
-	}
-
-	inslogger.FromContext(ctx).Errorf("[ watchRemoteClose ] unexpected data on connection to %s", e.host.String())
+func (e *entry) watchRemoteClose(ctx context.Context, session *mux.Session) {
+	<-session.Done()
+	inslogger.FromContext(ctx).Infof("[ watchRemoteClose ] remote host 'closed' connection to %s: %s", e.host.String(), session.Err())
+	e.onClose(ctx, e.host)
 }
 
-func (e *entry) open(ctx context.Context) (io.ReadWriteCloser, error) {
+func (e *entry) open(ctx context.Context) (*mux.Session, error) {
 	e.Lock()
 	defer e.Unlock()
-	if e.conn != nil {
-		return e.conn, nil
+	if e.session != nil {
+		return e.session, nil
 	}
 
 	conn, err := e.dial(ctx)
@@ -106,12 +101,17 @@ func (e *entry) open(ctx context.Context) (io.ReadWriteCloser, error) {
 		return nil, err
 	}
 
-	e.conn = conn
-	go e.watchRemoteClose(ctx)
-	return e.conn, nil
+	e.session = mux.NewSession(conn, e.host.Address.String(), func(priority mux.Priority, message []byte) {
+		inslogger.FromContext(ctx).Errorf("[ open ] unexpected data on connection to %s", e.host.String())
+	})
+	go e.watchRemoteClose(ctx, e.session)
+	return e.session, nil
 }
 
 func (e *entry) dial(ctx context.Context) (io.ReadWriteCloser, error) {
+	if !e.host.NodeID.IsEmpty() {
+		ctx = transport.WithRemoteNode(ctx, e.host.NodeID)
+	}
 	conn, err := e.transport.Dial(ctx, e.host.Address.String())
 	if err != nil {
 		return nil, errors.Wrap(err, "[ Open ] Failed to create TCP connection")
@@ -124,7 +124,7 @@ func (e *entry) close() {
 	e.Lock()
 	defer e.Unlock()
 
-	if e.conn != nil {
-		network.CloseVerbose(e.conn)
+	if e.session != nil {
+		network.CloseVerbose(e.session)
 	}
 }
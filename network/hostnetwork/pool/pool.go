@@ -52,17 +52,17 @@ package pool
 
 import (
 	"context"
-	"io"
 
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/metrics"
 	"github.com/insolar/insolar/network/hostnetwork/host"
+	"github.com/insolar/insolar/network/hostnetwork/mux"
 	"github.com/insolar/insolar/network/transport"
 )
 
 // ConnectionPool interface provides methods to manage pool of network connections
 type ConnectionPool interface {
-	GetConnection(ctx context.Context, host *host.Host) (io.ReadWriter, error)
+	GetConnection(ctx context.Context, host *host.Host) (*mux.Session, error)
 	CloseConnection(ctx context.Context, host *host.Host)
 	Reset()
 }
@@ -86,7 +86,7 @@ func newConnectionPool(t transport.StreamTransport) *connectionPool {
 }
 
 // GetConnection returns connection from the pool, if connection isn't exist, it will be created
-func (cp *connectionPool) GetConnection(ctx context.Context, host *host.Host) (io.ReadWriter, error) {
+func (cp *connectionPool) GetConnection(ctx context.Context, host *host.Host) (*mux.Session, error) {
 	logger := inslogger.FromContext(ctx)
 	logger.Debugf("[ GetConnection ] Finding entry for connection to %s in pool", host)
 
@@ -53,6 +53,7 @@ package pool
 import (
 	"context"
 	"io"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -62,27 +63,35 @@ import (
 	"github.com/insolar/insolar/testutils/network"
 )
 
+// fakeConnection is idle connection, Read blocks until connection is closed.
 type fakeConnection struct {
 	io.ReadWriteCloser
+	closeOnce sync.Once
+	closed    chan struct{}
 }
 
-func (fakeConnection) Read(p []byte) (n int, err error) {
-	return 0, nil
+func newFakeConnection() *fakeConnection {
+	return &fakeConnection{closed: make(chan struct{})}
 }
 
-func (fakeConnection) Write(p []byte) (n int, err error) {
-	return 0, nil
+func (c *fakeConnection) Read(p []byte) (n int, err error) {
+	<-c.closed
+	return 0, io.EOF
 }
 
-func (fakeConnection) Close() error {
-	return nil
+func (c *fakeConnection) Write(p []byte) (n int, err error) {
+	return len(p), nil
+}
 
+func (c *fakeConnection) Close() error {
+	c.closeOnce.Do(func() { close(c.closed) })
+	return nil
 }
 
 func newTransportMock(t *testing.T) transport.StreamTransport {
 	tr := network.NewStreamTransportMock(t)
 	tr.DialMock.Set(func(p context.Context, p1 string) (r io.ReadWriteCloser, r1 error) {
-		return fakeConnection{}, nil
+		return newFakeConnection(), nil
 	})
 	return tr
 }
@@ -60,6 +60,10 @@ import (
 var (
 	// TagPhase is a tag for consensus metrics.
 	TagPhase = insmetrics.MustTagKey("phase")
+	// TagViolator is a tag for node a misbehavior is reported about.
+	TagViolator = insmetrics.MustTagKey("violator")
+	// TagMisbehavior is a tag for misbehavior type.
+	TagMisbehavior = insmetrics.MustTagKey("misbehavior")
 )
 
 var (
@@ -78,6 +82,9 @@ var (
 
 	// ActiveNodes active nodes count after consensus.
 	ActiveNodes = stats.Int64("consensus_active_nodes_count", "Active nodes count after consensus", stats.UnitDimensionless)
+
+	// MisbehaviorReports consensus blame and fraud reports counter.
+	MisbehaviorReports = stats.Int64("consensus_misbehavior_reports", "Consensus blame and fraud reports counter", stats.UnitDimensionless)
 )
 
 func init() {
@@ -128,6 +135,13 @@ func init() {
 			Measure:     ActiveNodes,
 			Aggregation: view.LastValue(),
 		},
+		&view.View{
+			Name:        MisbehaviorReports.Name(),
+			Description: MisbehaviorReports.Description(),
+			Measure:     MisbehaviorReports,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{TagViolator, TagMisbehavior},
+		},
 	)
 	if err != nil {
 		panic(err)
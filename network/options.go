@@ -78,6 +78,9 @@ type Options struct {
 
 	// The maximum time to wait for a new pulse
 	PulseWatchdogTimeout time.Duration
+
+	// Accept pulses from any pulsar if certificate has no pulsar keys
+	TrustAnyPulsar bool
 }
 
 // ConfigureOptions convert daemon configuration to controller options
@@ -91,5 +94,6 @@ func ConfigureOptions(conf configuration.Configuration) *Options {
 		AckPacketTimeout:     5 * time.Second,
 		BootstrapTimeout:     90 * time.Second,
 		PulseWatchdogTimeout: 30 * time.Second,
+		TrustAnyPulsar:       config.TrustAnyPulsar,
 	}
 }
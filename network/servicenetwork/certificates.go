@@ -0,0 +1,292 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package servicenetwork
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/certificate"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/network"
+)
+
+const (
+	deliverCertificateUpdate = "ServiceNetwork.processCertificateUpdate"
+	getRevocationList        = "ServiceNetwork.processRevocationListRequest"
+)
+
+// certificateUpdate is rotation or revocation announced to network.
+type certificateUpdate struct {
+	Rotation   *certificate.Rotation   `json:"rotation,omitempty"`
+	Revocation *certificate.Revocation `json:"revocation,omitempty"`
+}
+
+// revocationList is applied rotations and revocations node sends to joined nodes.
+type revocationList struct {
+	Rotations   []certificate.Rotation   `json:"rotations"`
+	Revocations []certificate.Revocation `json:"revocations"`
+}
+
+// Rotate announces rotation of node key to active nodes. It's applied when consensus finishes.
+func (n *ServiceNetwork) Rotate(ctx context.Context, rotation certificate.Rotation) error {
+	return n.announceCertificateUpdate(ctx, certificateUpdate{Rotation: &rotation})
+}
+
+// Revoke announces revocation of node key to active nodes. It's applied when consensus finishes.
+func (n *ServiceNetwork) Revoke(ctx context.Context, revocation certificate.Revocation) error {
+	return n.announceCertificateUpdate(ctx, certificateUpdate{Revocation: &revocation})
+}
+
+// RevocationList returns applied rotations and revocations.
+func (n *ServiceNetwork) RevocationList() *certificate.RevocationList {
+	return n.Revocations
+}
+
+func (n *ServiceNetwork) verifyCertificateUpdate(update certificateUpdate) error {
+	cert := n.CertificateManager.GetCertificate()
+	switch {
+	case update.Rotation != nil && update.Revocation == nil:
+		return certificate.VerifyRotation(n.CryptographyService, cert, update.Rotation)
+	case update.Revocation != nil && update.Rotation == nil:
+		return certificate.VerifyRevocation(n.CryptographyService, cert, update.Revocation)
+	default:
+		return errors.New("update must be either rotation or revocation")
+	}
+}
+
+func (n *ServiceNetwork) announceCertificateUpdate(ctx context.Context, update certificateUpdate) error {
+	err := n.verifyCertificateUpdate(update)
+	if err != nil {
+		return errors.Wrap(err, "failed to verify certificate update")
+	}
+	data, err := json.Marshal(update)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal certificate update")
+	}
+	n.queueCertificateUpdate(update)
+
+	logger := inslogger.FromContext(ctx)
+	origin := n.NodeKeeper.GetOrigin()
+	p, err := n.PulseAccessor.GetLatestPulse(ctx)
+	if err != nil {
+		p = *insolar.GenesisPulse
+	}
+	for _, node := range n.NodeKeeper.GetAccessor(p.PulseNumber).GetActiveNodes() {
+		if node.ID().Equal(origin.ID()) {
+			continue
+		}
+		go func(ref insolar.Reference) {
+			_, err := n.RPC.SendBytes(ctx, ref, deliverCertificateUpdate, data)
+			if err != nil {
+				logger.Warnf("failed to announce certificate update to node %s: %s", ref, err)
+			}
+		}(node.ID())
+	}
+	return nil
+}
+
+func (n *ServiceNetwork) processCertificateUpdate(ctx context.Context, args []byte) ([]byte, error) {
+	update := certificateUpdate{}
+	err := json.Unmarshal(args, &update)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal certificate update")
+	}
+	err = n.verifyCertificateUpdate(update)
+	if err != nil {
+		inslogger.FromContext(ctx).Warn("rejected certificate update: ", err)
+		return nil, errors.Wrap(err, "failed to verify certificate update")
+	}
+	n.queueCertificateUpdate(update)
+	return ack, nil
+}
+
+func (n *ServiceNetwork) processRevocationListRequest(ctx context.Context, args []byte) ([]byte, error) {
+	data, err := json.Marshal(revocationList{
+		Rotations:   n.Revocations.Rotations(),
+		Revocations: n.Revocations.Revocations(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal revocation list")
+	}
+	return data, nil
+}
+
+// syncRevocationList requests revocation list from active nodes, so node joined network
+// gets updates announced before it has joined. Received updates are applied when consensus finishes.
+func (n *ServiceNetwork) syncRevocationList(ctx context.Context, pn insolar.PulseNumber) {
+	logger := inslogger.FromContext(ctx)
+	origin := n.NodeKeeper.GetOrigin()
+
+	requested := false
+	for _, node := range n.NodeKeeper.GetAccessor(pn).GetActiveNodes() {
+		if node.ID().Equal(origin.ID()) {
+			continue
+		}
+		requested = true
+
+		res, err := n.RPC.SendBytes(ctx, node.ID(), getRevocationList, nil)
+		if err != nil {
+			logger.Warnf("failed to request revocation list from node %s: %s", node.ID(), err)
+			continue
+		}
+		list := revocationList{}
+		err = json.Unmarshal(res, &list)
+		if err != nil {
+			logger.Warnf("failed to unmarshal revocation list from node %s: %s", node.ID(), err)
+			continue
+		}
+
+		// rotations go first, revocations may refer to rotated keys
+		for i := range list.Rotations {
+			n.queueVerifiedCertificateUpdate(ctx, certificateUpdate{Rotation: &list.Rotations[i]})
+		}
+		for i := range list.Revocations {
+			n.queueVerifiedCertificateUpdate(ctx, certificateUpdate{Revocation: &list.Revocations[i]})
+		}
+		return
+	}
+
+	if requested {
+		// try again when next consensus finishes
+		atomic.StoreUint32(&n.revocationsSynced, 0)
+	}
+}
+
+func (n *ServiceNetwork) queueVerifiedCertificateUpdate(ctx context.Context, update certificateUpdate) {
+	err := n.verifyCertificateUpdate(update)
+	if err != nil {
+		inslogger.FromContext(ctx).Warn("rejected certificate update from revocation list: ", err)
+		return
+	}
+	n.queueCertificateUpdate(update)
+}
+
+func (n *ServiceNetwork) queueCertificateUpdate(update certificateUpdate) {
+	n.certUpdatesLock.Lock()
+	defer n.certUpdatesLock.Unlock()
+	n.pendingCertUpdates = append(n.pendingCertUpdates, update)
+}
+
+// applyCertificateUpdates applies announced updates when consensus finishes,
+// so nodes of network start to use them at the same pulse.
+func (n *ServiceNetwork) applyCertificateUpdates(ctx context.Context, report network.Report) {
+	n.certUpdatesLock.Lock()
+	updates := n.pendingCertUpdates
+	n.pendingCertUpdates = nil
+	n.certUpdatesLock.Unlock()
+
+	logger := inslogger.FromContext(ctx)
+	origin := n.NodeKeeper.GetOrigin()
+	revokedOrigin := false
+	for _, update := range updates {
+		switch {
+		case update.Rotation != nil:
+			added, err := n.Revocations.AddRotation(*update.Rotation)
+			if err != nil {
+				logger.Warnf("failed to apply rotation of node %s: %s", update.Rotation.Reference, err)
+				continue
+			}
+			if added {
+				logger.Infof("key of node %s is rotated in pulse %d", update.Rotation.Reference, report.PulseNumber)
+			}
+			if added && update.Rotation.Reference == origin.ID().String() {
+				logger.Warn("key of this node is rotated, restart node with new key and certificate")
+			}
+		case update.Revocation != nil:
+			added, err := n.Revocations.AddRevocation(*update.Revocation)
+			if err != nil {
+				logger.Warnf("failed to apply revocation of node %s: %s", update.Revocation.Reference, err)
+				continue
+			}
+			if added {
+				logger.Infof("key of node %s is revoked in pulse %d: %s", update.Revocation.Reference, report.PulseNumber, update.Revocation.Reason)
+			}
+			revokedOrigin = revokedOrigin || (added && update.Revocation.Reference == origin.ID().String())
+		}
+	}
+
+	if revokedOrigin && n.Revocations.Check(origin.ID(), origin.PublicKey()) != nil {
+		logger.Error("key of this node is revoked, leaving network")
+		go n.TerminationHandler.Leave(ctx, 0)
+	}
+
+	n.evictRevokedNodes(ctx, report.PulseNumber)
+
+	if atomic.CompareAndSwapUint32(&n.revocationsSynced, 0, 1) {
+		go n.syncRevocationList(ctx, report.PulseNumber)
+	}
+}
+
+// evictRevokedNodes makes consensus refuse packets of active nodes with revoked keys,
+// so these nodes are dropped from active list.
+func (n *ServiceNetwork) evictRevokedNodes(ctx context.Context, pn insolar.PulseNumber) {
+	logger := inslogger.FromContext(ctx)
+	origin := n.NodeKeeper.GetOrigin()
+	for _, node := range n.NodeKeeper.GetAccessor(pn).GetActiveNodes() {
+		if node.ID().Equal(origin.ID()) {
+			continue
+		}
+		err := n.Revocations.Check(node.ID(), node.PublicKey())
+		if err == nil {
+			continue
+		}
+		publicKey, ok := node.PublicKey().(*ecdsa.PublicKey)
+		if ok && n.RevokedKeys.Add(publicKey) {
+			logger.Warnf("evicting node %s: %s", node.ID(), err)
+		}
+	}
+}
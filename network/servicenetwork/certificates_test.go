@@ -0,0 +1,222 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package servicenetwork
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gojuno/minimock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/certificate"
+	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/gen"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/controller"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	networkUtils "github.com/insolar/insolar/testutils/network"
+)
+
+func TestServiceNetwork_CertificateUpdates(t *testing.T) {
+	ctx := context.Background()
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+	defer mc.Wait(time.Minute)
+
+	kp := platformpolicy.NewKeyProcessor()
+	newKey := func() (insolar.CryptographyService, string) {
+		privKey, err := kp.GeneratePrivateKey()
+		require.NoError(t, err)
+		pubKey, err := kp.ExportPublicKeyPEM(kp.ExtractPublicKey(privKey))
+		require.NoError(t, err)
+		return cryptography.NewKeyBoundCryptographyService(privKey), string(pubKey)
+	}
+
+	discoveryCS, discoveryKey := newKey()
+	discoveryPK, err := discoveryCS.GetPublicKey()
+	require.NoError(t, err)
+	discoveryRef := gen.Reference()
+	cert := &certificate.Certificate{MajorityRule: 1}
+	cert.BootstrapNodes = []certificate.BootstrapNode{
+		*certificate.NewBootstrapNode(discoveryPK, discoveryKey, "127.0.0.1:1", discoveryRef.String(), "virtual"),
+	}
+
+	originCS, originKey := newKey()
+	originPK, err := originCS.GetPublicKey()
+	require.NoError(t, err)
+	origin := networkUtils.NewNetworkNodeMock(mc)
+	origin.IDMock.Return(gen.Reference())
+	origin.PublicKeyMock.Return(originPK)
+	otherCS, otherKey := newKey()
+	otherPK, err := otherCS.GetPublicKey()
+	require.NoError(t, err)
+	other := networkUtils.NewNetworkNodeMock(mc)
+	other.IDMock.Return(gen.Reference())
+	other.PublicKeyMock.Return(otherPK)
+
+	accessor := networkUtils.NewAccessorMock(mc)
+	accessor.GetActiveNodesMock.Return([]insolar.NetworkNode{origin, other})
+	nodeKeeper := networkUtils.NewNodeKeeperMock(mc)
+	nodeKeeper.GetOriginMock.Return(origin)
+	nodeKeeper.GetAccessorMock.Return(accessor)
+	pulses := networkUtils.NewPulseAccessorMock(mc)
+	pulses.GetLatestPulseMock.Return(*insolar.GenesisPulse, nil)
+
+	announced := make(chan []byte, 1)
+	synced := make(chan struct{}, 1)
+	rpc := controller.NewRPCControllerMock(mc)
+	rpc.SendBytesMock.Set(func(ctx context.Context, ref insolar.Reference, name string, data []byte) ([]byte, error) {
+		require.Equal(t, other.ID(), ref)
+		switch name {
+		case deliverCertificateUpdate:
+			announced <- data
+			return ack, nil
+		case getRevocationList:
+			synced <- struct{}{}
+			return []byte(`{}`), nil
+		}
+		require.FailNow(t, "unexpected procedure", name)
+		return nil, nil
+	})
+
+	terminationHandler := testutils.NewTerminationHandlerMock(mc)
+	terminationHandler.LeaveMock.Expect(ctx, 0)
+
+	serviceNetwork, err := NewServiceNetwork(configuration.NewConfiguration(), &component.Manager{})
+	require.NoError(t, err)
+	serviceNetwork.CertificateManager = certificate.NewCertificateManager(cert)
+	serviceNetwork.CryptographyService = originCS
+	serviceNetwork.NodeKeeper = nodeKeeper
+	serviceNetwork.PulseAccessor = pulses
+	serviceNetwork.RPC = rpc
+	serviceNetwork.TerminationHandler = terminationHandler
+
+	revocation := certificate.Revocation{Reference: origin.ID().String(), PublicKey: originKey, Reason: "test"}
+	err = serviceNetwork.Revoke(ctx, revocation)
+	require.Error(t, err, "revocation is not signed")
+
+	require.NoError(t, revocation.Sign(discoveryCS, discoveryRef))
+	require.NoError(t, serviceNetwork.Revoke(ctx, revocation))
+
+	var data []byte
+	select {
+	case data = <-announced:
+	case <-time.After(time.Second):
+		require.FailNow(t, "revocation is not announced")
+	}
+
+	// node applies updates when consensus finishes only
+	require.NoError(t, serviceNetwork.Revocations.Check(origin.ID(), originPK))
+	serviceNetwork.applyCertificateUpdates(ctx, network.Report{PulseNumber: insolar.GenesisPulse.PulseNumber})
+	require.Error(t, serviceNetwork.Revocations.Check(origin.ID(), originPK))
+	require.Len(t, serviceNetwork.RevocationList().Revocations(), 1)
+
+	select {
+	case <-synced:
+	case <-time.After(time.Second):
+		require.FailNow(t, "revocation list is not requested")
+	}
+
+	t.Run("received", func(t *testing.T) {
+		res, err := serviceNetwork.processCertificateUpdate(ctx, data)
+		require.NoError(t, err)
+		require.Equal(t, ack, res)
+
+		_, err = serviceNetwork.processCertificateUpdate(ctx, []byte(`{"rotation":{},"revocation":{}}`))
+		require.Error(t, err)
+	})
+
+	t.Run("evicted", func(t *testing.T) {
+		revocation := certificate.Revocation{Reference: other.ID().String(), PublicKey: otherKey, Reason: "test"}
+		require.NoError(t, revocation.Sign(discoveryCS, discoveryRef))
+		data, err := json.Marshal(certificateUpdate{Revocation: &revocation})
+		require.NoError(t, err)
+		_, err = serviceNetwork.processCertificateUpdate(ctx, data)
+		require.NoError(t, err)
+
+		require.False(t, serviceNetwork.RevokedKeys.IsRevoked(otherPK.(*ecdsa.PublicKey)))
+		serviceNetwork.applyCertificateUpdates(ctx, network.Report{PulseNumber: insolar.GenesisPulse.PulseNumber})
+		require.True(t, serviceNetwork.RevokedKeys.IsRevoked(otherPK.(*ecdsa.PublicKey)))
+	})
+
+	t.Run("synced", func(t *testing.T) {
+		list, err := serviceNetwork.processRevocationListRequest(ctx, nil)
+		require.NoError(t, err)
+
+		joinerRPC := controller.NewRPCControllerMock(t)
+		joinerRPC.SendBytesMock.Expect(ctx, other.ID(), getRevocationList, nil).Return(list, nil)
+
+		joiner, err := NewServiceNetwork(configuration.NewConfiguration(), &component.Manager{})
+		require.NoError(t, err)
+		joiner.CertificateManager = certificate.NewCertificateManager(cert)
+		joiner.CryptographyService = originCS
+		joiner.NodeKeeper = nodeKeeper
+		joiner.RPC = joinerRPC
+		joiner.TerminationHandler = terminationHandler
+
+		joiner.revocationsSynced = 1
+		joiner.syncRevocationList(ctx, insolar.GenesisPulse.PulseNumber)
+		require.Equal(t, uint32(1), joiner.revocationsSynced)
+		joiner.applyCertificateUpdates(ctx, network.Report{PulseNumber: insolar.GenesisPulse.PulseNumber})
+
+		require.Len(t, joiner.RevocationList().Revocations(), 2)
+		require.Error(t, joiner.Revocations.Check(other.ID(), otherPK))
+		require.True(t, joiner.RevokedKeys.IsRevoked(otherPK.(*ecdsa.PublicKey)))
+	})
+}
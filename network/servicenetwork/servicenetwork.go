@@ -54,6 +54,8 @@ import (
 	"bytes"
 	"context"
 	"crypto/rand"
+	"sync"
+
 	"github.com/insolar/insolar/network/nodenetwork"
 	"github.com/insolar/insolar/network/storage"
 
@@ -62,11 +64,13 @@ import (
 	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/pkg/errors"
 
+	"github.com/insolar/insolar/certificate"
 	"github.com/insolar/insolar/component"
 	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/insolar"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/blame"
 	"github.com/insolar/insolar/network/consensus"
 	"github.com/insolar/insolar/network/consensus/adapters"
 	"github.com/insolar/insolar/network/consensus/common/endpoints"
@@ -112,6 +116,12 @@ type ServiceNetwork struct {
 	// DB               storage.DB               `inject:"subcomponent"`
 
 	HostNetwork network.HostNetwork
+	// MisbehaviorJournal keeps blame and fraud reports consensus makes about other nodes.
+	MisbehaviorJournal *blame.Journal
+	// Revocations keeps rotated and revoked keys of nodes.
+	Revocations *certificate.RevocationList
+	// RevokedKeys keeps keys of active nodes evicted from consensus after revocation.
+	RevokedKeys *adapters.RevokedKeys
 
 	CurrentPulse insolar.Pulse
 	Gatewayer    network.Gatewayer
@@ -123,12 +133,28 @@ type ServiceNetwork struct {
 	consensusInstaller  consensus.Installer
 	consensusController consensus.Controller
 
+	certUpdatesLock    sync.Mutex
+	pendingCertUpdates []certificateUpdate
+	revocationsSynced  uint32
+
 	ConsensusMode consensus.Mode
 }
 
 // NewServiceNetwork returns a new ServiceNetwork.
 func NewServiceNetwork(conf configuration.Configuration, rootCm *component.Manager) (*ServiceNetwork, error) {
-	serviceNetwork := &ServiceNetwork{cm: component.NewManager(rootCm), cfg: conf, ConsensusMode: consensus.Joiner}
+	revocations, err := certificate.NewRevocationList(conf.Service.RevocationListPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load revocation list")
+	}
+
+	serviceNetwork := &ServiceNetwork{
+		cm:                 component.NewManager(rootCm),
+		cfg:                conf,
+		ConsensusMode:      consensus.Joiner,
+		MisbehaviorJournal: blame.NewJournal(conf.Service.Blame),
+		Revocations:        revocations,
+		RevokedKeys:        adapters.NewRevokedKeys(),
+	}
 	return serviceNetwork, nil
 }
 
@@ -149,7 +175,7 @@ func (n *ServiceNetwork) Init(ctx context.Context) error {
 		return errors.Wrap(err, "failed to create NodeNetwork")
 	}
 
-	n.BaseGateway = &gateway.Base{Options: options}
+	n.BaseGateway = &gateway.Base{Options: options, Revocations: n.Revocations}
 	n.Gatewayer = gateway.NewGatewayer(n.BaseGateway.NewGateway(ctx, insolar.NoNetworkState))
 
 	table := &routing.Table{}
@@ -158,15 +184,17 @@ func (n *ServiceNetwork) Init(ctx context.Context) error {
 		table,
 		cert,
 		transport.NewFactory(n.cfg.Host.Transport),
+		transport.NewNodeKeyResolver(options, n.Revocations),
 		hostNetwork,
 		nodeNetwork,
 		controller.NewRPCController(options),
-		controller.NewPulseController(),
+		controller.NewPulseController(options),
 		bootstrap.NewRequester(options),
 		storage.NewMemoryStorage(),
 		n.BaseGateway,
 		n.Gatewayer,
 		storage.NewMemoryStorage(),
+		n.MisbehaviorJournal,
 	)
 
 	n.datagramHandler = adapters.NewDatagramHandler()
@@ -218,6 +246,8 @@ func (n *ServiceNetwork) Init(ctx context.Context) error {
 		StateUpdater:        n,
 		DatagramTransport:   n.datagramTransport,
 		EphemeralController: n,
+		MisbehaviorJournal:  n.MisbehaviorJournal,
+		RevokedKeys:         n.RevokedKeys,
 	})
 
 	return nil
@@ -234,6 +264,7 @@ func (n *ServiceNetwork) initConsensus() {
 	n.consensusController.RegisterFinishedNotifier(func(ctx context.Context, report network.Report) {
 		n.Gatewayer.Gateway().OnConsensusFinished(ctx, report)
 	})
+	n.consensusController.RegisterFinishedNotifier(n.applyCertificateUpdates)
 	n.BaseGateway.ConsensusController = n.consensusController
 	n.BaseGateway.ConsensusPulseHandler = pulseHandler
 }
@@ -251,6 +282,8 @@ func (n *ServiceNetwork) Start(ctx context.Context) error {
 	n.Gatewayer.Gateway().Run(ctx, bootstrapPulse)
 
 	n.RPC.RemoteProcedureRegister(deliverWatermillMsg, n.processIncoming)
+	n.RPC.RemoteProcedureRegister(deliverCertificateUpdate, n.processCertificateUpdate)
+	n.RPC.RemoteProcedureRegister(getRevocationList, n.processRevocationListRequest)
 
 	return nil
 }
@@ -259,8 +292,18 @@ func (n *ServiceNetwork) Leave(ctx context.Context, eta insolar.PulseNumber) {
 	logger := inslogger.FromContext(ctx)
 	logger.Info("Gracefully stopping service network")
 
-	// TODO: fix leave
-	n.consensusController.Leave(0)
+	// TODO: leave at eta
+	left := n.consensusController.Leave(0)
+	go func() {
+		<-left
+		logger.Info("Leaving claim is accepted by network")
+		n.TerminationHandler.OnLeaveApproved(ctx)
+	}()
+}
+
+func (n *ServiceNetwork) PrepareLeave(ctx context.Context) <-chan struct{} {
+	inslogger.FromContext(ctx).Info("Requesting zero power, node will not receive new tasks")
+	return n.consensusController.PrepareLeave()
 }
 
 func (n *ServiceNetwork) GracefulStop(ctx context.Context) error {
@@ -53,6 +53,9 @@ package termination
 import (
 	"context"
 	"sync"
+	"time"
+
+	"github.com/pkg/errors"
 
 	"github.com/insolar/insolar/insolar/pulse"
 	"github.com/insolar/insolar/instrumentation/inslogger"
@@ -60,17 +63,27 @@ import (
 	"github.com/insolar/insolar/insolar"
 )
 
+// drainCheckInterval is how often drainers are asked for progress during decommission.
+const drainCheckInterval = time.Second
+
 type terminationHandler struct {
 	sync.Mutex
 	done        chan insolar.LeaveApproved
 	terminating bool
+	left        bool
+
+	drainers      []insolar.Drainer
+	checkInterval time.Duration
+	decommission  insolar.DecommissionStatus
 
 	Leaver        insolar.Leaver `inject:""`
 	PulseAccessor pulse.Accessor `inject:""`
 }
 
-func NewHandler(l insolar.Leaver) insolar.TerminationHandler {
-	return &terminationHandler{Leaver: l}
+// NewHandler creates termination handler. Decommission waits until drainers hand over their work
+// before node leaves network.
+func NewHandler(l insolar.Leaver, drainers ...insolar.Drainer) insolar.TerminationHandler {
+	return &terminationHandler{Leaver: l, drainers: drainers}
 }
 
 // TODO take ETA by role of node
@@ -83,7 +96,7 @@ func (t *terminationHandler) leave(ctx context.Context, leaveAfterPulses insolar
 	t.Lock()
 	defer t.Unlock()
 
-	if !t.terminating {
+	if !t.terminating && !t.left {
 		t.terminating = true
 		t.done = make(chan insolar.LeaveApproved, 1)
 
@@ -111,6 +124,10 @@ func (t *terminationHandler) OnLeaveApproved(ctx context.Context) {
 	if t.terminating {
 		inslogger.FromContext(ctx).Debug("terminationHandler.OnLeaveApproved() received")
 		t.terminating = false
+		t.left = true
+		if t.decommission.State == insolar.DecommissionLeaving {
+			t.decommission.State = insolar.DecommissionLeft
+		}
 		close(t.done)
 	}
 }
@@ -122,3 +139,82 @@ func (t *terminationHandler) Abort(reason string) {
 func (t *terminationHandler) Terminating() bool {
 	return t.terminating
 }
+
+func (t *terminationHandler) Decommission(ctx context.Context) error {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.terminating || t.left {
+		return errors.New("node is already leaving network")
+	}
+	t.terminating = true
+	t.done = make(chan insolar.LeaveApproved, 1)
+	t.decommission = insolar.DecommissionStatus{
+		State:     insolar.DecommissionDraining,
+		StartedAt: time.Now(),
+	}
+
+	go t.runDecommission(ctx)
+	return nil
+}
+
+func (t *terminationHandler) runDecommission(ctx context.Context) {
+	logger := inslogger.FromContext(ctx)
+
+	logger.Info("[ Decommission ] waiting until network stops to assign new tasks")
+	<-t.Leaver.PrepareLeave(ctx)
+
+	logger.Info("[ Decommission ] waiting until work is handed over")
+	t.setDecommissionState(insolar.DecommissionHandoff)
+	interval := t.checkInterval
+	if interval == 0 {
+		interval = drainCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for !t.drained(ctx) {
+		<-ticker.C
+	}
+
+	logger.Info("[ Decommission ] work is handed over, leaving network")
+	t.setDecommissionState(insolar.DecommissionLeaving)
+	t.Leaver.Leave(ctx, 0)
+}
+
+// drained collects progress of drainers and checks if all of them are done.
+func (t *terminationHandler) drained(ctx context.Context) bool {
+	done := true
+	progress := make([]insolar.DrainProgress, 0, len(t.drainers))
+	for _, d := range t.drainers {
+		p := d.DrainProgress(ctx)
+		done = done && p.Done
+		progress = append(progress, p)
+	}
+
+	t.Lock()
+	t.decommission.Components = progress
+	t.Unlock()
+
+	return done
+}
+
+func (t *terminationHandler) setDecommissionState(state insolar.DecommissionState) {
+	t.Lock()
+	defer t.Unlock()
+	t.decommission.State = state
+}
+
+func (t *terminationHandler) DecommissionStatus() insolar.DecommissionStatus {
+	t.Lock()
+	defer t.Unlock()
+
+	status := t.decommission
+	if status.State == "" {
+		status.State = insolar.DecommissionNone
+		if t.left {
+			status.State = insolar.DecommissionLeft
+		}
+	}
+	status.Components = append([]insolar.DrainProgress(nil), t.decommission.Components...)
+	return status
+}
@@ -170,3 +170,71 @@ func (s *AbortTestSuite) TestBasicUsage() {
 
 	s.handler.Abort("abort")
 }
+
+func TestDecommission(t *testing.T) {
+	suite.Run(t, new(DecommissionTestSuite))
+}
+
+type DecommissionTestSuite struct {
+	CommonTestSuite
+}
+
+func (s *DecommissionTestSuite) TestInitialStatus() {
+	s.Equal(insolar.DecommissionNone, s.handler.DecommissionStatus().State)
+}
+
+func (s *DecommissionTestSuite) TestBasicUsage() {
+	prepared := make(chan struct{})
+	close(prepared)
+	s.leaver.PrepareLeaveMock.Return(prepared)
+	s.leaver.LeaveMock.Set(func(ctx context.Context, eta insolar.PulseNumber) {
+		s.Equal(insolar.PulseNumber(0), eta)
+		s.handler.OnLeaveApproved(ctx)
+	})
+
+	drainer := testutils.NewDrainerMock(s.T())
+	drainer.DrainProgressMock.Set(func(ctx context.Context) insolar.DrainProgress {
+		if drainer.DrainProgressBeforeCounter() < 3 {
+			return insolar.DrainProgress{Component: "test", Pending: 1}
+		}
+		return insolar.DrainProgress{Component: "test", Done: true}
+	})
+	s.handler.drainers = []insolar.Drainer{drainer}
+	s.handler.checkInterval = time.Millisecond
+
+	err := s.handler.Decommission(s.ctx)
+	s.Require().NoError(err)
+	s.True(s.handler.Terminating())
+
+	select {
+	case <-s.handler.done:
+	case <-time.After(time.Second):
+		s.FailNow("done chanel doesn't close")
+	}
+
+	status := s.handler.DecommissionStatus()
+	s.Equal(insolar.DecommissionLeft, status.State)
+	s.False(status.StartedAt.IsZero())
+	s.Equal([]insolar.DrainProgress{{Component: "test", Done: true}}, status.Components)
+	s.Equal(uint64(3), drainer.DrainProgressAfterCounter())
+	s.False(s.handler.Terminating())
+
+	// node has left, nothing to do
+	s.handler.Leave(s.ctx, 0)
+	s.Error(s.handler.Decommission(s.ctx))
+}
+
+func (s *DecommissionTestSuite) TestWaitsForPrepareLeave() {
+	prepared := make(chan struct{})
+	s.leaver.PrepareLeaveMock.Return(prepared)
+
+	err := s.handler.Decommission(s.ctx)
+	s.Require().NoError(err)
+
+	status := s.handler.DecommissionStatus()
+	s.Equal(insolar.DecommissionDraining, status.State)
+	s.Empty(status.Components)
+
+	err = s.handler.Decommission(s.ctx)
+	s.Error(err)
+}
@@ -570,6 +570,7 @@ func (s *testSuite) preInitNode(node *networkNode) {
 	cfg := configuration.NewConfiguration()
 	cfg.Pulsar.PulseTime = pulseDelta * 1000
 	cfg.Host.Transport.Address = node.host
+	cfg.Host.TrustAnyPulsar = true
 	cfg.Service.CacheDirectory = cacheDir + node.host
 
 	node.componentManager = &component.Manager{}
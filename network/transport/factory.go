@@ -62,8 +62,12 @@ type Factory interface {
 	CreateDatagramTransport(DatagramHandler) (DatagramTransport, error)
 }
 
-// NewFactory constructor creates new transport factory
+// NewFactory constructor creates new transport factory.
+// Factory for "SecureTCP" protocol requires PeerKeyResolver, CryptographyService and KeyProcessor components.
 func NewFactory(cfg configuration.Transport) Factory {
+	if cfg.Protocol == secureTCPProtocol {
+		return &secureFactory{cfg: cfg}
+	}
 	return &factory{cfg: cfg}
 }
 
@@ -63,50 +63,69 @@ import (
 	"github.com/insolar/insolar/log"
 )
 
-var (
+// FakeNetwork is an in-memory network of fake transports. Transports created by factories of the same network
+// reach each other by address, transports of different networks are isolated.
+type FakeNetwork struct {
 	udpMutex         sync.RWMutex
-	datagramHandlers = make(map[string]DatagramHandler)
+	datagramHandlers map[string]DatagramHandler
 	tcpMutex         sync.RWMutex
-	streamHandlers   = make(map[string]StreamHandler)
-)
+	streamHandlers   map[string]StreamHandler
+}
+
+// NewFakeNetwork creates new isolated in-memory network.
+func NewFakeNetwork() *FakeNetwork {
+	return &FakeNetwork{
+		datagramHandlers: make(map[string]DatagramHandler),
+		streamHandlers:   make(map[string]StreamHandler),
+	}
+}
+
+var defaultFakeNetwork = NewFakeNetwork()
 
-// NewFakeFactory constructor creates new fake transport factory
+// NewFakeFactory constructor creates new fake transport factory on the default fake network
 func NewFakeFactory(cfg configuration.Transport) Factory {
-	return &fakeFactory{cfg: cfg}
+	return defaultFakeNetwork.NewFactory(cfg)
+}
+
+// NewFactory creates new fake transport factory which transports are attached to the network
+func (n *FakeNetwork) NewFactory(cfg configuration.Transport) Factory {
+	return &fakeFactory{cfg: cfg, network: n}
 }
 
 type fakeFactory struct {
-	cfg configuration.Transport
+	cfg     configuration.Transport
+	network *FakeNetwork
 }
 
 // CreateStreamTransport creates fake StreamTransport for tests
 func (f *fakeFactory) CreateStreamTransport(handler StreamHandler) (StreamTransport, error) {
-	return &fakeStreamTransport{address: f.cfg.Address, handler: handler}, nil
+	return &fakeStreamTransport{network: f.network, address: f.cfg.Address, handler: handler}, nil
 }
 
 // CreateDatagramTransport creates fake DatagramTransport for tests
 func (f *fakeFactory) CreateDatagramTransport(handler DatagramHandler) (DatagramTransport, error) {
-	return &fakeDatagramTransport{address: f.cfg.Address, handler: handler}, nil
+	return &fakeDatagramTransport{network: f.network, address: f.cfg.Address, handler: handler}, nil
 }
 
 type fakeDatagramTransport struct {
+	network *FakeNetwork
 	address string
 	handler DatagramHandler
 }
 
 func (f *fakeDatagramTransport) Start(ctx context.Context) error {
-	udpMutex.Lock()
-	defer udpMutex.Unlock()
+	f.network.udpMutex.Lock()
+	defer f.network.udpMutex.Unlock()
 
-	datagramHandlers[f.address] = f.handler
+	f.network.datagramHandlers[f.address] = f.handler
 	return nil
 }
 
 func (f *fakeDatagramTransport) Stop(ctx context.Context) error {
-	udpMutex.Lock()
-	defer udpMutex.Unlock()
+	f.network.udpMutex.Lock()
+	defer f.network.udpMutex.Unlock()
 
-	datagramHandlers[f.address] = nil
+	f.network.datagramHandlers[f.address] = nil
 	return nil
 }
 
@@ -122,10 +141,10 @@ func (f *fakeDatagramTransport) SendDatagram(ctx context.Context, address string
 		return errors.Wrap(err, "Failed to resolve UDP address")
 	}
 
-	udpMutex.RLock()
-	defer udpMutex.RUnlock()
+	f.network.udpMutex.RLock()
+	defer f.network.udpMutex.RUnlock()
 
-	h := datagramHandlers[address]
+	h := f.network.datagramHandlers[address]
 	if h != nil {
 		go h.HandleDatagram(ctx, f.address, data)
 	}
@@ -138,6 +157,7 @@ func (f *fakeDatagramTransport) Address() string {
 }
 
 type fakeStreamTransport struct {
+	network *FakeNetwork
 	address string
 	handler StreamHandler
 	cancel  context.CancelFunc
@@ -145,30 +165,30 @@ type fakeStreamTransport struct {
 }
 
 func (f *fakeStreamTransport) Start(ctx context.Context) error {
-	tcpMutex.Lock()
-	defer tcpMutex.Unlock()
+	f.network.tcpMutex.Lock()
+	defer f.network.tcpMutex.Unlock()
 
 	f.ctx, f.cancel = context.WithCancel(ctx)
-	streamHandlers[f.address] = f.handler
+	f.network.streamHandlers[f.address] = f.handler
 	return nil
 }
 
 func (f *fakeStreamTransport) Stop(ctx context.Context) error {
-	tcpMutex.Lock()
-	defer tcpMutex.Unlock()
+	f.network.tcpMutex.Lock()
+	defer f.network.tcpMutex.Unlock()
 
 	f.cancel()
-	streamHandlers[f.address] = nil
+	f.network.streamHandlers[f.address] = nil
 	return nil
 }
 
 func (f *fakeStreamTransport) Dial(ctx context.Context, address string) (io.ReadWriteCloser, error) {
 	log.Debug("fakeStreamTransport Dial from %s to %s", f.address, address)
 
-	tcpMutex.RLock()
-	defer tcpMutex.RUnlock()
+	f.network.tcpMutex.RLock()
+	defer f.network.tcpMutex.RUnlock()
 
-	h := streamHandlers[address]
+	h := f.network.streamHandlers[address]
 
 	if h == nil {
 		return nil, errors.New("fakeStreamTransport: dial failed")
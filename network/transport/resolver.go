@@ -0,0 +1,199 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package transport
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/certificate"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/storage"
+)
+
+// NewNodeKeyResolver creates PeerKeyResolver for network node. Key of a peer must match key of the node
+// from discovery list of certificate or from active list. Peers unknown yet (joiners) are accepted
+// only with authorization certificate signed by all discovery nodes. Peer without reference is a pulsar
+// and is checked by certificate pulsar keys, any pulsar is accepted only if TrustAnyPulsar option is set.
+func NewNodeKeyResolver(options *network.Options, revocations *certificate.RevocationList) PeerKeyResolver {
+	return &nodeKeyResolver{options: options, revocations: revocations}
+}
+
+type nodeKeyResolver struct {
+	CertificateManager  insolar.CertificateManager  `inject:""`
+	CryptographyService insolar.CryptographyService `inject:""`
+	KeyProcessor        insolar.KeyProcessor        `inject:""`
+	NodeKeeper          network.NodeKeeper          `inject:""`
+	PulseAccessor       storage.PulseAccessor       `inject:""`
+
+	options     *network.Options
+	revocations *certificate.RevocationList
+}
+
+func (r *nodeKeyResolver) LocalReference() insolar.Reference {
+	return *r.CertificateManager.GetCertificate().GetNodeRef()
+}
+
+func (r *nodeKeyResolver) LocalCertificate() ([]byte, error) {
+	cert := r.CertificateManager.GetCertificate()
+	if c, ok := cert.(*certificate.Certificate); ok {
+		return certificate.Serialize(&c.AuthorizationCertificate)
+	}
+	return certificate.Serialize(cert)
+}
+
+func (r *nodeKeyResolver) CheckPeer(ctx context.Context, ref insolar.Reference, key crypto.PublicKey, authCert []byte) error {
+	cert := r.CertificateManager.GetCertificate()
+
+	if ref.IsEmpty() {
+		pulsarKeys := cert.GetPulsarPublicKeys()
+		if len(pulsarKeys) == 0 {
+			if r.options != nil && r.options.TrustAnyPulsar {
+				return nil
+			}
+			return errors.New("no trusted pulsar keys in certificate")
+		}
+		for _, pulsarKey := range pulsarKeys {
+			if r.sameKeys(pulsarKey, key) {
+				return nil
+			}
+		}
+		return errors.New("key doesn't belong to any trusted pulsar")
+	}
+
+	known := r.knownKey(ctx, cert, ref)
+	if known == nil {
+		return r.checkJoiner(cert, ref, key, authCert)
+	}
+	if !r.sameKeys(known, key) {
+		return errors.New("key doesn't match key of the node")
+	}
+	return r.revocations.Check(ref, key)
+}
+
+// checkJoiner accepts peer unknown yet if it presents certificate signed by all discovery nodes.
+func (r *nodeKeyResolver) checkJoiner(cert insolar.Certificate, ref insolar.Reference, key crypto.PublicKey, authCert []byte) error {
+	if len(authCert) == 0 {
+		return errors.New("unknown node without certificate")
+	}
+	peerCert, err := certificate.Deserialize(authCert, r.KeyProcessor)
+	if err != nil {
+		return errors.Wrap(err, "invalid certificate")
+	}
+	if peerRef := peerCert.GetNodeRef(); peerRef == nil || !peerRef.Equal(ref) {
+		return errors.New("certificate doesn't belong to the node")
+	}
+	if !r.sameKeys(peerCert.GetPublicKey(), key) {
+		return errors.New("key doesn't match key from certificate")
+	}
+	valid, err := certificate.VerifyAuthorizationCertificate(r.CryptographyService, cert.GetDiscoveryNodes(), r.revocations, peerCert)
+	if err != nil {
+		return errors.Wrap(err, "failed to verify certificate")
+	}
+	if !valid {
+		return errors.New("certificate isn't signed by discovery nodes")
+	}
+	return nil
+}
+
+func (r *nodeKeyResolver) knownKey(ctx context.Context, cert insolar.Certificate, ref insolar.Reference) crypto.PublicKey {
+	for _, discovery := range cert.GetDiscoveryNodes() {
+		if discovery.GetNodeRef().Equal(ref) {
+			return discovery.GetPublicKey()
+		}
+	}
+
+	p, err := r.PulseAccessor.GetLatestPulse(ctx)
+	if err != nil {
+		return nil
+	}
+	node := r.NodeKeeper.GetAccessor(p.PulseNumber).GetActiveNode(ref)
+	if node == nil {
+		return nil
+	}
+	return node.PublicKey()
+}
+
+func (r *nodeKeyResolver) sameKeys(a, b crypto.PublicKey) bool {
+	aBytes, err := r.KeyProcessor.ExportPublicKeyBinary(a)
+	if err != nil {
+		return false
+	}
+	bBytes, err := r.KeyProcessor.ExportPublicKeyBinary(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(aBytes, bBytes)
+}
+
+// NewPulsarKeyResolver creates PeerKeyResolver for pulsar. Pulsar has no reference
+// and trusts any node that proves it owns the key it presents.
+func NewPulsarKeyResolver() PeerKeyResolver {
+	return &pulsarKeyResolver{}
+}
+
+type pulsarKeyResolver struct{}
+
+func (pulsarKeyResolver) LocalReference() insolar.Reference {
+	return insolar.Reference{}
+}
+
+func (pulsarKeyResolver) LocalCertificate() ([]byte, error) {
+	return nil, nil
+}
+
+func (pulsarKeyResolver) CheckPeer(context.Context, insolar.Reference, crypto.PublicKey, []byte) error {
+	return nil
+}
@@ -0,0 +1,485 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package transport
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/network"
+)
+
+const (
+	secureTCPProtocol = "SecureTCP"
+
+	secureHandshakeTimeout = 5 * time.Second
+	secureProtocolVersion  = 1
+	// secureMaxFrameSize is a maximum size of plaintext in one encrypted frame.
+	secureMaxFrameSize = 64 * 1024
+	// secureMaxHandshakeMessage limits size of handshake messages read from not authenticated peer.
+	// Hello carries authorization certificate with signatures of all discovery nodes.
+	secureMaxHandshakeMessage = 32 * 1024
+
+	roleInitiator byte = 'I'
+	roleResponder byte = 'R'
+)
+
+var secureTranscriptLabel = []byte("insolar secure stream transport")
+
+type remoteNodeKey struct{}
+
+// WithRemoteNode returns context that makes secure StreamTransport refuse Dial
+// if remote peer is not the node with given reference.
+func WithRemoteNode(ctx context.Context, ref insolar.Reference) context.Context {
+	return context.WithValue(ctx, remoteNodeKey{}, ref)
+}
+
+func remoteNodeFromContext(ctx context.Context) (insolar.Reference, bool) {
+	ref, ok := ctx.Value(remoteNodeKey{}).(insolar.Reference)
+	return ref, ok && !ref.IsEmpty()
+}
+
+// secureFactory creates StreamTransport which authenticates peers by certificate keys and encrypts traffic.
+type secureFactory struct {
+	cfg configuration.Transport
+
+	CryptographyService insolar.CryptographyService `inject:""`
+	KeyProcessor        insolar.KeyProcessor        `inject:""`
+	PeerKeyResolver     PeerKeyResolver             `inject:""`
+}
+
+// CreateStreamTransport creates new secure TCP transport
+func (f *secureFactory) CreateStreamTransport(handler StreamHandler) (StreamTransport, error) {
+	if f.CryptographyService == nil || f.KeyProcessor == nil || f.PeerKeyResolver == nil {
+		return nil, errors.New("secure transport dependencies are not injected")
+	}
+	return newSecureTransport(f.cfg, handler, f.CryptographyService, f.KeyProcessor, f.PeerKeyResolver), nil
+}
+
+// CreateDatagramTransport creates new UDP transport, datagrams are signed by consensus itself
+func (f *secureFactory) CreateDatagramTransport(handler DatagramHandler) (DatagramTransport, error) {
+	return newUDPTransport(f.cfg.Address, f.cfg.FixedPublicAddress, handler), nil
+}
+
+type secureTransport struct {
+	*tcpTransport
+
+	handler   StreamHandler
+	cs        insolar.CryptographyService
+	kp        insolar.KeyProcessor
+	resolver  PeerKeyResolver
+	publicKey []byte
+}
+
+func newSecureTransport(
+	cfg configuration.Transport,
+	handler StreamHandler,
+	cs insolar.CryptographyService,
+	kp insolar.KeyProcessor,
+	resolver PeerKeyResolver,
+) *secureTransport {
+	t := &secureTransport{
+		handler:  handler,
+		cs:       cs,
+		kp:       kp,
+		resolver: resolver,
+	}
+	t.tcpTransport = newTCPTransport(cfg.Address, cfg.FixedPublicAddress, t)
+	return t
+}
+
+func (t *secureTransport) Start(ctx context.Context) error {
+	publicKey, err := t.cs.GetPublicKey()
+	if err != nil {
+		return errors.Wrap(err, "failed to get node public key")
+	}
+	t.publicKey, err = t.kp.ExportPublicKeyBinary(publicKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to export node public key")
+	}
+
+	return t.tcpTransport.Start(ctx)
+}
+
+// Dial opens connection and makes handshake. If reference of remote node is set
+// in context with WithRemoteNode, peer with another reference is refused.
+func (t *secureTransport) Dial(ctx context.Context, address string) (io.ReadWriteCloser, error) {
+	conn, err := t.tcpTransport.Dial(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	secured, err := t.handshake(ctx, conn, roleInitiator)
+	if err != nil {
+		network.CloseVerbose(conn)
+		inslogger.FromContext(ctx).Warnf("[ Dial ] Secure handshake with %s failed: %s", address, err)
+		return nil, errors.Wrap(err, "[ Dial ] Secure handshake failed")
+	}
+
+	if expected, ok := remoteNodeFromContext(ctx); ok && !secured.peer.Equal(expected) {
+		network.CloseVerbose(conn)
+		return nil, errors.Errorf("[ Dial ] Peer %s is %s, expected %s", address, secured.peer, expected)
+	}
+
+	return secured, nil
+}
+
+// HandleStream makes handshake with accepted connection before passing it to handler.
+func (t *secureTransport) HandleStream(ctx context.Context, address string, stream io.ReadWriteCloser) {
+	secured, err := t.handshake(ctx, stream, roleResponder)
+	if err != nil {
+		network.CloseVerbose(stream)
+		inslogger.FromContext(ctx).Warnf("[ HandleStream ] Secure handshake with %s failed: %s", address, err)
+		return
+	}
+
+	t.handler.HandleStream(ctx, address, secured)
+}
+
+// hello is the first handshake message, peers exchange their identities, certificates and ephemeral keys.
+type hello struct {
+	version      byte
+	ref          insolar.Reference
+	publicKey    []byte
+	ephemeralKey []byte
+	certificate  []byte
+}
+
+func (h *hello) marshal() []byte {
+	var buf bytes.Buffer
+	buf.WriteByte(h.version)
+	buf.Write(h.ref.Bytes())
+	writeChunk(&buf, h.publicKey)
+	writeChunk(&buf, h.ephemeralKey)
+	writeChunk(&buf, h.certificate)
+	return buf.Bytes()
+}
+
+func unmarshalHello(data []byte) (*hello, error) {
+	r := bytes.NewReader(data)
+	h := &hello{}
+
+	var err error
+	if h.version, err = r.ReadByte(); err != nil {
+		return nil, err
+	}
+	if h.version != secureProtocolVersion {
+		return nil, errors.Errorf("unsupported protocol version %d", h.version)
+	}
+
+	ref := make([]byte, insolar.RecordRefSize)
+	if _, err = io.ReadFull(r, ref); err != nil {
+		return nil, err
+	}
+	if err = h.ref.Unmarshal(ref); err != nil {
+		return nil, errors.Wrap(err, "invalid node reference")
+	}
+	if h.publicKey, err = readChunk(r, secureMaxHandshakeMessage); err != nil {
+		return nil, err
+	}
+	if h.ephemeralKey, err = readChunk(r, secureMaxHandshakeMessage); err != nil {
+		return nil, err
+	}
+	if h.certificate, err = readChunk(r, secureMaxHandshakeMessage); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// handshake authenticates peer and derives session keys:
+//  1. peers exchange hello with reference, certificate key, authorization certificate and ephemeral ECDH key;
+//  2. each peer signs hash of both hellos and its role with certificate key;
+//  3. peer key and certificate are checked by PeerKeyResolver;
+//  4. keys for each direction are derived from ECDH secret and handshake hash.
+func (t *secureTransport) handshake(ctx context.Context, conn io.ReadWriteCloser, role byte) (*secureConn, error) {
+	if nc, ok := conn.(net.Conn); ok {
+		if err := nc.SetDeadline(time.Now().Add(secureHandshakeTimeout)); err != nil {
+			return nil, errors.Wrap(err, "failed to set handshake deadline")
+		}
+		defer nc.SetDeadline(time.Time{}) // nolint: errcheck
+	}
+
+	curve := elliptic.P256()
+	ephemeralPrivate, x, y, err := elliptic.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate ephemeral key")
+	}
+
+	localCert, err := t.resolver.LocalCertificate()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get local certificate")
+	}
+
+	local := &hello{
+		version:      secureProtocolVersion,
+		ref:          t.resolver.LocalReference(),
+		publicKey:    t.publicKey,
+		ephemeralKey: elliptic.Marshal(curve, x, y),
+		certificate:  localCert,
+	}
+	localHello := local.marshal()
+	if err := writeChunk(conn, localHello); err != nil {
+		return nil, errors.Wrap(err, "failed to send hello")
+	}
+	remoteHello, err := readChunk(conn, secureMaxHandshakeMessage)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to receive hello")
+	}
+	remote, err := unmarshalHello(remoteHello)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid hello")
+	}
+
+	peerX, peerY := elliptic.Unmarshal(curve, remote.ephemeralKey)
+	if peerX == nil {
+		return nil, errors.New("invalid ephemeral key")
+	}
+	peerKey, err := t.kp.ImportPublicKeyBinary(remote.publicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid peer public key")
+	}
+
+	peerRole := roleResponder
+	transcript := sha256.New()
+	transcript.Write(secureTranscriptLabel) // nolint: errcheck
+	if role == roleInitiator {
+		transcript.Write(localHello)  // nolint: errcheck
+		transcript.Write(remoteHello) // nolint: errcheck
+	} else {
+		peerRole = roleInitiator
+		transcript.Write(remoteHello) // nolint: errcheck
+		transcript.Write(localHello)  // nolint: errcheck
+	}
+	digest := transcript.Sum(nil)
+
+	signature, err := t.cs.Sign(append(digest, role))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign handshake")
+	}
+	if err := writeChunk(conn, signature.Bytes()); err != nil {
+		return nil, errors.Wrap(err, "failed to send signature")
+	}
+	peerSignature, err := readChunk(conn, secureMaxHandshakeMessage)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to receive signature")
+	}
+	if !t.cs.Verify(peerKey, insolar.SignatureFromBytes(peerSignature), append(digest, peerRole)) {
+		return nil, errors.New("invalid handshake signature")
+	}
+	if err := t.resolver.CheckPeer(ctx, remote.ref, peerKey, remote.certificate); err != nil {
+		return nil, errors.Wrapf(err, "peer %s is refused", remote.ref)
+	}
+
+	sharedX, _ := curve.ScalarMult(peerX, peerY, ephemeralPrivate)
+	secret := sharedX.Bytes()
+	initiatorKey := deriveKey(secret, digest, roleInitiator)
+	responderKey := deriveKey(secret, digest, roleResponder)
+	if role == roleInitiator {
+		return newSecureConn(conn, remote.ref, responderKey, initiatorKey)
+	}
+	return newSecureConn(conn, remote.ref, initiatorKey, responderKey)
+}
+
+func deriveKey(secret []byte, digest []byte, role byte) []byte {
+	h := sha256.New()
+	h.Write(secret)       // nolint: errcheck
+	h.Write(digest)       // nolint: errcheck
+	h.Write([]byte{role}) // nolint: errcheck
+	return h.Sum(nil)
+}
+
+func writeChunk(w io.Writer, data []byte) error {
+	header := make([]byte, 4)
+	binary.BigEndian.PutUint32(header, uint32(len(data)))
+	_, err := w.Write(append(header, data...))
+	return err
+}
+
+func readChunk(r io.Reader, limit int) ([]byte, error) {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(header)
+	if size > uint32(limit) {
+		return nil, errors.Errorf("message size %d exceeds limit %d", size, limit)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// secureConn encrypts data with AES-GCM. Each direction has its own key, frame number is used as nonce.
+type secureConn struct {
+	conn io.ReadWriteCloser
+	peer insolar.Reference
+
+	readLock    sync.Mutex
+	readCipher  cipher.AEAD
+	readNumber  uint64
+	readPending []byte
+
+	writeLock   sync.Mutex
+	writeCipher cipher.AEAD
+	writeNumber uint64
+}
+
+func newSecureConn(conn io.ReadWriteCloser, peer insolar.Reference, readKey, writeKey []byte) (*secureConn, error) {
+	readCipher, err := newAEAD(readKey)
+	if err != nil {
+		return nil, err
+	}
+	writeCipher, err := newAEAD(writeKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &secureConn{
+		conn:        conn,
+		peer:        peer,
+		readCipher:  readCipher,
+		writeCipher: writeCipher,
+	}, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create cipher")
+	}
+	return cipher.NewGCM(block)
+}
+
+func frameNonce(aead cipher.AEAD, number uint64) []byte {
+	nonce := make([]byte, aead.NonceSize())
+	binary.BigEndian.PutUint64(nonce[len(nonce)-8:], number)
+	return nonce
+}
+
+func (c *secureConn) Write(data []byte) (int, error) {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	written := 0
+	for written < len(data) {
+		size := len(data) - written
+		if size > secureMaxFrameSize {
+			size = secureMaxFrameSize
+		}
+
+		frame := c.writeCipher.Seal(nil, frameNonce(c.writeCipher, c.writeNumber), data[written:written+size], nil)
+		c.writeNumber++
+		if err := writeChunk(c.conn, frame); err != nil {
+			return written, err
+		}
+		written += size
+	}
+	return written, nil
+}
+
+func (c *secureConn) Read(data []byte) (int, error) {
+	c.readLock.Lock()
+	defer c.readLock.Unlock()
+
+	if len(c.readPending) == 0 {
+		frame, err := readChunk(c.conn, secureMaxFrameSize+c.readCipher.Overhead())
+		if err != nil {
+			return 0, err
+		}
+		c.readPending, err = c.readCipher.Open(frame[:0], frameNonce(c.readCipher, c.readNumber), frame, nil)
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to decrypt frame")
+		}
+		c.readNumber++
+	}
+
+	n := copy(data, c.readPending)
+	c.readPending = c.readPending[n:]
+	return n, nil
+}
+
+// Peer returns reference of the peer authenticated by handshake.
+func (c *secureConn) Peer() insolar.Reference {
+	return c.peer
+}
+
+func (c *secureConn) Close() error {
+	return c.conn.Close()
+}
+
+// PeerKeyResolver provides identities for secure StreamTransport handshake.
+type PeerKeyResolver interface {
+	// LocalReference returns reference presented to peers, it is empty for pulsar.
+	LocalReference() insolar.Reference
+	// LocalCertificate returns serialized authorization certificate presented to peers, it is empty for pulsar.
+	LocalCertificate() ([]byte, error)
+	// CheckPeer returns error if key doesn't belong to node with given reference
+	// or peer is unknown and its authorization certificate isn't valid.
+	CheckPeer(ctx context.Context, ref insolar.Reference, key crypto.PublicKey, cert []byte) error
+}
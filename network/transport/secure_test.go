@@ -0,0 +1,367 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package transport
+
+import (
+	"context"
+	"crypto"
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+
+	"github.com/gojuno/minimock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/insolar/insolar/certificate"
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/gen"
+	network2 "github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/network"
+)
+
+// staticKeyResolver knows keys of some nodes and refuses peers with other keys.
+type staticKeyResolver struct {
+	kp    insolar.KeyProcessor
+	local insolar.Reference
+	keys  map[insolar.Reference]crypto.PublicKey
+}
+
+func (r *staticKeyResolver) LocalReference() insolar.Reference {
+	return r.local
+}
+
+func (r *staticKeyResolver) LocalCertificate() ([]byte, error) {
+	return nil, nil
+}
+
+func (r *staticKeyResolver) CheckPeer(_ context.Context, ref insolar.Reference, key crypto.PublicKey, _ []byte) error {
+	known, ok := r.keys[ref]
+	if !ok {
+		return errors.New("unknown node")
+	}
+	a, _ := r.kp.ExportPublicKeyBinary(known)
+	b, _ := r.kp.ExportPublicKeyBinary(key)
+	if string(a) != string(b) {
+		return errors.New("wrong key")
+	}
+	return nil
+}
+
+type secureNode struct {
+	ref      insolar.Reference
+	key      crypto.PublicKey
+	factory  *secureFactory
+	resolver *staticKeyResolver
+}
+
+func newSecureNode(t *testing.T, kp insolar.KeyProcessor) *secureNode {
+	privateKey, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	n := &secureNode{ref: gen.Reference(), key: kp.ExtractPublicKey(privateKey)}
+	n.resolver = &staticKeyResolver{kp: kp, local: n.ref, keys: map[insolar.Reference]crypto.PublicKey{}}
+	n.factory = &secureFactory{
+		cfg:                 configuration.Transport{Protocol: secureTCPProtocol, Address: "127.0.0.1:0"},
+		CryptographyService: cryptography.NewKeyBoundCryptographyService(privateKey),
+		KeyProcessor:        kp,
+		PeerKeyResolver:     n.resolver,
+	}
+	return n
+}
+
+func newSecurePair(t *testing.T) (*secureNode, *secureNode) {
+	kp := platformpolicy.NewKeyProcessor()
+	n1, n2 := newSecureNode(t, kp), newSecureNode(t, kp)
+	n1.resolver.keys[n2.ref] = n2.key
+	n2.resolver.keys[n1.ref] = n1.key
+	return n1, n2
+}
+
+func TestSecureTransport(t *testing.T) {
+	n1, n2 := newSecurePair(t)
+	suite.Run(t, &suiteTest{factory1: n1.factory, factory2: n2.factory})
+}
+
+func TestNewFactory_SecureTCP(t *testing.T) {
+	f := NewFactory(configuration.Transport{Protocol: "SecureTCP", Address: "127.0.0.1:0"})
+	require.IsType(t, &secureFactory{}, f)
+
+	_, err := f.CreateStreamTransport(&fakeNode{})
+	require.Error(t, err, "dependencies are not injected")
+}
+
+type streamReceiver struct {
+	streams chan io.ReadWriteCloser
+}
+
+func (r *streamReceiver) HandleStream(ctx context.Context, address string, stream io.ReadWriteCloser) {
+	r.streams <- stream
+}
+
+func startSecureTransport(t *testing.T, ctx context.Context, n *secureNode) (StreamTransport, *streamReceiver) {
+	receiver := &streamReceiver{streams: make(chan io.ReadWriteCloser, 1)}
+	tr, err := n.factory.CreateStreamTransport(receiver)
+	require.NoError(t, err)
+	require.NoError(t, tr.Start(ctx))
+	return tr, receiver
+}
+
+func TestSecureTransport_Encryption(t *testing.T) {
+	ctx := context.Background()
+	n1, n2 := newSecurePair(t)
+	t1, _ := startSecureTransport(t, ctx, n1)
+	defer t1.Stop(ctx)
+	t2, receiver := startSecureTransport(t, ctx, n2)
+	defer t2.Stop(ctx)
+
+	// intercept traffic between nodes to check it is not plaintext
+	proxy, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer proxy.Close()
+	sniffed := make(chan []byte, 1)
+	go func() {
+		in, err := proxy.Accept()
+		if err != nil {
+			return
+		}
+		out, err := net.Dial("tcp", t2.Address())
+		if err != nil {
+			return
+		}
+		go io.Copy(in, out) // nolint: errcheck
+		data, _ := ioutil.ReadAll(io.TeeReader(in, out))
+		out.Close()
+		sniffed <- data
+	}()
+
+	conn, err := t1.Dial(WithRemoteNode(ctx, n2.ref), proxy.Addr().String())
+	require.NoError(t, err)
+
+	payload := make([]byte, secureMaxFrameSize*2+100)
+	for i := range payload {
+		payload[i] = 'x'
+	}
+	n, err := conn.Write(payload)
+	require.NoError(t, err)
+	require.Equal(t, len(payload), n)
+
+	stream := <-receiver.streams
+	received := make([]byte, len(payload))
+	_, err = io.ReadFull(stream, received)
+	require.NoError(t, err)
+	require.Equal(t, payload, received)
+
+	require.NoError(t, conn.Close())
+	require.NotContains(t, string(<-sniffed), string(payload[:64]))
+}
+
+func TestSecureTransport_RefusesPeers(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("unexpected reference", func(t *testing.T) {
+		n1, n2 := newSecurePair(t)
+		t1, _ := startSecureTransport(t, ctx, n1)
+		defer t1.Stop(ctx)
+		t2, _ := startSecureTransport(t, ctx, n2)
+		defer t2.Stop(ctx)
+
+		_, err := t1.Dial(WithRemoteNode(ctx, gen.Reference()), t2.Address())
+		require.Error(t, err)
+
+		conn, err := t1.Dial(WithRemoteNode(ctx, n2.ref), t2.Address())
+		require.NoError(t, err)
+		require.NoError(t, conn.Close())
+	})
+
+	t.Run("key doesn't match reference", func(t *testing.T) {
+		n1, n2 := newSecurePair(t)
+		impostor := newSecureNode(t, n1.factory.KeyProcessor)
+		impostor.resolver.local = n2.ref
+		impostor.resolver.keys[n1.ref] = n1.key
+
+		t1, _ := startSecureTransport(t, ctx, n1)
+		defer t1.Stop(ctx)
+		ti, _ := startSecureTransport(t, ctx, impostor)
+		defer ti.Stop(ctx)
+
+		_, err := t1.Dial(WithRemoteNode(ctx, n2.ref), ti.Address())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "wrong key")
+	})
+
+	t.Run("not secure peer", func(t *testing.T) {
+		n1, _ := newSecurePair(t)
+		t1, receiver := startSecureTransport(t, ctx, n1)
+		defer t1.Stop(ctx)
+
+		plain := newTCPTransport("127.0.0.1:0", "", nil)
+		conn, err := plain.Dial(ctx, t1.Address())
+		require.NoError(t, err)
+		_, err = conn.Write([]byte("GET / HTTP/1.0\r\n\r\n"))
+		require.NoError(t, err)
+
+		// returns when secure side closes connection
+		_, _ = ioutil.ReadAll(conn)
+		require.Empty(t, receiver.streams)
+	})
+}
+
+func TestNodeKeyResolver(t *testing.T) {
+	ctx := context.Background()
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	kp := platformpolicy.NewKeyProcessor()
+	newPrivateKey := func() crypto.PrivateKey {
+		privateKey, err := kp.GeneratePrivateKey()
+		require.NoError(t, err)
+		return privateKey
+	}
+	newKey := func() crypto.PublicKey {
+		return kp.ExtractPublicKey(newPrivateKey())
+	}
+
+	discoveryRef, discoveryPrivateKey := gen.Reference(), newPrivateKey()
+	discoveryKey := kp.ExtractPublicKey(discoveryPrivateKey)
+	activeRef, activeKey := gen.Reference(), newKey()
+	pulsarKey := newKey()
+
+	discovery := testutils.NewDiscoveryNodeMock(mc)
+	discovery.GetNodeRefMock.Return(&discoveryRef)
+	discovery.GetPublicKeyMock.Return(discoveryKey)
+
+	cert := testutils.NewCertificateMock(mc)
+	cert.GetDiscoveryNodesMock.Return([]insolar.DiscoveryNode{discovery})
+	cert.GetPulsarPublicKeysMock.Return([]crypto.PublicKey{pulsarKey})
+
+	activeNode := network.NewNetworkNodeMock(mc)
+	activeNode.PublicKeyMock.Return(activeKey)
+	accessor := network.NewAccessorMock(mc)
+	accessor.GetActiveNodeMock.Set(func(ref insolar.Reference) insolar.NetworkNode {
+		if ref.Equal(activeRef) {
+			return activeNode
+		}
+		return nil
+	})
+
+	resolver := &nodeKeyResolver{
+		CertificateManager:  testutils.NewCertificateManagerMock(mc).GetCertificateMock.Return(cert),
+		CryptographyService: cryptography.NewKeyBoundCryptographyService(newPrivateKey()),
+		KeyProcessor:        kp,
+		NodeKeeper:          network.NewNodeKeeperMock(mc).GetAccessorMock.Return(accessor),
+		PulseAccessor:       network.NewPulseAccessorMock(mc).GetLatestPulseMock.Return(*insolar.GenesisPulse, nil),
+		options:             &network2.Options{},
+	}
+
+	// newJoinerCert returns serialized authorization certificate of a joiner signed by discovery node.
+	newJoinerCert := func(ref insolar.Reference, key crypto.PublicKey, signer crypto.PrivateKey) []byte {
+		pem, err := kp.ExportPublicKeyPEM(key)
+		require.NoError(t, err)
+		authCert := &certificate.AuthorizationCertificate{
+			PublicKey: string(pem),
+			Reference: ref.String(),
+			Role:      insolar.StaticRoleVirtual.String(),
+		}
+		sign, err := authCert.SignNodePart(signer)
+		require.NoError(t, err)
+		authCert.DiscoverySigns = map[insolar.Reference][]byte{discoveryRef: sign}
+		data, err := certificate.Serialize(authCert)
+		require.NoError(t, err)
+		return data
+	}
+
+	t.Run("known nodes", func(t *testing.T) {
+		require.NoError(t, resolver.CheckPeer(ctx, discoveryRef, discoveryKey, nil))
+		require.Error(t, resolver.CheckPeer(ctx, discoveryRef, activeKey, nil))
+
+		require.NoError(t, resolver.CheckPeer(ctx, activeRef, activeKey, nil))
+		require.Error(t, resolver.CheckPeer(ctx, activeRef, discoveryKey, nil))
+	})
+
+	t.Run("joiner", func(t *testing.T) {
+		joinerRef, joinerKey := gen.Reference(), newKey()
+		require.Error(t, resolver.CheckPeer(ctx, joinerRef, joinerKey, nil), "unknown node without certificate")
+
+		joinerCert := newJoinerCert(joinerRef, joinerKey, discoveryPrivateKey)
+		require.NoError(t, resolver.CheckPeer(ctx, joinerRef, joinerKey, joinerCert))
+		require.Error(t, resolver.CheckPeer(ctx, gen.Reference(), joinerKey, joinerCert), "certificate of other node")
+		require.Error(t, resolver.CheckPeer(ctx, joinerRef, newKey(), joinerCert), "key from other certificate")
+
+		selfSigned := newJoinerCert(joinerRef, joinerKey, newPrivateKey())
+		require.Error(t, resolver.CheckPeer(ctx, joinerRef, joinerKey, selfSigned))
+		require.Error(t, resolver.CheckPeer(ctx, joinerRef, joinerKey, []byte("garbage")))
+	})
+
+	t.Run("pulsar", func(t *testing.T) {
+		require.NoError(t, resolver.CheckPeer(ctx, insolar.Reference{}, pulsarKey, nil))
+		require.Error(t, resolver.CheckPeer(ctx, insolar.Reference{}, newKey(), nil))
+	})
+
+	t.Run("pulsar without configured keys", func(t *testing.T) {
+		noKeysCert := testutils.NewCertificateMock(mc).GetPulsarPublicKeysMock.Return(nil)
+		resolver := &nodeKeyResolver{
+			CertificateManager: testutils.NewCertificateManagerMock(mc).GetCertificateMock.Return(noKeysCert),
+			KeyProcessor:       kp,
+			options:            &network2.Options{},
+		}
+		require.Error(t, resolver.CheckPeer(ctx, insolar.Reference{}, newKey(), nil))
+
+		resolver.options = &network2.Options{TrustAnyPulsar: true}
+		require.NoError(t, resolver.CheckPeer(ctx, insolar.Reference{}, newKey(), nil))
+	})
+}
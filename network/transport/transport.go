@@ -55,6 +55,7 @@ import (
 	"io"
 
 	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/insolar"
 )
 
 // DatagramHandler interface provides callback method to process received datagrams
@@ -76,6 +77,13 @@ type StreamHandler interface {
 	HandleStream(ctx context.Context, address string, stream io.ReadWriteCloser)
 }
 
+// AuthenticatedStream is a stream accepted by StreamTransport which authenticates peers.
+type AuthenticatedStream interface {
+	io.ReadWriteCloser
+	// Peer returns reference of remote node, it is empty for pulsar.
+	Peer() insolar.Reference
+}
+
 //go:generate minimock -i github.com/insolar/insolar/network/transport.StreamTransport -o ../../testutils/network -s _mock.go -g
 
 // StreamTransport interface provides methods to send and receive data streams
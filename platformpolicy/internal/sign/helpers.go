@@ -36,3 +36,16 @@ func MustConvertPrivateKeyToEcdsa(privateKey crypto.PrivateKey) *ecdsa.PrivateKe
 	}
 	return ecdsaPrivateKey
 }
+
+// MustConvertPrivateKeyToEcdsaSigner returns signer for private keys which are not held in memory,
+// e.g. keys of an external signer. Signer must be backed by an ecdsa key.
+func MustConvertPrivateKeyToEcdsaSigner(privateKey crypto.PrivateKey) crypto.Signer {
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		panic("Failed to convert private key to ecdsa signer")
+	}
+	if _, ok := signer.Public().(*ecdsa.PublicKey); !ok {
+		panic("Failed to convert private key to ecdsa signer")
+	}
+	return signer
+}
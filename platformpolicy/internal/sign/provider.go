@@ -18,6 +18,7 @@ package sign
 
 import (
 	"crypto"
+	"crypto/ecdsa"
 
 	"github.com/insolar/insolar/insolar"
 )
@@ -31,15 +32,19 @@ func NewECDSAProvider() AlgorithmProvider {
 
 func (p *ecdsaProvider) DataSigner(privateKey crypto.PrivateKey, hasher insolar.Hasher) insolar.Signer {
 	return &ecdsaDataSignerWrapper{
-		ecdsaDigestSignerWrapper: ecdsaDigestSignerWrapper{
-			privateKey: MustConvertPrivateKeyToEcdsa(privateKey),
-		},
-		hasher: hasher,
+		digestSigner: p.DigestSigner(privateKey),
+		hasher:       hasher,
 	}
 }
 func (p *ecdsaProvider) DigestSigner(privateKey crypto.PrivateKey) insolar.Signer {
-	return &ecdsaDigestSignerWrapper{
-		privateKey: MustConvertPrivateKeyToEcdsa(privateKey),
+	if ecdsaPrivateKey, ok := privateKey.(*ecdsa.PrivateKey); ok {
+		return &ecdsaDigestSignerWrapper{
+			privateKey: ecdsaPrivateKey,
+		}
+	}
+
+	return &externalDigestSignerWrapper{
+		signer: MustConvertPrivateKeyToEcdsaSigner(privateKey),
 	}
 }
 
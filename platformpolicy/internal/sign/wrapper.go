@@ -17,8 +17,11 @@
 package sign
 
 import (
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/rand"
+	"encoding/asn1"
+	"math/big"
 
 	"github.com/insolar/insolar/insolar"
 	"github.com/insolar/insolar/log"
@@ -41,13 +44,40 @@ func (sw *ecdsaDigestSignerWrapper) Sign(digest []byte) (*insolar.Signature, err
 	return &signature, nil
 }
 
+// externalDigestSignerWrapper signs digests with a key that is not available in memory
+// (e.g. held by an external signer process) and converts ASN.1 signatures to the platform format.
+type externalDigestSignerWrapper struct {
+	signer crypto.Signer
+}
+
+func (sw *externalDigestSignerWrapper) Sign(digest []byte) (*insolar.Signature, error) {
+	asn1Signature, err := sw.signer.Sign(rand.Reader, digest, crypto.Hash(0))
+	if err != nil {
+		return nil, errors.Wrap(err, "[ DataSigner ] could't sign data")
+	}
+
+	var ecdsaSignature struct {
+		R, S *big.Int
+	}
+	rest, err := asn1.Unmarshal(asn1Signature, &ecdsaSignature)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ DataSigner ] could't parse signature")
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("[ DataSigner ] trailing data after signature")
+	}
+
+	signature := insolar.SignatureFromBytes(SerializeTwoBigInt(ecdsaSignature.R, ecdsaSignature.S))
+	return &signature, nil
+}
+
 type ecdsaDataSignerWrapper struct {
-	ecdsaDigestSignerWrapper
-	hasher insolar.Hasher
+	digestSigner insolar.Signer
+	hasher       insolar.Hasher
 }
 
 func (sw *ecdsaDataSignerWrapper) Sign(data []byte) (*insolar.Signature, error) {
-	return sw.ecdsaDigestSignerWrapper.Sign(sw.hasher.Hash(data))
+	return sw.digestSigner.Sign(sw.hasher.Hash(data))
 }
 
 type ecdsaDigestVerifyWrapper struct {
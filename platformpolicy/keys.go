@@ -45,6 +45,10 @@ func (kp *keyProcessor) GeneratePrivateKey() (crypto.PrivateKey, error) {
 }
 
 func (*keyProcessor) ExtractPublicKey(privateKey crypto.PrivateKey) crypto.PublicKey {
+	if _, ok := privateKey.(*ecdsa.PrivateKey); !ok {
+		return sign.MustConvertPrivateKeyToEcdsaSigner(privateKey).Public()
+	}
+
 	ecdsaPrivateKey := sign.MustConvertPrivateKeyToEcdsa(privateKey)
 	publicKey := ecdsaPrivateKey.PublicKey
 	return &publicKey
@@ -0,0 +1,122 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package pulsar
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// DefaultStateHistory is a number of pulses reported by state endpoint if limit is not set.
+const DefaultStateHistory = 10
+
+// SignState is a confirmation of sent pulse.
+type SignState struct {
+	PublicKey       string `json:"publicKey"`
+	ChosenPublicKey string `json:"chosenPublicKey"`
+	Signature       []byte `json:"signature"`
+}
+
+// PulseState is a pulse sent by pulsar.
+type PulseState struct {
+	PulseNumber     uint32      `json:"pulseNumber"`
+	PrevPulseNumber uint32      `json:"prevPulseNumber"`
+	NextPulseNumber uint32      `json:"nextPulseNumber"`
+	Timestamp       int64       `json:"timestamp"`
+	Entropy         []byte      `json:"entropy"`
+	Signs           []SignState `json:"signs"`
+}
+
+// State is a state of pulsar reported by admin endpoint.
+type State struct {
+	PublicKey       string       `json:"publicKey"`
+	Federated       bool         `json:"federated"`
+	LastPulseNumber uint32       `json:"lastPulseNumber"`
+	NextPulseNumber uint32       `json:"nextPulseNumber"`
+	History         []PulseState `json:"history"`
+}
+
+func newPulseState(pls insolar.Pulse) PulseState {
+	state := PulseState{
+		PulseNumber:     uint32(pls.PulseNumber),
+		PrevPulseNumber: uint32(pls.PrevPulseNumber),
+		NextPulseNumber: uint32(pls.NextPulseNumber),
+		Timestamp:       pls.PulseTimestamp,
+		Entropy:         pls.Entropy[:],
+		Signs:           make([]SignState, 0, len(pls.Signs)),
+	}
+	for key, psc := range pls.Signs {
+		state.Signs = append(state.Signs, SignState{
+			PublicKey:       key,
+			ChosenPublicKey: psc.ChosenPublicKey,
+			Signature:       psc.Signature,
+		})
+	}
+	sort.Slice(state.Signs, func(i, j int) bool {
+		return state.Signs[i].PublicKey < state.Signs[j].PublicKey
+	})
+	return state
+}
+
+// State returns state of pulsar with up to limit recent pulses.
+func (p *Pulsar) State(limit int, now time.Time) State {
+	last := p.LastPN()
+	state := State{
+		PublicKey:       p.PublicKeyRaw,
+		Federated:       p.Federation != nil,
+		LastPulseNumber: uint32(last),
+	}
+	if state.Federated {
+		state.NextPulseNumber = uint32(NextFederatedPulseNumber(p.Config.NumberDelta, now))
+	} else {
+		state.NextPulseNumber = uint32(NextPulseNumber(last, p.Config.NumberDelta, now))
+	}
+
+	history := p.History(limit)
+	state.History = make([]PulseState, 0, len(history))
+	for _, pls := range history {
+		state.History = append(state.History, newPulseState(pls))
+	}
+	return state
+}
+
+// StateHandler serves state of pulsar as JSON. Number of reported pulses is set by limit query param.
+func StateHandler(p *Pulsar) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit := DefaultStateHistory
+		if param := r.URL.Query().Get("limit"); param != "" {
+			var err error
+			limit, err = strconv.Atoi(param)
+			if err != nil || limit < 0 {
+				http.Error(w, "bad limit", http.StatusBadRequest)
+				return
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(p.State(limit, time.Now()))
+		if err != nil {
+			inslogger.FromContext(r.Context()).Error("failed to write pulsar state: ", err)
+		}
+	}
+}
@@ -0,0 +1,534 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package pulsar
+
+import (
+	"context"
+	"crypto"
+	"encoding/binary"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/pulsar/entropygenerator"
+)
+
+// CommitMessage is a signed commitment of pulsar to its entropy for pulse round.
+type CommitMessage struct {
+	PulseNumber insolar.PulseNumber
+	PublicKey   string
+	Commitment  []byte
+	Signature   []byte
+}
+
+// RevealMessage discloses entropy committed by pulsar. It's checked against commitment, so it isn't signed.
+type RevealMessage struct {
+	PulseNumber insolar.PulseNumber
+	PublicKey   string
+	Entropy     insolar.Entropy
+}
+
+// ConfirmationMessage carries signed result of pulse round to chosen pulsar.
+type ConfirmationMessage struct {
+	PublicKey    string
+	Confirmation insolar.PulseSenderConfirmation
+}
+
+// FederationTransport delivers messages to other pulsars of federation.
+type FederationTransport interface {
+	SendCommit(ctx context.Context, address string, msg CommitMessage) error
+	SendReveal(ctx context.Context, address string, msg RevealMessage) error
+	SendConfirmation(ctx context.Context, address string, msg ConfirmationMessage) error
+}
+
+// FederationQuorum returns default number of pulsars needed to make a pulse: more than two thirds of federation.
+func FederationQuorum(size int) int {
+	return size*2/3 + 1
+}
+
+type neighbour struct {
+	address string
+	key     crypto.PublicKey
+}
+
+// round is a state of pulse round collected from messages of pulsars.
+type round struct {
+	commits       map[string][]byte
+	reveals       map[string]insolar.Entropy
+	confirmations map[string]insolar.PulseSenderConfirmation
+	// changed is closed and replaced on every update.
+	changed chan struct{}
+}
+
+func newRound() *round {
+	return &round{
+		commits:       map[string][]byte{},
+		reveals:       map[string]insolar.Entropy{},
+		confirmations: map[string]insolar.PulseSenderConfirmation{},
+		changed:       make(chan struct{}),
+	}
+}
+
+func (r *round) notify() {
+	close(r.changed)
+	r.changed = make(chan struct{})
+}
+
+// roundResult is a pulse data agreed by federation.
+type roundResult struct {
+	entropy insolar.Entropy
+	chosen  string
+	// signs are confirmations collected by chosen pulsar. They are empty for other pulsars.
+	signs map[string]insolar.PulseSenderConfirmation
+}
+
+// Federation makes pulses together with other pulsars.
+//
+// Every round has three steps. Pulsars exchange signed commitments to their entropies, then reveal entropies.
+// Entropy of pulse is a hash of all revealed entropies, it also chooses the pulsar which sends the pulse.
+// Then every pulsar sends signed confirmation of pulse data to the chosen one. The chosen pulsar sends pulse
+// with confirmations of quorum, which are checked by nodes. Pulsars which see different sets of participants
+// get different entropies, so their confirmations don't match and the pulse is skipped.
+type Federation struct {
+	cfg        configuration.PulsarFederation
+	transport  FederationTransport
+	crypto     insolar.CryptographyService
+	scheme     insolar.PlatformCryptographyScheme
+	generator  entropygenerator.EntropyGenerator
+	publicKey  string
+	neighbours map[string]neighbour
+	quorum     int
+
+	lock     sync.Mutex
+	rounds   map[insolar.PulseNumber]*round
+	finished insolar.PulseNumber
+}
+
+// NewFederation creates federation of pulsar with its configured neighbours.
+func NewFederation(cfg configuration.PulsarFederation, p *Pulsar, transport FederationTransport) (*Federation, error) {
+	f := &Federation{
+		cfg:        cfg,
+		transport:  transport,
+		crypto:     p.CryptographyService,
+		scheme:     p.PlatformCryptographyScheme,
+		generator:  p.EntropyGenerator,
+		publicKey:  p.PublicKeyRaw,
+		neighbours: map[string]neighbour{},
+		rounds:     map[insolar.PulseNumber]*round{},
+	}
+
+	for _, n := range cfg.Neighbours {
+		key, err := p.KeyProcessor.ImportPublicKeyPEM([]byte(n.PublicKey))
+		if err != nil {
+			return nil, errors.Wrapf(err, "bad public key of neighbour %s", n.Address)
+		}
+		// Keys are compared as strings, so they are exported the same way as the own key.
+		pem, err := p.KeyProcessor.ExportPublicKeyPEM(key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "bad public key of neighbour %s", n.Address)
+		}
+		if string(pem) == f.publicKey {
+			return nil, errors.Errorf("neighbour %s has key of this pulsar", n.Address)
+		}
+		if _, ok := f.neighbours[string(pem)]; ok {
+			return nil, errors.Errorf("neighbour %s has key of another neighbour", n.Address)
+		}
+		f.neighbours[string(pem)] = neighbour{address: n.Address, key: key}
+	}
+	if len(f.neighbours) == 0 {
+		return nil, errors.New("federation has no neighbours")
+	}
+
+	size := len(f.neighbours) + 1
+	f.quorum = cfg.Quorum
+	if f.quorum == 0 {
+		f.quorum = FederationQuorum(size)
+	}
+	if f.quorum < 0 || f.quorum > size {
+		return nil, errors.Errorf("quorum %d is out of federation size %d", f.quorum, size)
+	}
+
+	return f, nil
+}
+
+// HandleCommit saves commitment of neighbour.
+func (f *Federation) HandleCommit(msg CommitMessage) error {
+	n, ok := f.neighbours[msg.PublicKey]
+	if !ok {
+		return errors.New("commit from unknown pulsar")
+	}
+	hash, err := f.commitHash(msg.PulseNumber, msg.Commitment)
+	if err != nil {
+		return err
+	}
+	if !f.crypto.Verify(n.key, insolar.SignatureFromBytes(msg.Signature), hash) {
+		return errors.Errorf("bad signature of commit from %s", n.address)
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	r, err := f.round(msg.PulseNumber)
+	if err != nil {
+		return err
+	}
+	if _, ok := r.commits[msg.PublicKey]; ok {
+		return errors.Errorf("%s has already committed to pulse %d", n.address, msg.PulseNumber)
+	}
+	r.commits[msg.PublicKey] = msg.Commitment
+	r.notify()
+	return nil
+}
+
+// HandleReveal saves entropy of neighbour if it matches the commitment.
+func (f *Federation) HandleReveal(msg RevealMessage) error {
+	n, ok := f.neighbours[msg.PublicKey]
+	if !ok {
+		return errors.New("reveal from unknown pulsar")
+	}
+	commitment, err := f.commitment(msg.PulseNumber, msg.PublicKey, msg.Entropy)
+	if err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	r, err := f.round(msg.PulseNumber)
+	if err != nil {
+		return err
+	}
+	committed, ok := r.commits[msg.PublicKey]
+	if !ok {
+		return errors.Errorf("%s has not committed to pulse %d", n.address, msg.PulseNumber)
+	}
+	if string(committed) != string(commitment) {
+		return errors.Errorf("entropy of %s doesn't match commitment", n.address)
+	}
+	r.reveals[msg.PublicKey] = msg.Entropy
+	r.notify()
+	return nil
+}
+
+// HandleConfirmation saves confirmation of pulse data sent by neighbour.
+func (f *Federation) HandleConfirmation(msg ConfirmationMessage) error {
+	n, ok := f.neighbours[msg.PublicKey]
+	if !ok {
+		return errors.New("confirmation from unknown pulsar")
+	}
+	payload := PulseSenderConfirmationPayload{PulseSenderConfirmation: msg.Confirmation}
+	hash, err := payload.Hash(f.scheme.IntegrityHasher())
+	if err != nil {
+		return err
+	}
+	if !f.crypto.Verify(n.key, insolar.SignatureFromBytes(msg.Confirmation.Signature), hash) {
+		return errors.Errorf("bad signature of confirmation from %s", n.address)
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	r, err := f.round(msg.Confirmation.PulseNumber)
+	if err != nil {
+		return err
+	}
+	r.confirmations[msg.PublicKey] = msg.Confirmation
+	r.notify()
+	return nil
+}
+
+// round returns state of round. It must be called under lock.
+func (f *Federation) round(pn insolar.PulseNumber) (*round, error) {
+	if pn <= f.finished {
+		return nil, errors.Errorf("round of pulse %d is finished", pn)
+	}
+	r, ok := f.rounds[pn]
+	if !ok {
+		r = newRound()
+		f.rounds[pn] = r
+	}
+	return r, nil
+}
+
+func (f *Federation) finish(pn insolar.PulseNumber) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if pn > f.finished {
+		f.finished = pn
+	}
+	for n := range f.rounds {
+		if n <= f.finished {
+			delete(f.rounds, n)
+		}
+	}
+}
+
+// run makes round of pulse with neighbours.
+func (f *Federation) run(ctx context.Context, pn insolar.PulseNumber) (*roundResult, error) {
+	logger := inslogger.FromContext(ctx)
+	timeout := time.Duration(f.cfg.StepTimeout) * time.Millisecond
+
+	f.lock.Lock()
+	r, err := f.round(pn)
+	f.lock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	defer f.finish(pn)
+
+	// Commit.
+	entropy := f.generator.GenerateEntropy()
+	commitment, err := f.commitment(pn, f.publicKey, entropy)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := f.commitHash(pn, commitment)
+	if err != nil {
+		return nil, err
+	}
+	sign, err := f.crypto.Sign(hash)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign commit")
+	}
+	f.lock.Lock()
+	r.commits[f.publicKey] = commitment
+	f.lock.Unlock()
+
+	commit := CommitMessage{PulseNumber: pn, PublicKey: f.publicKey, Commitment: commitment, Signature: sign.Bytes()}
+	f.broadcast(ctx, timeout, func(ctx context.Context, address string) error {
+		return f.transport.SendCommit(ctx, address, commit)
+	})
+	f.wait(ctx, r, timeout, func() bool {
+		return len(r.commits) == len(f.neighbours)+1
+	})
+
+	f.lock.Lock()
+	committed := make([]string, 0, len(r.commits))
+	for key := range r.commits {
+		committed = append(committed, key)
+	}
+	r.reveals[f.publicKey] = entropy
+	f.lock.Unlock()
+	if len(committed) < f.quorum {
+		return nil, errors.Errorf("got %d commits, quorum is %d", len(committed), f.quorum)
+	}
+
+	// Reveal.
+	reveal := RevealMessage{PulseNumber: pn, PublicKey: f.publicKey, Entropy: entropy}
+	f.broadcast(ctx, timeout, func(ctx context.Context, address string) error {
+		return f.transport.SendReveal(ctx, address, reveal)
+	})
+	f.wait(ctx, r, timeout, func() bool {
+		for _, key := range committed {
+			if _, ok := r.reveals[key]; !ok {
+				return false
+			}
+		}
+		return true
+	})
+
+	f.lock.Lock()
+	revealed := map[string]insolar.Entropy{}
+	for _, key := range committed {
+		if e, ok := r.reveals[key]; ok {
+			revealed[key] = e
+		}
+	}
+	f.lock.Unlock()
+	if len(revealed) < f.quorum {
+		return nil, errors.Errorf("got %d reveals, quorum is %d", len(revealed), f.quorum)
+	}
+
+	// Confirm.
+	result, err := f.combine(revealed)
+	if err != nil {
+		return nil, err
+	}
+	confirmation, err := f.confirmation(pn, result)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.chosen != f.publicKey {
+		chosen := f.neighbours[result.chosen]
+		logger.Debugf("pulsar %s is chosen to send pulse %d", chosen.address, pn)
+		sendCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		err := f.transport.SendConfirmation(sendCtx, chosen.address, ConfirmationMessage{
+			PublicKey:    f.publicKey,
+			Confirmation: confirmation,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to send confirmation to %s", chosen.address)
+		}
+		return result, nil
+	}
+
+	matching := func() map[string]insolar.PulseSenderConfirmation {
+		res := map[string]insolar.PulseSenderConfirmation{}
+		for key, c := range r.confirmations {
+			if c.PulseNumber == pn && c.ChosenPublicKey == result.chosen && c.Entropy == result.entropy {
+				res[key] = c
+			}
+		}
+		return res
+	}
+	f.lock.Lock()
+	r.confirmations[f.publicKey] = confirmation
+	f.lock.Unlock()
+	f.wait(ctx, r, timeout, func() bool {
+		return len(matching()) >= len(revealed)
+	})
+
+	f.lock.Lock()
+	result.signs = matching()
+	f.lock.Unlock()
+	if len(result.signs) < f.quorum {
+		return nil, errors.Errorf("got %d matching confirmations, quorum is %d", len(result.signs), f.quorum)
+	}
+	return result, nil
+}
+
+// broadcast sends message to all neighbours and waits for completion. Failures are logged.
+func (f *Federation) broadcast(
+	ctx context.Context,
+	timeout time.Duration,
+	send func(ctx context.Context, address string) error,
+) {
+	logger := inslogger.FromContext(ctx)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, n := range f.neighbours {
+		wg.Add(1)
+		go func(address string) {
+			defer wg.Done()
+			err := send(ctx, address)
+			if err != nil {
+				logger.Warnf("failed to send message to pulsar %s: %s", address, err)
+			}
+		}(n.address)
+	}
+	wg.Wait()
+}
+
+// wait blocks until done returns true, timeout expires or ctx is canceled. Done is called under lock.
+func (f *Federation) wait(ctx context.Context, r *round, timeout time.Duration, done func() bool) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		f.lock.Lock()
+		ok := done()
+		changed := r.changed
+		f.lock.Unlock()
+		if ok {
+			return
+		}
+
+		select {
+		case <-changed:
+		case <-timer.C:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// combine calculates entropy of pulse and chooses pulsar which sends it.
+func (f *Federation) combine(revealed map[string]insolar.Entropy) (*roundResult, error) {
+	keys := make([]string, 0, len(revealed))
+	for key := range revealed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	hasher := f.scheme.IntegrityHasher()
+	for _, key := range keys {
+		e := revealed[key]
+		_, err := hasher.Write([]byte(key))
+		if err != nil {
+			return nil, err
+		}
+		_, err = hasher.Write(e[:])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &roundResult{}
+	copy(result.entropy[:], hasher.Sum(nil))
+	result.chosen = keys[binary.BigEndian.Uint64(result.entropy[:8])%uint64(len(keys))]
+	return result, nil
+}
+
+func (f *Federation) confirmation(pn insolar.PulseNumber, result *roundResult) (insolar.PulseSenderConfirmation, error) {
+	confirmation := insolar.PulseSenderConfirmation{
+		PulseNumber:     pn,
+		ChosenPublicKey: result.chosen,
+		Entropy:         result.entropy,
+	}
+	payload := PulseSenderConfirmationPayload{PulseSenderConfirmation: confirmation}
+	hash, err := payload.Hash(f.scheme.IntegrityHasher())
+	if err != nil {
+		return confirmation, err
+	}
+	sign, err := f.crypto.Sign(hash)
+	if err != nil {
+		return confirmation, errors.Wrap(err, "failed to sign confirmation")
+	}
+	confirmation.Signature = sign.Bytes()
+	return confirmation, nil
+}
+
+func (f *Federation) commitment(pn insolar.PulseNumber, key string, entropy insolar.Entropy) ([]byte, error) {
+	hasher := f.scheme.IntegrityHasher()
+	_, err := hasher.Write(pn.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	_, err = hasher.Write([]byte(key))
+	if err != nil {
+		return nil, err
+	}
+	_, err = hasher.Write(entropy[:])
+	if err != nil {
+		return nil, err
+	}
+	return hasher.Sum(nil), nil
+}
+
+func (f *Federation) commitHash(pn insolar.PulseNumber, commitment []byte) ([]byte, error) {
+	hasher := f.scheme.IntegrityHasher()
+	_, err := hasher.Write(pn.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	_, err = hasher.Write(commitment)
+	if err != nil {
+		return nil, err
+	}
+	return hasher.Sum(nil), nil
+}
@@ -0,0 +1,161 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package pulsar
+
+import (
+	"context"
+	"net"
+	"net/rpc"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// federationService is net/rpc service for messages of other pulsars.
+type federationService struct {
+	federation *Federation
+}
+
+func (s *federationService) Commit(msg CommitMessage, reply *bool) error {
+	return s.federation.HandleCommit(msg)
+}
+
+func (s *federationService) Reveal(msg RevealMessage, reply *bool) error {
+	return s.federation.HandleReveal(msg)
+}
+
+func (s *federationService) Confirm(msg ConfirmationMessage, reply *bool) error {
+	return s.federation.HandleConfirmation(msg)
+}
+
+// FederationRPC is FederationTransport over net/rpc. It also serves messages of other pulsars.
+type FederationRPC struct {
+	address  string
+	listener net.Listener
+
+	lock    sync.Mutex
+	clients map[string]*rpc.Client
+}
+
+// NewFederationRPC creates transport listening on address.
+func NewFederationRPC(address string) *FederationRPC {
+	return &FederationRPC{
+		address: address,
+		clients: map[string]*rpc.Client{},
+	}
+}
+
+// Start starts serving messages for federation.
+func (t *FederationRPC) Start(f *Federation) error {
+	server := rpc.NewServer()
+	err := server.RegisterName("Federation", &federationService{federation: f})
+	if err != nil {
+		return errors.Wrap(err, "failed to register federation service")
+	}
+	listener, err := net.Listen("tcp", t.address)
+	if err != nil {
+		return errors.Wrapf(err, "failed to listen %s", t.address)
+	}
+	t.listener = listener
+	go server.Accept(listener)
+	return nil
+}
+
+// Addr returns address of started transport.
+func (t *FederationRPC) Addr() string {
+	return t.listener.Addr().String()
+}
+
+// Stop stops serving and closes connections to other pulsars.
+func (t *FederationRPC) Stop() error {
+	t.lock.Lock()
+	for address, client := range t.clients {
+		client.Close()
+		delete(t.clients, address)
+	}
+	t.lock.Unlock()
+
+	if t.listener == nil {
+		return nil
+	}
+	return t.listener.Close()
+}
+
+func (t *FederationRPC) SendCommit(ctx context.Context, address string, msg CommitMessage) error {
+	return t.call(ctx, address, "Federation.Commit", msg)
+}
+
+func (t *FederationRPC) SendReveal(ctx context.Context, address string, msg RevealMessage) error {
+	return t.call(ctx, address, "Federation.Reveal", msg)
+}
+
+func (t *FederationRPC) SendConfirmation(ctx context.Context, address string, msg ConfirmationMessage) error {
+	return t.call(ctx, address, "Federation.Confirm", msg)
+}
+
+func (t *FederationRPC) call(ctx context.Context, address, method string, msg interface{}) error {
+	client, err := t.client(ctx, address)
+	if err != nil {
+		return err
+	}
+
+	call := client.Go(method, msg, new(bool), make(chan *rpc.Call, 1))
+	select {
+	case <-call.Done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if _, ok := call.Error.(rpc.ServerError); call.Error != nil && !ok {
+		// Connection is broken, it will be reestablished by the next call.
+		t.drop(address, client)
+	}
+	return call.Error
+}
+
+func (t *FederationRPC) client(ctx context.Context, address string) (*rpc.Client, error) {
+	t.lock.Lock()
+	client, ok := t.clients[address]
+	t.lock.Unlock()
+	if ok {
+		return client, nil
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to %s", address)
+	}
+	client = rpc.NewClient(conn)
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if existing, ok := t.clients[address]; ok {
+		client.Close()
+		return existing, nil
+	}
+	t.clients[address] = client
+	return client, nil
+}
+
+func (t *FederationRPC) drop(address string, client *rpc.Client) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.clients[address] == client {
+		delete(t.clients, address)
+	}
+	client.Close()
+}
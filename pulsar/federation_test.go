@@ -0,0 +1,327 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package pulsar
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/pulsar/entropygenerator"
+	"github.com/insolar/insolar/testutils"
+)
+
+// memoryTransport delivers messages to federations of the same process.
+type memoryTransport struct {
+	lock        sync.Mutex
+	federations map[string]*Federation
+}
+
+func (t *memoryTransport) get(address string) (*Federation, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	f, ok := t.federations[address]
+	if !ok {
+		return nil, errors.Errorf("pulsar %s is down", address)
+	}
+	return f, nil
+}
+
+func (t *memoryTransport) SendCommit(ctx context.Context, address string, msg CommitMessage) error {
+	f, err := t.get(address)
+	if err != nil {
+		return err
+	}
+	return f.HandleCommit(msg)
+}
+
+func (t *memoryTransport) SendReveal(ctx context.Context, address string, msg RevealMessage) error {
+	f, err := t.get(address)
+	if err != nil {
+		return err
+	}
+	return f.HandleReveal(msg)
+}
+
+func (t *memoryTransport) SendConfirmation(ctx context.Context, address string, msg ConfirmationMessage) error {
+	f, err := t.get(address)
+	if err != nil {
+		return err
+	}
+	return f.HandleConfirmation(msg)
+}
+
+type testPulsar struct {
+	*Pulsar
+	address string
+}
+
+// newTestFederation creates pulsars of one federation. Pulses sent by them are written to distributed.
+func newTestFederation(
+	t *testing.T, addresses []string, distributed chan insolar.Pulse, transport func(address string) FederationTransport,
+) []testPulsar {
+	proc := platformpolicy.NewKeyProcessor()
+	var (
+		pulsars    []testPulsar
+		neighbours []configuration.PulsarNodeAddress
+	)
+	for _, address := range addresses {
+		key, err := proc.GeneratePrivateKey()
+		require.NoError(t, err)
+		pem, err := proc.ExportPublicKeyPEM(proc.ExtractPublicKey(key))
+		require.NoError(t, err)
+
+		dist := testutils.NewPulseDistributorMock(t).DistributeMock.Set(func(_ context.Context, p insolar.Pulse) {
+			distributed <- p
+		})
+		p := NewPulsar(
+			configuration.NewPulsar(),
+			cryptography.NewKeyBoundCryptographyService(key),
+			platformpolicy.NewPlatformCryptographyScheme(),
+			proc,
+			dist,
+			&entropygenerator.StandardEntropyGenerator{},
+		)
+		pulsars = append(pulsars, testPulsar{Pulsar: p, address: address})
+		neighbours = append(neighbours, configuration.PulsarNodeAddress{Address: address, PublicKey: string(pem)})
+	}
+
+	for i, p := range pulsars {
+		cfg := configuration.NewPulsar().Federation
+		cfg.StepTimeout = 200
+		cfg.Neighbours = append(append([]configuration.PulsarNodeAddress{}, neighbours[:i]...), neighbours[i+1:]...)
+		f, err := NewFederation(cfg, p.Pulsar, transport(p.address))
+		require.NoError(t, err)
+		p.Federation = f
+	}
+	return pulsars
+}
+
+// sendAll runs pulse round on pulsars and returns errors of Send.
+func sendAll(ctx context.Context, pulsars []testPulsar, pn insolar.PulseNumber) []error {
+	errs := make([]error, len(pulsars))
+	var wg sync.WaitGroup
+	for i, p := range pulsars {
+		wg.Add(1)
+		go func(i int, p testPulsar) {
+			defer wg.Done()
+			errs[i] = p.Send(ctx, pn)
+		}(i, p)
+	}
+	wg.Wait()
+	return errs
+}
+
+func verifyFederatedPulse(t *testing.T, p insolar.Pulse, pn insolar.PulseNumber, signs int) {
+	require.Equal(t, pn, p.PulseNumber)
+	require.Len(t, p.Signs, signs)
+
+	scheme := platformpolicy.NewPlatformCryptographyScheme()
+	proc := platformpolicy.NewKeyProcessor()
+	for key, psc := range p.Signs {
+		require.Equal(t, pn, psc.PulseNumber)
+		require.Equal(t, p.Entropy, psc.Entropy)
+		require.Equal(t, scheme.IntegrityHasher().Hash([]byte(psc.ChosenPublicKey))[:insolar.OriginIDSize], p.OriginID[:])
+
+		payload := PulseSenderConfirmationPayload{PulseSenderConfirmation: psc}
+		hash, err := payload.Hash(scheme.IntegrityHasher())
+		require.NoError(t, err)
+		pk, err := proc.ImportPublicKeyPEM([]byte(key))
+		require.NoError(t, err)
+		require.True(t, scheme.DataVerifier(pk, scheme.IntegrityHasher()).Verify(insolar.SignatureFromBytes(psc.Signature), hash))
+	}
+}
+
+func TestFederation_Round(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	addresses := []string{"a", "b", "c", "d"}
+
+	t.Run("all pulsars", func(t *testing.T) {
+		transport := &memoryTransport{federations: map[string]*Federation{}}
+		distributed := make(chan insolar.Pulse, len(addresses))
+		pulsars := newTestFederation(t, addresses, distributed, func(string) FederationTransport { return transport })
+		for _, p := range pulsars {
+			transport.federations[p.address] = p.Federation
+		}
+
+		for _, pn := range []insolar.PulseNumber{65537, 65547} {
+			for _, err := range sendAll(ctx, pulsars, pn) {
+				require.NoError(t, err)
+			}
+			p := <-distributed
+			verifyFederatedPulse(t, p, pn, len(addresses))
+			require.Len(t, distributed, 0)
+			for _, p := range pulsars {
+				require.Equal(t, pn, p.LastPN())
+			}
+		}
+	})
+
+	t.Run("skipped round", func(t *testing.T) {
+		transport := &memoryTransport{federations: map[string]*Federation{}}
+		distributed := make(chan insolar.Pulse, len(addresses))
+		pulsars := newTestFederation(t, addresses, distributed, func(string) FederationTransport { return transport })
+		for _, p := range pulsars {
+			transport.federations[p.address] = p.Federation
+		}
+
+		for _, err := range sendAll(ctx, pulsars, 65537) {
+			require.NoError(t, err)
+		}
+		verifyFederatedPulse(t, <-distributed, 65537, len(addresses))
+
+		for _, err := range sendAll(ctx, pulsars[2:], 65547) {
+			require.Error(t, err)
+		}
+		require.Len(t, distributed, 0)
+
+		for _, err := range sendAll(ctx, pulsars, 65557) {
+			require.NoError(t, err)
+		}
+		p := <-distributed
+		verifyFederatedPulse(t, p, 65557, len(addresses))
+		require.Equal(t, insolar.PulseNumber(65537), p.PrevPulseNumber)
+	})
+
+	t.Run("quorum without failed pulsar", func(t *testing.T) {
+		transport := &memoryTransport{federations: map[string]*Federation{}}
+		distributed := make(chan insolar.Pulse, len(addresses))
+		pulsars := newTestFederation(t, addresses, distributed, func(string) FederationTransport { return transport })
+		for _, p := range pulsars[1:] {
+			transport.federations[p.address] = p.Federation
+		}
+
+		// Chosen pulsar may be the failed one, then confirmations can't be sent and rounds are repeated.
+		for pn := insolar.PulseNumber(65537); pn < 65537+1000; pn += 10 {
+			var failed bool
+			for _, err := range sendAll(ctx, pulsars[1:], pn) {
+				failed = failed || err != nil
+			}
+			if !failed {
+				verifyFederatedPulse(t, <-distributed, pn, len(addresses)-1)
+				return
+			}
+			require.Len(t, distributed, 0)
+		}
+		t.Fatal("no pulse in 100 rounds")
+	})
+
+	t.Run("no quorum", func(t *testing.T) {
+		transport := &memoryTransport{federations: map[string]*Federation{}}
+		distributed := make(chan insolar.Pulse, len(addresses))
+		pulsars := newTestFederation(t, addresses, distributed, func(string) FederationTransport { return transport })
+		for _, p := range pulsars[2:] {
+			transport.federations[p.address] = p.Federation
+		}
+
+		for _, err := range sendAll(ctx, pulsars[2:], 65537) {
+			require.Error(t, err)
+		}
+		require.Len(t, distributed, 0)
+	})
+}
+
+func TestFederation_Handle(t *testing.T) {
+	transport := &memoryTransport{federations: map[string]*Federation{}}
+	pulsars := newTestFederation(t, []string{"a", "b"}, make(chan insolar.Pulse), func(string) FederationTransport {
+		return transport
+	})
+	a, b := pulsars[0].Federation, pulsars[1].Federation
+	pn := insolar.PulseNumber(65537)
+
+	entropy := (&entropygenerator.StandardEntropyGenerator{}).GenerateEntropy()
+	commitment, err := a.commitment(pn, a.publicKey, entropy)
+	require.NoError(t, err)
+	hash, err := a.commitHash(pn, commitment)
+	require.NoError(t, err)
+	sign, err := a.crypto.Sign(hash)
+	require.NoError(t, err)
+	commit := CommitMessage{PulseNumber: pn, PublicKey: a.publicKey, Commitment: commitment, Signature: sign.Bytes()}
+
+	t.Run("reveal before commit", func(t *testing.T) {
+		err := b.HandleReveal(RevealMessage{PulseNumber: pn, PublicKey: a.publicKey, Entropy: entropy})
+		require.Error(t, err)
+	})
+
+	t.Run("unknown pulsar", func(t *testing.T) {
+		unknown := commit
+		unknown.PublicKey = "unknown"
+		require.Error(t, b.HandleCommit(unknown))
+	})
+
+	t.Run("bad signature", func(t *testing.T) {
+		forged := commit
+		forged.Commitment = hash
+		require.Error(t, b.HandleCommit(forged))
+	})
+
+	t.Run("commit and reveal", func(t *testing.T) {
+		require.NoError(t, b.HandleCommit(commit))
+		require.Error(t, b.HandleCommit(commit), "second commit")
+
+		other := (&entropygenerator.StandardEntropyGenerator{}).GenerateEntropy()
+		err := b.HandleReveal(RevealMessage{PulseNumber: pn, PublicKey: a.publicKey, Entropy: other})
+		require.Error(t, err, "entropy doesn't match commitment")
+
+		err = b.HandleReveal(RevealMessage{PulseNumber: pn, PublicKey: a.publicKey, Entropy: entropy})
+		require.NoError(t, err)
+		require.Equal(t, entropy, b.rounds[pn].reveals[a.publicKey])
+	})
+
+	t.Run("finished round", func(t *testing.T) {
+		b.finish(pn)
+		require.Empty(t, b.rounds)
+		require.Error(t, b.HandleCommit(commit))
+	})
+}
+
+func TestFederationRPC(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+
+	var addresses []string
+	for i := 0; i < 2; i++ {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addresses = append(addresses, l.Addr().String())
+		require.NoError(t, l.Close())
+	}
+
+	transports := map[string]*FederationRPC{}
+	distributed := make(chan insolar.Pulse, len(addresses))
+	pulsars := newTestFederation(t, addresses, distributed, func(address string) FederationTransport {
+		transports[address] = NewFederationRPC(address)
+		return transports[address]
+	})
+	for _, p := range pulsars {
+		require.NoError(t, transports[p.address].Start(p.Federation))
+		defer transports[p.address].Stop()
+	}
+
+	for _, err := range sendAll(ctx, pulsars, 65537) {
+		require.NoError(t, err)
+	}
+	verifyFederatedPulse(t, <-distributed, 65537, len(addresses))
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/platformpolicy"
 	"github.com/insolar/insolar/pulsar/entropygenerator"
+	"github.com/pkg/errors"
 	"go.opencensus.io/stats"
 
 	"github.com/insolar/insolar/configuration"
@@ -47,8 +48,15 @@ type Pulsar struct {
 	KeyProcessor               insolar.KeyProcessor
 	PulseDistributor           insolar.PulseDistributor
 
+	// Federation makes pulses together with other pulsars. Pulsar works standalone if it's nil.
+	Federation *Federation
+	// Storage keeps sent pulses. Pulses are kept only in memory if it's nil.
+	Storage Storage
+
 	lastPNMutex sync.RWMutex
 	lastPN      insolar.PulseNumber
+	// history is recent sent pulses, the latest is the last.
+	history []insolar.Pulse
 }
 
 // NewPulsar creates a new pulse with using of custom GeneratedEntropy Generator
@@ -89,22 +97,21 @@ func (p *Pulsar) Send(ctx context.Context, pulseNumber insolar.PulseNumber) erro
 	logger := inslogger.FromContext(ctx)
 	logger.Info("before sending new pulseNumber: %v", pulseNumber)
 
+	if last := p.LastPN(); pulseNumber <= last {
+		return errors.Errorf("pulse %v is not after the last pulse %v", pulseNumber, last)
+	}
+
+	if p.Federation != nil {
+		return p.sendFederated(ctx, pulseNumber)
+	}
+
 	entropy, _, err := p.generateNewEntropyAndSign()
 	if err != nil {
 		logger.Error(err)
 		return err
 	}
 
-	pulseForSending := insolar.Pulse{
-		PulseNumber:      pulseNumber,
-		Entropy:          entropy,
-		NextPulseNumber:  pulseNumber + insolar.PulseNumber(p.Config.NumberDelta),
-		PrevPulseNumber:  p.lastPN,
-		EpochPulseNumber: int(pulseNumber),
-		OriginID:         [16]byte{206, 41, 229, 190, 7, 240, 162, 155, 121, 245, 207, 56, 161, 67, 189, 0},
-		PulseTimestamp:   time.Now().UnixNano(),
-		Signs:            map[string]insolar.PulseSenderConfirmation{},
-	}
+	pulseForSending := p.newPulse(pulseNumber, p.LastPN(), entropy, p.PublicKeyRaw)
 
 	payload := PulseSenderConfirmationPayload{PulseSenderConfirmation: insolar.PulseSenderConfirmation{
 		ChosenPublicKey: p.PublicKeyRaw,
@@ -128,21 +135,81 @@ func (p *Pulsar) Send(ctx context.Context, pulseNumber insolar.PulseNumber) erro
 		PulseNumber:     pulseNumber,
 	}
 
+	return p.distribute(ctx, pulseForSending)
+}
+
+// sendFederated makes pulse with federation. Pulse is distributed only by the chosen pulsar.
+func (p *Pulsar) sendFederated(ctx context.Context, pulseNumber insolar.PulseNumber) error {
+	logger := inslogger.FromContext(ctx)
+
+	result, err := p.Federation.run(ctx, pulseNumber)
+	if err != nil {
+		return errors.Wrapf(err, "federation failed to make pulse %v", pulseNumber)
+	}
+	if result.chosen != p.PublicKeyRaw {
+		p.setLastPN(pulseNumber)
+		logger.Infof("pulse %v is sent by another pulsar", pulseNumber)
+		return nil
+	}
+
+	// Round may be skipped without pulse, so the previous pulse is the last distributed one.
+	pulseForSending := p.newPulse(pulseNumber, p.LastPN(), result.entropy, result.chosen)
+	pulseForSending.Signs = result.signs
+
+	return p.distribute(ctx, pulseForSending)
+}
+
+func (p *Pulsar) newPulse(
+	pulseNumber, prevPulseNumber insolar.PulseNumber,
+	entropy insolar.Entropy,
+	chosenPublicKey string,
+) insolar.Pulse {
+	pulse := insolar.Pulse{
+		PulseNumber:      pulseNumber,
+		Entropy:          entropy,
+		NextPulseNumber:  pulseNumber + insolar.PulseNumber(p.Config.NumberDelta),
+		PrevPulseNumber:  prevPulseNumber,
+		EpochPulseNumber: int(pulseNumber),
+		PulseTimestamp:   time.Now().UnixNano(),
+		Signs:            map[string]insolar.PulseSenderConfirmation{},
+	}
+	// Origin is the pulsar which sends pulse.
+	hash := p.PlatformCryptographyScheme.IntegrityHasher().Hash([]byte(chosenPublicKey))
+	copy(pulse.OriginID[:], hash)
+	return pulse
+}
+
+func (p *Pulsar) distribute(ctx context.Context, pulseForSending insolar.Pulse) error {
+	logger := inslogger.FromContext(ctx)
+
+	// Pulse is saved before sending, so its number is never sent again after restart.
+	if p.Storage != nil {
+		err := p.Storage.Append(ctx, pulseForSending)
+		if err != nil {
+			return errors.Wrapf(err, "failed to save pulse %v", pulseForSending.PulseNumber)
+		}
+	}
+
 	logger.Debug("Start a process of sending pulse")
 	go func() {
 		logger.Debug("Before sending to network")
 		p.PulseDistributor.Distribute(ctx, pulseForSending)
 	}()
 
-	p.lastPNMutex.Lock()
-	p.lastPN = pulseNumber
-	p.lastPNMutex.Unlock()
+	p.setLastPN(pulseForSending.PulseNumber)
+	p.addHistory(pulseForSending)
 	logger.Infof("set latest pulse: %v", pulseForSending.PulseNumber)
 
 	stats.Record(ctx, statPulseGenerated.M(1))
 	return nil
 }
 
+func (p *Pulsar) setLastPN(pulseNumber insolar.PulseNumber) {
+	p.lastPNMutex.Lock()
+	p.lastPN = pulseNumber
+	p.lastPNMutex.Unlock()
+}
+
 func (p *Pulsar) LastPN() insolar.PulseNumber {
 	p.lastPNMutex.RLock()
 	defer p.lastPNMutex.RUnlock()
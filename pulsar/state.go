@@ -0,0 +1,108 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package pulsar
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/pulse"
+)
+
+// HistorySize is a number of recent pulses kept in memory of pulsar.
+const HistorySize = 100
+
+// Storage keeps pulses sent by pulsar, so numbering continues after restart.
+type Storage interface {
+	Append(ctx context.Context, pulse insolar.Pulse) error
+	// Recent returns up to limit latest pulses, the latest first.
+	Recent(ctx context.Context, limit int) ([]insolar.Pulse, error)
+}
+
+// NextPulseNumber returns number of the pulse after last one. Numbers go with delta step, but if pulsar was
+// stopped for a while, they skip ahead to stay close to wall-clock time. Zero last means there were no pulses.
+func NextPulseNumber(last insolar.PulseNumber, delta uint32, now time.Time) insolar.PulseNumber {
+	current := pulse.OfTime(now)
+	if last == 0 {
+		return current
+	}
+
+	step := insolar.PulseNumber(delta)
+	next := last + step
+	if current > next {
+		next = last + (current-last)/step*step
+	}
+	return next
+}
+
+// NextFederatedPulseNumber returns number of the next pulse round of federation. Rounds start at time
+// of every delta-th pulse number, so all pulsars of federation start the same rounds.
+func NextFederatedPulseNumber(delta uint32, now time.Time) insolar.PulseNumber {
+	current := pulse.OfTime(now)
+	step := insolar.PulseNumber(delta)
+	return current - (current-pulse.MinTimePulse)%step + step
+}
+
+// Recover restores recent pulses from storage. It must be called before the first pulse is sent.
+func (p *Pulsar) Recover(ctx context.Context) error {
+	recent, err := p.Storage.Recent(ctx, HistorySize)
+	if err != nil {
+		return errors.Wrap(err, "failed to get recent pulses")
+	}
+	if len(recent) == 0 {
+		return nil
+	}
+
+	history := make([]insolar.Pulse, 0, len(recent))
+	for i := len(recent) - 1; i >= 0; i-- {
+		history = append(history, recent[i])
+	}
+
+	p.lastPNMutex.Lock()
+	defer p.lastPNMutex.Unlock()
+	p.lastPN = recent[0].PulseNumber
+	p.history = history
+	return nil
+}
+
+// History returns up to limit recent pulses sent by pulsar, the latest first.
+func (p *Pulsar) History(limit int) []insolar.Pulse {
+	p.lastPNMutex.RLock()
+	defer p.lastPNMutex.RUnlock()
+
+	if limit > len(p.history) {
+		limit = len(p.history)
+	}
+	res := make([]insolar.Pulse, 0, limit)
+	for i := len(p.history) - 1; i >= len(p.history)-limit; i-- {
+		res = append(res, p.history[i])
+	}
+	return res
+}
+
+func (p *Pulsar) addHistory(pls insolar.Pulse) {
+	p.lastPNMutex.Lock()
+	defer p.lastPNMutex.Unlock()
+
+	p.history = append(p.history, pls)
+	if len(p.history) > HistorySize {
+		p.history = append(p.history[:0], p.history[len(p.history)-HistorySize:]...)
+	}
+}
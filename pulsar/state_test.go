@@ -0,0 +1,170 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package pulsar
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/store"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/pulsar/entropygenerator"
+	"github.com/insolar/insolar/pulsar/storage"
+	"github.com/insolar/insolar/pulse"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestNextPulseNumber(t *testing.T) {
+	now := time.Unix(pulse.UnixTimeOfMinTimePulse+1000, 0)
+	current := pulse.OfTime(now)
+
+	table := []struct {
+		name     string
+		last     insolar.PulseNumber
+		expected insolar.PulseNumber
+	}{
+		{name: "first pulse", last: 0, expected: current},
+		{name: "in time", last: current - 10, expected: current},
+		{name: "late tick", last: current - 11, expected: current - 1},
+		{name: "ahead of clock", last: current + 30, expected: current + 40},
+		{name: "after downtime", last: current - 105, expected: current - 5},
+	}
+	for _, tc := range table {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, NextPulseNumber(tc.last, 10, now))
+		})
+	}
+}
+
+func TestNextFederatedPulseNumber(t *testing.T) {
+	for offset := int64(0); offset < 20; offset++ {
+		now := time.Unix(pulse.UnixTimeOfMinTimePulse+1000+offset, 0)
+		next := NextFederatedPulseNumber(10, now)
+		require.Equal(t, insolar.PulseNumber(0), (next-pulse.MinTimePulse)%10)
+		require.True(t, next > pulse.OfTime(now) && next <= pulse.OfTime(now)+10)
+	}
+}
+
+func newStoredPulsar(t *testing.T, db store.DB) *Pulsar {
+	proc := platformpolicy.NewKeyProcessor()
+	key, err := proc.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	p := NewPulsar(
+		configuration.NewPulsar(),
+		cryptography.NewKeyBoundCryptographyService(key),
+		platformpolicy.NewPlatformCryptographyScheme(),
+		proc,
+		testutils.NewPulseDistributorMock(t).DistributeMock.Set(func(context.Context, insolar.Pulse) {}),
+		&entropygenerator.StandardEntropyGenerator{},
+	)
+	p.Storage = storage.NewDB(db)
+	return p
+}
+
+func TestPulsar_Recover(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+
+	tmpdir, err := ioutil.TempDir("", "pulsar-test-")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpdir)
+
+	db, err := store.NewSkipListDB(store.DefaultSkipListOptions(tmpdir))
+	require.NoError(t, err)
+	p := newStoredPulsar(t, db)
+	require.NoError(t, p.Recover(ctx))
+	require.Equal(t, insolar.PulseNumber(0), p.LastPN())
+
+	first := pulse.OfNow()
+	var sent []insolar.Pulse
+	for pn := first; pn < first+HistorySize*10+50; pn += 10 {
+		require.NoError(t, p.Send(ctx, pn))
+		sent = append(sent, p.History(1)[0])
+	}
+	last := sent[len(sent)-1].PulseNumber
+	require.NoError(t, db.Stop(ctx))
+
+	db, err = store.NewSkipListDB(store.DefaultSkipListOptions(tmpdir))
+	require.NoError(t, err)
+	defer db.Stop(ctx)
+	p = newStoredPulsar(t, db)
+	require.NoError(t, p.Recover(ctx))
+
+	require.Equal(t, last, p.LastPN())
+	history := p.History(HistorySize + 1)
+	require.Len(t, history, HistorySize)
+	for i, pls := range history {
+		require.Equal(t, sent[len(sent)-1-i], pls)
+	}
+
+	require.Error(t, p.Send(ctx, last), "pulse number is sent again")
+	require.NoError(t, p.Send(ctx, NextPulseNumber(p.LastPN(), p.Config.NumberDelta, time.Now())))
+}
+
+func TestStateHandler(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+
+	tmpdir, err := ioutil.TempDir("", "pulsar-test-")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpdir)
+	db, err := store.NewSkipListDB(store.DefaultSkipListOptions(tmpdir))
+	require.NoError(t, err)
+	defer db.Stop(ctx)
+
+	p := newStoredPulsar(t, db)
+	first := pulse.OfNow()
+	for i := insolar.PulseNumber(0); i < 3; i++ {
+		require.NoError(t, p.Send(ctx, first+i*10))
+	}
+
+	server := httptest.NewServer(StateHandler(p))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "?limit=2")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var state State
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&state))
+	require.Equal(t, p.PublicKeyRaw, state.PublicKey)
+	require.False(t, state.Federated)
+	require.Equal(t, uint32(first+20), state.LastPulseNumber)
+	require.True(t, state.NextPulseNumber > state.LastPulseNumber)
+	require.Len(t, state.History, 2)
+	require.Equal(t, uint32(first+20), state.History[0].PulseNumber)
+	require.Equal(t, uint32(first+10), state.History[1].PulseNumber)
+	require.Len(t, state.History[0].Signs, 1)
+	require.Equal(t, p.PublicKeyRaw, state.History[0].Signs[0].ChosenPublicKey)
+
+	resp, err = http.Get(server.URL + "?limit=bad")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
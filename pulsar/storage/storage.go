@@ -0,0 +1,98 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package storage keeps pulses sent by pulsar.
+package storage
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/pulse"
+	"github.com/insolar/insolar/insolar/store"
+)
+
+type pulseKey insolar.PulseNumber
+
+func (k pulseKey) Scope() store.Scope {
+	return store.ScopePulse
+}
+
+func (k pulseKey) ID() []byte {
+	return insolar.PulseNumber(k).Bytes()
+}
+
+// DB keeps pulses in their network format, so signatures can be checked later.
+type DB struct {
+	lock sync.Mutex
+	db   store.DB
+}
+
+// NewDB creates pulsar storage on top of db.
+func NewDB(db store.DB) *DB {
+	return &DB{db: db}
+}
+
+// Append saves pulse. It must be after the latest saved pulse.
+func (s *DB) Append(ctx context.Context, pls insolar.Pulse) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	latest, err := s.recent(1)
+	if err != nil {
+		return err
+	}
+	if len(latest) > 0 && pls.PulseNumber <= latest[0].PulseNumber {
+		return errors.Errorf("pulse %d is not after the latest saved pulse %d", pls.PulseNumber, latest[0].PulseNumber)
+	}
+
+	buf, err := pulse.ToProto(&pls).Marshal()
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal pulse")
+	}
+	return s.db.Set(pulseKey(pls.PulseNumber), buf)
+}
+
+// Recent returns up to limit latest pulses, the latest first.
+func (s *DB) Recent(ctx context.Context, limit int) ([]insolar.Pulse, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.recent(limit)
+}
+
+func (s *DB) recent(limit int) ([]insolar.Pulse, error) {
+	it := s.db.NewIterator(pulseKey(insolar.PulseNumber(0xFFFFFFFF)), true)
+	defer it.Close()
+
+	var res []insolar.Pulse
+	for len(res) < limit && it.Next() {
+		buf, err := it.Value()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read pulse")
+		}
+		proto := &pulse.PulseProto{}
+		err = proto.Unmarshal(buf)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal pulse")
+		}
+		res = append(res, *pulse.FromProto(proto))
+	}
+	return res, nil
+}
@@ -45,8 +45,10 @@ var (
 	defaultHost                      = "127.0.0.1"
 	defaultJaegerEndPoint            = ""
 	discoveryDataDirectoryTemplate   = withBaseDir("discoverynodes/%d/data")
+	discoveryBlameDirectoryTemplate  = withBaseDir("discoverynodes/%d/blame")
 	discoveryCertificatePathTemplate = withBaseDir("discoverynodes/certs/discovery_cert_%d.json")
 	nodeDataDirectoryTemplate        = "nodes/%d/data"
+	nodeBlameDirectoryTemplate       = "nodes/%d/blame"
 	nodeCertificatePathTemplate      = "nodes/%d/cert.json"
 	pulsewatcherFileName             = withBaseDir("pulsewatcher.yaml")
 
@@ -118,7 +120,7 @@ func main() {
 	bootstrapConf, err := bootstrap.ParseConfig(bootstrapFileName)
 	check("Can't read bootstrap config", err)
 
-	pwConfig := pulsewatcher.Config{}
+	pwConfig := pulsewatcher.Config{Daemon: pulsewatcher.NewDaemonConfig()}
 	discoveryNodesConfigs := make([]configuration.Configuration, 0, len(bootstrapConf.DiscoveryNodes))
 
 	var gorundPorts [][]string
@@ -135,6 +137,7 @@ func main() {
 
 		conf.Host.Transport.Address = node.Host
 		conf.Host.Transport.Protocol = "TCP"
+		conf.Host.TrustAnyPulsar = true
 
 		rpcListenPort := 33300 + (index+nodeIndex)*nodeIndex
 		conf.LogicRunner = configuration.NewLogicRunner()
@@ -162,6 +165,7 @@ func main() {
 
 		conf.KeysPath = bootstrapConf.DiscoveryKeysDir + fmt.Sprintf(bootstrapConf.KeysNameFormat, nodeIndex)
 		conf.Ledger.Storage.DataDirectory = fmt.Sprintf(discoveryDataDirectoryTemplate, nodeIndex)
+		conf.Service.Blame.Storage.DataDirectory = fmt.Sprintf(discoveryBlameDirectoryTemplate, nodeIndex)
 		conf.CertificatePath = fmt.Sprintf(discoveryCertificatePathTemplate, nodeIndex)
 
 		discoveryNodesConfigs = append(discoveryNodesConfigs, conf)
@@ -173,6 +177,7 @@ func main() {
 
 	// process extra nodes
 	nodeDataDirectoryTemplate = filepath.Join(outputDir, nodeDataDirectoryTemplate)
+	nodeBlameDirectoryTemplate = filepath.Join(outputDir, nodeBlameDirectoryTemplate)
 	nodeCertificatePathTemplate = filepath.Join(outputDir, nodeCertificatePathTemplate)
 
 	nodesConfigs := make([]configuration.Configuration, 0, len(bootstrapConf.DiscoveryNodes))
@@ -183,6 +188,7 @@ func main() {
 
 		conf.Host.Transport.Address = node.Host
 		conf.Host.Transport.Protocol = "TCP"
+		conf.Host.TrustAnyPulsar = true
 
 		rpcListenPort := 34300 + (index+nodeIndex+len(bootstrapConf.DiscoveryNodes)+1)*nodeIndex
 		conf.LogicRunner = configuration.NewLogicRunner()
@@ -204,6 +210,7 @@ func main() {
 
 		conf.KeysPath = node.KeysFile
 		conf.Ledger.Storage.DataDirectory = fmt.Sprintf(nodeDataDirectoryTemplate, nodeIndex)
+		conf.Service.Blame.Storage.DataDirectory = fmt.Sprintf(nodeBlameDirectoryTemplate, nodeIndex)
 		conf.CertificatePath = fmt.Sprintf(nodeCertificatePathTemplate, nodeIndex)
 
 		nodesConfigs = append(nodesConfigs, conf)
@@ -20,13 +20,11 @@ import (
 	"context"
 	"fmt"
 	"net"
-	"path/filepath"
 
 	"github.com/ThreeDotsLabs/watermill"
 	watermillMsg "github.com/ThreeDotsLabs/watermill/message"
 	"github.com/ThreeDotsLabs/watermill/message/router/middleware"
 	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
-	"github.com/dgraph-io/badger"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 
@@ -63,19 +61,41 @@ import (
 	"github.com/insolar/insolar/server/internal"
 )
 
-type components struct {
-	cmp         component.Manager
-	NodeRef     string
-	NodeRole    string
+// Components is a component graph of a heavy node.
+type Components struct {
+	cmp      component.Manager
+	NodeRef  string
+	NodeRole string
+
+	// Network, pulse manager and storages of the node, exposed for in-process networks (see server/simulator).
+	Network      *servicenetwork.ServiceNetwork
+	PulseManager insolar.PulseManager
+	Pulses       insolarPulse.Accessor
+	Records      object.RecordAccessor
+	Indexes      object.IndexAccessor
+	JetKeeper    executor.JetKeeper
+
 	rollback    *executor.DBRollback
 	stateKeeper *executor.InitialStateKeeper
 	inRouter    *watermillMsg.Router
 	outRouter   *watermillMsg.Router
 
-	replicator executor.HeavyReplicator
+	replicator         executor.HeavyReplicator
+	consistencyChecker *executor.ConsistencyChecker
+
+	exporter       *grpc.Server
+	recordExporter *exporter.RecordServer
+	pulseExporter  *exporter.PulseServer
 }
 
-func newComponents(ctx context.Context, cfg configuration.Configuration, genesisCfg insolar.GenesisHeavyConfig) (*components, error) {
+// NewComponents creates and initializes components of a heavy node. Extra components are registered along with the
+// node components and take precedence over network defaults, e.g. a transport.Factory replacing real sockets.
+func NewComponents(
+	ctx context.Context,
+	cfg configuration.Configuration,
+	genesisCfg insolar.GenesisHeavyConfig,
+	extra ...interface{},
+) (*Components, error) {
 	// Cryptography.
 	var (
 		KeyProcessor  insolar.KeyProcessor
@@ -86,7 +106,7 @@ func newComponents(ctx context.Context, cfg configuration.Configuration, genesis
 	{
 		var err error
 		// Private key storage.
-		ks, err := keystore.NewKeyStore(cfg.KeysPath)
+		ks, err := keystore.NewKeyStoreFromConfig(cfg.KeysPath, cfg.KeyStore)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to load KeyStore")
 		}
@@ -112,7 +132,7 @@ func newComponents(ctx context.Context, cfg configuration.Configuration, genesis
 		}
 	}
 
-	c := &components{}
+	c := &Components{}
 	c.cmp = component.Manager{}
 	c.NodeRef = CertManager.GetCertificate().GetNodeRef().String()
 	c.NodeRole = CertManager.GetCertificate().GetRole().String()
@@ -148,6 +168,7 @@ func newComponents(ctx context.Context, cfg configuration.Configuration, genesis
 		}
 
 		Termination = termination.NewHandler(NetworkService)
+		c.Network = NetworkService
 	}
 
 	// Storage.
@@ -155,16 +176,12 @@ func newComponents(ctx context.Context, cfg configuration.Configuration, genesis
 		Coordinator jet.Coordinator
 		Pulses      *insolarPulse.DB
 		Nodes       *node.Storage
-		DB          *store.BadgerDB
+		DB          store.Engine
 		Jets        *jet.DBStore
 	)
 	{
 		var err error
-		fullDataDirectoryPath, err := filepath.Abs(cfg.Ledger.Storage.DataDirectory)
-		if err != nil {
-			panic(errors.Wrap(err, "failed to get absolute path for DataDirectory"))
-		}
-		DB, err = store.NewBadgerDB(badger.DefaultOptions(fullDataDirectoryPath))
+		DB, err = store.NewEngine(cfg.Ledger.Storage)
 		if err != nil {
 			panic(errors.Wrap(err, "failed to initialize DB"))
 		}
@@ -209,8 +226,11 @@ func newComponents(ctx context.Context, cfg configuration.Configuration, genesis
 			return nil, errors.Wrap(err, "failed to start ContractRequester")
 		}
 
+		// Events are dispatched by virtual pulse manager only, heavy node doesn't serve them.
+		apiCfg, adminAPICfg := cfg.APIRunner, cfg.AdminAPIRunner
+		apiCfg.Events.Path, adminAPICfg.Events.Path = "", ""
 		API, err := api.NewRunner(
-			&cfg.APIRunner,
+			&apiCfg,
 			CertManager,
 			Requester,
 			NetworkService,
@@ -219,13 +239,16 @@ func newComponents(ctx context.Context, cfg configuration.Configuration, genesis
 			ArtifactsClient,
 			Coordinator,
 			NetworkService,
+			NetworkService.MisbehaviorJournal,
+			Termination,
+			NetworkService,
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start ApiRunner")
 		}
 
 		AdminAPIRunner, err := api.NewRunner(
-			&cfg.AdminAPIRunner,
+			&adminAPICfg,
 			CertManager,
 			Requester,
 			NetworkService,
@@ -234,6 +257,9 @@ func newComponents(ctx context.Context, cfg configuration.Configuration, genesis
 			ArtifactsClient,
 			Coordinator,
 			NetworkService,
+			NetworkService.MisbehaviorJournal,
+			Termination,
+			NetworkService,
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start AdminAPIRunner")
@@ -257,16 +283,22 @@ func newComponents(ctx context.Context, cfg configuration.Configuration, genesis
 		Handler      *handler.Handler
 		Genesis      *genesis.Genesis
 		Records      *object.RecordDB
+		Indexes      *object.IndexDB
 		JetKeeper    *executor.DBJetKeeper
 	)
 	{
 		Records = object.NewRecordDB(DB)
-		indexes := object.NewIndexDB(DB, Records)
+		Indexes = object.NewIndexDB(DB, Records)
 		drops := drop.NewDB(DB)
 		JetKeeper = executor.NewJetKeeper(Jets, DB, Pulses)
 
-		c.rollback = executor.NewDBRollback(JetKeeper, drops, Records, indexes, Jets, Pulses, JetKeeper)
-		c.stateKeeper = executor.NewInitialStateKeeper(JetKeeper, Jets, Coordinator, indexes, drops)
+		c.Pulses = Pulses
+		c.Records = Records
+		c.Indexes = Indexes
+		c.JetKeeper = JetKeeper
+
+		c.rollback = executor.NewDBRollback(JetKeeper, drops, Records, Indexes, Jets, Pulses, JetKeeper)
+		c.stateKeeper = executor.NewInitialStateKeeper(JetKeeper, Jets, Coordinator, Indexes, drops)
 
 		sp := insolarPulse.NewStartPulse()
 
@@ -284,16 +316,21 @@ func newComponents(ctx context.Context, cfg configuration.Configuration, genesis
 		PulseManager.JetModifier = Jets
 		PulseManager.StartPulse = sp
 		PulseManager.FinalizationKeeper = executor.NewFinalizationKeeperDefault(JetKeeper, Pulses, cfg.Ledger.LightChainLimit)
+		c.PulseManager = PulseManager
 
-		replicator := executor.NewHeavyReplicatorDefault(Records, indexes, CryptoScheme, Pulses, drops, JetKeeper, backupMaker, Jets)
+		replicator := executor.NewHeavyReplicatorDefault(Records, Indexes, CryptoScheme, Pulses, drops, JetKeeper, backupMaker, Jets)
 		c.replicator = replicator
 
+		if cfg.Ledger.ConsistencyCheck.Enabled {
+			c.consistencyChecker = executor.NewConsistencyChecker(Pulses, Records, Indexes, drops, Jets, JetKeeper)
+		}
+
 		h := handler.New(cfg.Ledger)
 		h.RecordAccessor = Records
 		h.RecordModifier = Records
 		h.JetCoordinator = Coordinator
-		h.IndexAccessor = indexes
-		h.IndexModifier = indexes
+		h.IndexAccessor = Indexes
+		h.IndexModifier = Indexes
 		h.DropModifier = drops
 		h.PCS = CryptoScheme
 		h.PulseAccessor = Pulses
@@ -316,8 +353,8 @@ func newComponents(ctx context.Context, cfg configuration.Configuration, genesis
 			PCS:            CryptoScheme,
 			RecordAccessor: Records,
 			RecordModifier: Records,
-			IndexModifier:  indexes,
-			IndexAccessor:  indexes,
+			IndexModifier:  Indexes,
+			IndexAccessor:  Indexes,
 		}
 		Genesis = &genesis.Genesis{
 			ArtifactManager: artifactManager,
@@ -327,7 +364,7 @@ func newComponents(ctx context.Context, cfg configuration.Configuration, genesis
 				PulseAppender:  Pulses,
 				PulseAccessor:  Pulses,
 				RecordModifier: Records,
-				IndexModifier:  indexes,
+				IndexModifier:  Indexes,
 			},
 
 			DiscoveryNodes:  genesisCfg.DiscoveryNodes,
@@ -336,17 +373,19 @@ func newComponents(ctx context.Context, cfg configuration.Configuration, genesis
 	}
 
 	// Exporter
-	var (
-		recordExporter *exporter.RecordServer
-		pulseExporter  *exporter.PulseServer
-	)
 	{
-		recordExporter = exporter.NewRecordServer(Pulses, Records, Records, JetKeeper)
-		pulseExporter = exporter.NewPulseServer(Pulses, JetKeeper)
+		recordExporter := exporter.NewRecordServer(Pulses, Records, Records, JetKeeper)
+		pulseExporter := exporter.NewPulseServer(Pulses, JetKeeper)
+		historyServer := exporter.NewHistoryServer(Indexes, Records, JetKeeper)
 
 		grpcServer := grpc.NewServer()
 		exporter.RegisterRecordExporterServer(grpcServer, recordExporter)
 		exporter.RegisterPulseExporterServer(grpcServer, pulseExporter)
+		exporter.RegisterObjectHistoryServer(grpcServer, historyServer)
+
+		c.exporter = grpcServer
+		c.recordExporter = recordExporter
+		c.pulseExporter = pulseExporter
 
 		lis, err := net.Listen("tcp", cfg.Exporter.Addr)
 		if err != nil {
@@ -360,6 +399,7 @@ func newComponents(ctx context.Context, cfg configuration.Configuration, genesis
 		}()
 	}
 
+	c.cmp.Register(extra...)
 	c.cmp.Inject(
 		DB,
 		WmBus,
@@ -396,7 +436,7 @@ func newComponents(ctx context.Context, cfg configuration.Configuration, genesis
 	return c, nil
 }
 
-func (c *components) Start(ctx context.Context) error {
+func (c *Components) Start(ctx context.Context) error {
 	err := c.rollback.Start(ctx)
 	if err != nil {
 		return errors.Wrapf(err, "rollback.Start return error: %s", err.Error())
@@ -406,10 +446,14 @@ func (c *components) Start(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrapf(err, "stateKeeper.Start return error: %s", err.Error())
 	}
+
+	if c.consistencyChecker != nil {
+		c.consistencyChecker.Start(ctx)
+	}
 	return c.cmp.Start(ctx)
 }
 
-func (c *components) Stop(ctx context.Context) error {
+func (c *Components) Stop(ctx context.Context) error {
 	err := c.inRouter.Close()
 	if err != nil {
 		inslogger.FromContext(ctx).Error("Error while closing router", err)
@@ -419,10 +463,19 @@ func (c *components) Stop(ctx context.Context) error {
 		inslogger.FromContext(ctx).Error("Error while closing router", err)
 	}
 	c.replicator.Stop()
+	if c.consistencyChecker != nil {
+		c.consistencyChecker.Stop()
+	}
+
+	// Release streams in follow mode before graceful stop, otherwise it waits for them forever.
+	c.recordExporter.Stop()
+	c.pulseExporter.Stop()
+	c.exporter.GracefulStop()
+
 	return c.cmp.Stop(ctx)
 }
 
-func (c *components) startWatermill(
+func (c *Components) startWatermill(
 	ctx context.Context,
 	logger watermill.LoggerAdapter,
 	sub watermillMsg.Subscriber,
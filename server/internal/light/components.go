@@ -54,14 +54,25 @@ import (
 	"github.com/insolar/insolar/server/internal"
 )
 
-type components struct {
+// Components is a component graph of a light node.
+type Components struct {
 	cmp               component.Manager
 	NodeRef, NodeRole string
-	replicator        executor.LightReplicator
-	cleaner           executor.Cleaner
+
+	// Network, pulse manager and storages of the node, exposed for in-process networks (see server/simulator).
+	Network      *servicenetwork.ServiceNetwork
+	PulseManager insolar.PulseManager
+	Pulses       pulse.Accessor
+	Records      object.RecordAccessor
+	Indexes      object.IndexAccessor
+
+	replicator executor.LightReplicator
+	cleaner    executor.Cleaner
 }
 
-func newComponents(ctx context.Context, cfg configuration.Configuration) (*components, error) {
+// NewComponents creates and initializes components of a light node. Extra components are registered along with the
+// node components and take precedence over network defaults, e.g. a transport.Factory replacing real sockets.
+func NewComponents(ctx context.Context, cfg configuration.Configuration, extra ...interface{}) (*Components, error) {
 	// Cryptography.
 	var (
 		KeyProcessor  insolar.KeyProcessor
@@ -72,7 +83,7 @@ func newComponents(ctx context.Context, cfg configuration.Configuration) (*compo
 	{
 		var err error
 		// Private key storage.
-		ks, err := keystore.NewKeyStore(cfg.KeysPath)
+		ks, err := keystore.NewKeyStoreFromConfig(cfg.KeysPath, cfg.KeyStore)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to load KeyStore")
 		}
@@ -98,7 +109,7 @@ func newComponents(ctx context.Context, cfg configuration.Configuration) (*compo
 		}
 	}
 
-	comps := &components{}
+	comps := &Components{}
 	comps.cmp = component.Manager{}
 	comps.NodeRef = CertManager.GetCertificate().GetNodeRef().String()
 	comps.NodeRole = CertManager.GetCertificate().GetRole().String()
@@ -121,7 +132,6 @@ func newComponents(ctx context.Context, cfg configuration.Configuration) (*compo
 	// Network.
 	var (
 		NetworkService *servicenetwork.ServiceNetwork
-		Termination    insolar.TerminationHandler
 	)
 	{
 		var err error
@@ -131,7 +141,7 @@ func newComponents(ctx context.Context, cfg configuration.Configuration) (*compo
 			return nil, errors.Wrap(err, "failed to start Network")
 		}
 
-		Termination = termination.NewHandler(NetworkService)
+		comps.Network = NetworkService
 	}
 
 	// Role calculations.
@@ -165,11 +175,10 @@ func newComponents(ctx context.Context, cfg configuration.Configuration) (*compo
 		Sender = bus.NewBus(cfg.Bus, publisher, Pulses, Coordinator, CryptoScheme)
 	}
 
-	// API.
+	// Contract requester.
 	var (
 		Requester       *contractrequester.ContractRequester
 		ArtifactsClient = artifacts.NewClient(Sender)
-		APIWrapper      *api.RunnerWrapper
 	)
 	{
 		var err error
@@ -182,38 +191,6 @@ func newComponents(ctx context.Context, cfg configuration.Configuration) (*compo
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to start ContractRequester")
 		}
-
-		API, err := api.NewRunner(
-			&cfg.APIRunner,
-			CertManager,
-			Requester,
-			NetworkService,
-			NetworkService,
-			Pulses,
-			ArtifactsClient,
-			Coordinator,
-			NetworkService,
-		)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to start ApiRunner")
-		}
-
-		AdminAPIRunner, err := api.NewRunner(
-			&cfg.AdminAPIRunner,
-			CertManager,
-			Requester,
-			NetworkService,
-			NetworkService,
-			Pulses,
-			ArtifactsClient,
-			Coordinator,
-			NetworkService,
-		)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to start AdminAPIRunner")
-		}
-
-		APIWrapper = api.NewWrapper(API, AdminAPIRunner)
 	}
 
 	metricsHandler, err := metrics.NewMetrics(
@@ -230,6 +207,7 @@ func newComponents(ctx context.Context, cfg configuration.Configuration) (*compo
 	var (
 		PulseManager   *executor.PulseManager
 		FlowDispatcher dispatcher.Dispatcher
+		Termination    insolar.TerminationHandler
 	)
 	{
 		conf := cfg.Ledger
@@ -240,6 +218,10 @@ func newComponents(ctx context.Context, cfg configuration.Configuration) (*compo
 		writeController := executor.NewWriteController()
 		hotWaitReleaser := executor.NewChannelWaiter()
 
+		comps.Pulses = Pulses
+		comps.Records = records
+		comps.Indexes = indexes
+
 		c := component.Manager{}
 		c.Inject(CryptoScheme)
 
@@ -287,6 +269,10 @@ func newComponents(ctx context.Context, cfg configuration.Configuration) (*compo
 			Jets,
 		)
 		comps.replicator = lthSyncer
+		Termination = termination.NewHandler(
+			NetworkService,
+			executor.NewReplicationDrainer(lthSyncer, Pulses, Pulses),
+		)
 
 		jetSplitter := executor.NewJetSplitter(
 			conf.JetSplit, jetCalculator, Jets, Jets, drops, drops, Pulses, records,
@@ -352,8 +338,58 @@ func newComponents(ctx context.Context, cfg configuration.Configuration) (*compo
 			stateIniter,
 			hotWaitReleaser,
 		)
+		comps.PulseManager = PulseManager
+	}
+
+	// API.
+	var (
+		APIWrapper *api.RunnerWrapper
+	)
+	{
+		var err error
+		// Events are dispatched by virtual pulse manager only, light node doesn't serve them.
+		apiCfg, adminAPICfg := cfg.APIRunner, cfg.AdminAPIRunner
+		apiCfg.Events.Path, adminAPICfg.Events.Path = "", ""
+		API, err := api.NewRunner(
+			&apiCfg,
+			CertManager,
+			Requester,
+			NetworkService,
+			NetworkService,
+			Pulses,
+			ArtifactsClient,
+			Coordinator,
+			NetworkService,
+			NetworkService.MisbehaviorJournal,
+			Termination,
+			NetworkService,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to start ApiRunner")
+		}
+
+		AdminAPIRunner, err := api.NewRunner(
+			&adminAPICfg,
+			CertManager,
+			Requester,
+			NetworkService,
+			NetworkService,
+			Pulses,
+			ArtifactsClient,
+			Coordinator,
+			NetworkService,
+			NetworkService.MisbehaviorJournal,
+			Termination,
+			NetworkService,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to start AdminAPIRunner")
+		}
+
+		APIWrapper = api.NewWrapper(API, AdminAPIRunner)
 	}
 
+	comps.cmp.Register(extra...)
 	comps.cmp.Inject(
 		Sender,
 		Jets,
@@ -383,17 +419,17 @@ func newComponents(ctx context.Context, cfg configuration.Configuration) (*compo
 	return comps, nil
 }
 
-func (c *components) Start(ctx context.Context) error {
+func (c *Components) Start(ctx context.Context) error {
 	return c.cmp.Start(ctx)
 }
 
-func (c *components) Stop(ctx context.Context) error {
+func (c *Components) Stop(ctx context.Context) error {
 	c.replicator.Stop()
 	c.cleaner.Stop()
 	return c.cmp.Stop(ctx)
 }
 
-func (c *components) startWatermill(
+func (c *Components) startWatermill(
 	ctx context.Context,
 	logger watermill.LoggerAdapter,
 	sub message.Subscriber,
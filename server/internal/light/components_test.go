@@ -33,6 +33,6 @@ func TestComponents(t *testing.T) {
 	cfg.Metrics.ListenAddress = "0.0.0.0:0"
 	cfg.APIRunner.Address = "0.0.0.0:0"
 
-	_, err := newComponents(ctx, cfg)
+	_, err := NewComponents(ctx, cfg)
 	require.NoError(t, err)
 }
@@ -63,7 +63,7 @@ func (s *Server) Serve() {
 	traceID := "main_" + utils.RandTraceID()
 	ctx, inslog := inslogger.InitNodeLogger(ctx, cfg.Log, traceID, "", "")
 
-	cmp, err := newComponents(ctx, *cfg)
+	cmp, err := NewComponents(ctx, *cfg)
 	fatal(ctx, err, "failed to create components")
 
 	ctx, jaegerFlush := internal.Jaeger(ctx, cfg.Tracer.Jaeger, traceID, cmp.NodeRef, cmp.NodeRole)
@@ -61,7 +61,7 @@ type bootstrapComponents struct {
 func initBootstrapComponents(ctx context.Context, cfg configuration.Configuration) bootstrapComponents {
 	earlyComponents := component.Manager{}
 
-	keyStore, err := keystore.NewKeyStore(cfg.KeysPath)
+	keyStore, err := keystore.NewKeyStoreFromConfig(cfg.KeysPath, cfg.KeyStore)
 	checkError(ctx, err, "failed to load KeyStore: ")
 
 	platformCryptographyScheme := platformpolicy.NewPlatformCryptographyScheme()
@@ -97,6 +97,56 @@ func initCertificateManager(
 	return certManager
 }
 
+// Components is a component graph of a virtual node.
+type Components struct {
+	cmp           *component.Manager
+	termination   insolar.TerminationHandler
+	stopWatermill func()
+
+	NodeRef, NodeRole string
+
+	// Network, pulse manager, storages and contract requester of the node, exposed for in-process networks
+	// (see server/simulator).
+	Network           *servicenetwork.ServiceNetwork
+	PulseManager      insolar.PulseManager
+	Pulses            pulse.Accessor
+	ContractRequester insolar.ContractRequester
+}
+
+// NewComponents creates and initializes components of a virtual node. Extra components are registered along with the
+// node components and take precedence over network defaults, e.g. a transport.Factory replacing real sockets.
+func NewComponents(ctx context.Context, cfg configuration.Configuration, extra ...interface{}) *Components {
+	bootstrapComponents := initBootstrapComponents(ctx, cfg)
+	certManager := initCertificateManager(
+		ctx,
+		cfg,
+		bootstrapComponents.CryptographyService,
+		bootstrapComponents.KeyProcessor,
+	)
+	return initComponents(
+		ctx,
+		cfg,
+		bootstrapComponents.CryptographyService,
+		bootstrapComponents.PlatformCryptographyScheme,
+		bootstrapComponents.KeyStore,
+		bootstrapComponents.KeyProcessor,
+		certManager,
+		extra...,
+	)
+}
+
+// Start starts all components of the node.
+func (c *Components) Start(ctx context.Context) error {
+	return c.cmp.Start(ctx)
+}
+
+// Stop stops all components of the node. Unlike graceful stop of insolard, the node doesn't wait for the network to
+// accept its leaving.
+func (c *Components) Stop(ctx context.Context) error {
+	c.stopWatermill()
+	return c.cmp.Stop(ctx)
+}
+
 // initComponents creates and links all insolard components
 func initComponents(
 	ctx context.Context,
@@ -106,8 +156,8 @@ func initComponents(
 	keyStore insolar.KeyStore,
 	keyProcessor insolar.KeyProcessor,
 	certManager insolar.CertificateManager,
-
-) (*component.Manager, insolar.TerminationHandler, func()) {
+	extra ...interface{},
+) *Components {
 	cm := component.Manager{}
 
 	// Watermill.
@@ -128,8 +178,6 @@ func initComponents(
 	nw, err := servicenetwork.NewServiceNetwork(cfg, &cm)
 	checkError(ctx, err, "failed to start Network")
 
-	terminationHandler := termination.NewHandler(nw)
-
 	metricsHandler, err := metrics.NewMetrics(ctx, cfg.Metrics, metrics.GetInsolarRegistry("virtual"), "virtual")
 	checkError(ctx, err, "failed to start Metrics")
 
@@ -142,6 +190,8 @@ func initComponents(
 	logicRunner, err := logicrunner.NewLogicRunner(&cfg.LogicRunner, publisher, b)
 	checkError(ctx, err, "failed to start LogicRunner")
 
+	terminationHandler := termination.NewHandler(nw, logicRunner)
+
 	contractRequester, err := contractrequester.New(
 		b,
 		pulses,
@@ -162,6 +212,9 @@ func initComponents(
 		artifactsClient,
 		jc,
 		nw,
+		nw.MisbehaviorJournal,
+		terminationHandler,
+		nw,
 	)
 	checkError(ctx, err, "failed to start ApiRunner")
 
@@ -175,6 +228,9 @@ func initComponents(
 		artifactsClient,
 		jc,
 		nw,
+		nw.MisbehaviorJournal,
+		terminationHandler,
+		nw,
 	)
 	checkError(ctx, err, "failed to start AdminAPIRunner")
 
@@ -183,8 +239,12 @@ func initComponents(
 	// TODO: remove this hack in INS-3341
 	contractRequester.LR = logicRunner
 
+	contractRequester.AddResultHook(API.Events.OnResult)
+	contractRequester.AddResultHook(AdminAPIRunner.Events.OnResult)
+
 	pm := pulsemanager.NewPulseManager()
 
+	cm.Register(extra...)
 	cm.Register(
 		terminationHandler,
 		pcs,
@@ -224,14 +284,26 @@ func initComponents(
 	checkError(ctx, err, "failed to init components")
 
 	// this should be done after Init due to inject
-	pm.AddDispatcher(logicRunner.FlowDispatcher, contractRequester.FlowDispatcher)
-
-	return &cm, terminationHandler, startWatermill(
-		ctx, wmLogger, subscriber, b,
-		nw.SendMessageHandler,
-		logicRunner.FlowDispatcher.Process,
-		contractRequester.FlowDispatcher.Process,
-	)
+	pm.AddDispatcher(logicRunner.FlowDispatcher, contractRequester.FlowDispatcher, API.Events, AdminAPIRunner.Events)
+
+	return &Components{
+		cmp:         &cm,
+		termination: terminationHandler,
+		stopWatermill: startWatermill(
+			ctx, wmLogger, subscriber, b,
+			nw.SendMessageHandler,
+			logicRunner.FlowDispatcher.Process,
+			contractRequester.FlowDispatcher.Process,
+		),
+
+		NodeRef:  certManager.GetCertificate().GetNodeRef().String(),
+		NodeRole: certManager.GetCertificate().GetRole().String(),
+
+		Network:           nw,
+		PulseManager:      pm,
+		Pulses:            pulses,
+		ContractRequester: contractRequester,
+	}
 }
 
 func startWatermill(
@@ -40,7 +40,7 @@ func TestComponents(t *testing.T) {
 		bootstrapComponents.CryptographyService,
 		bootstrapComponents.KeyProcessor,
 	)
-	cm, _, stopWatermill := initComponents(
+	c := initComponents(
 		ctx,
 		cfg,
 		bootstrapComponents.CryptographyService,
@@ -49,9 +49,9 @@ func TestComponents(t *testing.T) {
 		bootstrapComponents.KeyProcessor,
 		cert,
 	)
-	require.NotNil(t, cm)
-	require.NotNil(t, stopWatermill)
+	require.NotNil(t, c.cmp)
+	require.NotNil(t, c.stopWatermill)
 
-	err := cm.Init(ctx)
+	err := c.cmp.Init(ctx)
 	require.NoError(t, err)
 }
@@ -77,7 +77,7 @@ func (s *Server) Serve() {
 	ctx, jaegerFlush := internal.Jaeger(ctx, cfg.Tracer.Jaeger, traceID, nodeRef, nodeRole)
 	defer jaegerFlush()
 
-	cm, th, stopWatermill := initComponents(
+	c := initComponents(
 		ctx,
 		*cfg,
 		bootstrapComponents.CryptographyService,
@@ -98,19 +98,19 @@ func (s *Server) Serve() {
 		inslog.Debug("caught sig: ", sig)
 
 		inslog.Warn("GRACEFUL STOP APP")
-		th.Leave(ctx, 10)
+		c.termination.Leave(ctx, 10)
 		inslog.Info("main leave ends ")
-		err = cm.GracefulStop(ctx)
+		err = c.cmp.GracefulStop(ctx)
 		checkError(ctx, err, "failed to graceful stop components")
 
-		stopWatermill()
+		c.stopWatermill()
 
-		err = cm.Stop(ctx)
+		err = c.cmp.Stop(ctx)
 		checkError(ctx, err, "failed to stop components")
 		close(waitChannel)
 	}()
 
-	err = cm.Start(ctx)
+	err = c.Start(ctx)
 	checkError(ctx, err, "failed to start components")
 	fmt.Println("All components were started")
 	<-waitChannel
@@ -0,0 +1,207 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package simulator
+
+import (
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/certificate"
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/genesisrefs"
+	"github.com/insolar/insolar/insolar/secrets"
+	"github.com/insolar/insolar/platformpolicy"
+)
+
+// firstPort is a port of the first node address. Addresses only identify nodes in the in-memory network,
+// nothing listens on them.
+const firstPort = 10000
+
+type nodeInfo struct {
+	name       string
+	role       insolar.StaticRole
+	address    string
+	privateKey crypto.PrivateKey
+	publicKey  string
+	keysPath   string
+	certPath   string
+}
+
+func (ni nodeInfo) reference() insolar.Reference {
+	return genesisrefs.GenesisRef(ni.publicKey)
+}
+
+// generateKeys creates a key pair and writes it to the key file format read by keystore.
+func generateKeys(path string) (crypto.PrivateKey, string, error) {
+	pair, err := secrets.GenerateKeyPair()
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to generate keys")
+	}
+
+	kp := platformpolicy.NewKeyProcessor()
+	privateKey, err := kp.ExportPrivateKeyPEM(pair.Private)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to export private key")
+	}
+	publicKey, err := kp.ExportPublicKeyPEM(pair.Public)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to export public key")
+	}
+
+	if path != "" {
+		data, err := json.MarshalIndent(map[string]interface{}{
+			"private_key": string(privateKey),
+			"public_key":  string(publicKey),
+		}, "", "    ")
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed to marshal keys")
+		}
+		if err := ioutil.WriteFile(path, data, 0600); err != nil {
+			return nil, "", errors.Wrapf(err, "failed to write keys to %s", path)
+		}
+	}
+	return pair.Private, string(publicKey), nil
+}
+
+// makeNodes generates keys of nodes with given roles. Every node of the simulated network is a discovery node,
+// pulses are accepted from the pulsar with given public key only.
+func makeNodes(dir string, roles []insolar.StaticRole, pulsarKey string) ([]nodeInfo, error) {
+	nodes := make([]nodeInfo, 0, len(roles))
+	for i, role := range roles {
+		name := fmt.Sprintf("%s_%d", role, i)
+		n := nodeInfo{
+			name:     name,
+			role:     role,
+			address:  fmt.Sprintf("127.0.0.1:%d", firstPort+i),
+			keysPath: filepath.Join(dir, name+"_keys.json"),
+			certPath: filepath.Join(dir, name+"_cert.json"),
+		}
+
+		var err error
+		n.privateKey, n.publicKey, err = generateKeys(n.keysPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to make keys of %s", name)
+		}
+		nodes = append(nodes, n)
+	}
+
+	for _, n := range nodes {
+		if err := writeCertificate(n, nodes, pulsarKey); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// writeCertificate writes certificate of the node signed by all discovery nodes, the same way bootstrap does it.
+func writeCertificate(n nodeInfo, discovery []nodeInfo, pulsarKey string) error {
+	cert := certificate.Certificate{
+		AuthorizationCertificate: certificate.AuthorizationCertificate{
+			PublicKey: n.publicKey,
+			Role:      n.role.String(),
+			Reference: n.reference().String(),
+		},
+		RootDomainReference: genesisrefs.ContractRootDomain.String(),
+		PulsarPublicKeys:    []string{pulsarKey},
+	}
+	cert.MinRoles.Virtual = 1
+	cert.MinRoles.HeavyMaterial = 1
+	cert.MinRoles.LightMaterial = 1
+
+	for _, d := range discovery {
+		cert.BootstrapNodes = append(cert.BootstrapNodes, certificate.BootstrapNode{
+			PublicKey: d.publicKey,
+			Host:      d.address,
+			NodeRef:   d.reference().String(),
+			NodeRole:  d.role.String(),
+		})
+	}
+
+	var err error
+	for i, d := range discovery {
+		cert.BootstrapNodes[i].NetworkSign, err = cert.SignNetworkPart(d.privateKey)
+		if err != nil {
+			return errors.Wrapf(err, "failed to sign network part for %s", d.reference())
+		}
+		cert.BootstrapNodes[i].NodeSign, err = cert.SignNodePart(d.privateKey)
+		if err != nil {
+			return errors.Wrapf(err, "failed to sign node part for %s", d.reference())
+		}
+	}
+
+	data, err := json.MarshalIndent(cert, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal certificate")
+	}
+	return errors.Wrapf(ioutil.WriteFile(n.certPath, data, 0600), "failed to write certificate to %s", n.certPath)
+}
+
+// genesisConfig returns heavy genesis config registering the nodes. Members created by genesis get fresh keys which
+// are not kept, the simulator doesn't sign member requests.
+func genesisConfig(nodes []nodeInfo) (insolar.GenesisHeavyConfig, error) {
+	cfg := insolar.GenesisHeavyConfig{}
+	for _, n := range nodes {
+		cfg.DiscoveryNodes = append(cfg.DiscoveryNodes, insolar.DiscoveryNodeRegister{
+			Role:      n.role.String(),
+			PublicKey: n.publicKey,
+		})
+	}
+
+	keys := make([]string, 4)
+	for i := range keys {
+		_, key, err := generateKeys("")
+		if err != nil {
+			return cfg, errors.Wrap(err, "failed to make member keys")
+		}
+		keys[i] = key
+	}
+	cfg.ContractsConfig = insolar.GenesisContractsConfig{
+		RootBalance:                 "0",
+		MDBalance:                   "0",
+		RootPublicKey:               keys[0],
+		FeePublicKey:                keys[1],
+		MigrationAdminPublicKey:     keys[2],
+		FundsAndEnterprisePublicKey: keys[3],
+		VestingStepInPulses:         1,
+	}
+	return cfg, nil
+}
+
+// nodeConfig returns configuration of the node. Every listener is moved to a random port or disabled, so several
+// simulated networks may run in parallel, and the logic runner executes builtin contracts only.
+func nodeConfig(dir string, n nodeInfo) configuration.Configuration {
+	cfg := configuration.NewConfiguration()
+	cfg.KeysPath = n.keysPath
+	cfg.CertificatePath = n.certPath
+	cfg.Host.Transport.Address = n.address
+
+	cfg.Metrics.ListenAddress = "127.0.0.1:0"
+	cfg.APIRunner.Address = "127.0.0.1:0"
+	cfg.AdminAPIRunner.Address = "127.0.0.1:0"
+	cfg.Exporter.Addr = "127.0.0.1:0"
+	cfg.Introspection.Addr = ""
+	cfg.LogicRunner.RPCListen = ""
+	cfg.LogicRunner.GoPlugin = nil
+	cfg.Ledger.Storage.DataDirectory = filepath.Join(dir, n.name+"_data")
+	return cfg
+}
@@ -0,0 +1,140 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package simulator
+
+import (
+	"context"
+	"crypto"
+	"math/rand"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/network/pulsenetwork"
+	"github.com/insolar/insolar/network/transport"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/pulsar"
+	"github.com/insolar/insolar/pulse"
+)
+
+// steppedPulsar distributes pulses on demand. Pulse numbers follow from the genesis pulse and the pulse delta,
+// entropy comes from a seeded source, so a simulation replays the same pulses.
+type steppedPulsar struct {
+	cm          *component.Manager
+	distributor insolar.PulseDistributor
+
+	privateKey crypto.PrivateKey
+	publicKey  string
+	rand       *rand.Rand
+	delta      insolar.PulseNumber
+	epoch      int
+
+	last insolar.Pulse
+}
+
+func newSteppedPulsar(
+	network *transport.FakeNetwork,
+	address string,
+	bootstrapHosts []string,
+	privateKey crypto.PrivateKey,
+	publicKey string,
+	seed int64,
+	delta uint16,
+) (*steppedPulsar, error) {
+	distributor, err := pulsenetwork.NewDistributor(configuration.PulseDistributor{
+		BootstrapHosts:      bootstrapHosts,
+		PulseRequestTimeout: configuration.NewPulsar().PulseDistributor.PulseRequestTimeout,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create pulse distributor")
+	}
+
+	transportCfg := configuration.NewHostNetwork().Transport
+	transportCfg.Address = address
+
+	cm := &component.Manager{}
+	cm.Register(network.NewFactory(transportCfg))
+	cm.Inject(distributor)
+
+	return &steppedPulsar{
+		cm:          cm,
+		distributor: distributor,
+		privateKey:  privateKey,
+		publicKey:   publicKey,
+		rand:        rand.New(rand.NewSource(seed)),
+		delta:       insolar.PulseNumber(delta),
+		last:        *insolar.GenesisPulse,
+	}, nil
+}
+
+func (p *steppedPulsar) Start(ctx context.Context) error {
+	if err := p.cm.Init(ctx); err != nil {
+		return errors.Wrap(err, "failed to init pulsar components")
+	}
+	return errors.Wrap(p.cm.Start(ctx), "failed to start pulsar components")
+}
+
+func (p *steppedPulsar) Stop(ctx context.Context) error {
+	return p.cm.Stop(ctx)
+}
+
+// Next builds, signs and distributes the pulse following the last one. Distribution returns when the pulse is
+// delivered to bootstrap hosts, not when nodes have processed it.
+func (p *steppedPulsar) Next(ctx context.Context) (insolar.Pulse, error) {
+	pn := p.last.PulseNumber + p.delta
+	if p.epoch == 0 {
+		p.epoch = int(pn)
+	}
+	timestamp, err := pulse.Number(pn).AsApproximateTime()
+	if err != nil {
+		return insolar.Pulse{}, errors.Wrap(err, "failed to get pulse time")
+	}
+
+	next := insolar.Pulse{
+		PulseNumber:      pn,
+		PrevPulseNumber:  p.last.PulseNumber,
+		NextPulseNumber:  pn + p.delta,
+		EpochPulseNumber: p.epoch,
+		PulseTimestamp:   timestamp.UnixNano(),
+	}
+	p.rand.Read(next.Entropy[:])
+	p.rand.Read(next.OriginID[:])
+
+	psc := insolar.PulseSenderConfirmation{
+		PulseNumber:     next.PulseNumber,
+		ChosenPublicKey: p.publicKey,
+		Entropy:         next.Entropy,
+	}
+	payload := pulsar.PulseSenderConfirmationPayload{PulseSenderConfirmation: psc}
+	hash, err := payload.Hash(platformpolicy.NewPlatformCryptographyScheme().IntegrityHasher())
+	if err != nil {
+		return next, errors.Wrap(err, "failed to hash pulse confirmation")
+	}
+	sign, err := cryptography.NewKeyBoundCryptographyService(p.privateKey).Sign(hash)
+	if err != nil {
+		return next, errors.Wrap(err, "failed to sign pulse confirmation")
+	}
+	psc.Signature = sign.Bytes()
+	next.Signs = map[string]insolar.PulseSenderConfirmation{p.publicKey: psc}
+
+	p.distributor.Distribute(ctx, next)
+	p.last = next
+	return next, nil
+}
@@ -0,0 +1,351 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package simulator runs a network of virtual, light and heavy nodes in a single process. Nodes are assembled from
+// the same component graphs as insolard, connected by an in-memory transport and driven by a pulsar which
+// distributes pulses only when asked to, so integration tests can step pulses and assert on ledger and contract
+// state of any node.
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/pulse"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/heavy/executor"
+	"github.com/insolar/insolar/ledger/object"
+	"github.com/insolar/insolar/network/consensus"
+	"github.com/insolar/insolar/network/node"
+	"github.com/insolar/insolar/network/servicenetwork"
+	"github.com/insolar/insolar/network/transport"
+	"github.com/insolar/insolar/server/internal/heavy"
+	"github.com/insolar/insolar/server/internal/light"
+	"github.com/insolar/insolar/server/internal/virtual"
+)
+
+const (
+	// pollInterval is how often NextPulse checks whether nodes have reached the pulse.
+	pollInterval = 50 * time.Millisecond
+	// startPower is the power nodes announce when joining.
+	startPower insolar.Power = 10
+)
+
+// Config holds parameters of a simulated network. The network always has a single heavy material node.
+type Config struct {
+	// Virtual is a count of virtual nodes.
+	Virtual int
+	// Light is a count of light material nodes.
+	Light int
+	// Seed is a seed of pulse entropy.
+	Seed int64
+	// PulseDelta is a difference between subsequent pulse numbers.
+	PulseDelta uint16
+	// StepTimeout limits the time NextPulse waits for all nodes to reach a pulse. Nodes consider the network lost
+	// when no pulse comes for network.PulseWatchdogTimeout, so steps have to be shorter than that.
+	StepTimeout time.Duration
+	// Dir is a directory for keys, certificates and heavy storage. If empty, a temporary directory is created
+	// and removed by Stop.
+	Dir string
+}
+
+// NewConfig creates new default configuration of a simulated network.
+func NewConfig() Config {
+	return Config{
+		Virtual:     1,
+		Light:       1,
+		Seed:        1,
+		PulseDelta:  4,
+		StepTimeout: 20 * time.Second,
+	}
+}
+
+// Node is a node of a simulated network.
+type Node struct {
+	Ref     insolar.Reference
+	Role    insolar.StaticRole
+	Address string
+
+	Network *servicenetwork.ServiceNetwork
+	Pulses  pulse.Accessor
+
+	// Records and Indexes are storages of light and heavy material nodes, nil on virtual nodes.
+	Records object.RecordAccessor
+	Indexes object.IndexAccessor
+	// JetKeeper tracks pulses replicated to the heavy material node, nil on other nodes.
+	JetKeeper executor.JetKeeper
+	// ContractRequester sends requests from a virtual node, nil on other nodes.
+	ContractRequester insolar.ContractRequester
+
+	ctx        context.Context
+	components interface {
+		Start(ctx context.Context) error
+		Stop(ctx context.Context) error
+	}
+}
+
+// Simulator is a network of nodes running in the current process.
+type Simulator struct {
+	cfg    Config
+	dir    string
+	ownDir bool
+
+	nodes  []*Node
+	pulsar *steppedPulsar
+	pulse  insolar.Pulse
+}
+
+// New creates and initializes nodes of a simulated network and runs genesis on the heavy material node. Nodes are
+// bootstrapped in place, the network is complete once started.
+func New(ctx context.Context, cfg Config) (*Simulator, error) {
+	s := &Simulator{cfg: cfg, dir: cfg.Dir, pulse: *insolar.GenesisPulse}
+	if s.dir == "" {
+		dir, err := ioutil.TempDir("", "simulator")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create simulator directory")
+		}
+		s.dir = dir
+		s.ownDir = true
+	}
+
+	if err := s.init(ctx); err != nil {
+		if s.ownDir {
+			os.RemoveAll(s.dir) // nolint: errcheck
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Simulator) init(ctx context.Context) error {
+	cfg := s.cfg
+	roles := []insolar.StaticRole{insolar.StaticRoleHeavyMaterial}
+	for i := 0; i < cfg.Light; i++ {
+		roles = append(roles, insolar.StaticRoleLightMaterial)
+	}
+	for i := 0; i < cfg.Virtual; i++ {
+		roles = append(roles, insolar.StaticRoleVirtual)
+	}
+
+	pulsarPrivateKey, pulsarPublicKey, err := generateKeys("")
+	if err != nil {
+		return errors.Wrap(err, "failed to make pulsar keys")
+	}
+	infos, err := makeNodes(s.dir, roles, pulsarPublicKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to make nodes")
+	}
+	genesisCfg, err := genesisConfig(infos)
+	if err != nil {
+		return errors.Wrap(err, "failed to make genesis config")
+	}
+
+	network := transport.NewFakeNetwork()
+	hosts := make([]string, 0, len(infos))
+	for _, info := range infos {
+		n, err := s.newNode(ctx, network, info, genesisCfg)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create node %s", info.name)
+		}
+		s.nodes = append(s.nodes, n)
+		hosts = append(hosts, info.address)
+	}
+	if err := s.bootstrap(); err != nil {
+		return err
+	}
+
+	s.pulsar, err = newSteppedPulsar(
+		network,
+		fmt.Sprintf("127.0.0.1:%d", firstPort-1),
+		hosts,
+		pulsarPrivateKey,
+		pulsarPublicKey,
+		cfg.Seed,
+		cfg.PulseDelta,
+	)
+	return errors.Wrap(err, "failed to create pulsar")
+}
+
+func (s *Simulator) newNode(
+	ctx context.Context,
+	network *transport.FakeNetwork,
+	info nodeInfo,
+	genesisCfg insolar.GenesisHeavyConfig,
+) (*Node, error) {
+	cfg := nodeConfig(s.dir, info)
+	factory := network.NewFactory(cfg.Host.Transport)
+	pm := &pulseManager{}
+
+	n := &Node{
+		Ref:     info.reference(),
+		Role:    info.role,
+		Address: info.address,
+	}
+	n.ctx, _ = inslogger.WithFields(ctx, map[string]interface{}{
+		"node_ref":  n.Ref.String(),
+		"node_role": n.Role.String(),
+	})
+
+	switch info.role {
+	case insolar.StaticRoleHeavyMaterial:
+		c, err := heavy.NewComponents(n.ctx, cfg, genesisCfg, factory, pm)
+		if err != nil {
+			return nil, err
+		}
+		n.components, pm.target = c, c.PulseManager
+		n.Network, n.Pulses = c.Network, c.Pulses
+		n.Records, n.Indexes, n.JetKeeper = c.Records, c.Indexes, c.JetKeeper
+	case insolar.StaticRoleLightMaterial:
+		c, err := light.NewComponents(n.ctx, cfg, factory, pm)
+		if err != nil {
+			return nil, err
+		}
+		n.components, pm.target = c, c.PulseManager
+		n.Network, n.Pulses = c.Network, c.Pulses
+		n.Records, n.Indexes = c.Records, c.Indexes
+	case insolar.StaticRoleVirtual:
+		c := virtual.NewComponents(n.ctx, cfg, factory, pm)
+		n.components, pm.target = c, c.PulseManager
+		n.Network, n.Pulses = c.Network, c.Pulses
+		n.ContractRequester = c.ContractRequester
+	default:
+		return nil, errors.Errorf("unsupported role %s", info.role)
+	}
+	return n, nil
+}
+
+// bootstrap makes every node consider the others as the working population of a network that has already passed
+// the genesis pulse, so the first pulse starts consensus of a complete network.
+func (s *Simulator) bootstrap() error {
+	origins := make([]insolar.NetworkNode, 0, len(s.nodes))
+	for _, n := range s.nodes {
+		origin := n.Network.NodeKeeper.GetOrigin()
+		// Discovery nodes join with this power, consensus hasn't assigned any yet.
+		origin.(node.MutableNode).SetPower(startPower)
+		origins = append(origins, origin)
+	}
+	for _, n := range s.nodes {
+		n.Network.ConsensusMode = consensus.ReadyNetwork
+		n.Network.NodeKeeper.SetInitialSnapshot(origins)
+		if err := n.Network.PulseAppender.AppendPulse(n.ctx, *insolar.GenesisPulse); err != nil {
+			return errors.Wrapf(err, "failed to append genesis pulse on %s node %s", n.Role, n.Ref)
+		}
+		n.Network.Gatewayer.SwitchState(n.ctx, insolar.CompleteNetworkState, *insolar.GenesisPulse)
+	}
+	return nil
+}
+
+// Start starts all nodes and the pulsar. No pulse is distributed until NextPulse is called.
+func (s *Simulator) Start(ctx context.Context) error {
+	for _, n := range s.nodes {
+		if err := n.components.Start(n.ctx); err != nil {
+			return errors.Wrapf(err, "failed to start %s node %s", n.Role, n.Ref)
+		}
+	}
+	return errors.Wrap(s.pulsar.Start(ctx), "failed to start pulsar")
+}
+
+// Stop stops the pulsar and all nodes and removes the temporary directory.
+func (s *Simulator) Stop(ctx context.Context) error {
+	var result error
+	if err := s.pulsar.Stop(ctx); err != nil {
+		result = errors.Wrap(err, "failed to stop pulsar")
+	}
+	for i := len(s.nodes) - 1; i >= 0; i-- {
+		n := s.nodes[i]
+		if err := n.components.Stop(n.ctx); err != nil && result == nil {
+			result = errors.Wrapf(err, "failed to stop %s node %s", n.Role, n.Ref)
+		}
+	}
+	if s.ownDir {
+		if err := os.RemoveAll(s.dir); err != nil && result == nil {
+			result = errors.Wrap(err, "failed to remove simulator directory")
+		}
+	}
+	return result
+}
+
+// NextPulse distributes the next pulse and waits until every node has accepted it. The returned pulse is the one
+// nodes have agreed on in consensus, it carries consensus entropy rather than the pulsar one.
+func (s *Simulator) NextPulse(ctx context.Context) (insolar.Pulse, error) {
+	p, err := s.pulsar.Next(ctx)
+	if err != nil {
+		return p, errors.Wrap(err, "failed to distribute pulse")
+	}
+
+	deadline := time.After(s.cfg.StepTimeout)
+	for _, n := range s.nodes {
+		for !n.reached(p.PulseNumber) {
+			select {
+			case <-deadline:
+				return p, errors.Errorf("%s node %s didn't reach pulse %d in %s", n.Role, n.Ref, p.PulseNumber, s.cfg.StepTimeout)
+			case <-time.After(pollInterval):
+			}
+		}
+	}
+
+	p, err = s.Heavy().Pulses.ForPulseNumber(ctx, p.PulseNumber)
+	if err != nil {
+		return p, errors.Wrap(err, "failed to fetch accepted pulse")
+	}
+	s.pulse = p
+	return p, nil
+}
+
+// Pulse returns the last pulse every node has reached.
+func (s *Simulator) Pulse() insolar.Pulse {
+	return s.pulse
+}
+
+// Nodes returns nodes with the role in the order of creation.
+func (s *Simulator) Nodes(role insolar.StaticRole) []*Node {
+	var nodes []*Node
+	for _, n := range s.nodes {
+		if n.Role == role {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+// Heavy returns the heavy material node.
+func (s *Simulator) Heavy() *Node {
+	return s.nodes[0]
+}
+
+// pulseManager passes pulses from the network to the node pulse manager except the genesis pulse set by bootstrap.
+// Ledger of a real network never gets the genesis pulse from the network either, the first pulse comes from pulsar.
+type pulseManager struct {
+	target insolar.PulseManager
+}
+
+func (m *pulseManager) Set(ctx context.Context, p insolar.Pulse) error {
+	if p.PulseNumber == insolar.GenesisPulse.PulseNumber {
+		return nil
+	}
+	return m.target.Set(ctx, p)
+}
+
+func (n *Node) reached(pn insolar.PulseNumber) bool {
+	latest, err := n.Pulses.Latest(n.ctx)
+	return err == nil && latest.PulseNumber >= pn
+}
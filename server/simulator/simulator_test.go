@@ -0,0 +1,128 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// +build slowtest
+
+package simulator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/genesisrefs"
+	"github.com/insolar/insolar/insolar/reply"
+	"github.com/insolar/insolar/insolar/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/logicrunner/builtin/foundation"
+)
+
+func startSimulator(t *testing.T) (context.Context, *Simulator) {
+	ctx := inslogger.TestContext(t)
+	sim, err := New(ctx, NewConfig())
+	require.NoError(t, err)
+	require.NoError(t, sim.Start(ctx))
+	return ctx, sim
+}
+
+func call(
+	ctx context.Context, t *testing.T, sim *Simulator, object insolar.Reference, method string, args ...interface{},
+) interface{} {
+	if args == nil {
+		args = []interface{}{}
+	}
+	// Requests with the same trace, pulse and arguments are the same request.
+	ctx, _ = inslogger.WithTraceField(ctx, utils.RandTraceID())
+	virtual := sim.Nodes(insolar.StaticRoleVirtual)[0]
+	res, _, err := virtual.ContractRequester.SendRequest(ctx, &object, method, args, sim.Pulse().PulseNumber)
+	require.NoError(t, err)
+
+	var (
+		result      interface{}
+		contractErr *foundation.Error
+	)
+	err = foundation.UnmarshalMethodResultSimplified(res.(*reply.CallMethod).Result, &result, &contractErr)
+	require.NoError(t, err)
+	require.Nil(t, contractErr)
+	return result
+}
+
+func TestSimulator_Pulses(t *testing.T) {
+	ctx, sim := startSimulator(t)
+	defer func() {
+		require.NoError(t, sim.Stop(ctx))
+	}()
+
+	first, err := sim.NextPulse(ctx)
+	require.NoError(t, err)
+	second, err := sim.NextPulse(ctx)
+	require.NoError(t, err)
+
+	require.Equal(t, insolar.GenesisPulse.PulseNumber+insolar.PulseNumber(NewConfig().PulseDelta), first.PulseNumber)
+	require.Equal(t, first.PulseNumber, second.PrevPulseNumber)
+	require.Equal(t, second, sim.Pulse())
+
+	for _, role := range []insolar.StaticRole{
+		insolar.StaticRoleHeavyMaterial, insolar.StaticRoleLightMaterial, insolar.StaticRoleVirtual,
+	} {
+		nodes := sim.Nodes(role)
+		require.NotEmpty(t, nodes, role.String())
+		for _, n := range nodes {
+			latest, err := n.Pulses.Latest(ctx)
+			require.NoError(t, err)
+			require.Equal(t, second.PulseNumber, latest.PulseNumber, role.String())
+			require.Equal(t, second.Entropy, latest.Entropy, role.String())
+			require.Equal(t, first.PulseNumber, latest.PrevPulseNumber, role.String())
+			require.Len(t, n.Network.GetAccessor(latest.PulseNumber).GetWorkingNodes(), len(sim.nodes), role.String())
+		}
+	}
+}
+
+func TestSimulator_ContractState(t *testing.T) {
+	ctx, sim := startSimulator(t)
+	defer func() {
+		require.NoError(t, sim.Stop(ctx))
+	}()
+	for i := 0; i < 2; i++ {
+		_, err := sim.NextPulse(ctx)
+		require.NoError(t, err)
+	}
+
+	created := call(ctx, t, sim, genesisrefs.ContractRootDomain, "CreateHelloWorld")
+	ref, err := insolar.NewReferenceFromBase58(created.(string))
+	require.NoError(t, err)
+
+	require.Equal(t, "Hello world' world", call(ctx, t, sim, *ref, "Greet", "world"))
+	require.EqualValues(t, 1, call(ctx, t, sim, *ref, "Count"))
+	createdAt := sim.Pulse().PulseNumber
+
+	// Lights replicate a pulse to the heavy once it's finalized, which takes a few pulses.
+	heavy := sim.Heavy()
+	for heavy.JetKeeper.TopSyncPulse() < createdAt {
+		_, err := sim.NextPulse(ctx)
+		require.NoError(t, err)
+		require.True(t, sim.Pulse().PulseNumber < createdAt+10*insolar.PulseNumber(NewConfig().PulseDelta),
+			"object isn't replicated to heavy")
+	}
+
+	idx, err := heavy.Indexes.ForID(ctx, heavy.JetKeeper.TopSyncPulse(), *ref.GetLocal())
+	require.NoError(t, err)
+	require.NotNil(t, idx.Lifeline.LatestState)
+	_, err = heavy.Records.ForID(ctx, *idx.Lifeline.LatestState)
+	require.NoError(t, err)
+}
@@ -51,6 +51,12 @@ type CertificateMock struct {
 	beforeGetPublicKeyCounter uint64
 	GetPublicKeyMock          mCertificateMockGetPublicKey
 
+	funcGetPulsarPublicKeys          func() (pa1 []crypto.PublicKey)
+	inspectFuncGetPulsarPublicKeys   func()
+	afterGetPulsarPublicKeysCounter  uint64
+	beforeGetPulsarPublicKeysCounter uint64
+	GetPulsarPublicKeysMock          mCertificateMockGetPulsarPublicKeys
+
 	funcGetRole          func() (s1 mm_insolar.StaticRole)
 	inspectFuncGetRole   func()
 	afterGetRoleCounter  uint64
@@ -89,6 +95,8 @@ func NewCertificateMock(t minimock.Tester) *CertificateMock {
 
 	m.GetPublicKeyMock = mCertificateMockGetPublicKey{mock: m}
 
+	m.GetPulsarPublicKeysMock = mCertificateMockGetPulsarPublicKeys{mock: m}
+
 	m.GetRoleMock = mCertificateMockGetRole{mock: m}
 
 	m.GetRootDomainReferenceMock = mCertificateMockGetRootDomainReference{mock: m}
@@ -958,6 +966,149 @@ func (m *CertificateMock) MinimockGetPublicKeyInspect() {
 	}
 }
 
+type mCertificateMockGetPulsarPublicKeys struct {
+	mock               *CertificateMock
+	defaultExpectation *CertificateMockGetPulsarPublicKeysExpectation
+	expectations       []*CertificateMockGetPulsarPublicKeysExpectation
+}
+
+// CertificateMockGetPulsarPublicKeysExpectation specifies expectation struct of the Certificate.GetPulsarPublicKeys
+type CertificateMockGetPulsarPublicKeysExpectation struct {
+	mock *CertificateMock
+
+	results *CertificateMockGetPulsarPublicKeysResults
+	Counter uint64
+}
+
+// CertificateMockGetPulsarPublicKeysResults contains results of the Certificate.GetPulsarPublicKeys
+type CertificateMockGetPulsarPublicKeysResults struct {
+	pa1 []crypto.PublicKey
+}
+
+// Expect sets up expected params for Certificate.GetPulsarPublicKeys
+func (mmGetPulsarPublicKeys *mCertificateMockGetPulsarPublicKeys) Expect() *mCertificateMockGetPulsarPublicKeys {
+	if mmGetPulsarPublicKeys.mock.funcGetPulsarPublicKeys != nil {
+		mmGetPulsarPublicKeys.mock.t.Fatalf("CertificateMock.GetPulsarPublicKeys mock is already set by Set")
+	}
+
+	if mmGetPulsarPublicKeys.defaultExpectation == nil {
+		mmGetPulsarPublicKeys.defaultExpectation = &CertificateMockGetPulsarPublicKeysExpectation{}
+	}
+
+	return mmGetPulsarPublicKeys
+}
+
+// Inspect accepts an inspector function that has same arguments as the Certificate.GetPulsarPublicKeys
+func (mmGetPulsarPublicKeys *mCertificateMockGetPulsarPublicKeys) Inspect(f func()) *mCertificateMockGetPulsarPublicKeys {
+	if mmGetPulsarPublicKeys.mock.inspectFuncGetPulsarPublicKeys != nil {
+		mmGetPulsarPublicKeys.mock.t.Fatalf("Inspect function is already set for CertificateMock.GetPulsarPublicKeys")
+	}
+
+	mmGetPulsarPublicKeys.mock.inspectFuncGetPulsarPublicKeys = f
+
+	return mmGetPulsarPublicKeys
+}
+
+// Return sets up results that will be returned by Certificate.GetPulsarPublicKeys
+func (mmGetPulsarPublicKeys *mCertificateMockGetPulsarPublicKeys) Return(pa1 []crypto.PublicKey) *CertificateMock {
+	if mmGetPulsarPublicKeys.mock.funcGetPulsarPublicKeys != nil {
+		mmGetPulsarPublicKeys.mock.t.Fatalf("CertificateMock.GetPulsarPublicKeys mock is already set by Set")
+	}
+
+	if mmGetPulsarPublicKeys.defaultExpectation == nil {
+		mmGetPulsarPublicKeys.defaultExpectation = &CertificateMockGetPulsarPublicKeysExpectation{mock: mmGetPulsarPublicKeys.mock}
+	}
+	mmGetPulsarPublicKeys.defaultExpectation.results = &CertificateMockGetPulsarPublicKeysResults{pa1}
+	return mmGetPulsarPublicKeys.mock
+}
+
+//Set uses given function f to mock the Certificate.GetPulsarPublicKeys method
+func (mmGetPulsarPublicKeys *mCertificateMockGetPulsarPublicKeys) Set(f func() (pa1 []crypto.PublicKey)) *CertificateMock {
+	if mmGetPulsarPublicKeys.defaultExpectation != nil {
+		mmGetPulsarPublicKeys.mock.t.Fatalf("Default expectation is already set for the Certificate.GetPulsarPublicKeys method")
+	}
+
+	if len(mmGetPulsarPublicKeys.expectations) > 0 {
+		mmGetPulsarPublicKeys.mock.t.Fatalf("Some expectations are already set for the Certificate.GetPulsarPublicKeys method")
+	}
+
+	mmGetPulsarPublicKeys.mock.funcGetPulsarPublicKeys = f
+	return mmGetPulsarPublicKeys.mock
+}
+
+// GetPulsarPublicKeys implements insolar.Certificate
+func (mmGetPulsarPublicKeys *CertificateMock) GetPulsarPublicKeys() (pa1 []crypto.PublicKey) {
+	mm_atomic.AddUint64(&mmGetPulsarPublicKeys.beforeGetPulsarPublicKeysCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetPulsarPublicKeys.afterGetPulsarPublicKeysCounter, 1)
+
+	if mmGetPulsarPublicKeys.inspectFuncGetPulsarPublicKeys != nil {
+		mmGetPulsarPublicKeys.inspectFuncGetPulsarPublicKeys()
+	}
+
+	if mmGetPulsarPublicKeys.GetPulsarPublicKeysMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetPulsarPublicKeys.GetPulsarPublicKeysMock.defaultExpectation.Counter, 1)
+
+		results := mmGetPulsarPublicKeys.GetPulsarPublicKeysMock.defaultExpectation.results
+		if results == nil {
+			mmGetPulsarPublicKeys.t.Fatal("No results are set for the CertificateMock.GetPulsarPublicKeys")
+		}
+		return (*results).pa1
+	}
+	if mmGetPulsarPublicKeys.funcGetPulsarPublicKeys != nil {
+		return mmGetPulsarPublicKeys.funcGetPulsarPublicKeys()
+	}
+	mmGetPulsarPublicKeys.t.Fatalf("Unexpected call to CertificateMock.GetPulsarPublicKeys.")
+	return
+}
+
+// GetPulsarPublicKeysAfterCounter returns a count of finished CertificateMock.GetPulsarPublicKeys invocations
+func (mmGetPulsarPublicKeys *CertificateMock) GetPulsarPublicKeysAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetPulsarPublicKeys.afterGetPulsarPublicKeysCounter)
+}
+
+// GetPulsarPublicKeysBeforeCounter returns a count of CertificateMock.GetPulsarPublicKeys invocations
+func (mmGetPulsarPublicKeys *CertificateMock) GetPulsarPublicKeysBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetPulsarPublicKeys.beforeGetPulsarPublicKeysCounter)
+}
+
+// MinimockGetPulsarPublicKeysDone returns true if the count of the GetPulsarPublicKeys invocations corresponds
+// the number of defined expectations
+func (m *CertificateMock) MinimockGetPulsarPublicKeysDone() bool {
+	for _, e := range m.GetPulsarPublicKeysMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetPulsarPublicKeysMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetPulsarPublicKeysCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetPulsarPublicKeys != nil && mm_atomic.LoadUint64(&m.afterGetPulsarPublicKeysCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockGetPulsarPublicKeysInspect logs each unmet expectation
+func (m *CertificateMock) MinimockGetPulsarPublicKeysInspect() {
+	for _, e := range m.GetPulsarPublicKeysMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Error("Expected call to CertificateMock.GetPulsarPublicKeys")
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetPulsarPublicKeysMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterGetPulsarPublicKeysCounter) < 1 {
+		m.t.Error("Expected call to CertificateMock.GetPulsarPublicKeys")
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetPulsarPublicKeys != nil && mm_atomic.LoadUint64(&m.afterGetPulsarPublicKeysCounter) < 1 {
+		m.t.Error("Expected call to CertificateMock.GetPulsarPublicKeys")
+	}
+}
+
 type mCertificateMockGetRole struct {
 	mock               *CertificateMock
 	defaultExpectation *CertificateMockGetRoleExpectation
@@ -1402,6 +1553,8 @@ func (m *CertificateMock) MinimockFinish() {
 
 		m.MinimockGetPublicKeyInspect()
 
+		m.MinimockGetPulsarPublicKeysInspect()
+
 		m.MinimockGetRoleInspect()
 
 		m.MinimockGetRootDomainReferenceInspect()
@@ -1436,6 +1589,7 @@ func (m *CertificateMock) minimockDone() bool {
 		m.MinimockGetMinRolesDone() &&
 		m.MinimockGetNodeRefDone() &&
 		m.MinimockGetPublicKeyDone() &&
+		m.MinimockGetPulsarPublicKeysDone() &&
 		m.MinimockGetRoleDone() &&
 		m.MinimockGetRootDomainReferenceDone() &&
 		m.MinimockSerializeNodePartDone()
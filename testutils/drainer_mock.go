@@ -0,0 +1,282 @@
+package testutils
+
+// Code generated by http://github.com/gojuno/minimock (dev). DO NOT EDIT.
+
+import (
+	"context"
+	"sync"
+	mm_atomic "sync/atomic"
+	mm_time "time"
+
+	"github.com/gojuno/minimock"
+	mm_insolar "github.com/insolar/insolar/insolar"
+)
+
+// DrainerMock implements insolar.Drainer
+type DrainerMock struct {
+	t minimock.Tester
+
+	funcDrainProgress          func(ctx context.Context) (d1 mm_insolar.DrainProgress)
+	inspectFuncDrainProgress   func(ctx context.Context)
+	afterDrainProgressCounter  uint64
+	beforeDrainProgressCounter uint64
+	DrainProgressMock          mDrainerMockDrainProgress
+}
+
+// NewDrainerMock returns a mock for insolar.Drainer
+func NewDrainerMock(t minimock.Tester) *DrainerMock {
+	m := &DrainerMock{t: t}
+	if controller, ok := t.(minimock.MockController); ok {
+		controller.RegisterMocker(m)
+	}
+
+	m.DrainProgressMock = mDrainerMockDrainProgress{mock: m}
+	m.DrainProgressMock.callArgs = []*DrainerMockDrainProgressParams{}
+
+	return m
+}
+
+type mDrainerMockDrainProgress struct {
+	mock               *DrainerMock
+	defaultExpectation *DrainerMockDrainProgressExpectation
+	expectations       []*DrainerMockDrainProgressExpectation
+
+	callArgs []*DrainerMockDrainProgressParams
+	mutex    sync.RWMutex
+}
+
+// DrainerMockDrainProgressExpectation specifies expectation struct of the Drainer.DrainProgress
+type DrainerMockDrainProgressExpectation struct {
+	mock    *DrainerMock
+	params  *DrainerMockDrainProgressParams
+	results *DrainerMockDrainProgressResults
+	Counter uint64
+}
+
+// DrainerMockDrainProgressParams contains parameters of the Drainer.DrainProgress
+type DrainerMockDrainProgressParams struct {
+	ctx context.Context
+}
+
+// DrainerMockDrainProgressResults contains results of the Drainer.DrainProgress
+type DrainerMockDrainProgressResults struct {
+	d1 mm_insolar.DrainProgress
+}
+
+// Expect sets up expected params for Drainer.DrainProgress
+func (mmDrainProgress *mDrainerMockDrainProgress) Expect(ctx context.Context) *mDrainerMockDrainProgress {
+	if mmDrainProgress.mock.funcDrainProgress != nil {
+		mmDrainProgress.mock.t.Fatalf("DrainerMock.DrainProgress mock is already set by Set")
+	}
+
+	if mmDrainProgress.defaultExpectation == nil {
+		mmDrainProgress.defaultExpectation = &DrainerMockDrainProgressExpectation{}
+	}
+
+	mmDrainProgress.defaultExpectation.params = &DrainerMockDrainProgressParams{ctx}
+	for _, e := range mmDrainProgress.expectations {
+		if minimock.Equal(e.params, mmDrainProgress.defaultExpectation.params) {
+			mmDrainProgress.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmDrainProgress.defaultExpectation.params)
+		}
+	}
+
+	return mmDrainProgress
+}
+
+// Inspect accepts an inspector function that has same arguments as the Drainer.DrainProgress
+func (mmDrainProgress *mDrainerMockDrainProgress) Inspect(f func(ctx context.Context)) *mDrainerMockDrainProgress {
+	if mmDrainProgress.mock.inspectFuncDrainProgress != nil {
+		mmDrainProgress.mock.t.Fatalf("Inspect function is already set for DrainerMock.DrainProgress")
+	}
+
+	mmDrainProgress.mock.inspectFuncDrainProgress = f
+
+	return mmDrainProgress
+}
+
+// Return sets up results that will be returned by Drainer.DrainProgress
+func (mmDrainProgress *mDrainerMockDrainProgress) Return(d1 mm_insolar.DrainProgress) *DrainerMock {
+	if mmDrainProgress.mock.funcDrainProgress != nil {
+		mmDrainProgress.mock.t.Fatalf("DrainerMock.DrainProgress mock is already set by Set")
+	}
+
+	if mmDrainProgress.defaultExpectation == nil {
+		mmDrainProgress.defaultExpectation = &DrainerMockDrainProgressExpectation{mock: mmDrainProgress.mock}
+	}
+	mmDrainProgress.defaultExpectation.results = &DrainerMockDrainProgressResults{d1}
+	return mmDrainProgress.mock
+}
+
+//Set uses given function f to mock the Drainer.DrainProgress method
+func (mmDrainProgress *mDrainerMockDrainProgress) Set(f func(ctx context.Context) (d1 mm_insolar.DrainProgress)) *DrainerMock {
+	if mmDrainProgress.defaultExpectation != nil {
+		mmDrainProgress.mock.t.Fatalf("Default expectation is already set for the Drainer.DrainProgress method")
+	}
+
+	if len(mmDrainProgress.expectations) > 0 {
+		mmDrainProgress.mock.t.Fatalf("Some expectations are already set for the Drainer.DrainProgress method")
+	}
+
+	mmDrainProgress.mock.funcDrainProgress = f
+	return mmDrainProgress.mock
+}
+
+// When sets expectation for the Drainer.DrainProgress which will trigger the result defined by the following
+// Then helper
+func (mmDrainProgress *mDrainerMockDrainProgress) When(ctx context.Context) *DrainerMockDrainProgressExpectation {
+	if mmDrainProgress.mock.funcDrainProgress != nil {
+		mmDrainProgress.mock.t.Fatalf("DrainerMock.DrainProgress mock is already set by Set")
+	}
+
+	expectation := &DrainerMockDrainProgressExpectation{
+		mock:   mmDrainProgress.mock,
+		params: &DrainerMockDrainProgressParams{ctx},
+	}
+	mmDrainProgress.expectations = append(mmDrainProgress.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Drainer.DrainProgress return parameters for the expectation previously defined by the When method
+func (e *DrainerMockDrainProgressExpectation) Then(d1 mm_insolar.DrainProgress) *DrainerMock {
+	e.results = &DrainerMockDrainProgressResults{d1}
+	return e.mock
+}
+
+// DrainProgress implements insolar.Drainer
+func (mmDrainProgress *DrainerMock) DrainProgress(ctx context.Context) (d1 mm_insolar.DrainProgress) {
+	mm_atomic.AddUint64(&mmDrainProgress.beforeDrainProgressCounter, 1)
+	defer mm_atomic.AddUint64(&mmDrainProgress.afterDrainProgressCounter, 1)
+
+	if mmDrainProgress.inspectFuncDrainProgress != nil {
+		mmDrainProgress.inspectFuncDrainProgress(ctx)
+	}
+
+	params := &DrainerMockDrainProgressParams{ctx}
+
+	// Record call args
+	mmDrainProgress.DrainProgressMock.mutex.Lock()
+	mmDrainProgress.DrainProgressMock.callArgs = append(mmDrainProgress.DrainProgressMock.callArgs, params)
+	mmDrainProgress.DrainProgressMock.mutex.Unlock()
+
+	for _, e := range mmDrainProgress.DrainProgressMock.expectations {
+		if minimock.Equal(e.params, params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.d1
+		}
+	}
+
+	if mmDrainProgress.DrainProgressMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmDrainProgress.DrainProgressMock.defaultExpectation.Counter, 1)
+		want := mmDrainProgress.DrainProgressMock.defaultExpectation.params
+		got := DrainerMockDrainProgressParams{ctx}
+		if want != nil && !minimock.Equal(*want, got) {
+			mmDrainProgress.t.Errorf("DrainerMock.DrainProgress got unexpected parameters, want: %#v, got: %#v%s\n", *want, got, minimock.Diff(*want, got))
+		}
+
+		results := mmDrainProgress.DrainProgressMock.defaultExpectation.results
+		if results == nil {
+			mmDrainProgress.t.Fatal("No results are set for the DrainerMock.DrainProgress")
+		}
+		return (*results).d1
+	}
+	if mmDrainProgress.funcDrainProgress != nil {
+		return mmDrainProgress.funcDrainProgress(ctx)
+	}
+	mmDrainProgress.t.Fatalf("Unexpected call to DrainerMock.DrainProgress. %v", ctx)
+	return
+}
+
+// DrainProgressAfterCounter returns a count of finished DrainerMock.DrainProgress invocations
+func (mmDrainProgress *DrainerMock) DrainProgressAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDrainProgress.afterDrainProgressCounter)
+}
+
+// DrainProgressBeforeCounter returns a count of DrainerMock.DrainProgress invocations
+func (mmDrainProgress *DrainerMock) DrainProgressBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDrainProgress.beforeDrainProgressCounter)
+}
+
+// Calls returns a list of arguments used in each call to DrainerMock.DrainProgress.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmDrainProgress *mDrainerMockDrainProgress) Calls() []*DrainerMockDrainProgressParams {
+	mmDrainProgress.mutex.RLock()
+
+	argCopy := make([]*DrainerMockDrainProgressParams, len(mmDrainProgress.callArgs))
+	copy(argCopy, mmDrainProgress.callArgs)
+
+	mmDrainProgress.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockDrainProgressDone returns true if the count of the DrainProgress invocations corresponds
+// the number of defined expectations
+func (m *DrainerMock) MinimockDrainProgressDone() bool {
+	for _, e := range m.DrainProgressMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.DrainProgressMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterDrainProgressCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcDrainProgress != nil && mm_atomic.LoadUint64(&m.afterDrainProgressCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockDrainProgressInspect logs each unmet expectation
+func (m *DrainerMock) MinimockDrainProgressInspect() {
+	for _, e := range m.DrainProgressMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to DrainerMock.DrainProgress with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.DrainProgressMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterDrainProgressCounter) < 1 {
+		if m.DrainProgressMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to DrainerMock.DrainProgress")
+		} else {
+			m.t.Errorf("Expected call to DrainerMock.DrainProgress with params: %#v", *m.DrainProgressMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcDrainProgress != nil && mm_atomic.LoadUint64(&m.afterDrainProgressCounter) < 1 {
+		m.t.Error("Expected call to DrainerMock.DrainProgress")
+	}
+}
+
+// MinimockFinish checks that all mocked methods have been called the expected number of times
+func (m *DrainerMock) MinimockFinish() {
+	if !m.minimockDone() {
+		m.MinimockDrainProgressInspect()
+		m.t.FailNow()
+	}
+}
+
+// MinimockWait waits for all mocked methods to be called the expected number of times
+func (m *DrainerMock) MinimockWait(timeout mm_time.Duration) {
+	timeoutCh := mm_time.After(timeout)
+	for {
+		if m.minimockDone() {
+			return
+		}
+		select {
+		case <-timeoutCh:
+			m.MinimockFinish()
+			return
+		case <-mm_time.After(10 * mm_time.Millisecond):
+		}
+	}
+}
+
+func (m *DrainerMock) minimockDone() bool {
+	done := true
+	return done &&
+		m.MinimockDrainProgressDone()
+}
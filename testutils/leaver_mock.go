@@ -21,6 +21,13 @@ type LeaverMock struct {
 	afterLeaveCounter  uint64
 	beforeLeaveCounter uint64
 	LeaveMock          mLeaverMockLeave
+
+	funcPrepareLeave func(ctx context.Context) (ch1 <-chan struct {
+	})
+	inspectFuncPrepareLeave   func(ctx context.Context)
+	afterPrepareLeaveCounter  uint64
+	beforePrepareLeaveCounter uint64
+	PrepareLeaveMock          mLeaverMockPrepareLeave
 }
 
 // NewLeaverMock returns a mock for insolar.Leaver
@@ -33,6 +40,9 @@ func NewLeaverMock(t minimock.Tester) *LeaverMock {
 	m.LeaveMock = mLeaverMockLeave{mock: m}
 	m.LeaveMock.callArgs = []*LeaverMockLeaveParams{}
 
+	m.PrepareLeaveMock = mLeaverMockPrepareLeave{mock: m}
+	m.PrepareLeaveMock.callArgs = []*LeaverMockPrepareLeaveParams{}
+
 	return m
 }
 
@@ -224,10 +234,232 @@ func (m *LeaverMock) MinimockLeaveInspect() {
 	}
 }
 
+type mLeaverMockPrepareLeave struct {
+	mock               *LeaverMock
+	defaultExpectation *LeaverMockPrepareLeaveExpectation
+	expectations       []*LeaverMockPrepareLeaveExpectation
+
+	callArgs []*LeaverMockPrepareLeaveParams
+	mutex    sync.RWMutex
+}
+
+// LeaverMockPrepareLeaveExpectation specifies expectation struct of the Leaver.PrepareLeave
+type LeaverMockPrepareLeaveExpectation struct {
+	mock    *LeaverMock
+	params  *LeaverMockPrepareLeaveParams
+	results *LeaverMockPrepareLeaveResults
+	Counter uint64
+}
+
+// LeaverMockPrepareLeaveParams contains parameters of the Leaver.PrepareLeave
+type LeaverMockPrepareLeaveParams struct {
+	ctx context.Context
+}
+
+// LeaverMockPrepareLeaveResults contains results of the Leaver.PrepareLeave
+type LeaverMockPrepareLeaveResults struct {
+	ch1 <-chan struct {
+	}
+}
+
+// Expect sets up expected params for Leaver.PrepareLeave
+func (mmPrepareLeave *mLeaverMockPrepareLeave) Expect(ctx context.Context) *mLeaverMockPrepareLeave {
+	if mmPrepareLeave.mock.funcPrepareLeave != nil {
+		mmPrepareLeave.mock.t.Fatalf("LeaverMock.PrepareLeave mock is already set by Set")
+	}
+
+	if mmPrepareLeave.defaultExpectation == nil {
+		mmPrepareLeave.defaultExpectation = &LeaverMockPrepareLeaveExpectation{}
+	}
+
+	mmPrepareLeave.defaultExpectation.params = &LeaverMockPrepareLeaveParams{ctx}
+	for _, e := range mmPrepareLeave.expectations {
+		if minimock.Equal(e.params, mmPrepareLeave.defaultExpectation.params) {
+			mmPrepareLeave.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmPrepareLeave.defaultExpectation.params)
+		}
+	}
+
+	return mmPrepareLeave
+}
+
+// Inspect accepts an inspector function that has same arguments as the Leaver.PrepareLeave
+func (mmPrepareLeave *mLeaverMockPrepareLeave) Inspect(f func(ctx context.Context)) *mLeaverMockPrepareLeave {
+	if mmPrepareLeave.mock.inspectFuncPrepareLeave != nil {
+		mmPrepareLeave.mock.t.Fatalf("Inspect function is already set for LeaverMock.PrepareLeave")
+	}
+
+	mmPrepareLeave.mock.inspectFuncPrepareLeave = f
+
+	return mmPrepareLeave
+}
+
+// Return sets up results that will be returned by Leaver.PrepareLeave
+func (mmPrepareLeave *mLeaverMockPrepareLeave) Return(ch1 <-chan struct {
+}) *LeaverMock {
+	if mmPrepareLeave.mock.funcPrepareLeave != nil {
+		mmPrepareLeave.mock.t.Fatalf("LeaverMock.PrepareLeave mock is already set by Set")
+	}
+
+	if mmPrepareLeave.defaultExpectation == nil {
+		mmPrepareLeave.defaultExpectation = &LeaverMockPrepareLeaveExpectation{mock: mmPrepareLeave.mock}
+	}
+	mmPrepareLeave.defaultExpectation.results = &LeaverMockPrepareLeaveResults{ch1}
+	return mmPrepareLeave.mock
+}
+
+//Set uses given function f to mock the Leaver.PrepareLeave method
+func (mmPrepareLeave *mLeaverMockPrepareLeave) Set(f func(ctx context.Context) (ch1 <-chan struct {
+})) *LeaverMock {
+	if mmPrepareLeave.defaultExpectation != nil {
+		mmPrepareLeave.mock.t.Fatalf("Default expectation is already set for the Leaver.PrepareLeave method")
+	}
+
+	if len(mmPrepareLeave.expectations) > 0 {
+		mmPrepareLeave.mock.t.Fatalf("Some expectations are already set for the Leaver.PrepareLeave method")
+	}
+
+	mmPrepareLeave.mock.funcPrepareLeave = f
+	return mmPrepareLeave.mock
+}
+
+// When sets expectation for the Leaver.PrepareLeave which will trigger the result defined by the following
+// Then helper
+func (mmPrepareLeave *mLeaverMockPrepareLeave) When(ctx context.Context) *LeaverMockPrepareLeaveExpectation {
+	if mmPrepareLeave.mock.funcPrepareLeave != nil {
+		mmPrepareLeave.mock.t.Fatalf("LeaverMock.PrepareLeave mock is already set by Set")
+	}
+
+	expectation := &LeaverMockPrepareLeaveExpectation{
+		mock:   mmPrepareLeave.mock,
+		params: &LeaverMockPrepareLeaveParams{ctx},
+	}
+	mmPrepareLeave.expectations = append(mmPrepareLeave.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Leaver.PrepareLeave return parameters for the expectation previously defined by the When method
+func (e *LeaverMockPrepareLeaveExpectation) Then(ch1 <-chan struct {
+}) *LeaverMock {
+	e.results = &LeaverMockPrepareLeaveResults{ch1}
+	return e.mock
+}
+
+// PrepareLeave implements insolar.Leaver
+func (mmPrepareLeave *LeaverMock) PrepareLeave(ctx context.Context) (ch1 <-chan struct {
+}) {
+	mm_atomic.AddUint64(&mmPrepareLeave.beforePrepareLeaveCounter, 1)
+	defer mm_atomic.AddUint64(&mmPrepareLeave.afterPrepareLeaveCounter, 1)
+
+	if mmPrepareLeave.inspectFuncPrepareLeave != nil {
+		mmPrepareLeave.inspectFuncPrepareLeave(ctx)
+	}
+
+	params := &LeaverMockPrepareLeaveParams{ctx}
+
+	// Record call args
+	mmPrepareLeave.PrepareLeaveMock.mutex.Lock()
+	mmPrepareLeave.PrepareLeaveMock.callArgs = append(mmPrepareLeave.PrepareLeaveMock.callArgs, params)
+	mmPrepareLeave.PrepareLeaveMock.mutex.Unlock()
+
+	for _, e := range mmPrepareLeave.PrepareLeaveMock.expectations {
+		if minimock.Equal(e.params, params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.ch1
+		}
+	}
+
+	if mmPrepareLeave.PrepareLeaveMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmPrepareLeave.PrepareLeaveMock.defaultExpectation.Counter, 1)
+		want := mmPrepareLeave.PrepareLeaveMock.defaultExpectation.params
+		got := LeaverMockPrepareLeaveParams{ctx}
+		if want != nil && !minimock.Equal(*want, got) {
+			mmPrepareLeave.t.Errorf("LeaverMock.PrepareLeave got unexpected parameters, want: %#v, got: %#v%s\n", *want, got, minimock.Diff(*want, got))
+		}
+
+		results := mmPrepareLeave.PrepareLeaveMock.defaultExpectation.results
+		if results == nil {
+			mmPrepareLeave.t.Fatal("No results are set for the LeaverMock.PrepareLeave")
+		}
+		return (*results).ch1
+	}
+	if mmPrepareLeave.funcPrepareLeave != nil {
+		return mmPrepareLeave.funcPrepareLeave(ctx)
+	}
+	mmPrepareLeave.t.Fatalf("Unexpected call to LeaverMock.PrepareLeave. %v", ctx)
+	return
+}
+
+// PrepareLeaveAfterCounter returns a count of finished LeaverMock.PrepareLeave invocations
+func (mmPrepareLeave *LeaverMock) PrepareLeaveAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmPrepareLeave.afterPrepareLeaveCounter)
+}
+
+// PrepareLeaveBeforeCounter returns a count of LeaverMock.PrepareLeave invocations
+func (mmPrepareLeave *LeaverMock) PrepareLeaveBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmPrepareLeave.beforePrepareLeaveCounter)
+}
+
+// Calls returns a list of arguments used in each call to LeaverMock.PrepareLeave.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmPrepareLeave *mLeaverMockPrepareLeave) Calls() []*LeaverMockPrepareLeaveParams {
+	mmPrepareLeave.mutex.RLock()
+
+	argCopy := make([]*LeaverMockPrepareLeaveParams, len(mmPrepareLeave.callArgs))
+	copy(argCopy, mmPrepareLeave.callArgs)
+
+	mmPrepareLeave.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockPrepareLeaveDone returns true if the count of the PrepareLeave invocations corresponds
+// the number of defined expectations
+func (m *LeaverMock) MinimockPrepareLeaveDone() bool {
+	for _, e := range m.PrepareLeaveMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.PrepareLeaveMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterPrepareLeaveCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcPrepareLeave != nil && mm_atomic.LoadUint64(&m.afterPrepareLeaveCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockPrepareLeaveInspect logs each unmet expectation
+func (m *LeaverMock) MinimockPrepareLeaveInspect() {
+	for _, e := range m.PrepareLeaveMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to LeaverMock.PrepareLeave with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.PrepareLeaveMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterPrepareLeaveCounter) < 1 {
+		if m.PrepareLeaveMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to LeaverMock.PrepareLeave")
+		} else {
+			m.t.Errorf("Expected call to LeaverMock.PrepareLeave with params: %#v", *m.PrepareLeaveMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcPrepareLeave != nil && mm_atomic.LoadUint64(&m.afterPrepareLeaveCounter) < 1 {
+		m.t.Error("Expected call to LeaverMock.PrepareLeave")
+	}
+}
+
 // MinimockFinish checks that all mocked methods have been called the expected number of times
 func (m *LeaverMock) MinimockFinish() {
 	if !m.minimockDone() {
 		m.MinimockLeaveInspect()
+
+		m.MinimockPrepareLeaveInspect()
 		m.t.FailNow()
 	}
 }
@@ -251,5 +483,6 @@ func (m *LeaverMock) MinimockWait(timeout mm_time.Duration) {
 func (m *LeaverMock) minimockDone() bool {
 	done := true
 	return done &&
-		m.MinimockLeaveDone()
+		m.MinimockLeaveDone() &&
+		m.MinimockPrepareLeaveDone()
 }
@@ -22,6 +22,18 @@ type TerminationHandlerMock struct {
 	beforeAbortCounter uint64
 	AbortMock          mTerminationHandlerMockAbort
 
+	funcDecommission          func(ctx context.Context) (err error)
+	inspectFuncDecommission   func(ctx context.Context)
+	afterDecommissionCounter  uint64
+	beforeDecommissionCounter uint64
+	DecommissionMock          mTerminationHandlerMockDecommission
+
+	funcDecommissionStatus          func() (d1 mm_insolar.DecommissionStatus)
+	inspectFuncDecommissionStatus   func()
+	afterDecommissionStatusCounter  uint64
+	beforeDecommissionStatusCounter uint64
+	DecommissionStatusMock          mTerminationHandlerMockDecommissionStatus
+
 	funcLeave          func(ctx context.Context, p1 mm_insolar.PulseNumber)
 	inspectFuncLeave   func(ctx context.Context, p1 mm_insolar.PulseNumber)
 	afterLeaveCounter  uint64
@@ -51,6 +63,11 @@ func NewTerminationHandlerMock(t minimock.Tester) *TerminationHandlerMock {
 	m.AbortMock = mTerminationHandlerMockAbort{mock: m}
 	m.AbortMock.callArgs = []*TerminationHandlerMockAbortParams{}
 
+	m.DecommissionMock = mTerminationHandlerMockDecommission{mock: m}
+	m.DecommissionMock.callArgs = []*TerminationHandlerMockDecommissionParams{}
+
+	m.DecommissionStatusMock = mTerminationHandlerMockDecommissionStatus{mock: m}
+
 	m.LeaveMock = mTerminationHandlerMockLeave{mock: m}
 	m.LeaveMock.callArgs = []*TerminationHandlerMockLeaveParams{}
 
@@ -249,6 +266,364 @@ func (m *TerminationHandlerMock) MinimockAbortInspect() {
 	}
 }
 
+type mTerminationHandlerMockDecommission struct {
+	mock               *TerminationHandlerMock
+	defaultExpectation *TerminationHandlerMockDecommissionExpectation
+	expectations       []*TerminationHandlerMockDecommissionExpectation
+
+	callArgs []*TerminationHandlerMockDecommissionParams
+	mutex    sync.RWMutex
+}
+
+// TerminationHandlerMockDecommissionExpectation specifies expectation struct of the TerminationHandler.Decommission
+type TerminationHandlerMockDecommissionExpectation struct {
+	mock    *TerminationHandlerMock
+	params  *TerminationHandlerMockDecommissionParams
+	results *TerminationHandlerMockDecommissionResults
+	Counter uint64
+}
+
+// TerminationHandlerMockDecommissionParams contains parameters of the TerminationHandler.Decommission
+type TerminationHandlerMockDecommissionParams struct {
+	ctx context.Context
+}
+
+// TerminationHandlerMockDecommissionResults contains results of the TerminationHandler.Decommission
+type TerminationHandlerMockDecommissionResults struct {
+	err error
+}
+
+// Expect sets up expected params for TerminationHandler.Decommission
+func (mmDecommission *mTerminationHandlerMockDecommission) Expect(ctx context.Context) *mTerminationHandlerMockDecommission {
+	if mmDecommission.mock.funcDecommission != nil {
+		mmDecommission.mock.t.Fatalf("TerminationHandlerMock.Decommission mock is already set by Set")
+	}
+
+	if mmDecommission.defaultExpectation == nil {
+		mmDecommission.defaultExpectation = &TerminationHandlerMockDecommissionExpectation{}
+	}
+
+	mmDecommission.defaultExpectation.params = &TerminationHandlerMockDecommissionParams{ctx}
+	for _, e := range mmDecommission.expectations {
+		if minimock.Equal(e.params, mmDecommission.defaultExpectation.params) {
+			mmDecommission.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmDecommission.defaultExpectation.params)
+		}
+	}
+
+	return mmDecommission
+}
+
+// Inspect accepts an inspector function that has same arguments as the TerminationHandler.Decommission
+func (mmDecommission *mTerminationHandlerMockDecommission) Inspect(f func(ctx context.Context)) *mTerminationHandlerMockDecommission {
+	if mmDecommission.mock.inspectFuncDecommission != nil {
+		mmDecommission.mock.t.Fatalf("Inspect function is already set for TerminationHandlerMock.Decommission")
+	}
+
+	mmDecommission.mock.inspectFuncDecommission = f
+
+	return mmDecommission
+}
+
+// Return sets up results that will be returned by TerminationHandler.Decommission
+func (mmDecommission *mTerminationHandlerMockDecommission) Return(err error) *TerminationHandlerMock {
+	if mmDecommission.mock.funcDecommission != nil {
+		mmDecommission.mock.t.Fatalf("TerminationHandlerMock.Decommission mock is already set by Set")
+	}
+
+	if mmDecommission.defaultExpectation == nil {
+		mmDecommission.defaultExpectation = &TerminationHandlerMockDecommissionExpectation{mock: mmDecommission.mock}
+	}
+	mmDecommission.defaultExpectation.results = &TerminationHandlerMockDecommissionResults{err}
+	return mmDecommission.mock
+}
+
+//Set uses given function f to mock the TerminationHandler.Decommission method
+func (mmDecommission *mTerminationHandlerMockDecommission) Set(f func(ctx context.Context) (err error)) *TerminationHandlerMock {
+	if mmDecommission.defaultExpectation != nil {
+		mmDecommission.mock.t.Fatalf("Default expectation is already set for the TerminationHandler.Decommission method")
+	}
+
+	if len(mmDecommission.expectations) > 0 {
+		mmDecommission.mock.t.Fatalf("Some expectations are already set for the TerminationHandler.Decommission method")
+	}
+
+	mmDecommission.mock.funcDecommission = f
+	return mmDecommission.mock
+}
+
+// When sets expectation for the TerminationHandler.Decommission which will trigger the result defined by the following
+// Then helper
+func (mmDecommission *mTerminationHandlerMockDecommission) When(ctx context.Context) *TerminationHandlerMockDecommissionExpectation {
+	if mmDecommission.mock.funcDecommission != nil {
+		mmDecommission.mock.t.Fatalf("TerminationHandlerMock.Decommission mock is already set by Set")
+	}
+
+	expectation := &TerminationHandlerMockDecommissionExpectation{
+		mock:   mmDecommission.mock,
+		params: &TerminationHandlerMockDecommissionParams{ctx},
+	}
+	mmDecommission.expectations = append(mmDecommission.expectations, expectation)
+	return expectation
+}
+
+// Then sets up TerminationHandler.Decommission return parameters for the expectation previously defined by the When method
+func (e *TerminationHandlerMockDecommissionExpectation) Then(err error) *TerminationHandlerMock {
+	e.results = &TerminationHandlerMockDecommissionResults{err}
+	return e.mock
+}
+
+// Decommission implements insolar.TerminationHandler
+func (mmDecommission *TerminationHandlerMock) Decommission(ctx context.Context) (err error) {
+	mm_atomic.AddUint64(&mmDecommission.beforeDecommissionCounter, 1)
+	defer mm_atomic.AddUint64(&mmDecommission.afterDecommissionCounter, 1)
+
+	if mmDecommission.inspectFuncDecommission != nil {
+		mmDecommission.inspectFuncDecommission(ctx)
+	}
+
+	params := &TerminationHandlerMockDecommissionParams{ctx}
+
+	// Record call args
+	mmDecommission.DecommissionMock.mutex.Lock()
+	mmDecommission.DecommissionMock.callArgs = append(mmDecommission.DecommissionMock.callArgs, params)
+	mmDecommission.DecommissionMock.mutex.Unlock()
+
+	for _, e := range mmDecommission.DecommissionMock.expectations {
+		if minimock.Equal(e.params, params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmDecommission.DecommissionMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmDecommission.DecommissionMock.defaultExpectation.Counter, 1)
+		want := mmDecommission.DecommissionMock.defaultExpectation.params
+		got := TerminationHandlerMockDecommissionParams{ctx}
+		if want != nil && !minimock.Equal(*want, got) {
+			mmDecommission.t.Errorf("TerminationHandlerMock.Decommission got unexpected parameters, want: %#v, got: %#v%s\n", *want, got, minimock.Diff(*want, got))
+		}
+
+		results := mmDecommission.DecommissionMock.defaultExpectation.results
+		if results == nil {
+			mmDecommission.t.Fatal("No results are set for the TerminationHandlerMock.Decommission")
+		}
+		return (*results).err
+	}
+	if mmDecommission.funcDecommission != nil {
+		return mmDecommission.funcDecommission(ctx)
+	}
+	mmDecommission.t.Fatalf("Unexpected call to TerminationHandlerMock.Decommission. %v", ctx)
+	return
+}
+
+// DecommissionAfterCounter returns a count of finished TerminationHandlerMock.Decommission invocations
+func (mmDecommission *TerminationHandlerMock) DecommissionAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDecommission.afterDecommissionCounter)
+}
+
+// DecommissionBeforeCounter returns a count of TerminationHandlerMock.Decommission invocations
+func (mmDecommission *TerminationHandlerMock) DecommissionBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDecommission.beforeDecommissionCounter)
+}
+
+// Calls returns a list of arguments used in each call to TerminationHandlerMock.Decommission.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmDecommission *mTerminationHandlerMockDecommission) Calls() []*TerminationHandlerMockDecommissionParams {
+	mmDecommission.mutex.RLock()
+
+	argCopy := make([]*TerminationHandlerMockDecommissionParams, len(mmDecommission.callArgs))
+	copy(argCopy, mmDecommission.callArgs)
+
+	mmDecommission.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockDecommissionDone returns true if the count of the Decommission invocations corresponds
+// the number of defined expectations
+func (m *TerminationHandlerMock) MinimockDecommissionDone() bool {
+	for _, e := range m.DecommissionMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.DecommissionMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterDecommissionCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcDecommission != nil && mm_atomic.LoadUint64(&m.afterDecommissionCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockDecommissionInspect logs each unmet expectation
+func (m *TerminationHandlerMock) MinimockDecommissionInspect() {
+	for _, e := range m.DecommissionMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to TerminationHandlerMock.Decommission with params: %#v", *e.params)
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.DecommissionMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterDecommissionCounter) < 1 {
+		if m.DecommissionMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to TerminationHandlerMock.Decommission")
+		} else {
+			m.t.Errorf("Expected call to TerminationHandlerMock.Decommission with params: %#v", *m.DecommissionMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcDecommission != nil && mm_atomic.LoadUint64(&m.afterDecommissionCounter) < 1 {
+		m.t.Error("Expected call to TerminationHandlerMock.Decommission")
+	}
+}
+
+type mTerminationHandlerMockDecommissionStatus struct {
+	mock               *TerminationHandlerMock
+	defaultExpectation *TerminationHandlerMockDecommissionStatusExpectation
+	expectations       []*TerminationHandlerMockDecommissionStatusExpectation
+}
+
+// TerminationHandlerMockDecommissionStatusExpectation specifies expectation struct of the TerminationHandler.DecommissionStatus
+type TerminationHandlerMockDecommissionStatusExpectation struct {
+	mock *TerminationHandlerMock
+
+	results *TerminationHandlerMockDecommissionStatusResults
+	Counter uint64
+}
+
+// TerminationHandlerMockDecommissionStatusResults contains results of the TerminationHandler.DecommissionStatus
+type TerminationHandlerMockDecommissionStatusResults struct {
+	d1 mm_insolar.DecommissionStatus
+}
+
+// Expect sets up expected params for TerminationHandler.DecommissionStatus
+func (mmDecommissionStatus *mTerminationHandlerMockDecommissionStatus) Expect() *mTerminationHandlerMockDecommissionStatus {
+	if mmDecommissionStatus.mock.funcDecommissionStatus != nil {
+		mmDecommissionStatus.mock.t.Fatalf("TerminationHandlerMock.DecommissionStatus mock is already set by Set")
+	}
+
+	if mmDecommissionStatus.defaultExpectation == nil {
+		mmDecommissionStatus.defaultExpectation = &TerminationHandlerMockDecommissionStatusExpectation{}
+	}
+
+	return mmDecommissionStatus
+}
+
+// Inspect accepts an inspector function that has same arguments as the TerminationHandler.DecommissionStatus
+func (mmDecommissionStatus *mTerminationHandlerMockDecommissionStatus) Inspect(f func()) *mTerminationHandlerMockDecommissionStatus {
+	if mmDecommissionStatus.mock.inspectFuncDecommissionStatus != nil {
+		mmDecommissionStatus.mock.t.Fatalf("Inspect function is already set for TerminationHandlerMock.DecommissionStatus")
+	}
+
+	mmDecommissionStatus.mock.inspectFuncDecommissionStatus = f
+
+	return mmDecommissionStatus
+}
+
+// Return sets up results that will be returned by TerminationHandler.DecommissionStatus
+func (mmDecommissionStatus *mTerminationHandlerMockDecommissionStatus) Return(d1 mm_insolar.DecommissionStatus) *TerminationHandlerMock {
+	if mmDecommissionStatus.mock.funcDecommissionStatus != nil {
+		mmDecommissionStatus.mock.t.Fatalf("TerminationHandlerMock.DecommissionStatus mock is already set by Set")
+	}
+
+	if mmDecommissionStatus.defaultExpectation == nil {
+		mmDecommissionStatus.defaultExpectation = &TerminationHandlerMockDecommissionStatusExpectation{mock: mmDecommissionStatus.mock}
+	}
+	mmDecommissionStatus.defaultExpectation.results = &TerminationHandlerMockDecommissionStatusResults{d1}
+	return mmDecommissionStatus.mock
+}
+
+//Set uses given function f to mock the TerminationHandler.DecommissionStatus method
+func (mmDecommissionStatus *mTerminationHandlerMockDecommissionStatus) Set(f func() (d1 mm_insolar.DecommissionStatus)) *TerminationHandlerMock {
+	if mmDecommissionStatus.defaultExpectation != nil {
+		mmDecommissionStatus.mock.t.Fatalf("Default expectation is already set for the TerminationHandler.DecommissionStatus method")
+	}
+
+	if len(mmDecommissionStatus.expectations) > 0 {
+		mmDecommissionStatus.mock.t.Fatalf("Some expectations are already set for the TerminationHandler.DecommissionStatus method")
+	}
+
+	mmDecommissionStatus.mock.funcDecommissionStatus = f
+	return mmDecommissionStatus.mock
+}
+
+// DecommissionStatus implements insolar.TerminationHandler
+func (mmDecommissionStatus *TerminationHandlerMock) DecommissionStatus() (d1 mm_insolar.DecommissionStatus) {
+	mm_atomic.AddUint64(&mmDecommissionStatus.beforeDecommissionStatusCounter, 1)
+	defer mm_atomic.AddUint64(&mmDecommissionStatus.afterDecommissionStatusCounter, 1)
+
+	if mmDecommissionStatus.inspectFuncDecommissionStatus != nil {
+		mmDecommissionStatus.inspectFuncDecommissionStatus()
+	}
+
+	if mmDecommissionStatus.DecommissionStatusMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmDecommissionStatus.DecommissionStatusMock.defaultExpectation.Counter, 1)
+
+		results := mmDecommissionStatus.DecommissionStatusMock.defaultExpectation.results
+		if results == nil {
+			mmDecommissionStatus.t.Fatal("No results are set for the TerminationHandlerMock.DecommissionStatus")
+		}
+		return (*results).d1
+	}
+	if mmDecommissionStatus.funcDecommissionStatus != nil {
+		return mmDecommissionStatus.funcDecommissionStatus()
+	}
+	mmDecommissionStatus.t.Fatalf("Unexpected call to TerminationHandlerMock.DecommissionStatus.")
+	return
+}
+
+// DecommissionStatusAfterCounter returns a count of finished TerminationHandlerMock.DecommissionStatus invocations
+func (mmDecommissionStatus *TerminationHandlerMock) DecommissionStatusAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDecommissionStatus.afterDecommissionStatusCounter)
+}
+
+// DecommissionStatusBeforeCounter returns a count of TerminationHandlerMock.DecommissionStatus invocations
+func (mmDecommissionStatus *TerminationHandlerMock) DecommissionStatusBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDecommissionStatus.beforeDecommissionStatusCounter)
+}
+
+// MinimockDecommissionStatusDone returns true if the count of the DecommissionStatus invocations corresponds
+// the number of defined expectations
+func (m *TerminationHandlerMock) MinimockDecommissionStatusDone() bool {
+	for _, e := range m.DecommissionStatusMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.DecommissionStatusMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterDecommissionStatusCounter) < 1 {
+		return false
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcDecommissionStatus != nil && mm_atomic.LoadUint64(&m.afterDecommissionStatusCounter) < 1 {
+		return false
+	}
+	return true
+}
+
+// MinimockDecommissionStatusInspect logs each unmet expectation
+func (m *TerminationHandlerMock) MinimockDecommissionStatusInspect() {
+	for _, e := range m.DecommissionStatusMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Error("Expected call to TerminationHandlerMock.DecommissionStatus")
+		}
+	}
+
+	// if default expectation was set then invocations count should be greater than zero
+	if m.DecommissionStatusMock.defaultExpectation != nil && mm_atomic.LoadUint64(&m.afterDecommissionStatusCounter) < 1 {
+		m.t.Error("Expected call to TerminationHandlerMock.DecommissionStatus")
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcDecommissionStatus != nil && mm_atomic.LoadUint64(&m.afterDecommissionStatusCounter) < 1 {
+		m.t.Error("Expected call to TerminationHandlerMock.DecommissionStatus")
+	}
+}
+
 type mTerminationHandlerMockLeave struct {
 	mock               *TerminationHandlerMock
 	defaultExpectation *TerminationHandlerMockLeaveExpectation
@@ -772,6 +1147,10 @@ func (m *TerminationHandlerMock) MinimockFinish() {
 	if !m.minimockDone() {
 		m.MinimockAbortInspect()
 
+		m.MinimockDecommissionInspect()
+
+		m.MinimockDecommissionStatusInspect()
+
 		m.MinimockLeaveInspect()
 
 		m.MinimockOnLeaveApprovedInspect()
@@ -801,6 +1180,8 @@ func (m *TerminationHandlerMock) minimockDone() bool {
 	done := true
 	return done &&
 		m.MinimockAbortDone() &&
+		m.MinimockDecommissionDone() &&
+		m.MinimockDecommissionStatusDone() &&
 		m.MinimockLeaveDone() &&
 		m.MinimockOnLeaveApprovedDone() &&
 		m.MinimockTerminatingDone()